@@ -58,6 +58,11 @@ test_config() {
 
     # Replace BR ID
     sed -i "s/id = .*$/id = \"${BRID}\"/g" "$BR_TOML"
+
+    # Tests with the _v2 suffix run with the SCION v2 header
+    if [[ "$TEST_NAME" == *_v2 ]]; then
+        sed -i '/^\[features\]/a header_v2 = true' "$BR_TOML" "$TEST_ARTIFACTS_DIR/conf/disp.toml"
+    fi
 }
 
 test_run() {
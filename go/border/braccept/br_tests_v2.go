@@ -0,0 +1,80 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+// The tests in this file run against a border router that uses the SCION v2
+// header, i.e., with the header_v2 feature enabled.
+
+func br_multi_v2() int {
+	var failures int
+
+	failures += br_peer_v2()
+	failures += br_child_v2()
+	failures += br_parent_v2()
+
+	scmpCfg := scmpTestCfg{
+		DstIA:          xtest.MustParseIA("2-ff00:0:3"),
+		LocalInterface: 131,
+	}
+	failures += scmpCfg.scmpBadMacV2()
+	failures += scmpCfg.scmpExpiredHopFieldV2()
+	failures += scmpCfg.scmpBadInterfaceV2()
+
+	return failures
+}
+
+func br_peer_v2() int {
+	var failures int
+
+	failures += shortcut_peer_to_internal_host_v2()
+	failures += shortcut_internal_host_to_peer_v2()
+	failures += shortcut_peer_to_internal_child_v2()
+
+	return failures
+}
+
+func br_child_v2() int {
+	var failures int
+
+	failures += child_to_internal_host_v2()
+	failures += internal_host_to_child_v2()
+
+	failures += xover_child_to_internal_core_v2()
+	failures += xover_internal_core_to_child_v2()
+
+	failures += child_to_internal_parent_v2()
+	failures += internal_parent_to_child_v2()
+
+	return failures
+}
+
+func br_parent_v2() int {
+	var failures int
+
+	failures += parent_to_internal_host_v2()
+	failures += internal_host_to_parent_v2()
+	failures += parent_to_internal_child_v2()
+	failures += internal_child_to_parent_v2()
+
+	failures += ohp_parent_to_internal_bs_v2()
+	failures += ohp_internal_bs_to_parent_v2()
+	failures += ohp_internal_bs_to_parent_prev_key_v2()
+
+	return failures
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+)
+
+func child_to_internal_host_v2() int {
+	up := segV2{Hops: [][2]uint16{{0, 141}, {411, 0}}}
+	scn := scionV2("1-ff00:0:4", "172.16.4.1", "1-ff00:0:1", "192.168.0.51", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, true, up))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_141",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:14",
+			"192.168.14.3", "192.168.14.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.51", 30001, 30041), withPathV2(scn, pathV2(1, false, up))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("child to internal/host v2", defaultTimeout, pkt1)
+}
+
+func internal_host_to_child_v2() int {
+	down := segV2{ConsDir: true, Hops: [][2]uint16{{0, 141}, {411, 0}}}
+	scn := scionV2("1-ff00:0:1", "192.168.0.51", "1-ff00:0:4", "172.16.4.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(0, false, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.51", "192.168.0.11", 30041, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_141",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:14", "f0:0d:ca:fe:be:ef",
+			"192.168.14.2", "192.168.14.3", 50000, 40000), withPathV2(scn, pathV2(1, true, down))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("internal/host to child v2", defaultTimeout, pkt1)
+}
+
+// xover_child_to_internal_core_v2 sends a packet on an up segment that crosses
+// over to a down segment in the local AS. The egress interface is on brB.
+func xover_child_to_internal_core_v2() int {
+	up := segV2{Hops: [][2]uint16{{0, 141}, {411, 0}}}
+	down := segV2{ConsDir: true, Hops: [][2]uint16{{0, 171}, {711, 0}}}
+	scn := scionV2("1-ff00:0:4", "172.16.4.1", "1-ff00:0:7", "172.16.7.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, true, up, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_141",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:14",
+			"192.168.14.3", "192.168.14.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.12", 30001, 30002),
+			withPathV2(scn, pathV2(2, false, up, down))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("xover child to internal/core v2", defaultTimeout, pkt1)
+}
+
+// xover_internal_core_to_child_v2 sends a packet that already crossed over at
+// brB to the child interface.
+func xover_internal_core_to_child_v2() int {
+	up := segV2{Hops: [][2]uint16{{0, 171}, {711, 0}}}
+	down := segV2{ConsDir: true, Hops: [][2]uint16{{0, 141}, {411, 0}}}
+	scn := scionV2("1-ff00:0:7", "172.16.7.1", "1-ff00:0:4", "172.16.4.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(2, false, up, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.12", "192.168.0.11", 30002, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_141",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:14", "f0:0d:ca:fe:be:ef",
+			"192.168.14.2", "192.168.14.3", 50000, 40000),
+			withPathV2(scn, pathV2(3, true, up, down))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("xover internal/core to child v2", defaultTimeout, pkt1)
+}
+
+func child_to_internal_parent_v2() int {
+	up := segV2{Hops: [][2]uint16{{0, 911}, {191, 141}, {411, 0}}}
+	scn := scionV2("1-ff00:0:4", "172.16.4.1", "1-ff00:0:9", "172.16.9.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, true, up))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_141",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:14",
+			"192.168.14.3", "192.168.14.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.14", 30001, 30004), withPathV2(scn, pathV2(1, false, up))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("child to internal/parent v2", defaultTimeout, pkt1)
+}
+
+func internal_parent_to_child_v2() int {
+	down := segV2{ConsDir: true, Hops: [][2]uint16{{0, 911}, {191, 141}, {411, 0}}}
+	scn := scionV2("1-ff00:0:9", "172.16.9.1", "1-ff00:0:4", "172.16.4.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, false, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.14", "192.168.0.11", 30004, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_141",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:14", "f0:0d:ca:fe:be:ef",
+			"192.168.14.2", "192.168.14.3", 50000, 40000), withPathV2(scn, pathV2(2, true, down))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("internal/parent to child v2", defaultTimeout, pkt1)
+}
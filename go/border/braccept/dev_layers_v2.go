@@ -0,0 +1,228 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket"
+	golayers "github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/border/braccept/shared"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+// DevLayersV2 is a packet with a SCION v2 header together with the device it
+// is sent on or expected at. Unlike DevTaggedLayers, the packet is described
+// directly by its gopacket layers.
+type DevLayersV2 struct {
+	Dev    string
+	Layers []gopacket.SerializableLayer
+}
+
+// Serialize serializes the packet. Lengths and checksums are computed.
+func (d *DevLayersV2) Serialize() common.RawBytes {
+	var ip4 *golayers.IPv4
+	var scn *slayers.SCION
+	for _, l := range d.Layers {
+		var err error
+		switch l := l.(type) {
+		case *golayers.IPv4:
+			ip4 = l
+		case *slayers.SCION:
+			scn = l
+		case *golayers.UDP:
+			err = l.SetNetworkLayerForChecksum(ip4)
+		case *slayers.UDP:
+			err = l.SetNetworkLayerForChecksum(scn)
+		case *slayers.SCMP:
+			err = l.SetNetworkLayerForChecksum(scn)
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, d.Layers...); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+// underlayV2 returns the Ethernet, IPv4 and UDP layers of a packet.
+func underlayV2(srcMAC, dstMAC, src, dst string,
+	srcPort, dstPort int) []gopacket.SerializableLayer {
+
+	return []gopacket.SerializableLayer{
+		&golayers.Ethernet{
+			SrcMAC:       mustParseMAC(srcMAC),
+			DstMAC:       mustParseMAC(dstMAC),
+			EthernetType: golayers.EthernetTypeIPv4,
+		},
+		&golayers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Flags:    golayers.IPv4DontFragment,
+			Protocol: golayers.IPProtocolUDP,
+			SrcIP:    net.ParseIP(src).To4(),
+			DstIP:    net.ParseIP(dst).To4(),
+		},
+		&golayers.UDP{
+			SrcPort: golayers.UDPPort(srcPort),
+			DstPort: golayers.UDPPort(dstPort),
+		},
+	}
+}
+
+// scionV2 returns a SCION v2 header. The host addresses are either IPv4
+// addresses or SVC addresses, e.g., BS_M.
+func scionV2(srcIA, src, dstIA, dst string, nextHdr common.L4ProtocolType,
+	pathType slayers.PathType, p slayers.Path) *slayers.SCION {
+
+	scn := &slayers.SCION{
+		Version:  2,
+		NextHdr:  nextHdr,
+		PathType: pathType,
+		SrcIA:    xtest.MustParseIA(srcIA),
+		DstIA:    xtest.MustParseIA(dstIA),
+		Path:     p,
+	}
+	if err := scn.SetSrcAddr(hostV2(src)); err != nil {
+		panic(err)
+	}
+	if err := scn.SetDstAddr(hostV2(dst)); err != nil {
+		panic(err)
+	}
+	return scn
+}
+
+// udpV2 returns a SCION UDP header.
+func udpV2(srcPort, dstPort int) *slayers.UDP {
+	return &slayers.UDP{UDP: golayers.UDP{
+		SrcPort: golayers.UDPPort(srcPort),
+		DstPort: golayers.UDPPort(dstPort),
+	}}
+}
+
+func hostV2(s string) net.Addr {
+	if ip := net.ParseIP(s); ip != nil {
+		return &net.IPAddr{IP: ip.To4()}
+	}
+	svc := addr.HostSVCFromString(s)
+	if svc == addr.SvcNone {
+		panic(fmt.Sprintf("invalid host address: %s", s))
+	}
+	return svc
+}
+
+func mustParseMAC(s string) net.HardwareAddr {
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		panic(err)
+	}
+	return mac
+}
+
+// udpPktV2 returns the layers of a SCION UDP packet with the given underlay
+// and SCION header.
+func udpPktV2(underlay []gopacket.SerializableLayer,
+	scn *slayers.SCION) []gopacket.SerializableLayer {
+
+	return append(underlay, scn, udpV2(40111, 40222), gopacket.Payload("braccept"))
+}
+
+// withPathV2 returns a copy of the SCION header with the path replaced.
+func withPathV2(scn *slayers.SCION, p slayers.Path) *slayers.SCION {
+	c := *scn
+	c.Path = p
+	return &c
+}
+
+// segV2 describes a segment of a SCION v2 path. The hops are listed in
+// construction direction, each as a pair of {ConsIngress, ConsEgress}.
+type segV2 struct {
+	ConsDir bool
+	Peer    bool
+	// Timestamp of the info field. If zero, the current time is used.
+	Timestamp uint32
+	Hops      [][2]uint16
+}
+
+// pathV2 creates a SCION v2 path from the segments with the current hop field
+// set to currHF, which is the index in traversal order. The MACs are chained
+// the same way as during beaconing, and the SegIDs are set to the values that
+// are used to verify the hop fields, i.e., the values after ingress
+// processing. If external is set, the packet arrives at the current hop on an
+// external interface, and the SegID of a current segment that is traversed
+// against construction direction is set to the value before the ingress router
+// updated it.
+func pathV2(currHF int, external bool, segs ...segV2) *scion.Decoded {
+	p := &scion.Decoded{}
+	p.NumINF = len(segs)
+	p.PathMeta.CurrHF = uint8(currHF)
+	var first int
+	for i, seg := range segs {
+		n := len(seg.Hops)
+		info := &path.InfoField{ConsDir: seg.ConsDir, Peer: seg.Peer, Timestamp: seg.Timestamp}
+		if info.Timestamp == 0 {
+			info.Timestamp = shared.TsNow32
+		}
+		// segIDs[k] is the SegID that is used to compute the MAC of hop k.
+		segIDs := make([]uint16, n)
+		hops := make([]*path.HopField, n)
+		segID := uint16(0x1111 * (i + 1))
+		for k, h := range seg.Hops {
+			hops[k] = &path.HopField{ConsIngress: h[0], ConsEgress: h[1], ExpTime: 63}
+			segIDs[k] = segID
+			info.SegID = segID
+			hops[k].Mac = path.MAC(shared.HashMac, info, hops[k])
+			if !seg.Peer {
+				segID ^= binary.BigEndian.Uint16(hops[k].Mac[:2])
+			}
+		}
+		if !seg.ConsDir {
+			for l, r := 0, n-1; l < r; l, r = l+1, r-1 {
+				hops[l], hops[r] = hops[r], hops[l]
+				segIDs[l], segIDs[r] = segIDs[r], segIDs[l]
+			}
+		}
+		switch {
+		case currHF < first:
+			info.SegID = segIDs[0]
+		case currHF >= first+n:
+			info.SegID = segIDs[n-1]
+		default:
+			p.PathMeta.CurrINF = uint8(i)
+			info.SegID = segIDs[currHF-first]
+			if external && !seg.ConsDir && !seg.Peer {
+				info.UpdateSegID(hops[currHF-first].Mac)
+			}
+		}
+		p.PathMeta.SegLen[i] = uint8(n)
+		p.InfoFields = append(p.InfoFields, info)
+		p.HopFields = append(p.HopFields, hops...)
+		first += n
+	}
+	p.NumHops = first
+	return p
+}
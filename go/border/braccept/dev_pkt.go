@@ -33,3 +33,19 @@ func toGoPackets(pkts ...*DevTaggedLayers) []*DevPkt {
 	}
 	return goPkts
 }
+
+func toGoPacketsV2(pkts ...*DevLayersV2) []*DevPkt {
+	goPkts := make([]*DevPkt, len(pkts))
+	for i := range pkts {
+		goPkts[i] = &DevPkt{Dev: pkts[i].Dev}
+		raw := pkts[i].Serialize()
+		goPkts[i].Pkt = gopacket.NewPacket(raw, layers.LayerTypeEthernet, gopacket.DecodeOptions{})
+		// The IPv4 ID and checksum are set by the kernel of the BR and are
+		// ignored by the comparison if they are 0 in the expected packet.
+		if ip4, ok := goPkts[i].Pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4); ok {
+			ip4.Id = 0
+			ip4.Checksum = 0
+		}
+	}
+	return goPkts
+}
@@ -27,6 +27,15 @@ import (
 )
 
 func ExpectedPackets(desc string, to string, pkts ...*DevTaggedLayers) int {
+	// Serialize all expected packets so that we generate proper length values, checksums, etc.
+	return expectPackets(desc, to, toGoPackets(pkts...))
+}
+
+func ExpectedPacketsV2(desc string, to string, pkts ...*DevLayersV2) int {
+	return expectPackets(desc, to, toGoPacketsV2(pkts...))
+}
+
+func expectPackets(desc string, to string, expPkts []*DevPkt) int {
 	var errors int
 	// Given that the number of interfaces changes depending on the BR configuration,
 	// we use a dynamic select/switch case approach, where each interface has an equivalent
@@ -39,8 +48,6 @@ func ExpectedPackets(desc string, to string, pkts ...*DevTaggedLayers) int {
 	timerCh := time.After(timeout)
 	// Add timeout channel as the last select case.
 	cases[timerIdx] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timerCh)}
-	// Serialize all expected packets so that we generate proper length values, checksums, etc.
-	expPkts := toGoPackets(pkts...)
 	var errStr []string
 	for {
 		idx, pktV, ok := reflect.Select(cases)
@@ -14,6 +14,17 @@
 
 package main
 
+import (
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/border/braccept/shared"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+)
+
 var IgnoredPkts []*DevPkt
 
 func IgnoredPackets(dtls ...*DevTaggedLayers) {
@@ -21,6 +32,10 @@ func IgnoredPackets(dtls ...*DevTaggedLayers) {
 	IgnoredPkts = append(IgnoredPkts, pkts...)
 }
 
+func IgnoredPacketsV2(pkts ...*DevLayersV2) {
+	IgnoredPkts = append(IgnoredPkts, toGoPacketsV2(pkts...)...)
+}
+
 func ClearIgnoredPackets() {
 	IgnoredPkts = IgnoredPkts[:0]
 }
@@ -42,3 +57,29 @@ func IgnorePkts() {
 
 	IgnoredPackets(pkt0)
 }
+
+// IgnorePktsV2 ignores the IFStateReq packets that the BR sends with a SCION v2
+// header.
+func IgnorePktsV2() {
+	pmpld, err := path_mgmt.NewPld(&path_mgmt.IFStateReq{}, nil)
+	if err != nil {
+		panic(err)
+	}
+	pld, err := shared.CtrlCapnpEnc(infra.NullSigner, pmpld)
+	if err != nil {
+		panic(err)
+	}
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: append(
+			underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+				"192.168.0.11", "192.168.0.61", 30041, 30041),
+			scionV2("1-ff00:0:1", "192.168.0.101", "1-ff00:0:1", "BS_M", common.L4UDP,
+				slayers.PathTypeSCION, &scion.Decoded{}),
+			udpV2(20001, 0),
+			gopacket.Payload(pld),
+		),
+	}
+
+	IgnoredPacketsV2(pkt0)
+}
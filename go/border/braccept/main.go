@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/google/gopacket"
@@ -30,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/border/braccept/layers"
 	"github.com/scionproto/scion/go/border/braccept/shared"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/slayers"
 )
 
 const (
@@ -105,7 +107,11 @@ func realMain() int {
 
 	registerScionPorts()
 
-	IgnorePkts()
+	if isV2Test() {
+		IgnorePktsV2()
+	} else {
+		IgnorePkts()
+	}
 
 	var failures int
 	log.Info("Acceptance tests:", "testName", testName)
@@ -118,6 +124,14 @@ func realMain() int {
 		failures += br_parent()
 	case "br_peer":
 		failures += br_peer()
+	case "br_multi_v2":
+		failures += br_multi_v2()
+	case "br_child_v2":
+		failures += br_child_v2()
+	case "br_parent_v2":
+		failures += br_parent_v2()
+	case "br_peer_v2":
+		failures += br_peer_v2()
 	case "br_core_multi":
 		failures += br_core_multi()
 	case "br_core_coreIf":
@@ -145,12 +159,22 @@ func checkFlags() error {
 // registerScionPorts basically register the following UDP ports in gopacket such as SCION is the
 // next layer. In other words, map the following ports to expect SCION as the payload.
 func registerScionPorts() {
+	scionLayer := layers.LayerTypeScion
+	if isV2Test() {
+		scionLayer = slayers.LayerTypeSCION
+	}
 	// Bind ports to SCION layer
-	golayers.RegisterUDPPortLayerType(golayers.UDPPort(30041), layers.LayerTypeScion)
+	golayers.RegisterUDPPortLayerType(golayers.UDPPort(30041), scionLayer)
 	for i := 30000; i < 30010; i += 1 {
-		golayers.RegisterUDPPortLayerType(golayers.UDPPort(i), layers.LayerTypeScion)
+		golayers.RegisterUDPPortLayerType(golayers.UDPPort(i), scionLayer)
 	}
 	for i := 50000; i < 50010; i += 1 {
-		golayers.RegisterUDPPortLayerType(golayers.UDPPort(i), layers.LayerTypeScion)
+		golayers.RegisterUDPPortLayerType(golayers.UDPPort(i), scionLayer)
 	}
 }
+
+// isV2Test returns whether the test runs against a border router that uses the
+// SCION v2 header.
+func isV2Test() bool {
+	return strings.HasSuffix(testName, "_v2")
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/scionproto/scion/go/border/braccept/shared"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
+)
+
+func parent_to_internal_host_v2() int {
+	down := segV2{ConsDir: true, Hops: [][2]uint16{{0, 311}, {131, 0}}}
+	scn := scionV2("1-ff00:0:3", "172.16.3.1", "1-ff00:0:1", "192.168.0.51", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, true, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_131",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:13",
+			"192.168.13.3", "192.168.13.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.51", 30001, 30041), scn),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("parent to internal/host v2", defaultTimeout, pkt1)
+}
+
+func internal_host_to_parent_v2() int {
+	up := segV2{Hops: [][2]uint16{{0, 311}, {131, 0}}}
+	scn := scionV2("1-ff00:0:1", "192.168.0.51", "1-ff00:0:3", "172.16.3.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(0, false, up))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.51", "192.168.0.11", 30041, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_131",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:13", "f0:0d:ca:fe:be:ef",
+			"192.168.13.2", "192.168.13.3", 50000, 40000), withPathV2(scn, pathV2(1, true, up))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("internal/host to parent v2", defaultTimeout, pkt1)
+}
+
+func parent_to_internal_child_v2() int {
+	down := segV2{ConsDir: true, Hops: [][2]uint16{{0, 311}, {131, 181}, {811, 0}}}
+	scn := scionV2("1-ff00:0:3", "172.16.3.1", "1-ff00:0:8", "172.16.8.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, true, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_131",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:13",
+			"192.168.13.3", "192.168.13.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.13", 30001, 30003), scn),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("parent to internal/child v2", defaultTimeout, pkt1)
+}
+
+func internal_child_to_parent_v2() int {
+	up := segV2{Hops: [][2]uint16{{0, 311}, {131, 181}, {811, 0}}}
+	scn := scionV2("1-ff00:0:8", "172.16.8.1", "1-ff00:0:3", "172.16.3.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, false, up))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.13", "192.168.0.11", 30003, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_131",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:13", "f0:0d:ca:fe:be:ef",
+			"192.168.13.2", "192.168.13.3", 50000, 40000), withPathV2(scn, pathV2(2, true, up))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("internal/child to parent v2", defaultTimeout, pkt1)
+}
+
+// ohp_parent_to_internal_bs_v2 sends a one-hop path packet from the parent
+// beacon service. The BR fills in the second hop field.
+func ohp_parent_to_internal_bs_v2() int {
+	ohp := &onehop.Path{
+		Info:     path.InfoField{ConsDir: true, SegID: 0x1111, Timestamp: shared.TsNow32},
+		FirstHop: path.HopField{ConsEgress: 311, ExpTime: 63},
+	}
+	ohp.FirstHop.Mac = path.MAC(shared.HashMac, &ohp.Info, &ohp.FirstHop)
+	ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
+	scn := scionV2("1-ff00:0:3", "172.16.3.1", "1-ff00:0:1", "BS", common.L4UDP,
+		slayers.PathTypeOneHop, ohp)
+
+	expOHP := *ohp
+	expOHP.SecondHop = path.HopField{ConsIngress: 131, ExpTime: 63}
+	expOHP.SecondHop.Mac = path.MAC(shared.HashMac, &expOHP.Info, &expOHP.SecondHop)
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_131",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:13",
+			"192.168.13.3", "192.168.13.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.71", 30001, 30041), withPathV2(scn, &expOHP)),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("one-hop-path parent to internal/bs v2", defaultTimeout, pkt1)
+}
+
+func ohp_internal_bs_to_parent_v2() int {
+	ohp := &onehop.Path{
+		Info:     path.InfoField{ConsDir: true, SegID: 0x1111, Timestamp: shared.TsNow32},
+		FirstHop: path.HopField{ConsEgress: 131, ExpTime: 63},
+	}
+	ohp.FirstHop.Mac = path.MAC(shared.HashMac, &ohp.Info, &ohp.FirstHop)
+	scn := scionV2("1-ff00:0:1", "192.168.0.71", "1-ff00:0:3", "BS", common.L4UDP,
+		slayers.PathTypeOneHop, ohp)
+
+	expOHP := *ohp
+	expOHP.Info.UpdateSegID(ohp.FirstHop.Mac)
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.71", "192.168.0.11", 30041, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_131",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:13", "f0:0d:ca:fe:be:ef",
+			"192.168.13.2", "192.168.13.3", 50000, 40000), withPathV2(scn, &expOHP)),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("one-hop-path internal/bs to parent v2", defaultTimeout, pkt1)
+}
+
+// ohp_internal_bs_to_parent_prev_key_v2 sends a one-hop path packet whose
+// first hop field is MACed with the previous master key. The BR must still
+// accept it during the master key rotation overlap period.
+func ohp_internal_bs_to_parent_prev_key_v2() int {
+	ohp := &onehop.Path{
+		Info:     path.InfoField{ConsDir: true, SegID: 0x1111, Timestamp: shared.TsNow32},
+		FirstHop: path.HopField{ConsEgress: 131, ExpTime: 63},
+	}
+	ohp.FirstHop.Mac = path.MAC(shared.PrevHashMac, &ohp.Info, &ohp.FirstHop)
+	scn := scionV2("1-ff00:0:1", "192.168.0.71", "1-ff00:0:3", "BS", common.L4UDP,
+		slayers.PathTypeOneHop, ohp)
+
+	expOHP := *ohp
+	expOHP.Info.UpdateSegID(ohp.FirstHop.Mac)
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.71", "192.168.0.11", 30041, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_131",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:13", "f0:0d:ca:fe:be:ef",
+			"192.168.13.2", "192.168.13.3", 50000, 40000), withPathV2(scn, &expOHP)),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("one-hop-path internal/bs to parent previous key v2",
+		defaultTimeout, pkt1)
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+)
+
+// In the SCION v2 header, a peering shortcut consists of an up and a down
+// segment that both have the peer flag set. The SegIDs are not updated along
+// peering segments.
+
+func shortcut_peer_to_internal_host_v2() int {
+	up := segV2{Peer: true, Hops: [][2]uint16{{211, 0}}}
+	down := segV2{ConsDir: true, Peer: true, Hops: [][2]uint16{{121, 0}}}
+	scn := scionV2("1-ff00:0:2", "172.16.2.1", "1-ff00:0:1", "192.168.0.51", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, true, up, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_121",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:12",
+			"192.168.12.3", "192.168.12.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.51", 30001, 30041), scn),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("shortcut peer to internal/host v2", defaultTimeout, pkt1)
+}
+
+func shortcut_internal_host_to_peer_v2() int {
+	up := segV2{Peer: true, Hops: [][2]uint16{{121, 0}}}
+	down := segV2{ConsDir: true, Peer: true, Hops: [][2]uint16{{211, 0}}}
+	scn := scionV2("1-ff00:0:1", "192.168.0.51", "1-ff00:0:2", "172.16.2.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(0, false, up, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.51", "192.168.0.11", 30041, 30001), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_121",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:12", "f0:0d:ca:fe:be:ef",
+			"192.168.12.2", "192.168.12.3", 50000, 40000),
+			withPathV2(scn, pathV2(1, true, up, down))),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("shortcut internal/host to peer v2", defaultTimeout, pkt1)
+}
+
+// shortcut_peer_to_internal_child_v2 sends a packet over the peering link to a
+// child interface of brC.
+func shortcut_peer_to_internal_child_v2() int {
+	up := segV2{Peer: true, Hops: [][2]uint16{{211, 0}}}
+	down := segV2{ConsDir: true, Peer: true, Hops: [][2]uint16{{121, 181}, {811, 0}}}
+	scn := scionV2("1-ff00:0:2", "172.16.2.1", "1-ff00:0:8", "172.16.8.1", common.L4UDP,
+		slayers.PathTypeSCION, pathV2(1, true, up, down))
+
+	pkt0 := &DevLayersV2{
+		Dev: "veth_121",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:12",
+			"192.168.12.3", "192.168.12.2", 40000, 50000), scn),
+	}
+	pkt1 := &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+			"192.168.0.11", "192.168.0.13", 30001, 30003), scn),
+	}
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("shortcut peer to internal/child v2", defaultTimeout, pkt1)
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+)
+
+// scmpBadMacV2 sends a packet with an invalid hop field MAC and checks that an
+// SCMP parameter problem is sent back.
+func (c scmpTestCfg) scmpBadMacV2() int {
+	up := segV2{Hops: [][2]uint16{{0, 311}, {uint16(c.LocalInterface), 0}}}
+	p := pathV2(0, false, up)
+	p.HopFields[0].Mac = []byte{0x00, 0x77, 0x00, 0xc0, 0xbe, 0xef}
+	pkt0 := c.internalPktV2(p)
+	pkt1 := c.paramProblemV2(pkt0, slayers.SCMPCodeInvalidHopFieldMAC, p)
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("scmp bad mac v2", defaultTimeout, pkt1)
+}
+
+// scmpExpiredHopFieldV2 sends a packet with an expired hop field and checks
+// that an SCMP parameter problem is sent back.
+func (c scmpTestCfg) scmpExpiredHopFieldV2() int {
+	up := segV2{Timestamp: 1, Hops: [][2]uint16{{0, 311}, {uint16(c.LocalInterface), 0}}}
+	p := pathV2(0, false, up)
+	pkt0 := c.internalPktV2(p)
+	pkt1 := c.paramProblemV2(pkt0, slayers.SCMPCodePathExpired, p)
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("scmp expired hop field v2", defaultTimeout, pkt1)
+}
+
+// scmpBadInterfaceV2 sends a packet with an unknown egress interface and checks
+// that an SCMP parameter problem is sent back.
+func (c scmpTestCfg) scmpBadInterfaceV2() int {
+	up := segV2{Hops: [][2]uint16{{0, 311}, {666, 0}}}
+	p := pathV2(0, false, up)
+	pkt0 := c.internalPktV2(p)
+	pkt1 := c.paramProblemV2(pkt0, slayers.SCMPCodeUnknownHopFieldInterface, p)
+
+	SendPacketsV2(pkt0)
+
+	return ExpectedPacketsV2("scmp bad interface v2", defaultTimeout, pkt1)
+}
+
+// internalPktV2 returns a packet that is sent by an internal host to the
+// destination IA on the given path.
+func (c scmpTestCfg) internalPktV2(p *scion.Decoded) *DevLayersV2 {
+	return &DevLayersV2{
+		Dev: "veth_int",
+		Layers: udpPktV2(underlayV2("f0:0d:ca:fe:be:ef", "f0:0d:ca:fe:00:01",
+			"192.168.0.61", "192.168.0.11", 20006, 30001),
+			scionV2("1-ff00:0:1", "192.168.0.61", c.DstIA.String(), "172.16.3.1",
+				common.L4UDP, slayers.PathTypeSCION, p)),
+	}
+}
+
+// paramProblemV2 returns the SCMP parameter problem the BR sends back to the
+// internal host for the packet. The pointer refers to the current hop field.
+func (c scmpTestCfg) paramProblemV2(pkt *DevLayersV2, code uint8,
+	p *scion.Decoded) *DevLayersV2 {
+
+	scn := pkt.Layers[3].(*slayers.SCION)
+	quote := (&DevLayersV2{Layers: pkt.Layers[3:]}).Serialize()
+	pointer := slayers.CmnHdrLen + scn.AddrHdrLen() + scion.MetaLen +
+		p.NumINF*path.InfoLen + int(p.PathMeta.CurrHF)*path.HopLen
+	return &DevLayersV2{
+		Dev: "veth_int",
+		Layers: append(
+			underlayV2("f0:0d:ca:fe:00:01", "f0:0d:ca:fe:be:ef",
+				"192.168.0.11", "192.168.0.61", 30001, 20006),
+			scionV2("1-ff00:0:1", "192.168.0.11", "1-ff00:0:1", "192.168.0.61",
+				common.L4SCMP, slayers.PathTypeSCION, reversePathV2(p)),
+			&slayers.SCMP{
+				TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem, code),
+			},
+			&slayers.SCMPParameterProblem{Pointer: uint16(pointer)},
+			gopacket.Payload(quote),
+		),
+	}
+}
+
+// reversePathV2 returns a reversed copy of the path.
+func reversePathV2(p *scion.Decoded) *scion.Decoded {
+	raw := make([]byte, p.Len())
+	if err := p.SerializeTo(raw); err != nil {
+		panic(err)
+	}
+	rev := &scion.Decoded{}
+	if err := rev.DecodeFromBytes(raw); err != nil {
+		panic(err)
+	}
+	if err := rev.Reverse(); err != nil {
+		panic(err)
+	}
+	return rev
+}
@@ -31,3 +31,14 @@ func SendPackets(pkts ...*DevTaggedLayers) {
 		devInfo.Handle.WritePacketData(raw)
 	}
 }
+
+func SendPacketsV2(pkts ...*DevLayersV2) {
+	for i := range pkts {
+		pkt := pkts[i]
+		devInfo, ok := shared.DevByName[pkt.Dev]
+		if !ok {
+			panic(fmt.Errorf("No device information for: %s\n", pkt.Dev))
+		}
+		devInfo.Handle.WritePacketData(pkt.Serialize())
+	}
+}
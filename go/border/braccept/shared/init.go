@@ -37,9 +37,12 @@ var (
 	DevByName map[string]*DevInfo
 	DevList   []*DevInfo
 	HashMac   hash.Hash
-	Now       = time.Now()
-	TsNow32   = uint32(Now.Unix())
-	NoTime    = time.Time{}
+	// PrevHashMac is the MAC of the previous master key. The BR accepts hop
+	// fields MACed with it during a master key rotation.
+	PrevHashMac hash.Hash
+	Now         = time.Now()
+	TsNow32     = uint32(Now.Unix())
+	NoTime      = time.Time{}
 )
 
 func UpdateNow() {
@@ -90,5 +93,10 @@ func generateKeys(fn string) error {
 		return err
 	}
 	HashMac = hfMacFactory()
+	prevHfMacFactory, err := scrypto.HFMacFactory(masterKeys.Key1)
+	if err != nil {
+		return err
+	}
+	PrevHashMac = prevHfMacFactory()
 	return nil
 }
@@ -73,6 +73,10 @@ func (r *Router) PacketError() {
 // metadata attached to the error object, then an SCMP error response is
 // generated and sent.
 func (r *Router) doPktError(rp *rpkt.RtrPkt, perr error) {
+	if cfg.Features.HeaderV2 {
+		r.doPktErrorV2(rp, perr)
+		return
+	}
 	var serr *scmp.Error
 	isSCMPErr := errors.As(perr, &serr)
 	if !isSCMPErr || rp.DirFrom == rcmn.DirSelf || rp.SCMPError {
@@ -116,6 +120,20 @@ func (r *Router) doPktError(rp *rpkt.RtrPkt, perr error) {
 	reply.Route()
 }
 
+// doPktErrorV2 is the SCION v2 header counterpart of doPktError.
+func (r *Router) doPktErrorV2(rp *rpkt.RtrPkt, perr error) {
+	var serr *rpkt.SCMPErrorV2
+	if !errors.As(perr, &serr) || rp.DirFrom == rcmn.DirSelf || rp.SCMPError {
+		return
+	}
+	reply, err := rp.CreateSCMPErrorReplyV2(serr)
+	if err != nil {
+		rp.Error("Error creating SCMP response", "err", err)
+		return
+	}
+	reply.Route()
+}
+
 // createSCMPErrorReply generates an SCMP error reply to the supplied packet.
 func (r *Router) createSCMPErrorReply(rp *rpkt.RtrPkt, ct scmp.ClassType,
 	info scmp.Info) (*rpkt.RtrPkt, error) {
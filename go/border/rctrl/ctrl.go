@@ -44,7 +44,7 @@ var (
 	logger   log.Logger
 )
 
-func Control(sRevInfoQ chan rpkt.RawSRevCallbackArgs, dispatcherReconnect bool) {
+func Control(sRevInfoQ chan rpkt.RawSRevCallbackArgs, dispatcherReconnect, headerV2 bool) {
 	var err error
 	logger = log.New("Part", "Control")
 	ctx := rctx.Get()
@@ -57,7 +57,9 @@ func Control(sRevInfoQ chan rpkt.RawSRevCallbackArgs, dispatcherReconnect bool)
 		LocalIA: ia,
 		Dispatcher: &snet.DefaultPacketDispatcherService{
 			Dispatcher: dispatcherService,
+			Version2:   headerV2,
 		},
+		Version2: headerV2,
 	}
 	ctrlAddr := ctx.Conf.BR.CtrlAddrs
 	snetConn, err = scionNetwork.Listen(context.Background(), "udp", ctrlAddr.SCIONAddress,
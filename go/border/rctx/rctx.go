@@ -18,6 +18,7 @@
 package rctx
 
 import (
+	"hash"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -32,8 +33,14 @@ import (
 type Ctx struct {
 	// Conf contains the router state for this context.
 	Conf *brconf.BRConf
-	// HFMacPool is the pool of Hop Field MAC generation instances.
+	// HFMacPool is the pool of Hop Field MAC generation instances for the
+	// active master key (Key0). It is used to issue and verify hop fields.
 	HFMacPool *sync.Pool
+	// PrevHFMacPool is the pool of Hop Field MAC generation instances for the
+	// previous master key (Key1). It is only used to verify hop fields that
+	// were issued before the last master key rotation. If nil, only the
+	// active master key is accepted.
+	PrevHFMacPool *sync.Pool
 	// LockSockIn is a Sock for receiving packets from the local AS,
 	LocSockIn *Sock
 	// LocSockOut is a Sock for sending packets to the local AS,
@@ -59,19 +66,52 @@ func New(conf *brconf.BRConf) *Ctx {
 	return ctx
 }
 
-// initMacPool initializes the hop field mac pool.
+// InitMacPool initializes the hop field mac pools for the active and the
+// previous master key.
 func (ctx *Ctx) InitMacPool() error {
-	hfMacFactory, err := scrypto.HFMacFactory(ctx.Conf.MasterKeys.Key0)
-	if err != nil {
+	var err error
+	if ctx.HFMacPool, err = newMacPool(ctx.Conf.MasterKeys.Key0); err != nil {
+		return common.NewBasicError("Unable to create MAC pool for active master key", err)
+	}
+	if ctx.PrevHFMacPool, err = newMacPool(ctx.Conf.MasterKeys.Key1); err != nil {
+		return common.NewBasicError("Unable to create MAC pool for previous master key", err)
+	}
+	return nil
+}
+
+// VerifyHFMac calls verify with a MAC instance of the active master key. If
+// that fails, verify is retried with a MAC instance of the previous master
+// key, such that hop fields issued before a master key rotation stay valid
+// during the overlap period. If both attempts fail, the error of the first
+// attempt is returned.
+func (ctx *Ctx) VerifyHFMac(verify func(mac hash.Hash) error) error {
+	err := withMac(ctx.HFMacPool, verify)
+	if err == nil || ctx.PrevHFMacPool == nil {
 		return err
 	}
+	if withMac(ctx.PrevHFMacPool, verify) == nil {
+		return nil
+	}
+	return err
+}
+
+func withMac(pool *sync.Pool, f func(mac hash.Hash) error) error {
+	mac := pool.Get().(hash.Hash)
+	defer pool.Put(mac)
+	return f(mac)
+}
+
+func newMacPool(key []byte) (*sync.Pool, error) {
+	hfMacFactory, err := scrypto.HFMacFactory(key)
+	if err != nil {
+		return nil, err
+	}
 	// Create a pool of MAC instances.
-	ctx.HFMacPool = &sync.Pool{
+	return &sync.Pool{
 		New: func() interface{} {
 			return hfMacFactory()
 		},
-	}
-	return nil
+	}, nil
 }
 
 func (ctx *Ctx) ResolveSVC(svc addr.HostSVC) ([]*net.UDPAddr, error) {
@@ -71,7 +71,7 @@ func (r *Router) Start() {
 	}()
 	go func() {
 		defer log.HandlePanic()
-		rctrl.Control(r.sRevInfoQ, cfg.General.ReconnectToDispatcher)
+		rctrl.Control(r.sRevInfoQ, cfg.General.ReconnectToDispatcher, cfg.Features.HeaderV2)
 	}()
 }
 
@@ -132,6 +132,10 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 	rp.Logger = log.New("rpkt", rp.Id)
 	// XXX(kormat): uncomment for debugging:
 	//rp.Debug("processPacket", "raw", rp.Raw)
+	if cfg.Features.HeaderV2 {
+		r.processPacketV2(rp, l)
+		return
+	}
 	if err := rp.Parse(); err != nil {
 		r.handlePktError(rp, err, "Error parsing packet")
 		l.Result = metrics.ErrParse
@@ -186,3 +190,30 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 		}
 	}
 }
+
+// processPacketV2 handles packets with a SCION v2 header. In contrast to the
+// legacy header, there are no extensions that require hooks, hence the packet
+// is parsed, processed and routed directly.
+func (r *Router) processPacketV2(rp *rpkt.RtrPkt, l metrics.ProcessLabels) {
+	if err := rp.ParseV2(); err != nil {
+		r.handlePktError(rp, err, "Error parsing packet")
+		l.Result = metrics.ErrParse
+		metrics.Process.Pkts(l).Inc()
+		return
+	}
+	if err := rp.ProcessV2(); err != nil {
+		r.handlePktError(rp, err, "Error processing packet")
+		l.Result = metrics.ErrProcess
+		metrics.Process.Pkts(l).Inc()
+		return
+	}
+	if len(rp.Egress) == 0 {
+		// The packet has been consumed by the router, e.g., a traceroute request.
+		return
+	}
+	if err := rp.Route(); err != nil {
+		r.handlePktError(rp, err, "Error routing packet")
+		l.Result = metrics.ErrRoute
+		metrics.Process.Pkts(l).Inc()
+	}
+}
@@ -67,9 +67,9 @@ func (rp *RtrPkt) validatePath(dirFrom rcmn.Dir) error {
 		)
 	}
 	// Verify the Hop Field MAC.
-	hfmac := rp.Ctx.HFMacPool.Get().(hash.Hash)
-	err := rp.hopF.Verify(hfmac, rp.infoF.TsInt, rp.getHopFVer(dirFrom))
-	rp.Ctx.HFMacPool.Put(hfmac)
+	err := rp.Ctx.VerifyHFMac(func(hfmac hash.Hash) error {
+		return rp.hopF.Verify(hfmac, rp.infoF.TsInt, rp.getHopFVer(dirFrom))
+	})
 	if err != nil && errors.Is(err, spath.ErrorHopFBadMac) {
 		err = scmp.NewError(scmp.C_Path, scmp.T_P_BadMac,
 			rp.mkInfoPathOffsets(rp.CmnHdr.CurrInfoF, rp.CmnHdr.CurrHopF), err)
@@ -0,0 +1,449 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file handles parsing, processing and forwarding of packets with a SCION
+// v2 header (see go/lib/slayers).
+
+package rpkt
+
+import (
+	"hash"
+	"net"
+	"time"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// v2Hdr contains the state of a packet with a SCION v2 header.
+type v2Hdr struct {
+	// scn is the decoded SCION header. The path references the raw buffer of the packet.
+	scn slayers.SCION
+	// path is the SCION path of the packet, if the path type is SCION.
+	path *scion.Raw
+	// infoF is the current info field, if the path type is SCION.
+	infoF *path.InfoField
+	// hopF is the current hop field, if the path type is SCION.
+	hopF *path.HopField
+	// peering indicates whether the current segment is a peering segment.
+	peering bool
+}
+
+// ParseV2 parses the SCION v2 header of the packet. Parsing is lazy, all path
+// processing is done in ProcessV2.
+func (rp *RtrPkt) ParseV2() error {
+	rp.v2 = &v2Hdr{}
+	if err := rp.v2.scn.DecodeFromBytes(rp.Raw, gopacket.NilDecodeFeedback); err != nil {
+		return err
+	}
+	rp.dstIA = rp.v2.scn.DstIA
+	rp.srcIA = rp.v2.scn.SrcIA
+	rp.L4Type = rp.v2.scn.NextHdr
+	// SCMP error messages have a type < 128. They must never trigger an SCMP
+	// error reply.
+	if pld := rp.v2.scn.Payload; rp.L4Type == common.L4SCMP && len(pld) > 0 && pld[0] < 128 {
+		rp.SCMPError = true
+	}
+	return nil
+}
+
+// ProcessV2 validates the path of a SCION v2 packet, updates it and determines
+// the egress of the packet. If an SCMP error has to be sent back to the source,
+// an *SCMPErrorV2 is returned. If the packet has been consumed by the router
+// (e.g., a traceroute request), Egress is left empty.
+func (rp *RtrPkt) ProcessV2() error {
+	switch p := rp.v2.scn.Path.(type) {
+	case *scion.Raw:
+		rp.v2.path = p
+		return rp.processSCIONV2()
+	case *onehop.Path:
+		return rp.processOHPV2(p)
+	default:
+		return serrors.New("unsupported path type", "type", rp.v2.scn.PathType)
+	}
+}
+
+func (rp *RtrPkt) processSCIONV2() error {
+	if err := rp.parseV2Fields(); err != nil {
+		return err
+	}
+	if err := rp.validateV2HopExpiry(); err != nil {
+		return err
+	}
+	if err := rp.validateV2IngressID(); err != nil {
+		return err
+	}
+	if err := rp.updateV2NonConsDirIngressSegID(); err != nil {
+		return err
+	}
+	if err := rp.verifyV2CurrentMAC(); err != nil {
+		return err
+	}
+	if handled, err := rp.handleV2IngressRouterAlert(); err != nil || handled {
+		return err
+	}
+	// Inbound: packets destined to the local AS.
+	if rp.v2.scn.DstIA.Equal(rp.Ctx.Conf.IA) {
+		return rp.deliverV2Local()
+	}
+	// Outbound: packets leaving the local AS. Cross-over points (except for
+	// peering) are handled at the ingress router.
+	if rp.v2.path.IsXover() && !rp.v2.peering {
+		if err := rp.doV2Xover(); err != nil {
+			return err
+		}
+	}
+	egressID := rp.egressV2ID()
+	if handled, err := rp.handleV2EgressRouterAlert(egressID); err != nil || handled {
+		return err
+	}
+	if err := rp.validateV2EgressUp(egressID); err != nil {
+		return err
+	}
+	if s, ok := rp.Ctx.ExtSockOut[egressID]; ok {
+		if err := rp.validateV2EgressMTU(egressID); err != nil {
+			return err
+		}
+		if err := rp.processV2Egress(); err != nil {
+			return err
+		}
+		rp.Egress = append(rp.Egress, EgressPair{S: s})
+		return nil
+	}
+	// Transit: the egress interface is on a different router of the local AS.
+	// Packets from the local AS must have been sent to the correct router.
+	if info, ok := rp.Ctx.Conf.Topo.IFInfoMap()[egressID]; ok && rp.DirFrom == rcmn.DirExternal {
+		rp.Egress = append(rp.Egress, EgressPair{S: rp.Ctx.LocSockOut, Dst: info.InternalAddr})
+		return nil
+	}
+	return rp.newV2ParamProblem(slayers.SCMPCodeUnknownHopFieldInterface,
+		rp.currentV2HopPointer(), serrors.New("unknown egress interface", "egress", egressID))
+}
+
+// parseV2Fields parses the current info and hop field of the SCION path.
+func (rp *RtrPkt) parseV2Fields() error {
+	var err error
+	if rp.v2.infoF, err = rp.v2.path.GetCurrentInfoField(); err != nil {
+		return err
+	}
+	if rp.v2.hopF, err = rp.v2.path.GetCurrentHopField(); err != nil {
+		return err
+	}
+	rp.v2.peering = rp.v2.infoF.Peer
+	return nil
+}
+
+func (rp *RtrPkt) validateV2HopExpiry() error {
+	expiration := util.SecsToTime(rp.v2.infoF.Timestamp).Add(
+		spath.ExpTimeType(rp.v2.hopF.ExpTime).ToDuration())
+	if !expiration.Before(time.Now()) {
+		return nil
+	}
+	return rp.newV2ParamProblem(slayers.SCMPCodePathExpired, rp.currentV2HopPointer(),
+		serrors.New("expired hop", "cons_dir", rp.v2.infoF.ConsDir, "if_id", rp.Ingress.IfID,
+			"curr_inf", rp.v2.path.PathMeta.CurrINF, "curr_hf", rp.v2.path.PathMeta.CurrHF))
+}
+
+func (rp *RtrPkt) validateV2IngressID() error {
+	pktIngressID := rp.v2.hopF.ConsIngress
+	if !rp.v2.infoF.ConsDir {
+		pktIngressID = rp.v2.hopF.ConsEgress
+	}
+	if rp.Ingress.IfID != 0 && rp.Ingress.IfID != common.IFIDType(pktIngressID) {
+		return rp.newV2ParamProblem(slayers.SCMPCodeUnknownHopFieldInterface,
+			rp.currentV2HopPointer(), serrors.New("ingress interface invalid",
+				"pkt_ingress", pktIngressID, "router_ingress", rp.Ingress.IfID))
+	}
+	return nil
+}
+
+// updateV2NonConsDirIngressSegID updates the SegID of the current info field
+// for packets traversing the segment against construction direction. This must
+// happen on ingress before the MAC is verified.
+func (rp *RtrPkt) updateV2NonConsDirIngressSegID() error {
+	// Peering segments do not use MAC chaining, and packets from the local AS
+	// have the SegID already set correctly.
+	if rp.v2.infoF.ConsDir || rp.Ingress.IfID == 0 || rp.v2.peering {
+		return nil
+	}
+	rp.v2.infoF.UpdateSegID(rp.v2.hopF.Mac)
+	if err := rp.v2.path.SetInfoField(rp.v2.infoF,
+		int(rp.v2.path.PathMeta.CurrINF)); err != nil {
+		return serrors.WrapStr("updating info field", err)
+	}
+	return nil
+}
+
+func (rp *RtrPkt) verifyV2CurrentMAC() error {
+	err := rp.Ctx.VerifyHFMac(func(mac hash.Hash) error {
+		return path.VerifyMAC(mac, rp.v2.infoF, rp.v2.hopF)
+	})
+	if err != nil {
+		return rp.newV2ParamProblem(slayers.SCMPCodeInvalidHopFieldMAC,
+			rp.currentV2HopPointer(), serrors.WrapStr("verifying hop field MAC", err,
+				"cons_dir", rp.v2.infoF.ConsDir, "if_id", rp.Ingress.IfID,
+				"curr_inf", rp.v2.path.PathMeta.CurrINF, "curr_hf", rp.v2.path.PathMeta.CurrHF,
+				"seg_id", rp.v2.infoF.SegID))
+	}
+	return nil
+}
+
+// doV2Xover increments the path at a cross-over point and validates the hop
+// field of the new segment.
+func (rp *RtrPkt) doV2Xover() error {
+	if err := rp.v2.path.IncPath(); err != nil {
+		return rp.newV2ParamProblem(slayers.SCMPCodeInvalidPath, rp.currentV2HopPointer(),
+			serrors.WrapStr("incrementing path", err))
+	}
+	rp.IncrementedPath = true
+	if err := rp.parseV2Fields(); err != nil {
+		return err
+	}
+	if err := rp.validateV2HopExpiry(); err != nil {
+		return err
+	}
+	return rp.verifyV2CurrentMAC()
+}
+
+func (rp *RtrPkt) egressV2ID() common.IFIDType {
+	if rp.v2.infoF.ConsDir {
+		return common.IFIDType(rp.v2.hopF.ConsEgress)
+	}
+	return common.IFIDType(rp.v2.hopF.ConsIngress)
+}
+
+// validateV2EgressUp checks that the egress interface is not known to be
+// down. Packets for revoked interfaces are answered with an SCMP
+// ExternalInterfaceDown error.
+func (rp *RtrPkt) validateV2EgressUp(egressID common.IFIDType) error {
+	state, ok := ifstate.LoadState(egressID)
+	if !ok || state.Active {
+		return nil
+	}
+	return &SCMPErrorV2{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+		Msg: &slayers.SCMPExternalInterfaceDown{
+			IA:   rp.Ctx.Conf.IA,
+			IfID: uint64(egressID),
+		},
+		Cause: serrors.New("egress interface down", "egress", egressID),
+	}
+}
+
+// validateV2EgressMTU checks that the packet fits the MTU of the egress
+// interface. Packets that are too big are answered with an SCMP PacketTooBig
+// error that contains the MTU of the interface.
+func (rp *RtrPkt) validateV2EgressMTU(egressID common.IFIDType) error {
+	info, ok := rp.Ctx.Conf.Topo.IFInfoMap()[egressID]
+	if !ok || info.MTU <= 0 || len(rp.Raw) <= info.MTU {
+		return nil
+	}
+	return &SCMPErrorV2{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypePacketTooBig, 0),
+		Msg:      &slayers.SCMPPacketTooBig{MTU: uint16(info.MTU)},
+		Cause: serrors.New("packet exceeds egress MTU", "egress", egressID,
+			"len", len(rp.Raw), "mtu", info.MTU),
+	}
+}
+
+// processV2Egress updates the path before the packet leaves the local AS.
+func (rp *RtrPkt) processV2Egress() error {
+	// In construction direction the SegID is updated on egress, i.e. after the
+	// MAC of the current hop field has been verified.
+	if rp.v2.infoF.ConsDir && !rp.v2.peering {
+		rp.v2.infoF.UpdateSegID(rp.v2.hopF.Mac)
+		if err := rp.v2.path.SetInfoField(rp.v2.infoF,
+			int(rp.v2.path.PathMeta.CurrINF)); err != nil {
+			return serrors.WrapStr("updating info field", err)
+		}
+	}
+	if err := rp.v2.path.IncPath(); err != nil {
+		return rp.newV2ParamProblem(slayers.SCMPCodeInvalidPath, rp.currentV2HopPointer(),
+			serrors.WrapStr("incrementing path", err))
+	}
+	rp.IncrementedPath = true
+	return rp.v2.path.PathMeta.SerializeTo(rp.v2.path.Raw[:scion.MetaLen])
+}
+
+// handleV2IngressRouterAlert answers traceroute requests that have the router
+// alert flag set for the ingress interface of this router.
+func (rp *RtrPkt) handleV2IngressRouterAlert() (bool, error) {
+	if rp.Ingress.IfID == 0 {
+		return false, nil
+	}
+	alert := &rp.v2.hopF.IngressRouterAlert
+	if !rp.v2.infoF.ConsDir {
+		alert = &rp.v2.hopF.EgressRouterAlert
+	}
+	if !*alert {
+		return false, nil
+	}
+	*alert = false
+	if err := rp.v2.path.SetHopField(rp.v2.hopF, int(rp.v2.path.PathMeta.CurrHF)); err != nil {
+		return false, serrors.WrapStr("updating hop field", err)
+	}
+	return rp.handleV2TracerouteRequest(rp.Ingress.IfID)
+}
+
+// handleV2EgressRouterAlert answers traceroute requests that have the router
+// alert flag set for an egress interface of this router.
+func (rp *RtrPkt) handleV2EgressRouterAlert(egressID common.IFIDType) (bool, error) {
+	alert := &rp.v2.hopF.EgressRouterAlert
+	if !rp.v2.infoF.ConsDir {
+		alert = &rp.v2.hopF.IngressRouterAlert
+	}
+	if !*alert {
+		return false, nil
+	}
+	if _, ok := rp.Ctx.ExtSockOut[egressID]; !ok {
+		// The interface is on a different router, it will answer the request.
+		return false, nil
+	}
+	*alert = false
+	if err := rp.v2.path.SetHopField(rp.v2.hopF, int(rp.v2.path.PathMeta.CurrHF)); err != nil {
+		return false, serrors.WrapStr("updating hop field", err)
+	}
+	return rp.handleV2TracerouteRequest(egressID)
+}
+
+// handleV2TracerouteRequest sends a traceroute reply if the packet is an SCMP
+// traceroute request. The bool result indicates whether the packet has been
+// consumed.
+func (rp *RtrPkt) handleV2TracerouteRequest(ifID common.IFIDType) (bool, error) {
+	if rp.L4Type != common.L4SCMP {
+		return false, nil
+	}
+	var scmpH slayers.SCMP
+	if err := scmpH.DecodeFromBytes(rp.v2.scn.Payload, gopacket.NilDecodeFeedback); err != nil {
+		return false, serrors.WrapStr("decoding SCMP header", err)
+	}
+	if scmpH.TypeCode.Type() != slayers.SCMPTypeTracerouteRequest {
+		return false, nil
+	}
+	var req slayers.SCMPTraceroute
+	if err := req.DecodeFromBytes(scmpH.Payload, gopacket.NilDecodeFeedback); err != nil {
+		return false, serrors.WrapStr("decoding SCMP traceroute request", err)
+	}
+	reply, err := rp.CreateSCMPReplyV2(
+		slayers.CreateSCMPTypeCode(slayers.SCMPTypeTracerouteReply, 0),
+		&slayers.SCMPTraceroute{
+			Identifier: req.Identifier,
+			IA:         rp.Ctx.Conf.IA,
+			Interface:  uint64(ifID),
+		},
+		false,
+	)
+	if err != nil {
+		return false, serrors.WrapStr("creating traceroute reply", err)
+	}
+	return true, reply.Route()
+}
+
+// deliverV2Local sets the egress for a packet destined to the local AS.
+func (rp *RtrPkt) deliverV2Local() error {
+	dst, err := rp.v2.scn.DstAddr()
+	if err != nil {
+		return err
+	}
+	switch v := dst.(type) {
+	case addr.HostSVC:
+		addrs, err := rp.Ctx.ResolveSVC(v)
+		if err != nil {
+			return err
+		}
+		for _, a := range addrs {
+			rp.Egress = append(rp.Egress, EgressPair{S: rp.Ctx.LocSockOut, Dst: a})
+		}
+	case *net.IPAddr:
+		rp.Egress = append(rp.Egress, EgressPair{
+			S:   rp.Ctx.LocSockOut,
+			Dst: &net.UDPAddr{IP: v.IP, Port: topology.EndhostPort},
+		})
+	default:
+		return serrors.New("unsupported destination address", "addr", dst)
+	}
+	return nil
+}
+
+// processOHPV2 processes packets with a one-hop path. On the way out, the
+// first hop field is verified and the SegID is updated. On the way in, the
+// second hop field is created by the router.
+func (rp *RtrPkt) processOHPV2(ohp *onehop.Path) error {
+	pathOffset := slayers.CmnHdrLen + rp.v2.scn.AddrHdrLen()
+	if rp.DirFrom != rcmn.DirExternal {
+		if !rp.v2.scn.SrcIA.Equal(rp.Ctx.Conf.IA) {
+			return serrors.New("bad source IA", "type", "ohp",
+				"expected", rp.Ctx.Conf.IA, "actual", rp.v2.scn.SrcIA)
+		}
+		egressID := common.IFIDType(ohp.FirstHop.ConsEgress)
+		s, ok := rp.Ctx.ExtSockOut[egressID]
+		if !ok {
+			return serrors.New("unknown egress interface", "type", "ohp", "egress", egressID)
+		}
+		err := rp.Ctx.VerifyHFMac(func(mac hash.Hash) error {
+			return path.VerifyMAC(mac, &ohp.Info, &ohp.FirstHop)
+		})
+		if err != nil {
+			return serrors.WrapStr("verifying first hop MAC", err, "type", "ohp")
+		}
+		ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
+		if err := ohp.SerializeTo(rp.Raw[pathOffset:]); err != nil {
+			return err
+		}
+		rp.Egress = append(rp.Egress, EgressPair{S: s})
+		return nil
+	}
+	if !rp.v2.scn.DstIA.Equal(rp.Ctx.Conf.IA) {
+		return serrors.New("bad destination IA", "type", "ohp",
+			"expected", rp.Ctx.Conf.IA, "actual", rp.v2.scn.DstIA)
+	}
+	ohp.SecondHop = path.HopField{
+		ConsIngress: uint16(rp.Ingress.IfID),
+		ExpTime:     ohp.FirstHop.ExpTime,
+	}
+	mac := rp.Ctx.HFMacPool.Get().(hash.Hash)
+	ohp.SecondHop.Mac = path.MAC(mac, &ohp.Info, &ohp.SecondHop)
+	rp.Ctx.HFMacPool.Put(mac)
+	if err := ohp.SerializeTo(rp.Raw[pathOffset:]); err != nil {
+		return err
+	}
+	return rp.deliverV2Local()
+}
+
+// currentV2HopPointer returns the offset of the current hop field from the
+// start of the SCION header.
+func (rp *RtrPkt) currentV2HopPointer() uint16 {
+	return uint16(slayers.CmnHdrLen + rp.v2.scn.AddrHdrLen() + scion.MetaLen +
+		rp.v2.path.NumINF*path.InfoLen + int(rp.v2.path.PathMeta.CurrHF)*path.HopLen)
+}
+
+func (rp *RtrPkt) newV2ParamProblem(code uint8, pointer uint16, cause error) error {
+	return &SCMPErrorV2{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem, code),
+		Msg:      &slayers.SCMPParameterProblem{Pointer: pointer},
+		Cause:    cause,
+	}
+}
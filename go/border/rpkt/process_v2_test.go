@@ -0,0 +1,369 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpkt
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	v2Key     = []byte("testkey_xxxxxxxx")
+	v2PrevKey = []byte("testkey_yyyyyyyy")
+)
+
+func TestProcessV2(t *testing.T) {
+	now := time.Now()
+	testCases := map[string]struct {
+		// prepare returns the packet and the expected processed packet.
+		prepare      func(t *testing.T) (*RtrPkt, []byte)
+		egress       func(ctx *rctx.Ctx) []EgressPair
+		expectedSCMP slayers.SCMPTypeCode
+	}{
+		"transit cons dir": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, true, 1, [][2]uint16{{0, 41}, {1, 2}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 1)
+				dpath.InfoFields[0].UpdateSegID(dpath.HopFields[1].Mac)
+				_ = dpath.IncPath()
+				return rp, v2TestRaw(t, dpath, "1-ff00:0:4", "1-ff00:0:5")
+			},
+			egress: func(ctx *rctx.Ctx) []EgressPair {
+				return []EgressPair{{S: ctx.ExtSockOut[2]}}
+			},
+		},
+		"transit previous master key": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPathWithKey(v2PrevKey, now, true, 1,
+					[][2]uint16{{0, 41}, {1, 2}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 1)
+				dpath.InfoFields[0].UpdateSegID(dpath.HopFields[1].Mac)
+				_ = dpath.IncPath()
+				return rp, v2TestRaw(t, dpath, "1-ff00:0:4", "1-ff00:0:5")
+			},
+			egress: func(ctx *rctx.Ctx) []EgressPair {
+				return []EgressPair{{S: ctx.ExtSockOut[2]}}
+			},
+		},
+		"transit against cons dir": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, false, 1, [][2]uint16{{0, 41}, {1, 2}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 2)
+				dpath.InfoFields[0].UpdateSegID(dpath.HopFields[1].Mac)
+				_ = dpath.IncPath()
+				return rp, v2TestRaw(t, dpath, "1-ff00:0:4", "1-ff00:0:5")
+			},
+			egress: func(ctx *rctx.Ctx) []EgressPair {
+				return []EgressPair{{S: ctx.ExtSockOut[1]}}
+			},
+		},
+		"transit to other router": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, true, 1, [][2]uint16{{0, 41}, {1, 3}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 1)
+				return rp, v2TestRaw(t, dpath, "1-ff00:0:4", "1-ff00:0:5")
+			},
+			egress: func(ctx *rctx.Ctx) []EgressPair {
+				return []EgressPair{{
+					S:   ctx.LocSockOut,
+					Dst: ctx.Conf.Topo.IFInfoMap()[3].InternalAddr,
+				}}
+			},
+		},
+		"inbound": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, true, 1, [][2]uint16{{0, 41}, {1, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:1", rcmn.DirExternal, 1)
+				return rp, v2TestRaw(t, dpath, "1-ff00:0:4", "1-ff00:0:1")
+			},
+			egress: func(ctx *rctx.Ctx) []EgressPair {
+				return []EgressPair{{
+					S:   ctx.LocSockOut,
+					Dst: &net.UDPAddr{IP: net.IP{10, 0, 0, 2}, Port: topology.EndhostPort},
+				}}
+			},
+		},
+		"outbound": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, true, 0, [][2]uint16{{0, 2}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:1", "1-ff00:0:5", rcmn.DirLocal, 0)
+				dpath.InfoFields[0].UpdateSegID(dpath.HopFields[0].Mac)
+				_ = dpath.IncPath()
+				return rp, v2TestRaw(t, dpath, "1-ff00:0:1", "1-ff00:0:5")
+			},
+			egress: func(ctx *rctx.Ctx) []EgressPair {
+				return []EgressPair{{S: ctx.ExtSockOut[2]}}
+			},
+		},
+		"packet too big": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, true, 1, [][2]uint16{{0, 41}, {1, 4}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 1)
+				return rp, nil
+			},
+			expectedSCMP: slayers.CreateSCMPTypeCode(slayers.SCMPTypePacketTooBig, 0),
+		},
+		"bad mac": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, true, 1, [][2]uint16{{0, 41}, {1, 2}, {42, 0}})
+				dpath.HopFields[1].Mac = []byte{1, 2, 3, 4, 5, 6}
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 1)
+				return rp, nil
+			},
+			expectedSCMP: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidHopFieldMAC),
+		},
+		"bad ingress": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now, true, 1, [][2]uint16{{0, 41}, {1, 2}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 2)
+				return rp, nil
+			},
+			expectedSCMP: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeUnknownHopFieldInterface),
+		},
+		"expired": {
+			prepare: func(t *testing.T) (*RtrPkt, []byte) {
+				dpath := v2TestPath(now.Add(-48*time.Hour), true, 1,
+					[][2]uint16{{0, 41}, {1, 2}, {42, 0}})
+				rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 1)
+				return rp, nil
+			},
+			expectedSCMP: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodePathExpired),
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			rp, expected := tc.prepare(t)
+			require.NoError(t, rp.ParseV2())
+			err := rp.ProcessV2()
+			if tc.expectedSCMP != 0 {
+				var serr *SCMPErrorV2
+				require.True(t, errors.As(err, &serr), "err: %v", err)
+				assert.Equal(t, tc.expectedSCMP, serr.TypeCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expected, []byte(rp.Raw))
+			assert.Equal(t, tc.egress(rp.Ctx), rp.Egress)
+		})
+	}
+}
+
+func TestProcessV2OneHop(t *testing.T) {
+	mac, err := scrypto.HFMacFactory(v2Key)
+	require.NoError(t, err)
+	ohp := &onehop.Path{
+		Info: path.InfoField{
+			ConsDir:   true,
+			SegID:     0x111,
+			Timestamp: util.TimeToSecs(time.Now()),
+		},
+		FirstHop: path.HopField{ConsEgress: 2, ExpTime: 63},
+	}
+	ohp.FirstHop.Mac = path.MAC(mac(), &ohp.Info, &ohp.FirstHop)
+
+	t.Run("outbound", func(t *testing.T) {
+		rp := v2TestPktWithPath(t, slayers.PathTypeOneHop, ohp, "1-ff00:0:1", "1-ff00:0:5",
+			rcmn.DirLocal, 0)
+		require.NoError(t, rp.ParseV2())
+		require.NoError(t, rp.ProcessV2())
+		assert.Equal(t, []EgressPair{{S: rp.Ctx.ExtSockOut[2]}}, rp.Egress)
+	})
+	t.Run("inbound", func(t *testing.T) {
+		in := *ohp
+		in.Info.UpdateSegID(ohp.FirstHop.Mac)
+		rp := v2TestPktWithPath(t, slayers.PathTypeOneHop, &in, "1-ff00:0:5", "1-ff00:0:1",
+			rcmn.DirExternal, 1)
+		require.NoError(t, rp.ParseV2())
+		require.NoError(t, rp.ProcessV2())
+		var scn slayers.SCION
+		require.NoError(t, scn.DecodeFromBytes(rp.Raw, gopacket.NilDecodeFeedback))
+		processed := scn.Path.(*onehop.Path)
+		assert.Equal(t, uint16(1), processed.SecondHop.ConsIngress)
+		assert.NoError(t, path.VerifyMAC(mac(), &processed.Info, &processed.SecondHop))
+	})
+}
+
+func TestCreateSCMPErrorReplyV2(t *testing.T) {
+	dpath := v2TestPath(time.Now(), true, 1, [][2]uint16{{0, 41}, {1, 2}, {42, 0}})
+	dpath.HopFields[1].Mac = []byte{1, 2, 3, 4, 5, 6}
+	rp := v2TestPkt(t, dpath, "1-ff00:0:4", "1-ff00:0:5", rcmn.DirExternal, 1)
+	require.NoError(t, rp.ParseV2())
+	err := rp.ProcessV2()
+	var serr *SCMPErrorV2
+	require.True(t, errors.As(err, &serr))
+
+	reply, err := rp.CreateSCMPErrorReplyV2(serr)
+	require.NoError(t, err)
+	assert.Equal(t, []EgressPair{{S: rp.Ctx.ExtSockOut[1]}}, reply.Egress)
+
+	pkt := gopacket.NewPacket(reply.Raw, slayers.LayerTypeSCION, gopacket.Default)
+	scn := pkt.Layer(slayers.LayerTypeSCION).(*slayers.SCION)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:4"), scn.DstIA)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:1"), scn.SrcIA)
+	revPath, err := scn.Path.(*scion.Raw).ToDecoded()
+	require.NoError(t, err)
+	assert.False(t, revPath.InfoFields[0].ConsDir)
+	assert.Equal(t, uint8(2), revPath.PathMeta.CurrHF)
+	scmpH := pkt.Layer(slayers.LayerTypeSCMP).(*slayers.SCMP)
+	assert.Equal(t, serr.TypeCode, scmpH.TypeCode)
+	pp := pkt.Layer(slayers.LayerTypeSCMPParameterProblem).(*slayers.SCMPParameterProblem)
+	assert.Equal(t, rp.currentV2HopPointer(), pp.Pointer)
+	assert.Equal(t, []byte(rp.Raw), pp.Payload)
+}
+
+// v2TestPath creates a single segment path with the given hops, which are
+// specified in construction direction. The MACs are chained as done during
+// beaconing. currHF is the index of the current hop field in the packet.
+func v2TestPath(ts time.Time, consDir bool, currHF uint8, hops [][2]uint16) *scion.Decoded {
+	return v2TestPathWithKey(v2Key, ts, consDir, currHF, hops)
+}
+
+// v2TestPathWithKey creates a path like v2TestPath, but with the hop fields
+// MACed with the provided master key.
+func v2TestPathWithKey(key []byte, ts time.Time, consDir bool, currHF uint8,
+	hops [][2]uint16) *scion.Decoded {
+
+	mac, err := scrypto.HFMacFactory(key)
+	if err != nil {
+		panic(err)
+	}
+	dpath := &scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{uint8(len(hops))}},
+			NumINF:   1,
+			NumHops:  len(hops),
+		},
+		InfoFields: []*path.InfoField{{ConsDir: true, Timestamp: util.TimeToSecs(ts)}},
+	}
+	betas := []uint16{0x222}
+	for i, h := range hops {
+		hop := &path.HopField{ConsIngress: h[0], ConsEgress: h[1], ExpTime: 63}
+		info := &path.InfoField{SegID: betas[i], Timestamp: dpath.InfoFields[0].Timestamp}
+		hop.Mac = path.MAC(mac(), info, hop)
+		info.UpdateSegID(hop.Mac)
+		betas = append(betas, info.SegID)
+		dpath.HopFields = append(dpath.HopFields, hop)
+	}
+	if consDir {
+		dpath.PathMeta.CurrHF = currHF
+		dpath.InfoFields[0].SegID = betas[currHF]
+		return dpath
+	}
+	if err := dpath.Reverse(); err != nil {
+		panic(err)
+	}
+	// Against construction direction, the packet carries the SegID of the
+	// previous hop field in construction direction.
+	dpath.PathMeta.CurrHF = currHF
+	dpath.InfoFields[0].SegID = betas[len(hops)-int(currHF)]
+	return dpath
+}
+
+func v2TestPkt(t *testing.T, p *scion.Decoded, src, dst string, dir rcmn.Dir,
+	ifID common.IFIDType) *RtrPkt {
+
+	return v2TestPktWithPath(t, slayers.PathTypeSCION, p, src, dst, dir, ifID)
+}
+
+func v2TestPktWithPath(t *testing.T, pathType slayers.PathType, p slayers.Path,
+	src, dst string, dir rcmn.Dir, ifID common.IFIDType) *RtrPkt {
+
+	rp := NewRtrPkt()
+	raw := v2TestRawWithPath(t, pathType, p, src, dst)
+	rp.Raw = rp.Raw[:len(raw)]
+	copy(rp.Raw, raw)
+	rp.Ctx = v2TestCtx(t)
+	rp.DirFrom = dir
+	rp.Ingress = addrIFPair{
+		IfID: ifID,
+		Src:  &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 30041},
+	}
+	return rp
+}
+
+func v2TestRaw(t *testing.T, p *scion.Decoded, src, dst string) []byte {
+	return v2TestRawWithPath(t, slayers.PathTypeSCION, p, src, dst)
+}
+
+func v2TestRawWithPath(t *testing.T, pathType slayers.PathType, p slayers.Path,
+	src, dst string) []byte {
+
+	scn := &slayers.SCION{
+		Version:  2,
+		FlowID:   1,
+		NextHdr:  common.L4UDP,
+		PathType: pathType,
+		SrcIA:    xtest.MustParseIA(src),
+		DstIA:    xtest.MustParseIA(dst),
+		Path:     p,
+	}
+	require.NoError(t, scn.SetSrcAddr(&net.IPAddr{IP: net.IP{10, 0, 0, 1}}))
+	require.NoError(t, scn.SetDstAddr(&net.IPAddr{IP: net.IP{10, 0, 0, 2}}))
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(buf,
+		gopacket.SerializeOptions{FixLengths: true}, scn, gopacket.Payload("payload")))
+	return buf.Bytes()
+}
+
+func v2TestCtx(t *testing.T) *rctx.Ctx {
+	ia := xtest.MustParseIA("1-ff00:0:1")
+	topo := topology.FromRWTopology(&topology.RWTopology{
+		IA: ia,
+		IFInfoMap: topology.IfInfoMap{
+			1: {ID: 1},
+			2: {ID: 2},
+			3: {ID: 3, InternalAddr: &net.UDPAddr{IP: net.IP{10, 0, 0, 3}, Port: 30003}},
+			4: {ID: 4, MTU: 64},
+		},
+	})
+	ctx := rctx.New(&brconf.BRConf{
+		IA:   ia,
+		Topo: topo,
+		BR: &topology.BRInfo{
+			InternalAddr: &net.UDPAddr{IP: net.IP{10, 0, 0, 11}, Port: 30001},
+		},
+		MasterKeys: keyconf.Master{Key0: v2Key, Key1: v2PrevKey},
+	})
+	require.NoError(t, ctx.InitMacPool())
+	ctx.LocSockOut = &rctx.Sock{Label: "loc"}
+	ctx.ExtSockOut[1] = &rctx.Sock{Label: "1"}
+	ctx.ExtSockOut[2] = &rctx.Sock{Label: "2"}
+	ctx.ExtSockOut[4] = &rctx.Sock{Label: "4"}
+	return ctx
+}
@@ -122,6 +122,8 @@ type RtrPkt struct {
 	// Logger is used to log messages associated with a packet. The Id field is automatically
 	// included in the output.
 	log.Logger
+	// v2 contains the decoded SCION v2 header, if the packet has one. (PARSE)
+	v2 *v2Hdr
 	// The current router context to process this packet.
 	Ctx *rctx.Ctx
 	// Reference count
@@ -239,6 +241,7 @@ func (rp *RtrPkt) Reset() {
 	rp.hooks = hooks{}
 	rp.SCMPError = false
 	rp.Logger = nil
+	rp.v2 = nil
 	rp.Ctx = nil
 	rp.refCnt = 1
 	rp.Free = nil
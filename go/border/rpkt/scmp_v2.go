@@ -0,0 +1,172 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file handles the creation of SCMP messages for packets with a SCION v2
+// header.
+
+package rpkt
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+)
+
+// maxSCMPPacketLen is the maximum length of an SCMP message created by the
+// router. The quoted packet is truncated accordingly.
+const maxSCMPPacketLen = 1232
+
+// SCMPErrorV2 is the error returned by ProcessV2 if an SCMP error message
+// should be sent to the source of the packet.
+type SCMPErrorV2 struct {
+	// TypeCode is the type and code of the SCMP message.
+	TypeCode slayers.SCMPTypeCode
+	// Msg is the type specific part of the SCMP message, e.g.,
+	// slayers.SCMPParameterProblem.
+	Msg gopacket.SerializableLayer
+	// Cause is the error that caused the SCMP message.
+	Cause error
+}
+
+func (e *SCMPErrorV2) Error() string {
+	return fmt.Sprintf("SCMP %s: %v", e.TypeCode, e.Cause)
+}
+
+func (e *SCMPErrorV2) Unwrap() error {
+	return e.Cause
+}
+
+// CreateSCMPErrorReplyV2 creates an SCMP error reply for the SCION v2 packet,
+// quoting as much of the original packet as possible.
+func (rp *RtrPkt) CreateSCMPErrorReplyV2(serr *SCMPErrorV2) (*RtrPkt, error) {
+	return rp.CreateSCMPReplyV2(serr.TypeCode, serr.Msg, true)
+}
+
+// CreateSCMPReplyV2 creates an SCMP message that is sent back to the source of
+// the SCION v2 packet on the reversed path. If quote is set, the original
+// packet is appended to the message.
+func (rp *RtrPkt) CreateSCMPReplyV2(typeCode slayers.SCMPTypeCode,
+	msg gopacket.SerializableLayer, quote bool) (*RtrPkt, error) {
+
+	if rp.v2 == nil || rp.v2.path == nil {
+		return nil, serrors.New("SCMP replies require a SCION v2 path")
+	}
+	revPath, err := rp.reverseV2Path()
+	if err != nil {
+		return nil, serrors.WrapStr("reversing path", err)
+	}
+	scn := &slayers.SCION{
+		Version:      rp.v2.scn.Version,
+		TrafficClass: rp.v2.scn.TrafficClass,
+		FlowID:       rp.v2.scn.FlowID,
+		NextHdr:      common.L4SCMP,
+		PathType:     slayers.PathTypeSCION,
+		DstIA:        rp.v2.scn.SrcIA,
+		SrcIA:        rp.Ctx.Conf.IA,
+		Path:         revPath,
+	}
+	srcAddr, err := rp.v2.scn.SrcAddr()
+	if err != nil {
+		return nil, serrors.WrapStr("extracting source address", err)
+	}
+	if err := scn.SetDstAddr(srcAddr); err != nil {
+		return nil, serrors.WrapStr("setting destination address", err)
+	}
+	if err := scn.SetSrcAddr(&net.IPAddr{IP: rp.Ctx.Conf.BR.InternalAddr.IP}); err != nil {
+		return nil, serrors.WrapStr("setting source address", err)
+	}
+	scmpH := &slayers.SCMP{TypeCode: typeCode}
+	if err := scmpH.SetNetworkLayerForChecksum(scn); err != nil {
+		return nil, err
+	}
+	opts := gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true}
+	var quoted []byte
+	if quote {
+		msgBuf := gopacket.NewSerializeBuffer()
+		if err := msg.SerializeTo(msgBuf, opts); err != nil {
+			return nil, err
+		}
+		hdrLen := slayers.CmnHdrLen + scn.AddrHdrLen() + revPath.Len() + 4 +
+			len(msgBuf.Bytes())
+		quoted = rp.Raw
+		if maxQuoteLen := maxSCMPPacketLen - hdrLen; len(quoted) > maxQuoteLen {
+			quoted = quoted[:maxQuoteLen]
+		}
+	}
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, opts, scn, scmpH, msg,
+		gopacket.Payload(quoted)); err != nil {
+		return nil, serrors.WrapStr("serializing SCMP message", err)
+	}
+	reply := NewRtrPkt()
+	reply.Ctx = rp.Ctx
+	reply.TimeIn = time.Now()
+	reply.Id = log.NewDebugID().String()
+	reply.Logger = log.New("rpkt", reply.Id)
+	reply.DirFrom = rcmn.DirSelf
+	reply.Raw = reply.Raw[:len(buf.Bytes())]
+	copy(reply.Raw, buf.Bytes())
+	if err := reply.ParseV2(); err != nil {
+		return nil, serrors.WrapStr("parsing SCMP message", err)
+	}
+	// The reply leaves through the interface the original packet arrived on.
+	if rp.DirFrom == rcmn.DirExternal {
+		reply.Egress = append(reply.Egress, EgressPair{S: rp.Ctx.ExtSockOut[rp.Ingress.IfID]})
+	} else {
+		reply.Egress = append(reply.Egress, EgressPair{S: rp.Ctx.LocSockOut, Dst: rp.Ingress.Src})
+	}
+	return reply, nil
+}
+
+// reverseV2Path returns the reversed path of the packet, ready to be sent from
+// this router back to the source.
+func (rp *RtrPkt) reverseV2Path() (*scion.Decoded, error) {
+	revPath, err := rp.v2.path.ToDecoded()
+	if err != nil {
+		return nil, err
+	}
+	if err := revPath.Reverse(); err != nil {
+		return nil, err
+	}
+	// Revert a potential segment change that was done during processing.
+	if revPath.IsXover() {
+		if err := revPath.IncPath(); err != nil {
+			return nil, err
+		}
+	}
+	// If the reply is sent out on an external interface, the egress processing
+	// of this router has to be done as well. Since the path is reversed, the
+	// ingress interface of the original packet is the egress interface of the
+	// reply.
+	if rp.DirFrom == rcmn.DirExternal {
+		infoF := revPath.InfoFields[revPath.PathMeta.CurrINF]
+		if infoF.ConsDir {
+			hopF := revPath.HopFields[revPath.PathMeta.CurrHF]
+			infoF.UpdateSegID(hopF.Mac)
+		}
+		if err := revPath.IncPath(); err != nil {
+			return nil, err
+		}
+	}
+	return revPath, nil
+}
@@ -17,12 +17,24 @@ package beacon
 import (
 	"encoding/json"
 	"io/ioutil"
+	"sort"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/hiddenpath"
 	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+const (
+	// HPDefaultActionRegister indicates that segments received on interfaces
+	// without a policy are registered publicly.
+	HPDefaultActionRegister = "register"
+	// HPDefaultActionDrop indicates that segments received on interfaces
+	// without a policy are not registered at all.
+	HPDefaultActionDrop = "drop"
 )
 
 // HPGroup holds a hidden path group
@@ -38,6 +50,23 @@ type RegPolicy struct {
 	MaxExpiration util.DurWrap `yaml:"MaxExpiration"`
 }
 
+// Allows returns whether a segment of the given type that expires at the given
+// time may be registered according to the policy. A zero MaxExpiration does not
+// restrict the expiration time.
+func (p RegPolicy) Allows(segType proto.PathSegType, expiry, now time.Time) bool {
+	if p.MaxExpiration.Duration != 0 && expiry.After(now.Add(p.MaxExpiration.Duration)) {
+		return false
+	}
+	switch segType {
+	case proto.PathSegType_up:
+		return p.RegUp
+	case proto.PathSegType_down:
+		return p.RegDown
+	default:
+		return false
+	}
+}
+
 // HPPolicy holds the public and hidden registration policies for an interface
 type HPPolicy struct {
 	Public RegPolicy                        `yaml:"PS"`
@@ -60,6 +89,12 @@ type HPRegistration struct {
 // Validate verifies that for all hidden path policies the referenced Group exists
 // and checks if all GroupId keys match the initialized HPGroup
 func (hp *HPRegistration) Validate() error {
+	switch hp.HPPolicies.DefaultAction {
+	case HPDefaultActionRegister, HPDefaultActionDrop:
+	default:
+		return common.NewBasicError("Invalid default action", nil,
+			"action", hp.HPPolicies.DefaultAction)
+	}
 	for _, p := range hp.HPPolicies.Policies {
 		for id := range p.Hidden {
 			if _, ok := hp.HPGroups[id]; !ok {
@@ -77,6 +112,31 @@ func (hp *HPRegistration) Validate() error {
 	return nil
 }
 
+// Targets returns where a segment of the given type, that was received on
+// interface ifid and expires at the given time, is registered. The returned
+// groups are sorted by their ID. If public is set, the segment is also
+// registered publicly.
+func (hp *HPRegistration) Targets(ifid common.IFIDType, segType proto.PathSegType,
+	expiry, now time.Time) ([]*hiddenpath.Group, bool) {
+
+	policy, ok := hp.HPPolicies.Policies[ifid]
+	if !ok {
+		return nil, hp.HPPolicies.DefaultAction == HPDefaultActionRegister
+	}
+	var groups []*hiddenpath.Group
+	for id, p := range policy.Hidden {
+		if p.Allows(segType, expiry, now) {
+			groups = append(groups, &hp.HPGroups[id].Group)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Id.String() < groups[j].Id.String()
+	})
+	public := policy.Public.Allows(segType, expiry, now) &&
+		(len(groups) == 0 || hp.HPPolicies.HiddenAndPublic)
+	return groups, public
+}
+
 // ParseHPRegYaml parses the registration policies in yaml format and performs validation.
 // Hidden path groups pointed to by config file paths are loaded.
 func ParseHPRegYaml(b common.RawBytes) (*HPRegistration, error) {
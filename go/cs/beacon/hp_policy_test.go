@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/go/lib/hiddenpath"
 	"github.com/scionproto/scion/go/lib/hiddenpath/hiddenpathtest"
 	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
 )
 
 var (
@@ -104,4 +105,91 @@ func TestHPRegistrationFromYaml(t *testing.T) {
 		assert.EqualError(t, err, `GroupId key doesn't match loaded `+
 			`HPGroup key="ff00:0:110-69b5" loaded="ffaa:0:222-abcd"`)
 	})
+
+	t.Run("Invalid default action", func(t *testing.T) {
+		b, err := ioutil.ReadFile("testdata/hp_policy.yml")
+		require.NoError(t, err)
+
+		modified := strings.Replace(string(b), "DefaultAction: register",
+			"DefaultAction: ignore", 1)
+		_, err = beacon.ParseHPRegYaml([]byte(modified))
+		assert.EqualError(t, err, `Invalid default action action="ignore"`)
+	})
+}
+
+func TestHPRegistrationTargets(t *testing.T) {
+	now := time.Now()
+	soon, late := now.Add(30*time.Minute), now.Add(2*time.Hour)
+	tests := map[string]struct {
+		ifid            common.IFIDType
+		segType         proto.PathSegType
+		expiry          time.Time
+		defaultAction   string
+		hiddenAndPublic bool
+		groups          []hiddenpath.GroupId
+		public          bool
+	}{
+		"hidden and public": {
+			ifid:            2,
+			segType:         proto.PathSegType_down,
+			expiry:          soon,
+			hiddenAndPublic: true,
+			groups:          []hiddenpath.GroupId{id69b5, idabcd},
+			public:          true,
+		},
+		"hidden only": {
+			ifid:    2,
+			segType: proto.PathSegType_up,
+			expiry:  soon,
+			groups:  []hiddenpath.GroupId{id69b5, idabcd},
+		},
+		"public only": {
+			ifid:    3,
+			segType: proto.PathSegType_down,
+			expiry:  soon,
+			public:  true,
+		},
+		"expiration too late": {
+			ifid:            2,
+			segType:         proto.PathSegType_down,
+			expiry:          late,
+			hiddenAndPublic: true,
+		},
+		"core segment": {
+			ifid:            2,
+			segType:         proto.PathSegType_core,
+			expiry:          soon,
+			hiddenAndPublic: true,
+		},
+		"default register": {
+			ifid:          5,
+			segType:       proto.PathSegType_down,
+			expiry:        late,
+			defaultAction: beacon.HPDefaultActionRegister,
+			public:        true,
+		},
+		"default drop": {
+			ifid:          5,
+			segType:       proto.PathSegType_down,
+			expiry:        late,
+			defaultAction: beacon.HPDefaultActionDrop,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := beacon.LoadHPRegFromYaml("testdata/hp_policy.yml")
+			require.NoError(t, err)
+			r.HPPolicies.HiddenAndPublic = test.hiddenAndPublic
+			if test.defaultAction != "" {
+				r.HPPolicies.DefaultAction = test.defaultAction
+			}
+			groups, public := r.Targets(test.ifid, test.segType, test.expiry, now)
+			var ids []hiddenpath.GroupId
+			for _, g := range groups {
+				ids = append(ids, g.Id)
+			}
+			assert.Equal(t, test.groups, ids)
+			assert.Equal(t, test.public, public)
+		})
+	}
 }
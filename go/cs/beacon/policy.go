@@ -21,6 +21,8 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/spath"
 )
 
@@ -80,6 +82,11 @@ func (p *Policies) Validate() error {
 		return common.NewBasicError("Invalid policy type", nil,
 			"expected", DownRegPolicy, "actual", p.DownReg.Type)
 	}
+	for _, policy := range []*Policy{&p.Prop, &p.UpReg, &p.DownReg} {
+		if err := policy.validateAlgorithm(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -142,6 +149,11 @@ func (p *CorePolicies) Validate() error {
 		return common.NewBasicError("Invalid policy type", nil,
 			"expected", CoreRegPolicy, "actual", p.CoreReg.Type)
 	}
+	for _, policy := range []*Policy{&p.Prop, &p.CoreReg} {
+		if err := policy.validateAlgorithm(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -188,6 +200,9 @@ type Policy struct {
 	Filter Filter `yaml:"Filter"`
 	// Type is the policy type.
 	Type PolicyType `yaml:"Type"`
+	// SelectionAlgorithm is the algorithm that selects the best beacons from
+	// the candidate set. (default Shortest)
+	SelectionAlgorithm SelectionAlgorithmType `yaml:"SelectionAlgorithm"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -202,6 +217,9 @@ func (p *Policy) InitDefaults() {
 		m := DefaultMaxExpTime
 		p.MaxExpTime = &m
 	}
+	if p.SelectionAlgorithm == "" {
+		p.SelectionAlgorithm = ShortestSelection
+	}
 	p.Filter.InitDefaults()
 }
 
@@ -212,9 +230,26 @@ func (p *Policy) initDefaults(t PolicyType) error {
 			"expected", t, "actual", p.Type)
 	}
 	p.Type = t
+	return p.validateAlgorithm()
+}
+
+// validateAlgorithm returns an error if the selection algorithm is unknown.
+func (p *Policy) validateAlgorithm() error {
+	if _, ok := selectionAlgorithms[p.SelectionAlgorithm]; !ok {
+		return common.NewBasicError("Unknown selection algorithm", nil,
+			"policy", p.Type, "algorithm", p.SelectionAlgorithm)
+	}
 	return nil
 }
 
+// algorithm returns the selection algorithm of the policy.
+func (p *Policy) algorithm() selectionAlgorithm {
+	if algo, ok := selectionAlgorithms[p.SelectionAlgorithm]; ok {
+		return algo
+	}
+	return baseAlgo{}
+}
+
 // ParsePolicyYaml parses the policy in yaml format and initializes the default values.
 func ParsePolicyYaml(b common.RawBytes, t PolicyType) (*Policy, error) {
 	p := &Policy{}
@@ -241,12 +276,67 @@ func LoadPolicyFromYaml(path string, t PolicyType) (*Policy, error) {
 type Filter struct {
 	// MaxHopsLength is the maximum number of hops a segment can have.
 	MaxHopsLength int `yaml:"MaxHopsLength"`
-	// ASBlackList contains all ASes that may not appear in a segment.
+	// ASBlackList contains all ASes that may not appear in a segment. Only
+	// the AS number is matched, use DenyList to match the full ISD-AS.
 	AsBlackList []addr.AS `yaml:"AsBlackList"`
 	// IsdBlackList contains all ISD that may not appear in a segment.
 	IsdBlackList []addr.ISD `yaml:"IsdBlackList"`
 	// AllowIsdLoop indicates whether ISD loops should not be filtered.
 	AllowIsdLoop *bool `yaml:"AllowIsdLoop"`
+	// HopFilter contains the hop rules that apply to all beacons.
+	HopFilter `yaml:",inline"`
+	// InterfaceFilters contains hop rules that only apply to beacons that
+	// are received or propagated on specific interfaces.
+	InterfaceFilters []InterfaceFilter `yaml:"InterfaceFilters"`
+}
+
+// HopFilter filters beacons based on the hops of the segment. The hops are
+// matched with hop predicates, see doc/PathPolicy.md. The interfaces of a hop
+// are its ingress and egress interface in construction direction.
+type HopFilter struct {
+	// AllowList contains the hops that may appear in a segment. If it is not
+	// empty, every hop of the segment must match at least one entry.
+	AllowList []pathpol.HopPredicate `yaml:"AllowList"`
+	// DenyList contains the hops that may not appear in a segment.
+	DenyList []pathpol.HopPredicate `yaml:"DenyList"`
+	// DenyTransit contains the pairs of hops that may not be adjacent in a
+	// segment, in either order.
+	DenyTransit []TransitPair `yaml:"DenyTransit"`
+}
+
+// InterfaceFilter is a hop filter that only applies to beacons that are
+// received on one of the listed local ingress interfaces, or propagated on one
+// of the listed local egress interfaces. If both are listed, both must match.
+type InterfaceFilter struct {
+	// Interfaces are the local ingress interfaces the filter applies to. If
+	// empty, the ingress interface is not restricted.
+	Interfaces []common.IFIDType `yaml:"Interfaces"`
+	// EgressInterfaces are the local egress interfaces the filter applies to.
+	// The egress interface is only known when a beacon is propagated, thus
+	// these filters are applied by ApplyEgress and ignored by Apply.
+	EgressInterfaces []common.IFIDType `yaml:"EgressInterfaces"`
+	HopFilter        `yaml:",inline"`
+}
+
+// TransitPair is a pair of hop predicates. In YAML, it is written as a list
+// of exactly two hop predicates.
+type TransitPair struct {
+	A pathpol.HopPredicate
+	B pathpol.HopPredicate
+}
+
+// UnmarshalYAML parses the pair from a list of two hop predicates.
+func (p *TransitPair) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var hps []pathpol.HopPredicate
+	if err := unmarshal(&hps); err != nil {
+		return err
+	}
+	if len(hps) != 2 {
+		return common.NewBasicError("Transit pair must have two entries", nil,
+			"actual", len(hps))
+	}
+	p.A, p.B = hps[0], hps[1]
+	return nil
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -282,9 +372,111 @@ func (f Filter) Apply(beacon Beacon) error {
 			}
 		}
 	}
+	if err := f.HopFilter.apply(beacon); err != nil {
+		return err
+	}
+	for _, intfFilter := range f.InterfaceFilters {
+		if len(intfFilter.EgressInterfaces) > 0 || !intfFilter.appliesTo(beacon.InIfId, 0) {
+			continue
+		}
+		if err := intfFilter.apply(beacon); err != nil {
+			return common.NewBasicError("Filtered by interface filter", err,
+				"ingress", beacon.InIfId)
+		}
+	}
 	return nil
 }
 
+// ApplyEgress returns an error if the beacon is filtered by one of the
+// interface filters that are scoped to the egress interface the beacon is
+// propagated on. The remaining rules are checked by Apply.
+func (f Filter) ApplyEgress(beacon Beacon, egress common.IFIDType) error {
+	for _, intfFilter := range f.InterfaceFilters {
+		if len(intfFilter.EgressInterfaces) == 0 ||
+			!intfFilter.appliesTo(beacon.InIfId, egress) {
+			continue
+		}
+		if err := intfFilter.apply(beacon); err != nil {
+			return common.NewBasicError("Filtered by interface filter", err,
+				"ingress", beacon.InIfId, "egress", egress)
+		}
+	}
+	return nil
+}
+
+// apply returns an error if the beacon is filtered.
+func (f HopFilter) apply(beacon Beacon) error {
+	var prev *seg.ASEntry
+	for _, asEntry := range beacon.Segment.ASEntries {
+		ia, in, eg := hop(asEntry)
+		if len(f.AllowList) > 0 && !matchesAny(f.AllowList, ia, in, eg) {
+			return common.NewBasicError("Hop not allowed", nil, "ia", ia,
+				"ingress", in, "egress", eg)
+		}
+		if matchesAny(f.DenyList, ia, in, eg) {
+			return common.NewBasicError("Contains denied hop", nil, "ia", ia,
+				"ingress", in, "egress", eg)
+		}
+		if prev != nil {
+			pia, pin, peg := hop(prev)
+			for _, pair := range f.DenyTransit {
+				if (pair.A.MatchesHop(pia, pin, peg) && pair.B.MatchesHop(ia, in, eg)) ||
+					(pair.B.MatchesHop(pia, pin, peg) && pair.A.MatchesHop(ia, in, eg)) {
+
+					return common.NewBasicError("Contains denied transit", nil,
+						"first", pia, "second", ia)
+				}
+			}
+		}
+		prev = asEntry
+	}
+	return nil
+}
+
+// appliesTo indicates whether the filter applies to beacons received on the
+// given ingress interface and propagated on the given egress interface. An
+// egress interface of 0 indicates that the beacon is not propagated.
+func (f InterfaceFilter) appliesTo(ingress, egress common.IFIDType) bool {
+	if len(f.Interfaces) == 0 && len(f.EgressInterfaces) == 0 {
+		return false
+	}
+	if len(f.Interfaces) > 0 && !containsIfID(f.Interfaces, ingress) {
+		return false
+	}
+	if len(f.EgressInterfaces) > 0 && !containsIfID(f.EgressInterfaces, egress) {
+		return false
+	}
+	return true
+}
+
+func containsIfID(ifids []common.IFIDType, ifid common.IFIDType) bool {
+	for _, id := range ifids {
+		if id == ifid {
+			return true
+		}
+	}
+	return false
+}
+
+// hop returns the ISD-AS and the interfaces of the AS entry in construction
+// direction. If the AS entry has no hop entries, the interfaces are 0.
+func hop(asEntry *seg.ASEntry) (addr.IA, common.IFIDType, common.IFIDType) {
+	if len(asEntry.HopEntries) == 0 {
+		return asEntry.IA(), 0, 0
+	}
+	hf := asEntry.HopEntries[0].HopField
+	return asEntry.IA(), common.IFIDType(hf.ConsIngress), common.IFIDType(hf.ConsEgress)
+}
+
+func matchesAny(hps []pathpol.HopPredicate, ia addr.IA, in, eg common.IFIDType) bool {
+	for _, hp := range hps {
+		if hp.MatchesHop(ia, in, eg) {
+			return true
+		}
+	}
+	return false
+}
+
 // FilterLoop returns an error if the beacon contains an AS or ISD loop. If ISD
 // loops are allowed, an error is returned only on AS loops.
 func FilterLoop(beacon Beacon, next addr.IA, allowIsdLoop bool) error {
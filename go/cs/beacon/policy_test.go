@@ -18,10 +18,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/cs/beacon"
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
@@ -76,6 +79,45 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 			assert.Equal(t, []addr.AS{ia110.A, ia111.A}, p.Filter.AsBlackList)
 			assert.Equal(t, []addr.ISD{1, 2, 3}, p.Filter.IsdBlackList)
 			assert.True(t, *p.Filter.AllowIsdLoop)
+			assert.Equal(t, beacon.LatencySelection, p.SelectionAlgorithm)
+		})
+	}
+}
+
+func TestParsePolicyYamlSelectionAlgorithm(t *testing.T) {
+	tests := map[string]struct {
+		Yaml         string
+		Expected     beacon.SelectionAlgorithmType
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"default": {
+			Yaml:         "BestSetSize: 6",
+			Expected:     beacon.ShortestSelection,
+			ErrAssertion: assert.NoError,
+		},
+		"shortest": {
+			Yaml:         "SelectionAlgorithm: Shortest",
+			Expected:     beacon.ShortestSelection,
+			ErrAssertion: assert.NoError,
+		},
+		"latency": {
+			Yaml:         "SelectionAlgorithm: Latency",
+			Expected:     beacon.LatencySelection,
+			ErrAssertion: assert.NoError,
+		},
+		"unknown": {
+			Yaml:         "SelectionAlgorithm: Random",
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := beacon.ParsePolicyYaml([]byte(test.Yaml), beacon.PropPolicy)
+			test.ErrAssertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.Expected, p.SelectionAlgorithm)
 		})
 	}
 }
@@ -155,6 +197,132 @@ func TestFilterApply(t *testing.T) {
 	}
 }
 
+func TestHopFilterApply(t *testing.T) {
+	// Beacon 1-ff00:0:110 (1) -> (2) 1-ff00:0:111 (3) -> (4) 1-ff00:0:112 (5) -> local.
+	b := newTestBeaconWithIfs(6,
+		testHop{IA: ia110, Egress: 1},
+		testHop{IA: ia111, Ingress: 2, Egress: 3},
+		testHop{IA: ia112, Ingress: 4, Egress: 5},
+	)
+	testCases := map[string]struct {
+		Filter       beacon.HopFilter
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"empty": {
+			ErrAssertion: assert.NoError,
+		},
+		"allow list matches all hops": {
+			Filter:       beacon.HopFilter{AllowList: hps(t, "1")},
+			ErrAssertion: assert.NoError,
+		},
+		"allow list misses hop": {
+			Filter:       beacon.HopFilter{AllowList: hps(t, "1-ff00:0:110", "1-ff00:0:111")},
+			ErrAssertion: assert.Error,
+		},
+		"deny list full ISD-AS": {
+			Filter:       beacon.HopFilter{DenyList: hps(t, "1-ff00:0:111")},
+			ErrAssertion: assert.Error,
+		},
+		"deny list other ISD": {
+			Filter:       beacon.HopFilter{DenyList: hps(t, "2-ff00:0:111")},
+			ErrAssertion: assert.NoError,
+		},
+		"deny list ingress interface": {
+			Filter:       beacon.HopFilter{DenyList: hps(t, "1-ff00:0:111#2,0")},
+			ErrAssertion: assert.Error,
+		},
+		"deny list egress interface mismatch": {
+			Filter:       beacon.HopFilter{DenyList: hps(t, "1-ff00:0:111#0,2")},
+			ErrAssertion: assert.NoError,
+		},
+		"deny transit": {
+			Filter: beacon.HopFilter{DenyTransit: []beacon.TransitPair{
+				{A: hp(t, "1-ff00:0:111"), B: hp(t, "1-ff00:0:112")},
+			}},
+			ErrAssertion: assert.Error,
+		},
+		"deny transit reversed": {
+			Filter: beacon.HopFilter{DenyTransit: []beacon.TransitPair{
+				{A: hp(t, "1-ff00:0:112"), B: hp(t, "1-ff00:0:111")},
+			}},
+			ErrAssertion: assert.Error,
+		},
+		"deny transit not adjacent": {
+			Filter: beacon.HopFilter{DenyTransit: []beacon.TransitPair{
+				{A: hp(t, "1-ff00:0:110"), B: hp(t, "1-ff00:0:112")},
+			}},
+			ErrAssertion: assert.NoError,
+		},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			f := &beacon.Filter{
+				MaxHopsLength: 8,
+				AllowIsdLoop:  &true_val,
+				HopFilter:     test.Filter,
+			}
+			test.ErrAssertion(t, f.Apply(b))
+		})
+	}
+	t.Run("interface filter", func(t *testing.T) {
+		f := &beacon.Filter{
+			MaxHopsLength: 8,
+			AllowIsdLoop:  &true_val,
+			InterfaceFilters: []beacon.InterfaceFilter{
+				{
+					Interfaces: []common.IFIDType{7},
+					HopFilter:  beacon.HopFilter{DenyList: hps(t, "1-ff00:0:111")},
+				},
+			},
+		}
+		assert.NoError(t, f.Apply(b))
+		f.InterfaceFilters[0].Interfaces = append(f.InterfaceFilters[0].Interfaces, 6)
+		assert.Error(t, f.Apply(b))
+	})
+	t.Run("egress interface filter", func(t *testing.T) {
+		f := &beacon.Filter{
+			MaxHopsLength: 8,
+			AllowIsdLoop:  &true_val,
+			InterfaceFilters: []beacon.InterfaceFilter{
+				{
+					EgressInterfaces: []common.IFIDType{7},
+					HopFilter:        beacon.HopFilter{DenyList: hps(t, "1-ff00:0:111")},
+				},
+			},
+		}
+		assert.NoError(t, f.Apply(b), "egress filters are not applied on insertion")
+		assert.NoError(t, f.ApplyEgress(b, 8))
+		assert.Error(t, f.ApplyEgress(b, 7))
+		f.InterfaceFilters[0].Interfaces = []common.IFIDType{5}
+		assert.NoError(t, f.ApplyEgress(b, 7), "ingress does not match")
+		f.InterfaceFilters[0].Interfaces = []common.IFIDType{5, 6}
+		assert.Error(t, f.ApplyEgress(b, 7))
+	})
+}
+
+func TestParseHopFilterYaml(t *testing.T) {
+	p, err := beacon.LoadPolicyFromYaml("testdata/hopFilterPolicy.yml", beacon.PropPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, hps(t, "1-ff00:0:110", "1-ff00:0:111#2,0"), p.Filter.AllowList)
+	assert.Equal(t, hps(t, "2-0"), p.Filter.DenyList)
+	assert.Equal(t, []beacon.TransitPair{
+		{A: hp(t, "1-ff00:0:110"), B: hp(t, "1-ff00:0:112")},
+	}, p.Filter.DenyTransit)
+	require.Len(t, p.Filter.InterfaceFilters, 2)
+	assert.Equal(t, []common.IFIDType{1, 2}, p.Filter.InterfaceFilters[0].Interfaces)
+	assert.Equal(t, hps(t, "1-ff00:0:113"), p.Filter.InterfaceFilters[0].DenyList)
+	assert.Empty(t, p.Filter.InterfaceFilters[1].Interfaces)
+	assert.Equal(t, []common.IFIDType{3}, p.Filter.InterfaceFilters[1].EgressInterfaces)
+	assert.Equal(t, hps(t, "1-ff00:0:114"), p.Filter.InterfaceFilters[1].DenyList)
+
+	_, err = beacon.ParsePolicyYaml([]byte(`
+Filter:
+  DenyTransit:
+    - ["1-ff00:0:110"]
+`), beacon.PropPolicy)
+	assert.Error(t, err)
+}
+
 func TestFilterLoop(t *testing.T) {
 	testCases := []struct {
 		Name         string
@@ -199,6 +367,42 @@ func TestFilterLoop(t *testing.T) {
 	}
 }
 
+type testHop struct {
+	IA      addr.IA
+	Ingress uint16
+	Egress  uint16
+}
+
+func newTestBeaconWithIfs(inIfId common.IFIDType, hops ...testHop) beacon.Beacon {
+	var entries []*seg.ASEntry
+	for _, hop := range hops {
+		entries = append(entries, &seg.ASEntry{
+			RawIA: hop.IA.IAInt(),
+			HopEntries: []*seg.HopEntry{
+				{HopField: seg.HopField{ConsIngress: hop.Ingress, ConsEgress: hop.Egress}},
+			},
+		})
+	}
+	return beacon.Beacon{
+		Segment: &seg.PathSegment{ASEntries: entries},
+		InIfId:  inIfId,
+	}
+}
+
+func hp(t *testing.T, str string) pathpol.HopPredicate {
+	hp, err := pathpol.HopPredicateFromString(str)
+	require.NoError(t, err)
+	return *hp
+}
+
+func hps(t *testing.T, strs ...string) []pathpol.HopPredicate {
+	var res []pathpol.HopPredicate
+	for _, str := range strs {
+		res = append(res, hp(t, str))
+	}
+	return res
+}
+
 func newTestBeacon(hops ...addr.IA) beacon.Beacon {
 	var entries []*seg.ASEntry
 	for _, hop := range hops {
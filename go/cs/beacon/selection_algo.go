@@ -14,7 +14,30 @@
 
 package beacon
 
-import "math"
+import (
+	"math"
+	"sort"
+)
+
+// SelectionAlgorithmType is the name of a beacon selection algorithm.
+type SelectionAlgorithmType string
+
+const (
+	// ShortestSelection selects the shortest beacons, and one beacon that is
+	// most diverse from the shortest one.
+	ShortestSelection SelectionAlgorithmType = "Shortest"
+	// LatencySelection selects the beacons with the lowest latency according
+	// to their StaticInfo extension, and one beacon that is most diverse from
+	// the one with the lowest latency.
+	LatencySelection SelectionAlgorithmType = "Latency"
+)
+
+// selectionAlgorithms contains the implementations of the selection
+// algorithms that can be configured in a policy.
+var selectionAlgorithms = map[SelectionAlgorithmType]selectionAlgorithm{
+	ShortestSelection: baseAlgo{},
+	LatencySelection:  latencyAlgo{},
+}
 
 type selectionAlgorithm interface {
 	// SelectAndServe selects the n best beacons from the beacons channel and
@@ -110,6 +133,111 @@ func (baseAlgo) serveMostDiverse(beacons <-chan BeaconOrErr, results chan<- Beac
 	results <- BeaconOrErr{Beacon: first}
 }
 
+// latencyAlgo implements a selection algorithm that optimizes for low latency
+// and high bandwidth, as announced in the StaticInfo extension of the beacons,
+// but also tries to achieve some path diversity.
+type latencyAlgo struct{}
+
+// SelectAndServe reads all beacons from the channel and ranks them by
+// latency, bottleneck bandwidth and length, in this order. Beacons that do
+// not carry StaticInfo in every AS entry are ranked after the ones that do.
+// The channel is filled with the k-1 best ranked beacons. The last beacon is
+// the most diverse beacon from the remaining beacons compared to the best
+// beacon, if the diversity exceeds what has already been served. Otherwise,
+// it is the best ranked remaining beacon. Errors are served as they are read.
+func (latencyAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	var scored []scoredBeacon
+	for res := range beacons {
+		if res.Err != nil {
+			results <- res
+			continue
+		}
+		scored = append(scored, newScoredBeacon(res.Beacon))
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].less(scored[j])
+	})
+	if len(scored) == 0 || resultSize <= 0 {
+		return
+	}
+	if resultSize == 1 {
+		results <- BeaconOrErr{Beacon: scored[0].beacon}
+		return
+	}
+	// Create shallow copy to avoid data race.
+	best := Beacon{
+		Segment: scored[0].beacon.Segment.ShallowCopy(),
+		InIfId:  scored[0].beacon.InIfId,
+	}
+	var servedDiversity int
+	i := 0
+	for ; i < len(scored) && i < resultSize-1; i++ {
+		servedDiversity = max(servedDiversity, best.Diversity(scored[i].beacon))
+		results <- BeaconOrErr{Beacon: scored[i].beacon}
+	}
+	if i == len(scored) {
+		return
+	}
+	remaining := scored[i:]
+	diverse, maxDiversity := remaining[0].beacon, -1
+	for _, s := range remaining {
+		if diversity := best.Diversity(s.beacon); diversity > maxDiversity {
+			diverse, maxDiversity = s.beacon, diversity
+		}
+	}
+	if maxDiversity > servedDiversity {
+		results <- BeaconOrErr{Beacon: diverse}
+		return
+	}
+	results <- BeaconOrErr{Beacon: remaining[0].beacon}
+}
+
+// scoredBeacon is a beacon with the metrics that are accumulated from the
+// StaticInfo extensions of its AS entries.
+type scoredBeacon struct {
+	beacon Beacon
+	// complete indicates whether all AS entries carry StaticInfo.
+	complete bool
+	// latency is the accumulated latency in milliseconds.
+	latency int
+	// bandwidth is the bottleneck bandwidth in Kbit/s. Zero if unknown.
+	bandwidth uint32
+}
+
+func newScoredBeacon(b Beacon) scoredBeacon {
+	s := scoredBeacon{beacon: b, complete: len(b.Segment.ASEntries) > 0}
+	for _, asEntry := range b.Segment.ASEntries {
+		info := asEntry.Exts.StaticInfo
+		if info == nil {
+			s.complete = false
+			continue
+		}
+		s.latency += int(info.Latency.IngressToEgressLatency) + int(info.Latency.Egresslatency)
+		for _, bw := range []uint32{info.Bandwidth.IngressToEgressBW, info.Bandwidth.EgressBW} {
+			if bw != 0 && (s.bandwidth == 0 || bw < s.bandwidth) {
+				s.bandwidth = bw
+			}
+		}
+	}
+	return s
+}
+
+// less indicates whether s is ranked before other.
+func (s scoredBeacon) less(other scoredBeacon) bool {
+	if s.complete != other.complete {
+		return s.complete
+	}
+	if s.complete && s.latency != other.latency {
+		return s.latency < other.latency
+	}
+	if s.bandwidth != other.bandwidth {
+		return s.bandwidth > other.bandwidth
+	}
+	return len(s.beacon.Segment.ASEntries) < len(other.beacon.Segment.ASEntries)
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
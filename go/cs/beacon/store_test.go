@@ -195,6 +195,89 @@ func testStoreSelection(t *testing.T,
 	}
 }
 
+func TestStoreLatencySelection(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_210_X_220_X
+	short := withStaticInfo(testBeaconOrErr(g, graph.If_130_A_110_X, graph.If_110_X_210_X,
+		stub), 100, 1000)
+	fast := withStaticInfo(testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_B_220_X,
+		graph.If_220_X_210_X, stub), 1, 1000)
+	slowerSameLength := withStaticInfo(testBeaconOrErr(g, graph.If_130_B_111_A,
+		graph.If_111_B_120_X, graph.If_120_B_220_X, graph.If_220_X_210_X, stub), 5, 1000)
+	noStaticInfo := testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_A_110_X,
+		graph.If_110_X_210_X, stub)
+	beaconErr := beacon.BeaconOrErr{Err: errors.New("Fail")}
+
+	tests := map[string]struct {
+		Results   []beacon.BeaconOrErr
+		BestSize  int
+		Expected  []beacon.BeaconOrErr
+		ExpectErr bool
+	}{
+		"single best beacon": {
+			Results:  []beacon.BeaconOrErr{short, noStaticInfo, fast, slowerSameLength},
+			BestSize: 1,
+			Expected: []beacon.BeaconOrErr{fast},
+		},
+		"ranked by latency, missing static info last": {
+			Results:  []beacon.BeaconOrErr{noStaticInfo, short, slowerSameLength, fast},
+			BestSize: 4,
+			Expected: []beacon.BeaconOrErr{fast, slowerSameLength, short, noStaticInfo},
+		},
+		"errors are forwarded": {
+			Results:   []beacon.BeaconOrErr{short, beaconErr, fast},
+			BestSize:  2,
+			Expected:  []beacon.BeaconOrErr{fast, short},
+			ExpectErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			db := mock_beacon.NewMockDB(mctrl)
+			policies := beacon.Policies{
+				Prop: beacon.Policy{
+					BestSetSize:        test.BestSize,
+					SelectionAlgorithm: beacon.LatencySelection,
+				},
+			}
+			store, err := beacon.NewBeaconStore(policies, db)
+			require.NoError(t, err)
+			db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(),
+				addr.IA{}).DoAndReturn(
+				func(_ ...interface{}) (<-chan beacon.BeaconOrErr, error) {
+					results := make(chan beacon.BeaconOrErr, len(test.Results))
+					defer close(results)
+					for _, res := range test.Results {
+						results <- res
+					}
+					return results, nil
+				},
+			)
+			res, err := store.BeaconsToPropagate(context.Background())
+			require.NoError(t, err)
+			var served []beacon.BeaconOrErr
+			var errs int
+			for bOrErr := range res {
+				if bOrErr.Err != nil {
+					errs++
+					continue
+				}
+				served = append(served, bOrErr)
+			}
+			require.Equal(t, len(test.Expected), len(served))
+			for i := range test.Expected {
+				require.Equal(t, test.Expected[i].Beacon.Segment, served[i].Beacon.Segment)
+			}
+			require.Equal(t, test.ExpectErr, errs > 0)
+		})
+	}
+}
+
 func TestCoreStoreSegmentsToRegister(t *testing.T) {
 	testCoreStoreSelection(t, func(store *beacon.CoreStore) (<-chan beacon.BeaconOrErr, error) {
 		return store.SegmentsToRegister(context.Background(), proto.PathSegType_core)
@@ -380,6 +463,18 @@ func testBeaconOrErr(g *graph.Graph, desc ...common.IFIDType) beacon.BeaconOrErr
 	}
 }
 
+// withStaticInfo adds a StaticInfo extension with the given egress latency
+// and bandwidth to every AS entry of the beacon.
+func withStaticInfo(b beacon.BeaconOrErr, latency uint16, bw uint32) beacon.BeaconOrErr {
+	for _, asEntry := range b.Beacon.Segment.ASEntries {
+		asEntry.Exts.StaticInfo = &seg.StaticInfoExtn{
+			Latency:   seg.LatencyInfo{Egresslatency: latency},
+			Bandwidth: seg.BandwidthInfo{EgressBW: bw},
+		}
+	}
+	return b
+}
+
 func testBeacon(g *graph.Graph, ifids []common.IFIDType) *seg.PathSegment {
 	pseg := g.Beacon(ifids)
 	pseg.RawASEntries = pseg.RawASEntries[:len(pseg.RawASEntries)-1]
@@ -64,6 +64,9 @@ type LegacyExtender struct {
 	Task string
 	// StaticInfo contains the configuration used for the StaticInfo Extension.
 	StaticInfo func() *StaticInfoCfg
+	// HiddenPathSeg indicates that the created AS entries carry the hidden path
+	// segment extension.
+	HiddenPathSeg bool
 }
 
 // Extend extends the beacon with hop fields of the old format.
@@ -96,6 +99,9 @@ func (s *LegacyExtender) Extend(ctx context.Context, pseg *seg.PathSegment,
 		staticInfo := static.generateStaticinfo(staticInfoPeers, egress, ingress)
 		asEntry.Exts.StaticInfo = &staticInfo
 	}
+	if s.HiddenPathSeg {
+		asEntry.Exts.HiddenPathSeg = seg.NewHiddenPathSegExtn()
+	}
 	if err := pseg.AddASEntry(ctx, asEntry, s.Signer); err != nil {
 		return err
 	}
@@ -216,6 +222,9 @@ type DefaultExtender struct {
 	Task string
 	// StaticInfo contains the configuration used for the StaticInfo Extension.
 	StaticInfo func() *StaticInfoCfg
+	// HiddenPathSeg indicates that the created AS entries carry the hidden path
+	// segment extension.
+	HiddenPathSeg bool
 }
 
 // Extend extends the beacon with hop fields of the old format.
@@ -244,6 +253,9 @@ func (s *DefaultExtender) Extend(ctx context.Context, pseg *seg.PathSegment,
 		staticInfo := static.generateStaticinfo(staticInfoPeers, egress, ingress)
 		asEntry.Exts.StaticInfo = &staticInfo
 	}
+	if s.HiddenPathSeg {
+		asEntry.Exts.HiddenPathSeg = seg.NewHiddenPathSegExtn()
+	}
 	if err := pseg.AddASEntry(ctx, asEntry, s.Signer); err != nil {
 		return err
 	}
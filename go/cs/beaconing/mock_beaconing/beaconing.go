@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/cs/beaconing (interfaces: BeaconInserter,BeaconProvider,BeaconSender,RPC,SegmentProvider,SegmentStore)
+// Source: github.com/scionproto/scion/go/cs/beaconing (interfaces: BeaconInserter,BeaconProvider,BeaconSender,HiddenRPC,RPC,SegmentProvider,SegmentStore)
 
 // Package mock_beaconing is a generated GoMock package.
 package mock_beaconing
@@ -12,6 +12,7 @@ import (
 	common "github.com/scionproto/scion/go/lib/common"
 	ctrl "github.com/scionproto/scion/go/lib/ctrl"
 	seg "github.com/scionproto/scion/go/lib/ctrl/seg"
+	hiddenpath "github.com/scionproto/scion/go/lib/hiddenpath"
 	seghandler "github.com/scionproto/scion/go/lib/infra/modules/seghandler"
 	proto "github.com/scionproto/scion/go/proto"
 	net "net"
@@ -145,6 +146,43 @@ func (mr *MockBeaconSenderMockRecorder) Send(arg0, arg1, arg2, arg3, arg4, arg5
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockBeaconSender)(nil).Send), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// MockHiddenRPC is a mock of HiddenRPC interface
+type MockHiddenRPC struct {
+	ctrl     *gomock.Controller
+	recorder *MockHiddenRPCMockRecorder
+}
+
+// MockHiddenRPCMockRecorder is the mock recorder for MockHiddenRPC
+type MockHiddenRPCMockRecorder struct {
+	mock *MockHiddenRPC
+}
+
+// NewMockHiddenRPC creates a new mock instance
+func NewMockHiddenRPC(ctrl *gomock.Controller) *MockHiddenRPC {
+	mock := &MockHiddenRPC{ctrl: ctrl}
+	mock.recorder = &MockHiddenRPCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockHiddenRPC) EXPECT() *MockHiddenRPCMockRecorder {
+	return m.recorder
+}
+
+// RegisterHiddenSegment mocks base method
+func (m *MockHiddenRPC) RegisterHiddenSegment(arg0 context.Context, arg1 hiddenpath.GroupId, arg2 seg.Meta, arg3 net.Addr) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterHiddenSegment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterHiddenSegment indicates an expected call of RegisterHiddenSegment
+func (mr *MockHiddenRPCMockRecorder) RegisterHiddenSegment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterHiddenSegment", reflect.TypeOf((*MockHiddenRPC)(nil).RegisterHiddenSegment), arg0, arg1, arg2, arg3)
+}
+
 // MockRPC is a mock of RPC interface
 type MockRPC struct {
 	ctrl     *gomock.Controller
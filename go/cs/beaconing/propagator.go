@@ -41,7 +41,8 @@ var _ periodic.Task = (*Propagator)(nil)
 // Propagator forwards beacons to neighboring ASes. In a core AS, the beacons
 // are propagated to neighbors on core links. In a non-core AS, the beacons are
 // forwarded on child links. Selection of the beacons is handled by the beacon
-// provider, the propagator only filters AS loops.
+// provider, the propagator only filters AS loops and applies the egress
+// interface filters.
 type Propagator struct {
 	Extender     Extender
 	BeaconSender BeaconSender
@@ -51,6 +52,10 @@ type Propagator struct {
 	Intfs        *ifstate.Interfaces
 	Core         bool
 	AllowIsdLoop bool
+	// Filter is the propagation filter. The interface filters that are scoped
+	// to egress interfaces are applied to the beacons per egress interface. If
+	// nil, no egress filtering is done.
+	Filter *beacon.Filter
 
 	// tick is mutable.
 	Tick Tick
@@ -285,6 +290,13 @@ func (p *beaconPropagator) shouldIgnore(bseg beacon.Beacon, egIfid common.IFIDTy
 		p.logger.Debug("[beaconing.Propagator] Ignoring beacon on loop", "ifid", egIfid, "err", err)
 		return true
 	}
+	if p.Filter != nil {
+		if err := p.Filter.ApplyEgress(bseg, egIfid); err != nil {
+			p.logger.Debug("[beaconing.Propagator] Ignoring filtered beacon", "ifid", egIfid,
+				"err", err)
+			return true
+		}
+	}
 	return false
 }
 
@@ -36,6 +36,8 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo/itopotest"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/xtest/graph"
@@ -277,3 +279,64 @@ func TestPropagatorRun(t *testing.T) {
 		p.Run(nil)
 	})
 }
+
+func TestPropagatorEgressFilter(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	topoProvider := itopotest.TopoProviderFromFile(t, topoCore)
+	intfs := ifstate.NewInterfaces(topoProvider.Get().IFInfoMap(), ifstate.Config{})
+	g := graph.NewDefaultGraph(mctrl)
+	// The beacon is originated in 1-ff00:0:130 and received from 1-ff00:0:120.
+	b := testBeaconOrErr(g, []common.IFIDType{graph.If_130_B_120_A, graph.If_120_A_110_X}).Beacon
+	deny130, err := pathpol.HopPredicateFromString("1-ff00:0:130")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		Filter   *beacon.Filter
+		Expected map[common.IFIDType]bool
+	}{
+		"no filter": {
+			Expected: map[common.IFIDType]bool{
+				graph.If_110_X_130_A: true,
+				graph.If_110_X_210_X: false,
+			},
+		},
+		"egress filter": {
+			Filter: &beacon.Filter{InterfaceFilters: []beacon.InterfaceFilter{{
+				EgressInterfaces: []common.IFIDType{graph.If_110_X_210_X},
+				HopFilter:        beacon.HopFilter{DenyList: []pathpol.HopPredicate{*deny130}},
+			}}},
+			Expected: map[common.IFIDType]bool{
+				graph.If_110_X_130_A: true,
+				graph.If_110_X_210_X: true,
+			},
+		},
+		"egress filter with other ingress": {
+			Filter: &beacon.Filter{InterfaceFilters: []beacon.InterfaceFilter{{
+				Interfaces:       []common.IFIDType{graph.If_110_X_130_A},
+				EgressInterfaces: []common.IFIDType{graph.If_110_X_210_X},
+				HopFilter:        beacon.HopFilter{DenyList: []pathpol.HopPredicate{*deny130}},
+			}}},
+			Expected: map[common.IFIDType]bool{
+				graph.If_110_X_130_A: true,
+				graph.If_110_X_210_X: false,
+			},
+		},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := beaconPropagator{
+				Propagator: &Propagator{
+					IA:           topoProvider.Get().IA(),
+					Intfs:        intfs,
+					AllowIsdLoop: true,
+					Filter:       test.Filter,
+				},
+				logger: log.Root(),
+			}
+			for egress, ignored := range test.Expected {
+				assert.Equal(t, ignored, p.shouldIgnore(b, egress), "egress %d", egress)
+			}
+		})
+	}
+}
@@ -27,9 +27,11 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
 	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/proto"
@@ -51,11 +53,23 @@ type RPC interface {
 	RegisterSegment(ctx context.Context, meta seg.Meta, remote net.Addr) error
 }
 
+// HiddenRPC registers the path segment for a hidden path group with the remote
+// hidden path registry.
+type HiddenRPC interface {
+	RegisterHiddenSegment(ctx context.Context, id hiddenpath.GroupId, meta seg.Meta,
+		remote net.Addr) error
+}
+
 var _ periodic.Task = (*Registrar)(nil)
 
 // Registrar is used to periodically register path segments with the appropriate
 // path servers. Core and Up segments are registered with the local path server.
 // Down segments are registered at the core.
+//
+// If a hidden path registration policy is set, up and down segments are
+// registered according to it. Segments that are registered for a hidden path
+// group are terminated with the hidden path segment extension and sent to all
+// registries of the group.
 type Registrar struct {
 	Extender Extender
 	Provider SegmentProvider
@@ -67,6 +81,15 @@ type Registrar struct {
 	Intfs    *ifstate.Interfaces
 	Type     proto.PathSegType
 
+	// HiddenPath is the hidden path registration policy. If it is nil, all
+	// segments are registered publicly.
+	HiddenPath *beacon.HPRegistration
+	// HiddenExtender terminates the segments that are registered with hidden
+	// path registries. It must set the hidden path segment extension.
+	HiddenExtender Extender
+	// HiddenRPC registers segments with hidden path registries.
+	HiddenRPC HiddenRPC
+
 	// tick is mutable.
 	Tick     Tick
 	lastSucc time.Time
@@ -124,6 +147,14 @@ func (r *Registrar) registerRemote(ctx context.Context, segments <-chan beacon.B
 		if !intfActive(r.Intfs, bOrErr.Beacon.InIfId) {
 			continue
 		}
+		groups, public := r.targets(bOrErr.Beacon)
+		if len(groups) > 0 {
+			expected++
+			r.startHidden(ctx, bOrErr.Beacon, groups, peers, s, &wg)
+		}
+		if !public {
+			continue
+		}
 		err := r.Extender.Extend(ctx, bOrErr.Beacon.Segment, bOrErr.Beacon.InIfId, 0, peers)
 		if err != nil {
 			metrics.Registrar.InternalErrorsWithType(r.Type.String()).Inc()
@@ -160,6 +191,8 @@ func (r *Registrar) registerLocal(ctx context.Context, segments <-chan beacon.Be
 	logger := log.FromCtx(ctx)
 	beacons := make(map[string]beacon.Beacon)
 	var toRegister []*seghandler.SegWithHP
+	hidden := newSummary()
+	var wg sync.WaitGroup
 	for bOrErr := range segments {
 		if bOrErr.Err != nil {
 			logger.Error("[beaconing.Registrar] Unable to get beacon", "err", bOrErr.Err)
@@ -169,6 +202,13 @@ func (r *Registrar) registerLocal(ctx context.Context, segments <-chan beacon.Be
 		if !intfActive(r.Intfs, bOrErr.Beacon.InIfId) {
 			continue
 		}
+		groups, public := r.targets(bOrErr.Beacon)
+		if len(groups) > 0 {
+			r.startHidden(ctx, bOrErr.Beacon, groups, peers, hidden, &wg)
+		}
+		if !public {
+			continue
+		}
 		err := r.Extender.Extend(ctx, bOrErr.Beacon.Segment, bOrErr.Beacon.InIfId, 0, peers)
 		if err != nil {
 			metrics.Registrar.InternalErrorsWithType(r.Type.String()).Inc()
@@ -181,6 +221,12 @@ func (r *Registrar) registerLocal(ctx context.Context, segments <-chan beacon.Be
 		})
 		beacons[bOrErr.Beacon.Segment.GetLoggingID()] = bOrErr.Beacon
 	}
+	wg.Wait()
+	if hidden.count > 0 {
+		r.lastSucc = r.Tick.now
+		logger.Debug("[beaconing.Registrar] Registered hidden beacons", "type", r.Type,
+			"count", hidden.count, "startIAs", len(hidden.srcs))
+	}
 	if len(toRegister) == 0 {
 		return nil
 	}
@@ -195,6 +241,44 @@ func (r *Registrar) registerLocal(ctx context.Context, segments <-chan beacon.Be
 	return nil
 }
 
+// targets returns the hidden path groups the beacon is registered for, and
+// whether it is registered publicly.
+func (r *Registrar) targets(b beacon.Beacon) ([]*hiddenpath.Group, bool) {
+	if r.HiddenPath == nil {
+		return nil, true
+	}
+	return r.HiddenPath.Targets(b.InIfId, r.Type, b.Segment.MaxExpiry(), r.Tick.now)
+}
+
+// startHidden terminates a copy of the beacon with the hidden path extender and
+// starts registering it with the registries of all groups. The beacon itself is
+// not modified.
+func (r *Registrar) startHidden(ctx context.Context, b beacon.Beacon,
+	groups []*hiddenpath.Group, peers []common.IFIDType, s *summary, wg *sync.WaitGroup) {
+
+	logger := log.FromCtx(ctx)
+	hidden := beacon.Beacon{Segment: b.Segment.ShallowCopy(), InIfId: b.InIfId}
+	if err := r.HiddenExtender.Extend(ctx, hidden.Segment, hidden.InIfId, 0, peers); err != nil {
+		metrics.Registrar.InternalErrorsWithType(r.Type.String()).Inc()
+		logger.Error("[beaconing.Registrar] Unable to terminate hidden beacon",
+			"beacon", b, "err", err)
+		return
+	}
+	reg := seg.Meta{Type: r.Type, Segment: hidden.Segment}
+	for _, g := range groups {
+		for _, registry := range g.Registries {
+			hr := hiddenRegistrar{
+				segType: r.Type,
+				rpc:     r.HiddenRPC,
+				summary: s,
+				wg:      wg,
+			}
+			hr.startSendSegReg(ctx, hidden, g.Id, reg,
+				&snet.SVCAddr{IA: registry, SVC: addr.SvcHPS})
+		}
+	}
+}
+
 func (r *Registrar) logSummary(logger log.Logger, s *summary) {
 	if r.Tick.passed() {
 		logger.Info("[beaconing.Registrar] Registered beacons", "type", r.Type, "count", s.count,
@@ -259,6 +343,44 @@ func (r *remoteRegistrar) startSendSegReg(ctx context.Context, bseg beacon.Beaco
 	}()
 }
 
+// hiddenRegistrar registers one segment with a hidden path registry.
+type hiddenRegistrar struct {
+	segType proto.PathSegType
+	rpc     HiddenRPC
+	summary *summary
+	wg      *sync.WaitGroup
+}
+
+// startSendSegReg adds to the wait group and starts a goroutine that sends the
+// hidden registration message to the registry.
+func (r *hiddenRegistrar) startSendSegReg(ctx context.Context, bseg beacon.Beacon,
+	id hiddenpath.GroupId, reg seg.Meta, addr net.Addr) {
+
+	r.wg.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		defer r.wg.Done()
+		logger := log.FromCtx(ctx)
+		l := metrics.HPRegistrarLabels{
+			GroupID: id.String(),
+			SegType: r.segType.String(),
+			Result:  metrics.Success,
+		}
+		if err := r.rpc.RegisterHiddenSegment(ctx, id, reg, addr); err != nil {
+			logger.Error("[beaconing.Registrar] Unable to register hidden segment",
+				"type", r.segType, "group", id, "addr", addr, "err", err)
+			l.Result = metrics.ErrSend
+			metrics.Registrar.HiddenBeacons(l).Inc()
+			return
+		}
+		r.summary.AddSrc(bseg.Segment.FirstIA())
+		r.summary.Inc()
+		metrics.Registrar.HiddenBeacons(l).Inc()
+		logger.Debug("[beaconing.Registrar] Successfully registered hidden segment",
+			"type", r.segType, "group", id, "addr", addr, "seg", bseg.Segment)
+	}()
+}
+
 func updateMetricsFromStat(s seghandler.SegStats, b map[string]beacon.Beacon, segType string) {
 	for _, id := range s.InsertedSegs {
 		metrics.Registrar.Beacons(metrics.RegistrarLabels{
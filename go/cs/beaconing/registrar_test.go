@@ -39,12 +39,15 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/hiddenpath/hiddenpathtest"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo/itopotest"
 	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
 	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/lib/xtest/graph"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/proto"
@@ -348,6 +351,143 @@ func TestRegistrarRun(t *testing.T) {
 	})
 }
 
+func TestRegistrarRunHidden(t *testing.T) {
+	mac, err := scrypto.InitMac(make(common.RawBytes, 16))
+	require.NoError(t, err)
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub := priv.Public()
+
+	groupID := hiddenpathtest.MustParseHPGroupId("ff00:0:110-69b5")
+	registries := []addr.IA{xtest.MustParseIA("1-ff00:0:111"), xtest.MustParseIA("1-ff00:0:112")}
+	beacons := [][]common.IFIDType{
+		{graph.If_120_X_111_B},
+		{graph.If_130_B_120_A, graph.If_120_X_111_B},
+	}
+	tests := map[string]struct {
+		hiddenAndPublic bool
+		publicRegs      int
+	}{
+		"hidden only": {},
+		"hidden and public": {
+			hiddenAndPublic: true,
+			publicRegs:      len(beacons),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			topoProvider := itopotest.TopoProviderFromFile(t, topoNonCore)
+			intfs := ifstate.NewInterfaces(topoProvider.Get().IFInfoMap(), ifstate.Config{})
+			segProvider := mock_beaconing.NewMockSegmentProvider(mctrl)
+			rpc := mock_beaconing.NewMockRPC(mctrl)
+			hiddenRPC := mock_beaconing.NewMockHiddenRPC(mctrl)
+
+			extender := func(hidden bool) Extender {
+				return &LegacyExtender{
+					IA:            topoProvider.Get().IA(),
+					MTU:           topoProvider.Get().MTU(),
+					Signer:        testSigner(t, priv, topoProvider.Get().IA()),
+					Intfs:         intfs,
+					MAC:           func() hash.Hash { return mac },
+					MaxExpTime:    maxExpTimeFactory(beacon.DefaultMaxExpTime),
+					StaticInfo:    func() *StaticInfoCfg { return nil },
+					HiddenPathSeg: hidden,
+				}
+			}
+			r := Registrar{
+				Extender: extender(false),
+				IA:       topoProvider.Get().IA(),
+				Signer:   testSigner(t, priv, topoProvider.Get().IA()),
+				Intfs:    intfs,
+				Tick:     NewTick(time.Hour),
+				Provider: segProvider,
+				Pather:   addrutil.LegacyPather{TopoProvider: topoProvider},
+				Type:     proto.PathSegType_down,
+				RPC:      rpc,
+				HiddenPath: &beacon.HPRegistration{
+					HPPolicies: beacon.HPPolicies{
+						DefaultAction:   beacon.HPDefaultActionDrop,
+						HiddenAndPublic: test.hiddenAndPublic,
+						Policies: map[common.IFIDType]beacon.HPPolicy{
+							graph.If_111_B_120_X: {
+								Public: beacon.RegPolicy{RegDown: true},
+								Hidden: map[hiddenpath.GroupId]beacon.RegPolicy{
+									groupID: {RegDown: true},
+								},
+							},
+						},
+					},
+					HPGroups: map[hiddenpath.GroupId]*beacon.HPGroup{
+						groupID: {
+							Group: hiddenpath.Group{Id: groupID, Registries: registries},
+						},
+					},
+				},
+				HiddenExtender: extender(true),
+				HiddenRPC:      hiddenRPC,
+			}
+			g := graph.NewDefaultGraph(mctrl)
+			segProvider.EXPECT().SegmentsToRegister(gomock.Any(),
+				proto.PathSegType_down).DoAndReturn(
+				func(_, _ interface{}) (<-chan beacon.BeaconOrErr, error) {
+					res := make(chan beacon.BeaconOrErr, len(beacons))
+					for _, desc := range beacons {
+						res <- testBeaconOrErr(g, desc)
+					}
+					close(res)
+					return res, nil
+				})
+			var mu sync.Mutex
+			var sentHidden []seg.Meta
+			var sentAddrs []addr.IA
+			hiddenRPC.EXPECT().RegisterHiddenSegment(gomock.Any(), groupID, gomock.Any(),
+				gomock.Any()).Times(len(beacons) * len(registries)).DoAndReturn(
+				func(_ context.Context, _ hiddenpath.GroupId, meta seg.Meta,
+					remote net.Addr) error {
+
+					mu.Lock()
+					defer mu.Unlock()
+					sentHidden = append(sentHidden, meta)
+					a := remote.(*snet.SVCAddr)
+					assert.Equal(t, addr.SvcHPS, a.SVC)
+					sentAddrs = append(sentAddrs, a.IA)
+					return nil
+				},
+			)
+			var sentPublic []seg.Meta
+			rpc.EXPECT().RegisterSegment(gomock.Any(), gomock.Any(),
+				gomock.Any()).Times(test.publicRegs).DoAndReturn(
+				func(_ context.Context, meta seg.Meta, _ net.Addr) error {
+					mu.Lock()
+					defer mu.Unlock()
+					sentPublic = append(sentPublic, meta)
+					return nil
+				},
+			)
+			for _, intf := range intfs.All() {
+				intf.Activate(42)
+			}
+			r.Run(context.Background())
+
+			assert.ElementsMatch(t, append(registries, registries...), sentAddrs)
+			for _, m := range sentHidden {
+				pseg := m.Segment
+				assert.NoError(t, pseg.Validate(seg.ValidateSegment))
+				assert.NoError(t, pseg.VerifyASEntry(context.Background(),
+					segVerifier{pubKey: pub}, pseg.MaxAEIdx()))
+				assert.NotNil(t, pseg.ASEntries[pseg.MaxAEIdx()].Exts.HiddenPathSeg)
+			}
+			for _, m := range sentPublic {
+				pseg := m.Segment
+				assert.NoError(t, pseg.Validate(seg.ValidateSegment))
+				assert.Nil(t, pseg.ASEntries[pseg.MaxAEIdx()].Exts.HiddenPathSeg)
+			}
+		})
+	}
+}
+
 func testBeaconOrErr(g *graph.Graph, desc []common.IFIDType) beacon.BeaconOrErr {
 	b := testBeacon(g, desc)
 	asEntry := b.Segment.ASEntries[b.Segment.MaxAEIdx()]
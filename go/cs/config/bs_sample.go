@@ -65,7 +65,7 @@ up_registration = ""
 down_registration = ""
 
 # The file path for the hidden path registration policy. In case of the empty string,
-# no hidden path functionality is used.
+# no hidden path functionality is used. In a core beacon server, this field is ignored.
 # (default "")
 hidden_path_registration = ""
 `
@@ -16,6 +16,7 @@
 package config
 
 import (
+	"fmt"
 	"io"
 	"time"
 
@@ -58,6 +59,14 @@ const (
 	DefaultQueryInterval = 5 * time.Minute
 	// DefaultMaxASValidity is the default validity period for renewed AS certificates.
 	DefaultMaxASValidity = 3 * 24 * time.Hour
+	// DefaultColibriDelta is the default fraction of the free bandwidth that can be granted
+	// to a COLIBRI segment reservation.
+	DefaultColibriDelta = 1.0
+	// DefaultDRKeyEpochDuration is the default duration of the DRKey epochs.
+	DefaultDRKeyEpochDuration = 24 * time.Hour
+	// DefaultASRenewalInterval is the default interval between checking
+	// whether the AS certificate chain needs to be renewed.
+	DefaultASRenewalInterval = 10 * time.Minute
 )
 
 // Error values
@@ -80,9 +89,13 @@ type Config struct {
 	TrustDB   truststorage.TrustDBConf   `toml:"trust_db,omitempty"`
 	RenewalDB truststorage.RenewalDBConf `toml:"renewal_db,omitempty"`
 	PathDB    pathstorage.PathDBConf     `toml:"path_db,omitempty"`
+	RevCache  pathstorage.RevCacheConf   `toml:"rev_cache,omitempty"`
 	BS        BSConfig                   `toml:"beaconing,omitempty"`
 	PS        PSConfig                   `toml:"path,omitempty"`
 	CA        CA                         `toml:"ca,omitempty"`
+	ASRenewal ASRenewal                  `toml:"as_renewal,omitempty"`
+	Colibri   Colibri                    `toml:"colibri,omitempty"`
+	DRKey     DRKey                      `toml:"drkey,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -97,9 +110,13 @@ func (cfg *Config) InitDefaults() {
 		&cfg.TrustDB,
 		&cfg.RenewalDB,
 		&cfg.PathDB,
+		&cfg.RevCache,
 		&cfg.BS,
 		&cfg.PS,
 		&cfg.CA,
+		&cfg.ASRenewal,
+		&cfg.Colibri,
+		&cfg.DRKey,
 	)
 }
 
@@ -114,9 +131,13 @@ func (cfg *Config) Validate() error {
 		&cfg.TrustDB,
 		&cfg.RenewalDB,
 		&cfg.PathDB,
+		&cfg.RevCache,
 		&cfg.BS,
 		&cfg.PS,
 		&cfg.CA,
+		&cfg.ASRenewal,
+		&cfg.Colibri,
+		&cfg.DRKey,
 	)
 }
 
@@ -133,9 +154,13 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.TrustDB,
 		&cfg.RenewalDB,
 		&cfg.PathDB,
+		&cfg.RevCache,
 		&cfg.BS,
 		&cfg.PS,
 		&cfg.CA,
+		&cfg.ASRenewal,
+		&cfg.Colibri,
+		&cfg.DRKey,
 	)
 }
 
@@ -275,7 +300,8 @@ type Policies struct {
 	DownRegistration string `toml:"down_registration,omitempty"`
 	// HiddenPathRegistration contains the file path for the hidden path registration policy
 	// and the corresponding hidden path groups.
-	// If this is the empty string, no hidden path functionality is used.
+	// If this is the empty string, no hidden path functionality is used. In a
+	// core beacon server, this field is ignored.
 	HiddenPathRegistration string `toml:"hidden_path_registration,omitempty"`
 }
 
@@ -310,3 +336,110 @@ func (cfg *CA) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
 func (cfg *CA) ConfigName() string {
 	return "ca"
 }
+
+var _ config.Config = (*ASRenewal)(nil)
+
+// ASRenewal is the configuration of the automatic AS certificate renewal.
+type ASRenewal struct {
+	// Enabled enables the automatic renewal of the AS certificate chain.
+	Enabled bool `toml:"enabled,omitempty"`
+	// Interval is the interval between checking whether the AS certificate
+	// chain needs to be renewed. (default 10m)
+	Interval util.DurWrap `toml:"interval,omitempty"`
+}
+
+func (cfg *ASRenewal) InitDefaults() {
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = DefaultASRenewalInterval
+	}
+}
+
+func (cfg *ASRenewal) Validate() error {
+	if cfg.Interval.Duration < time.Second {
+		return serrors.New("interval must be at least one second",
+			"interval", cfg.Interval)
+	}
+	return nil
+}
+
+func (cfg *ASRenewal) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, asRenewalSample)
+}
+
+func (cfg *ASRenewal) ConfigName() string {
+	return "as_renewal"
+}
+
+var _ config.Config = (*Colibri)(nil)
+
+// Colibri is the COLIBRI configuration.
+type Colibri struct {
+	// Enabled enables the handling of COLIBRI requests.
+	Enabled bool `toml:"enabled,omitempty"`
+	// DB is the path to the sqlite database of the reservations.
+	DB string `toml:"db,omitempty"`
+	// Delta is the fraction of the free bandwidth that can be granted to a segment
+	// reservation. (default 1)
+	Delta float64 `toml:"delta,omitempty"`
+}
+
+func (cfg *Colibri) InitDefaults() {
+	if cfg.Delta == 0 {
+		cfg.Delta = DefaultColibriDelta
+	}
+}
+
+func (cfg *Colibri) Validate() error {
+	if cfg.Delta <= 0 || cfg.Delta > 1 {
+		return serrors.New("delta must be in (0, 1]", "delta", cfg.Delta)
+	}
+	if cfg.Enabled && cfg.DB == "" {
+		return serrors.New("db must be set if colibri is enabled")
+	}
+	return nil
+}
+
+func (cfg *Colibri) Sample(dst io.Writer, _ config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, fmt.Sprintf(colibriSample, ctx[config.ID]))
+}
+
+func (cfg *Colibri) ConfigName() string {
+	return "colibri"
+}
+
+var _ config.Config = (*DRKey)(nil)
+
+// DRKey is the DRKey configuration.
+type DRKey struct {
+	// Enabled enables the handling of DRKey requests.
+	Enabled bool `toml:"enabled,omitempty"`
+	// DB is the path to the sqlite database of the level 1 keys fetched from other ASes.
+	DB string `toml:"db,omitempty"`
+	// EpochDuration is the duration of the DRKey epochs. (default 24h)
+	EpochDuration util.DurWrap `toml:"epoch_duration,omitempty"`
+}
+
+func (cfg *DRKey) InitDefaults() {
+	if cfg.EpochDuration.Duration == 0 {
+		cfg.EpochDuration.Duration = DefaultDRKeyEpochDuration
+	}
+}
+
+func (cfg *DRKey) Validate() error {
+	if cfg.EpochDuration.Duration < time.Second {
+		return serrors.New("epoch_duration must be at least one second",
+			"epoch_duration", cfg.EpochDuration)
+	}
+	if cfg.Enabled && cfg.DB == "" {
+		return serrors.New("db must be set if drkey is enabled")
+	}
+	return nil
+}
+
+func (cfg *DRKey) Sample(dst io.Writer, _ config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, fmt.Sprintf(drkeySample, ctx[config.ID]))
+}
+
+func (cfg *DRKey) ConfigName() string {
+	return "drkey"
+}
@@ -62,8 +62,12 @@ func InitTestConfig(cfg *Config) {
 	truststoragetest.InitTestConfig(&cfg.TrustDB)
 	beaconstoragetest.InitTestBeaconDBConf(&cfg.BeaconDB)
 	pathstoragetest.InitTestPathDBConf(&cfg.PathDB)
+	pathstoragetest.InitTestRevCacheConf(&cfg.RevCache)
 	InitTestBSConfig(&cfg.BS)
 	InitTestCA(&cfg.CA)
+	InitTestASRenewal(&cfg.ASRenewal)
+	InitTestColibri(&cfg.Colibri)
+	InitTestDRKey(&cfg.DRKey)
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
@@ -83,9 +87,13 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	truststoragetest.CheckTestConfig(t, &cfg.TrustDB, id)
 	beaconstoragetest.CheckTestBeaconDBConf(t, &cfg.BeaconDB, id)
 	pathstoragetest.CheckTestPathDBConf(t, &cfg.PathDB, id)
+	pathstoragetest.CheckTestRevCacheConf(t, &cfg.RevCache, id)
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
 	CheckTestCA(t, &cfg.CA, id)
+	CheckTestASRenewal(t, &cfg.ASRenewal)
+	CheckTestColibri(t, &cfg.Colibri, id)
+	CheckTestDRKey(t, &cfg.DRKey, id)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
@@ -118,3 +126,26 @@ func InitTestCA(cfg *CA) {}
 func CheckTestCA(t *testing.T, cfg *CA, id string) {
 	assert.Equal(t, DefaultMaxASValidity, cfg.MaxASValidity.Duration)
 }
+
+func InitTestASRenewal(cfg *ASRenewal) {}
+
+func CheckTestASRenewal(t *testing.T, cfg *ASRenewal) {
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, DefaultASRenewalInterval, cfg.Interval.Duration)
+}
+
+func InitTestColibri(cfg *Colibri) {}
+
+func CheckTestColibri(t *testing.T, cfg *Colibri, id string) {
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, "/var/lib/scion/colibri/"+id+".colibri.db", cfg.DB)
+	assert.Equal(t, DefaultColibriDelta, cfg.Delta)
+}
+
+func InitTestDRKey(cfg *DRKey) {}
+
+func CheckTestDRKey(t *testing.T, cfg *DRKey, id string) {
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, "/var/lib/scion/drkey/"+id+".drkey.db", cfg.DB)
+	assert.Equal(t, DefaultDRKeyEpochDuration, cfg.EpochDuration.Duration)
+}
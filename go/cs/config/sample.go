@@ -30,3 +30,38 @@ const caSample = `
 # loaded that satisfies the condition. (default 3d)
 max_as_validity = "3d"
 `
+
+const asRenewalSample = `
+# Enables the automatic renewal of the AS certificate chain. The chain is
+# renewed with the issuing CA once less than a third of the validity period of
+# the AS certificate remains. (default false)
+enabled = false
+
+# The interval between checking whether the AS certificate chain needs to be
+# renewed. (default 10m)
+interval = "10m"
+`
+
+const colibriSample = `
+# Enables the handling of COLIBRI reservation requests. (default false)
+enabled = false
+
+# The path to the sqlite database of the COLIBRI reservations.
+db = "/var/lib/scion/colibri/%s.colibri.db"
+
+# The fraction of the free bandwidth that can be granted to a segment
+# reservation. Must be in (0, 1]. (default 1)
+delta = 1.0
+`
+
+const drkeySample = `
+# Enables the handling of DRKey requests. (default false)
+enabled = false
+
+# The path to the sqlite database of the level 1 keys fetched from other ASes.
+db = "/var/lib/scion/drkey/%s.drkey.db"
+
+# The duration of the DRKey epochs. A new secret value is derived from the
+# master key for every epoch. (default 24h)
+epoch_duration = "24h"
+`
@@ -22,6 +22,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 
+	"github.com/scionproto/scion/go/cs/beacon"
 	"github.com/scionproto/scion/go/cs/beaconing"
 	"github.com/scionproto/scion/go/cs/beaconstorage"
 	"github.com/scionproto/scion/go/cs/config"
@@ -48,6 +49,7 @@ import (
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/squic"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/pkg/command"
@@ -110,7 +112,11 @@ func run(file string) error {
 	// approach.
 	metrics.InitBSMetrics()
 	metrics.InitPSMetrics()
-	intfs, err := setup(&cfg)
+	macGen, err := cs.NewMACGen(cfg.General.ConfigDir)
+	if err != nil {
+		return err
+	}
+	intfs, err := setup(&cfg, macGen)
 	if err != nil {
 		return err
 	}
@@ -122,6 +128,10 @@ func run(file string) error {
 	}
 	defer closer.Close()
 
+	// The TLS crypto manager is completed once the signer and the trust engine
+	// are initialized, which in turn requires the messenger. It is only used
+	// for handshakes after the messengers have started.
+	tlsMgr := &trust.TLSCryptoManager{}
 	nc := infraenv.NetworkConfig{
 		IA:                    topo.IA(),
 		Public:                topo.PublicAddress(addr.SvcBS, cfg.General.ID),
@@ -136,12 +146,16 @@ func run(file string) error {
 		SVCRouter:             messenger.NewSVCRouter(itopo.Provider()),
 		Version2:              cfg.Features.HeaderV2,
 	}
+	if cfg.QUIC.CPPKI {
+		nc.QUIC.CryptoManager = tlsMgr
+		squic.InitCPPKI(tlsMgr)
+	}
 	msgr, tcpMsgr, err := cs.NewMessenger(nc)
 	if err != nil {
 		return err
 	}
 
-	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.PathDB)
+	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.PathDB, cfg.RevCache)
 	if err != nil {
 		return serrors.WrapStr("initializing path storage", err)
 	}
@@ -159,7 +173,7 @@ func run(file string) error {
 		return err
 	}
 
-	beaconStore, isdLoopAllowed, err := loadBeaconStore(topo.Core(), topo.IA(), cfg)
+	beaconStore, propFilter, err := loadBeaconStore(topo.Core(), topo.IA(), cfg)
 	if err != nil {
 		return serrors.WrapStr("initializing beacon store", err)
 	}
@@ -196,6 +210,8 @@ func run(file string) error {
 	cs.MultiRegister(infra.TRCRequest, trcHandler, msgr, tcpMsgr)
 	chainHandler := trusthandler.ChainReq{Provider: provider, IA: topo.IA()}
 	cs.MultiRegister(infra.ChainRequest, chainHandler, msgr, tcpMsgr)
+	denyListHandler := trusthandler.DenyListReq{Provider: provider, IA: topo.IA()}
+	cs.MultiRegister(infra.DenyListRequest, denyListHandler, msgr, tcpMsgr)
 
 	// Register pathing related handlers
 	msgr.AddHandler(infra.Seg, beaconing.NewHandler(topo.IA(), intfs, beaconStore, verifier))
@@ -263,6 +279,8 @@ func run(file string) error {
 	if err != nil {
 		return serrors.WrapStr("initializing AS signer", err)
 	}
+	tlsMgr.Signer = signer.SignerGen
+	tlsMgr.Verifier = trust.Verifier{Engine: provider}
 
 	var chainBuilder cstrust.ChainBuilder
 	if topo.CA() {
@@ -289,6 +307,42 @@ func run(file string) error {
 		)
 	}
 
+	if cfg.Colibri.Enabled {
+		colibri, err := cs.StartColibri(cs.ColibriConfig{
+			DB:     cfg.Colibri.DB,
+			Delta:  cfg.Colibri.Delta,
+			Topo:   topo,
+			Msgr:   msgr,
+			Router: segreq.NewRouter(fetcherCfg),
+		})
+		if err != nil {
+			return serrors.WrapStr("initializing COLIBRI", err)
+		}
+		defer colibri.Close()
+		cs.MultiRegister(infra.ColibriRequest, colibri.Handler, msgr, tcpMsgr)
+		log.Info("Started COLIBRI service")
+	}
+
+	if cfg.DRKey.Enabled {
+		drkey, err := cs.StartDRKey(cs.DRKeyConfig{
+			DB:            cfg.DRKey.DB,
+			EpochDuration: cfg.DRKey.EpochDuration.Duration,
+			ConfigDir:     cfg.General.ConfigDir,
+			IA:            topo.IA(),
+			RPC:           msgr,
+			Router:        segreq.NewRouter(fetcherCfg),
+			Signer:        signer,
+			Provider:      provider,
+		})
+		if err != nil {
+			return serrors.WrapStr("initializing DRKey", err)
+		}
+		defer drkey.Close()
+		cs.MultiRegister(infra.DRKeyLvl1Request, drkey.Lvl1Handler, msgr, tcpMsgr)
+		cs.MultiRegister(infra.DRKeyLvl2Request, drkey.Lvl2Handler, msgr, tcpMsgr)
+		log.Info("Started DRKey service")
+	}
+
 	go func() {
 		defer log.HandlePanic()
 		msgr.ListenAndServe()
@@ -306,14 +360,19 @@ func run(file string) error {
 	if err != nil {
 		return serrors.WrapStr("creating one-hop connection", err)
 	}
-	macGen, err := cs.MACGenFactory(cfg.General.ConfigDir)
-	if err != nil {
-		return err
-	}
 	staticInfo, err := beaconing.ParseStaticInfoCfg(cfg.General.StaticInfoConfig())
 	if err != nil {
 		log.Info("Failed to read static info", "err", err)
 	}
+	hpRegistration, err := cs.LoadHiddenPathRegistration(cfg.BS.Policies)
+	if err != nil {
+		return err
+	}
+	var chainRenewer *cstrust.ChainRenewer
+	if cfg.ASRenewal.Enabled {
+		chainRenewer = cs.NewChainRenewer(topo.IA(), trustDB, signer, msgr,
+			cfg.General.ConfigDir)
+	}
 	tasks, err := cs.StartTasks(cs.TasksConfig{
 		Public:      nc.Public,
 		Intfs:       intfs,
@@ -333,15 +392,22 @@ func run(file string) error {
 			},
 		),
 		Inspector:    inspector,
-		MACGen:       macGen,
+		MACGen:       macGen.New,
 		TopoProvider: itopo.Provider(),
 		StaticInfo:   func() *beaconing.StaticInfoCfg { return staticInfo },
 
+		HiddenPathRegistration: hpRegistration,
+
 		OriginationInterval:  cfg.BS.OriginationInterval.Duration,
 		PropagationInterval:  cfg.BS.PropagationInterval.Duration,
 		RegistrationInterval: cfg.BS.RegistrationInterval.Duration,
-		AllowIsdLoop:         isdLoopAllowed,
+		AllowIsdLoop:         *propFilter.AllowIsdLoop,
+		PropagationFilter:    &propFilter,
 		HeaderV2:             cfg.Features.HeaderV2,
+		ChainRenewer:         chainRenewer,
+		ChainRenewalInterval: cfg.ASRenewal.Interval.Duration,
+		TrustProvider:        provider,
+		DenyListInterval:     time.Minute,
 	})
 	if err != nil {
 		serrors.WrapStr("starting periodic tasks", err)
@@ -358,7 +424,7 @@ func run(file string) error {
 			BeaconStore:          beaconStore,
 			Signer:               signer,
 			Msgr:                 msgr,
-			MACGen:               macGen,
+			MACGen:               macGen.New,
 			TopoProvider:         itopo.Provider(),
 			KeepaliveInterval:    cfg.BS.KeepaliveInterval.Duration,
 			ExpiredCheckInterval: cfg.BS.ExpiredCheckInterval.Duration,
@@ -394,7 +460,7 @@ func setupBasic(file string) (config.Config, error) {
 	return cfg, nil
 }
 
-func setup(cfg *config.Config) (*ifstate.Interfaces, error) {
+func setup(cfg *config.Config, macGen *cs.MACGen) (*ifstate.Interfaces, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, serrors.WrapStr("validating config", err)
 	}
@@ -416,23 +482,31 @@ func setup(cfg *config.Config) (*ifstate.Interfaces, error) {
 	if err := itopo.Update(topo); err != nil {
 		return nil, serrors.WrapStr("setting initial static topology", err)
 	}
-	infraenv.InitInfraEnvironment(cfg.General.Topology())
+	infraenv.InitInfraEnvironmentFunc(cfg.General.Topology(), func() {
+		if err := macGen.Reload(); err != nil {
+			log.Error("Unable to reload master keys", "err", err)
+			return
+		}
+		log.Info("Master keys reloaded")
+	})
 	return intfs, nil
 }
 
-func loadBeaconStore(core bool, ia addr.IA, cfg config.Config) (beaconstorage.Store, bool, error) {
+func loadBeaconStore(core bool, ia addr.IA,
+	cfg config.Config) (beaconstorage.Store, beacon.Filter, error) {
+
 	if core {
 		policies, err := cs.LoadCorePolicies(cfg.BS.Policies)
 		if err != nil {
-			return nil, false, err
+			return nil, beacon.Filter{}, err
 		}
 		store, err := cfg.BeaconDB.NewCoreStore(ia, policies)
-		return store, *policies.Prop.Filter.AllowIsdLoop, err
+		return store, policies.Prop.Filter, err
 	}
 	policies, err := cs.LoadNonCorePolicies(cfg.BS.Policies)
 	if err != nil {
-		return nil, false, err
+		return nil, beacon.Filter{}, err
 	}
 	store, err := cfg.BeaconDB.NewStore(ia, policies)
-	return store, *policies.Prop.Filter.AllowIsdLoop, err
+	return store, policies.Prop.Filter, err
 }
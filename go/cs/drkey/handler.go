@@ -0,0 +1,160 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// Lvl1ReqHandler handles the level 1 key requests from the control services of other ASes.
+type Lvl1ReqHandler struct {
+	Store    *ServiceStore
+	Signer   ctrl.Signer
+	Verifier Verifier
+}
+
+// Handle derives the requested level 1 key and replies with the key encrypted for the
+// requester.
+func (h *Lvl1ReqHandler) Handle(r *infra.Request) *infra.HandlerResult {
+	ctx := r.Context()
+	logger := log.FromCtx(ctx)
+	req, ok := r.Message.(*drkey_mgmt.Lvl1Req)
+	if !ok {
+		logger.Error("[drkey] Wrong message type, expected drkey_mgmt.Lvl1Req",
+			"msg", r.Message, "type", common.TypeOf(r.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(ctx)
+	if !ok {
+		logger.Error("[drkey] Unable to service request, no ResponseWriter found")
+		return infra.MetricsErrInternal
+	}
+	sendAck := messenger.SendAckHelper(ctx, rw)
+	logger.Debug("[drkey] Received level 1 request", "req", req, "peer", r.Peer)
+
+	if err := verifyLvl1Req(ctx, req, h.Verifier); err != nil {
+		logger.Info("[drkey] Invalid level 1 request", "peer", r.Peer, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectFailedToVerify)
+		return infra.MetricsErrInvalid
+	}
+	key, err := h.Store.DeriveLvl1(ctx, req.DstIA(), req.ValTimeRaw())
+	if err != nil {
+		logger.Error("[drkey] Failed to derive level 1 key", "err", err)
+		sendAck(proto.Ack_ErrCode_reject, "failed to derive key")
+		return infra.MetricsErrInternal
+	}
+	rep, err := newLvl1Rep(ctx, req, key, h.Signer)
+	if err != nil {
+		logger.Error("[drkey] Failed to create level 1 reply", "err", err)
+		sendAck(proto.Ack_ErrCode_reject, "failed to create reply")
+		return infra.MetricsErrInternal
+	}
+	if err := rw.SendDRKeyLvl1Reply(ctx, rep); err != nil {
+		logger.Info("[drkey] Failed to send level 1 reply", "peer", r.Peer, "err", err)
+		return infra.MetricsErrInternal
+	}
+	return infra.MetricsResultOk
+}
+
+// Lvl2ReqHandler handles the level 2 key requests of the end hosts in the local AS. The
+// requests are relayed by sciond that runs on the end host. A host only obtains the keys it
+// is an endpoint of, see drkey.Lvl2Meta.AuthorizeHost.
+type Lvl2ReqHandler struct {
+	Store *ServiceStore
+}
+
+// Handle replies with the requested level 2 key. If the key cannot be obtained by the local
+// AS, the reply carries an empty key.
+func (h *Lvl2ReqHandler) Handle(r *infra.Request) *infra.HandlerResult {
+	ctx := r.Context()
+	logger := log.FromCtx(ctx)
+	req, ok := r.Message.(*drkey_mgmt.Lvl2Req)
+	if !ok {
+		logger.Error("[drkey] Wrong message type, expected drkey_mgmt.Lvl2Req",
+			"msg", r.Message, "type", common.TypeOf(r.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(ctx)
+	if !ok {
+		logger.Error("[drkey] Unable to service request, no ResponseWriter found")
+		return infra.MetricsErrInternal
+	}
+	sendAck := messenger.SendAckHelper(ctx, rw)
+	logger.Debug("[drkey] Received level 2 request", "req", req, "peer", r.Peer)
+
+	meta, err := req.ToMeta()
+	if err != nil {
+		logger.Info("[drkey] Invalid level 2 request", "peer", r.Peer, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectFailedToParse)
+		return infra.MetricsErrInvalid
+	}
+	host, err := h.requester(r.Peer)
+	if err == nil {
+		err = meta.AuthorizeHost(h.Store.IA, host)
+	}
+	if err != nil {
+		logger.Info("[drkey] Unauthorized level 2 request", "peer", r.Peer, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, "requester not authorized for key")
+		return infra.MetricsErrInvalid
+	}
+	rep := &drkey_mgmt.Lvl2Rep{Timestamp: util.TimeToSecs(time.Now())}
+	key, err := h.Store.GetLvl2Key(ctx, meta, req.ValTimeRaw())
+	switch {
+	case errors.Is(err, ErrNotDerivable):
+		logger.Info("[drkey] Level 2 key not derivable", "req", req)
+	case err != nil:
+		logger.Info("[drkey] Failed to get level 2 key", "req", req, "err", err)
+	default:
+		rep = drkey_mgmt.NewLvl2RepFromKey(key, time.Now())
+	}
+	if err := rw.SendDRKeyLvl2Reply(ctx, rep); err != nil {
+		logger.Info("[drkey] Failed to send level 2 reply", "peer", r.Peer, "err", err)
+		return infra.MetricsErrInternal
+	}
+	return infra.MetricsResultOk
+}
+
+// requester returns the address of the end host that sent the request. Level 2 keys are
+// only served to the hosts in the local AS.
+func (h *Lvl2ReqHandler) requester(peer net.Addr) (addr.HostAddr, error) {
+	switch p := peer.(type) {
+	case *net.TCPAddr:
+		return addr.HostFromIP(p.IP), nil
+	case *snet.UDPAddr:
+		if !p.IA.Equal(h.Store.IA) {
+			return nil, serrors.New("peer not in local AS", "isd_as", p.IA)
+		}
+		if p.Host == nil {
+			return nil, serrors.New("peer without host address")
+		}
+		return addr.HostFromIP(p.Host.IP), nil
+	default:
+		return nil, serrors.New("unsupported peer address", "type", common.TypeOf(peer))
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/ack"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestLvl2ReqHandler(t *testing.T) {
+	hostIP := net.IP{10, 0, 0, 1}
+	asToHost := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: "test",
+		SrcIA:    srcIA,
+		DstIA:    srcIA,
+		DstHost:  addr.HostFromIP(hostIP),
+	}
+	asToAS := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2AS,
+		Protocol: "test",
+		SrcIA:    srcIA,
+		DstIA:    srcIA,
+	}
+
+	testCases := map[string]struct {
+		Meta       drkey.Lvl2Meta
+		Peer       net.Addr
+		Authorized bool
+	}{
+		"TCP peer is the host": {
+			Meta:       asToHost,
+			Peer:       &net.TCPAddr{IP: hostIP, Port: 4000},
+			Authorized: true,
+		},
+		"SCION peer is the host": {
+			Meta:       asToHost,
+			Peer:       &snet.UDPAddr{IA: srcIA, Host: &net.UDPAddr{IP: hostIP, Port: 4000}},
+			Authorized: true,
+		},
+		"TCP peer is another host": {
+			Meta: asToHost,
+			Peer: &net.TCPAddr{IP: net.IP{10, 0, 0, 2}, Port: 4000},
+		},
+		"SCION peer in remote AS": {
+			Meta: asToHost,
+			Peer: &snet.UDPAddr{IA: dstIA, Host: &net.UDPAddr{IP: hostIP, Port: 4000}},
+		},
+		"unknown peer type": {
+			Meta: asToHost,
+			Peer: &net.UDPAddr{IP: hostIP, Port: 4000},
+		},
+		"AS to AS key": {
+			Meta: asToAS,
+			Peer: &net.TCPAddr{IP: hostIP, Port: 4000},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rw := mock_infra.NewMockResponseWriter(ctrl)
+			if tc.Authorized {
+				rw.EXPECT().SendDRKeyLvl2Reply(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, rep *drkey_mgmt.Lvl2Rep) error {
+						assert.NotEmpty(t, rep.DRKey)
+						return nil
+					},
+				)
+			} else {
+				rw.EXPECT().SendAckReply(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, a *ack.Ack) error {
+						assert.Equal(t, proto.Ack_ErrCode_reject, a.Err)
+						return nil
+					},
+				)
+			}
+			h := &Lvl2ReqHandler{Store: newTestStore(t, srcIA, "0123456789abcdef")}
+			ctx := infra.NewContextWithResponseWriter(context.Background(), rw)
+			req := drkey_mgmt.NewLvl2ReqFromMeta(tc.Meta, time.Now())
+			h.Handle(infra.NewRequest(ctx, req, nil, tc.Peer, 1))
+		})
+	}
+}
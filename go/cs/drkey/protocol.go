@@ -0,0 +1,157 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/pkg/trust"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// MaxMessageAge is the maximum age of level 1 key requests and replies. Older messages are
+// rejected to limit replays.
+const MaxMessageAge = 10 * time.Second
+
+// Verifier verifies the signatures of the level 1 key messages.
+type Verifier interface {
+	// Verify verifies that the signature over msg was created by the given AS.
+	Verify(ctx context.Context, ia addr.IA, msg []byte, sign *proto.SignS) error
+}
+
+// TrustVerifier verifies the signatures with the certificate chains provided by the trust
+// engine.
+type TrustVerifier struct {
+	Engine trust.Provider
+}
+
+// Verify verifies that the signature over msg was created by the given AS.
+func (v TrustVerifier) Verify(ctx context.Context, ia addr.IA, msg []byte,
+	sign *proto.SignS) error {
+
+	return trust.Verifier{BoundIA: ia, Engine: v.Engine}.Verify(ctx, msg, sign)
+}
+
+// newLvl1Req creates a signed level 1 key request for the key valid at valTime. The returned
+// private key is needed to decrypt the reply.
+func newLvl1Req(ctx context.Context, localIA addr.IA, valTime time.Time,
+	signer ctrl.Signer) (*drkey_mgmt.Lvl1Req, []byte, error) {
+
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Curve25519xSalsa20Poly1305)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("generating ephemeral key pair", err)
+	}
+	req := drkey_mgmt.NewLvl1Req(localIA, valTime, pub)
+	if req.Signature, err = signer.Sign(ctx, req.SigInput()); err != nil {
+		return nil, nil, serrors.WrapStr("signing request", err)
+	}
+	return req, priv, nil
+}
+
+// newLvl1Rep creates the signed reply carrying the level 1 key encrypted for the requester.
+// The request must already be verified.
+func newLvl1Rep(ctx context.Context, req *drkey_mgmt.Lvl1Req, key drkey.Lvl1Key,
+	signer ctrl.Signer) (*drkey_mgmt.Lvl1Rep, error) {
+
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Curve25519xSalsa20Poly1305)
+	if err != nil {
+		return nil, serrors.WrapStr("generating ephemeral key pair", err)
+	}
+	nonce, err := scrypto.Nonce(scrypto.NaClBoxNonceSize)
+	if err != nil {
+		return nil, serrors.WrapStr("generating nonce", err)
+	}
+	cipher, err := scrypto.Encrypt(key.Key, nonce, req.EphemeralKey, priv,
+		scrypto.Curve25519xSalsa20Poly1305)
+	if err != nil {
+		return nil, serrors.WrapStr("encrypting key", err)
+	}
+	rep := &drkey_mgmt.Lvl1Rep{
+		RawDstIA:     req.RawDstIA,
+		EpochBegin:   key.Epoch.Begin(),
+		EpochEnd:     key.Epoch.End(),
+		Cipher:       cipher,
+		Nonce:        nonce,
+		EphemeralKey: pub,
+		Timestamp:    util.TimeToSecs(time.Now()),
+	}
+	if rep.Signature, err = signer.Sign(ctx, rep.SigInput()); err != nil {
+		return nil, serrors.WrapStr("signing reply", err)
+	}
+	return rep, nil
+}
+
+// verifyLvl1Req verifies that the request is recent and signed by the requesting AS.
+func verifyLvl1Req(ctx context.Context, req *drkey_mgmt.Lvl1Req, verifier Verifier) error {
+	if err := checkTimestamp(req.TimestampRaw()); err != nil {
+		return err
+	}
+	return verifier.Verify(ctx, req.DstIA(), req.SigInput(), req.Signature)
+}
+
+// lvl1KeyFromRep verifies the reply from srcIA to the request and decrypts the level 1 key
+// with the private key of the request.
+func lvl1KeyFromRep(ctx context.Context, srcIA addr.IA, req *drkey_mgmt.Lvl1Req,
+	rep *drkey_mgmt.Lvl1Rep, priv []byte, verifier Verifier) (drkey.Lvl1Key, error) {
+
+	if !rep.DstIA().Equal(req.DstIA()) {
+		return drkey.Lvl1Key{}, serrors.New("reply for wrong destination",
+			"expected", req.DstIA(), "actual", rep.DstIA())
+	}
+	if err := checkTimestamp(rep.TimestampRaw()); err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	if err := verifier.Verify(ctx, srcIA, rep.SigInput(), rep.Signature); err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("verifying reply", err)
+	}
+	epoch := drkey.NewEpoch(rep.EpochBegin, rep.EpochEnd)
+	if !epoch.Contains(req.ValTimeRaw()) {
+		return drkey.Lvl1Key{}, serrors.New("key not valid at requested time",
+			"epoch", epoch, "val_time", req.ValTimeRaw())
+	}
+	key, err := scrypto.Decrypt(rep.Cipher, rep.Nonce, rep.EphemeralKey, priv,
+		scrypto.Curve25519xSalsa20Poly1305)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("decrypting key", err)
+	}
+	if len(key) != drkey.KeyLen {
+		return drkey.Lvl1Key{}, serrors.New("invalid key length",
+			"expected", drkey.KeyLen, "actual", len(key))
+	}
+	return drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{
+			Epoch: epoch,
+			SrcIA: srcIA,
+			DstIA: req.DstIA(),
+		},
+		Key: drkey.DRKey(key),
+	}, nil
+}
+
+func checkTimestamp(ts time.Time) error {
+	if age := time.Since(ts); age > MaxMessageAge || age < -MaxMessageAge {
+		return serrors.New("message timestamp out of range", "timestamp", ts,
+			"max_age", MaxMessageAge)
+	}
+	return nil
+}
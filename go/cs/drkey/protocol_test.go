@@ -0,0 +1,173 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/sqlite"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	srcIA = xtest.MustParseIA("1-ff00:0:110")
+	dstIA = xtest.MustParseIA("1-ff00:0:111")
+)
+
+// fakeSigner signs with the IA as signature.
+type fakeSigner struct {
+	IA addr.IA
+}
+
+func (s fakeSigner) Sign(_ context.Context, msg []byte) (*proto.SignS, error) {
+	return &proto.SignS{Src: []byte(s.IA.String()), Signature: append([]byte(nil), msg...)}, nil
+}
+
+// fakeVerifier accepts the signatures of fakeSigner.
+type fakeVerifier struct{}
+
+func (fakeVerifier) Verify(_ context.Context, ia addr.IA, msg []byte, sign *proto.SignS) error {
+	if string(sign.Src) != ia.String() || string(sign.Signature) != string(msg) {
+		return serrors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestLvl1Exchange(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	svs, err := NewSecretValueFactory([]byte("0123456789abcdef"), time.Hour, nil)
+	require.NoError(t, err)
+	store := &ServiceStore{IA: srcIA, SecretValues: svs}
+	expected, err := store.DeriveLvl1(ctx, dstIA, now)
+	require.NoError(t, err)
+
+	req, priv, err := newLvl1Req(ctx, dstIA, now, fakeSigner{IA: dstIA})
+	require.NoError(t, err)
+	require.NoError(t, verifyLvl1Req(ctx, req, fakeVerifier{}))
+	rep, err := newLvl1Rep(ctx, req, expected, fakeSigner{IA: srcIA})
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		key, err := lvl1KeyFromRep(ctx, srcIA, req, rep, priv, fakeVerifier{})
+		require.NoError(t, err)
+		assert.Equal(t, expected.Lvl1Meta, key.Lvl1Meta)
+		assert.True(t, expected.Key.Equal(key.Key))
+	})
+	t.Run("wrong source", func(t *testing.T) {
+		_, err := lvl1KeyFromRep(ctx, dstIA, req, rep, priv, fakeVerifier{})
+		assert.Error(t, err)
+	})
+	t.Run("wrong private key", func(t *testing.T) {
+		_, other, err := newLvl1Req(ctx, dstIA, now, fakeSigner{IA: dstIA})
+		require.NoError(t, err)
+		_, err = lvl1KeyFromRep(ctx, srcIA, req, rep, other, fakeVerifier{})
+		assert.Error(t, err)
+	})
+	t.Run("forged request", func(t *testing.T) {
+		forged, _, err := newLvl1Req(ctx, dstIA, now, fakeSigner{IA: srcIA})
+		require.NoError(t, err)
+		assert.Error(t, verifyLvl1Req(ctx, forged, fakeVerifier{}))
+	})
+	t.Run("old request", func(t *testing.T) {
+		old, _, err := newLvl1Req(ctx, dstIA, now, fakeSigner{IA: dstIA})
+		require.NoError(t, err)
+		old.Timestamp -= uint32(2 * MaxMessageAge / time.Second)
+		old.Signature, err = fakeSigner{IA: dstIA}.Sign(ctx, old.SigInput())
+		require.NoError(t, err)
+		assert.Error(t, verifyLvl1Req(ctx, old, fakeVerifier{}))
+	})
+}
+
+func TestSecretValueFactory(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewSecretValueFactory(nil, time.Hour, nil)
+	assert.Error(t, err)
+	_, err = NewSecretValueFactory([]byte("key"), time.Millisecond, nil)
+	assert.Error(t, err)
+
+	f, err := NewSecretValueFactory([]byte("0123456789abcdef"), time.Hour, nil)
+	require.NoError(t, err)
+	now := time.Unix(10*3600+1800, 0)
+	sv, err := f.GetSecretValue(ctx, now)
+	require.NoError(t, err)
+	same, err := f.GetSecretValue(ctx, now.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, sv, same)
+	next, err := f.GetSecretValue(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, sv.Key.Equal(next.Key))
+	assert.Equal(t, sv.Epoch.NotAfter, next.Epoch.NotBefore)
+}
+
+func TestSecretValueFactoryRotation(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "drkey-sv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := sqlite.New(filepath.Join(dir, "drkey.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	old, err := NewSecretValueFactory([]byte("0123456789abcdef"), time.Hour, db)
+	require.NoError(t, err)
+	sv, err := old.GetSecretValue(ctx, now)
+	require.NoError(t, err)
+
+	// A restart with a rotated master key keeps the secret value of the current epoch, and
+	// uses the new master key for the next one.
+	rotated, err := NewSecretValueFactory([]byte("fedcba9876543210"), time.Hour, db)
+	require.NoError(t, err)
+	same, err := rotated.GetSecretValue(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, sv.Epoch, same.Epoch)
+	assert.True(t, sv.Key.Equal(same.Key))
+
+	next, err := rotated.GetSecretValue(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	expected, err := drkey.DeriveSV(drkey.SVMeta{Epoch: next.Epoch},
+		[]byte("fedcba9876543210"))
+	require.NoError(t, err)
+	assert.True(t, expected.Key.Equal(next.Key))
+}
+
+// fakeFetcher derives the level 1 keys with the store of the source AS.
+type fakeFetcher struct {
+	Src     *ServiceStore
+	Fetches int
+}
+
+func (f *fakeFetcher) FetchLvl1(_ context.Context, srcIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	f.Fetches++
+	if !srcIA.Equal(f.Src.IA) {
+		return drkey.Lvl1Key{}, serrors.New("unknown AS", "ia", srcIA)
+	}
+	return f.Src.DeriveLvl1(context.Background(), dstIA, valTime)
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkey implements the DRKey service of the control service. It derives the secret
+// values of the AS from its master key, serves the level 1 keys to the control services of
+// other ASes, fetches the level 1 keys of other ASes and serves level 2 keys to the end hosts
+// in the local AS.
+package drkey
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// SecretValueFactory derives the secret values of the local AS. A new secret value is used
+// for every epoch. If a database is set, the secret value of an epoch is stored when it is
+// first derived, and the stored one is used afterwards. Thus, rotating the master key does not
+// change the secret value of the current epoch, and with it the keys already handed out. The
+// new master key is used starting with the first epoch that has no stored secret value.
+type SecretValueFactory struct {
+	masterKey     []byte
+	epochDuration time.Duration
+	db            drkey.SVReadWrite
+
+	mu    sync.Mutex
+	cache map[uint32]drkey.SV
+}
+
+// NewSecretValueFactory creates a factory deriving the secret values from the master key,
+// with epochs of the given duration. The database is optional.
+func NewSecretValueFactory(masterKey []byte, epochDuration time.Duration,
+	db drkey.SVReadWrite) (*SecretValueFactory, error) {
+
+	if len(masterKey) == 0 {
+		return nil, serrors.New("empty master key")
+	}
+	if epochDuration < time.Second {
+		return nil, serrors.New("epoch duration must be at least one second",
+			"duration", epochDuration)
+	}
+	return &SecretValueFactory{
+		masterKey:     masterKey,
+		epochDuration: epochDuration,
+		db:            db,
+		cache:         make(map[uint32]drkey.SV),
+	}, nil
+}
+
+// GetSecretValue returns the secret value of the epoch containing t. Secret values of epochs
+// that ended before the previous epoch are discarded.
+func (f *SecretValueFactory) GetSecretValue(ctx context.Context,
+	t time.Time) (drkey.SV, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	epoch := drkey.EpochAt(t, f.epochDuration)
+	if sv, ok := f.cache[epoch.Begin()]; ok {
+		return sv, nil
+	}
+	sv, err := f.load(ctx, epoch)
+	if err != nil {
+		return drkey.SV{}, err
+	}
+	f.cache[epoch.Begin()] = sv
+	f.cleanup(t)
+	return sv, nil
+}
+
+// DeleteExpired removes the stored secret values that are not needed anymore.
+func (f *SecretValueFactory) DeleteExpired(ctx context.Context) (int, error) {
+	if f.db == nil {
+		return 0, nil
+	}
+	return f.db.RemoveOutdatedSVs(ctx, time.Now().Add(-f.epochDuration))
+}
+
+// load returns the stored secret value of the epoch. If there is none, it is derived from
+// the master key and stored.
+func (f *SecretValueFactory) load(ctx context.Context, epoch drkey.Epoch) (drkey.SV, error) {
+	if f.db != nil {
+		sv, err := f.db.GetSV(ctx, epoch.NotBefore)
+		switch {
+		case err == nil && sv.Epoch.Begin() == epoch.Begin() && sv.Epoch.End() == epoch.End():
+			return sv, nil
+		case err != nil && !errors.Is(err, drkey.ErrKeyNotFound):
+			return drkey.SV{}, serrors.WrapStr("reading secret value from database", err)
+		}
+	}
+	sv, err := drkey.DeriveSV(drkey.SVMeta{Epoch: epoch}, f.masterKey)
+	if err != nil {
+		return drkey.SV{}, serrors.WrapStr("deriving secret value", err)
+	}
+	if f.db != nil {
+		if err := f.db.InsertSV(ctx, sv); err != nil {
+			return drkey.SV{}, serrors.WrapStr("storing secret value", err)
+		}
+	}
+	return sv, nil
+}
+
+// cleanup removes the secret values that are not needed anymore. The secret value of the
+// previous epoch is kept, as keys derived from it may still be requested close to the epoch
+// change.
+func (f *SecretValueFactory) cleanup(t time.Time) {
+	cutoff := t.Add(-f.epochDuration)
+	for begin, sv := range f.cache {
+		if !sv.Epoch.NotAfter.After(cutoff) {
+			delete(f.cache, begin)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// ErrNotDerivable indicates that the local AS cannot obtain the requested level 2 key, because
+// it is neither the source nor the destination AS of the key.
+var ErrNotDerivable = serrors.New("key not derivable in local AS")
+
+// RPC sends level 1 key requests to the control service of another AS.
+type RPC interface {
+	RequestDRKeyLvl1(ctx context.Context, msg *drkey_mgmt.Lvl1Req, a net.Addr,
+		id uint64) (*drkey_mgmt.Lvl1Rep, error)
+}
+
+// Fetcher fetches level 1 keys from the control services of other ASes.
+type Fetcher struct {
+	// IA is the local IA.
+	IA addr.IA
+	// RPC is used to send the requests.
+	RPC RPC
+	// Router finds the paths to the control services of the other ASes.
+	Router snet.Router
+	// Signer signs the requests.
+	Signer ctrl.Signer
+	// Verifier verifies the replies.
+	Verifier Verifier
+}
+
+// FetchLvl1 fetches the level 1 key K_{srcIA->IA} valid at valTime from srcIA.
+func (f *Fetcher) FetchLvl1(ctx context.Context, srcIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	req, priv, err := newLvl1Req(ctx, f.IA, valTime, f.Signer)
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	path, err := f.Router.Route(ctx, srcIA)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("cannot find path to AS", err, "ia", srcIA)
+	}
+	if path == nil {
+		return drkey.Lvl1Key{}, serrors.New("no path to AS", "ia", srcIA)
+	}
+	dst := &snet.SVCAddr{
+		IA:      srcIA,
+		Path:    path.Path(),
+		NextHop: path.UnderlayNextHop(),
+		SVC:     addr.SvcCS,
+	}
+	rep, err := f.RPC.RequestDRKeyLvl1(ctx, req, dst, messenger.NextId())
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("requesting level 1 key", err, "ia", srcIA)
+	}
+	return lvl1KeyFromRep(ctx, srcIA, req, rep, priv, f.Verifier)
+}
+
+// Lvl1Fetcher fetches level 1 keys from other ASes.
+type Lvl1Fetcher interface {
+	FetchLvl1(ctx context.Context, srcIA addr.IA, valTime time.Time) (drkey.Lvl1Key, error)
+}
+
+// ServiceStore provides the DRKeys of the local AS. Level 1 keys from the local AS are derived
+// from its secret values, level 1 keys to the local AS are fetched from the source AS and
+// stored in the database.
+type ServiceStore struct {
+	// IA is the local IA.
+	IA addr.IA
+	// DB stores the level 1 keys fetched from other ASes.
+	DB drkey.Lvl1ReadWrite
+	// SecretValues provides the secret values of the local AS.
+	SecretValues *SecretValueFactory
+	// Fetcher fetches the level 1 keys from other ASes.
+	Fetcher Lvl1Fetcher
+}
+
+// DeriveLvl1 derives the level 1 key K_{IA->dstIA} valid at valTime.
+func (s *ServiceStore) DeriveLvl1(ctx context.Context, dstIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	sv, err := s.SecretValues.GetSecretValue(ctx, valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	return drkey.DeriveLvl1(drkey.Lvl1Meta{SrcIA: s.IA, DstIA: dstIA}, sv)
+}
+
+// GetLvl1Key returns the level 1 key K_{srcIA->IA} valid at valTime. If the key is not in
+// the database, it is fetched from srcIA.
+func (s *ServiceStore) GetLvl1Key(ctx context.Context, srcIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	if srcIA.Equal(s.IA) {
+		return s.DeriveLvl1(ctx, s.IA, valTime)
+	}
+	key, err := s.DB.GetLvl1Key(ctx, srcIA, s.IA, valTime)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, drkey.ErrKeyNotFound) {
+		return drkey.Lvl1Key{}, serrors.WrapStr("reading level 1 key from database", err)
+	}
+	key, err = s.Fetcher.FetchLvl1(ctx, srcIA, valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	if err := s.DB.InsertLvl1Key(ctx, key); err != nil {
+		log.FromCtx(ctx).Info("[drkey] Failed to store level 1 key", "src_ia", srcIA,
+			"err", err)
+	}
+	return key, nil
+}
+
+// GetLvl2Key returns the level 2 key described by meta valid at valTime. The local AS must be
+// either the source or the destination AS of the key, otherwise ErrNotDerivable is returned.
+func (s *ServiceStore) GetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	var lvl1 drkey.Lvl1Key
+	var err error
+	switch {
+	case meta.SrcIA.Equal(s.IA):
+		lvl1, err = s.DeriveLvl1(ctx, meta.DstIA, valTime)
+	case meta.DstIA.Equal(s.IA):
+		lvl1, err = s.GetLvl1Key(ctx, meta.SrcIA, valTime)
+	default:
+		return drkey.Lvl2Key{}, serrors.WithCtx(ErrNotDerivable, "src_ia", meta.SrcIA,
+			"dst_ia", meta.DstIA)
+	}
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	return drkey.DeriveLvl2(meta, lvl1)
+}
+
+// DeleteExpiredKeys removes the expired level 1 keys and secret values from the database.
+func (s *ServiceStore) DeleteExpiredKeys(ctx context.Context) (int, error) {
+	n, err := s.DB.RemoveOutdatedLvl1Keys(ctx, time.Now())
+	if err != nil {
+		return n, err
+	}
+	svs, err := s.SecretValues.DeleteExpired(ctx)
+	return n + svs, err
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/sqlite"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func newTestStore(t *testing.T, ia addr.IA, masterKey string) *ServiceStore {
+	svs, err := NewSecretValueFactory([]byte(masterKey), time.Hour, nil)
+	require.NoError(t, err)
+	return &ServiceStore{IA: ia, SecretValues: svs}
+}
+
+func TestServiceStore(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "drkey-store")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := sqlite.New(filepath.Join(dir, "drkey.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	src := newTestStore(t, srcIA, "0123456789abcdef")
+	fetcher := &fakeFetcher{Src: src}
+	store := newTestStore(t, dstIA, "fedcba9876543210")
+	store.DB = db
+	store.Fetcher = fetcher
+	now := time.Now()
+
+	t.Run("level 1 fetched once", func(t *testing.T) {
+		expected, err := src.DeriveLvl1(ctx, dstIA, now)
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			key, err := store.GetLvl1Key(ctx, srcIA, now)
+			require.NoError(t, err)
+			assert.True(t, expected.Key.Equal(key.Key))
+		}
+		assert.Equal(t, 1, fetcher.Fetches)
+	})
+	t.Run("level 2 both sides", func(t *testing.T) {
+		meta := drkey.Lvl2Meta{
+			KeyType:  drkey.Host2Host,
+			Protocol: "test",
+			SrcIA:    srcIA,
+			DstIA:    dstIA,
+			SrcHost:  addr.HostFromIPStr("10.0.0.1"),
+			DstHost:  addr.HostFromIPStr("10.0.0.2"),
+		}
+		fromSrc, err := src.GetLvl2Key(ctx, meta, now)
+		require.NoError(t, err)
+		fromDst, err := store.GetLvl2Key(ctx, meta, now)
+		require.NoError(t, err)
+		assert.True(t, fromSrc.Key.Equal(fromDst.Key))
+	})
+	t.Run("level 2 not derivable", func(t *testing.T) {
+		meta := drkey.Lvl2Meta{
+			KeyType: drkey.AS2AS,
+			SrcIA:   srcIA,
+			DstIA:   xtest.MustParseIA("1-ff00:0:112"),
+		}
+		_, err := store.GetLvl2Key(ctx, meta, now)
+		assert.True(t, errors.Is(err, ErrNotDerivable))
+	})
+	t.Run("expired keys", func(t *testing.T) {
+		old := now.Add(-3 * time.Hour)
+		_, err := store.GetLvl1Key(ctx, srcIA, old)
+		require.NoError(t, err)
+		n, err := store.DeleteExpiredKeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+}
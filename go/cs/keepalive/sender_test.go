@@ -49,7 +49,7 @@ func TestSenderRun(t *testing.T) {
 	mctrl := gomock.NewController(t)
 	defer mctrl.Finish()
 	topoProvider := itopotest.TopoProviderFromFile(t, "testdata/topology.json")
-	mac, err := scrypto.InitMac(make(common.RawBytes, 16))
+	mac, err := scrypto.HFMacFactory(make(common.RawBytes, 16))
 	require.NoError(t, err)
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
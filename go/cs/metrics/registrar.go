@@ -39,6 +39,21 @@ func (l RegistrarLabels) Values() []string {
 	return []string{l.StartIA.String(), l.InIfID.String(), l.SegType, l.Result}
 }
 
+// HPRegistrarLabels define the labels attached to hidden path registrar metrics.
+type HPRegistrarLabels struct {
+	GroupID, SegType, Result string
+}
+
+// Labels returns the name of the labels in correct order.
+func (l HPRegistrarLabels) Labels() []string {
+	return []string{"group_id", "seg_type", prom.LabelResult}
+}
+
+// Values returns the values of the label in correct order.
+func (l HPRegistrarLabels) Values() []string {
+	return []string{l.GroupID, l.SegType, l.Result}
+}
+
 // TypeOnlyLabel is used by clients to pass in a safe way labels
 // values to prometheus metric types (e.g. counter).
 type TypeOnlyLabel struct {
@@ -56,7 +71,7 @@ func (l TypeOnlyLabel) Values() []string {
 }
 
 type registrar struct {
-	registeredBeacons, runtime, internalErrors *prometheus.CounterVec
+	registeredBeacons, registeredHiddenBeacons, runtime, internalErrors *prometheus.CounterVec
 }
 
 func newRegistrar() registrar {
@@ -65,6 +80,10 @@ func newRegistrar() registrar {
 		registeredBeacons: prom.NewCounterVecWithLabels(ns, sub, "registered_beacons_total",
 			"Number of beacons registered",
 			RegistrarLabels{}),
+		registeredHiddenBeacons: prom.NewCounterVecWithLabels(ns, sub,
+			"registered_hidden_beacons_total",
+			"Number of beacons registered with hidden path registries",
+			HPRegistrarLabels{}),
 		runtime: prom.NewCounterVecWithLabels(ns, sub, "registrar_run_durations_seconds_total",
 			"Registrar total time spent on every periodic run", TypeOnlyLabel{"up"}),
 		internalErrors: prom.NewCounterVecWithLabels(ns, sub, "registrar_errors_total",
@@ -76,6 +95,10 @@ func (e *registrar) Beacons(l RegistrarLabels) prometheus.Counter {
 	return e.registeredBeacons.WithLabelValues(l.Values()...)
 }
 
+func (e *registrar) HiddenBeacons(l HPRegistrarLabels) prometheus.Counter {
+	return e.registeredHiddenBeacons.WithLabelValues(l.Values()...)
+}
+
 func (e *registrar) RuntimeWithType(s string) prometheus.Counter {
 	l := TypeOnlyLabel{SegType: s}
 	return e.runtime.WithLabelValues(l.Values()...)
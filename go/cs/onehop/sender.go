@@ -19,7 +19,6 @@ import (
 	"context"
 	"hash"
 	"net"
-	"sync"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -63,10 +62,10 @@ type Sender struct {
 	Addr *net.UDPAddr
 	// Conn is used to send the packets.
 	Conn snet.PacketConn
-	// macMtx protects the MAC.
-	macMtx sync.Mutex
-	// MAC is the mac to issue hop fields.
-	MAC hash.Hash
+	// MAC creates the MAC instances to issue hop fields. A new instance is
+	// created for every path, such that a master key rotation takes effect
+	// immediately.
+	MAC func() hash.Hash
 	// HeaderV2 indicates the new header format should be used.
 	HeaderV2 bool
 }
@@ -110,16 +109,15 @@ func (s *Sender) CreatePkt(msg *Msg) (*snet.Packet, error) {
 
 // CreatePath creates the one-hop path and initializes it.
 func (s *Sender) CreatePath(ifid common.IFIDType, now time.Time) (*Path, error) {
-	s.macMtx.Lock()
-	defer s.macMtx.Unlock()
+	mac := s.MAC()
 	if s.HeaderV2 {
-		path, err := spath.NewOneHopV2(s.IA.I, ifid, now, spath.DefaultHopFExpiry, s.MAC)
+		path, err := spath.NewOneHopV2(s.IA.I, ifid, now, spath.DefaultHopFExpiry, mac)
 		if err != nil {
 			return nil, err
 		}
 		return (*Path)(path), nil
 	}
-	path := spath.NewOneHop(s.IA.I, ifid, now, spath.DefaultHopFExpiry, s.MAC)
+	path := spath.NewOneHop(s.IA.I, ifid, now, spath.DefaultHopFExpiry, mac)
 	return (*Path)(path), path.InitOffsets()
 }
 
@@ -65,7 +65,7 @@ func TestSenderCreatePath(t *testing.T) {
 		SoMsg("Hop.ExpTime", hop.ExpTime, ShouldEqual, spath.DefaultHopFExpiry)
 		SoMsg("Hop.ConsIngress", hop.ConsIngress, ShouldEqual, 0)
 		SoMsg("Hop.ConsEgress", hop.ConsEgress, ShouldEqual, 12)
-		SoMsg("Hop.Verify", hop.Verify(s.MAC, info.TsInt, nil), ShouldBeNil)
+		SoMsg("Hop.Verify", hop.Verify(s.MAC(), info.TsInt, nil), ShouldBeNil)
 
 		// Second hop field set.
 		err = path.IncOffsets()
@@ -161,8 +161,8 @@ func (conn *testConn) ReadFrom(b []byte) (int, net.Addr, error) {
 	return n, &net.UDPAddr{}, err
 }
 
-func createMac(t *testing.T) hash.Hash {
-	mac, err := scrypto.InitMac(make(common.RawBytes, 16))
+func createMac(t *testing.T) func() hash.Hash {
+	mac, err := scrypto.HFMacFactory(make(common.RawBytes, 16))
 	xtest.FailOnErr(t, err)
 	return mac
 }
@@ -39,6 +39,9 @@ type BaseSetupReq struct {
 	ID          reservation.E2EID    // the ID this request refers to
 	timestamp   time.Time            // the mandatory timestamp
 	reservation *Reservation         // nil if no reservation yet
+	// SegmentRsvs are the segment reservations the e2e one uses in this AS. They are used
+	// to create the e2e reservation if it does not exist yet.
+	SegmentRsvs []reservation.SegmentID
 }
 
 func NewBaseSetupReq(path *spath.Path, ts time.Time,
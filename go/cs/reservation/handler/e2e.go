@@ -0,0 +1,145 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// e2eSetup admits an e2e reservation setup or renewal. The segment reservation in the request
+// is the one the e2e reservation uses, needed only if the AS does not know the e2e reservation
+// yet. Stitching several segment reservations is not supported by these requests.
+func (h *Handler) e2eSetup(ctx context.Context, req *request,
+	setup *colibri_mgmt.E2ESetup) (*colibri_mgmt.Response, error) {
+
+	setupReq, err := newE2ESetupReq(req, setup)
+	if err != nil {
+		return nil, err
+	}
+	respond := func(accepted bool, failedHop int) *colibri_mgmt.Response {
+		res := newResponse(req, accepted, failedHop)
+		if req.pld.Request.Which == proto.Request_Which_e2eRenewal {
+			res.E2ERenewal = setup
+		} else {
+			res.E2ESetup = setup
+		}
+		return res
+	}
+	successReq, ok := setupReq.(*e2e.SuccessSetupReq)
+	if !ok {
+		// failed setups reserve nothing, they are only forwarded along the path
+		return h.applyAndForward(ctx, req, func() error { return nil }, respond), nil
+	}
+	if err := h.Store.AdmitE2EReservation(ctx, setupReq); err != nil {
+		log.FromCtx(ctx).Info("[colibri] E2E reservation not admitted", "id", successReq.ID,
+			"err", err)
+		return respond(false, req.step), nil
+	}
+	if req.isLast() {
+		return respond(true, 0), nil
+	}
+	res := h.forwardOrFail(ctx, req, func(failedHop int) *colibri_mgmt.Response {
+		return respond(false, failedHop)
+	})
+	if !res.Accepted {
+		undo(ctx, h.Store.CleanupE2EReservation(ctx, successReq.ID, successReq.Token.Idx))
+	}
+	return res, nil
+}
+
+// e2eCleanup removes an index of an e2e reservation. The index is the one of the request.
+func (h *Handler) e2eCleanup(ctx context.Context, req *request,
+	cleanup *colibri_mgmt.E2ECleanup) (*colibri_mgmt.Response, error) {
+
+	if cleanup == nil {
+		return nil, serrors.New("empty e2e cleanup request")
+	}
+	id, err := e2eIDFromCtrl(cleanup.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+	idx := reservation.IndexNumber(req.pld.Index)
+	return h.applyAndForward(ctx, req,
+		func() error { return h.Store.CleanupE2EReservation(ctx, id, idx) },
+		func(accepted bool, failedHop int) *colibri_mgmt.Response {
+			res := newResponse(req, accepted, failedHop)
+			res.E2ECleanup = cleanup
+			return res
+		}), nil
+}
+
+func newE2ESetupReq(req *request, setup *colibri_mgmt.E2ESetup) (e2e.SetupReq, error) {
+	if setup == nil {
+		return nil, serrors.New("empty e2e setup request")
+	}
+	id, err := e2eIDFromCtrl(setup.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+	base := e2e.BaseSetupReq{ID: id}
+	if req.pld.SegmentID != nil {
+		segID, err := segmentIDFromCtrl(req.pld.SegmentID)
+		if err != nil {
+			return nil, err
+		}
+		base.SegmentRsvs = []reservation.SegmentID{segID}
+	}
+	switch {
+	case setup.Which == proto.E2ESetupData_Which_success && setup.Success != nil:
+		tok, err := reservation.TokenFromRaw(setup.Success.Token)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid e2e token", err)
+		}
+		if tok == nil {
+			return nil, serrors.New("missing e2e token")
+		}
+		return &e2e.SuccessSetupReq{BaseSetupReq: base, Token: *tok}, nil
+	case setup.Which == proto.E2ESetupData_Which_failure && setup.Failure != nil:
+		inf, err := reservation.InfoFieldFromRaw(setup.Failure.InfoField)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid e2e info field", err)
+		}
+		bwTrail := make([]reservation.BWCls, len(setup.Failure.MaxBWs))
+		for i, bw := range setup.Failure.MaxBWs {
+			bwTrail[i] = reservation.BWCls(bw)
+		}
+		return &e2e.FailureSetupReq{
+			BaseSetupReq: base,
+			ErrorCode:    int(setup.Failure.ErrorCode),
+			InfoField:    *inf,
+			MaxBWTrail:   bwTrail,
+		}, nil
+	default:
+		return nil, serrors.New("e2e setup request neither successful nor failed")
+	}
+}
+
+func e2eIDFromCtrl(id *colibri_mgmt.E2EReservationID) (reservation.E2EID, error) {
+	if id == nil {
+		return reservation.E2EID{}, serrors.New("missing e2e reservation ID")
+	}
+	e2eID, err := reservation.E2EIDFromRawBuffers(id.ASID, id.Suffix)
+	if err != nil {
+		return reservation.E2EID{}, serrors.WrapStr("invalid e2e reservation ID", err)
+	}
+	return *e2eID, nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package handler contains the control service handler for COLIBRI requests.
+//
+// A request travels along the reservation path from the AS that initiates it to the last AS
+// of the path. Every AS validates the request, applies it to its reservation store and
+// forwards it to the control service of the next AS. The response of the last AS travels back
+// along the same ASes to the initiator. If the request fails somewhere on the path, the ASes
+// before the failing one revert the changes they applied, e.g. by removing the newly admitted
+// index.
+package handler
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// RPC sends COLIBRI requests to the control service of another AS.
+type RPC interface {
+	RequestColibri(ctx context.Context, msg *colibri_mgmt.ColibriRequestPayload, a net.Addr,
+		id uint64) (*colibri_mgmt.ColibriRequestPayload, error)
+}
+
+// Handler handles the COLIBRI requests received by the control service.
+type Handler struct {
+	// IA is the local IA.
+	IA addr.IA
+	// Store is the local reservation store.
+	Store reservationstorage.Store
+	// RPC is used to forward the requests to the next AS on the reservation path.
+	RPC RPC
+	// Router finds the paths to the control service of the next AS.
+	Router snet.Router
+}
+
+// Handle handles a COLIBRI request. Malformed requests are rejected with an ack, while requests
+// denied by any AS on the path are answered with a not accepted response.
+func (h *Handler) Handle(r *infra.Request) *infra.HandlerResult {
+	ctx := r.Context()
+	logger := log.FromCtx(ctx)
+	pld, ok := r.Message.(*colibri_mgmt.ColibriRequestPayload)
+	if !ok || pld.Which != proto.ColibriRequestPayload_Which_request || pld.Request == nil {
+		logger.Error("[colibri] Wrong message type, expected COLIBRI request",
+			"msg", r.Message, "type", common.TypeOf(r.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(ctx)
+	if !ok {
+		logger.Error("[colibri] Unable to service request, no ResponseWriter found")
+		return infra.MetricsErrInternal
+	}
+	sendAck := messenger.SendAckHelper(ctx, rw)
+	logger.Debug("[colibri] Received request", "type", pld.Request.Which, "peer", r.Peer)
+
+	req, err := h.parse(pld, r.Peer)
+	if err != nil {
+		logger.Info("[colibri] Invalid request", "err", err)
+		sendAck(proto.Ack_ErrCode_reject, err.Error())
+		return infra.MetricsErrInvalid
+	}
+	res, err := h.handle(ctx, req)
+	if err != nil {
+		logger.Info("[colibri] Unable to handle request", "err", err)
+		sendAck(proto.Ack_ErrCode_reject, err.Error())
+		return infra.MetricsErrInvalid
+	}
+	reply := &colibri_mgmt.ColibriRequestPayload{
+		Timestamp: pld.Timestamp,
+		Which:     proto.ColibriRequestPayload_Which_response,
+		Response:  res,
+		SegmentID: req.pld.SegmentID,
+		Index:     req.pld.Index,
+		Path:      req.pld.Path,
+	}
+	if err := rw.SendColibriReply(ctx, reply); err != nil {
+		logger.Error("[colibri] Messenger API error", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	logger.Debug("[colibri] Replied", "type", res.Which, "accepted", res.Accepted,
+		"failed_hop", res.FailedHop)
+	return infra.MetricsResultOk
+}
+
+// request is a COLIBRI request together with the position of this AS on its path.
+type request struct {
+	pld  *colibri_mgmt.ColibriRequestPayload
+	path segment.Path
+	step int // index of this AS in path
+}
+
+func (r *request) timestamp() time.Time {
+	return time.Unix(int64(r.pld.Timestamp), 0)
+}
+
+func (r *request) isLast() bool {
+	return r.step == len(r.path)-1
+}
+
+// parse validates the path of the request and finds this AS on it. Requests must come from the
+// previous AS on the path, or from the local AS if this is the first one. Requests received over
+// TCP are from within the local AS.
+func (h *Handler) parse(pld *colibri_mgmt.ColibriRequestPayload,
+	peer net.Addr) (*request, error) {
+
+	path, err := segment.NewPathFromRaw(pld.Path)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot parse reservation path", err)
+	}
+	if err := path.Validate(); err != nil {
+		return nil, serrors.WrapStr("invalid reservation path", err)
+	}
+	step := -1
+	for i := range path {
+		if path[i].IA.Equal(h.IA) {
+			step = i
+			break
+		}
+	}
+	if step == -1 {
+		return nil, serrors.New("local AS not on the reservation path", "path", path)
+	}
+	expected := h.IA
+	if step > 0 {
+		expected = path[step-1].IA
+	}
+	var actual addr.IA
+	switch p := peer.(type) {
+	case *snet.UDPAddr:
+		actual = p.IA
+	case *net.TCPAddr:
+		actual = h.IA
+	default:
+		return nil, serrors.New("unsupported peer address", "type", common.TypeOf(peer))
+	}
+	if !actual.Equal(expected) {
+		return nil, serrors.New("request not sent by the previous AS on the path",
+			"expected", expected, "actual", actual)
+	}
+	return &request{pld: pld, path: path, step: step}, nil
+}
+
+// handle applies the request locally and forwards it along the path. It returns the response
+// for the previous AS, or an error if the request cannot be handled at all.
+func (h *Handler) handle(ctx context.Context, req *request) (*colibri_mgmt.Response, error) {
+	r := req.pld.Request
+	switch r.Which {
+	case proto.Request_Which_segmentSetup:
+		return h.segmentSetup(ctx, req, r.SegmentSetup)
+	case proto.Request_Which_segmentRenewal:
+		return h.segmentSetup(ctx, req, r.SegmentRenewal)
+	case proto.Request_Which_segmentIndexConfirmation:
+		return h.segmentIndexConfirmation(ctx, req, r.SegmentIndexConfirmation)
+	case proto.Request_Which_segmentCleanup:
+		return h.segmentCleanup(ctx, req, r.SegmentCleanup)
+	case proto.Request_Which_segmentTeardown:
+		return h.segmentTeardown(ctx, req)
+	case proto.Request_Which_e2eSetup:
+		return h.e2eSetup(ctx, req, r.E2ESetup)
+	case proto.Request_Which_e2eRenewal:
+		return h.e2eSetup(ctx, req, r.E2ERenewal)
+	case proto.Request_Which_e2eCleanup:
+		return h.e2eCleanup(ctx, req, r.E2ECleanup)
+	default:
+		return nil, serrors.New("unsupported request type", "type", r.Which)
+	}
+}
+
+// forward sends the request to the control service of the next AS on the path and returns
+// its response.
+func (h *Handler) forward(ctx context.Context, req *request) (*colibri_mgmt.Response, error) {
+	next := req.path[req.step+1].IA
+	path, err := h.Router.Route(ctx, next)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot find path to next AS", err, "next", next)
+	}
+	if path == nil {
+		return nil, serrors.New("no path to next AS", "next", next)
+	}
+	dst := &snet.SVCAddr{
+		IA:      next,
+		Path:    path.Path(),
+		NextHop: path.UnderlayNextHop(),
+		SVC:     addr.SvcCS,
+	}
+	reply, err := h.RPC.RequestColibri(ctx, req.pld, dst, messenger.NextId())
+	if err != nil {
+		return nil, serrors.WrapStr("cannot forward request", err, "next", next)
+	}
+	if reply.Which != proto.ColibriRequestPayload_Which_response || reply.Response == nil {
+		return nil, serrors.New("reply is not a response", "next", next, "type", reply.Which)
+	}
+	if reply.Response.Which != proto.Response_Which(req.pld.Request.Which) {
+		return nil, serrors.New("response type does not match request", "next", next,
+			"request", req.pld.Request.Which, "response", reply.Response.Which)
+	}
+	return reply.Response, nil
+}
+
+// forwardOrFail forwards the request. A failure to forward is reported as a response not
+// accepted by the next AS, built with failed.
+func (h *Handler) forwardOrFail(ctx context.Context, req *request,
+	failed func(failedHop int) *colibri_mgmt.Response) *colibri_mgmt.Response {
+
+	res, err := h.forward(ctx, req)
+	if err != nil {
+		log.FromCtx(ctx).Info("[colibri] Forwarding failed", "err", err)
+		return failed(req.step + 1)
+	}
+	return res
+}
+
+// undo logs a failure to revert the local changes of a request that failed further on the
+// path. The changes expire with the index anyways.
+func undo(ctx context.Context, err error) {
+	if err != nil {
+		log.FromCtx(ctx).Info("[colibri] Unable to revert failed request", "err", err)
+	}
+}
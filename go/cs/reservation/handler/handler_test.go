@@ -0,0 +1,460 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/handler"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/cs/reservationstore"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/ack"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	ia1 = xtest.MustParseIA("1-ff00:0:1")
+	ia2 = xtest.MustParseIA("1-ff00:0:2")
+	ia3 = xtest.MustParseIA("1-ff00:0:3")
+)
+
+func TestSegmentReservationLifecycle(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	n := newTestNet(t, mctrl)
+	defer n.Close()
+	ctx := context.Background()
+
+	res, ok := n.Request(t, newSegmentSetup(nil, 0))
+	require.False(t, ok)
+	require.True(t, res.Response.Accepted, "%v", res.Response)
+	require.Equal(t, proto.Response_Which_segmentSetup, res.Response.Which)
+	require.NotNil(t, res.SegmentID)
+	id := n.segmentID(t, res.SegmentID)
+	require.Equal(t, ia1.A, id.ASID)
+	require.Equal(t, uint8(0), res.Index)
+	for _, ia := range []addr.IA{ia1, ia2, ia3} {
+		rsv, err := n.dbs[ia].GetSegmentRsvFromID(ctx, &id)
+		require.NoError(t, err)
+		require.NotNil(t, rsv, "AS %s", ia)
+		require.Len(t, rsv.Indices, 1)
+		require.Equal(t, segment.IndexTemporary, rsv.Indices[0].State())
+	}
+
+	for _, state := range []proto.ReservationIndexState{
+		proto.ReservationIndexState_pending, proto.ReservationIndexState_active} {
+
+		res, ok = n.Request(t, newIndexConfirmation(res.SegmentID, 0, state))
+		require.False(t, ok)
+		require.True(t, res.Response.Accepted, "%v", res.Response)
+	}
+	for _, ia := range []addr.IA{ia1, ia2, ia3} {
+		rsv, err := n.dbs[ia].GetSegmentRsvFromID(ctx, &id)
+		require.NoError(t, err)
+		require.NotNil(t, rsv.ActiveIndex(), "AS %s", ia)
+	}
+
+	// renewal keeps the same ID
+	res, ok = n.Request(t, newSegmentSetup(res.SegmentID, 0))
+	require.False(t, ok)
+	require.True(t, res.Response.Accepted, "%v", res.Response)
+	require.Equal(t, id, n.segmentID(t, res.SegmentID))
+	require.Equal(t, uint8(1), res.Index)
+	res, ok = n.Request(t, newSegmentCleanup(res.SegmentID, 1))
+	require.False(t, ok)
+	require.True(t, res.Response.Accepted, "%v", res.Response)
+
+	// e2e reservation on top of the segment reservation
+	e2eID := &colibri_mgmt.E2EReservationID{
+		ASID:   []byte{0xff, 0, 0, 0, 0, 1},
+		Suffix: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+	}
+	res, ok = n.Request(t, newE2ESetup(res.SegmentID, e2eID))
+	require.False(t, ok)
+	require.True(t, res.Response.Accepted, "%v", res.Response)
+	for _, ia := range []addr.IA{ia1, ia2, ia3} {
+		rsvID, err := reservation.E2EIDFromRawBuffers(e2eID.ASID, e2eID.Suffix)
+		require.NoError(t, err)
+		rsv, err := n.dbs[ia].GetE2ERsvFromID(ctx, rsvID)
+		require.NoError(t, err)
+		require.NotNil(t, rsv, "AS %s", ia)
+		require.Len(t, rsv.Indices, 1)
+	}
+}
+
+func TestSegmentSetupFailure(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	n := newTestNet(t, mctrl)
+	defer n.Close()
+	ctx := context.Background()
+
+	n.admitters[ia3].err = serrors.New("admission denied")
+	res, ok := n.Request(t, newSegmentSetup(nil, 0))
+	require.False(t, ok)
+	require.False(t, res.Response.Accepted)
+	require.Equal(t, uint8(2), res.Response.FailedHop)
+	require.NotNil(t, res.Response.SegmentSetup)
+	require.Equal(t, proto.SegmentSetupResData_Which_failure, res.Response.SegmentSetup.Which)
+	// the allocation trail contains the beads of the ASes before the failing one
+	require.Len(t, res.Response.SegmentSetup.Failure.AllocationTrail, 2)
+	id := n.segmentID(t, res.SegmentID)
+	for _, ia := range []addr.IA{ia1, ia2, ia3} {
+		rsv, err := n.dbs[ia].GetSegmentRsvFromID(ctx, &id)
+		require.NoError(t, err)
+		require.Nil(t, rsv, "AS %s", ia)
+	}
+}
+
+func TestInvalidRequests(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	n := newTestNet(t, mctrl)
+	defer n.Close()
+
+	cases := map[string]*colibri_mgmt.ColibriRequestPayload{
+		"no path": func() *colibri_mgmt.ColibriRequestPayload {
+			pld := newSegmentSetup(nil, 0)
+			pld.Path = nil
+			return pld
+		}(),
+		"local AS not on path": func() *colibri_mgmt.ColibriRequestPayload {
+			pld := newSegmentSetup(nil, 0)
+			pld.Path = segment.Path{
+				{PathStep: segment.PathStep{Ingress: 0, Egress: 1}, IA: ia2},
+				{PathStep: segment.PathStep{Ingress: 2, Egress: 0}, IA: ia3},
+			}.ToRaw()
+			return pld
+		}(),
+		"missing ID": newIndexConfirmation(nil, 0, proto.ReservationIndexState_active),
+		"unsupported": func() *colibri_mgmt.ColibriRequestPayload {
+			pld := newSegmentSetup(nil, 0)
+			pld.Request = &colibri_mgmt.Request{
+				Which:             proto.Request_Which_segmentTelesSetup,
+				SegmentTelesSetup: &colibri_mgmt.SegmentTelesSetup{},
+			}
+			return pld
+		}(),
+	}
+	for name, pld := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, ok := n.Request(t, pld)
+			require.True(t, ok)
+		})
+	}
+}
+
+func TestRequestPeers(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	cases := map[string]struct {
+		peer     net.Addr
+		to       addr.IA
+		rejected bool
+	}{
+		"local AS at first AS": {
+			peer: &snet.UDPAddr{IA: ia1},
+			to:   ia1,
+		},
+		"other AS": {
+			peer:     &snet.UDPAddr{IA: ia3},
+			to:       ia2,
+			rejected: true,
+		},
+		"local TCP peer at first AS": {
+			peer: &net.TCPAddr{IP: net.IP{127, 0, 0, 1}, Port: 4000},
+			to:   ia1,
+		},
+		"local TCP peer at later AS": {
+			peer:     &net.TCPAddr{IP: net.IP{127, 0, 0, 1}, Port: 4000},
+			to:       ia2,
+			rejected: true,
+		},
+		"unknown peer type": {
+			peer:     &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 4000},
+			to:       ia1,
+			rejected: true,
+		},
+		"no peer": {
+			to:       ia1,
+			rejected: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := newTestNet(t, mctrl)
+			defer n.Close()
+			_, err := n.sendFrom(tc.peer, tc.to, newSegmentSetup(nil, 0))
+			if tc.rejected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// testNet is a chain of three ASes 1-ff00:0:1 -> 1-ff00:0:2 -> 1-ff00:0:3, each with its own
+// COLIBRI handler and reservation store.
+type testNet struct {
+	handlers  map[addr.IA]*handler.Handler
+	dbs       map[addr.IA]*sqlite.Backend
+	admitters map[addr.IA]*testAdmitter
+}
+
+func newTestNet(t *testing.T, mctrl *gomock.Controller) *testNet {
+	path := mock_snet.NewMockPath(mctrl)
+	path.EXPECT().Path().Return(&spath.Path{}).AnyTimes()
+	path.EXPECT().UnderlayNextHop().Return(&net.UDPAddr{}).AnyTimes()
+	router := mock_snet.NewMockRouter(mctrl)
+	router.EXPECT().Route(gomock.Any(), gomock.Any()).Return(path, nil).AnyTimes()
+
+	n := &testNet{
+		handlers:  make(map[addr.IA]*handler.Handler),
+		dbs:       make(map[addr.IA]*sqlite.Backend),
+		admitters: make(map[addr.IA]*testAdmitter),
+	}
+	ifs := map[addr.IA]topology.IfInfoMap{
+		ia1: {1: topology.IFInfo{LinkType: topology.Child}},
+		ia2: {
+			2: topology.IFInfo{LinkType: topology.Parent},
+			3: topology.IFInfo{LinkType: topology.Child},
+		},
+		ia3: {4: topology.IFInfo{LinkType: topology.Parent}},
+	}
+	for ia, ifInfo := range ifs {
+		db, err := sqlite.New("file::memory:")
+		require.NoError(t, err)
+		topo := topology.FromRWTopology(&topology.RWTopology{IA: ia, IFInfoMap: ifInfo})
+		n.dbs[ia] = db
+		n.admitters[ia] = &testAdmitter{alloc: 11}
+		n.handlers[ia] = &handler.Handler{
+			IA:     ia,
+			Store:  reservationstore.NewStore(db, n.admitters[ia], topo),
+			RPC:    &testRPC{net: n, from: ia},
+			Router: router,
+		}
+	}
+	return n
+}
+
+// Request sends the request to the first AS on the path, as the initiator would do. It returns
+// the reply, or whether the request was rejected with an ack.
+func (n *testNet) Request(t *testing.T,
+	pld *colibri_mgmt.ColibriRequestPayload) (*colibri_mgmt.ColibriRequestPayload, bool) {
+
+	t.Helper()
+	reply, err := n.send(ia1, ia1, pld)
+	if err != nil {
+		return nil, true
+	}
+	require.Equal(t, proto.ColibriRequestPayload_Which_response, reply.Which)
+	return reply, false
+}
+
+// send serializes the request and hands it to the handler of the destination AS.
+func (n *testNet) send(from, to addr.IA,
+	pld *colibri_mgmt.ColibriRequestPayload) (*colibri_mgmt.ColibriRequestPayload, error) {
+
+	return n.sendFrom(&snet.UDPAddr{IA: from}, to, pld)
+}
+
+// sendFrom is like send, but the request is received from the given peer.
+func (n *testNet) sendFrom(peer net.Addr, to addr.IA,
+	pld *colibri_mgmt.ColibriRequestPayload) (*colibri_mgmt.ColibriRequestPayload, error) {
+
+	raw, err := pld.PackRoot()
+	if err != nil {
+		return nil, err
+	}
+	if pld, err = colibri_mgmt.NewFromRaw(raw); err != nil {
+		return nil, err
+	}
+	h, ok := n.handlers[to]
+	if !ok {
+		return nil, serrors.New("unknown AS", "ia", to)
+	}
+	rw := &testResponseWriter{}
+	ctx := infra.NewContextWithResponseWriter(context.Background(), rw)
+	h.Handle(infra.NewRequest(ctx, pld, nil, peer, 1))
+	if rw.ack != nil {
+		return nil, serrors.New("request rejected", "desc", rw.ack.ErrDesc)
+	}
+	if rw.reply == nil {
+		return nil, serrors.New("no reply")
+	}
+	return rw.reply, nil
+}
+
+func (n *testNet) segmentID(t *testing.T,
+	id *colibri_mgmt.SegmentReservationID) reservation.SegmentID {
+
+	t.Helper()
+	segID, err := reservation.SegmentIDFromRawBuffers(id.ASID, id.Suffix)
+	require.NoError(t, err)
+	return *segID
+}
+
+func (n *testNet) Close() {
+	for _, db := range n.dbs {
+		db.Close()
+	}
+}
+
+type testRPC struct {
+	net  *testNet
+	from addr.IA
+}
+
+func (r *testRPC) RequestColibri(_ context.Context, msg *colibri_mgmt.ColibriRequestPayload,
+	a net.Addr, _ uint64) (*colibri_mgmt.ColibriRequestPayload, error) {
+
+	return r.net.send(r.from, a.(*snet.SVCAddr).IA, msg)
+}
+
+type testResponseWriter struct {
+	infra.ResponseWriter
+	reply *colibri_mgmt.ColibriRequestPayload
+	ack   *ack.Ack
+}
+
+func (rw *testResponseWriter) SendColibriReply(_ context.Context,
+	msg *colibri_mgmt.ColibriRequestPayload) error {
+
+	rw.reply = msg
+	return nil
+}
+
+func (rw *testResponseWriter) SendAckReply(_ context.Context, msg *ack.Ack) error {
+	rw.ack = msg
+	return nil
+}
+
+// testAdmitter grants always the same bandwidth, or fails with err.
+type testAdmitter struct {
+	alloc reservation.BWCls
+	err   error
+}
+
+func (a *testAdmitter) AdmitRsv(_ context.Context, _ backend.TransitOnly,
+	req *segment.SetupReq) error {
+
+	if a.err != nil {
+		return a.err
+	}
+	req.AllocTrail = append(req.AllocTrail, reservation.AllocationBead{
+		AllocBW: uint8(a.alloc),
+		MaxBW:   uint8(a.alloc),
+	})
+	return nil
+}
+
+func newPayload(id *colibri_mgmt.SegmentReservationID, index uint8,
+	req *colibri_mgmt.Request) *colibri_mgmt.ColibriRequestPayload {
+
+	path := segment.Path{
+		{PathStep: segment.PathStep{Ingress: 0, Egress: 1}, IA: ia1},
+		{PathStep: segment.PathStep{Ingress: 2, Egress: 3}, IA: ia2},
+		{PathStep: segment.PathStep{Ingress: 4, Egress: 0}, IA: ia3},
+	}
+	return &colibri_mgmt.ColibriRequestPayload{
+		Timestamp: uint32(time.Now().Unix()),
+		Which:     proto.ColibriRequestPayload_Which_request,
+		Request:   req,
+		SegmentID: id,
+		Index:     index,
+		Path:      path.ToRaw(),
+	}
+}
+
+func newSegmentSetup(id *colibri_mgmt.SegmentReservationID,
+	index uint8) *colibri_mgmt.ColibriRequestPayload {
+
+	setup := &colibri_mgmt.SegmentSetup{
+		MinBW:           1,
+		MaxBW:           13,
+		AllocationTrail: []*colibri_mgmt.AllocationBeads{},
+	}
+	req := &colibri_mgmt.Request{
+		Which:        proto.Request_Which_segmentSetup,
+		SegmentSetup: setup,
+	}
+	return newPayload(id, index, req)
+}
+
+func newIndexConfirmation(id *colibri_mgmt.SegmentReservationID, index uint8,
+	state proto.ReservationIndexState) *colibri_mgmt.ColibriRequestPayload {
+
+	return newPayload(id, index, &colibri_mgmt.Request{
+		Which: proto.Request_Which_segmentIndexConfirmation,
+		SegmentIndexConfirmation: &colibri_mgmt.SegmentIndexConfirmation{
+			Index: index,
+			State: state,
+		},
+	})
+}
+
+func newSegmentCleanup(id *colibri_mgmt.SegmentReservationID,
+	index uint8) *colibri_mgmt.ColibriRequestPayload {
+
+	return newPayload(id, index, &colibri_mgmt.Request{
+		Which: proto.Request_Which_segmentCleanup,
+		SegmentCleanup: &colibri_mgmt.SegmentCleanup{
+			ID:    id,
+			Index: index,
+		},
+	})
+}
+
+func newE2ESetup(segID *colibri_mgmt.SegmentReservationID,
+	id *colibri_mgmt.E2EReservationID) *colibri_mgmt.ColibriRequestPayload {
+
+	token := reservation.Token{
+		InfoField: reservation.InfoField{
+			ExpirationTick: reservation.TickFromTime(time.Now().Add(16 * time.Second)),
+			BWCls:          5,
+			PathType:       reservation.E2EPath,
+		},
+		HopFields: []spath.HopField{{ConsIngress: common.IFIDType(1)}},
+	}
+	return newPayload(segID, 0, &colibri_mgmt.Request{
+		Which: proto.Request_Which_e2eSetup,
+		E2ESetup: &colibri_mgmt.E2ESetup{
+			ReservationID: id,
+			Which:         proto.E2ESetupData_Which_success,
+			Success:       &colibri_mgmt.E2ESetupSuccess{Token: token.ToRaw()},
+		},
+	})
+}
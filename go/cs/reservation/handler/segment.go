@@ -0,0 +1,241 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// segmentSetup admits a segment reservation setup or renewal. The initiator may omit the
+// reservation ID to create a new reservation. The ID and the index admitted by the initiator
+// are set in the request, and every other AS on the path must admit the same index.
+func (h *Handler) segmentSetup(ctx context.Context, req *request,
+	setup *colibri_mgmt.SegmentSetup) (*colibri_mgmt.Response, error) {
+
+	if setup == nil {
+		return nil, serrors.New("empty segment setup request")
+	}
+	var id reservation.SegmentID
+	if req.step > 0 || req.pld.SegmentID != nil {
+		var err error
+		if id, err = segmentIDFromCtrl(req.pld.SegmentID); err != nil {
+			return nil, err
+		}
+	}
+	setupReq := newSetupReq(req, id, setup)
+	logger := log.FromCtx(ctx)
+	failed := func(failedHop int) *colibri_mgmt.Response {
+		return setupResponse(req, &colibri_mgmt.SegmentSetupRes{
+			Which:   proto.SegmentSetupResData_Which_failure,
+			Failure: setupReq.ToCtrlMsg(),
+		}, false, failedHop)
+	}
+
+	idx, err := h.Store.AdmitSegmentReservation(ctx, setupReq)
+	if err != nil {
+		logger.Info("[colibri] Segment reservation not admitted", "id", setupReq.ID, "err", err)
+		return failed(req.step), nil
+	}
+	if req.step == 0 {
+		req.pld.SegmentID = segmentIDToCtrl(setupReq.ID)
+		req.pld.Index = uint8(idx)
+	} else if idx != reservation.IndexNumber(req.pld.Index) {
+		logger.Info("[colibri] Admitted index differs from the initiator's", "id", setupReq.ID,
+			"index", idx, "expected", req.pld.Index)
+		undo(ctx, h.Store.CleanupSegmentReservation(ctx, setupReq.ID, idx))
+		return failed(req.step), nil
+	}
+	if req.isLast() {
+		return setupResponse(req, &colibri_mgmt.SegmentSetupRes{
+			Which: proto.SegmentSetupResData_Which_token,
+		}, true, 0), nil
+	}
+	// the next AS continues the allocation trail
+	if req.pld.Request.Which == proto.Request_Which_segmentRenewal {
+		req.pld.Request.SegmentRenewal = setupReq.ToCtrlMsg()
+	} else {
+		req.pld.Request.SegmentSetup = setupReq.ToCtrlMsg()
+	}
+	res := h.forwardOrFail(ctx, req, failed)
+	if !res.Accepted {
+		undo(ctx, h.Store.CleanupSegmentReservation(ctx, setupReq.ID, idx))
+	}
+	return res, nil
+}
+
+// segmentIndexConfirmation confirms or activates an index of a segment reservation.
+func (h *Handler) segmentIndexConfirmation(ctx context.Context, req *request,
+	conf *colibri_mgmt.SegmentIndexConfirmation) (*colibri_mgmt.Response, error) {
+
+	if conf == nil {
+		return nil, serrors.New("empty segment index confirmation request")
+	}
+	id, err := segmentIDFromCtrl(req.pld.SegmentID)
+	if err != nil {
+		return nil, err
+	}
+	idx := reservation.IndexNumber(conf.Index)
+	var apply func() error
+	switch conf.State {
+	case proto.ReservationIndexState_pending:
+		apply = func() error { return h.Store.ConfirmSegmentReservation(ctx, id, idx) }
+	case proto.ReservationIndexState_active:
+		apply = func() error { return h.Store.ActivateSegmentReservation(ctx, id, idx) }
+	default:
+		return nil, serrors.New("unknown index state", "state", conf.State)
+	}
+	return h.applyAndForward(ctx, req, apply,
+		func(accepted bool, failedHop int) *colibri_mgmt.Response {
+			res := newResponse(req, accepted, failedHop)
+			res.SegmentIndexConfirmation = conf
+			return res
+		}), nil
+}
+
+// segmentCleanup removes an index of a segment reservation, e.g. after a failed setup.
+func (h *Handler) segmentCleanup(ctx context.Context, req *request,
+	cleanup *colibri_mgmt.SegmentCleanup) (*colibri_mgmt.Response, error) {
+
+	if cleanup == nil {
+		return nil, serrors.New("empty segment cleanup request")
+	}
+	id, err := segmentIDFromCtrl(cleanup.ID)
+	if err != nil {
+		return nil, err
+	}
+	idx := reservation.IndexNumber(cleanup.Index)
+	return h.applyAndForward(ctx, req,
+		func() error { return h.Store.CleanupSegmentReservation(ctx, id, idx) },
+		func(accepted bool, failedHop int) *colibri_mgmt.Response {
+			res := newResponse(req, accepted, failedHop)
+			res.SegmentCleanup = cleanup
+			return res
+		}), nil
+}
+
+// segmentTeardown removes a segment reservation. The reservation ID and its active index
+// are those of the request.
+func (h *Handler) segmentTeardown(ctx context.Context,
+	req *request) (*colibri_mgmt.Response, error) {
+
+	id, err := segmentIDFromCtrl(req.pld.SegmentID)
+	if err != nil {
+		return nil, err
+	}
+	idx := reservation.IndexNumber(req.pld.Index)
+	return h.applyAndForward(ctx, req,
+		func() error { return h.Store.TearDownSegmentReservation(ctx, id, idx) },
+		func(accepted bool, failedHop int) *colibri_mgmt.Response {
+			res := newResponse(req, accepted, failedHop)
+			res.SegmentTeardown = &colibri_mgmt.SegmentTeardownRes{}
+			if !accepted {
+				res.SegmentTeardown.ErrorCode = 1
+			}
+			return res
+		}), nil
+}
+
+// applyAndForward applies the request locally and forwards it if successful. Requests
+// handled this way do not need to be reverted when they fail further on the path.
+func (h *Handler) applyAndForward(ctx context.Context, req *request, apply func() error,
+	respond func(accepted bool, failedHop int) *colibri_mgmt.Response) *colibri_mgmt.Response {
+
+	if err := apply(); err != nil {
+		log.FromCtx(ctx).Info("[colibri] Request failed", "type", req.pld.Request.Which,
+			"err", err)
+		return respond(false, req.step)
+	}
+	if req.isLast() {
+		return respond(true, 0)
+	}
+	return h.forwardOrFail(ctx, req, func(failedHop int) *colibri_mgmt.Response {
+		return respond(false, failedHop)
+	})
+}
+
+func newSetupReq(req *request, id reservation.SegmentID,
+	setup *colibri_mgmt.SegmentSetup) *segment.SetupReq {
+
+	s := &segment.SetupReq{
+		Request: segment.Request{
+			ID:        id,
+			Timestamp: req.timestamp(),
+			Ingress:   req.path[req.step].Ingress,
+			Egress:    req.path[req.step].Egress,
+			Path:      req.path,
+		},
+		MinBW:      setup.MinBW,
+		MaxBW:      setup.MaxBW,
+		SplitCls:   setup.SplitCls,
+		AllocTrail: make([]reservation.AllocationBead, len(setup.AllocationTrail)),
+		PathProps: reservation.NewPathEndProps(setup.StartProps.Local, setup.StartProps.Transfer,
+			setup.EndProps.Local, setup.EndProps.Transfer),
+	}
+	for i, ab := range setup.AllocationTrail {
+		s.AllocTrail[i] = reservation.AllocationBead{
+			AllocBW: ab.AllocBW,
+			MaxBW:   ab.MaxBW,
+		}
+	}
+	return s
+}
+
+func setupResponse(req *request, setupRes *colibri_mgmt.SegmentSetupRes, accepted bool,
+	failedHop int) *colibri_mgmt.Response {
+
+	res := newResponse(req, accepted, failedHop)
+	if req.pld.Request.Which == proto.Request_Which_segmentRenewal {
+		res.SegmentRenewal = setupRes
+	} else {
+		res.SegmentSetup = setupRes
+	}
+	return res
+}
+
+// newResponse creates a response of the same type as the request, without the body.
+func newResponse(req *request, accepted bool, failedHop int) *colibri_mgmt.Response {
+	return &colibri_mgmt.Response{
+		Which:     proto.Response_Which(req.pld.Request.Which),
+		Accepted:  accepted,
+		FailedHop: uint8(failedHop),
+	}
+}
+
+func segmentIDFromCtrl(id *colibri_mgmt.SegmentReservationID) (reservation.SegmentID, error) {
+	if id == nil {
+		return reservation.SegmentID{}, serrors.New("missing segment reservation ID")
+	}
+	segID, err := reservation.SegmentIDFromRawBuffers(id.ASID, id.Suffix)
+	if err != nil {
+		return reservation.SegmentID{}, serrors.WrapStr("invalid segment reservation ID", err)
+	}
+	return *segID, nil
+}
+
+func segmentIDToCtrl(id reservation.SegmentID) *colibri_mgmt.SegmentReservationID {
+	raw := make([]byte, reservation.SegmentIDLen)
+	id.Read(raw)
+	return &colibri_mgmt.SegmentReservationID{
+		ASID:   raw[:6],
+		Suffix: raw[6:],
+	}
+}
@@ -573,6 +573,11 @@ func testGetE2ERsvFromID(ctx context.Context, t *testing.T, db backend.DB) {
 	rsv, err = db.GetE2ERsvFromID(ctx, &r.ID)
 	require.NoError(t, err)
 	require.Equal(t, r, rsv)
+	// not found
+	ID := reservation.E2EID{ASID: xtest.MustParseAS("ff00:0:2")}
+	rsv, err = db.GetE2ERsvFromID(ctx, &ID)
+	require.NoError(t, err)
+	require.Nil(t, rsv)
 }
 
 func testGetE2ERsvsOnSegRsv(ctx context.Context, t *testing.T, db backend.DB) {
@@ -605,6 +610,13 @@ func testGetE2ERsvsOnSegRsv(ctx context.Context, t *testing.T, db backend.DB) {
 	rsvs, err = db.GetE2ERsvsOnSegRsv(ctx, &s2.ID)
 	require.NoError(t, err)
 	require.ElementsMatch(t, rsvs, []*e2e.Reservation{e2, e3})
+	// persisting the segment reservation again keeps the e2e ones on it
+	s1.TrafficSplit = 4
+	err = db.PersistSegmentRsv(ctx, s1)
+	require.NoError(t, err)
+	rsvs, err = db.GetE2ERsvsOnSegRsv(ctx, &s1.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, rsvs, []*e2e.Reservation{e1, e3})
 }
 
 // newToken just returns a token that can be serialized. This one has two HopFields.
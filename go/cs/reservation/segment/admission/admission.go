@@ -0,0 +1,40 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// Admitter specifies what an admission entity has to implement to govern the segment admission.
+type Admitter interface {
+	// AdmitRsv decides whether the segment reservation in the request can be admitted. It
+	// appends an allocation bead to the allocation trail of the request with the bandwidth
+	// granted by this AS and the maximum bandwidth it could have granted. If the granted
+	// bandwidth is lower than the minimum requested one, an error is returned.
+	AdmitRsv(ctx context.Context, x backend.TransitOnly, req *segment.SetupReq) error
+}
+
+// Capacities describes what a capacity description must offer.
+type Capacities interface {
+	// CapacityIngress returns the capacity in kbps of the ingress interface.
+	CapacityIngress(ingress common.IFIDType) uint64
+	// CapacityEgress returns the capacity in kbps of the egress interface.
+	CapacityEgress(egress common.IFIDType) uint64
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// StatelessAdmission can admit a segment reservation without any state other than the DB.
+// The granted bandwidth is the minimum of the free bandwidth on the interfaces, and the ideal
+// bandwidth according to the demand of all the reservations leaving through the same egress.
+type StatelessAdmission struct {
+	Caps  admission.Capacities
+	Delta float64 // fraction of the free bandwidth that can be granted, 0 < Delta <= 1
+}
+
+var _ admission.Admitter = (*StatelessAdmission)(nil)
+
+// AdmitRsv admits a segment reservation. The request will contain a new allocation bead with
+// the granted and the maximum grantable bandwidth, even if the admission fails.
+func (a *StatelessAdmission) AdmitRsv(ctx context.Context, x backend.TransitOnly,
+	req *segment.SetupReq) error {
+
+	avail, err := a.availableBW(ctx, x, req)
+	if err != nil {
+		return serrors.WrapStr("cannot compute available bandwidth", err, "segment_id", req.ID)
+	}
+	ideal, err := a.idealBW(ctx, x, req)
+	if err != nil {
+		return serrors.WrapStr("cannot compute ideal bandwidth", err, "segment_id", req.ID)
+	}
+	maxAlloc := minBW(avail, ideal)
+	alloc := reservation.BWClsFromBW(maxAlloc)
+	if alloc > reservation.BWCls(req.MaxBW) {
+		alloc = reservation.BWCls(req.MaxBW)
+	}
+	req.AllocTrail = append(req.AllocTrail, reservation.AllocationBead{
+		AllocBW: uint8(alloc),
+		MaxBW:   uint8(reservation.BWClsFromBW(avail)),
+	})
+	if alloc < reservation.BWCls(req.MinBW) {
+		return serrors.New("admission denied", "max_allowed", alloc, "min_requested", req.MinBW,
+			"segment_id", req.ID)
+	}
+	return nil
+}
+
+// availableBW computes the bandwidth in kbps that is not blocked by other reservations on the
+// ingress and egress interfaces of the request, scaled by delta.
+func (a *StatelessAdmission) availableBW(ctx context.Context, x backend.TransitOnly,
+	req *segment.SetupReq) (uint64, error) {
+
+	sameIngress, err := x.GetSegmentRsvsFromIFPair(ctx, &req.Ingress, nil)
+	if err != nil {
+		return 0, serrors.WrapStr("cannot get reservations using ingress", err,
+			"ingress", req.Ingress)
+	}
+	sameEgress, err := x.GetSegmentRsvsFromIFPair(ctx, nil, &req.Egress)
+	if err != nil {
+		return 0, serrors.WrapStr("cannot get reservations using egress", err,
+			"egress", req.Egress)
+	}
+	freeIngress := subBW(a.Caps.CapacityIngress(req.Ingress), sumMaxBlockedBW(sameIngress, req.ID))
+	freeEgress := subBW(a.Caps.CapacityEgress(req.Egress), sumMaxBlockedBW(sameEgress, req.ID))
+	free := float64(minBW(freeIngress, freeEgress))
+	return uint64(free * a.Delta), nil
+}
+
+// idealBW computes the bandwidth in kbps that the request would get if the egress capacity
+// was split according to the demands of the reservations leaving through it. The egress
+// capacity is first split among the ingress interfaces (tube ratio), with the demand of each
+// ingress capped to its capacity. The share of the request's ingress is then split among the
+// reservations entering there (link ratio).
+func (a *StatelessAdmission) idealBW(ctx context.Context, x backend.TransitOnly,
+	req *segment.SetupReq) (uint64, error) {
+
+	sameEgress, err := x.GetSegmentRsvsFromIFPair(ctx, nil, &req.Egress)
+	if err != nil {
+		return 0, serrors.WrapStr("cannot get reservations using egress", err,
+			"egress", req.Egress)
+	}
+	reqDemand := a.demand(req.Ingress, req.Egress, reservation.BWCls(req.MaxBW))
+	tubeDemands := map[common.IFIDType]uint64{req.Ingress: reqDemand}
+	for _, rsv := range sameEgress {
+		if rsv.ID == req.ID {
+			continue
+		}
+		tubeDemands[rsv.Ingress] += a.demand(rsv.Ingress, rsv.Egress, maxRequestedBW(rsv))
+	}
+	var totalDemand uint64
+	for ingress, dem := range tubeDemands {
+		totalDemand += minBW(dem, a.Caps.CapacityIngress(ingress))
+	}
+	if totalDemand == 0 {
+		return 0, nil
+	}
+	tubeDemand := tubeDemands[req.Ingress]
+	tubeRatio := float64(minBW(tubeDemand, a.Caps.CapacityIngress(req.Ingress))) /
+		float64(totalDemand)
+	linkRatio := float64(reqDemand) / float64(tubeDemand)
+	return uint64(float64(a.Caps.CapacityEgress(req.Egress)) * tubeRatio * linkRatio), nil
+}
+
+// demand returns the requested bandwidth in kbps capped by the capacities of the interfaces.
+func (a *StatelessAdmission) demand(ingress, egress common.IFIDType,
+	maxBW reservation.BWCls) uint64 {
+
+	return minBW(minBW(a.Caps.CapacityIngress(ingress), a.Caps.CapacityEgress(egress)),
+		maxBW.ToKbps())
+}
+
+// sumMaxBlockedBW adds up the bandwidth blocked by the reservations, excluding the one
+// with the given ID.
+func sumMaxBlockedBW(rsvs []*segment.Reservation, excludeID reservation.SegmentID) uint64 {
+	var total uint64
+	for _, rsv := range rsvs {
+		if rsv.ID == excludeID {
+			continue
+		}
+		total += maxBlockedBW(rsv)
+	}
+	return total
+}
+
+// maxBlockedBW returns the maximum bandwidth in kbps allocated by any of the indices.
+func maxBlockedBW(rsv *segment.Reservation) uint64 {
+	var max uint64
+	for _, index := range rsv.Indices {
+		if bw := index.AllocBW.ToKbps(); bw > max {
+			max = bw
+		}
+	}
+	return max
+}
+
+// maxRequestedBW returns the maximum bandwidth class requested by any of the indices.
+func maxRequestedBW(rsv *segment.Reservation) reservation.BWCls {
+	var max reservation.BWCls
+	for _, index := range rsv.Indices {
+		if index.MaxBW > max {
+			max = index.MaxBW
+		}
+	}
+	return max
+}
+
+func minBW(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func subBW(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
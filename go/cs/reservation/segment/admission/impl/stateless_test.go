@@ -0,0 +1,160 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestAdmitRsv(t *testing.T) {
+	cases := map[string]struct {
+		Existing []*segment.Reservation
+		Req      *segment.SetupReq
+		Bead     reservation.AllocationBead
+		Admitted bool
+	}{
+		"empty": {
+			Req:      newTestRequest(1, 1, 2, 1, 13),
+			Bead:     reservation.AllocationBead{AllocBW: 13, MaxBW: 13},
+			Admitted: true,
+		},
+		"capped by max bw": {
+			Req:      newTestRequest(1, 1, 2, 1, 5),
+			Bead:     reservation.AllocationBead{AllocBW: 5, MaxBW: 13},
+			Admitted: true,
+		},
+		"same ingress and egress": {
+			Existing: []*segment.Reservation{newTestRsv(t, 2, 1, 2, 13, 11)},
+			Req:      newTestRequest(1, 1, 2, 1, 13),
+			Bead:     reservation.AllocationBead{AllocBW: 11, MaxBW: 11},
+			Admitted: true,
+		},
+		"same egress": {
+			Existing: []*segment.Reservation{newTestRsv(t, 2, 3, 2, 13, 11)},
+			Req:      newTestRequest(1, 1, 2, 1, 13),
+			Bead:     reservation.AllocationBead{AllocBW: 11, MaxBW: 11},
+			Admitted: true,
+		},
+		"other interfaces": {
+			Existing: []*segment.Reservation{newTestRsv(t, 2, 3, 4, 13, 13)},
+			Req:      newTestRequest(1, 1, 2, 1, 13),
+			Bead:     reservation.AllocationBead{AllocBW: 13, MaxBW: 13},
+			Admitted: true,
+		},
+		"renewal": {
+			Existing: []*segment.Reservation{newTestRsv(t, 1, 1, 2, 13, 13)},
+			Req:      newTestRequest(1, 1, 2, 1, 13),
+			Bead:     reservation.AllocationBead{AllocBW: 13, MaxBW: 13},
+			Admitted: true,
+		},
+		"denied": {
+			Existing: []*segment.Reservation{newTestRsv(t, 2, 3, 2, 13, 13)},
+			Req:      newTestRequest(1, 1, 2, 1, 13),
+			Bead:     reservation.AllocationBead{AllocBW: 0, MaxBW: 0},
+			Admitted: false,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			db, err := sqlite.New("file::memory:")
+			require.NoError(t, err)
+			defer db.Close()
+			for _, rsv := range tc.Existing {
+				require.NoError(t, db.PersistSegmentRsv(ctx, rsv))
+			}
+			admitter := &StatelessAdmission{
+				Caps:  testCapacities{1: 1024, 2: 1024, 3: 1024, 4: 1024},
+				Delta: 1,
+			}
+			err = admitter.AdmitRsv(ctx, db, tc.Req)
+			if tc.Admitted {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			require.Len(t, tc.Req.AllocTrail, 1)
+			require.Equal(t, tc.Bead, tc.Req.AllocTrail[0])
+		})
+	}
+}
+
+func TestAdmitRsvDelta(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	admitter := &StatelessAdmission{
+		Caps:  testCapacities{1: 1024, 2: 1024},
+		Delta: 0.5,
+	}
+	req := newTestRequest(1, 1, 2, 1, 13)
+	err = admitter.AdmitRsv(ctx, db, req)
+	require.NoError(t, err)
+	require.Equal(t, []reservation.AllocationBead{{AllocBW: 11, MaxBW: 11}}, req.AllocTrail)
+}
+
+type testCapacities map[common.IFIDType]uint64
+
+func (c testCapacities) CapacityIngress(ingress common.IFIDType) uint64 { return c[ingress] }
+func (c testCapacities) CapacityEgress(egress common.IFIDType) uint64   { return c[egress] }
+
+func newTestID(suffix uint32) reservation.SegmentID {
+	id := reservation.SegmentID{ASID: xtest.MustParseAS("ff00:0:1")}
+	binary.BigEndian.PutUint32(id.Suffix[:], suffix)
+	return id
+}
+
+func newTestRequest(suffix uint32, ingress, egress common.IFIDType,
+	minBW, maxBW uint8) *segment.SetupReq {
+
+	return &segment.SetupReq{
+		Request: segment.Request{
+			ID:        newTestID(suffix),
+			Timestamp: time.Now(),
+			Ingress:   ingress,
+			Egress:    egress,
+		},
+		MinBW: minBW,
+		MaxBW: maxBW,
+	}
+}
+
+func newTestRsv(t *testing.T, suffix uint32, ingress, egress common.IFIDType,
+	maxBW, allocBW reservation.BWCls) *segment.Reservation {
+
+	t.Helper()
+	rsv := segment.NewReservation()
+	rsv.ID = newTestID(suffix)
+	rsv.Ingress = ingress
+	rsv.Egress = egress
+	_, err := rsv.NewIndexAtSource(time.Now().Add(time.Minute), 1, maxBW, allocBW, 0,
+		reservation.UpPath)
+	require.NoError(t, err)
+	return rsv
+}
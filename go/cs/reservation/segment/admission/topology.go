@@ -0,0 +1,63 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+// TopologyCapacities derives the interface capacities from the bandwidth of the border router
+// interfaces in the topology. The bandwidth in the topology is expressed in kbps.
+// The interface with ID 0 represents the AS itself (reservations starting or ending here),
+// and its capacity is the sum of the capacities of all the interfaces.
+type TopologyCapacities struct {
+	caps  map[common.IFIDType]uint64
+	total uint64
+}
+
+var _ Capacities = (*TopologyCapacities)(nil)
+
+// NewTopologyCapacities returns the capacities of the interfaces in the topology.
+func NewTopologyCapacities(topo topology.Topology) *TopologyCapacities {
+	c := &TopologyCapacities{
+		caps: make(map[common.IFIDType]uint64),
+	}
+	for ifid, info := range topo.IFInfoMap() {
+		if info.Bandwidth <= 0 {
+			continue
+		}
+		c.caps[ifid] = uint64(info.Bandwidth)
+		c.total += uint64(info.Bandwidth)
+	}
+	return c
+}
+
+// CapacityIngress returns the capacity of the ingress interface, or 0 if unknown.
+func (c *TopologyCapacities) CapacityIngress(ingress common.IFIDType) uint64 {
+	return c.capacity(ingress)
+}
+
+// CapacityEgress returns the capacity of the egress interface, or 0 if unknown.
+func (c *TopologyCapacities) CapacityEgress(egress common.IFIDType) uint64 {
+	return c.capacity(egress)
+}
+
+func (c *TopologyCapacities) capacity(ifid common.IFIDType) uint64 {
+	if ifid == 0 {
+		return c.total
+	}
+	return c.caps[ifid]
+}
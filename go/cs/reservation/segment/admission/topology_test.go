@@ -0,0 +1,42 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+func TestTopologyCapacities(t *testing.T) {
+	topo := topology.FromRWTopology(&topology.RWTopology{
+		IFInfoMap: topology.IfInfoMap{
+			1: topology.IFInfo{Bandwidth: 1000},
+			2: topology.IFInfo{Bandwidth: 5000},
+			3: topology.IFInfo{},
+		},
+	})
+	caps := admission.NewTopologyCapacities(topo)
+	require.Equal(t, uint64(1000), caps.CapacityIngress(1))
+	require.Equal(t, uint64(1000), caps.CapacityEgress(1))
+	require.Equal(t, uint64(5000), caps.CapacityEgress(2))
+	require.Equal(t, uint64(0), caps.CapacityIngress(3))
+	require.Equal(t, uint64(0), caps.CapacityIngress(4))
+	require.Equal(t, uint64(6000), caps.CapacityIngress(0))
+	require.Equal(t, uint64(6000), caps.CapacityEgress(0))
+}
@@ -32,6 +32,7 @@ type Request struct {
 	Timestamp   time.Time             // the mandatory timestamp
 	Ingress     common.IFIDType       // the interface the reservation traffic uses to enter the AS
 	Egress      common.IFIDType       // the interface the reservation traffic uses to leave the AS
+	Path        Path                  // the reservation path, nil if not known
 	Reservation *Reservation          // nil if no reservation yet
 }
 
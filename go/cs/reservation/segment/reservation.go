@@ -200,7 +200,7 @@ func (r *Reservation) RemoveIndex(idx reservation.IndexNumber) error {
 		return err
 	}
 	r.Indices = r.Indices[sliceIndex+1:]
-	r.activeIndex -= sliceIndex
+	r.activeIndex -= sliceIndex + 1
 	if r.activeIndex < -1 {
 		r.activeIndex = -1
 	}
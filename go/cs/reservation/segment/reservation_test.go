@@ -225,4 +225,18 @@ func TestRemoveIndex(t *testing.T) {
 	require.True(t, r.Indices[0].Idx == idx2)
 	err = r.Validate()
 	require.NoError(t, err)
+
+	// removing the active index leaves the reservation without active index
+	err = r.SetIndexConfirmed(idx2)
+	require.NoError(t, err)
+	err = r.SetIndexActive(idx2)
+	require.NoError(t, err)
+	idx, _ = r.NewIndexAtSource(expTime, 0, 0, 0, 0, reservation.CorePath)
+	err = r.RemoveIndex(idx2)
+	require.NoError(t, err)
+	require.Nil(t, r.ActiveIndex())
+	require.Len(t, r.Indices, 1)
+	require.True(t, r.Indices[0].Idx == idx)
+	err = r.Validate()
+	require.NoError(t, err)
 }
@@ -233,12 +233,16 @@ func (x *executor) NewSegmentRsv(ctx context.Context, rsv *segment.Reservation)
 
 func (x *executor) PersistSegmentRsv(ctx context.Context, rsv *segment.Reservation) error {
 	err := db.DoInTx(ctx, x.db, func(ctx context.Context, tx *sql.Tx) error {
-		err := deleteSegmentRsv(ctx, tx, &rsv.ID)
+		rowID, err := getSegRsvRowID(ctx, tx, &rsv.ID)
 		if err != nil {
 			return err
 		}
-		suffix := binary.BigEndian.Uint32(rsv.ID.Suffix[:])
-		return insertNewSegReservation(ctx, tx, rsv, suffix)
+		if rowID == -1 {
+			suffix := binary.BigEndian.Uint32(rsv.ID.Suffix[:])
+			return insertNewSegReservation(ctx, tx, rsv, suffix)
+		}
+		// update in place, so that the e2e reservations keep referencing this one
+		return updateSegReservation(ctx, tx, rsv, rowID)
 	})
 	if err != nil {
 		return db.NewTxError("error persisting reservation", err)
@@ -375,23 +379,70 @@ func insertNewSegReservation(ctx context.Context, x *sql.Tx, rsv *segment.Reserv
 		if err != nil {
 			return db.NewTxError("cannot obtain last insertion row id", err)
 		}
-		const queryIndexTmpl = `INSERT INTO seg_index (reservation, index_number, expiration, state,
-		min_bw, max_bw, alloc_bw, token) VALUES (?,?,?,?,?,?,?,?)`
-		params := make([]interface{}, 0, 8*len(rsv.Indices))
-		for _, index := range rsv.Indices {
-			params = append(params, rsvRowID, index.Idx,
-				util.TimeToSecs(index.Expiration), index.State(), index.MinBW, index.MaxBW,
-				index.AllocBW, index.Token.ToRaw())
-		}
-		q := queryIndexTmpl + strings.Repeat(",(?,?,?,?,?,?,?,?)", len(rsv.Indices)-1)
-		_, err = x.ExecContext(ctx, q, params...)
-		if err != nil {
-			return err
-		}
+		return insertSegIndices(ctx, x, rsv.Indices, rsvRowID)
+	}
+	return nil
+}
+
+// updateSegReservation overwrites the reservation stored with the rowID, and replaces its indices.
+func updateSegReservation(ctx context.Context, x *sql.Tx, rsv *segment.Reservation,
+	rowID int64) error {
+
+	activeIndex := -1
+	if rsv.ActiveIndex() != nil {
+		activeIndex = int(rsv.ActiveIndex().Idx)
+	}
+	const query = `UPDATE seg_reservation SET ingress = ?, egress = ?, path = ?, end_props = ?,
+		traffic_split = ?, src_ia = ?, dst_ia = ?, active_index = ? WHERE ROWID = ?`
+	_, err := x.ExecContext(ctx, query, rsv.Ingress, rsv.Egress, rsv.Path.ToRaw(),
+		rsv.PathEndProps, rsv.TrafficSplit, rsv.Path.GetSrcIA().IAInt(),
+		rsv.Path.GetDstIA().IAInt(), activeIndex, rowID)
+	if err != nil {
+		return err
+	}
+	const queryDelete = `DELETE FROM seg_index WHERE reservation = ?`
+	if _, err := x.ExecContext(ctx, queryDelete, rowID); err != nil {
+		return err
+	}
+	if len(rsv.Indices) > 0 {
+		return insertSegIndices(ctx, x, rsv.Indices, rowID)
 	}
 	return nil
 }
 
+func insertSegIndices(ctx context.Context, x *sql.Tx, indices segment.Indices,
+	rsvRowID int64) error {
+
+	const queryIndexTmpl = `INSERT INTO seg_index (reservation, index_number, expiration, state,
+		min_bw, max_bw, alloc_bw, token) VALUES (?,?,?,?,?,?,?,?)`
+	params := make([]interface{}, 0, 8*len(indices))
+	for _, index := range indices {
+		params = append(params, rsvRowID, index.Idx,
+			util.TimeToSecs(index.Expiration), index.State(), index.MinBW, index.MaxBW,
+			index.AllocBW, index.Token.ToRaw())
+	}
+	q := queryIndexTmpl + strings.Repeat(",(?,?,?,?,?,?,?,?)", len(indices)-1)
+	_, err := x.ExecContext(ctx, q, params...)
+	return err
+}
+
+// getSegRsvRowID returns the row ID of the segment reservation, or -1 if not found.
+func getSegRsvRowID(ctx context.Context, x db.Sqler, rsvID *reservation.SegmentID) (
+	int64, error) {
+
+	const query = `SELECT ROWID FROM seg_reservation WHERE id_as = ? AND id_suffix = ?`
+	suffix := binary.BigEndian.Uint32(rsvID.Suffix[:])
+	var rowID int64
+	err := x.QueryRowContext(ctx, query, rsvID.ASID, suffix).Scan(&rowID)
+	switch {
+	case err == sql.ErrNoRows:
+		return -1, nil
+	case err != nil:
+		return 0, err
+	}
+	return rowID, nil
+}
+
 type rsvFields struct {
 	RowID        int
 	AsID         uint64
@@ -571,7 +622,10 @@ func getE2ERsvFromID(ctx context.Context, x *sql.Tx, ID *reservation.E2EID) (
 	var rowID int
 	const query = `SELECT ROWID FROM e2e_reservation WHERE reservation_id = ?`
 	err := x.QueryRowContext(ctx, query, ID.ToRaw()).Scan(&rowID)
-	if err != nil {
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
 		return nil, err
 	}
 	// read indices
@@ -67,7 +67,7 @@ type ReserverAndTransit interface {
 	// Used on schedule.
 	DeleteExpiredIndices(ctx context.Context, now time.Time) (int, error)
 
-	// GetE2ERsvFromID finds the end to end resevation given its ID.
+	// GetE2ERsvFromID finds the end to end resevation given its ID, or nil if not found.
 	GetE2ERsvFromID(ctx context.Context, ID *reservation.E2EID) (*e2e.Reservation, error)
 	// GetE2ERsvsOnSegRsv returns the e2e reservations running on top of a given segment one.
 	GetE2ERsvsOnSegRsv(ctx context.Context, ID *reservation.SegmentID) ([]*e2e.Reservation, error)
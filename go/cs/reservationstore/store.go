@@ -0,0 +1,426 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore
+
+import (
+	"context"
+	"time"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+// SegmentRsvLifetime is the lifetime of a newly admitted segment reservation index.
+const SegmentRsvLifetime = 5 * time.Minute
+
+// Store is the reservation store.
+type Store struct {
+	db       backend.DB
+	admitter admission.Admitter
+	topo     topology.Topology
+}
+
+var _ reservationstorage.Store = (*Store)(nil)
+
+// NewStore creates a new reservation store. The topology is used to find the link types of the
+// interfaces, from which the path type of the segment reservations is derived.
+func NewStore(db backend.DB, admitter admission.Admitter, topo topology.Topology) *Store {
+	return &Store{
+		db:       db,
+		admitter: admitter,
+		topo:     topo,
+	}
+}
+
+// AdmitSegmentReservation receives a setup/renewal request to admit a segment reservation.
+// The admission appends an allocation bead to the request's trail, also when it fails.
+// If admitted, a new temporary index is added to the reservation and its number returned.
+// A request starting in this AS without reservation ID creates a new reservation along the
+// request's path, and the new ID is set in the request.
+func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupReq) (
+	reservation.IndexNumber, error) {
+
+	pathType, err := s.pathType(req.Ingress, req.Egress)
+	if err != nil {
+		return 0, serrors.WrapStr("cannot admit segment reservation", err, "id", req.ID)
+	}
+	var idx reservation.IndexNumber
+	err = s.doInTx(ctx, func(tx backend.Transaction) error {
+		rsv, err := s.segmentRsvForSetup(ctx, tx, req)
+		if err != nil {
+			return err
+		}
+		if err := s.admitter.AdmitRsv(ctx, tx, req); err != nil {
+			return serrors.WrapStr("segment reservation not admitted", err, "id", req.ID)
+		}
+		allocBW := minAllocBW(req.AllocTrail)
+		idx, err = rsv.NewIndexAtSource(req.Timestamp.Add(SegmentRsvLifetime),
+			reservation.BWCls(req.MinBW), reservation.BWCls(req.MaxBW), allocBW, 0, pathType)
+		if err != nil {
+			return serrors.WrapStr("cannot create new index", err, "id", req.ID)
+		}
+		if req.ID.ASID == 0 {
+			if err := tx.NewSegmentRsv(ctx, rsv); err != nil {
+				return serrors.WrapStr("cannot create segment reservation", err)
+			}
+			req.ID = rsv.ID
+			return nil
+		}
+		return tx.PersistSegmentRsv(ctx, rsv)
+	})
+	return idx, err
+}
+
+// segmentRsvForSetup returns the reservation the setup request refers to. If it does not
+// exist yet, a new one without ID is returned for requests starting in this AS, and one
+// with the request's ID otherwise.
+func (s *Store) segmentRsvForSetup(ctx context.Context, tx backend.Transaction,
+	req *segment.SetupReq) (*segment.Reservation, error) {
+
+	var rsv *segment.Reservation
+	if req.ID.ASID != 0 {
+		var err error
+		if rsv, err = tx.GetSegmentRsvFromID(ctx, &req.ID); err != nil {
+			return nil, serrors.WrapStr("cannot obtain segment reservation", err, "id", req.ID)
+		}
+	}
+	if rsv != nil {
+		if rsv.Ingress != req.Ingress || rsv.Egress != req.Egress {
+			return nil, serrors.New("segment reservation interfaces do not match",
+				"id", req.ID, "ingress", rsv.Ingress, "egress", rsv.Egress,
+				"req_ingress", req.Ingress, "req_egress", req.Egress)
+		}
+		return rsv, nil
+	}
+	rsv = segment.NewReservation()
+	rsv.Ingress = req.Ingress
+	rsv.Egress = req.Egress
+	rsv.PathEndProps = req.PathProps
+	rsv.TrafficSplit = reservation.SplitCls(req.SplitCls)
+	if req.Ingress != 0 {
+		rsv.ID = req.ID
+		return rsv, nil
+	}
+	if req.ID.ASID != 0 || req.Path == nil {
+		return nil, serrors.New("segment reservation starting in this AS not found",
+			"id", req.ID)
+	}
+	if err := req.Path.Validate(); err != nil {
+		return nil, serrors.WrapStr("invalid reservation path", err)
+	}
+	rsv.ID.ASID = s.topo.IA().A
+	rsv.Path = req.Path
+	return rsv, nil
+}
+
+// ConfirmSegmentReservation changes the state of the index from temporary to pending.
+func (s *Store) ConfirmSegmentReservation(ctx context.Context, id reservation.SegmentID,
+	idx reservation.IndexNumber) error {
+
+	return s.modifySegmentRsv(ctx, id, func(tx backend.Transaction,
+		rsv *segment.Reservation) error {
+
+		if err := rsv.SetIndexConfirmed(idx); err != nil {
+			return serrors.WrapStr("cannot confirm index", err, "id", id, "idx", idx)
+		}
+		return tx.PersistSegmentRsv(ctx, rsv)
+	})
+}
+
+// ActivateSegmentReservation activates the pending index. The previous indices are removed.
+func (s *Store) ActivateSegmentReservation(ctx context.Context, id reservation.SegmentID,
+	idx reservation.IndexNumber) error {
+
+	return s.modifySegmentRsv(ctx, id, func(tx backend.Transaction,
+		rsv *segment.Reservation) error {
+
+		if err := rsv.SetIndexActive(idx); err != nil {
+			return serrors.WrapStr("cannot activate index", err, "id", id, "idx", idx)
+		}
+		return tx.PersistSegmentRsv(ctx, rsv)
+	})
+}
+
+// CleanupSegmentReservation removes the index of a failed setup or renewal, which must be the
+// newest one and not active. If the reservation is left without indices, it is removed.
+func (s *Store) CleanupSegmentReservation(ctx context.Context, id reservation.SegmentID,
+	idx reservation.IndexNumber) error {
+
+	return s.modifySegmentRsv(ctx, id, func(tx backend.Transaction,
+		rsv *segment.Reservation) error {
+
+		if active := rsv.ActiveIndex(); active != nil && active.Idx == idx {
+			return serrors.New("cannot remove active index", "id", id, "idx", idx)
+		}
+		last := len(rsv.Indices) - 1
+		if last < 0 || rsv.Indices[last].Idx != idx {
+			return serrors.New("only the newest index can be removed", "id", id, "idx", idx)
+		}
+		rsv.Indices = rsv.Indices[:last]
+		if len(rsv.Indices) == 0 {
+			return tx.DeleteSegmentRsv(ctx, &rsv.ID)
+		}
+		return tx.PersistSegmentRsv(ctx, rsv)
+	})
+}
+
+// TearDownSegmentReservation removes the reservation.
+func (s *Store) TearDownSegmentReservation(ctx context.Context, id reservation.SegmentID,
+	idx reservation.IndexNumber) error {
+
+	return s.modifySegmentRsv(ctx, id, func(tx backend.Transaction,
+		rsv *segment.Reservation) error {
+
+		if _, err := rsv.Index(idx); err != nil {
+			return serrors.WrapStr("cannot tear down reservation", err, "id", id, "idx", idx)
+		}
+		return tx.DeleteSegmentRsv(ctx, &rsv.ID)
+	})
+}
+
+// AdmitE2EReservation admits an E2E reservation on top of its segment reservations. Only
+// successful setup requests reserve bandwidth. The bandwidth in the token must fit in what
+// is left in the active index of all the segment reservations after subtracting the other
+// E2E reservations on them.
+func (s *Store) AdmitE2EReservation(ctx context.Context, req e2e.SetupReq) error {
+	successReq, ok := req.(*e2e.SuccessSetupReq)
+	if !ok {
+		// failed setups are only forwarded, nothing to reserve
+		return nil
+	}
+	tok := successReq.Token
+	return s.doInTx(ctx, func(tx backend.Transaction) error {
+		rsv, err := tx.GetE2ERsvFromID(ctx, &successReq.ID)
+		if err != nil {
+			return serrors.WrapStr("cannot obtain e2e reservation", err, "id", successReq.ID)
+		}
+		if rsv == nil {
+			if rsv, err = newE2ERsv(ctx, tx, successReq); err != nil {
+				return err
+			}
+		}
+		requested := tok.BWCls.ToKbps()
+		for _, segRsv := range rsv.SegmentReservations {
+			free, err := freeE2EBW(ctx, tx, segRsv.ID, successReq.ID)
+			if err != nil {
+				return serrors.WrapStr("cannot compute free bandwidth", err,
+					"id", successReq.ID, "segment_id", segRsv.ID)
+			}
+			if requested > free {
+				return serrors.New("not enough bandwidth in segment reservation",
+					"id", successReq.ID, "segment_id", segRsv.ID,
+					"requested", requested, "free", free)
+			}
+		}
+		indices := make(e2e.Indices, len(rsv.Indices), len(rsv.Indices)+1)
+		copy(indices, rsv.Indices)
+		indices = append(indices, e2e.Index{
+			Idx:        tok.Idx,
+			Expiration: tok.ExpirationTick.ToTime(),
+			AllocBW:    tok.BWCls,
+			Token:      &tok,
+		})
+		if err := base.ValidateIndices(indices); err != nil {
+			return serrors.WrapStr("cannot add index", err, "id", successReq.ID)
+		}
+		rsv.Indices = indices
+		return tx.PersistE2ERsv(ctx, rsv)
+	})
+}
+
+// newE2ERsv returns the reservation referenced by the request, or a new one on top of the
+// segment reservations listed in the request.
+func newE2ERsv(ctx context.Context, tx backend.Transaction,
+	req *e2e.SuccessSetupReq) (*e2e.Reservation, error) {
+
+	if rsv := req.Reservation(); rsv != nil {
+		return rsv, nil
+	}
+	if len(req.SegmentRsvs) == 0 {
+		return nil, serrors.New("unknown e2e reservation", "id", req.ID)
+	}
+	rsv := &e2e.Reservation{
+		ID:                  req.ID,
+		SegmentReservations: make([]*segment.Reservation, len(req.SegmentRsvs)),
+	}
+	for i, segID := range req.SegmentRsvs {
+		segID := segID
+		segRsv, err := tx.GetSegmentRsvFromID(ctx, &segID)
+		if err != nil {
+			return nil, serrors.WrapStr("cannot obtain segment reservation", err,
+				"id", req.ID, "segment_id", segID)
+		}
+		if segRsv == nil {
+			return nil, serrors.New("segment reservation not found", "id", req.ID,
+				"segment_id", segID)
+		}
+		rsv.SegmentReservations[i] = segRsv
+	}
+	return rsv, nil
+}
+
+// CleanupE2EReservation removes the index of a failed setup or renewal, which must be the
+// newest one.
+func (s *Store) CleanupE2EReservation(ctx context.Context, id reservation.E2EID,
+	idx reservation.IndexNumber) error {
+
+	return s.doInTx(ctx, func(tx backend.Transaction) error {
+		rsv, err := tx.GetE2ERsvFromID(ctx, &id)
+		if err != nil {
+			return serrors.WrapStr("cannot obtain e2e reservation", err, "id", id)
+		}
+		if rsv == nil {
+			return serrors.New("e2e reservation not found", "id", id)
+		}
+		last := len(rsv.Indices) - 1
+		if last < 0 || rsv.Indices[last].Idx != idx {
+			return serrors.New("only the newest index can be removed", "id", id, "idx", idx)
+		}
+		rsv.Indices = rsv.Indices[:last]
+		return tx.PersistE2ERsv(ctx, rsv)
+	})
+}
+
+// DeleteExpiredIndices removes the expired indices of segment and e2e reservations, and the
+// reservations left without indices. It returns the number of removed indices.
+// It can be used with the cleaner task.
+func (s *Store) DeleteExpiredIndices(ctx context.Context) (int, error) {
+	return s.db.DeleteExpiredIndices(ctx, time.Now())
+}
+
+// modifySegmentRsv loads the segment reservation inside a transaction and calls f with it.
+func (s *Store) modifySegmentRsv(ctx context.Context, id reservation.SegmentID,
+	f func(backend.Transaction, *segment.Reservation) error) error {
+
+	return s.doInTx(ctx, func(tx backend.Transaction) error {
+		rsv, err := tx.GetSegmentRsvFromID(ctx, &id)
+		if err != nil {
+			return serrors.WrapStr("cannot obtain segment reservation", err, "id", id)
+		}
+		if rsv == nil {
+			return serrors.New("segment reservation not found", "id", id)
+		}
+		return f(tx, rsv)
+	})
+}
+
+func (s *Store) doInTx(ctx context.Context, f func(backend.Transaction) error) error {
+	tx, err := s.db.BeginTransaction(ctx, nil)
+	if err != nil {
+		return serrors.WrapStr("cannot create transaction", err)
+	}
+	defer tx.Rollback()
+	if err := f(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// pathType derives the type of the segment reservation from the link types of the interfaces.
+// An interface with ID 0 means the reservation starts or ends in this AS.
+func (s *Store) pathType(ingress, egress common.IFIDType) (reservation.PathType, error) {
+	infos := s.topo.IFInfoMap()
+	linkType := func(ifid common.IFIDType) (topology.LinkType, error) {
+		if ifid == 0 {
+			return topology.Unset, nil
+		}
+		info, ok := infos[ifid]
+		if !ok {
+			return topology.Unset, serrors.New("unknown interface", "ifid", ifid)
+		}
+		return info.LinkType, nil
+	}
+	in, err := linkType(ingress)
+	if err != nil {
+		return reservation.UnknownPath, err
+	}
+	eg, err := linkType(egress)
+	if err != nil {
+		return reservation.UnknownPath, err
+	}
+	switch {
+	case eg == topology.Peer:
+		return reservation.PeeringUpPath, nil
+	case in == topology.Peer:
+		return reservation.PeeringDownPath, nil
+	case in == topology.Core || eg == topology.Core:
+		return reservation.CorePath, nil
+	case in == topology.Parent || eg == topology.Child:
+		return reservation.DownPath, nil
+	case in == topology.Child || eg == topology.Parent:
+		return reservation.UpPath, nil
+	}
+	return reservation.UnknownPath, serrors.New("cannot derive path type",
+		"ingress", ingress, "egress", egress)
+}
+
+// freeE2EBW returns the bandwidth in kbps of the active index of the segment reservation
+// that is not used by E2E reservations other than the one with the given ID.
+func freeE2EBW(ctx context.Context, tx backend.Transaction, segID reservation.SegmentID,
+	excludeID reservation.E2EID) (uint64, error) {
+
+	segRsv, err := tx.GetSegmentRsvFromID(ctx, &segID)
+	if err != nil {
+		return 0, err
+	}
+	if segRsv == nil {
+		return 0, serrors.New("segment reservation not found")
+	}
+	active := segRsv.ActiveIndex()
+	if active == nil {
+		return 0, serrors.New("segment reservation has no active index")
+	}
+	e2eRsvs, err := tx.GetE2ERsvsOnSegRsv(ctx, &segID)
+	if err != nil {
+		return 0, err
+	}
+	var used uint64
+	for _, e2eRsv := range e2eRsvs {
+		if e2eRsv.ID == excludeID {
+			continue
+		}
+		var max uint64
+		for _, index := range e2eRsv.Indices {
+			if bw := index.AllocBW.ToKbps(); bw > max {
+				max = bw
+			}
+		}
+		used += max
+	}
+	total := active.AllocBW.ToKbps()
+	if used > total {
+		return 0, nil
+	}
+	return total - used, nil
+}
+
+// minAllocBW returns the minimum allocated bandwidth in the trail.
+func minAllocBW(trail []reservation.AllocationBead) reservation.BWCls {
+	var min reservation.BWCls
+	for i, bead := range trail {
+		if i == 0 || reservation.BWCls(bead.AllocBW) < min {
+			min = reservation.BWCls(bead.AllocBW)
+		}
+	}
+	return min
+}
@@ -0,0 +1,400 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/cs/reservationstore"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestAdmitSegmentReservation(t *testing.T) {
+	cases := map[string]struct {
+		Ingress  common.IFIDType
+		Egress   common.IFIDType
+		PathType reservation.PathType
+	}{
+		"down":      {Ingress: 1, Egress: 2, PathType: reservation.DownPath},
+		"up":        {Ingress: 2, Egress: 1, PathType: reservation.UpPath},
+		"core":      {Ingress: 3, Egress: 1, PathType: reservation.CorePath},
+		"peer up":   {Ingress: 2, Egress: 4, PathType: reservation.PeeringUpPath},
+		"peer down": {Ingress: 4, Egress: 2, PathType: reservation.PeeringDownPath},
+		"end here":  {Ingress: 1, Egress: 0, PathType: reservation.DownPath},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			db := newDB(t)
+			defer db.Close()
+			store := reservationstore.NewStore(db, &testAdmitter{alloc: 11}, newTopo())
+
+			req := newSetupReq(1, tc.Ingress, tc.Egress)
+			_, err := store.AdmitSegmentReservation(ctx, req)
+			require.NoError(t, err)
+			require.Len(t, req.AllocTrail, 1)
+			rsv, err := db.GetSegmentRsvFromID(ctx, &req.ID)
+			require.NoError(t, err)
+			require.NotNil(t, rsv)
+			require.Equal(t, tc.Ingress, rsv.Ingress)
+			require.Equal(t, tc.Egress, rsv.Egress)
+			require.Len(t, rsv.Indices, 1)
+			index := rsv.Indices[0]
+			require.Equal(t, segment.IndexTemporary, index.State())
+			require.Equal(t, reservation.BWCls(11), index.AllocBW)
+			require.Equal(t, reservation.BWCls(1), index.MinBW)
+			require.Equal(t, reservation.BWCls(13), index.MaxBW)
+			require.Equal(t, tc.PathType, index.Token.PathType)
+		})
+	}
+}
+
+func TestAdmitSegmentReservationErrors(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	defer db.Close()
+	admitter := &testAdmitter{alloc: 11}
+	store := reservationstore.NewStore(db, admitter, newTopo())
+
+	// source reservations must exist already
+	req := newSetupReq(1, 0, 1)
+	_, err := store.AdmitSegmentReservation(ctx, req)
+	require.Error(t, err)
+	// unknown interface
+	req = newSetupReq(1, 1, 5)
+	_, err = store.AdmitSegmentReservation(ctx, req)
+	require.Error(t, err)
+	// not admitted
+	admitter.err = serrors.New("admission denied")
+	req = newSetupReq(1, 1, 2)
+	_, err = store.AdmitSegmentReservation(ctx, req)
+	require.Error(t, err)
+	rsv, err := db.GetSegmentRsvFromID(ctx, &req.ID)
+	require.NoError(t, err)
+	require.Nil(t, rsv)
+	// different interfaces on renewal
+	admitter.err = nil
+	req = newSetupReq(1, 1, 2)
+	_, err = store.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	req = newSetupReq(1, 1, 3)
+	_, err = store.AdmitSegmentReservation(ctx, req)
+	require.Error(t, err)
+}
+
+func TestAdmitSegmentReservationAllocTrail(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	defer db.Close()
+	store := reservationstore.NewStore(db, &testAdmitter{alloc: 11}, newTopo())
+
+	req := newSetupReq(1, 1, 2)
+	req.AllocTrail = []reservation.AllocationBead{{AllocBW: 9, MaxBW: 13}}
+	_, err := store.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, req.AllocTrail, 2)
+	rsv, err := db.GetSegmentRsvFromID(ctx, &req.ID)
+	require.NoError(t, err)
+	require.Equal(t, reservation.BWCls(9), rsv.Indices[0].AllocBW)
+}
+
+func TestAdmitSegmentReservationAtSource(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	defer db.Close()
+	store := reservationstore.NewStore(db, &testAdmitter{alloc: 11}, newTopo())
+
+	req := newSetupReq(0, 0, 1)
+	req.ID = reservation.SegmentID{}
+	req.Path = segment.Path{
+		{PathStep: segment.PathStep{Ingress: 0, Egress: 1}, IA: xtest.MustParseIA("1-ff00:0:1")},
+		{PathStep: segment.PathStep{Ingress: 1, Egress: 0}, IA: xtest.MustParseIA("1-ff00:0:2")},
+	}
+	idx, err := store.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, reservation.IndexNumber(0), idx)
+	require.Equal(t, xtest.MustParseAS("ff00:0:1"), req.ID.ASID)
+	rsv, err := db.GetSegmentRsvFromID(ctx, &req.ID)
+	require.NoError(t, err)
+	require.NotNil(t, rsv)
+	require.Equal(t, req.Path, rsv.Path)
+	require.Len(t, rsv.Indices, 1)
+
+	// renewal with the assigned ID
+	renewal := newSetupReq(0, 0, 1)
+	renewal.ID = req.ID
+	idx, err = store.AdmitSegmentReservation(ctx, renewal)
+	require.NoError(t, err)
+	require.Equal(t, reservation.IndexNumber(1), idx)
+	// invalid path
+	req = newSetupReq(0, 0, 1)
+	req.ID = reservation.SegmentID{}
+	req.Path = segment.Path{
+		{PathStep: segment.PathStep{Ingress: 0, Egress: 1}, IA: xtest.MustParseIA("1-ff00:0:1")},
+	}
+	_, err = store.AdmitSegmentReservation(ctx, req)
+	require.Error(t, err)
+}
+
+func TestSegmentReservationIndices(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	defer db.Close()
+	store := reservationstore.NewStore(db, &testAdmitter{alloc: 11}, newTopo())
+
+	req := newSetupReq(1, 1, 2)
+	id := req.ID
+	idx, err := store.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, reservation.IndexNumber(0), idx)
+	// cannot activate a temporary index
+	err = store.ActivateSegmentReservation(ctx, id, 0)
+	require.Error(t, err)
+	require.NoError(t, store.ConfirmSegmentReservation(ctx, id, 0))
+	require.NoError(t, store.ActivateSegmentReservation(ctx, id, 0))
+	rsv, err := db.GetSegmentRsvFromID(ctx, &id)
+	require.NoError(t, err)
+	require.NotNil(t, rsv.ActiveIndex())
+	require.Equal(t, reservation.IndexNumber(0), rsv.ActiveIndex().Idx)
+
+	// renewal
+	idx, err = store.AdmitSegmentReservation(ctx, newSetupReq(1, 1, 2))
+	require.NoError(t, err)
+	require.Equal(t, reservation.IndexNumber(1), idx)
+	require.NoError(t, store.ConfirmSegmentReservation(ctx, id, 1))
+	require.NoError(t, store.ActivateSegmentReservation(ctx, id, 1))
+	rsv, err = db.GetSegmentRsvFromID(ctx, &id)
+	require.NoError(t, err)
+	require.Len(t, rsv.Indices, 1)
+	require.Equal(t, reservation.IndexNumber(1), rsv.ActiveIndex().Idx)
+
+	// cleanup of a failed renewal keeps the active index
+	_, err = store.AdmitSegmentReservation(ctx, newSetupReq(1, 1, 2))
+	require.NoError(t, err)
+	err = store.CleanupSegmentReservation(ctx, id, 1)
+	require.Error(t, err)
+	require.NoError(t, store.CleanupSegmentReservation(ctx, id, 2))
+	rsv, err = db.GetSegmentRsvFromID(ctx, &id)
+	require.NoError(t, err)
+	require.Len(t, rsv.Indices, 1)
+	require.Equal(t, reservation.IndexNumber(1), rsv.ActiveIndex().Idx)
+
+	// tear down
+	err = store.TearDownSegmentReservation(ctx, id, 2)
+	require.Error(t, err)
+	require.NoError(t, store.TearDownSegmentReservation(ctx, id, 1))
+	rsv, err = db.GetSegmentRsvFromID(ctx, &id)
+	require.NoError(t, err)
+	require.Nil(t, rsv)
+}
+
+func TestAdmitE2EReservation(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	defer db.Close()
+	store := reservationstore.NewStore(db, &testAdmitter{alloc: 13}, newTopo())
+
+	req := newSetupReq(1, 1, 2)
+	segID := req.ID
+	_, err := store.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	segRsv, err := db.GetSegmentRsvFromID(ctx, &segID)
+	require.NoError(t, err)
+
+	e2eRsv1 := &e2e.Reservation{
+		ID:                  newE2EID(1),
+		SegmentReservations: []*segment.Reservation{segRsv},
+	}
+	require.NoError(t, db.PersistE2ERsv(ctx, e2eRsv1))
+	e2eRsv2 := &e2e.Reservation{
+		ID:                  newE2EID(2),
+		SegmentReservations: []*segment.Reservation{segRsv},
+	}
+	require.NoError(t, db.PersistE2ERsv(ctx, e2eRsv2))
+
+	// segment reservation not active yet
+	err = store.AdmitE2EReservation(ctx, newE2ESuccessReq(e2eRsv1.ID, 0, 11))
+	require.Error(t, err)
+	require.NoError(t, store.ConfirmSegmentReservation(ctx, segID, 0))
+	require.NoError(t, store.ActivateSegmentReservation(ctx, segID, 0))
+
+	// 512 out of 1024 kbps
+	require.NoError(t, store.AdmitE2EReservation(ctx, newE2ESuccessReq(e2eRsv1.ID, 0, 11)))
+	rsv, err := db.GetE2ERsvFromID(ctx, &e2eRsv1.ID)
+	require.NoError(t, err)
+	require.Len(t, rsv.Indices, 1)
+	require.Equal(t, reservation.BWCls(11), rsv.Indices[0].AllocBW)
+	// renewal of the same reservation does not count its own bandwidth
+	require.NoError(t, store.AdmitE2EReservation(ctx, newE2ESuccessReq(e2eRsv1.ID, 1, 11)))
+	// another 1024 kbps don't fit
+	err = store.AdmitE2EReservation(ctx, newE2ESuccessReq(e2eRsv2.ID, 0, 13))
+	require.Error(t, err)
+	require.NoError(t, store.AdmitE2EReservation(ctx, newE2ESuccessReq(e2eRsv2.ID, 0, 11)))
+	// failed setups are not stored
+	require.NoError(t, store.AdmitE2EReservation(ctx, &e2e.FailureSetupReq{}))
+
+	err = store.CleanupE2EReservation(ctx, e2eRsv1.ID, 0)
+	require.Error(t, err)
+	require.NoError(t, store.CleanupE2EReservation(ctx, e2eRsv1.ID, 1))
+	rsv, err = db.GetE2ERsvFromID(ctx, &e2eRsv1.ID)
+	require.NoError(t, err)
+	require.Len(t, rsv.Indices, 1)
+	require.Equal(t, reservation.IndexNumber(0), rsv.Indices[0].Idx)
+	err = store.CleanupE2EReservation(ctx, newE2EID(3), 0)
+	require.Error(t, err)
+}
+
+func TestAdmitE2EReservationFromSegments(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	defer db.Close()
+	store := reservationstore.NewStore(db, &testAdmitter{alloc: 13}, newTopo())
+
+	req := newSetupReq(1, 1, 2)
+	segID := req.ID
+	_, err := store.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, store.ConfirmSegmentReservation(ctx, segID, 0))
+	require.NoError(t, store.ActivateSegmentReservation(ctx, segID, 0))
+
+	e2eReq := newE2ESuccessReq(newE2EID(1), 0, 11)
+	err = store.AdmitE2EReservation(ctx, e2eReq)
+	require.Error(t, err)
+	e2eReq.SegmentRsvs = []reservation.SegmentID{newSetupReq(2, 1, 2).ID}
+	err = store.AdmitE2EReservation(ctx, e2eReq)
+	require.Error(t, err)
+	e2eReq.SegmentRsvs = []reservation.SegmentID{segID}
+	require.NoError(t, store.AdmitE2EReservation(ctx, e2eReq))
+	rsv, err := db.GetE2ERsvFromID(ctx, &e2eReq.ID)
+	require.NoError(t, err)
+	require.NotNil(t, rsv)
+	require.Len(t, rsv.SegmentReservations, 1)
+	require.Equal(t, segID, rsv.SegmentReservations[0].ID)
+	require.Len(t, rsv.Indices, 1)
+}
+
+func TestDeleteExpiredIndices(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	defer db.Close()
+	store := reservationstore.NewStore(db, &testAdmitter{alloc: 11}, newTopo())
+
+	req := newSetupReq(1, 1, 2)
+	req.Timestamp = time.Now().Add(-2 * reservationstore.SegmentRsvLifetime)
+	_, err := store.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	_, err = store.AdmitSegmentReservation(ctx, newSetupReq(2, 1, 2))
+	require.NoError(t, err)
+	n, err := store.DeleteExpiredIndices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	rsv, err := db.GetSegmentRsvFromID(ctx, &req.ID)
+	require.NoError(t, err)
+	require.Nil(t, rsv)
+	n, err = store.DeleteExpiredIndices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+}
+
+// testAdmitter grants always the same bandwidth, or fails with err.
+type testAdmitter struct {
+	alloc reservation.BWCls
+	err   error
+}
+
+func (a *testAdmitter) AdmitRsv(_ context.Context, _ backend.TransitOnly,
+	req *segment.SetupReq) error {
+
+	if a.err != nil {
+		return a.err
+	}
+	req.AllocTrail = append(req.AllocTrail, reservation.AllocationBead{
+		AllocBW: uint8(a.alloc),
+		MaxBW:   uint8(a.alloc),
+	})
+	return nil
+}
+
+func newDB(t *testing.T) *sqlite.Backend {
+	t.Helper()
+	db, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	return db
+}
+
+func newTopo() topology.Topology {
+	return topology.FromRWTopology(&topology.RWTopology{
+		IA: xtest.MustParseIA("1-ff00:0:1"),
+		IFInfoMap: topology.IfInfoMap{
+			1: topology.IFInfo{LinkType: topology.Parent},
+			2: topology.IFInfo{LinkType: topology.Child},
+			3: topology.IFInfo{LinkType: topology.Core},
+			4: topology.IFInfo{LinkType: topology.Peer},
+		},
+	})
+}
+
+func newSetupReq(suffix uint32, ingress, egress common.IFIDType) *segment.SetupReq {
+	id := reservation.SegmentID{ASID: xtest.MustParseAS("ff00:0:1")}
+	binary.BigEndian.PutUint32(id.Suffix[:], suffix)
+	return &segment.SetupReq{
+		Request: segment.Request{
+			ID:        id,
+			Timestamp: time.Now(),
+			Ingress:   ingress,
+			Egress:    egress,
+		},
+		MinBW: 1,
+		MaxBW: 13,
+	}
+}
+
+func newE2EID(suffix uint32) reservation.E2EID {
+	id := reservation.E2EID{ASID: xtest.MustParseAS("ff00:0:1")}
+	binary.BigEndian.PutUint32(id.Suffix[6:], suffix)
+	return id
+}
+
+func newE2ESuccessReq(id reservation.E2EID, idx reservation.IndexNumber,
+	bw reservation.BWCls) *e2e.SuccessSetupReq {
+
+	return &e2e.SuccessSetupReq{
+		BaseSetupReq: e2e.BaseSetupReq{ID: id},
+		Token: reservation.Token{
+			InfoField: reservation.InfoField{
+				ExpirationTick: reservation.TickFromTime(
+					time.Now().Add(time.Duration(idx+1) * 16 * time.Second)),
+				BWCls:    bw,
+				Idx:      idx,
+				PathType: reservation.E2EPath,
+			},
+		},
+	}
+}
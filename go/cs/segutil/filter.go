@@ -48,9 +48,11 @@ type Policy interface {
 // NOTE: policy must not be nil.
 func Filter(segs seg.Segments, policy Policy, dir Direction) seg.Segments {
 	// The sequence filter doesn't work for segments, therefore the option to
-	// ignore sequences is passed.
+	// ignore sequences is passed. Constraints apply to end-to-end paths and are
+	// ignored as well.
 	return psToSegs(policy.FilterOpt(segsToPs(segs, dir), pathpol.FilterOptions{
-		IgnoreSequence: true,
+		IgnoreSequence:    true,
+		IgnoreConstraints: true,
 	}))
 }
 
@@ -35,6 +35,7 @@ func TestFilter(t *testing.T) {
 	g := graph.NewDefaultGraph(ctrl)
 	seg110To120 := g.Beacon([]common.IFIDType{graph.If_110_X_120_A})
 	seg110To130 := g.Beacon([]common.IFIDType{graph.If_110_X_130_A})
+	opts := pathpol.FilterOptions{IgnoreSequence: true, IgnoreConstraints: true}
 
 	tests := map[string]struct {
 		Segs         seg.Segments
@@ -46,7 +47,7 @@ func TestFilter(t *testing.T) {
 			Segs: seg.Segments{seg110To120, seg110To130},
 			Policy: func(ctrl *gomock.Controller) segutil.Policy {
 				pol := mock_segutil.NewMockPolicy(ctrl)
-				pol.EXPECT().FilterOpt(gomock.Any(), pathpol.FilterOptions{IgnoreSequence: true})
+				pol.EXPECT().FilterOpt(gomock.Any(), opts)
 				return pol
 			},
 			ExpectedSegs: seg.Segments{},
@@ -55,7 +56,7 @@ func TestFilter(t *testing.T) {
 			Segs: seg.Segments{seg110To120, seg110To130},
 			Policy: func(ctrl *gomock.Controller) segutil.Policy {
 				pol := mock_segutil.NewMockPolicy(ctrl)
-				pol.EXPECT().FilterOpt(gomock.Any(), pathpol.FilterOptions{IgnoreSequence: true}).
+				pol.EXPECT().FilterOpt(gomock.Any(), opts).
 					DoAndReturn(func(paths pathpol.PathSet,
 						f pathpol.FilterOptions) pathpol.PathSet {
 						for key := range paths {
@@ -73,7 +74,7 @@ func TestFilter(t *testing.T) {
 			Segs: seg.Segments{seg110To120, seg110To130},
 			Policy: func(ctrl *gomock.Controller) segutil.Policy {
 				pol := mock_segutil.NewMockPolicy(ctrl)
-				pol.EXPECT().FilterOpt(gomock.Any(), pathpol.FilterOptions{IgnoreSequence: true}).
+				pol.EXPECT().FilterOpt(gomock.Any(), opts).
 					DoAndReturn(func(paths pathpol.PathSet,
 						f pathpol.FilterOptions) pathpol.PathSet {
 
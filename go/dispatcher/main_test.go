@@ -82,6 +82,8 @@ type TestCase struct {
 	TestPackets     []*spkt.ScnPkt
 	UnderlayAddress *net.UDPAddr
 	ExpectedPacket  *spkt.ScnPkt
+	// ExpectedPacketV2 overrides ExpectedPacket for header v2, if set.
+	ExpectedPacketV2 *spkt.ScnPkt
 }
 
 func genTestCases(dispatcherPort int) []*TestCase {
@@ -373,6 +375,30 @@ func genTestCases(dispatcherPort int) []*TestCase {
 					L4Hdr:   common.RawBytes{},
 				},
 			},
+			// With header v2, traceroute uses the new SCMP format, which has
+			// a 16 bit identifier.
+			ExpectedPacketV2: &spkt.ScnPkt{
+				SrcIA:   clientYAddress.IA,
+				DstIA:   clientXAddress.IA,
+				SrcHost: clientYAddress.PublicAddress,
+				DstHost: clientXAddress.PublicAddress,
+				L4: &scmp.Hdr{
+					Class: scmp.C_General, Type: scmp.T_G_TraceRouteReply,
+					TotalLen: 24,
+					Checksum: common.RawBytes{0xb5, 0xde},
+				},
+				Pld: &scmp.Payload{
+					Meta: &scmp.Meta{
+						InfoLen: uint8((&scmp.InfoTraceRoute{}).Len()) / 8,
+					},
+					Info:    &scmp.InfoTraceRoute{Id: 0xcafe},
+					CmnHdr:  common.RawBytes{},
+					AddrHdr: common.RawBytes{},
+					PathHdr: common.RawBytes{},
+					ExtHdrs: common.RawBytes{},
+					L4Hdr:   common.RawBytes{},
+				},
+			},
 		},
 		{
 			Name:            "SCMP::General::RecordPathRequest",
@@ -520,13 +546,17 @@ func RunTestCase(t *testing.T, tc *TestCase, settings *TestSettings) {
 	err = conn.Close()
 	xtest.FailOnErr(t, err, "unable to close conn")
 
-	if !reflect.DeepEqual(&packet, tc.ExpectedPacket) {
-		t.Errorf("bad message received, have %#v, expect %#v", &packet, tc.ExpectedPacket)
-		if !reflect.DeepEqual(packet.L4, tc.ExpectedPacket.L4) {
-			t.Errorf("== headers: have %#v, expect %#v", packet.L4, tc.ExpectedPacket.L4)
+	expected := tc.ExpectedPacket
+	if settings.HeaderV2 && tc.ExpectedPacketV2 != nil {
+		expected = tc.ExpectedPacketV2
+	}
+	if !reflect.DeepEqual(&packet, expected) {
+		t.Errorf("bad message received, have %#v, expect %#v", &packet, expected)
+		if !reflect.DeepEqual(packet.L4, expected.L4) {
+			t.Errorf("== headers: have %#v, expect %#v", packet.L4, expected.L4)
 		}
-		if !reflect.DeepEqual(packet.Pld, tc.ExpectedPacket.Pld) {
-			t.Errorf("== payload: have %#v, expect %#v", packet.Pld, tc.ExpectedPacket.Pld)
+		if !reflect.DeepEqual(packet.Pld, expected.Pld) {
+			t.Errorf("== payload: have %#v, expect %#v", packet.Pld, expected.Pld)
 		}
 	}
 }
@@ -0,0 +1,125 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net"
+	"sync"
+
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hiddenpathdb"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpcfgreq"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpsegreq"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/registration"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// groupHandlers serves the hidden path requests with the currently loaded groups. The group
+// configuration files are loaded on creation and on every call to Reload. A failed reload
+// keeps the previous groups.
+type groupHandlers struct {
+	// Msgr is used to fetch hidden path segments from remote hidden path services.
+	Msgr infra.Messenger
+	// DB stores the hidden path segments.
+	DB hiddenpathdb.HiddenPathDB
+	// SegHandler verifies and stores the registered hidden path segments.
+	SegHandler seghandler.Handler
+
+	localIA addr.IA
+	files   []string
+
+	mu     sync.RWMutex
+	groups map[hiddenpath.GroupId]*hiddenpath.Group
+}
+
+func newGroupHandlers(localIA addr.IA, files []string) (*groupHandlers, error) {
+	h := &groupHandlers{
+		localIA: localIA,
+		files:   files,
+	}
+	if err := h.Reload(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Reload loads the group configuration files. Requests that are already being handled still
+// use the previous groups.
+func (h *groupHandlers) Reload() error {
+	groups, err := hiddenpath.LoadGroups(h.files)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.groups = groups
+	log.Info("Loaded hidden path groups", "count", len(groups))
+	return nil
+}
+
+// Handler returns the handler for the given message type. The handler uses the groups loaded
+// at the time a request is received.
+func (h *groupHandlers) Handler(msgType infra.MessageType) infra.Handler {
+	return infra.HandlerFunc(func(r *infra.Request) *infra.HandlerResult {
+		return h.handler(msgType).Handle(r)
+	})
+}
+
+// LocalHandler returns the handler for the given message type for requests that are received
+// over TCP from within the local AS. The peer address is converted to an address in the local
+// AS, such that the access of the local AS to the groups is checked.
+func (h *groupHandlers) LocalHandler(msgType infra.MessageType) infra.Handler {
+	return infra.HandlerFunc(func(r *infra.Request) *infra.HandlerResult {
+		if peer, ok := r.Peer.(*net.TCPAddr); ok {
+			r.Peer = &snet.UDPAddr{
+				IA:   h.localIA,
+				Host: &net.UDPAddr{IP: peer.IP, Port: peer.Port, Zone: peer.Zone},
+			}
+		}
+		return h.handler(msgType).Handle(r)
+	})
+}
+
+func (h *groupHandlers) handler(msgType infra.MessageType) infra.Handler {
+	h.mu.RLock()
+	groups := h.groups
+	h.mu.RUnlock()
+
+	switch msgType {
+	case infra.HPSegReg:
+		return registration.NewSegRegHandler(
+			registration.NewDefaultValidator(h.localIA, groups),
+			h.SegHandler,
+		)
+	case infra.HPSegRequest:
+		groupInfo := &hpsegreq.GroupInfo{
+			LocalIA: h.localIA,
+			Groups:  groups,
+		}
+		return hpsegreq.NewSegReqHandler(hpsegreq.NewDefaultFetcher(groupInfo, h.Msgr, h.DB))
+	case infra.HPCfgRequest:
+		list := make([]*hiddenpath.Group, 0, len(groups))
+		for _, g := range groups {
+			list = append(list, g)
+		}
+		return hpcfgreq.NewHandler(list, h.localIA)
+	default:
+		panic("unsupported message type: " + msgType.String())
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hpsconfig contains the configuration of the hidden path service.
+package hpsconfig
+
+import (
+	"io"
+	"net"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathstorage"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/truststorage"
+)
+
+var _ config.Config = (*Config)(nil)
+
+// Config is the hidden path service configuration.
+type Config struct {
+	General  env.General              `toml:"general,omitempty"`
+	Features env.Features             `toml:"features,omitempty"`
+	Logging  log.Config               `toml:"log,omitempty"`
+	Metrics  env.Metrics              `toml:"metrics,omitempty"`
+	QUIC     env.QUIC                 `toml:"quic,omitempty"`
+	Sciond   env.SCIONDClient         `toml:"sciond_connection,omitempty"`
+	TrustDB  truststorage.TrustDBConf `toml:"trust_db,omitempty"`
+	// PathDB contains the configuration for the database of the hidden path segments.
+	PathDB pathstorage.PathDBConf `toml:"path_db,omitempty"`
+	// RevCache contains the configuration for the revocation cache.
+	RevCache pathstorage.RevCacheConf `toml:"rev_cache,omitempty"`
+	HPS      HPSConfig                `toml:"hidden_path,omitempty"`
+}
+
+func (cfg *Config) InitDefaults() {
+	config.InitAll(
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.Sciond,
+		&cfg.TrustDB,
+		&cfg.PathDB,
+		&cfg.RevCache,
+		&cfg.HPS,
+	)
+}
+
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.Sciond,
+		&cfg.TrustDB,
+		&cfg.PathDB,
+		&cfg.RevCache,
+		&cfg.HPS,
+	)
+}
+
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteSample(dst, path, config.CtxMap{config.ID: idSample},
+		&cfg.General,
+		&cfg.Features,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.QUIC,
+		&cfg.Sciond,
+		&cfg.TrustDB,
+		&cfg.PathDB,
+		&cfg.RevCache,
+		&cfg.HPS,
+	)
+}
+
+var _ config.Config = (*HPSConfig)(nil)
+
+// HPSConfig holds the configuration specific to the hidden path service.
+type HPSConfig struct {
+	// Address is the local address to listen on for SCION messages, and to send out messages to
+	// other nodes.
+	Address string `toml:"address,omitempty"`
+	// GroupConfigFiles are the files containing the hidden path group configurations. Each file
+	// contains a single group. The files are reloaded on SIGHUP.
+	GroupConfigFiles []string `toml:"group_config_files,omitempty"`
+}
+
+func (cfg *HPSConfig) InitDefaults() {}
+
+func (cfg *HPSConfig) Validate() error {
+	if cfg.Address == "" {
+		return serrors.New("address must be set")
+	}
+	if _, err := net.ResolveUDPAddr("udp", cfg.Address); err != nil {
+		return serrors.WrapStr("invalid address", err, "address", cfg.Address)
+	}
+	return nil
+}
+
+func (cfg *HPSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, hpsSample)
+}
+
+func (cfg *HPSConfig) ConfigName() string {
+	return "hidden_path"
+}
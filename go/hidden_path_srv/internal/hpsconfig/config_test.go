@@ -0,0 +1,74 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpsconfig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/env/envtest"
+	"github.com/scionproto/scion/go/lib/log/logtest"
+	"github.com/scionproto/scion/go/lib/pathstorage/pathstoragetest"
+	"github.com/scionproto/scion/go/lib/truststorage/truststoragetest"
+)
+
+func TestConfigSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg Config
+	cfg.Sample(&sample, nil, nil)
+
+	InitTestConfig(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	CheckTestConfig(t, &cfg, idSample)
+}
+
+func TestHPSConfigValidate(t *testing.T) {
+	cfg := HPSConfig{}
+	cfg.InitDefaults()
+	assert.Error(t, cfg.Validate())
+	cfg.Address = "garbage"
+	assert.Error(t, cfg.Validate())
+	cfg.Address = "127.0.0.1:30260"
+	assert.NoError(t, cfg.Validate())
+}
+
+func InitTestConfig(cfg *Config) {
+	envtest.InitTest(&cfg.General, &cfg.Metrics, nil, &cfg.Sciond)
+	logtest.InitTestLogging(&cfg.Logging)
+	truststoragetest.InitTestConfig(&cfg.TrustDB)
+	pathstoragetest.InitTestPathDBConf(&cfg.PathDB)
+	pathstoragetest.InitTestRevCacheConf(&cfg.RevCache)
+	InitTestHPSConfig(&cfg.HPS)
+}
+
+func InitTestHPSConfig(cfg *HPSConfig) {}
+
+func CheckTestConfig(t *testing.T, cfg *Config, id string) {
+	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, &cfg.Sciond, id)
+	logtest.CheckTestLogging(t, &cfg.Logging, id)
+	truststoragetest.CheckTestConfig(t, &cfg.TrustDB, id)
+	pathstoragetest.CheckTestPathDBConf(t, &cfg.PathDB, id)
+	pathstoragetest.CheckTestRevCacheConf(t, &cfg.RevCache, id)
+	CheckTestHPSConfig(t, &cfg.HPS, id)
+}
+
+func CheckTestHPSConfig(t *testing.T, cfg *HPSConfig, id string) {
+	assert.Equal(t, "127.0.0.1:30260", cfg.Address)
+	assert.Equal(t, []string{"/etc/scion/hpg/ff00_0_110-69b5.json"}, cfg.GroupConfigFiles)
+}
@@ -0,0 +1,27 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpsconfig
+
+const idSample = "hps"
+
+const hpsSample = `
+# The local address to listen on for SCION messages, and to send out messages
+# to other nodes. (required)
+address = "127.0.0.1:30260"
+
+# The files containing the hidden path group configurations. Each file contains
+# a single group in JSON format. The groups are reloaded on SIGHUP.
+group_config_files = ["/etc/scion/hpg/ff00_0_110-69b5.json"]
+`
@@ -0,0 +1,281 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hiddenpathdb/adapter"
+	"github.com/scionproto/scion/go/hidden_path_srv/internal/hpsconfig"
+	"github.com/scionproto/scion/go/lib/addr"
+	libconfig "github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/infraenv"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/messenger/tcp"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathstorage"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/pkg/trust"
+	"github.com/scionproto/scion/go/pkg/trust/compat"
+	trustmetrics "github.com/scionproto/scion/go/pkg/trust/metrics"
+)
+
+func main() {
+	var flags struct {
+		config string
+	}
+	cmd := &cobra.Command{
+		Use:           "hidden_path_srv",
+		Short:         "SCION hidden path service",
+		Example:       "  hidden_path_srv --config hps.toml",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(flags.config)
+		},
+	}
+	cmd.AddCommand(
+		command.NewCompletion(cmd),
+		command.NewSample(cmd, command.NewSampleConfig(&hpsconfig.Config{})),
+		command.NewVersion(cmd),
+	)
+	cmd.Flags().StringVar(&flags.config, "config", "", "Configuration file (required)")
+	cmd.MarkFlagRequired("config")
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(file string) error {
+	fatal.Init()
+	cfg, err := setupBasic(file)
+	if err != nil {
+		return err
+	}
+	defer log.Flush()
+	defer env.LogAppStopped("HPS", cfg.General.ID)
+	defer log.HandlePanic()
+	if err := cfg.Validate(); err != nil {
+		return serrors.WrapStr("validating config", err)
+	}
+	if err := setupTopo(cfg); err != nil {
+		return err
+	}
+	topo := itopo.Get()
+
+	groups, err := newGroupHandlers(topo.IA(), cfg.HPS.GroupConfigFiles)
+	if err != nil {
+		return serrors.WrapStr("loading hidden path groups", err)
+	}
+	infraenv.InitInfraEnvironmentFunc(cfg.General.Topology(), func() {
+		if err := groups.Reload(); err != nil {
+			log.Error("Failed to reload hidden path groups", "err", err)
+		}
+	})
+
+	router, err := infraenv.NewRouter(topo.IA(), cfg.Sciond)
+	if err != nil {
+		return serrors.WrapStr("initializing path router", err)
+	}
+	public, err := net.ResolveUDPAddr("udp", cfg.HPS.Address)
+	if err != nil {
+		return serrors.WrapStr("resolving listen address", err, "address", cfg.HPS.Address)
+	}
+	nc := infraenv.NetworkConfig{
+		IA:                    topo.IA(),
+		Public:                public,
+		SVC:                   addr.SvcNone,
+		ReconnectToDispatcher: cfg.General.ReconnectToDispatcher,
+		QUIC: infraenv.QUIC{
+			Address:  cfg.QUIC.Address,
+			CertFile: cfg.QUIC.CertFile,
+			KeyFile:  cfg.QUIC.KeyFile,
+		},
+		SVCResolutionFraction: cfg.QUIC.ResolutionFraction,
+		Router:                router,
+		SVCRouter:             messenger.NewSVCRouter(itopo.Provider()),
+		Version2:              cfg.Features.HeaderV2,
+	}
+	msgr, err := nc.Messenger()
+	if err != nil {
+		return serrors.Wrap(infraenv.ErrAppUnableToInitMessenger, err)
+	}
+	defer msgr.CloseServer()
+
+	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.PathDB, cfg.RevCache)
+	if err != nil {
+		return serrors.WrapStr("initializing path storage", err)
+	}
+	pathDB = pathdb.WithMetrics(string(cfg.PathDB.Backend()), pathDB)
+	defer pathDB.Close()
+	defer revCache.Close()
+	cleaner := periodic.Start(pathdb.NewCleaner(pathDB, "hps_segments"),
+		300*time.Second, 295*time.Second)
+	defer cleaner.Stop()
+	rcCleaner := periodic.Start(revcache.NewCleaner(revCache, "hps_revocation"),
+		10*time.Second, 10*time.Second)
+	defer rcCleaner.Stop()
+
+	trustDB, err := cfg.TrustDB.New()
+	if err != nil {
+		return serrors.WrapStr("initializing trust database", err)
+	}
+	trustDB = trustmetrics.WrapDB(string(cfg.TrustDB.Backend()), trustDB)
+	defer trustDB.Close()
+	provider, err := newTrustProvider(cfg.General.ConfigDir, topo.IA(), trustDB, msgr)
+	if err != nil {
+		return serrors.WrapStr("creating trust provider", err)
+	}
+
+	groups.Msgr = msgr
+	groups.DB = adapter.New(pathDB)
+	groups.SegHandler = seghandler.Handler{
+		Verifier: &seghandler.DefaultVerifier{
+			Verifier: compat.Verifier{Verifier: trust.Verifier{Engine: provider}},
+		},
+		Storage: &seghandler.DefaultStorage{
+			PathDB:   pathDB,
+			RevCache: revCache,
+		},
+	}
+	msgr.AddHandler(infra.HPSegReg, groups.Handler(infra.HPSegReg))
+	msgr.AddHandler(infra.HPSegRequest, groups.Handler(infra.HPSegRequest))
+	msgr.AddHandler(infra.HPCfgRequest, groups.Handler(infra.HPCfgRequest))
+	go func() {
+		defer log.HandlePanic()
+		msgr.ListenAndServe()
+	}()
+	// Clients in the local AS, e.g., SCIOND, request hidden path segments over TCP.
+	tcpMsgr := tcp.NewServerMessenger(&net.TCPAddr{
+		IP:   public.IP,
+		Port: public.Port,
+		Zone: public.Zone,
+	})
+	tcpMsgr.AddHandler(infra.HPSegRequest, groups.LocalHandler(infra.HPSegRequest))
+	tcpMsgr.AddHandler(infra.HPCfgRequest, groups.LocalHandler(infra.HPCfgRequest))
+	go func() {
+		defer log.HandlePanic()
+		tcpMsgr.ListenAndServe()
+	}()
+	defer tcpMsgr.CloseServer()
+
+	startHTTPEndpoints(cfg)
+	select {
+	case <-fatal.ShutdownChan():
+		// Whenever we receive a SIGINT or SIGTERM we exit without an error.
+		// Deferred shutdowns for all running servers run now.
+		return nil
+	case <-fatal.FatalChan():
+		return serrors.New("shutdown on error")
+	}
+}
+
+func setupBasic(file string) (hpsconfig.Config, error) {
+	var cfg hpsconfig.Config
+	if err := libconfig.LoadFile(file, &cfg); err != nil {
+		return hpsconfig.Config{}, serrors.WrapStr("loading config from file", err,
+			"file", file)
+	}
+	cfg.InitDefaults()
+	if err := log.Setup(cfg.Logging); err != nil {
+		return hpsconfig.Config{}, serrors.WrapStr("initialize logging", err)
+	}
+	prom.ExportElementID(cfg.General.ID)
+	if err := env.LogAppStarted("HPS", cfg.General.ID); err != nil {
+		return hpsconfig.Config{}, err
+	}
+	return cfg, nil
+}
+
+func setupTopo(cfg hpsconfig.Config) error {
+	topo, err := topology.FromJSONFile(cfg.General.Topology())
+	if err != nil {
+		return serrors.WrapStr("loading topology", err)
+	}
+	itopo.Init(&itopo.Config{})
+	if err := itopo.Update(topo); err != nil {
+		return serrors.WrapStr("unable to set initial static topology", err)
+	}
+	return nil
+}
+
+// newTrustProvider loads the trust material from the config directory and creates a provider
+// that fetches missing material from the local control service.
+func newTrustProvider(configDir string, ia addr.IA, db trust.DB,
+	rpc trust.RPC) (trust.Provider, error) {
+
+	certsDir := filepath.Join(configDir, "certs")
+	loaded, err := trust.LoadTRCs(context.Background(), certsDir, db)
+	if err != nil {
+		return nil, serrors.WrapStr("loading TRCs", err)
+	}
+	log.Info("TRCs loaded", "files", loaded.Loaded)
+	for f, r := range loaded.Ignored {
+		log.Info("Ignoring non-TRC", "file", f, "reason", r)
+	}
+	loaded, err = trust.LoadChains(context.Background(), certsDir, db)
+	if err != nil {
+		return nil, serrors.WrapStr("loading certificate chains", err)
+	}
+	log.Info("Certificate chains loaded", "files", loaded.Loaded)
+	for f, r := range loaded.Ignored {
+		log.Info("Ignoring non-certificate chain", "file", f, "reason", r)
+	}
+	return trust.FetchingProvider{
+		DB: db,
+		Fetcher: trust.DefaultFetcher{
+			RPC: rpc,
+			IA:  ia,
+		},
+		Recurser: trust.LocalOnlyRecurser{},
+		Router:   trust.LocalRouter{IA: ia},
+	}, nil
+}
+
+func startHTTPEndpoints(cfg hpsconfig.Config) {
+	http.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		var buf bytes.Buffer
+		toml.NewEncoder(&buf).Order(toml.OrderPreserve).Encode(cfg)
+		fmt.Fprint(w, buf.String())
+	})
+	http.HandleFunc("/info", env.InfoHandler)
+	http.HandleFunc("/topology", itopo.TopologyHandler)
+	cfg.Metrics.StartPrometheus()
+}
@@ -88,6 +88,31 @@ func TestValidateBWCls(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestBWClsToKbps(t *testing.T) {
+	require.Equal(t, uint64(11), BWCls(0).ToKbps())
+	require.Equal(t, uint64(16), BWCls(1).ToKbps())
+	require.Equal(t, uint64(22), BWCls(2).ToKbps())
+	require.Equal(t, uint64(32), BWCls(3).ToKbps())
+	require.Equal(t, uint64(1024), BWCls(13).ToKbps())
+	require.Equal(t, uint64(1<<35), BWCls(63).ToKbps())
+}
+
+func TestBWClsFromBW(t *testing.T) {
+	require.Equal(t, BWCls(0), BWClsFromBW(0))
+	require.Equal(t, BWCls(0), BWClsFromBW(15))
+	require.Equal(t, BWCls(1), BWClsFromBW(16))
+	require.Equal(t, BWCls(1), BWClsFromBW(21))
+	require.Equal(t, BWCls(2), BWClsFromBW(22))
+	require.Equal(t, BWCls(3), BWClsFromBW(32))
+	require.Equal(t, BWCls(13), BWClsFromBW(1024))
+	require.Equal(t, BWCls(13), BWClsFromBW(1447))
+	require.Equal(t, BWCls(63), BWClsFromBW(1<<40))
+	for i := 1; i < 64; i++ {
+		c := BWCls(i)
+		require.Equal(t, c, BWClsFromBW(c.ToKbps()), "bw_cls %d", i)
+	}
+}
+
 func TestValidateRLC(t *testing.T) {
 	for i := 0; i < 64; i++ {
 		c := RLC(i)
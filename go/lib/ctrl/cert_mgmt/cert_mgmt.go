@@ -30,6 +30,8 @@ type union struct {
 	ChainRenewalReply   *ChainRenewalReply   `capnp:"certChainRenewalReply"`
 	TRCReq              *TRCReq              `capnp:"trcReq"`
 	TRCRep              *TRC                 `capnp:"trc"`
+	DenyListReq         *DenyListReq         `capnp:"denyListReq"`
+	DenyListRep         *DenyList            `capnp:"denyList"`
 }
 
 func (u *union) set(c proto.Cerealizable) error {
@@ -52,6 +54,12 @@ func (u *union) set(c proto.Cerealizable) error {
 	case *TRC:
 		u.Which = proto.CertMgmt_Which_trc
 		u.TRCRep = p
+	case *DenyListReq:
+		u.Which = proto.CertMgmt_Which_denyListReq
+		u.DenyListReq = p
+	case *DenyList:
+		u.Which = proto.CertMgmt_Which_denyList
+		u.DenyListRep = p
 	default:
 		return common.NewBasicError("Unsupported cert mgmt union type (set)", nil,
 			"type", common.TypeOf(c))
@@ -73,6 +81,10 @@ func (u *union) get() (proto.Cerealizable, error) {
 		return u.TRCReq, nil
 	case proto.CertMgmt_Which_trc:
 		return u.TRCRep, nil
+	case proto.CertMgmt_Which_denyListReq:
+		return u.DenyListReq, nil
+	case proto.CertMgmt_Which_denyList:
+		return u.DenyListRep, nil
 	}
 	return nil, common.NewBasicError("Unsupported cert mgmt union type (get)", nil, "type", u.Which)
 }
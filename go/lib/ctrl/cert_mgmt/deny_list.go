@@ -0,0 +1,44 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert_mgmt
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*DenyList)(nil)
+
+// DenyList is a capnp message that contains a deny list.
+type DenyList struct {
+	// RawDenyList contains the raw signed deny list. The raw signed deny list
+	// should be decodable by cppki.DecodeSignedDenyList.
+	RawDenyList []byte `capnp:"denyList"`
+}
+
+func (d *DenyList) ProtoId() proto.ProtoIdType {
+	return proto.DenyList_TypeID
+}
+
+func (d *DenyList) String() string {
+	dl, err := cppki.DecodeSignedDenyList(d.RawDenyList)
+	if err != nil {
+		return fmt.Sprintf("Invalid signed deny list: %v", err)
+	}
+	return fmt.Sprintf("ISD: %d Serial: %s Entries: %d", dl.DenyList.ISD,
+		dl.DenyList.Serial, len(dl.DenyList.Entries))
+}
@@ -0,0 +1,40 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert_mgmt
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*DenyListReq)(nil)
+
+// DenyListReq is the capnp encodable deny list request. A zero serial number
+// requests the latest deny list.
+type DenyListReq struct {
+	ISD    addr.ISD        `capnp:"isd"`
+	Serial scrypto.Version `capnp:"serial"`
+}
+
+func (r *DenyListReq) ProtoId() proto.ProtoIdType {
+	return proto.DenyListReq_TypeID
+}
+
+func (r *DenyListReq) String() string {
+	return fmt.Sprintf("ISD: %d Serial: %s", r.ISD, r.Serial)
+}
@@ -25,6 +25,11 @@ type ColibriRequestPayload struct {
 	Which     proto.ColibriRequestPayload_Which
 	Request   *Request
 	Response  *Response
+	// SegmentID, Index and Path travel in the hop by hop extension on the data plane,
+	// and are set here only when the payload is sent between control services.
+	SegmentID *SegmentReservationID `capnp:"segmentID"`
+	Index     uint8
+	Path      []byte
 }
 
 var _ proto.Cerealizable = (*ColibriRequestPayload)(nil)
@@ -40,6 +40,28 @@ func TestSerializeRoot(t *testing.T) {
 	require.Equal(t, buffer, otherBuffer)
 }
 
+func TestSerializeControlPlaneFields(t *testing.T) {
+	root := &colibri_mgmt.ColibriRequestPayload{
+		Timestamp: 42,
+		Which:     proto.ColibriRequestPayload_Which_request,
+		Request: &colibri_mgmt.Request{
+			Which:           proto.Request_Which_segmentTeardown,
+			SegmentTeardown: &colibri_mgmt.SegmentTeardownReq{},
+		},
+		SegmentID: &colibri_mgmt.SegmentReservationID{
+			ASID:   xtest.MustParseHexString("ff00cafe0001"),
+			Suffix: xtest.MustParseHexString("deadbeef"),
+		},
+		Index: 3,
+		Path:  xtest.MustParseHexString("0011223344556677"),
+	}
+	buffer, err := root.PackRoot()
+	require.NoError(t, err)
+	otherRoot, err := colibri_mgmt.NewFromRaw(buffer)
+	require.NoError(t, err)
+	require.Equal(t, root, otherRoot)
+}
+
 // tests serialization for all types of requests
 func TestSerializeRequest(t *testing.T) {
 	newSegmentSetup := func() *colibri_mgmt.SegmentSetup {
@@ -0,0 +1,119 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestSerialize(t *testing.T) {
+	testCases := map[string]proto.Cerealizable{
+		"lvl1 request": &drkey_mgmt.Lvl1Req{
+			RawDstIA:     xtest.MustParseIA("1-ff00:0:111").IAInt(),
+			ValTime:      10,
+			Timestamp:    11,
+			EphemeralKey: xtest.MustParseHexString("00112233"),
+			Signature: &proto.SignS{
+				Src:       []byte("src"),
+				Signature: []byte("signature"),
+			},
+		},
+		"lvl1 reply": &drkey_mgmt.Lvl1Rep{
+			RawDstIA:     xtest.MustParseIA("1-ff00:0:111").IAInt(),
+			EpochBegin:   1,
+			EpochEnd:     2,
+			Cipher:       xtest.MustParseHexString("aabbccdd"),
+			Nonce:        xtest.MustParseHexString("0102"),
+			EphemeralKey: xtest.MustParseHexString("00112233"),
+			Timestamp:    3,
+			Signature: &proto.SignS{
+				Src:       []byte("src"),
+				Signature: []byte("signature"),
+			},
+		},
+		"lvl2 request": &drkey_mgmt.Lvl2Req{
+			Protocol: "scmp",
+			ReqType:  uint8(drkey.Host2Host),
+			ValTime:  10,
+			RawSrcIA: xtest.MustParseIA("1-ff00:0:111").IAInt(),
+			RawDstIA: xtest.MustParseIA("1-ff00:0:112").IAInt(),
+			SrcHost:  drkey_mgmt.NewHost(addr.HostFromIPStr("127.0.0.1")),
+			DstHost:  drkey_mgmt.NewHost(addr.HostFromIPStr("::1")),
+		},
+		"lvl2 reply": &drkey_mgmt.Lvl2Rep{
+			Timestamp:  3,
+			DRKey:      xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+			EpochBegin: 1,
+			EpochEnd:   2,
+		},
+	}
+	for name, msg := range testCases {
+		name, msg := name, msg
+		t.Run(name, func(t *testing.T) {
+			pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, &ctrl.Data{ReqId: 42})
+			require.NoError(t, err)
+			raw, err := proto.PackRoot(pld)
+			require.NoError(t, err)
+			parsed, err := ctrl.NewPldFromRaw(raw)
+			require.NoError(t, err)
+			u, err := parsed.Union()
+			require.NoError(t, err)
+			dpld, ok := u.(*drkey_mgmt.Pld)
+			require.True(t, ok)
+			inner, err := dpld.Union()
+			require.NoError(t, err)
+			assert.Equal(t, msg, inner)
+		})
+	}
+}
+
+func TestLvl2ReqMeta(t *testing.T) {
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: "scmp",
+		SrcIA:    xtest.MustParseIA("1-ff00:0:111"),
+		DstIA:    xtest.MustParseIA("1-ff00:0:112"),
+		DstHost:  addr.HostFromIPStr("127.0.0.1"),
+	}
+	req := drkey_mgmt.NewLvl2ReqFromMeta(meta, time.Now())
+	parsed, err := req.ToMeta()
+	require.NoError(t, err)
+	assert.Equal(t, meta, parsed)
+}
+
+func TestSigInput(t *testing.T) {
+	req := &drkey_mgmt.Lvl1Req{
+		RawDstIA:     xtest.MustParseIA("1-ff00:0:111").IAInt(),
+		ValTime:      10,
+		Timestamp:    11,
+		EphemeralKey: xtest.MustParseHexString("00112233"),
+	}
+	input := req.SigInput()
+	req.Signature = &proto.SignS{Signature: []byte("signature")}
+	assert.Equal(t, input, req.SigInput())
+	req.ValTime = 12
+	assert.NotEqual(t, input, req.SigInput())
+}
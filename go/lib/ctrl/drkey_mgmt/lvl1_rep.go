@@ -0,0 +1,81 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Lvl1Rep)(nil)
+
+// Lvl1Rep is the reply to a Lvl1Req. It carries the level 1 key encrypted for the requester
+// and is signed by the responder.
+type Lvl1Rep struct {
+	// RawDstIA is the IA of the requester, i.e. the destination of the key.
+	RawDstIA addr.IAInt `capnp:"dstIA"`
+	// EpochBegin is the begin of the validity period of the key.
+	EpochBegin uint32
+	// EpochEnd is the end of the validity period of the key.
+	EpochEnd uint32
+	// Cipher is the encrypted level 1 key.
+	Cipher []byte
+	// Nonce is the nonce used to encrypt the key.
+	Nonce []byte
+	// EphemeralKey is the public key of the responder used to encrypt the key.
+	EphemeralKey []byte
+	// Timestamp is the time when the reply was created.
+	Timestamp uint32
+	Signature *proto.SignS `capnp:"sign"`
+}
+
+// DstIA returns the IA of the requester.
+func (r *Lvl1Rep) DstIA() addr.IA {
+	return r.RawDstIA.IA()
+}
+
+// TimestampRaw returns the timestamp as time.Time.
+func (r *Lvl1Rep) TimestampRaw() time.Time {
+	return util.SecsToTime(r.Timestamp)
+}
+
+// SigInput returns the bytes covered by the signature.
+func (r *Lvl1Rep) SigInput() []byte {
+	b := make([]byte, 20)
+	binary.BigEndian.PutUint64(b[0:8], uint64(r.RawDstIA))
+	binary.BigEndian.PutUint32(b[8:12], r.EpochBegin)
+	binary.BigEndian.PutUint32(b[12:16], r.EpochEnd)
+	binary.BigEndian.PutUint32(b[16:20], r.Timestamp)
+	for _, v := range [][]byte{r.Cipher, r.Nonce, r.EphemeralKey} {
+		b = append(b, byte(len(v)))
+		b = append(b, v...)
+	}
+	return b
+}
+
+func (r *Lvl1Rep) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyLvl1Rep_TypeID
+}
+
+func (r *Lvl1Rep) String() string {
+	return fmt.Sprintf("DstIA: %s Epoch: [%s, %s] Timestamp: %s", r.DstIA(),
+		util.SecsToCompact(r.EpochBegin), util.SecsToCompact(r.EpochEnd),
+		util.TimeToCompact(r.TimestampRaw()))
+}
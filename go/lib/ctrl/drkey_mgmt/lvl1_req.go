@@ -0,0 +1,84 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Lvl1Req)(nil)
+
+// Lvl1Req is a request for the level 1 key K_{A->B}, where A is the AS receiving the request and
+// B the AS of the requester. The request is signed by the requester.
+type Lvl1Req struct {
+	// RawDstIA is the IA of the requester, i.e. the destination of the key.
+	RawDstIA addr.IAInt `capnp:"dstIA"`
+	// ValTime is the point in time for which the key must be valid.
+	ValTime uint32
+	// Timestamp is the time when the request was created.
+	Timestamp uint32
+	// EphemeralKey is the public key the responder uses to encrypt the level 1 key.
+	EphemeralKey []byte
+	Signature    *proto.SignS `capnp:"sign"`
+}
+
+// NewLvl1Req creates a new unsigned level 1 key request.
+func NewLvl1Req(dstIA addr.IA, valTime time.Time, ephemeralKey []byte) *Lvl1Req {
+	return &Lvl1Req{
+		RawDstIA:     dstIA.IAInt(),
+		ValTime:      util.TimeToSecs(valTime),
+		Timestamp:    util.TimeToSecs(time.Now()),
+		EphemeralKey: ephemeralKey,
+	}
+}
+
+// DstIA returns the IA of the requester.
+func (r *Lvl1Req) DstIA() addr.IA {
+	return r.RawDstIA.IA()
+}
+
+// ValTimeRaw returns the validity time as time.Time.
+func (r *Lvl1Req) ValTimeRaw() time.Time {
+	return util.SecsToTime(r.ValTime)
+}
+
+// TimestampRaw returns the timestamp as time.Time.
+func (r *Lvl1Req) TimestampRaw() time.Time {
+	return util.SecsToTime(r.Timestamp)
+}
+
+// SigInput returns the bytes covered by the signature.
+func (r *Lvl1Req) SigInput() []byte {
+	b := make([]byte, 16, 16+len(r.EphemeralKey))
+	binary.BigEndian.PutUint64(b[0:8], uint64(r.RawDstIA))
+	binary.BigEndian.PutUint32(b[8:12], r.ValTime)
+	binary.BigEndian.PutUint32(b[12:16], r.Timestamp)
+	return append(b, r.EphemeralKey...)
+}
+
+func (r *Lvl1Req) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyLvl1Req_TypeID
+}
+
+func (r *Lvl1Req) String() string {
+	return fmt.Sprintf("DstIA: %s ValTime: %s Timestamp: %s", r.DstIA(),
+		util.TimeToCompact(r.ValTimeRaw()), util.TimeToCompact(r.TimestampRaw()))
+}
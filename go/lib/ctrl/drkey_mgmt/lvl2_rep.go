@@ -0,0 +1,70 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Lvl2Rep)(nil)
+
+// Lvl2Rep is the reply to a Lvl2Req. If the key cannot be derived, the key is empty.
+type Lvl2Rep struct {
+	// Timestamp is the time when the reply was created.
+	Timestamp  uint32
+	DRKey      []byte `capnp:"drkey"`
+	EpochBegin uint32
+	EpochEnd   uint32
+}
+
+// NewLvl2RepFromKey creates a reply carrying the key.
+func NewLvl2RepFromKey(key drkey.Lvl2Key, now time.Time) *Lvl2Rep {
+	return &Lvl2Rep{
+		Timestamp:  util.TimeToSecs(now),
+		DRKey:      key.Key,
+		EpochBegin: key.Epoch.Begin(),
+		EpochEnd:   key.Epoch.End(),
+	}
+}
+
+// ToKey returns the level 2 key described by meta from the reply. The epoch of meta is set
+// to the epoch in the reply.
+func (r *Lvl2Rep) ToKey(meta drkey.Lvl2Meta) drkey.Lvl2Key {
+	meta.Epoch = drkey.NewEpoch(r.EpochBegin, r.EpochEnd)
+	return drkey.Lvl2Key{
+		Lvl2Meta: meta,
+		Key:      r.DRKey,
+	}
+}
+
+// TimestampRaw returns the timestamp as time.Time.
+func (r *Lvl2Rep) TimestampRaw() time.Time {
+	return util.SecsToTime(r.Timestamp)
+}
+
+func (r *Lvl2Rep) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyLvl2Rep_TypeID
+}
+
+func (r *Lvl2Rep) String() string {
+	return fmt.Sprintf("Timestamp: %s Epoch: [%s, %s] Key set: %t",
+		util.TimeToCompact(r.TimestampRaw()), util.SecsToCompact(r.EpochBegin),
+		util.SecsToCompact(r.EpochEnd), len(r.DRKey) > 0)
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_mgmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Host)(nil)
+
+// Host is an end host address in a level 2 key request.
+type Host struct {
+	Type addr.HostAddrType
+	Host []byte
+}
+
+// NewHost creates a new host from the address. A nil address results in an empty host.
+func NewHost(host addr.HostAddr) *Host {
+	if host == nil {
+		return &Host{Type: addr.HostTypeNone}
+	}
+	return &Host{
+		Type: host.Type(),
+		Host: host.Pack(),
+	}
+}
+
+// ToHostAddr returns the host address, or nil if the host is empty.
+func (h *Host) ToHostAddr() (addr.HostAddr, error) {
+	if h == nil || h.Type == addr.HostTypeNone {
+		return nil, nil
+	}
+	host, err := addr.HostFromRaw(h.Host, h.Type)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing host", err, "type", h.Type)
+	}
+	return host, nil
+}
+
+func (h *Host) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyHost_TypeID
+}
+
+func (h *Host) String() string {
+	host, err := h.ToHostAddr()
+	if err != nil {
+		return fmt.Sprintf("invalid host: %v", err)
+	}
+	return fmt.Sprintf("%v", host)
+}
+
+var _ proto.Cerealizable = (*Lvl2Req)(nil)
+
+// Lvl2Req is a request for a level 2 key.
+type Lvl2Req struct {
+	Protocol string
+	ReqType  uint8
+	// ValTime is the point in time for which the key must be valid.
+	ValTime  uint32
+	RawSrcIA addr.IAInt `capnp:"srcIA"`
+	RawDstIA addr.IAInt `capnp:"dstIA"`
+	SrcHost  *Host
+	DstHost  *Host
+}
+
+// NewLvl2ReqFromMeta creates a new request for the level 2 key described by meta that is valid
+// at valTime. The epoch in meta is ignored.
+func NewLvl2ReqFromMeta(meta drkey.Lvl2Meta, valTime time.Time) *Lvl2Req {
+	return &Lvl2Req{
+		Protocol: meta.Protocol,
+		ReqType:  uint8(meta.KeyType),
+		ValTime:  util.TimeToSecs(valTime),
+		RawSrcIA: meta.SrcIA.IAInt(),
+		RawDstIA: meta.DstIA.IAInt(),
+		SrcHost:  NewHost(meta.SrcHost),
+		DstHost:  NewHost(meta.DstHost),
+	}
+}
+
+// ToMeta returns the description of the requested key. The epoch is not set.
+func (r *Lvl2Req) ToMeta() (drkey.Lvl2Meta, error) {
+	srcHost, err := r.SrcHost.ToHostAddr()
+	if err != nil {
+		return drkey.Lvl2Meta{}, err
+	}
+	dstHost, err := r.DstHost.ToHostAddr()
+	if err != nil {
+		return drkey.Lvl2Meta{}, err
+	}
+	return drkey.Lvl2Meta{
+		KeyType:  drkey.Lvl2KeyType(r.ReqType),
+		Protocol: r.Protocol,
+		SrcIA:    r.SrcIA(),
+		DstIA:    r.DstIA(),
+		SrcHost:  srcHost,
+		DstHost:  dstHost,
+	}, nil
+}
+
+// SrcIA returns the source IA of the key.
+func (r *Lvl2Req) SrcIA() addr.IA {
+	return r.RawSrcIA.IA()
+}
+
+// DstIA returns the destination IA of the key.
+func (r *Lvl2Req) DstIA() addr.IA {
+	return r.RawDstIA.IA()
+}
+
+// ValTimeRaw returns the validity time as time.Time.
+func (r *Lvl2Req) ValTimeRaw() time.Time {
+	return util.SecsToTime(r.ValTime)
+}
+
+func (r *Lvl2Req) ProtoId() proto.ProtoIdType {
+	return proto.DRKeyLvl2Req_TypeID
+}
+
+func (r *Lvl2Req) String() string {
+	return fmt.Sprintf("Protocol: %s Type: %s ValTime: %s SrcIA: %s DstIA: %s "+
+		"SrcHost: %s DstHost: %s", r.Protocol, drkey.Lvl2KeyType(r.ReqType),
+		util.TimeToCompact(r.ValTimeRaw()), r.SrcIA(), r.DstIA(), r.SrcHost, r.DstHost)
+}
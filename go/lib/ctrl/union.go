@@ -19,6 +19,8 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/extn"
 	"github.com/scionproto/scion/go/lib/ctrl/ifid"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
@@ -34,11 +36,12 @@ type union struct {
 	IfID      *ifid.IFID  `capnp:"ifid"`
 	CertMgmt  *cert_mgmt.Pld
 	PathMgmt  *path_mgmt.Pld
-	Sibra     []byte `capnp:"-"` // Omit for now
-	DRKeyMgmt []byte `capnp:"-"` // Omit for now
+	Sibra     []byte          `capnp:"-"` // Omit for now
+	DRKeyMgmt *drkey_mgmt.Pld `capnp:"drkeyMgmt"`
 	Sig       *sig_mgmt.Pld
 	Extn      *extn.CtrlExtnDataList
 	Ack       *ack.Ack
+	Colibri   *colibri_mgmt.ColibriRequestPayload
 }
 
 func (u *union) set(c proto.Cerealizable) error {
@@ -61,9 +64,15 @@ func (u *union) set(c proto.Cerealizable) error {
 	case *extn.CtrlExtnDataList:
 		u.Which = proto.CtrlPld_Which_extn
 		u.Extn = p
+	case *drkey_mgmt.Pld:
+		u.Which = proto.CtrlPld_Which_drkeyMgmt
+		u.DRKeyMgmt = p
 	case *ack.Ack:
 		u.Which = proto.CtrlPld_Which_ack
 		u.Ack = p
+	case *colibri_mgmt.ColibriRequestPayload:
+		u.Which = proto.CtrlPld_Which_colibri
+		u.Colibri = p
 	default:
 		return common.NewBasicError("Unsupported ctrl union type (set)", nil,
 			"type", common.TypeOf(c))
@@ -85,8 +94,12 @@ func (u *union) get() (proto.Cerealizable, error) {
 		return u.CertMgmt, nil
 	case proto.CtrlPld_Which_extn:
 		return u.Extn, nil
+	case proto.CtrlPld_Which_drkeyMgmt:
+		return u.DRKeyMgmt, nil
 	case proto.CtrlPld_Which_ack:
 		return u.Ack, nil
+	case proto.CtrlPld_Which_colibri:
+		return u.Colibri, nil
 	}
 	return nil, common.NewBasicError("Unsupported ctrl union type (get)", nil, "type", u.Which)
 }
@@ -0,0 +1,63 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ErrKeyNotFound indicates that the requested key is not in the database.
+var ErrKeyNotFound = serrors.New("key not found")
+
+// DB is the database for the level 1 keys and the secret values of the local AS.
+type DB interface {
+	Lvl1ReadWrite
+	SVReadWrite
+	db.LimitSetter
+	io.Closer
+}
+
+// Lvl1ReadWrite defines the operations on the level 1 key database.
+type Lvl1ReadWrite interface {
+	// GetLvl1Key returns the level 1 key from srcIA to dstIA that is valid at valTime. If no
+	// such key exists, ErrKeyNotFound is returned.
+	GetLvl1Key(ctx context.Context, srcIA, dstIA addr.IA, valTime time.Time) (Lvl1Key, error)
+	// InsertLvl1Key inserts the key. Inserting an already existing key is a no-op.
+	InsertLvl1Key(ctx context.Context, key Lvl1Key) error
+	// RemoveOutdatedLvl1Keys removes all keys that are expired at cutoff and returns the
+	// number of removed keys.
+	RemoveOutdatedLvl1Keys(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// SVReadWrite defines the operations on the secret values of the local AS. The secret value
+// of an epoch is stored when it is first used, such that it does not change within the epoch
+// if the master key is rotated.
+type SVReadWrite interface {
+	// GetSV returns the secret value that is valid at valTime. If no such secret value exists,
+	// ErrKeyNotFound is returned.
+	GetSV(ctx context.Context, valTime time.Time) (SV, error)
+	// InsertSV inserts the secret value. If a secret value for the epoch already exists, the
+	// stored one is kept.
+	InsertSV(ctx context.Context, sv SV) error
+	// RemoveOutdatedSVs removes all secret values that are expired at cutoff and returns the
+	// number of removed secret values.
+	RemoveOutdatedSVs(ctx context.Context, cutoff time.Time) (int, error)
+}
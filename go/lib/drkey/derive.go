@@ -0,0 +1,121 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// KeyLen is the length of all DRKeys in bytes.
+const KeyLen = 16
+
+var svSalt = []byte("Derive DRKey Key")
+
+// DeriveSV derives the secret value for the epoch in meta from the master key of the AS.
+func DeriveSV(meta SVMeta, masterKey []byte) (SV, error) {
+	if len(masterKey) == 0 {
+		return SV{}, serrors.New("empty master key")
+	}
+	input := make([]byte, len(masterKey)+16)
+	copy(input, masterKey)
+	binary.LittleEndian.PutUint64(input[len(masterKey):], uint64(meta.Epoch.NotBefore.Unix()))
+	binary.LittleEndian.PutUint64(input[len(masterKey)+8:], uint64(meta.Epoch.NotAfter.Unix()))
+	return SV{
+		SVMeta: meta,
+		Key:    pbkdf2.Key(input, svSalt, 1000, KeyLen, sha256.New),
+	}, nil
+}
+
+// DeriveLvl1 derives the level 1 key described by meta from the secret value of the source
+// AS. The epoch of the key is the one of the secret value.
+func DeriveLvl1(meta Lvl1Meta, sv SV) (Lvl1Key, error) {
+	input := make([]byte, 16)
+	meta.DstIA.Write(input)
+	key, err := mac(sv.Key, input)
+	if err != nil {
+		return Lvl1Key{}, err
+	}
+	meta.Epoch = sv.Epoch
+	return Lvl1Key{
+		Lvl1Meta: meta,
+		Key:      key,
+	}, nil
+}
+
+// DeriveLvl2 derives the level 2 key described by meta from the level 1 key. The epoch and
+// the ASes of the level 2 key are taken from the level 1 key.
+func DeriveLvl2(meta Lvl2Meta, key Lvl1Key) (Lvl2Key, error) {
+	if len(meta.Protocol) > 255 {
+		return Lvl2Key{}, serrors.New("protocol name too long", "len", len(meta.Protocol))
+	}
+	input := []byte{byte(meta.KeyType), byte(len(meta.Protocol))}
+	input = append(input, meta.Protocol...)
+	var err error
+	switch meta.KeyType {
+	case AS2AS:
+	case AS2Host:
+		if input, err = appendHost(input, meta.DstHost); err != nil {
+			return Lvl2Key{}, err
+		}
+	case Host2Host:
+		if input, err = appendHost(input, meta.SrcHost); err != nil {
+			return Lvl2Key{}, err
+		}
+		if input, err = appendHost(input, meta.DstHost); err != nil {
+			return Lvl2Key{}, err
+		}
+	default:
+		return Lvl2Key{}, serrors.New("unknown key type", "type", meta.KeyType)
+	}
+	k, err := mac(key.Key, input)
+	if err != nil {
+		return Lvl2Key{}, err
+	}
+	meta.Epoch = key.Epoch
+	meta.SrcIA = key.SrcIA
+	meta.DstIA = key.DstIA
+	return Lvl2Key{
+		Lvl2Meta: meta,
+		Key:      k,
+	}, nil
+}
+
+func appendHost(b []byte, host addr.HostAddr) ([]byte, error) {
+	if host == nil {
+		return nil, serrors.New("host address required for key type")
+	}
+	raw := host.Pack()
+	b = append(b, byte(host.Type()), byte(len(raw)))
+	return append(b, raw...), nil
+}
+
+func mac(key, input []byte) (DRKey, error) {
+	h, err := scrypto.InitMac(key)
+	if err != nil {
+		return nil, err
+	}
+	// Write must not return an error: https://godoc.org/hash#Hash
+	if _, err := h.Write(input); err != nil {
+		panic(err)
+	}
+	return h.Sum(nil), nil
+}
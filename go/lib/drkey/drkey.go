@@ -0,0 +1,183 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkey implements the dynamically recreatable keys (DRKey).
+//
+// Every AS derives a secret value (SV) per epoch from its master key. The level 1 key K_{A->B}
+// is derived by AS A from its SV and the IA of AS B, and fetched by B from A. Level 2 keys are
+// derived from a level 1 key for a protocol and, depending on the key type, the end hosts in
+// the two ASes.
+package drkey
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// DRKey is a derived key.
+type DRKey []byte
+
+// Equal returns whether the two keys are equal. The comparison takes constant time.
+func (k DRKey) Equal(other DRKey) bool {
+	return subtle.ConstantTimeCompare(k, other) == 1
+}
+
+// String does not print the key, to avoid leaking it to the logs.
+func (k DRKey) String() string {
+	return "[redacted key]"
+}
+
+// Epoch is the validity period of a key. The begin is inclusive, the end exclusive.
+type Epoch struct {
+	cppki.Validity
+}
+
+// NewEpoch creates an epoch from the begin and end in seconds since the Unix epoch.
+func NewEpoch(begin, end uint32) Epoch {
+	return Epoch{
+		Validity: cppki.Validity{
+			NotBefore: util.SecsToTime(begin),
+			NotAfter:  util.SecsToTime(end),
+		},
+	}
+}
+
+// EpochAt returns the epoch of the given duration that contains t. Epochs are aligned to the
+// Unix epoch.
+func EpochAt(t time.Time, duration time.Duration) Epoch {
+	d := int64(duration / time.Second)
+	begin := t.Unix() / d * d
+	return NewEpoch(uint32(begin), uint32(begin+d))
+}
+
+// Contains returns whether t is in the epoch.
+func (e Epoch) Contains(t time.Time) bool {
+	return !t.Before(e.NotBefore) && t.Before(e.NotAfter)
+}
+
+// Begin returns the begin of the epoch in seconds since the Unix epoch.
+func (e Epoch) Begin() uint32 {
+	return util.TimeToSecs(e.NotBefore)
+}
+
+// End returns the end of the epoch in seconds since the Unix epoch.
+func (e Epoch) End() uint32 {
+	return util.TimeToSecs(e.NotAfter)
+}
+
+func (e Epoch) String() string {
+	return fmt.Sprintf("[%s, %s)", util.TimeToCompact(e.NotBefore),
+		util.TimeToCompact(e.NotAfter))
+}
+
+// SVMeta describes a secret value.
+type SVMeta struct {
+	Epoch Epoch
+}
+
+// SV is the secret value of an AS for an epoch.
+type SV struct {
+	SVMeta
+	Key DRKey
+}
+
+// Lvl1Meta describes a level 1 key.
+type Lvl1Meta struct {
+	Epoch Epoch
+	SrcIA addr.IA
+	DstIA addr.IA
+}
+
+// Lvl1Key is the level 1 key K_{SrcIA->DstIA}.
+type Lvl1Key struct {
+	Lvl1Meta
+	Key DRKey
+}
+
+// Lvl2KeyType is the type of a level 2 key.
+type Lvl2KeyType uint8
+
+const (
+	// AS2AS is the key between two ASes.
+	AS2AS Lvl2KeyType = iota
+	// AS2Host is the key between an AS and an end host in the destination AS.
+	AS2Host
+	// Host2Host is the key between two end hosts.
+	Host2Host
+)
+
+func (t Lvl2KeyType) String() string {
+	switch t {
+	case AS2AS:
+		return "AS2AS"
+	case AS2Host:
+		return "AS2Host"
+	case Host2Host:
+		return "Host2Host"
+	default:
+		return fmt.Sprintf("UNKNOWN (%d)", t)
+	}
+}
+
+// Lvl2Meta describes a level 2 key.
+type Lvl2Meta struct {
+	KeyType  Lvl2KeyType
+	Protocol string
+	Epoch    Epoch
+	SrcIA    addr.IA
+	DstIA    addr.IA
+	// SrcHost is only used by Host2Host keys.
+	SrcHost addr.HostAddr
+	// DstHost is used by AS2Host and Host2Host keys.
+	DstHost addr.HostAddr
+}
+
+// AuthorizeHost checks that the end host with the given address in AS ia may obtain the key.
+// End hosts only obtain the keys they are an endpoint of, i.e., the destination host of an
+// AS2Host key and the source or destination host of a Host2Host key. AS2AS keys are reserved
+// for the infrastructure of the ASes.
+func (m Lvl2Meta) AuthorizeHost(ia addr.IA, host addr.HostAddr) error {
+	if host == nil {
+		return serrors.New("host address required")
+	}
+	isEndpoint := func(endIA addr.IA, endHost addr.HostAddr) bool {
+		return endIA.Equal(ia) && endHost != nil && endHost.Equal(host)
+	}
+	switch m.KeyType {
+	case AS2Host:
+		if isEndpoint(m.DstIA, m.DstHost) {
+			return nil
+		}
+	case Host2Host:
+		if isEndpoint(m.SrcIA, m.SrcHost) || isEndpoint(m.DstIA, m.DstHost) {
+			return nil
+		}
+	default:
+		return serrors.New("key type not available to end hosts", "type", m.KeyType)
+	}
+	return serrors.New("host is not an endpoint of the key", "type", m.KeyType,
+		"isd_as", ia, "host", host)
+}
+
+// Lvl2Key is a level 2 key.
+type Lvl2Key struct {
+	Lvl2Meta
+	Key DRKey
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestEpochAt(t *testing.T) {
+	now := time.Unix(1000, 0)
+	epoch := drkey.EpochAt(now, 300*time.Second)
+	assert.Equal(t, uint32(900), epoch.Begin())
+	assert.Equal(t, uint32(1200), epoch.End())
+	assert.True(t, epoch.Contains(now))
+	assert.True(t, epoch.Contains(time.Unix(900, 0)))
+	assert.False(t, epoch.Contains(time.Unix(1200, 0)))
+}
+
+func TestDeriveSV(t *testing.T) {
+	master := xtest.MustParseHexString("305554050357005ae398259bcdae7468")
+	meta := drkey.SVMeta{Epoch: drkey.NewEpoch(0, 1)}
+	sv, err := drkey.DeriveSV(meta, master)
+	require.NoError(t, err)
+	assert.Len(t, sv.Key, drkey.KeyLen)
+	assert.Equal(t, meta, sv.SVMeta)
+
+	other, err := drkey.DeriveSV(meta, master)
+	require.NoError(t, err)
+	assert.True(t, sv.Key.Equal(other.Key))
+
+	other, err = drkey.DeriveSV(drkey.SVMeta{Epoch: drkey.NewEpoch(1, 2)}, master)
+	require.NoError(t, err)
+	assert.False(t, sv.Key.Equal(other.Key))
+
+	_, err = drkey.DeriveSV(meta, nil)
+	assert.Error(t, err)
+}
+
+func TestDeriveLvl1(t *testing.T) {
+	master := xtest.MustParseHexString("305554050357005ae398259bcdae7468")
+	sv, err := drkey.DeriveSV(drkey.SVMeta{Epoch: drkey.NewEpoch(0, 1)}, master)
+	require.NoError(t, err)
+	meta := drkey.Lvl1Meta{
+		SrcIA: xtest.MustParseIA("1-ff00:0:111"),
+		DstIA: xtest.MustParseIA("1-ff00:0:112"),
+	}
+	key, err := drkey.DeriveLvl1(meta, sv)
+	require.NoError(t, err)
+	assert.Len(t, key.Key, drkey.KeyLen)
+	assert.Equal(t, sv.Epoch, key.Epoch)
+	assert.Equal(t, meta.SrcIA, key.SrcIA)
+	assert.Equal(t, meta.DstIA, key.DstIA)
+
+	meta.DstIA = xtest.MustParseIA("1-ff00:0:113")
+	other, err := drkey.DeriveLvl1(meta, sv)
+	require.NoError(t, err)
+	assert.False(t, key.Key.Equal(other.Key))
+}
+
+func TestDeriveLvl2(t *testing.T) {
+	lvl1 := drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{
+			Epoch: drkey.NewEpoch(0, 1),
+			SrcIA: xtest.MustParseIA("1-ff00:0:111"),
+			DstIA: xtest.MustParseIA("1-ff00:0:112"),
+		},
+		Key: xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+	}
+	srcHost := addr.HostFromIPStr("127.0.0.1")
+	dstHost := addr.HostFromIPStr("127.0.0.2")
+	testCases := map[string]struct {
+		Meta         drkey.Lvl2Meta
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"as2as": {
+			Meta:         drkey.Lvl2Meta{KeyType: drkey.AS2AS, Protocol: "scmp"},
+			ErrAssertion: assert.NoError,
+		},
+		"as2host": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.AS2Host, Protocol: "scmp",
+				DstHost: dstHost},
+			ErrAssertion: assert.NoError,
+		},
+		"host2host": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.Host2Host, Protocol: "scmp",
+				SrcHost: srcHost, DstHost: dstHost},
+			ErrAssertion: assert.NoError,
+		},
+		"as2host without host": {
+			Meta:         drkey.Lvl2Meta{KeyType: drkey.AS2Host, Protocol: "scmp"},
+			ErrAssertion: assert.Error,
+		},
+		"unknown type": {
+			Meta:         drkey.Lvl2Meta{KeyType: 7, Protocol: "scmp"},
+			ErrAssertion: assert.Error,
+		},
+	}
+	keys := make(map[string]drkey.DRKey)
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			key, err := drkey.DeriveLvl2(tc.Meta, lvl1)
+			tc.ErrAssertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Len(t, key.Key, drkey.KeyLen)
+			assert.Equal(t, lvl1.Epoch, key.Epoch)
+			assert.Equal(t, lvl1.SrcIA, key.SrcIA)
+			assert.Equal(t, lvl1.DstIA, key.DstIA)
+			for other, k := range keys {
+				assert.False(t, key.Key.Equal(k), other)
+			}
+			keys[name] = key.Key
+		})
+	}
+}
+
+func TestLvl2MetaAuthorizeHost(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:110")
+	remote := xtest.MustParseIA("1-ff00:0:111")
+	host := addr.HostFromIPStr("10.0.0.1")
+	other := addr.HostFromIPStr("10.0.0.2")
+
+	testCases := map[string]struct {
+		Meta      drkey.Lvl2Meta
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"AS2AS": {
+			Meta:      drkey.Lvl2Meta{KeyType: drkey.AS2AS, SrcIA: remote, DstIA: local},
+			Assertion: assert.Error,
+		},
+		"AS2Host destination": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.AS2Host, SrcIA: remote, DstIA: local,
+				DstHost: host},
+			Assertion: assert.NoError,
+		},
+		"AS2Host other host": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.AS2Host, SrcIA: remote, DstIA: local,
+				DstHost: other},
+			Assertion: assert.Error,
+		},
+		"AS2Host destination in remote AS": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.AS2Host, SrcIA: local, DstIA: remote,
+				DstHost: host},
+			Assertion: assert.Error,
+		},
+		"Host2Host source": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.Host2Host, SrcIA: local, DstIA: remote,
+				SrcHost: host, DstHost: other},
+			Assertion: assert.NoError,
+		},
+		"Host2Host destination": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.Host2Host, SrcIA: remote, DstIA: local,
+				SrcHost: other, DstHost: host},
+			Assertion: assert.NoError,
+		},
+		"Host2Host other hosts": {
+			Meta: drkey.Lvl2Meta{KeyType: drkey.Host2Host, SrcIA: local, DstIA: local,
+				SrcHost: other, DstHost: other},
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.Assertion(t, tc.Meta.AuthorizeHost(local, host))
+		})
+	}
+}
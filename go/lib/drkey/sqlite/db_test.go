@@ -0,0 +1,111 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/sqlite"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestLvl1Keys(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	db, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	srcIA := xtest.MustParseIA("1-ff00:0:111")
+	dstIA := xtest.MustParseIA("1-ff00:0:112")
+	newKey := func(begin, end uint32) drkey.Lvl1Key {
+		return drkey.Lvl1Key{
+			Lvl1Meta: drkey.Lvl1Meta{
+				Epoch: drkey.NewEpoch(begin, end),
+				SrcIA: srcIA,
+				DstIA: dstIA,
+			},
+			Key: xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+		}
+	}
+	first, second := newKey(100, 200), newKey(200, 300)
+	require.NoError(t, db.InsertLvl1Key(ctx, first))
+	require.NoError(t, db.InsertLvl1Key(ctx, second))
+	// inserting twice is a no-op
+	require.NoError(t, db.InsertLvl1Key(ctx, second))
+
+	key, err := db.GetLvl1Key(ctx, srcIA, dstIA, time.Unix(150, 0))
+	require.NoError(t, err)
+	assert.Equal(t, first, key)
+	key, err = db.GetLvl1Key(ctx, srcIA, dstIA, time.Unix(200, 0))
+	require.NoError(t, err)
+	assert.Equal(t, second, key)
+	_, err = db.GetLvl1Key(ctx, dstIA, srcIA, time.Unix(150, 0))
+	assert.Equal(t, drkey.ErrKeyNotFound, err)
+
+	n, err := db.RemoveOutdatedLvl1Keys(ctx, time.Unix(250, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	_, err = db.GetLvl1Key(ctx, srcIA, dstIA, time.Unix(150, 0))
+	assert.Equal(t, drkey.ErrKeyNotFound, err)
+	_, err = db.GetLvl1Key(ctx, srcIA, dstIA, time.Unix(250, 0))
+	assert.NoError(t, err)
+
+	assert.Error(t, db.InsertLvl1Key(ctx, drkey.Lvl1Key{}))
+}
+
+func TestSVs(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	db, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	newSV := func(begin, end uint32, key string) drkey.SV {
+		return drkey.SV{
+			SVMeta: drkey.SVMeta{Epoch: drkey.NewEpoch(begin, end)},
+			Key:    xtest.MustParseHexString(key),
+		}
+	}
+	first := newSV(100, 200, "c584cad32613547c64823c756651b6f5")
+	second := newSV(200, 300, "8a3b5b2b0d2e38a8e09a7ec0cc1c2d5f")
+	require.NoError(t, db.InsertSV(ctx, first))
+	require.NoError(t, db.InsertSV(ctx, second))
+	// the stored secret value of an epoch is kept
+	require.NoError(t, db.InsertSV(ctx, newSV(100, 200, "00000000000000000000000000000000")))
+
+	sv, err := db.GetSV(ctx, time.Unix(150, 0))
+	require.NoError(t, err)
+	assert.Equal(t, first, sv)
+	sv, err = db.GetSV(ctx, time.Unix(200, 0))
+	require.NoError(t, err)
+	assert.Equal(t, second, sv)
+	_, err = db.GetSV(ctx, time.Unix(300, 0))
+	assert.Equal(t, drkey.ErrKeyNotFound, err)
+
+	n, err := db.RemoveOutdatedSVs(ctx, time.Unix(250, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	_, err = db.GetSV(ctx, time.Unix(150, 0))
+	assert.Equal(t, drkey.ErrKeyNotFound, err)
+
+	assert.Error(t, db.InsertSV(ctx, drkey.SV{}))
+}
@@ -297,6 +297,7 @@ type QUIC struct {
 	Address            string  `toml:"address,omitempty"`
 	CertFile           string  `toml:"cert_file,omitempty"`
 	KeyFile            string  `toml:"key_file,omitempty"`
+	CPPKI              bool    `toml:"cppki,omitempty"`
 }
 
 func (cfg *QUIC) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
@@ -70,6 +70,11 @@ cert_file = "/etc/scion/quic/tls.pem"
 # Key file to use for authenticating QUIC connections.
 key_file = "/etc/scion/quic/tls.key"
 
+# Authenticate QUIC connections with the certificate chain of the AS and verify
+# the peers with the control-plane PKI. If set, cert_file and key_file are
+# ignored. (default false)
+cppki = false
+
 # Enables SVC resolution for traffic to SVC
 # destinations in a way that is also compatible with control plane servers
 # that do not implement the SVC Resolution Mechanism. The value represents
@@ -17,12 +17,14 @@ package hiddenpath
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"strconv"
 	"strings"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
 )
 
 // Parsing errors
@@ -205,6 +207,27 @@ func GroupFromMsg(m *path_mgmt.HPCfg) *Group {
 	}
 }
 
+// LoadGroups loads the hidden path groups from the given files. Each file contains a single
+// group in JSON format. Configuring the same GroupId in multiple files is an error.
+func LoadGroups(files []string) (map[GroupId]*Group, error) {
+	groups := make(map[GroupId]*Group, len(files))
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, serrors.WrapStr("reading group file", err, "file", file)
+		}
+		g := &Group{}
+		if err := json.Unmarshal(raw, g); err != nil {
+			return nil, serrors.WrapStr("parsing group file", err, "file", file)
+		}
+		if _, ok := groups[g.Id]; ok {
+			return nil, serrors.New("duplicate group", "group", g.Id, "file", file)
+		}
+		groups[g.Id] = g
+	}
+	return groups, nil
+}
+
 func toIAInt(in []addr.IA) []addr.IAInt {
 	out := make([]addr.IAInt, 0, len(in))
 	for _, i := range in {
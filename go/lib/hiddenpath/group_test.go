@@ -16,6 +16,9 @@ package hiddenpath
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -310,3 +313,36 @@ func TestHas(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadGroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hiddenpath-groups")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+		return file
+	}
+	valid := write("valid.json", testCfg)
+	other := write("other.json", strings.Replace(testCfg, "69b5", "69b6", 1))
+	invalid := write("invalid.json", `{"GroupID": "ff00:0:110-69b5"}`)
+
+	t.Run("valid", func(t *testing.T) {
+		groups, err := LoadGroups([]string{valid, other})
+		require.NoError(t, err)
+		assert.Len(t, groups, 2)
+		assert.Equal(t, testGroup, *groups[testGroup.Id])
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := LoadGroups([]string{valid, valid})
+		assert.Error(t, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := LoadGroups([]string{valid, invalid})
+		assert.Error(t, err)
+	})
+	t.Run("missing", func(t *testing.T) {
+		_, err := LoadGroups([]string{filepath.Join(dir, "missing.json")})
+		assert.Error(t, err)
+	})
+}
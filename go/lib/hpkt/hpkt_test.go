@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/google/gopacket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -28,6 +29,9 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/l4"
 	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/spkt"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -302,6 +306,177 @@ func TestScnPktWrite2(t *testing.T) {
 	}
 }
 
+func TestScnPktWriteColibri(t *testing.T) {
+	path := &colibri.Path{
+		InfoField: colibri.InfoField{
+			HFCount: 2,
+			BWCls:   5,
+			ExpTick: 0x10,
+			ResID:   [colibri.ResIDLen]byte{0xff, 0, 0, 0, 0x2, 0x22},
+		},
+		HopFields: []*colibri.HopField{
+			{ConsIngress: 0, ConsEgress: 1},
+			{ConsIngress: 2, ConsEgress: 0},
+		},
+	}
+	mac, err := scrypto.InitMac(make([]byte, 16))
+	require.NoError(t, err)
+	auths := [][]byte{
+		colibri.HopAuthenticator(mac, &path.InfoField, path.HopFields[0]),
+		colibri.HopAuthenticator(mac, &path.InfoField, path.HopFields[1]),
+	}
+	raw := make([]byte, path.Len())
+	require.NoError(t, path.SerializeTo(raw))
+
+	pkt := &spkt.ScnPkt{
+		SrcIA:   xtest.MustParseIA("2-ff00:0:222"),
+		DstIA:   xtest.MustParseIA("1-ff00:0:111"),
+		SrcHost: addr.HostFromIP(net.IP{10, 0, 0, 100}),
+		DstHost: addr.HostFromIP(net.IP{10, 0, 0, 101}),
+		Path:    spath.NewColibri(raw, auths),
+		L4:      &l4.UDP{SrcPort: 1280, DstPort: 80},
+		Pld:     common.RawBytes(generatePayload()),
+	}
+	b := make(common.RawBytes, common.MaxMTU)
+	n, err := WriteScnPkt2(pkt, b)
+	require.NoError(t, err)
+
+	parsed := &spkt.ScnPkt{}
+	require.NoError(t, ParseScnPkt2(parsed, b[:n]))
+	require.True(t, parsed.Path.IsColibri())
+	var got colibri.Path
+	require.NoError(t, got.DecodeFromBytes(parsed.Path.Raw))
+	assert.Equal(t, uint16(l4.UDPLen+len(generatePayload())), got.InfoField.OrigPayLen)
+	assert.NotZero(t, got.InfoField.PacketTimestamp)
+	for i, hf := range got.HopFields {
+		assert.NoError(t, colibri.VerifyMAC(mac, &got.InfoField, hf), "hop %d", i)
+	}
+}
+
+func TestScnPktWriteTraceroute(t *testing.T) {
+	for name, typ := range map[string]scmp.Type{
+		"request": scmp.T_G_TraceRouteRequest,
+		"reply":   scmp.T_G_TraceRouteReply,
+	} {
+		t.Run(name, func(t *testing.T) {
+			info := &scmp.InfoTraceRoute{
+				Id:   0xdeadcafe,
+				IA:   xtest.MustParseIA("1-ff00:0:110"),
+				IfID: 42,
+			}
+			ct := scmp.ClassType{Class: scmp.C_General, Type: typ}
+			pld := scmp.PldFromQuotes(ct, info, common.L4SCMP, nil)
+			pkt := &spkt.ScnPkt{
+				SrcIA:   xtest.MustParseIA("1-ff00:0:1"),
+				DstIA:   xtest.MustParseIA("1-ff00:0:2"),
+				SrcHost: addr.HostFromIP(net.IP{127, 0, 0, 1}),
+				DstHost: addr.HostFromIP(net.IP{127, 0, 0, 2}),
+				Path:    spath.NewV2(generatePath(), false),
+				L4:      scmp.NewHdr(ct, pld.Len()),
+				Pld:     pld,
+			}
+			b := make(common.RawBytes, common.MaxMTU)
+			n, err := WriteScnPkt2(pkt, b)
+			require.NoError(t, err)
+
+			// The message must be in the new SCMP format.
+			packet := gopacket.NewPacket(b[:n], slayers.LayerTypeSCION, gopacket.Default)
+			require.Nil(t, packet.ErrorLayer())
+			layer := packet.Layer(slayers.LayerTypeSCMPTraceroute)
+			require.NotNil(t, layer)
+			traceroute := layer.(*slayers.SCMPTraceroute)
+			assert.Equal(t, uint16(0xcafe), traceroute.Identifier)
+
+			parsed := &spkt.ScnPkt{}
+			require.NoError(t, ParseScnPkt2(parsed, b[:n]))
+			hdr, ok := parsed.L4.(*scmp.Hdr)
+			require.True(t, ok)
+			assert.Equal(t, ct, scmp.ClassType{Class: hdr.Class, Type: hdr.Type})
+			parsedPld, ok := parsed.Pld.(*scmp.Payload)
+			require.True(t, ok)
+			assert.Equal(t, &scmp.InfoTraceRoute{Id: 0xcafe, IA: info.IA, IfID: info.IfID},
+				parsedPld.Info)
+		})
+	}
+}
+
+func TestParseSCMPPacketTooBig(t *testing.T) {
+	quote := xtest.MustReadFromFile(t, "udp-scion-v2.bin")
+	var scn slayers.SCION
+	require.NoError(t, scn.DecodeFromBytes(quote, gopacket.NilDecodeFeedback))
+	scn.NextHdr = common.L4SCMP
+	scmpLayer := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypePacketTooBig, 0),
+	}
+	require.NoError(t, scmpLayer.SetNetworkLayerForChecksum(&scn))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	// The router only quotes the beginning of the offending packet.
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, &scn, scmpLayer,
+		&slayers.SCMPPacketTooBig{MTU: 1280}, gopacket.Payload(quote[:200])))
+
+	parsed := &spkt.ScnPkt{}
+	require.NoError(t, ParseScnPkt2(parsed, buf.Bytes()))
+	hdr, ok := parsed.L4.(*scmp.Hdr)
+	require.True(t, ok)
+	assert.Equal(t, scmp.ClassType{Class: scmp.C_Routing, Type: scmp.T_R_OversizePkt},
+		scmp.ClassType{Class: hdr.Class, Type: hdr.Type})
+	pld, ok := parsed.Pld.(*scmp.Payload)
+	require.True(t, ok)
+	assert.Equal(t, &scmp.InfoPktSize{Size: uint16(len(quote)), MTU: 1280}, pld.Info)
+	assert.Equal(t, common.L4UDP, pld.Meta.L4Proto)
+	assert.Equal(t, common.RawBytes(generatePath()), pld.PathHdr)
+	udp, err := l4.UDPFromRaw(pld.L4Hdr)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1280), udp.SrcPort)
+}
+
+func TestParseSCMPInterfaceDown(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:112")
+	testCases := map[string]struct {
+		Type     uint8
+		Layer    gopacket.SerializableLayer
+		Expected *scmp.InfoInterfaceDown
+	}{
+		"external interface down": {
+			Type:     slayers.SCMPTypeExternalInterfaceDown,
+			Layer:    &slayers.SCMPExternalInterfaceDown{IA: ia, IfID: 42},
+			Expected: &scmp.InfoInterfaceDown{IA: ia, Egress: 42},
+		},
+		"internal connectivity down": {
+			Type:     slayers.SCMPTypeInternalConnectivityDown,
+			Layer:    &slayers.SCMPInternalConnectivityDown{IA: ia, Ingress: 41, Egress: 42},
+			Expected: &scmp.InfoInterfaceDown{IA: ia, Ingress: 41, Egress: 42},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			quote := xtest.MustReadFromFile(t, "udp-scion-v2.bin")
+			var scn slayers.SCION
+			require.NoError(t, scn.DecodeFromBytes(quote, gopacket.NilDecodeFeedback))
+			scn.NextHdr = common.L4SCMP
+			scmpLayer := &slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(tc.Type, 0)}
+			require.NoError(t, scmpLayer.SetNetworkLayerForChecksum(&scn))
+			buf := gopacket.NewSerializeBuffer()
+			opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+			require.NoError(t, gopacket.SerializeLayers(buf, opts, &scn, scmpLayer,
+				tc.Layer, gopacket.Payload(quote[:200])))
+
+			parsed := &spkt.ScnPkt{}
+			require.NoError(t, ParseScnPkt2(parsed, buf.Bytes()))
+			hdr, ok := parsed.L4.(*scmp.Hdr)
+			require.True(t, ok)
+			assert.Equal(t, scmp.ClassType{Class: scmp.C_Path, Type: scmp.T_P_BadIF},
+				scmp.ClassType{Class: hdr.Class, Type: hdr.Type})
+			pld, ok := parsed.Pld.(*scmp.Payload)
+			require.True(t, ok)
+			assert.Equal(t, tc.Expected, pld.Info)
+			assert.Equal(t, common.L4UDP, pld.Meta.L4Proto)
+			assert.Equal(t, common.RawBytes(generatePath()), pld.PathHdr)
+		})
+	}
+}
+
 func generatePayload() []byte {
 	b := make([]byte, 4*256)
 	for i := 0; i < 4*256; i++ {
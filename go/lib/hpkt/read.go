@@ -15,6 +15,8 @@
 package hpkt
 
 import (
+	"errors"
+
 	"github.com/google/gopacket"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -285,17 +287,23 @@ func ParseScnPkt2(s *spkt.ScnPkt, b []byte) error {
 	var (
 		scionLayer slayers.SCION
 		udpLayer   slayers.UDP
-		scmpLayer  slayers.SCMPDummy
 		// XXX(scrye): HBH and E2E are not needed yet, so we silently ignore them.
 		payloadLayer gopacket.Payload
 	)
 
+	// SCMP messages are decoded separately, because the legacy and the new
+	// format share the same layer type, see decodeSCMP.
 	parser := gopacket.NewDecodingLayerParser(
-		slayers.LayerTypeSCION, &scionLayer, &udpLayer, &scmpLayer, &payloadLayer,
+		slayers.LayerTypeSCION, &scionLayer, &udpLayer, &payloadLayer,
 	)
 
 	decoded := []gopacket.LayerType{}
-	if err := parser.DecodeLayers(b, &decoded); err != nil {
+	err := parser.DecodeLayers(b, &decoded)
+	var unsupported gopacket.UnsupportedLayerType
+	switch {
+	case errors.As(err, &unsupported) && gopacket.LayerType(unsupported) == slayers.LayerTypeSCMP:
+		decoded = append(decoded, slayers.LayerTypeSCMP)
+	case err != nil:
 		return serrors.WrapStr("decoding layers", err)
 	}
 
@@ -328,7 +336,11 @@ func ParseScnPkt2(s *spkt.ScnPkt, b []byte) error {
 				if err := scionLayer.Path.SerializeTo(pathCopy); err != nil {
 					return serrors.WrapStr("extracting path", err)
 				}
-				s.Path = spath.NewV2(pathCopy, scionLayer.PathType == slayers.PathTypeOneHop)
+				if scionLayer.PathType == slayers.PathTypeCOLIBRI {
+					s.Path = spath.NewColibri(pathCopy, nil)
+				} else {
+					s.Path = spath.NewV2(pathCopy, scionLayer.PathType == slayers.PathTypeOneHop)
+				}
 			} else {
 				s.Path = nil
 			}
@@ -341,20 +353,11 @@ func ParseScnPkt2(s *spkt.ScnPkt, b []byte) error {
 			}
 			s.Pld = common.RawBytes(payloadLayer.Payload())
 		case slayers.LayerTypeSCMP:
-			s.L4 = &scmp.Hdr{
-				Class:     scmpLayer.Class,
-				Type:      scmpLayer.Type,
-				TotalLen:  scmpLayer.TotalLen,
-				Checksum:  scmpLayer.Checksum,
-				Timestamp: scmpLayer.Timestamp,
-			}
-			pld, err := scmp.PldFromRaw(scmpLayer.Payload, scmp.ClassType{
-				Class: scmpLayer.Class,
-				Type:  scmpLayer.Type,
-			})
+			hdr, pld, err := decodeSCMP(scionLayer.Payload)
 			if err != nil {
 				return err
 			}
+			s.L4 = hdr
 			s.Pld = pld
 		}
 	}
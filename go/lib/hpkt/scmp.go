@@ -0,0 +1,326 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpkt
+
+import (
+	"encoding/binary"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+)
+
+// While transitioning to HeaderV2, end hosts use the legacy SCMP header inside
+// of v2 packets (see slayers.SCMPDummy). The routers only support traceroute in
+// the new SCMP format, therefore traceroute messages are translated from and to
+// the legacy representation here. Errors that the routers send in the new
+// format, i.e., destination unreachable and packet too big, are translated to
+// the corresponding legacy routing errors. External interface down and
+// internal connectivity down errors have no legacy counterpart; they are
+// translated to legacy bad interface errors that carry the reported interfaces
+// in an scmp.InfoInterfaceDown. This keeps the dispatcher and snet, which
+// match replies on the legacy info ID, unchanged. Note that the identifier of
+// the new format only has 16 bits, the legacy ID is truncated accordingly.
+
+// scmpTracerouteLen is the length of an SCMP traceroute message in the new
+// format, including the SCMP header.
+const scmpTracerouteLen = 4 + 2 + 2 + addr.IABytes + 8
+
+func isTraceroute(class scmp.Class, t scmp.Type) bool {
+	return class == scmp.C_General &&
+		(t == scmp.T_G_TraceRouteRequest || t == scmp.T_G_TraceRouteReply)
+}
+
+// scmpTracerouteLayers returns the layers of the SCMP traceroute message in
+// the new format that corresponds to the legacy header and payload.
+func scmpTracerouteLayers(hdr *scmp.Hdr, pld common.Payload,
+	scn *slayers.SCION) ([]gopacket.SerializableLayer, error) {
+
+	raw := make([]byte, pld.Len())
+	if _, err := pld.WritePld(raw); err != nil {
+		return nil, serrors.WrapStr("writing SCMP payload", err)
+	}
+	scmpPld, err := scmp.PldFromRaw(raw, scmp.ClassType{Class: hdr.Class, Type: hdr.Type})
+	if err != nil {
+		return nil, serrors.WrapStr("parsing SCMP payload", err)
+	}
+	info, ok := scmpPld.Info.(*scmp.InfoTraceRoute)
+	if !ok {
+		return nil, serrors.New("invalid traceroute info", "type", common.TypeOf(scmpPld.Info))
+	}
+	typ := uint8(slayers.SCMPTypeTracerouteRequest)
+	if hdr.Type == scmp.T_G_TraceRouteReply {
+		typ = slayers.SCMPTypeTracerouteReply
+	}
+	scmpLayer := &slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(typ, 0)}
+	if err := scmpLayer.SetNetworkLayerForChecksum(scn); err != nil {
+		return nil, err
+	}
+	return []gopacket.SerializableLayer{
+		scmpLayer,
+		&slayers.SCMPTraceroute{
+			Identifier: uint16(info.Id),
+			IA:         info.IA,
+			Interface:  uint64(info.IfID),
+		},
+	}, nil
+}
+
+// decodeSCMP decodes the SCMP message of a v2 packet into the legacy
+// representation. Messages in the new format are distinguished from legacy
+// ones by their first byte: It is the non-zero type in the new format, and
+// the upper byte of the class, which is always zero, in the legacy format.
+func decodeSCMP(data []byte) (*scmp.Hdr, *scmp.Payload, error) {
+	if len(data) > 0 && data[0] != 0 {
+		return decodeSCMPV2(data)
+	}
+	var scmpLayer slayers.SCMPDummy
+	if err := scmpLayer.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return nil, nil, err
+	}
+	hdr := &scmp.Hdr{
+		Class:     scmpLayer.Class,
+		Type:      scmpLayer.Type,
+		TotalLen:  scmpLayer.TotalLen,
+		Checksum:  scmpLayer.Checksum,
+		Timestamp: scmpLayer.Timestamp,
+	}
+	pld, err := scmp.PldFromRaw(scmpLayer.Payload, scmp.ClassType{
+		Class: scmpLayer.Class,
+		Type:  scmpLayer.Type,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return hdr, pld, nil
+}
+
+// decodeSCMPV2 decodes an SCMP message in the new format into the legacy
+// representation. Traceroute, destination unreachable, packet too big,
+// external interface down and internal connectivity down messages are
+// supported.
+func decodeSCMPV2(data []byte) (*scmp.Hdr, *scmp.Payload, error) {
+	var scmpLayer slayers.SCMP
+	if err := scmpLayer.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return nil, nil, err
+	}
+	var ct scmp.ClassType
+	var pld *scmp.Payload
+	var err error
+	switch scmpLayer.TypeCode.Type() {
+	case slayers.SCMPTypeTracerouteRequest, slayers.SCMPTypeTracerouteReply:
+		ct, pld, err = decodeSCMPTraceroute(&scmpLayer)
+	case slayers.SCMPTypeDestinationUnreachable:
+		ct, pld, err = decodeSCMPDestinationUnreachable(&scmpLayer)
+	case slayers.SCMPTypePacketTooBig:
+		ct, pld, err = decodeSCMPPacketTooBig(&scmpLayer)
+	case slayers.SCMPTypeExternalInterfaceDown:
+		ct, pld, err = decodeSCMPExternalInterfaceDown(&scmpLayer)
+	case slayers.SCMPTypeInternalConnectivityDown:
+		ct, pld, err = decodeSCMPInternalConnectivityDown(&scmpLayer)
+	default:
+		return nil, nil, serrors.New("unsupported SCMP message", "type_code", scmpLayer.TypeCode)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	checksum := make(common.RawBytes, 2)
+	binary.BigEndian.PutUint16(checksum, scmpLayer.Checksum)
+	hdr := &scmp.Hdr{
+		Class:    ct.Class,
+		Type:     ct.Type,
+		TotalLen: uint16(len(data)),
+		Checksum: checksum,
+	}
+	return hdr, pld, nil
+}
+
+// decodeSCMPTraceroute decodes an SCMP traceroute message in the new format
+// into the legacy representation.
+func decodeSCMPTraceroute(scmpLayer *slayers.SCMP) (scmp.ClassType, *scmp.Payload, error) {
+	ct := scmp.ClassType{Class: scmp.C_General, Type: scmp.T_G_TraceRouteRequest}
+	if scmpLayer.TypeCode.Type() == slayers.SCMPTypeTracerouteReply {
+		ct.Type = scmp.T_G_TraceRouteReply
+	}
+	var traceroute slayers.SCMPTraceroute
+	err := traceroute.DecodeFromBytes(scmpLayer.Payload, gopacket.NilDecodeFeedback)
+	if err != nil {
+		return ct, nil, err
+	}
+	info := &scmp.InfoTraceRoute{
+		Id:   uint64(traceroute.Identifier),
+		IA:   traceroute.IA,
+		IfID: common.IFIDType(traceroute.Interface),
+	}
+	pld := &scmp.Payload{
+		Meta:    &scmp.Meta{InfoLen: uint8(info.Len() / common.LineLen)},
+		Info:    info,
+		CmnHdr:  common.RawBytes{},
+		AddrHdr: common.RawBytes{},
+		PathHdr: common.RawBytes{},
+		ExtHdrs: common.RawBytes{},
+		L4Hdr:   common.RawBytes{},
+	}
+	return ct, pld, nil
+}
+
+// destinationUnreachableTypes maps the destination unreachable codes to the
+// legacy routing types.
+var destinationUnreachableTypes = map[uint8]scmp.Type{
+	slayers.SCMPCodeNoRoute:                   scmp.T_R_UnreachNet,
+	slayers.SCMPCodeAdminDeny:                 scmp.T_R_AdminDenied,
+	slayers.SCMPCodeBeyondScopeOfSourceAddr:   scmp.T_R_UnreachNet,
+	slayers.SCMPCodeAddressUnreachable:        scmp.T_R_UnreachHost,
+	slayers.SCMPCodePortUnreachable:           scmp.T_R_UnreachPort,
+	slayers.SCMPCodeSourceAddressFailedPolicy: scmp.T_R_AdminDenied,
+	slayers.SCMPCodeRejectRouteToDest:         scmp.T_R_AdminDenied,
+}
+
+// decodeSCMPDestinationUnreachable decodes an SCMP destination unreachable
+// message in the new format into the legacy representation.
+func decodeSCMPDestinationUnreachable(
+	scmpLayer *slayers.SCMP) (scmp.ClassType, *scmp.Payload, error) {
+
+	ct := scmp.ClassType{Class: scmp.C_Routing, Type: scmp.T_R_UnreachNet}
+	if t, ok := destinationUnreachableTypes[scmpLayer.TypeCode.Code()]; ok {
+		ct.Type = t
+	}
+	var msg slayers.SCMPDestinationUnreachable
+	if err := msg.DecodeFromBytes(scmpLayer.Payload, gopacket.NilDecodeFeedback); err != nil {
+		return ct, nil, err
+	}
+	pld, _, err := quotedPayload(msg.Payload, nil)
+	return ct, pld, err
+}
+
+// decodeSCMPPacketTooBig decodes an SCMP packet too big message in the new
+// format into the legacy representation.
+func decodeSCMPPacketTooBig(scmpLayer *slayers.SCMP) (scmp.ClassType, *scmp.Payload, error) {
+	ct := scmp.ClassType{Class: scmp.C_Routing, Type: scmp.T_R_OversizePkt}
+	var msg slayers.SCMPPacketTooBig
+	if err := msg.DecodeFromBytes(scmpLayer.Payload, gopacket.NilDecodeFeedback); err != nil {
+		return ct, nil, err
+	}
+	info := &scmp.InfoPktSize{MTU: msg.MTU}
+	pld, size, err := quotedPayload(msg.Payload, info)
+	if err != nil {
+		return ct, nil, err
+	}
+	info.Size = size
+	return ct, pld, nil
+}
+
+// decodeSCMPExternalInterfaceDown decodes an SCMP external interface down
+// message in the new format into the legacy representation.
+func decodeSCMPExternalInterfaceDown(
+	scmpLayer *slayers.SCMP) (scmp.ClassType, *scmp.Payload, error) {
+
+	ct := scmp.ClassType{Class: scmp.C_Path, Type: scmp.T_P_BadIF}
+	var msg slayers.SCMPExternalInterfaceDown
+	if err := msg.DecodeFromBytes(scmpLayer.Payload, gopacket.NilDecodeFeedback); err != nil {
+		return ct, nil, err
+	}
+	info := &scmp.InfoInterfaceDown{IA: msg.IA, Egress: common.IFIDType(msg.IfID)}
+	pld, _, err := quotedPayload(msg.Payload, info)
+	return ct, pld, err
+}
+
+// decodeSCMPInternalConnectivityDown decodes an SCMP internal connectivity
+// down message in the new format into the legacy representation.
+func decodeSCMPInternalConnectivityDown(
+	scmpLayer *slayers.SCMP) (scmp.ClassType, *scmp.Payload, error) {
+
+	ct := scmp.ClassType{Class: scmp.C_Path, Type: scmp.T_P_BadIF}
+	var msg slayers.SCMPInternalConnectivityDown
+	if err := msg.DecodeFromBytes(scmpLayer.Payload, gopacket.NilDecodeFeedback); err != nil {
+		return ct, nil, err
+	}
+	info := &scmp.InfoInterfaceDown{
+		IA:      msg.IA,
+		Ingress: common.IFIDType(msg.Ingress),
+		Egress:  common.IFIDType(msg.Egress),
+	}
+	pld, _, err := quotedPayload(msg.Payload, info)
+	return ct, pld, err
+}
+
+// quotedPayload creates the legacy SCMP payload for an error message, which
+// quotes the offending packet. The quoted path and L4 header are extracted,
+// such that the dispatcher can route the error to the sender of the offending
+// packet. The size of the offending packet is returned, as indicated by its
+// common header.
+func quotedPayload(quote []byte, info scmp.Info) (*scmp.Payload, uint16, error) {
+	var scn slayers.SCION
+	if err := scn.DecodeFromBytes(quote, gopacket.NilDecodeFeedback); err != nil {
+		return nil, 0, serrors.WrapStr("decoding quoted SCION header", err)
+	}
+	hdrLen := int(scn.HdrLen) * slayers.LineLen
+	pathHdr := quote[slayers.CmnHdrLen+scn.AddrHdrLen() : hdrLen]
+	l4Hdr, err := quotedL4Hdr(scn.NextHdr, quote[hdrLen:])
+	if err != nil {
+		return nil, 0, err
+	}
+	pld := &scmp.Payload{
+		Meta: &scmp.Meta{
+			PathHdrLen: uint8(len(pathHdr) / common.LineLen),
+			L4HdrLen:   uint8(len(l4Hdr) / common.LineLen),
+			L4Proto:    scn.NextHdr,
+		},
+		Info:    info,
+		CmnHdr:  common.RawBytes{},
+		AddrHdr: common.RawBytes{},
+		PathHdr: common.RawBytes(pathHdr),
+		ExtHdrs: common.RawBytes{},
+		L4Hdr:   common.RawBytes(l4Hdr),
+	}
+	if info != nil {
+		pld.Meta.InfoLen = uint8(info.Len() / common.LineLen)
+	}
+	return pld, uint16(hdrLen + int(scn.PayloadLen)), nil
+}
+
+// quotedL4Hdr extracts the quoted L4 header. For SCMP, the header is followed
+// by the legacy meta and info fields, which are required by the dispatcher to
+// route the error based on the identifier of the offending message.
+func quotedL4Hdr(proto common.L4ProtocolType, data []byte) ([]byte, error) {
+	switch proto {
+	case common.L4UDP:
+		if len(data) < l4.UDPLen {
+			return nil, serrors.New("quoted UDP header too short", "len", len(data))
+		}
+		return data[:l4.UDPLen], nil
+	case common.L4SCMP:
+		if len(data) < scmp.HdrLen+scmp.MetaLen {
+			return nil, serrors.New("quoted SCMP header too short", "len", len(data))
+		}
+		meta, err := scmp.MetaFromRaw(data[scmp.HdrLen:])
+		if err != nil {
+			return nil, err
+		}
+		end := scmp.HdrLen + scmp.MetaLen + int(meta.InfoLen)*common.LineLen
+		if len(data) < end {
+			return nil, serrors.New("quoted SCMP info too short", "len", len(data),
+				"expected", end)
+		}
+		return data[:end], nil
+	default:
+		return nil, nil
+	}
+}
@@ -21,6 +21,7 @@ package hpkt
 
 import (
 	"net"
+	"time"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
@@ -32,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/lib/scmp"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/spkt"
@@ -221,6 +223,7 @@ func WriteScnPkt2(s *spkt.ScnPkt, b []byte) (int, error) {
 	}
 	scionLayer.PathType = slayers.PathTypeSCION
 
+	var colibriPath *colibri.Path
 	switch {
 	case s.Path == nil:
 		// Default nil paths to an empty SCION path
@@ -237,6 +240,13 @@ func WriteScnPkt2(s *spkt.ScnPkt, b []byte) (int, error) {
 		}
 		scionLayer.PathType = slayers.PathTypeOneHop
 		scionLayer.Path = &path
+	case s.Path.IsHeaderV2() && s.Path.IsColibri():
+		colibriPath = &colibri.Path{}
+		if err := colibriPath.DecodeFromBytes(s.Path.Raw); err != nil {
+			return 0, serrors.WrapStr("decoding path", err)
+		}
+		scionLayer.PathType = slayers.PathTypeCOLIBRI
+		scionLayer.Path = colibriPath
 	default:
 		// Use decoded for simplicity, easier to work with when debugging with delve.
 		var decodedPath scion.Decoded
@@ -255,8 +265,10 @@ func WriteScnPkt2(s *spkt.ScnPkt, b []byte) (int, error) {
 		return 0, serrors.New("E2E extensions are not supported for Header V2")
 	}
 
+	var l4Len int
 	switch layer := s.L4.(type) {
 	case *l4.UDP:
+		l4Len = l4.UDPLen + s.Pld.Len()
 		scionLayer.NextHdr = common.L4UDP
 		var udpLayer slayers.UDP
 		udpLayer.SrcPort = layers.UDPPort(layer.SrcPort)
@@ -265,6 +277,15 @@ func WriteScnPkt2(s *spkt.ScnPkt, b []byte) (int, error) {
 		packetLayers = append(packetLayers, &udpLayer)
 	case *scmp.Hdr:
 		scionLayer.NextHdr = common.L4SCMP
+		if isTraceroute(layer.Class, layer.Type) {
+			tracerouteLayers, err := scmpTracerouteLayers(layer, s.Pld, &scionLayer)
+			if err != nil {
+				return 0, err
+			}
+			l4Len = scmpTracerouteLen
+			packetLayers = append(packetLayers, tracerouteLayers...)
+			break
+		}
 		var scmpLayer slayers.SCMPDummy
 		scmpLayer.Class = layer.Class
 		scmpLayer.Type = layer.Type
@@ -273,6 +294,7 @@ func WriteScnPkt2(s *spkt.ScnPkt, b []byte) (int, error) {
 		} else {
 			scmpLayer.TotalLen = layer.TotalLen
 		}
+		l4Len = int(scmpLayer.TotalLen)
 		scmpLayer.SetNetworkLayerForChecksum(&scionLayer)
 		scmpLayer.Timestamp = layer.Timestamp
 		buf := make([]byte, s.Pld.Len())
@@ -287,6 +309,18 @@ func WriteScnPkt2(s *spkt.ScnPkt, b []byte) (int, error) {
 		packetLayers = append(packetLayers, &payloadLayer)
 	}
 
+	if colibriPath != nil {
+		// The per packet MACs of e2e reservations cover the timestamp and the payload length,
+		// so they are computed for every packet.
+		colibriPath.InfoField.PacketTimestamp = uint64(time.Now().UnixNano())
+		colibriPath.InfoField.OrigPayLen = uint16(l4Len)
+		if auths := s.Path.ColibriAuthenticators(); auths != nil {
+			if err := colibriPath.SetMACs(auths); err != nil {
+				return 0, serrors.WrapStr("setting COLIBRI MACs", err)
+			}
+		}
+	}
+
 	buffer := gopacket.NewSerializeBuffer()
 	options := gopacket.SerializeOptions{
 		ComputeChecksums: true,
@@ -25,6 +25,8 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/ifid"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
@@ -144,6 +146,14 @@ const (
 	HPSegReply
 	HPCfgRequest
 	HPCfgReply
+	ColibriRequest
+	ColibriResponse
+	DRKeyLvl1Request
+	DRKeyLvl1Reply
+	DRKeyLvl2Request
+	DRKeyLvl2Reply
+	DenyListRequest
+	DenyList
 )
 
 func (mt MessageType) String() string {
@@ -190,6 +200,22 @@ func (mt MessageType) String() string {
 		return "HPCfgRequest"
 	case HPCfgReply:
 		return "HPCfgReply"
+	case ColibriRequest:
+		return "ColibriRequest"
+	case ColibriResponse:
+		return "ColibriResponse"
+	case DRKeyLvl1Request:
+		return "DRKeyLvl1Request"
+	case DRKeyLvl1Reply:
+		return "DRKeyLvl1Reply"
+	case DRKeyLvl2Request:
+		return "DRKeyLvl2Request"
+	case DRKeyLvl2Reply:
+		return "DRKeyLvl2Reply"
+	case DenyListRequest:
+		return "DenyListRequest"
+	case DenyList:
+		return "DenyList"
 	default:
 		return fmt.Sprintf("Unknown (%d)", mt)
 	}
@@ -241,6 +267,22 @@ func (mt MessageType) MetricLabel() string {
 		return "hp_cfg_req"
 	case HPCfgReply:
 		return "hp_cfg_push"
+	case ColibriRequest:
+		return "colibri_req"
+	case ColibriResponse:
+		return "colibri_push"
+	case DRKeyLvl1Request:
+		return "drkey_lvl1_req"
+	case DRKeyLvl1Reply:
+		return "drkey_lvl1_push"
+	case DRKeyLvl2Request:
+		return "drkey_lvl2_req"
+	case DRKeyLvl2Reply:
+		return "drkey_lvl2_push"
+	case DenyListRequest:
+		return "deny_list_req"
+	case DenyList:
+		return "deny_list_push"
 	default:
 		return "unknown_mt"
 	}
@@ -291,6 +333,12 @@ type Messenger interface {
 		id uint64) (*cert_mgmt.TRC, error)
 	// SendTRC sends a reliable cert_mgmt.TRC to address a.
 	SendTRC(ctx context.Context, msg *cert_mgmt.TRC, a net.Addr, id uint64) error
+	// GetDenyList sends a cert_mgmt.DenyListReq request to address a, blocks
+	// until it receives a reply and returns the reply.
+	GetDenyList(ctx context.Context, msg *cert_mgmt.DenyListReq, a net.Addr,
+		id uint64) (*cert_mgmt.DenyList, error)
+	// SendDenyList sends a reliable cert_mgmt.DenyList to address a.
+	SendDenyList(ctx context.Context, msg *cert_mgmt.DenyList, a net.Addr, id uint64) error
 	// GetCertChain sends a cert_mgmt.ChainReq to address a, blocks until it
 	// receives a reply and returns the reply.
 	GetCertChain(ctx context.Context, msg *cert_mgmt.ChainReq, a net.Addr,
@@ -323,6 +371,25 @@ type Messenger interface {
 	SendChainRenewalReply(ctx context.Context, msg *cert_mgmt.ChainRenewalReply, a net.Addr,
 		id uint64) error
 	SendBeacon(ctx context.Context, msg *seg.Beacon, a net.Addr, id uint64) error
+	// RequestColibri sends a COLIBRI request to address a, blocks until it receives a
+	// COLIBRI response and returns the response.
+	RequestColibri(ctx context.Context, msg *colibri_mgmt.ColibriRequestPayload, a net.Addr,
+		id uint64) (*colibri_mgmt.ColibriRequestPayload, error)
+	// SendColibriReply sends a reliable COLIBRI response to address a.
+	SendColibriReply(ctx context.Context, msg *colibri_mgmt.ColibriRequestPayload, a net.Addr,
+		id uint64) error
+	// RequestDRKeyLvl1 sends a level 1 key request to address a, blocks until it receives a
+	// reply and returns the reply.
+	RequestDRKeyLvl1(ctx context.Context, msg *drkey_mgmt.Lvl1Req, a net.Addr,
+		id uint64) (*drkey_mgmt.Lvl1Rep, error)
+	// SendDRKeyLvl1Reply sends a reliable level 1 key reply to address a.
+	SendDRKeyLvl1Reply(ctx context.Context, msg *drkey_mgmt.Lvl1Rep, a net.Addr, id uint64) error
+	// RequestDRKeyLvl2 sends a level 2 key request to address a, blocks until it receives a
+	// reply and returns the reply.
+	RequestDRKeyLvl2(ctx context.Context, msg *drkey_mgmt.Lvl2Req, a net.Addr,
+		id uint64) (*drkey_mgmt.Lvl2Rep, error)
+	// SendDRKeyLvl2Reply sends a reliable level 2 key reply to address a.
+	SendDRKeyLvl2Reply(ctx context.Context, msg *drkey_mgmt.Lvl2Rep, a net.Addr, id uint64) error
 	UpdateSigner(signer ctrl.Signer, types []MessageType)
 	UpdateVerifier(verifier Verifier)
 	AddHandler(msgType MessageType, h Handler)
@@ -333,12 +400,16 @@ type Messenger interface {
 type ResponseWriter interface {
 	SendAckReply(ctx context.Context, msg *ack.Ack) error
 	SendTRCReply(ctx context.Context, msg *cert_mgmt.TRC) error
+	SendDenyListReply(ctx context.Context, msg *cert_mgmt.DenyList) error
 	SendCertChainReply(ctx context.Context, msg *cert_mgmt.Chain) error
 	SendChainRenewalReply(ctx context.Context, msg *cert_mgmt.ChainRenewalReply) error
 	SendSegReply(ctx context.Context, msg *path_mgmt.SegReply) error
 	SendIfStateInfoReply(ctx context.Context, msg *path_mgmt.IFStateInfos) error
 	SendHPSegReply(ctx context.Context, msg *path_mgmt.HPSegReply) error
 	SendHPCfgReply(ctx context.Context, msg *path_mgmt.HPCfgReply) error
+	SendColibriReply(ctx context.Context, msg *colibri_mgmt.ColibriRequestPayload) error
+	SendDRKeyLvl1Reply(ctx context.Context, msg *drkey_mgmt.Lvl1Rep) error
+	SendDRKeyLvl2Reply(ctx context.Context, msg *drkey_mgmt.Lvl2Rep) error
 }
 
 func ResponseWriterFromContext(ctx context.Context) (ResponseWriter, bool) {
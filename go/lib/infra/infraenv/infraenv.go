@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/squic"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect"
 	"github.com/scionproto/scion/go/lib/svc"
@@ -52,6 +53,9 @@ type QUIC struct {
 	CertFile string
 	// KeyFile is the private key to use for QUIC authentication.
 	KeyFile string
+	// CryptoManager, if set, authenticates QUIC connections with the
+	// control-plane PKI. In that case, CertFile and KeyFile are ignored.
+	CryptoManager squic.CryptoManager
 }
 
 // NetworkConfig describes the networking configuration of a SCION
@@ -235,6 +239,12 @@ func (nc *NetworkConfig) initQUICSocket() (net.PacketConn, error) {
 }
 
 func (nc *NetworkConfig) buildQUICConfig(conn net.PacketConn) (*messenger.QUICConfig, error) {
+	if nc.QUIC.CryptoManager != nil {
+		return &messenger.QUICConfig{
+			Conn:      conn,
+			TLSConfig: squic.CPPKITLSConfig(nc.QUIC.CryptoManager),
+		}, nil
+	}
 	cert, err := tls.LoadX509KeyPair(nc.QUIC.CertFile, nc.QUIC.KeyFile)
 	if err != nil {
 		return nil, err
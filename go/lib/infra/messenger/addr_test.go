@@ -478,6 +478,10 @@ func (t *testPath) Expiry() time.Time {
 	panic("not implemented")
 }
 
+func (t *testPath) Metadata() *snet.PathMetadata {
+	panic("not implemented")
+}
+
 func (t *testPath) Copy() snet.Path {
 	panic("not implemented")
 }
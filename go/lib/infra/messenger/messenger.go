@@ -19,6 +19,8 @@
 //  infra.Chain               -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.Chain
 //  infra.TRCRequest          -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.TRCReq
 //  infra.TRC                 -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.TRC
+//  infra.DenyListRequest     -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.DenyListReq
+//  infra.DenyList            -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.DenyList
 //  infra.IfId                -> ctrl.SignedPld/ctrl.Pld/ifid.IFID
 //  infra.IfStateInfos        -> ctrl.SignedPld/ctrl.Pld/path_mgmt.IFStateInfos
 //  infra.IfStateReq          -> ctrl.SignedPld/ctrl.Pld/path_mgmt.IFStateReq
@@ -34,6 +36,12 @@
 //  infra.HPCfgReply          -> ctrl.SignedPld/ctrl.Pld/path_mgmt.HPCfgReply
 //  infra.ChainRenewalRequest   -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.ChainRenewalRequest,
 //  infra.ChainRenewalReply     -> ctrl.SignedPld/ctrl.Pld/cert_mgmt.ChainRenewalReply,
+//  infra.ColibriRequest      -> ctrl.SignedPld/ctrl.Pld/colibri_mgmt.ColibriRequestPayload
+//  infra.ColibriResponse     -> ctrl.SignedPld/ctrl.Pld/colibri_mgmt.ColibriRequestPayload
+//  infra.DRKeyLvl1Request    -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl1Req
+//  infra.DRKeyLvl1Reply      -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl1Rep
+//  infra.DRKeyLvl2Request    -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl2Req
+//  infra.DRKeyLvl2Reply      -> ctrl.SignedPld/ctrl.Pld/drkey_mgmt.Lvl2Rep
 //
 // To start processing messages received via the Messenger, call
 // ListenAndServe. The method runs in the current goroutine, and spawns new
@@ -86,6 +94,8 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/ctrl_msg"
 	"github.com/scionproto/scion/go/lib/ctrl/ifid"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
@@ -280,6 +290,49 @@ func (m *Messenger) SendTRC(ctx context.Context, msg *cert_mgmt.TRC, a net.Addr,
 	return m.sendMessage(ctx, pld, a, id, infra.TRC)
 }
 
+func (m *Messenger) GetDenyList(ctx context.Context, msg *cert_mgmt.DenyListReq,
+	a net.Addr, id uint64) (*cert_mgmt.DenyList, error) {
+
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewCertMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger := log.FromCtx(ctx)
+	logger.Debug("[Messenger] Sending request", "req_type", infra.DenyListRequest,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.getFallbackRequester(infra.DenyListRequest).Request(ctx,
+		pld, a, false)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Request error", err,
+			"req_type", infra.DenyListRequest)
+	}
+	_, replyMsg, err := Validate(replyCtrlPld)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *cert_mgmt.DenyList:
+		logger.Debug("[Messenger] Received reply", "req_id", id, "reply", reply)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		err := newTypeAssertErr("*cert_mgmt.DenyList", replyMsg)
+		return nil, common.NewBasicError("[Messenger] Type assertion failed", err)
+	}
+}
+
+func (m *Messenger) SendDenyList(ctx context.Context, msg *cert_mgmt.DenyList, a net.Addr,
+	id uint64) error {
+
+	pld, err := cert_mgmt.NewPld(msg, nil)
+	if err != nil {
+		return err
+	}
+	return m.sendMessage(ctx, pld, a, id, infra.DenyList)
+}
+
 func (m *Messenger) GetCertChain(ctx context.Context, msg *cert_mgmt.ChainReq,
 	a net.Addr, id uint64) (*cert_mgmt.Chain, error) {
 
@@ -555,6 +608,145 @@ func (m *Messenger) SendChainRenewalReply(ctx context.Context, msg *cert_mgmt.Ch
 	return m.getFallbackRequester(infra.ChainRenewalReply).Notify(ctx, pld, a)
 }
 
+func (m *Messenger) RequestColibri(ctx context.Context,
+	msg *colibri_mgmt.ColibriRequestPayload, a net.Addr,
+	id uint64) (*colibri_mgmt.ColibriRequestPayload, error) {
+
+	logger := log.FromCtx(ctx)
+	pld, err := ctrl.NewPld(msg, &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("[Messenger] Sending request", "req_type", infra.ColibriRequest,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.getFallbackRequester(infra.ColibriRequest).Request(ctx,
+		pld, a, false)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Request error", err,
+			"req_type", infra.ColibriRequest)
+	}
+	msgType, replyMsg, err := Validate(replyCtrlPld)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *colibri_mgmt.ColibriRequestPayload:
+		if msgType != infra.ColibriResponse {
+			return nil, common.NewBasicError("[Messenger] Unexpected reply type", nil,
+				"type", msgType)
+		}
+		logger.Debug("[Messenger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		err := newTypeAssertErr("*colibri_mgmt.ColibriRequestPayload", replyMsg)
+		return nil, common.NewBasicError("[Messenger] Type assertion failed", err)
+	}
+}
+
+func (m *Messenger) SendColibriReply(ctx context.Context,
+	msg *colibri_mgmt.ColibriRequestPayload, a net.Addr, id uint64) error {
+
+	pld, err := ctrl.NewPld(msg, &ctrl.Data{ReqId: id})
+	if err != nil {
+		return err
+	}
+	logger := log.FromCtx(ctx)
+	logger.Debug("[Messenger] Sending Notify", "type", infra.ColibriResponse, "to", a, "id", id)
+	return m.getFallbackRequester(infra.ColibriResponse).Notify(ctx, pld, a)
+}
+
+func (m *Messenger) RequestDRKeyLvl1(ctx context.Context, msg *drkey_mgmt.Lvl1Req,
+	a net.Addr, id uint64) (*drkey_mgmt.Lvl1Rep, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("[Messenger] Sending request", "req_type", infra.DRKeyLvl1Request,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.getFallbackRequester(infra.DRKeyLvl1Request).Request(ctx,
+		pld, a, false)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Request error", err,
+			"req_type", infra.DRKeyLvl1Request)
+	}
+	_, replyMsg, err := Validate(replyCtrlPld)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *drkey_mgmt.Lvl1Rep:
+		logger.Debug("[Messenger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		err := newTypeAssertErr("*drkey_mgmt.Lvl1Rep", replyMsg)
+		return nil, common.NewBasicError("[Messenger] Type assertion failed", err)
+	}
+}
+
+func (m *Messenger) SendDRKeyLvl1Reply(ctx context.Context, msg *drkey_mgmt.Lvl1Rep,
+	a net.Addr, id uint64) error {
+
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, &ctrl.Data{ReqId: id})
+	if err != nil {
+		return err
+	}
+	logger := log.FromCtx(ctx)
+	logger.Debug("[Messenger] Sending Notify", "type", infra.DRKeyLvl1Reply, "to", a, "id", id)
+	return m.getFallbackRequester(infra.DRKeyLvl1Reply).Notify(ctx, pld, a)
+}
+
+func (m *Messenger) RequestDRKeyLvl2(ctx context.Context, msg *drkey_mgmt.Lvl2Req,
+	a net.Addr, id uint64) (*drkey_mgmt.Lvl2Rep, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("[Messenger] Sending request", "req_type", infra.DRKeyLvl2Request,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.getFallbackRequester(infra.DRKeyLvl2Request).Request(ctx,
+		pld, a, false)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Request error", err,
+			"req_type", infra.DRKeyLvl2Request)
+	}
+	_, replyMsg, err := Validate(replyCtrlPld)
+	if err != nil {
+		return nil, common.NewBasicError("[Messenger] Reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *drkey_mgmt.Lvl2Rep:
+		logger.Debug("[Messenger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		err := newTypeAssertErr("*drkey_mgmt.Lvl2Rep", replyMsg)
+		return nil, common.NewBasicError("[Messenger] Type assertion failed", err)
+	}
+}
+
+func (m *Messenger) SendDRKeyLvl2Reply(ctx context.Context, msg *drkey_mgmt.Lvl2Rep,
+	a net.Addr, id uint64) error {
+
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, &ctrl.Data{ReqId: id})
+	if err != nil {
+		return err
+	}
+	logger := log.FromCtx(ctx)
+	logger.Debug("[Messenger] Sending Notify", "type", infra.DRKeyLvl2Reply, "to", a, "id", id)
+	return m.getFallbackRequester(infra.DRKeyLvl2Reply).Notify(ctx, pld, a)
+}
+
 func (m *Messenger) SendBeacon(ctx context.Context, msg *seg.Beacon, a net.Addr, id uint64) error {
 	logger := log.FromCtx(ctx)
 	switch a.(type) {
@@ -975,6 +1167,10 @@ func Validate(pld *ctrl.Pld) (infra.MessageType, proto.Cerealizable, error) {
 			return infra.TRCRequest, pld.CertMgmt.TRCReq, nil
 		case proto.CertMgmt_Which_trc:
 			return infra.TRC, pld.CertMgmt.TRCRep, nil
+		case proto.CertMgmt_Which_denyListReq:
+			return infra.DenyListRequest, pld.CertMgmt.DenyListReq, nil
+		case proto.CertMgmt_Which_denyList:
+			return infra.DenyList, pld.CertMgmt.DenyListRep, nil
 		case proto.CertMgmt_Which_certChainRenewalRequest:
 			return infra.ChainRenewalRequest, pld.CertMgmt.ChainRenewalRequest, nil
 		case proto.CertMgmt_Which_certChainRenewalReply:
@@ -1013,8 +1209,34 @@ func Validate(pld *ctrl.Pld) (infra.MessageType, proto.Cerealizable, error) {
 				common.NewBasicError("Unsupported SignedPld.CtrlPld.PathMgmt.Xxx message type",
 					nil, "capnp_which", pld.PathMgmt.Which)
 		}
+	case proto.CtrlPld_Which_drkeyMgmt:
+		switch pld.DRKeyMgmt.Which {
+		case proto.DRKeyMgmt_Which_drkeyLvl1Req:
+			return infra.DRKeyLvl1Request, pld.DRKeyMgmt.Lvl1Req, nil
+		case proto.DRKeyMgmt_Which_drkeyLvl1Rep:
+			return infra.DRKeyLvl1Reply, pld.DRKeyMgmt.Lvl1Rep, nil
+		case proto.DRKeyMgmt_Which_drkeyLvl2Req:
+			return infra.DRKeyLvl2Request, pld.DRKeyMgmt.Lvl2Req, nil
+		case proto.DRKeyMgmt_Which_drkeyLvl2Rep:
+			return infra.DRKeyLvl2Reply, pld.DRKeyMgmt.Lvl2Rep, nil
+		default:
+			return infra.None, nil,
+				common.NewBasicError("Unsupported SignedPld.CtrlPld.DRKeyMgmt.Xxx message type",
+					nil, "capnp_which", pld.DRKeyMgmt.Which)
+		}
 	case proto.CtrlPld_Which_ack:
 		return infra.Ack, pld.Ack, nil
+	case proto.CtrlPld_Which_colibri:
+		switch pld.Colibri.Which {
+		case proto.ColibriRequestPayload_Which_request:
+			return infra.ColibriRequest, pld.Colibri, nil
+		case proto.ColibriRequestPayload_Which_response:
+			return infra.ColibriResponse, pld.Colibri, nil
+		default:
+			return infra.None, nil,
+				common.NewBasicError("Unsupported SignedPld.CtrlPld.Colibri.Xxx message type",
+					nil, "capnp_which", pld.Colibri.Which)
+		}
 	default:
 		return infra.None, nil, common.NewBasicError("Unsupported SignedPld.Pld.Xxx message type",
 			nil, "capnp_which", pld.Which)
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
@@ -105,6 +106,40 @@ func (m *Messenger) SendTRC(ctx context.Context, msg *cert_mgmt.TRC, a net.Addr,
 	panic("not implemented")
 }
 
+// GetDenyList sends a cert_mgmt.DenyListReq request to address a, blocks until
+// it receives a reply and returns the reply.
+func (m *Messenger) GetDenyList(ctx context.Context, msg *cert_mgmt.DenyListReq, a net.Addr,
+	id uint64) (*cert_mgmt.DenyList, error) {
+
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewCertMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger := log.FromCtx(ctx)
+	logger.Debug("[tcp-msger] Sending request", "req_type", infra.DenyListRequest,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.client.Request(ctx, pld, a)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] request error", err,
+			"req_type", infra.DenyListRequest)
+	}
+	_, replyMsg, err := messenger.Validate(replyCtrlPld)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *cert_mgmt.DenyList:
+		logger.Debug("[tcp-msger] Received reply", "req_id", id, "reply", reply)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		return nil, serrors.New("[tcp-msger] Type assertion failed",
+			"msg", replyMsg, "type", "*cert_mgmt.DenyList")
+	}
+}
+
 // GetCertChain sends a cert_mgmt.ChainReq to address a, blocks until it
 // receives a reply and returns the reply.
 func (m *Messenger) GetCertChain(ctx context.Context, msg *cert_mgmt.ChainReq, a net.Addr,
@@ -182,6 +217,77 @@ func (m *Messenger) GetSegs(ctx context.Context, msg *path_mgmt.SegReq, a net.Ad
 	}
 }
 
+// GetHPSegs asks the hidden path server at the remote address for the hidden
+// path segments that satisfy msg, and returns a verified reply.
+func (m *Messenger) GetHPSegs(ctx context.Context, msg *path_mgmt.HPSegReq, a net.Addr,
+	id uint64) (*path_mgmt.HPSegReply, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewPathMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("[tcp-msger] Sending request", "req_type", infra.HPSegRequest,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.client.Request(ctx, pld, a)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] request error", err,
+			"req_type", infra.HPSegRequest)
+	}
+	_, replyMsg, err := messenger.Validate(replyCtrlPld)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *path_mgmt.HPSegReply:
+		if err := reply.ParseRaw(); err != nil {
+			return nil, serrors.WrapStr("[tcp-msger] failed to parse reply", err)
+		}
+		logger.Debug("[tcp-msger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		return nil, serrors.New("[tcp-msger] Type assertion failed",
+			"msg", replyMsg, "type", "*path_mgmt.HPSegReply")
+	}
+}
+
+// RequestDRKeyLvl2 sends a drkey_mgmt.Lvl2Req to address a, blocks until it receives a
+// reply and returns the reply.
+func (m *Messenger) RequestDRKeyLvl2(ctx context.Context, msg *drkey_mgmt.Lvl2Req, a net.Addr,
+	id uint64) (*drkey_mgmt.Lvl2Rep, error) {
+
+	logger := log.FromCtx(ctx)
+	data := &ctrl.Data{ReqId: id, TraceId: tracing.IDFromCtx(ctx)}
+	pld, err := ctrl.NewDRKeyMgmtPld(msg, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("[tcp-msger] Sending request", "req_type", infra.DRKeyLvl2Request,
+		"msg_id", id, "request", msg, "peer", a)
+	replyCtrlPld, err := m.client.Request(ctx, pld, a)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] request error", err,
+			"req_type", infra.DRKeyLvl2Request)
+	}
+	_, replyMsg, err := messenger.Validate(replyCtrlPld)
+	if err != nil {
+		return nil, serrors.WrapStr("[tcp-msger] reply validation failed", err)
+	}
+	switch reply := replyMsg.(type) {
+	case *drkey_mgmt.Lvl2Rep:
+		logger.Debug("[tcp-msger] Received reply", "req_id", id)
+		return reply, nil
+	case *ack.Ack:
+		return nil, &infra.Error{Message: reply}
+	default:
+		return nil, serrors.New("[tcp-msger] Type assertion failed",
+			"msg", replyMsg, "type", "*drkey_mgmt.Lvl2Rep")
+	}
+}
+
 func (m *Messenger) AddHandler(msgType infra.MessageType, h infra.Handler) {
 	m.Handler.Handle(msgType, h)
 }
@@ -20,6 +20,8 @@ import (
 
 	"github.com/scionproto/scion/go/lib/ctrl/ack"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/infra"
 )
@@ -40,6 +42,12 @@ func (rw *UDPResponseWriter) SendTRCReply(ctx context.Context, msg *cert_mgmt.TR
 	return rw.Messenger.SendTRC(ctx, msg, rw.Remote, rw.ID)
 }
 
+func (rw *UDPResponseWriter) SendDenyListReply(ctx context.Context,
+	msg *cert_mgmt.DenyList) error {
+
+	return rw.Messenger.SendDenyList(ctx, msg, rw.Remote, rw.ID)
+}
+
 func (rw *UDPResponseWriter) SendCertChainReply(ctx context.Context, msg *cert_mgmt.Chain) error {
 	return rw.Messenger.SendCertChain(ctx, msg, rw.Remote, rw.ID)
 }
@@ -67,3 +75,21 @@ func (rw *UDPResponseWriter) SendHPSegReply(ctx context.Context, msg *path_mgmt.
 func (rw *UDPResponseWriter) SendHPCfgReply(ctx context.Context, msg *path_mgmt.HPCfgReply) error {
 	return rw.Messenger.SendHPCfgReply(ctx, msg, rw.Remote, rw.ID)
 }
+
+func (rw *UDPResponseWriter) SendColibriReply(ctx context.Context,
+	msg *colibri_mgmt.ColibriRequestPayload) error {
+
+	return rw.Messenger.SendColibriReply(ctx, msg, rw.Remote, rw.ID)
+}
+
+func (rw *UDPResponseWriter) SendDRKeyLvl1Reply(ctx context.Context,
+	msg *drkey_mgmt.Lvl1Rep) error {
+
+	return rw.Messenger.SendDRKeyLvl1Reply(ctx, msg, rw.Remote, rw.ID)
+}
+
+func (rw *UDPResponseWriter) SendDRKeyLvl2Reply(ctx context.Context,
+	msg *drkey_mgmt.Lvl2Rep) error {
+
+	return rw.Messenger.SendDRKeyLvl2Reply(ctx, msg, rw.Remote, rw.ID)
+}
@@ -11,6 +11,8 @@ import (
 	ctrl "github.com/scionproto/scion/go/lib/ctrl"
 	ack "github.com/scionproto/scion/go/lib/ctrl/ack"
 	cert_mgmt "github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	colibri_mgmt "github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	drkey_mgmt "github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	ifid "github.com/scionproto/scion/go/lib/ctrl/ifid"
 	path_mgmt "github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	seg "github.com/scionproto/scion/go/lib/ctrl/seg"
@@ -84,6 +86,21 @@ func (mr *MockMessengerMockRecorder) GetCertChain(arg0, arg1, arg2, arg3 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertChain", reflect.TypeOf((*MockMessenger)(nil).GetCertChain), arg0, arg1, arg2, arg3)
 }
 
+// GetDenyList mocks base method
+func (m *MockMessenger) GetDenyList(arg0 context.Context, arg1 *cert_mgmt.DenyListReq, arg2 net.Addr, arg3 uint64) (*cert_mgmt.DenyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDenyList", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*cert_mgmt.DenyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDenyList indicates an expected call of GetDenyList
+func (mr *MockMessengerMockRecorder) GetDenyList(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDenyList", reflect.TypeOf((*MockMessenger)(nil).GetDenyList), arg0, arg1, arg2, arg3)
+}
+
 // GetHPCfgs mocks base method
 func (m *MockMessenger) GetHPCfgs(arg0 context.Context, arg1 *path_mgmt.HPCfgReq, arg2 net.Addr, arg3 uint64) (*path_mgmt.HPCfgReply, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +188,51 @@ func (mr *MockMessengerMockRecorder) RequestChainRenewal(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestChainRenewal", reflect.TypeOf((*MockMessenger)(nil).RequestChainRenewal), arg0, arg1, arg2, arg3)
 }
 
+// RequestColibri mocks base method
+func (m *MockMessenger) RequestColibri(arg0 context.Context, arg1 *colibri_mgmt.ColibriRequestPayload, arg2 net.Addr, arg3 uint64) (*colibri_mgmt.ColibriRequestPayload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestColibri", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*colibri_mgmt.ColibriRequestPayload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestColibri indicates an expected call of RequestColibri
+func (mr *MockMessengerMockRecorder) RequestColibri(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestColibri", reflect.TypeOf((*MockMessenger)(nil).RequestColibri), arg0, arg1, arg2, arg3)
+}
+
+// RequestDRKeyLvl1 mocks base method
+func (m *MockMessenger) RequestDRKeyLvl1(arg0 context.Context, arg1 *drkey_mgmt.Lvl1Req, arg2 net.Addr, arg3 uint64) (*drkey_mgmt.Lvl1Rep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDRKeyLvl1", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*drkey_mgmt.Lvl1Rep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDRKeyLvl1 indicates an expected call of RequestDRKeyLvl1
+func (mr *MockMessengerMockRecorder) RequestDRKeyLvl1(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDRKeyLvl1", reflect.TypeOf((*MockMessenger)(nil).RequestDRKeyLvl1), arg0, arg1, arg2, arg3)
+}
+
+// RequestDRKeyLvl2 mocks base method
+func (m *MockMessenger) RequestDRKeyLvl2(arg0 context.Context, arg1 *drkey_mgmt.Lvl2Req, arg2 net.Addr, arg3 uint64) (*drkey_mgmt.Lvl2Rep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDRKeyLvl2", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*drkey_mgmt.Lvl2Rep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDRKeyLvl2 indicates an expected call of RequestDRKeyLvl2
+func (mr *MockMessengerMockRecorder) RequestDRKeyLvl2(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDRKeyLvl2", reflect.TypeOf((*MockMessenger)(nil).RequestDRKeyLvl2), arg0, arg1, arg2, arg3)
+}
+
 // SendAck mocks base method
 func (m *MockMessenger) SendAck(arg0 context.Context, arg1 *ack.Ack, arg2 net.Addr, arg3 uint64) error {
 	m.ctrl.T.Helper()
@@ -227,6 +289,62 @@ func (mr *MockMessengerMockRecorder) SendChainRenewalReply(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendChainRenewalReply", reflect.TypeOf((*MockMessenger)(nil).SendChainRenewalReply), arg0, arg1, arg2, arg3)
 }
 
+// SendColibriReply mocks base method
+func (m *MockMessenger) SendColibriReply(arg0 context.Context, arg1 *colibri_mgmt.ColibriRequestPayload, arg2 net.Addr, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendColibriReply", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendColibriReply indicates an expected call of SendColibriReply
+func (mr *MockMessengerMockRecorder) SendColibriReply(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendColibriReply", reflect.TypeOf((*MockMessenger)(nil).SendColibriReply), arg0, arg1, arg2, arg3)
+}
+
+// SendDRKeyLvl1Reply mocks base method
+func (m *MockMessenger) SendDRKeyLvl1Reply(arg0 context.Context, arg1 *drkey_mgmt.Lvl1Rep, arg2 net.Addr, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDRKeyLvl1Reply", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDRKeyLvl1Reply indicates an expected call of SendDRKeyLvl1Reply
+func (mr *MockMessengerMockRecorder) SendDRKeyLvl1Reply(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDRKeyLvl1Reply", reflect.TypeOf((*MockMessenger)(nil).SendDRKeyLvl1Reply), arg0, arg1, arg2, arg3)
+}
+
+// SendDRKeyLvl2Reply mocks base method
+func (m *MockMessenger) SendDRKeyLvl2Reply(arg0 context.Context, arg1 *drkey_mgmt.Lvl2Rep, arg2 net.Addr, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDRKeyLvl2Reply", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDRKeyLvl2Reply indicates an expected call of SendDRKeyLvl2Reply
+func (mr *MockMessengerMockRecorder) SendDRKeyLvl2Reply(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDRKeyLvl2Reply", reflect.TypeOf((*MockMessenger)(nil).SendDRKeyLvl2Reply), arg0, arg1, arg2, arg3)
+}
+
+// SendDenyList mocks base method
+func (m *MockMessenger) SendDenyList(arg0 context.Context, arg1 *cert_mgmt.DenyList, arg2 net.Addr, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDenyList", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDenyList indicates an expected call of SendDenyList
+func (mr *MockMessengerMockRecorder) SendDenyList(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDenyList", reflect.TypeOf((*MockMessenger)(nil).SendDenyList), arg0, arg1, arg2, arg3)
+}
+
 // SendHPCfgReply mocks base method
 func (m *MockMessenger) SendHPCfgReply(arg0 context.Context, arg1 *path_mgmt.HPCfgReply, arg2 net.Addr, arg3 uint64) error {
 	m.ctrl.T.Helper()
@@ -442,6 +560,62 @@ func (mr *MockResponseWriterMockRecorder) SendChainRenewalReply(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendChainRenewalReply", reflect.TypeOf((*MockResponseWriter)(nil).SendChainRenewalReply), arg0, arg1)
 }
 
+// SendColibriReply mocks base method
+func (m *MockResponseWriter) SendColibriReply(arg0 context.Context, arg1 *colibri_mgmt.ColibriRequestPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendColibriReply", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendColibriReply indicates an expected call of SendColibriReply
+func (mr *MockResponseWriterMockRecorder) SendColibriReply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendColibriReply", reflect.TypeOf((*MockResponseWriter)(nil).SendColibriReply), arg0, arg1)
+}
+
+// SendDRKeyLvl1Reply mocks base method
+func (m *MockResponseWriter) SendDRKeyLvl1Reply(arg0 context.Context, arg1 *drkey_mgmt.Lvl1Rep) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDRKeyLvl1Reply", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDRKeyLvl1Reply indicates an expected call of SendDRKeyLvl1Reply
+func (mr *MockResponseWriterMockRecorder) SendDRKeyLvl1Reply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDRKeyLvl1Reply", reflect.TypeOf((*MockResponseWriter)(nil).SendDRKeyLvl1Reply), arg0, arg1)
+}
+
+// SendDRKeyLvl2Reply mocks base method
+func (m *MockResponseWriter) SendDRKeyLvl2Reply(arg0 context.Context, arg1 *drkey_mgmt.Lvl2Rep) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDRKeyLvl2Reply", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDRKeyLvl2Reply indicates an expected call of SendDRKeyLvl2Reply
+func (mr *MockResponseWriterMockRecorder) SendDRKeyLvl2Reply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDRKeyLvl2Reply", reflect.TypeOf((*MockResponseWriter)(nil).SendDRKeyLvl2Reply), arg0, arg1)
+}
+
+// SendDenyListReply mocks base method
+func (m *MockResponseWriter) SendDenyListReply(arg0 context.Context, arg1 *cert_mgmt.DenyList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDenyListReply", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDenyListReply indicates an expected call of SendDenyListReply
+func (mr *MockResponseWriterMockRecorder) SendDenyListReply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDenyListReply", reflect.TypeOf((*MockResponseWriter)(nil).SendDenyListReply), arg0, arg1)
+}
+
 // SendHPCfgReply mocks base method
 func (m *MockResponseWriter) SendHPCfgReply(arg0 context.Context, arg1 *path_mgmt.HPCfgReply) error {
 	m.ctrl.T.Helper()
@@ -68,8 +68,18 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	if err != nil {
 		return nil, err
 	}
-	paths := p.buildAllPaths(src, dst, segs)
-	paths, err = p.filterRevoked(ctx, paths)
+	return p.Build(ctx, dst, segs)
+}
+
+// Build returns all non-revoked and non-expired paths to the destination that
+// can be combined from the given segments. The paths are sorted from best to
+// worst according to the weighting in path combinator. In case no path can be
+// built, ErrNoPaths is returned.
+func (p *Pather) Build(ctx context.Context, dst addr.IA,
+	segs Segments) ([]*combinator.Path, error) {
+
+	paths := p.buildAllPaths(p.TopoProvider.Get().IA(), dst, segs)
+	paths, err := p.filterRevoked(ctx, paths)
 	if err != nil {
 		return nil, err
 	}
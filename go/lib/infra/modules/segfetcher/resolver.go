@@ -125,6 +125,9 @@ func (r *DefaultResolver) loadSegment(ctx context.Context, req Request) (query.R
 		StartsAt: []addr.IA{start},
 		EndsAt:   []addr.IA{end},
 		SegTypes: []proto.PathSegType{req.SegType},
+		// Only public segments are resolved, hidden segments are looked up
+		// separately.
+		HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 	})
 }
 
@@ -152,6 +152,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg120_111_up, tg.seg130_111_up), nil)
 			},
 			ExpectedSegments: segfetcher.Segments{
@@ -191,6 +192,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg120_111_up, tg.seg130_111_up), nil)
 				// no cached core segments
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_120),
@@ -217,6 +219,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg120_111_up, tg.seg130_111_up), nil)
 				// cached core segments
 				db.EXPECT().GetNextQuery(gomock.Any(), gomock.Eq(core_120),
@@ -226,10 +229,12 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_110}, EndsAt: []addr.IA{core_120},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg110_120_core), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_110}, EndsAt: []addr.IA{core_130},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg110_130_core), nil)
 			},
 			ExpectedSegments: segfetcher.Segments{tg.seg120_111_up, tg.seg130_111_up,
@@ -269,14 +274,17 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_211},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg210_211_up), nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{isd2},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				}))
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_212},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg210_212_down), nil)
 			},
 			ExpectedSegments:  segfetcher.Segments{tg.seg210_211_up, tg.seg210_212_down},
@@ -313,6 +321,7 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg120_111_up, tg.seg130_111_up), nil)
 				db.EXPECT().GetNextQuery(gomock.Any(), isd1, isd2, gomock.Any())
 				db.EXPECT().GetNextQuery(gomock.Any(), isd2, non_core_211, gomock.Any())
@@ -336,12 +345,14 @@ func TestResolver(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd2}, EndsAt: []addr.IA{non_core_211},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg210_211_up), nil)
 				db.EXPECT().GetNextQuery(gomock.Any(), isd1, non_core_111, gomock.Any()).
 					Return(futureT, nil)
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_down},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg120_111_down, tg.seg130_111_down), nil)
 				db.EXPECT().GetNextQuery(gomock.Any(), isd2, isd1, gomock.Any())
 			},
@@ -410,6 +421,7 @@ func TestResolverWithRevocations(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_up},
 					StartsAt: []addr.IA{isd1}, EndsAt: []addr.IA{non_core_111},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg120_111_up, tg.seg130_111_up), nil)
 			},
 			ExpectRevcache: func(t *testing.T, revCache *mock_revcache.MockRevCache) {
@@ -442,6 +454,7 @@ func TestResolverWithRevocations(t *testing.T) {
 				db.EXPECT().Get(gomock.Any(), matchers.EqParams(&query.Params{
 					SegTypes: []proto.PathSegType{proto.PathSegType_core},
 					StartsAt: []addr.IA{core_130}, EndsAt: []addr.IA{core_210},
+					HpCfgIDs: []*query.HPCfgID{&query.NullHpCfgID},
 				})).Return(resultsFromSegs(tg.seg210_130_core, tg.seg210_130_2_core), nil)
 				// Other calls return 0
 				db.EXPECT().Get(gomock.Any(), gomock.Any()).Times(2)
@@ -56,6 +56,15 @@ func loadKey(file string, algo string) (common.RawBytes, error) {
 	return dbuf, nil
 }
 
+// Master holds the AS master keys.
+//
+// Key0 is the active key. It is used to issue and verify hop field MACs. Key1
+// is the previous key. The border routers still accept hop fields MACed with
+// it, such that paths in flight stay valid during a key rotation. To rotate
+// the master key, move master0.key to master1.key, write the new key to
+// master0.key, and reload the border routers before the control service
+// (SIGHUP). Key1 must be kept until all hop fields issued with it have
+// expired.
 type Master struct {
 	Key0 []byte
 	Key1 []byte
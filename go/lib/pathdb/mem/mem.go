@@ -0,0 +1,578 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mem contains an in-memory backend for the PathDB. The content of the
+// database is lost when the process terminates.
+//
+// The stored path segments are shared with the callers, i.e., segments that
+// are inserted into or returned from the database must not be modified.
+package mem
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ pathdb.PathDB = (*Backend)(nil)
+
+// Backend is an in-memory PathDB.
+type Backend struct {
+	*executor
+}
+
+// New returns a new empty in-memory backend.
+func New() *Backend {
+	return &Backend{
+		executor: &executor{
+			state: newState(),
+		},
+	}
+}
+
+// Close drops the content of the database. Any further operation on the
+// backend fails.
+func (b *Backend) Close() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.state = nil
+	return nil
+}
+
+// SetMaxOpenConns is a no-op, the in-memory backend has no connections.
+func (b *Backend) SetMaxOpenConns(_ int) {}
+
+// SetMaxIdleConns is a no-op, the in-memory backend has no connections.
+func (b *Backend) SetMaxIdleConns(_ int) {}
+
+// BeginTransaction starts a transaction. The transaction operates on a
+// snapshot of the database, i.e., it does not observe changes that are made
+// outside of the transaction. On commit, the write operations of the
+// transaction are applied to the database in the order they were issued. The
+// transaction options are ignored.
+func (b *Backend) BeginTransaction(ctx context.Context,
+	_ *sql.TxOptions) (pathdb.Transaction, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, common.NewBasicError("Failed to create transaction", err)
+	}
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	if b.state == nil {
+		return nil, serrors.New("No database open")
+	}
+	return &transaction{
+		executor: &executor{
+			state:   b.state.clone(),
+			journal: []func(*state){},
+		},
+		backend: b.executor,
+	}, nil
+}
+
+var _ pathdb.Transaction = (*transaction)(nil)
+
+type transaction struct {
+	*executor
+	backend *executor
+}
+
+func (tx *transaction) Commit() error {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	if tx.state == nil {
+		return serrors.New("Transaction already committed or rolled back")
+	}
+	tx.backend.mtx.Lock()
+	defer tx.backend.mtx.Unlock()
+	if tx.backend.state == nil {
+		return serrors.New("No database open")
+	}
+	for _, op := range tx.journal {
+		op(tx.backend.state)
+	}
+	tx.state, tx.journal = nil, nil
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	if tx.state == nil {
+		return serrors.New("Transaction already committed or rolled back")
+	}
+	tx.state, tx.journal = nil, nil
+	return nil
+}
+
+var _ pathdb.ReadWrite = (*executor)(nil)
+
+type executor struct {
+	mtx   sync.RWMutex
+	state *state
+	// journal records the write operations of a transaction, such that they
+	// can be applied to the backend on commit. It is nil outside of
+	// transactions.
+	journal []func(*state)
+}
+
+// read executes op on the current state.
+func (e *executor) read(ctx context.Context, op func(*state)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	if e.state == nil {
+		return serrors.New("No database open")
+	}
+	op(e.state)
+	return nil
+}
+
+// write executes op on the current state and records it in the journal, if
+// the executor belongs to a transaction.
+func (e *executor) write(ctx context.Context, op func(*state)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.state == nil {
+		return serrors.New("No database open")
+	}
+	op(e.state)
+	if e.journal != nil {
+		e.journal = append(e.journal, op)
+	}
+	return nil
+}
+
+func (e *executor) Insert(ctx context.Context, segMeta *seg.Meta) (pathdb.InsertStats, error) {
+	return e.InsertWithHPCfgIDs(ctx, segMeta, []*query.HPCfgID{&query.NullHpCfgID})
+}
+
+func (e *executor) InsertWithHPCfgIDs(ctx context.Context, segMeta *seg.Meta,
+	hpCfgIDs []*query.HPCfgID) (pathdb.InsertStats, error) {
+
+	var stats pathdb.InsertStats
+	err := e.write(ctx, func(s *state) {
+		stats = s.insert(segMeta, hpCfgIDs)
+	})
+	return stats, err
+}
+
+func (e *executor) Delete(ctx context.Context, params *query.Params) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) {
+		deleted = s.delete(func(entry *segEntry) bool {
+			_, ok := entry.match(params)
+			return ok
+		})
+	})
+	return deleted, err
+}
+
+func (e *executor) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) {
+		deleted = s.delete(func(entry *segEntry) bool {
+			return entry.seg.MaxExpiry().Unix() < now.Unix()
+		})
+	})
+	return deleted, err
+}
+
+func (e *executor) Get(ctx context.Context, params *query.Params) (query.Results, error) {
+	var res query.Results
+	err := e.read(ctx, func(s *state) {
+		res = s.get(params)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (e *executor) GetAll(ctx context.Context) (<-chan query.ResultOrErr, error) {
+	// Since we have everything in memory anyway we just fill the channel at the start.
+	res, err := e.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	resCh := make(chan query.ResultOrErr, len(res))
+	for _, r := range res {
+		resCh <- query.ResultOrErr{Result: r}
+	}
+	close(resCh)
+	return resCh, nil
+}
+
+func (e *executor) InsertNextQuery(ctx context.Context, src, dst addr.IA, policy pathdb.PolicyHash,
+	nextQuery time.Time) (bool, error) {
+
+	var updated bool
+	err := e.write(ctx, func(s *state) {
+		key := newNQKey(src, dst, policy)
+		if existing, ok := s.nextQueries[key]; ok && !nextQuery.After(existing) {
+			updated = false
+			return
+		}
+		s.nextQueries[key] = nextQuery
+		updated = true
+	})
+	return updated, err
+}
+
+func (e *executor) GetNextQuery(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (time.Time, error) {
+
+	var nextQuery time.Time
+	err := e.read(ctx, func(s *state) {
+		nextQuery = s.nextQueries[newNQKey(src, dst, policy)]
+	})
+	return nextQuery, err
+}
+
+func (e *executor) DeleteExpiredNQ(ctx context.Context, now time.Time) (int, error) {
+	return e.deleteNQ(ctx, func(_ nqKey, nextQuery time.Time) bool {
+		return nextQuery.Before(now)
+	})
+}
+
+func (e *executor) DeleteNQ(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (int, error) {
+
+	return e.deleteNQ(ctx, func(key nqKey, _ time.Time) bool {
+		return (src.IsZero() || key.src.Equal(src)) &&
+			(dst.IsZero() || key.dst.Equal(dst)) &&
+			(policy == nil || key.policy == string(policy))
+	})
+}
+
+func (e *executor) deleteNQ(ctx context.Context,
+	pred func(nqKey, time.Time) bool) (int, error) {
+
+	var deleted int
+	err := e.write(ctx, func(s *state) {
+		deleted = 0
+		for key, nextQuery := range s.nextQueries {
+			if pred(key, nextQuery) {
+				delete(s.nextQueries, key)
+				deleted++
+			}
+		}
+	})
+	return deleted, err
+}
+
+type nqKey struct {
+	src    addr.IA
+	dst    addr.IA
+	policy string
+}
+
+func newNQKey(src, dst addr.IA, policy pathdb.PolicyHash) nqKey {
+	if policy == nil {
+		policy = pathdb.NoPolicy
+	}
+	return nqKey{src: src, dst: dst, policy: string(policy)}
+}
+
+// state is the content of the database.
+type state struct {
+	// nextID is the insertion counter, it is used to order segments that have
+	// been updated at the same time.
+	nextID      uint64
+	segs        map[string]*segEntry
+	nextQueries map[nqKey]time.Time
+}
+
+func newState() *state {
+	return &state{
+		segs:        make(map[string]*segEntry),
+		nextQueries: make(map[nqKey]time.Time),
+	}
+}
+
+func (s *state) clone() *state {
+	c := &state{
+		nextID:      s.nextID,
+		segs:        make(map[string]*segEntry, len(s.segs)),
+		nextQueries: make(map[nqKey]time.Time, len(s.nextQueries)),
+	}
+	for k, entry := range s.segs {
+		c.segs[k] = entry.clone()
+	}
+	for k, nextQuery := range s.nextQueries {
+		c.nextQueries[k] = nextQuery
+	}
+	return c
+}
+
+func (s *state) insert(segMeta *seg.Meta, hpCfgIDs []*query.HPCfgID) pathdb.InsertStats {
+	pseg := segMeta.Segment
+	key := string(pseg.ID())
+	entry, ok := s.segs[key]
+	if ok {
+		// Only update if the new segment is more recent.
+		if !pseg.Timestamp().After(entry.seg.Timestamp()) {
+			return pathdb.InsertStats{}
+		}
+		entry.seg = pseg
+		entry.lastUpdated = time.Now()
+		entry.intfs = interfaces(pseg)
+		entry.addType(segMeta.Type)
+		entry.addHPCfgIDs(hpCfgIDs)
+		return pathdb.InsertStats{Updated: 1}
+	}
+	entry = &segEntry{
+		id:          s.nextID,
+		seg:         pseg,
+		lastUpdated: time.Now(),
+		intfs:       interfaces(pseg),
+	}
+	s.nextID++
+	entry.addType(segMeta.Type)
+	entry.addHPCfgIDs(hpCfgIDs)
+	s.segs[key] = entry
+	return pathdb.InsertStats{Inserted: 1}
+}
+
+func (s *state) delete(pred func(*segEntry) bool) int {
+	deleted := 0
+	for key, entry := range s.segs {
+		if pred(entry) {
+			delete(s.segs, key)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// get returns the results matching the params, ordered by the time of the last
+// update.
+func (s *state) get(params *query.Params) query.Results {
+	var entries []*segEntry
+	var res query.Results
+	for _, entry := range s.segs {
+		if r, ok := entry.match(params); ok {
+			entries = append(entries, entry)
+			res = append(res, r)
+		}
+	}
+	sort.Sort(byLastUpdate{entries: entries, res: res})
+	return res
+}
+
+// segEntry is a path segment with its associated information.
+type segEntry struct {
+	id          uint64
+	seg         *seg.PathSegment
+	lastUpdated time.Time
+	types       []proto.PathSegType
+	hpCfgIDs    []query.HPCfgID
+	intfs       []query.IntfSpec
+}
+
+func (e *segEntry) clone() *segEntry {
+	c := *e
+	c.types = append([]proto.PathSegType(nil), e.types...)
+	c.hpCfgIDs = append([]query.HPCfgID(nil), e.hpCfgIDs...)
+	// The interfaces are replaced, never modified, on update.
+	return &c
+}
+
+func (e *segEntry) addType(segType proto.PathSegType) {
+	for _, t := range e.types {
+		if t == segType {
+			return
+		}
+	}
+	e.types = append(e.types, segType)
+}
+
+func (e *segEntry) addHPCfgIDs(hpCfgIDs []*query.HPCfgID) {
+	for _, hpCfgID := range hpCfgIDs {
+		if !e.hasHPCfgID(hpCfgID) {
+			e.hpCfgIDs = append(e.hpCfgIDs, *hpCfgID)
+		}
+	}
+}
+
+func (e *segEntry) hasHPCfgID(hpCfgID *query.HPCfgID) bool {
+	for i := range e.hpCfgIDs {
+		if e.hpCfgIDs[i].Equal(hpCfgID) {
+			return true
+		}
+	}
+	return false
+}
+
+// match checks whether the segment matches the params. If it does, the result
+// contains the segment with the first matching type and all matching hidden
+// path configuration IDs.
+func (e *segEntry) match(params *query.Params) (*query.Result, bool) {
+	if params == nil {
+		params = &query.Params{}
+	}
+	if len(params.SegIDs) > 0 && !e.matchSegIDs(params.SegIDs) {
+		return nil, false
+	}
+	if len(params.Intfs) > 0 && !e.matchIntfs(params.Intfs) {
+		return nil, false
+	}
+	if len(params.StartsAt) > 0 && !matchIA(e.seg.FirstIA(), params.StartsAt) {
+		return nil, false
+	}
+	if len(params.EndsAt) > 0 && !matchIA(e.seg.LastIA(), params.EndsAt) {
+		return nil, false
+	}
+	if params.MinLastUpdate != nil && !e.lastUpdated.After(*params.MinLastUpdate) {
+		return nil, false
+	}
+	segType, ok := e.matchType(params.SegTypes)
+	if !ok {
+		return nil, false
+	}
+	var hpCfgIDs []*query.HPCfgID
+	for i := range e.hpCfgIDs {
+		hpCfgID := e.hpCfgIDs[i]
+		if len(params.HpCfgIDs) == 0 || containsHPCfgID(params.HpCfgIDs, &hpCfgID) {
+			hpCfgIDs = append(hpCfgIDs, &hpCfgID)
+		}
+	}
+	if len(hpCfgIDs) == 0 {
+		return nil, false
+	}
+	return &query.Result{
+		Seg:        e.seg,
+		LastUpdate: e.lastUpdated,
+		HpCfgIDs:   hpCfgIDs,
+		Type:       segType,
+	}, true
+}
+
+func (e *segEntry) matchSegIDs(segIDs []common.RawBytes) bool {
+	id := string(e.seg.ID())
+	for _, segID := range segIDs {
+		if string(segID) == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *segEntry) matchIntfs(intfs []*query.IntfSpec) bool {
+	for _, spec := range intfs {
+		for _, intf := range e.intfs {
+			if intf.IA.Equal(spec.IA) && intf.IfID == spec.IfID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *segEntry) matchType(segTypes []proto.PathSegType) (proto.PathSegType, bool) {
+	for _, t := range e.types {
+		if len(segTypes) == 0 {
+			return t, true
+		}
+		for _, segType := range segTypes {
+			if t == segType {
+				return t, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// matchIA checks whether ia matches any of the given ISD-AS identifiers. An
+// identifier with a zero AS number matches all ASes of the ISD.
+func matchIA(ia addr.IA, ias []addr.IA) bool {
+	for _, other := range ias {
+		if ia.I == other.I && (other.A == 0 || ia.A == other.A) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsHPCfgID(hpCfgIDs []*query.HPCfgID, hpCfgID *query.HPCfgID) bool {
+	for _, other := range hpCfgIDs {
+		if other.Equal(hpCfgID) {
+			return true
+		}
+	}
+	return false
+}
+
+// interfaces returns the interfaces of the segment. These are the ingress
+// interfaces of all hop entries and the egress interface of the first hop
+// entry of each AS entry.
+func interfaces(pseg *seg.PathSegment) []query.IntfSpec {
+	var intfs []query.IntfSpec
+	for _, as := range pseg.ASEntries {
+		ia := as.IA()
+		for idx, hop := range as.HopEntries {
+			hof := hop.HopField
+			if hof.ConsIngress != 0 {
+				intfs = append(intfs, query.IntfSpec{
+					IA:   ia,
+					IfID: common.IFIDType(hof.ConsIngress),
+				})
+			}
+			if idx == 0 && hof.ConsEgress != 0 {
+				intfs = append(intfs, query.IntfSpec{
+					IA:   ia,
+					IfID: common.IFIDType(hof.ConsEgress),
+				})
+			}
+		}
+	}
+	return intfs
+}
+
+// byLastUpdate sorts the results by the time of the last update of the
+// corresponding entries, and by insertion order for equal update times.
+type byLastUpdate struct {
+	entries []*segEntry
+	res     query.Results
+}
+
+func (s byLastUpdate) Len() int { return len(s.entries) }
+
+func (s byLastUpdate) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+	s.res[i], s.res[j] = s.res[j], s.res[i]
+}
+
+func (s byLastUpdate) Less(i, j int) bool {
+	a, b := s.entries[i], s.entries[j]
+	if !a.lastUpdated.Equal(b.lastUpdated) {
+		return a.lastUpdated.Before(b.lastUpdated)
+	}
+	return a.id < b.id
+}
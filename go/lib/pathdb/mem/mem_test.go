@@ -0,0 +1,92 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/pathdbtest"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+)
+
+var _ pathdbtest.TestablePathDB = (*TestPathDB)(nil)
+
+type TestPathDB struct {
+	*Backend
+}
+
+func (b *TestPathDB) Prepare(t *testing.T, _ context.Context) {
+	b.Backend = New()
+}
+
+func TestPathDBSuite(t *testing.T) {
+	tdb := &TestPathDB{}
+	pathdbtest.TestPathDB(t, tdb)
+}
+
+func TestTransactionIsolation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	b := New()
+	pseg1, _ := pathdbtest.AllocPathSegment(t, ctrl, []uint64{0, 5, 2, 3, 6, 3, 1, 0}, 10)
+	pseg2, _ := pathdbtest.AllocPathSegment(t, ctrl, []uint64{0, 4, 2, 3, 5, 3, 1, 0}, 10)
+	hpCfgIDs := []*query.HPCfgID{&query.NullHpCfgID}
+
+	tx, err := b.BeginTransaction(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, pathdb.InsertStats{Inserted: 1},
+		pathdbtest.InsertSeg(t, ctx, tx, pseg1, hpCfgIDs))
+	// Changes outside of the transaction are not visible to it, and the
+	// uncommitted changes of the transaction are not visible outside.
+	assert.Equal(t, pathdb.InsertStats{Inserted: 1},
+		pathdbtest.InsertSeg(t, ctx, b, pseg2, hpCfgIDs))
+	res, err := tx.Get(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, pseg1, res[0].Seg)
+	res, err = b.Get(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, pseg2, res[0].Seg)
+
+	// On commit, both changes are kept.
+	require.NoError(t, tx.Commit())
+	res, err = b.Get(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Error(t, tx.Commit())
+	_, err = tx.Get(ctx, nil)
+	assert.Error(t, err)
+}
+
+func TestClose(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	b := New()
+	require.NoError(t, b.Close())
+	_, err := b.Get(ctx, nil)
+	assert.Error(t, err)
+	_, err = b.BeginTransaction(ctx, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Metric is a numeric property of a path that constraints and orderings
+// operate on.
+type Metric string
+
+const (
+	// Hops is the number of inter-AS links of the path.
+	Hops Metric = "hops"
+	// MTU is the MTU of the path in bytes.
+	MTU Metric = "mtu"
+	// Expiry is the expiration time of the path.
+	Expiry Metric = "expiry"
+	// Latency is the total latency of the path, as announced in the path
+	// metadata.
+	Latency Metric = "latency"
+	// Bandwidth is the bottleneck bandwidth of the path in Kbit/s, as
+	// announced in the path metadata.
+	Bandwidth Metric = "bandwidth"
+)
+
+func (m Metric) validate() error {
+	switch m {
+	case Hops, MTU, Expiry, Latency, Bandwidth:
+		return nil
+	default:
+		return common.NewBasicError("Unknown metric", nil, "metric", string(m))
+	}
+}
+
+// value returns the value of the metric for the path. If the path does not
+// provide the value, ok is false. Hop counts are always known, all other
+// metrics require the path to implement MetadataPath.
+func (m Metric) value(path Path) (int64, bool) {
+	if m == Hops {
+		return int64(len(path.Interfaces()) / 2), true
+	}
+	mp, ok := path.(MetadataPath)
+	if !ok {
+		return 0, false
+	}
+	switch m {
+	case MTU:
+		mtu := mp.MTU()
+		return int64(mtu), mtu != 0
+	case Expiry:
+		expiry := mp.Expiry()
+		return expiry.UnixNano(), !expiry.IsZero()
+	case Latency:
+		meta := mp.Metadata()
+		if meta == nil || meta.Latency <= 0 {
+			return 0, false
+		}
+		return int64(meta.Latency), true
+	case Bandwidth:
+		meta := mp.Metadata()
+		if meta == nil || meta.Bandwidth == 0 {
+			return 0, false
+		}
+		return int64(meta.Bandwidth), true
+	}
+	return 0, false
+}
+
+// Constraints are numeric requirements on paths. Unset fields are not
+// checked. A path for which a constrained value is unknown, e.g., because the
+// path carries no latency metadata, does not satisfy the constraint.
+type Constraints struct {
+	// MaxHops is the maximum number of inter-AS links of the path.
+	MaxHops int `json:"max_hops,omitempty"`
+	// MinMTU is the minimum MTU of the path in bytes.
+	MinMTU uint16 `json:"min_mtu,omitempty"`
+	// MinExpiry is the minimum remaining lifetime of the path.
+	MinExpiry *util.DurWrap `json:"min_expiry,omitempty"`
+	// MaxLatency is the maximum total latency of the path.
+	MaxLatency *util.DurWrap `json:"max_latency,omitempty"`
+	// MinBandwidth is the minimum bottleneck bandwidth of the path in Kbit/s.
+	MinBandwidth uint32 `json:"min_bandwidth_kbps,omitempty"`
+}
+
+// Eval returns the set of paths that satisfy the constraints.
+func (c *Constraints) Eval(inputSet PathSet) PathSet {
+	if c == nil {
+		return inputSet
+	}
+	now := time.Now()
+	resultSet := make(PathSet)
+	for key, path := range inputSet {
+		if c.evalPath(path, now) {
+			resultSet[key] = path
+		}
+	}
+	return resultSet
+}
+
+func (c *Constraints) evalPath(path Path, now time.Time) bool {
+	if c.MaxHops > 0 && !satisfies(path, Hops, func(v int64) bool {
+		return v <= int64(c.MaxHops)
+	}) {
+		return false
+	}
+	if c.MinMTU > 0 && !satisfies(path, MTU, func(v int64) bool {
+		return v >= int64(c.MinMTU)
+	}) {
+		return false
+	}
+	if c.MinExpiry != nil && !satisfies(path, Expiry, func(v int64) bool {
+		return v >= now.Add(c.MinExpiry.Duration).UnixNano()
+	}) {
+		return false
+	}
+	if c.MaxLatency != nil && !satisfies(path, Latency, func(v int64) bool {
+		return v <= int64(c.MaxLatency.Duration)
+	}) {
+		return false
+	}
+	if c.MinBandwidth > 0 && !satisfies(path, Bandwidth, func(v int64) bool {
+		return v >= int64(c.MinBandwidth)
+	}) {
+		return false
+	}
+	return true
+}
+
+func satisfies(path Path, m Metric, pred func(int64) bool) bool {
+	v, ok := m.value(path)
+	return ok && pred(v)
+}
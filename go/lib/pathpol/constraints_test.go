@@ -0,0 +1,141 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestConstraintsEval(t *testing.T) {
+	now := time.Now()
+	short := newMetaPath("short", 1, 1400, now.Add(time.Hour),
+		&snet.PathMetadata{Latency: 10 * time.Millisecond, Bandwidth: 1000})
+	long := newMetaPath("long", 3, 1472, now.Add(10*time.Minute),
+		&snet.PathMetadata{Latency: 50 * time.Millisecond, Bandwidth: 100000})
+	unknown := newMetaPath("unknown", 2, 0, time.Time{}, nil)
+	plain := &testPath{interfaces: make([]snet.PathInterface, 2), key: "plain"}
+	paths := PathSet{
+		short.key:   short,
+		long.key:    long,
+		unknown.key: unknown,
+		plain.key:   plain,
+	}
+
+	tests := map[string]struct {
+		Constraints *Constraints
+		Expected    []snet.PathFingerprint
+	}{
+		"nil": {
+			Expected: []snet.PathFingerprint{"short", "long", "unknown", "plain"},
+		},
+		"empty": {
+			Constraints: &Constraints{},
+			Expected:    []snet.PathFingerprint{"short", "long", "unknown", "plain"},
+		},
+		"max hops": {
+			Constraints: &Constraints{MaxHops: 2},
+			Expected:    []snet.PathFingerprint{"short", "unknown", "plain"},
+		},
+		"min mtu": {
+			Constraints: &Constraints{MinMTU: 1450},
+			Expected:    []snet.PathFingerprint{"long"},
+		},
+		"min expiry": {
+			Constraints: &Constraints{MinExpiry: &util.DurWrap{Duration: 30 * time.Minute}},
+			Expected:    []snet.PathFingerprint{"short"},
+		},
+		"max latency": {
+			Constraints: &Constraints{
+				MaxLatency: &util.DurWrap{Duration: 20 * time.Millisecond},
+			},
+			Expected: []snet.PathFingerprint{"short"},
+		},
+		"min bandwidth": {
+			Constraints: &Constraints{MinBandwidth: 100000},
+			Expected:    []snet.PathFingerprint{"long"},
+		},
+		"combined": {
+			Constraints: &Constraints{MaxHops: 3, MinBandwidth: 1000, MinMTU: 1450},
+			Expected:    []snet.PathFingerprint{"long"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := test.Constraints.Eval(paths)
+			var keys []snet.PathFingerprint
+			for key := range result {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, test.Expected, keys)
+		})
+	}
+}
+
+func TestConstraintsJSON(t *testing.T) {
+	raw := []byte(`{"max_hops": 4, "min_mtu": 1280, "min_expiry": "10m",` +
+		` "max_latency": "100ms", "min_bandwidth_kbps": 100000}`)
+	var c Constraints
+	require.NoError(t, json.Unmarshal(raw, &c))
+	expected := Constraints{
+		MaxHops:      4,
+		MinMTU:       1280,
+		MinExpiry:    &util.DurWrap{Duration: 10 * time.Minute},
+		MaxLatency:   &util.DurWrap{Duration: 100 * time.Millisecond},
+		MinBandwidth: 100000,
+	}
+	assert.Equal(t, expected, c)
+
+	marshaled, err := json.Marshal(c)
+	require.NoError(t, err)
+	var roundTrip Constraints
+	require.NoError(t, json.Unmarshal(marshaled, &roundTrip))
+	assert.Equal(t, expected, roundTrip)
+}
+
+type metaPath struct {
+	testPath
+	mtu    uint16
+	expiry time.Time
+	meta   *snet.PathMetadata
+}
+
+// newMetaPath creates a path with the given number of inter-AS links.
+func newMetaPath(key string, hops int, mtu uint16, expiry time.Time,
+	meta *snet.PathMetadata) *metaPath {
+
+	intfs := make([]snet.PathInterface, 0, 2*hops)
+	for i := 0; i < 2*hops; i++ {
+		intfs = append(intfs, testPathIntf{ifid: common.IFIDType(i + 1)})
+	}
+	return &metaPath{
+		testPath: testPath{interfaces: intfs, key: snet.PathFingerprint(key)},
+		mtu:      mtu,
+		expiry:   expiry,
+		meta:     meta,
+	}
+}
+
+func (p *metaPath) MTU() uint16                  { return p.mtu }
+func (p *metaPath) Expiry() time.Time            { return p.expiry }
+func (p *metaPath) Metadata() *snet.PathMetadata { return p.meta }
@@ -107,6 +107,29 @@ func (hp *HopPredicate) pathIFMatch(pi snet.PathInterface, in bool) bool {
 	return true
 }
 
+// MatchesHop returns true if the HopPredicate matches the hop through the AS
+// ia that enters on the ingress and leaves on the egress interface. A
+// predicate with a single interface ID matches if either interface matches.
+// Interface ID 0 indicates that the interface is unknown, and it is only
+// matched by the wildcard interface.
+func (hp *HopPredicate) MatchesHop(ia addr.IA, ingress, egress common.IFIDType) bool {
+	if hp.ISD != 0 && ia.I != hp.ISD {
+		return false
+	}
+	if hp.AS != 0 && ia.A != hp.AS {
+		return false
+	}
+	switch len(hp.IfIDs) {
+	case 0:
+		return true
+	case 1:
+		return hp.IfIDs[0] == 0 || hp.IfIDs[0] == ingress || hp.IfIDs[0] == egress
+	default:
+		return (hp.IfIDs[0] == 0 || hp.IfIDs[0] == ingress) &&
+			(hp.IfIDs[1] == 0 || hp.IfIDs[1] == egress)
+	}
+}
+
 func (hp *HopPredicate) matchesAll() bool {
 	if hp == nil {
 		return true
@@ -138,6 +161,17 @@ func (hp *HopPredicate) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// UnmarshalText parses the hop predicate from its string representation. This
+// allows hop predicates to be used in YAML files.
+func (hp *HopPredicate) UnmarshalText(text []byte) error {
+	nhp, err := HopPredicateFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*hp = *nhp
+	return nil
+}
+
 func parseIfID(str string) (common.IFIDType, error) {
 	ifid, err := strconv.ParseUint(str, 10, 64)
 	if err != nil {
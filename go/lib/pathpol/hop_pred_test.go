@@ -20,8 +20,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 func TestNewHopPredicate(t *testing.T) {
@@ -161,3 +163,44 @@ func TestJsonConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesHop(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	tests := map[string]struct {
+		HP      string
+		Ingress common.IFIDType
+		Egress  common.IFIDType
+		Match   bool
+	}{
+		"any":                   {HP: "0", Ingress: 1, Egress: 2, Match: true},
+		"ISD":                   {HP: "1", Ingress: 1, Egress: 2, Match: true},
+		"other ISD":             {HP: "2", Ingress: 1, Egress: 2, Match: false},
+		"IA":                    {HP: "1-ff00:0:110", Ingress: 1, Egress: 2, Match: true},
+		"other AS":              {HP: "1-ff00:0:111", Ingress: 1, Egress: 2, Match: false},
+		"wildcard ISD":          {HP: "0-ff00:0:110", Ingress: 1, Egress: 2, Match: true},
+		"single IF ingress":     {HP: "1-ff00:0:110#1", Ingress: 1, Egress: 2, Match: true},
+		"single IF egress":      {HP: "1-ff00:0:110#2", Ingress: 1, Egress: 2, Match: true},
+		"single IF mismatch":    {HP: "1-ff00:0:110#3", Ingress: 1, Egress: 2, Match: false},
+		"ingress and egress":    {HP: "1-ff00:0:110#1,2", Ingress: 1, Egress: 2, Match: true},
+		"swapped interfaces":    {HP: "1-ff00:0:110#2,1", Ingress: 1, Egress: 2, Match: false},
+		"ingress only":          {HP: "1-ff00:0:110#1,0", Ingress: 1, Egress: 2, Match: true},
+		"egress only":           {HP: "1-ff00:0:110#0,2", Ingress: 1, Egress: 2, Match: true},
+		"egress only mismatch":  {HP: "1-ff00:0:110#0,1", Ingress: 1, Egress: 2, Match: false},
+		"unknown interface":     {HP: "1-ff00:0:110#1", Ingress: 0, Egress: 0, Match: false},
+		"unknown with wildcard": {HP: "1-ff00:0:110#0", Ingress: 0, Egress: 0, Match: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hp, err := HopPredicateFromString(test.HP)
+			require.NoError(t, err)
+			assert.Equal(t, test.Match, hp.MatchesHop(ia, test.Ingress, test.Egress))
+		})
+	}
+}
+
+func TestHopPredicateUnmarshalText(t *testing.T) {
+	var hp HopPredicate
+	require.NoError(t, hp.UnmarshalText([]byte("1-ff00:0:110#1,2")))
+	assert.Equal(t, "1-ff00:0:110#1,2", hp.String())
+	assert.Error(t, hp.UnmarshalText([]byte("1-ff00:0:110#1,2,3")))
+}
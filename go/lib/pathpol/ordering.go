@@ -0,0 +1,122 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+const (
+	ascSymbol  = "asc"
+	descSymbol = "desc"
+)
+
+// Ordering ranks paths by a list of keys. The first key has the highest
+// precedence, later keys are only consulted to break ties.
+type Ordering []OrderKey
+
+// Sort sorts the paths according to the ordering. Paths for which the value
+// of a key is unknown are ranked after all paths for which it is known. Paths
+// that are equal with regard to all keys are sorted by fingerprint, such that
+// the result is deterministic.
+func (o Ordering) Sort(paths []Path) {
+	sort.Slice(paths, func(i, j int) bool {
+		for _, key := range o {
+			if c := key.compare(paths[i], paths[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return paths[i].Fingerprint() < paths[j].Fingerprint()
+	})
+}
+
+// OrderKey is a single key of an ordering. It is encoded as the name of the
+// metric, optionally followed by the direction, e.g., "latency asc" or
+// "bandwidth desc". The default direction is ascending.
+type OrderKey struct {
+	Metric     Metric
+	Descending bool
+}
+
+// compare returns a negative number if a is ranked before b, a positive number
+// if b is ranked before a, and zero if they are equal.
+func (k OrderKey) compare(a, b Path) int {
+	va, okA := k.Metric.value(a)
+	vb, okB := k.Metric.value(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	}
+	c := 0
+	if va < vb {
+		c = -1
+	} else if va > vb {
+		c = 1
+	}
+	if k.Descending {
+		return -c
+	}
+	return c
+}
+
+func (k *OrderKey) LoadFromString(str string) error {
+	parts := strings.Fields(str)
+	if len(parts) == 0 || len(parts) > 2 {
+		return common.NewBasicError("Invalid order key", nil, "str", str)
+	}
+	metric := Metric(parts[0])
+	if err := metric.validate(); err != nil {
+		return err
+	}
+	k.Metric = metric
+	k.Descending = false
+	if len(parts) == 2 {
+		switch parts[1] {
+		case ascSymbol:
+		case descSymbol:
+			k.Descending = true
+		default:
+			return common.NewBasicError("Invalid order direction", nil, "str", str)
+		}
+	}
+	return nil
+}
+
+func (k OrderKey) String() string {
+	if k.Descending {
+		return string(k.Metric) + " " + descSymbol
+	}
+	return string(k.Metric) + " " + ascSymbol
+}
+
+func (k OrderKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+func (k *OrderKey) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	return k.LoadFromString(str)
+}
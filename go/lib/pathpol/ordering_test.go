@@ -0,0 +1,127 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+func TestOrderingSort(t *testing.T) {
+	now := time.Now()
+	a := newMetaPath("a", 1, 1400, now.Add(time.Hour),
+		&snet.PathMetadata{Latency: 30 * time.Millisecond, Bandwidth: 1000})
+	b := newMetaPath("b", 3, 1472, now.Add(10*time.Minute),
+		&snet.PathMetadata{Latency: 10 * time.Millisecond, Bandwidth: 100000})
+	c := newMetaPath("c", 1, 1472, now.Add(30*time.Minute), nil)
+	d := newMetaPath("d", 2, 1280, now.Add(30*time.Minute),
+		&snet.PathMetadata{Latency: 10 * time.Millisecond, Bandwidth: 1000})
+
+	tests := map[string]struct {
+		Ordering Ordering
+		Expected []snet.PathFingerprint
+	}{
+		"no ordering sorts by fingerprint": {
+			Expected: []snet.PathFingerprint{"a", "b", "c", "d"},
+		},
+		"latency ascending, unknown last": {
+			Ordering: Ordering{{Metric: Latency}},
+			Expected: []snet.PathFingerprint{"b", "d", "a", "c"},
+		},
+		"bandwidth descending, unknown last": {
+			Ordering: Ordering{{Metric: Bandwidth, Descending: true}},
+			Expected: []snet.PathFingerprint{"b", "a", "d", "c"},
+		},
+		"hops then mtu descending": {
+			Ordering: Ordering{{Metric: Hops}, {Metric: MTU, Descending: true}},
+			Expected: []snet.PathFingerprint{"c", "a", "d", "b"},
+		},
+		"expiry descending": {
+			Ordering: Ordering{{Metric: Expiry, Descending: true}},
+			Expected: []snet.PathFingerprint{"a", "c", "d", "b"},
+		},
+		"latency then hops": {
+			Ordering: Ordering{{Metric: Latency}, {Metric: Hops}},
+			Expected: []snet.PathFingerprint{"d", "b", "a", "c"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			paths := []Path{d, c, b, a}
+			test.Ordering.Sort(paths)
+			var keys []snet.PathFingerprint
+			for _, path := range paths {
+				keys = append(keys, path.Fingerprint())
+			}
+			assert.Equal(t, test.Expected, keys)
+		})
+	}
+}
+
+func TestOrderKeyJSON(t *testing.T) {
+	tests := map[string]struct {
+		Input     string
+		Expected  Ordering
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"default direction": {
+			Input:     `["latency"]`,
+			Expected:  Ordering{{Metric: Latency}},
+			Assertion: assert.NoError,
+		},
+		"explicit directions": {
+			Input: `["bandwidth desc", "hops asc"]`,
+			Expected: Ordering{
+				{Metric: Bandwidth, Descending: true},
+				{Metric: Hops},
+			},
+			Assertion: assert.NoError,
+		},
+		"unknown metric": {
+			Input:     `["jitter"]`,
+			Assertion: assert.Error,
+		},
+		"unknown direction": {
+			Input:     `["mtu up"]`,
+			Assertion: assert.Error,
+		},
+		"too many parts": {
+			Input:     `["mtu asc desc"]`,
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var o Ordering
+			err := json.Unmarshal([]byte(test.Input), &o)
+			test.Assertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.Expected, o)
+			raw, err := json.Marshal(o)
+			require.NoError(t, err)
+			var roundTrip Ordering
+			require.NoError(t, json.Unmarshal(raw, &roundTrip))
+			assert.Equal(t, o, roundTrip)
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package pathpol
 
 import (
+	"time"
+
 	"github.com/scionproto/scion/go/lib/snet"
 )
 
@@ -28,3 +30,17 @@ type Path interface {
 	// Returns a string that uniquely identifies this path.
 	Fingerprint() snet.PathFingerprint
 }
+
+// MetadataPath is a path that additionally exposes the properties that
+// constraints and orderings are evaluated on. snet.Path implements this
+// interface.
+type MetadataPath interface {
+	Path
+	// MTU returns the MTU of the path. Zero indicates that the MTU is unknown.
+	MTU() uint16
+	// Expiry returns the expiration time of the path. The zero value indicates
+	// that the expiration time is unknown.
+	Expiry() time.Time
+	// Metadata returns the metadata of the path, if any.
+	Metadata() *snet.PathMetadata
+}
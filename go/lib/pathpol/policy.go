@@ -14,9 +14,11 @@
 // limitations under the License.
 
 // Package pathpol implements path policies, documentation in doc/PathPolicy.md
-// Currently implemented: ACL, Sequence, Extends and Options.
+// Currently implemented: ACL, Sequence, Constraints, Ordering, Extends and Options.
 //
-// A policy has an Act() method that takes an AppPathSet and returns a filtered AppPathSet
+// A policy has a Filter() method that takes a PathSet and returns a filtered
+// PathSet, and a Rank() method that additionally orders the remaining paths
+// according to the Ordering of the policy.
 package pathpol
 
 import (
@@ -40,14 +42,19 @@ type PolicyMap map[string]*ExtPolicy
 type FilterOptions struct {
 	// IgnoreSequence can be used to ignore the sequence part of policies.
 	IgnoreSequence bool
+	// IgnoreConstraints can be used to ignore the constraints part of
+	// policies.
+	IgnoreConstraints bool
 }
 
 // Policy is a compiled path policy object, all extended policies have been merged.
 type Policy struct {
-	Name     string    `json:"-"`
-	ACL      *ACL      `json:"acl,omitempty"`
-	Sequence *Sequence `json:"sequence,omitempty"`
-	Options  []Option  `json:"options,omitempty"`
+	Name        string       `json:"-"`
+	ACL         *ACL         `json:"acl,omitempty"`
+	Sequence    *Sequence    `json:"sequence,omitempty"`
+	Constraints *Constraints `json:"constraints,omitempty"`
+	Ordering    Ordering     `json:"ordering,omitempty"`
+	Options     []Option     `json:"options,omitempty"`
 }
 
 // NewPolicy creates a Policy and sorts its Options
@@ -72,6 +79,9 @@ func (p *Policy) FilterOpt(paths PathSet, opts FilterOptions) PathSet {
 		return paths
 	}
 	resultSet := p.ACL.Eval(paths)
+	if !opts.IgnoreConstraints {
+		resultSet = p.Constraints.Eval(resultSet)
+	}
 	if p.Sequence != nil && !opts.IgnoreSequence {
 		resultSet = p.Sequence.Eval(resultSet)
 	}
@@ -82,6 +92,24 @@ func (p *Policy) FilterOpt(paths PathSet, opts FilterOptions) PathSet {
 	return resultSet
 }
 
+// Rank filters the path set according to the policy and returns the
+// remaining paths ordered by the Ordering of the policy, most preferred path
+// first. The orderings of sub policies in Options are not considered. A nil
+// policy ranks all paths by fingerprint.
+func (p *Policy) Rank(paths PathSet) []Path {
+	resultSet := p.Filter(paths)
+	ranked := make([]Path, 0, len(resultSet))
+	for _, path := range resultSet {
+		ranked = append(ranked, path)
+	}
+	var ordering Ordering
+	if p != nil {
+		ordering = p.Ordering
+	}
+	ordering.Sort(ranked)
+	return ranked
+}
+
 // PolicyFromExtPolicy creates a Policy from an extending Policy and the extended policies
 func PolicyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy) (*Policy, error) {
 	policy := extPolicy.Policy
@@ -127,6 +155,14 @@ func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error
 		if p.Sequence == nil {
 			p.Sequence = policy.Sequence
 		}
+		// Replace Constraints
+		if p.Constraints == nil {
+			p.Constraints = policy.Constraints
+		}
+		// Replace Ordering
+		if len(p.Ordering) == 0 {
+			p.Ordering = policy.Ordering
+		}
 	}
 	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/lib/xtest/graph"
 )
@@ -515,6 +517,33 @@ func TestExtends(t *testing.T) {
 			ExtendedPolicy: &Policy{
 				Sequence: newSequence(t, "1-ff00:0:133#1010 1-ff00:0:132#1910")},
 		},
+		"constraints and ordering of extended policies": {
+			Policy: &ExtPolicy{
+				Policy: &Policy{
+					Ordering: Ordering{{Metric: Hops}},
+				},
+				Extends: []string{"policy1", "policy2"},
+			},
+			Extended: []*ExtPolicy{
+				{
+					Policy: &Policy{
+						Name:        "policy1",
+						Constraints: &Constraints{MinMTU: 1280},
+						Ordering:    Ordering{{Metric: Latency}},
+					},
+				},
+				{
+					Policy: &Policy{
+						Name:        "policy2",
+						Constraints: &Constraints{MaxHops: 5},
+					},
+				},
+			},
+			ExtendedPolicy: &Policy{
+				Constraints: &Constraints{MaxHops: 5},
+				Ordering:    Ordering{{Metric: Hops}},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -586,6 +615,59 @@ func TestFilterOpt(t *testing.T) {
 	}
 }
 
+func TestRank(t *testing.T) {
+	now := time.Now()
+	fast := newMetaPath("fast", 3, 1472, now.Add(time.Hour),
+		&snet.PathMetadata{Latency: 10 * time.Millisecond})
+	slow := newMetaPath("slow", 1, 1472, now.Add(time.Hour),
+		&snet.PathMetadata{Latency: 80 * time.Millisecond})
+	small := newMetaPath("small", 1, 1000, now.Add(time.Hour),
+		&snet.PathMetadata{Latency: 5 * time.Millisecond})
+	paths := PathSet{fast.key: fast, slow.key: slow, small.key: small}
+
+	tests := map[string]struct {
+		Policy   *Policy
+		Expected []snet.PathFingerprint
+	}{
+		"nil policy": {
+			Expected: []snet.PathFingerprint{"fast", "slow", "small"},
+		},
+		"ordering": {
+			Policy:   &Policy{Ordering: Ordering{{Metric: Latency}}},
+			Expected: []snet.PathFingerprint{"small", "fast", "slow"},
+		},
+		"constraints and ordering": {
+			Policy: &Policy{
+				Constraints: &Constraints{MinMTU: 1280},
+				Ordering:    Ordering{{Metric: Latency}},
+			},
+			Expected: []snet.PathFingerprint{"fast", "slow"},
+		},
+		"constraints in options": {
+			Policy: NewPolicy("", nil, nil, []Option{
+				{
+					Weight: 1,
+					Policy: &ExtPolicy{Policy: &Policy{Constraints: &Constraints{MaxHops: 1}}},
+				},
+				{
+					Weight: 0,
+					Policy: &ExtPolicy{Policy: &Policy{}},
+				},
+			}),
+			Expected: []snet.PathFingerprint{"slow", "small"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var keys []snet.PathFingerprint
+			for _, path := range test.Policy.Rank(paths) {
+				keys = append(keys, path.Fingerprint())
+			}
+			assert.Equal(t, test.Expected, keys)
+		})
+	}
+}
+
 func TestPolicyJsonConversion(t *testing.T) {
 	policy := NewPolicy("", nil, nil, []Option{
 		{
@@ -602,6 +684,11 @@ func TestPolicyJsonConversion(t *testing.T) {
 			Weight: 0,
 		},
 	})
+	policy.Constraints = &Constraints{
+		MaxHops:    4,
+		MaxLatency: &util.DurWrap{Duration: 100 * time.Millisecond},
+	}
+	policy.Ordering = Ordering{{Metric: Bandwidth, Descending: true}, {Metric: Latency}}
 	jsonPol, err := json.Marshal(policy)
 	require.NoError(t, err)
 	var pol Policy
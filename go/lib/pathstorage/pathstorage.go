@@ -23,9 +23,11 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/modules/db"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	mempathdb "github.com/scionproto/scion/go/lib/pathdb/mem"
 	sqlitepathdb "github.com/scionproto/scion/go/lib/pathdb/sqlite"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/revcache/memrevcache"
+	"github.com/scionproto/scion/go/lib/revcache/sqliterevcache"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/util"
 )
@@ -100,7 +102,7 @@ func (cfg *PathDBConf) Validate() error {
 
 func (cfg *PathDBConf) validateBackend() error {
 	switch cfg.Backend() {
-	case BackendSqlite:
+	case BackendSqlite, BackendMem:
 		return nil
 	case BackendNone:
 		return serrors.New("No backend set")
@@ -109,7 +111,7 @@ func (cfg *PathDBConf) validateBackend() error {
 }
 
 func (cfg *PathDBConf) validateConnection() error {
-	if cfg.Connection() == "" {
+	if cfg.Backend() != BackendMem && cfg.Connection() == "" {
 		return serrors.New("Empty connection not allowed")
 	}
 	return nil
@@ -162,8 +164,8 @@ func (cfg *RevCacheConf) Validate() error {
 	return nil
 }
 
-func (cfg *RevCacheConf) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
-	config.WriteString(dst, revSample)
+func (cfg *RevCacheConf) Sample(dst io.Writer, _ config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, fmt.Sprintf(revSample, ctx[config.ID]))
 }
 
 func (cfg *RevCacheConf) ConfigName() string {
@@ -190,17 +192,22 @@ func (cfg *RevCacheConf) validateConnection() error {
 // NewPathStorage creates a PathStorage from the given configs. Periodic
 // cleaners for the given databases have to be manually created and started
 // (see cleaner package).
-func NewPathStorage(pdbConf PathDBConf) (pathdb.PathDB, revcache.RevCache, error) {
+func NewPathStorage(pdbConf PathDBConf,
+	rcConf RevCacheConf) (pathdb.PathDB, revcache.RevCache, error) {
 
 	if err := pdbConf.Validate(); err != nil {
 		return nil, nil, common.NewBasicError("Invalid pathdb config", err)
 	}
+	if err := rcConf.Validate(); err != nil {
+		return nil, nil, common.NewBasicError("Invalid revcache config", err)
+	}
 	pdb, err := newPathDB(pdbConf)
 	if err != nil {
 		return nil, nil, err
 	}
-	rc, err := newRevCache(pdbConf)
+	rc, err := newRevCache(rcConf)
 	if err != nil {
+		pdb.Close()
 		return nil, nil, err
 	}
 	return pdb, rc, nil
@@ -214,6 +221,8 @@ func newPathDB(conf PathDBConf) (pathdb.PathDB, error) {
 	switch conf.Backend() {
 	case BackendSqlite:
 		pdb, err = sqlitepathdb.New(conf.Connection())
+	case BackendMem:
+		pdb = mempathdb.New()
 	case BackendNone:
 		return nil, nil
 	default:
@@ -227,12 +236,23 @@ func newPathDB(conf PathDBConf) (pathdb.PathDB, error) {
 	return pdb, nil
 }
 
-func newRevCache(conf PathDBConf) (revcache.RevCache, error) {
+func newRevCache(conf RevCacheConf) (revcache.RevCache, error) {
+	log.Info("Connecting RevCache", "backend", conf.Backend(), "connection", conf.Connection())
+	var err error
+	var rc revcache.RevCache
+
 	switch conf.Backend() {
 	case BackendSqlite:
-		log.Info("Connecting RevCache", "backend", "memory")
-		return memrevcache.New(), nil
+		rc, err = sqliterevcache.New(conf.Connection())
+	case BackendMem:
+		rc = memrevcache.New()
 	default:
 		return nil, common.NewBasicError("Unsupported backend", nil, "backend", conf.Backend())
 	}
+
+	if err != nil {
+		return nil, err
+	}
+	db.SetConnLimits(&conf, rc)
+	return rc, nil
 }
@@ -49,11 +49,12 @@ func CheckTestPathDBConf(t *testing.T, cfg *pathstorage.PathDBConf, id string) {
 	assert.Equal(t, fmt.Sprintf("/var/lib/scion/pathdb/%s.path.db", id), cfg.Connection())
 }
 
-func CheckTestRevCacheConf(t *testing.T, cfg *pathstorage.RevCacheConf) {
+func CheckTestRevCacheConf(t *testing.T, cfg *pathstorage.RevCacheConf, id string) {
 	util.LowerKeys(*cfg)
 	assert.False(t, isSet(cfg.MaxOpenConns()))
 	assert.False(t, isSet(cfg.MaxIdleConns()))
 	assert.Equal(t, pathstorage.BackendMem, cfg.Backend())
+	assert.Equal(t, fmt.Sprintf("/var/lib/scion/revcache/%s.rev.db", id), cfg.Connection())
 }
 
 func isSet(_ int, set bool) bool {
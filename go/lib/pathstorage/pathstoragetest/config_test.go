@@ -42,5 +42,5 @@ func TestRevCacheConfSample(t *testing.T) {
 	InitTestRevCacheConf(&cfg)
 	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
 	assert.NoError(t, err)
-	CheckTestRevCacheConf(t, &cfg)
+	CheckTestRevCacheConf(t, &cfg, "test")
 }
@@ -15,10 +15,11 @@
 package pathstorage
 
 const pathDbSample = `
-# The type of pathdb backend.
+# The type of pathdb backend. The in-memory backend ("mem") does not persist
+# the paths across restarts. (sqlite|mem, default sqlite)
 backend = "sqlite"
 
-# Path to the path database. (required)
+# Path to the path database. (required for sqlite)
 connection = "/var/lib/scion/pathdb/%s.path.db"
 
 # The maximum number of open connections to the database. In case of the
@@ -31,9 +32,13 @@ max_idle_conns = ""
 `
 
 const revSample = `
-# The type of RevCache backend.
+# The type of RevCache backend. The in-memory backend ("mem") forgets all
+# revocations on restart. (sqlite|mem, default mem)
 backend = "mem"
 
+# Path to the revocation cache database. (required for sqlite)
+connection = "/var/lib/scion/revcache/%s.rev.db"
+
 # The maximum number of open connections to the database. In case of the
 # empty string, the limit is not set and uses the go default. (default "")
 max_open_conns = ""
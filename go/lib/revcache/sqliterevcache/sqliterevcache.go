@@ -0,0 +1,222 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqliterevcache contains an SQLite backend for the revocation cache.
+// In contrast to the in-memory cache, revocations survive a restart of the
+// process.
+package sqliterevcache
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/revcache"
+)
+
+// maxKeysPerQuery limits the number of keys that are looked up in a single
+// query, such that the number of query parameters stays below the SQLite
+// limit.
+const maxKeysPerQuery = 256
+
+var _ revcache.RevCache = (*Backend)(nil)
+
+// Backend is a revocation cache that stores the revocations in an SQLite
+// database. Revocations expire according to the timestamp and TTL of their
+// revocation info.
+type Backend struct {
+	sync.RWMutex
+	db *sql.DB
+}
+
+// New returns a new SQLite backend opening a database at the given path. If
+// no database exists a new database is be created. If the schema version of the
+// stored database is different from the one in schema.go, an error is returned.
+func New(path string) (*Backend, error) {
+	db, err := db.NewSqlite(path, Schema, SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{
+		db: db,
+	}, nil
+}
+
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+func (b *Backend) Get(ctx context.Context, keys revcache.KeySet) (revcache.Revocations, error) {
+	b.RLock()
+	defer b.RUnlock()
+	revs := make(revcache.Revocations, len(keys))
+	batch := make([]revcache.Key, 0, maxKeysPerQuery)
+	for k := range keys {
+		batch = append(batch, k)
+		if len(batch) == maxKeysPerQuery {
+			if err := b.get(ctx, batch, revs); err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := b.get(ctx, batch, revs); err != nil {
+			return nil, err
+		}
+	}
+	return revs, nil
+}
+
+// get looks up the revocations for the given keys and adds them to revs.
+func (b *Backend) get(ctx context.Context, keys []revcache.Key,
+	revs revcache.Revocations) error {
+
+	subQ := make([]string, 0, len(keys))
+	args := []interface{}{time.Now().UnixNano()}
+	for _, k := range keys {
+		subQ = append(subQ, "(IsdID=? AND AsID=? AND IfID=?)")
+		args = append(args, k.IA.I, k.IA.A, k.IfId)
+	}
+	query := fmt.Sprintf("SELECT RawSignedRev FROM Revocations WHERE Expiration>? AND (%s)",
+		strings.Join(subQ, " OR "))
+	rows, err := b.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return db.NewReadError("Error looking up revocations", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		rev, info, err := scanRev(rows)
+		if err != nil {
+			return err
+		}
+		revs[*revcache.NewKey(info.IA(), info.IfID)] = rev
+	}
+	return rows.Err()
+}
+
+func (b *Backend) GetAll(ctx context.Context) (revcache.ResultChan, error) {
+	b.RLock()
+	defer b.RUnlock()
+	query := "SELECT RawSignedRev FROM Revocations WHERE Expiration>?"
+	rows, err := b.db.QueryContext(ctx, query, time.Now().UnixNano())
+	if err != nil {
+		return nil, db.NewReadError("Error looking up revocations", err)
+	}
+	resCh := make(chan revcache.RevOrErr)
+	go func() {
+		defer log.HandlePanic()
+		defer close(resCh)
+		defer rows.Close()
+		for rows.Next() {
+			rev, _, err := scanRev(rows)
+			if err != nil {
+				resCh <- revcache.RevOrErr{Err: err}
+				return
+			}
+			resCh <- revcache.RevOrErr{Rev: rev}
+		}
+		if err := rows.Err(); err != nil {
+			resCh <- revcache.RevOrErr{Err: db.NewReadError("Error reading DB response", err)}
+		}
+	}()
+	return resCh, nil
+}
+
+func (b *Backend) Insert(ctx context.Context, rev *path_mgmt.SignedRevInfo) (bool, error) {
+	b.Lock()
+	defer b.Unlock()
+	info, err := rev.RevInfo()
+	if err != nil {
+		return false, db.NewInputDataError("Failed to parse revocation", err)
+	}
+	now := time.Now()
+	if !info.Expiration().After(now) {
+		return false, nil
+	}
+	packed, err := rev.Pack()
+	if err != nil {
+		return false, db.NewInputDataError("Failed to pack revocation", err)
+	}
+	// Only insert if there is no active revocation for the same interface, or
+	// if the existing one is older.
+	query := `
+		INSERT INTO Revocations (IsdID, AsID, IfID, Timestamp, Expiration, RawSignedRev)
+		SELECT data.* FROM
+		(SELECT ? AS IsdID, ? AS AsID, ? AS IfID, ? AS Ts, ? AS Exp, ? AS Raw) AS data
+		LEFT JOIN Revocations USING (IsdID, AsID, IfID)
+		WHERE Revocations.IsdID IS NULL OR Revocations.Expiration <= ? OR
+			data.Ts > Revocations.Timestamp
+	`
+	ia := info.IA()
+	var res sql.Result
+	err = db.DoInTx(ctx, b.db, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		res, err = tx.ExecContext(ctx, query, ia.I, ia.A, info.IfID,
+			info.Timestamp().UnixNano(), info.Expiration().UnixNano(), packed,
+			now.UnixNano())
+		return err
+	})
+	if err != nil {
+		return false, db.NewWriteError("Failed to insert revocation", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, db.NewWriteError("Failed to insert revocation", err)
+	}
+	return n > 0, nil
+}
+
+func (b *Backend) DeleteExpired(ctx context.Context) (int64, error) {
+	b.Lock()
+	defer b.Unlock()
+	deleted, err := db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Revocations WHERE Expiration <= ?`
+		return tx.ExecContext(ctx, delStmt, time.Now().UnixNano())
+	})
+	return int64(deleted), err
+}
+
+func scanRev(rows *sql.Rows) (*path_mgmt.SignedRevInfo, *path_mgmt.RevInfo, error) {
+	var raw common.RawBytes
+	if err := rows.Scan(&raw); err != nil {
+		return nil, nil, db.NewReadError("Error reading DB response", err)
+	}
+	rev, err := path_mgmt.NewSignedRevInfoFromRaw(raw)
+	if err != nil {
+		return nil, nil, db.NewDataError("Error unmarshalling revocation", err)
+	}
+	info, err := rev.RevInfo()
+	if err != nil {
+		return nil, nil, db.NewDataError("Error parsing revocation info", err)
+	}
+	return rev, info, nil
+}
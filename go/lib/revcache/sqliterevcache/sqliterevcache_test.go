@@ -0,0 +1,104 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqliterevcache
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/revcache/revcachetest"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ (revcachetest.TestableRevCache) = (*testRevCache)(nil)
+
+type testRevCache struct {
+	*Backend
+}
+
+func (c *testRevCache) InsertExpired(t *testing.T, ctx context.Context,
+	rev *path_mgmt.SignedRevInfo) {
+
+	info, err := rev.RevInfo()
+	xtest.FailOnErr(t, err)
+	if info.Expiration().After(time.Now()) {
+		panic("Should only be used for expired elements")
+	}
+	packed, err := rev.Pack()
+	xtest.FailOnErr(t, err)
+	query := `INSERT INTO Revocations
+		(IsdID, AsID, IfID, Timestamp, Expiration, RawSignedRev) VALUES (?, ?, ?, ?, ?, ?)`
+	_, err = c.db.ExecContext(ctx, query, info.IA().I, info.IA().A, info.IfID,
+		info.Timestamp().UnixNano(), info.Expiration().UnixNano(), packed)
+	xtest.FailOnErr(t, err)
+}
+
+func (c *testRevCache) Prepare(t *testing.T, _ context.Context) {
+	db, err := New("file::memory:")
+	xtest.FailOnErr(t, err)
+	c.Backend = db
+}
+
+func TestRevCacheSuite(t *testing.T) {
+	Convey("RevCache Suite", t, func() {
+		revcachetest.TestRevCache(t, &testRevCache{})
+	})
+}
+
+// TestOpenExisting tests that revocations survive reopening the database.
+func TestOpenExisting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "revcache-sqlite")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "rev.db")
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+
+	b, err := New(file)
+	require.NoError(t, err)
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	rev, err := path_mgmt.NewSignedRevInfo(&path_mgmt.RevInfo{
+		IfID:         15,
+		RawIsdas:     ia.IAInt(),
+		LinkType:     proto.LinkType_core,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       10,
+	}, infra.NullSigner)
+	require.NoError(t, err)
+	inserted, err := b.Insert(ctx, rev)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	require.NoError(t, b.Close())
+
+	b, err = New(file)
+	require.NoError(t, err)
+	defer b.Close()
+	revs, err := b.Get(ctx, revcache.SingleKey(ia, 15))
+	require.NoError(t, err)
+	assert.Len(t, revs, 1)
+}
@@ -108,7 +108,7 @@ type Path struct {
 	mtu        uint16
 	expiry     time.Time
 	dst        addr.IA
-	metadata *PathMetadata
+	metadata   *snet.PathMetadata
 }
 
 func pathReplyToPaths(pathReply *PathReply, dst addr.IA) ([]snet.Path, error) {
@@ -152,7 +152,7 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 		spath:      sp,
 		mtu:        pe.Path.Mtu,
 		expiry:     pe.Path.Expiry(),
-		metadata: pe.Path.Metadata,
+		metadata:   pe.Path.Metadata.toSnet(),
 	}
 	for _, intf := range pe.Path.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), id: intf.ID()})
@@ -223,32 +223,18 @@ func (p Path) Copy() snet.Path {
 		spath:      p.Path(),            // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
-		metadata: p.metadata.Copy(),
-	}
-}
-
-func (meta *PathMetadata) Copy() *PathMetadata{
-	if meta == nil {
-		return &PathMetadata{}
-	}
-	return &PathMetadata{
-		Latency: meta.Latency,
-		Hops:    meta.Hops,
-		Bandwidth:  meta.Bandwidth,
-		LinkTypes:    meta.LinkTypes,
-		Geos:    meta.Geos,
-		Notes:        meta.Notes,
+		metadata:   p.metadata.Copy(),
 	}
 }
 
 func (p Path) String() string {
 	hops := p.fmtInterfaces()
-	return fmt.Sprintf("Hops: [%s] MTU: %d, NextHop: %s, Metadata: %v",
-		strings.Join(hops, ">"), p.mtu, p.underlay, p.metadata)
+	return fmt.Sprintf("Hops: [%s] MTU: %d, NextHop: %s",
+		strings.Join(hops, ">"), p.mtu, p.underlay)
 }
 
-func (p Path) Metadata() *PathMetadata {
-	return p.metadata
+func (p Path) Metadata() *snet.PathMetadata {
+	return p.metadata.Copy()
 }
 
 func (p Path) fmtInterfaces() []string {
@@ -0,0 +1,84 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sciond
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPathMetadata(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	entry := PathReplyEntry{
+		Path: &FwdPathMeta{
+			FwdPath: []byte{0x00, 0x00, 0x00, 0x00},
+			Mtu:     1472,
+			Interfaces: []PathInterface{
+				{RawIsdas: ia110.IAInt(), IfID: 1},
+				{RawIsdas: ia111.IAInt(), IfID: 2},
+			},
+			Metadata: &PathMetadata{
+				Latency:   42,
+				Hops:      3,
+				Bandwidth: 1000,
+				LinkTypes: []LinkType{LinkTypeDirect, LinkTypeOpennet},
+				Geos: []*Geo{
+					{
+						RawIA: ia110.IAInt(),
+						RouterLocations: []*GeoLoc{
+							{Latitude: 47.3, Longitude: 8.5, Address: "Zurich"},
+						},
+					},
+				},
+				Notes: []*Note{{Note: "hello", RawIA: ia111.IAInt()}},
+			},
+			HeaderV2: true,
+		},
+		HostInfo: hostinfo.FromUDPAddr(net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041}),
+	}
+	path, err := pathReplyEntryToPath(entry, ia111)
+	require.NoError(t, err)
+	expected := &snet.PathMetadata{
+		Latency:      42 * time.Millisecond,
+		Bandwidth:    1000,
+		InternalHops: 3,
+		LinkTypes:    []snet.LinkType{snet.LinkTypeDirect, snet.LinkTypeOpennet},
+		Geo: map[addr.IA][]snet.GeoCoordinates{
+			ia110: {{Latitude: 47.3, Longitude: 8.5, Address: "Zurich"}},
+		},
+		Notes: map[addr.IA]string{ia111: "hello"},
+	}
+	assert.Equal(t, expected, path.Metadata())
+	assert.Equal(t, expected, path.Copy().Metadata())
+
+	// Modifying the returned metadata does not affect the path.
+	path.Metadata().Notes[ia111] = "modified"
+	assert.Equal(t, expected, path.Metadata())
+
+	entry.Path.Metadata = nil
+	path, err = pathReplyEntryToPath(entry, ia111)
+	require.NoError(t, err)
+	assert.Nil(t, path.Metadata())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -118,6 +119,10 @@ func (p Path) Expiry() time.Time {
 	return p.expirationTime
 }
 
+func (p Path) Metadata() *snet.PathMetadata {
+	return nil
+}
+
 func (p Path) Copy() snet.Path {
 	return &Path{
 		JSONFingerprint: p.JSONFingerprint,
@@ -225,6 +230,12 @@ func (c connector) RevNotification(ctx context.Context,
 	panic("not implemented")
 }
 
+func (c connector) DRKeyLvl2(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	panic("not implemented")
+}
+
 func (c connector) Close(ctx context.Context) error {
 	return nil
 }
@@ -30,6 +30,7 @@ const (
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
 	subsystemRevocation = "revocation"
+	subsystemDRKeyLvl2  = "drkey_lvl2"
 )
 
 // Result values
@@ -65,6 +66,8 @@ var (
 	IFInfos = newIFInfo()
 	// SVCInfos contains metrics for SVC info requests.
 	SVCInfos = newSVCInfo()
+	// DRKeyLvl2s contains metrics for DRKey level 2 key requests.
+	DRKeyLvl2s = newDRKeyLvl2()
 	// Conns contains metrics for connections to SCIOND.
 	Conns = newConn()
 )
@@ -120,3 +123,10 @@ func newIFInfo() Request {
 			"The amount of IF info requests sent.", resultLabel{}),
 	}
 }
+
+func newDRKeyLvl2() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemDRKeyLvl2, "requests_total",
+			"The amount of DRKey level 2 requests sent.", resultLabel{}),
+	}
+}
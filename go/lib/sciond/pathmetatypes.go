@@ -2,19 +2,21 @@ package sciond
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/proto"
 )
 
 // PathMetadata is the condensed form of metadata retaining only the most important values.
 type PathMetadata struct {
-	Latency uint16             `capnp:"totalLatency"`
-	Hops    uint8              `capnp:"totalHops"`
-	Bandwidth  uint32             `capnp:"minimalBandwidth"`
-	LinkTypes    []LinkType `capnp:"linkTypes"`
-	Geos    []*Geo        `capnp:"asLocations"`
-	Notes        []*Note       `capnp:"notes"`
+	Latency   uint16     `capnp:"totalLatency"`
+	Hops      uint8      `capnp:"totalHops"`
+	Bandwidth uint32     `capnp:"minimalBandwidth"`
+	LinkTypes []LinkType `capnp:"linkTypes"`
+	Geos      []*Geo     `capnp:"asLocations"`
+	Notes     []*Note    `capnp:"notes"`
 }
 
 func (s *PathMetadata) ProtoId() proto.ProtoIdType {
@@ -28,6 +30,51 @@ func (s *PathMetadata) String() string {
 		s.Notes)
 }
 
+// toSnet converts the metadata to the representation that is exposed by
+// snet.Path. The latency is transmitted in milliseconds.
+func (s *PathMetadata) toSnet() *snet.PathMetadata {
+	if s == nil {
+		return nil
+	}
+	m := &snet.PathMetadata{
+		Latency:      time.Duration(s.Latency) * time.Millisecond,
+		Bandwidth:    s.Bandwidth,
+		InternalHops: s.Hops,
+	}
+	for _, t := range s.LinkTypes {
+		m.LinkTypes = append(m.LinkTypes, snet.LinkType(t))
+	}
+	for _, geo := range s.Geos {
+		if geo == nil {
+			continue
+		}
+		if m.Geo == nil {
+			m.Geo = make(map[addr.IA][]snet.GeoCoordinates)
+		}
+		ia := geo.RawIA.IA()
+		for _, loc := range geo.RouterLocations {
+			if loc == nil {
+				continue
+			}
+			m.Geo[ia] = append(m.Geo[ia], snet.GeoCoordinates{
+				Latitude:  loc.Latitude,
+				Longitude: loc.Longitude,
+				Address:   loc.Address,
+			})
+		}
+	}
+	for _, note := range s.Notes {
+		if note == nil {
+			continue
+		}
+		if m.Notes == nil {
+			m.Notes = make(map[addr.IA]string)
+		}
+		m.Notes[note.RawIA.IA()] = note.Note
+	}
+	return m
+}
+
 type LinkType uint16
 
 const (
@@ -51,8 +98,8 @@ func (t LinkType) String() string {
 }
 
 type Geo struct {
-	RouterLocations []*GeoLoc `capnp:"routerLocations"`
-	RawIA           addr.IAInt     `capnp:"isdas"`
+	RouterLocations []*GeoLoc  `capnp:"routerLocations"`
+	RawIA           addr.IAInt `capnp:"isdas"`
 }
 
 func (s *Geo) ProtoId() proto.ProtoIdType {
@@ -31,13 +31,16 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	capnp "zombiezen.com/go/capnproto2"
 	"zombiezen.com/go/capnproto2/pogs"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/sciond/internal/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -101,6 +104,8 @@ type Connector interface {
 	RevNotificationFromRaw(ctx context.Context, b []byte) (*RevReply, error)
 	// RevNotification sends a RevocationInfo message to SCIOND.
 	RevNotification(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo) (*RevReply, error)
+	// DRKeyLvl2 requests from SCIOND the level 2 DRKey described by meta, valid at valTime.
+	DRKeyLvl2(ctx context.Context, meta drkey.Lvl2Meta, valTime time.Time) (drkey.Lvl2Key, error)
 	// Close shuts down the connection to a SCIOND server.
 	Close(ctx context.Context) error
 }
@@ -170,9 +175,10 @@ func (c *conn) Paths(ctx context.Context, dst, src addr.IA,
 			TraceId: tracing.IDFromCtx(ctx),
 			Which:   proto.SCIONDMsg_Which_pathReq,
 			PathReq: &PathReq{
-				Dst:   dst.IAInt(),
-				Src:   src.IAInt(),
-				Flags: f,
+				Dst:    dst.IAInt(),
+				Src:    src.IAInt(),
+				HPCfgs: f.HPCfgs(),
+				Flags:  f,
 			},
 		},
 		conn,
@@ -305,6 +311,36 @@ func (c *conn) RevNotification(ctx context.Context,
 	return reply.RevReply, nil
 }
 
+func (c *conn) DRKeyLvl2(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		metrics.DRKeyLvl2s.Inc(errorToPrometheusLabel(err))
+		return drkey.Lvl2Key{}, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	defer conn.Close()
+	reply, err := roundTrip(
+		&Pld{
+			TraceId:      tracing.IDFromCtx(ctx),
+			Which:        proto.SCIONDMsg_Which_drkeyLvl2Req,
+			DRKeyLvl2Req: drkey_mgmt.NewLvl2ReqFromMeta(meta, valTime),
+		},
+		conn,
+	)
+	if err != nil {
+		metrics.DRKeyLvl2s.Inc(errorToPrometheusLabel(err))
+		return drkey.Lvl2Key{}, serrors.WrapStr("[sciond-API] Failed to get DRKeyLvl2", err)
+	}
+	if reply.DRKeyLvl2Rep == nil || len(reply.DRKeyLvl2Rep.DRKey) == 0 {
+		metrics.DRKeyLvl2s.Inc(metrics.ErrNotClassified)
+		return drkey.Lvl2Key{}, serrors.New("[sciond-API] Level 2 key not available",
+			"meta", meta)
+	}
+	metrics.DRKeyLvl2s.Inc(metrics.OkSuccess)
+	return reply.DRKeyLvl2Rep.ToKey(meta), nil
+}
+
 func (c *conn) Close(_ context.Context) error {
 	return nil
 }
@@ -22,7 +22,9 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
 	"github.com/scionproto/scion/go/lib/hostinfo"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/proto"
@@ -74,6 +76,8 @@ type Pld struct {
 	IfInfoReply        *IFInfoReply
 	ServiceInfoRequest *ServiceInfoRequest
 	ServiceInfoReply   *ServiceInfoReply
+	DRKeyLvl2Req       *drkey_mgmt.Lvl2Req `capnp:"drkeyLvl2Req"`
+	DRKeyLvl2Rep       *drkey_mgmt.Lvl2Rep `capnp:"drkeyLvl2Rep"`
 }
 
 func NewPldFromRaw(b common.RawBytes) (*Pld, error) {
@@ -118,6 +122,10 @@ func (p *Pld) union() (interface{}, error) {
 		return p.ServiceInfoRequest, nil
 	case proto.SCIONDMsg_Which_serviceInfoReply:
 		return p.ServiceInfoReply, nil
+	case proto.SCIONDMsg_Which_drkeyLvl2Req:
+		return p.DRKeyLvl2Req, nil
+	case proto.SCIONDMsg_Which_drkeyLvl2Rep:
+		return p.DRKeyLvl2Rep, nil
 	}
 	return nil, common.NewBasicError("Unsupported SCIOND union type", nil, "type", p.Which)
 }
@@ -134,9 +142,10 @@ func (pathReq *PathReq) Copy() *PathReq {
 		return nil
 	}
 	return &PathReq{
-		Dst:   pathReq.Dst,
-		Src:   pathReq.Src,
-		Flags: pathReq.Flags,
+		Dst:    pathReq.Dst,
+		Src:    pathReq.Src,
+		HPCfgs: append([]*path_mgmt.HPGroupId(nil), pathReq.HPCfgs...),
+		Flags:  pathReq.Flags,
 	}
 }
 
@@ -145,10 +154,31 @@ func (pathReq *PathReq) String() string {
 		pathReq.Src, pathReq.Dst, pathReq.Flags)
 }
 
+// PublicHPGroupID is the hidden path group ID that is used in the HPCfgs of a
+// hidden path request to indicate that public segments should be used in
+// addition to the hidden segments. If the HPCfgs contain no other group IDs,
+// the segments of all hidden path groups SCIOND is a member of are used.
+var PublicHPGroupID = hiddenpath.GroupId{}
+
 type PathReqFlags struct {
 	PathCount uint16 `capnp:"-"`
 	Refresh   bool
-	Hidden    bool
+	// Hidden indicates that paths should be built from the hidden segments of
+	// the hidden path groups SCIOND is a member of.
+	Hidden bool
+	// Public indicates that public segments should be used in addition to the
+	// hidden segments. It is only considered if Hidden is set, otherwise only
+	// public segments are used.
+	Public bool `capnp:"-"`
+}
+
+// HPCfgs returns the hidden path group IDs that are sent in a path request
+// with the given flags.
+func (f PathReqFlags) HPCfgs() []*path_mgmt.HPGroupId {
+	if !f.Hidden || !f.Public {
+		return nil
+	}
+	return []*path_mgmt.HPGroupId{PublicHPGroupID.ToMsg()}
 }
 
 type PathReply struct {
@@ -0,0 +1,99 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Interface down packet format:
+//
+//  0B       1        2        3        4        5        6        7
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// |                                  IA                                   |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// |                                Ingress                                |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// |                                 Egress                                |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+//
+// There is no legacy SCMP message that reports interfaces that are down. The
+// info carries the external interface down and internal connectivity down
+// errors of the new SCMP format (see slayers.SCMPExternalInterfaceDown and
+// slayers.SCMPInternalConnectivityDown) in their legacy representation. For
+// an external interface, only the egress interface is set.
+//
+var _ Info = (*InfoInterfaceDown)(nil)
+
+const (
+	interfaceDownLen = 24
+)
+
+type InfoInterfaceDown struct {
+	IA      addr.IA
+	Ingress common.IFIDType
+	Egress  common.IFIDType
+}
+
+func InfoInterfaceDownFromRaw(b common.RawBytes) (*InfoInterfaceDown, error) {
+	if len(b) < interfaceDownLen {
+		return nil, serrors.New("Unable to parse InfoInterfaceDown, small buffer size")
+	}
+	return &InfoInterfaceDown{
+		IA:      addr.IAFromRaw(b),
+		Ingress: common.IFIDType(binary.BigEndian.Uint64(b[8:])),
+		Egress:  common.IFIDType(binary.BigEndian.Uint64(b[16:])),
+	}, nil
+}
+
+func (e *InfoInterfaceDown) Copy() Info {
+	if e == nil {
+		return nil
+	}
+	return &InfoInterfaceDown{IA: e.IA, Ingress: e.Ingress, Egress: e.Egress}
+}
+
+func (e *InfoInterfaceDown) Len() int {
+	return interfaceDownLen + util.CalcPadding(interfaceDownLen, common.LineLen)
+}
+
+func (e *InfoInterfaceDown) Write(b common.RawBytes) (int, error) {
+	e.IA.Write(b)
+	binary.BigEndian.PutUint64(b[8:], uint64(e.Ingress))
+	binary.BigEndian.PutUint64(b[16:], uint64(e.Egress))
+	return util.FillPadding(b, interfaceDownLen, common.LineLen), nil
+}
+
+// Interfaces returns the interfaces that are reported down. Unset interfaces
+// are omitted.
+func (e *InfoInterfaceDown) Interfaces() []common.IFIDType {
+	var ifIDs []common.IFIDType
+	for _, ifID := range []common.IFIDType{e.Ingress, e.Egress} {
+		if ifID != 0 {
+			ifIDs = append(ifIDs, ifID)
+		}
+	}
+	return ifIDs
+}
+
+func (e *InfoInterfaceDown) String() string {
+	return fmt.Sprintf("IA=%s Ingress=%d Egress=%d", e.IA, e.Ingress, e.Egress)
+}
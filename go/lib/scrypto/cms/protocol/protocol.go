@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"time"
 
 	_ "crypto/sha1" // for crypto.SHA1
@@ -261,6 +262,37 @@ func (attrs Attributes) MarshaledForSigning() ([]byte, error) {
 	return raw.Bytes, nil
 }
 
+// SortDER sorts the Attributes by their DER encoding. DER requires the elements
+// of a SET OF to be sorted. Signers must sort the SignedAttributes before
+// computing the signature, such that the signature is computed over the same
+// encoding that is included in the message.
+func (attrs Attributes) SortDER() error {
+	encoded := make([][]byte, len(attrs))
+	for i, attr := range attrs {
+		raw, err := asn1.Marshal(attr)
+		if err != nil {
+			return err
+		}
+		encoded[i] = raw
+	}
+	sort.Sort(derSorter{attrs: attrs, encoded: encoded})
+	return nil
+}
+
+type derSorter struct {
+	attrs   Attributes
+	encoded [][]byte
+}
+
+func (s derSorter) Len() int { return len(s.attrs) }
+
+func (s derSorter) Less(i, j int) bool { return bytes.Compare(s.encoded[i], s.encoded[j]) < 0 }
+
+func (s derSorter) Swap(i, j int) {
+	s.attrs[i], s.attrs[j] = s.attrs[j], s.attrs[i]
+	s.encoded[i], s.encoded[j] = s.encoded[j], s.encoded[i]
+}
+
 // GetOnlyAttributeValueBytes gets an attribute value, returning an error if the
 // attribute occurs multiple times or has multiple values.
 func (attrs Attributes) GetOnlyAttributeValueBytes(
@@ -651,6 +683,9 @@ func (sd *SignedData) AddSignerInfo(chain []*x509.Certificate, signer crypto.Sig
 		return err
 	}
 	si.SignedAttrs = append(si.SignedAttrs, stAttr, mdAttr, ctAttr)
+	if err := si.SignedAttrs.SortDER(); err != nil {
+		return err
+	}
 
 	// Signature is over the marshaled signed attributes
 	sm, err := si.SignedAttrs.MarshaledForSigning()
@@ -89,6 +89,40 @@ func TestSignerInfo(t *testing.T) {
 	}
 }
 
+func TestSignerInfoSignedAttrsDEROrder(t *testing.T) {
+	priv, cert, err := pkcs12.Decode(fixturePFX, "asdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eci, err := NewEncapsulatedContentInfo(oid.ContentTypeData, []byte("hello, world!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sd, err := NewSignedData(eci)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := []*x509.Certificate{cert}
+	if err = sd.AddSignerInfo(chain, priv.(*ecdsa.PrivateKey)); err != nil {
+		t.Fatal(err)
+	}
+
+	var prev []byte
+	for _, attr := range sd.SignerInfos[0].SignedAttrs {
+		raw, err := asn1.Marshal(attr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(prev, raw) > 0 {
+			t.Fatal("signed attributes not in DER order")
+		}
+		prev = raw
+	}
+}
+
 func TestEncapsulatedContentInfo(t *testing.T) {
 	ci, _ := ParseContentInfo(fixtureSignatureOpenSSLAttached)
 	sd, _ := ci.SignedDataContent()
@@ -0,0 +1,199 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cppki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DenyListEntry identifies a denied certificate by its issuer and serial
+// number.
+type DenyListEntry struct {
+	// Issuer is the DER encoded distinguished name of the certificate issuer.
+	Issuer []byte
+	// SerialNumber is the serial number of the certificate.
+	SerialNumber *big.Int
+}
+
+// NewDenyListEntry creates an entry that denies the provided certificate.
+func NewDenyListEntry(cert *x509.Certificate) DenyListEntry {
+	return DenyListEntry{
+		Issuer:       append([]byte(nil), cert.RawIssuer...),
+		SerialNumber: new(big.Int).Set(cert.SerialNumber),
+	}
+}
+
+// Matches indicates whether the entry identifies the provided certificate.
+func (e DenyListEntry) Matches(cert *x509.Certificate) bool {
+	return e.SerialNumber.Cmp(cert.SerialNumber) == 0 && bytes.Equal(e.Issuer, cert.RawIssuer)
+}
+
+// DenyList is the deny list payload. It lists the AS and CA certificates of an
+// ISD that must no longer be trusted, even if they are still valid and
+// verifiable with the TRC. A deny list is issued either by the voters of the
+// ISD, or by a CA for the certificates it issued. A deny list with a higher
+// serial number supersedes all deny lists of the same issuer with a lower
+// serial number.
+type DenyList struct {
+	Raw      []byte
+	ISD      addr.ISD
+	Serial   scrypto.Version
+	IssuedAt time.Time
+	Entries  []DenyListEntry
+}
+
+// Validate validates that the deny list payload is well formed.
+func (d *DenyList) Validate() error {
+	switch {
+	case d.ISD == 0:
+		return serrors.New("wildcard ISD")
+	case d.Serial == scrypto.LatestVer:
+		return serrors.New("invalid serial number")
+	case d.IssuedAt.IsZero():
+		return serrors.New("issued at not set")
+	}
+	for i, entry := range d.Entries {
+		if entry.SerialNumber == nil {
+			return serrors.New("serial number not set", "index", i)
+		}
+		var name pkix.RDNSequence
+		if rest, err := asn1.Unmarshal(entry.Issuer, &name); err != nil || len(rest) > 0 {
+			return serrors.New("invalid issuer", "index", i)
+		}
+		for j := 0; j < i; j++ {
+			if entry.SerialNumber.Cmp(d.Entries[j].SerialNumber) == 0 &&
+				bytes.Equal(entry.Issuer, d.Entries[j].Issuer) {
+				return serrors.New("duplicate entry", "index", i, "duplicate", j)
+			}
+		}
+	}
+	return nil
+}
+
+// Denies indicates whether the deny list contains the provided certificate.
+func (d *DenyList) Denies(cert *x509.Certificate) bool {
+	for _, entry := range d.Entries {
+		if entry.Matches(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniesChain indicates whether the deny list contains any certificate of the
+// provided chain.
+func (d *DenyList) DeniesChain(chain []*x509.Certificate) bool {
+	for _, cert := range chain {
+		if d.Denies(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZero reports whether this deny list is empty.
+func (d *DenyList) IsZero() bool {
+	return len(d.Raw) == 0 && d.ISD == 0 && d.Serial == 0 &&
+		d.IssuedAt.IsZero() && len(d.Entries) == 0
+}
+
+// asn1DenyListEntry is used to encode and decode the deny list entries.
+type asn1DenyListEntry struct {
+	Issuer       asn1.RawValue `asn1:"issuer"`
+	SerialNumber *big.Int      `asn1:"serialNumber"`
+}
+
+// asn1DenyListPayload is used to encode and decode the deny list payload.
+type asn1DenyListPayload struct {
+	Version  int64               `asn1:"version"`
+	ISD      int64               `asn1:"iSD"`
+	Serial   int64               `asn1:"serialNumber"`
+	IssuedAt time.Time           `asn1:"issuedAt,generalized"`
+	Entries  []asn1DenyListEntry `asn1:"entries"`
+}
+
+// DecodeDenyList parses the payload form ASN.1 DER format. The payload keeps a
+// reference to the input data.
+func DecodeDenyList(raw []byte) (DenyList, error) {
+	var a asn1DenyListPayload
+	rest, err := asn1.Unmarshal(raw, &a)
+	if err != nil {
+		return DenyList{}, err
+	}
+	if len(rest) > 0 {
+		return DenyList{}, serrors.New("trailing data")
+	}
+	if a.Version != 0 {
+		return DenyList{}, serrors.New("unsupported version", "version", a.Version)
+	}
+	if a.ISD > int64(addr.MaxISD) || a.ISD < 0 {
+		return DenyList{}, serrors.New("invalid ISD", "value", a.ISD)
+	}
+	if a.Serial < 0 {
+		return DenyList{}, serrors.New("invalid serial number", "value", a.Serial)
+	}
+	entries := make([]DenyListEntry, 0, len(a.Entries))
+	for _, entry := range a.Entries {
+		entries = append(entries, DenyListEntry{
+			Issuer:       entry.Issuer.FullBytes,
+			SerialNumber: entry.SerialNumber,
+		})
+	}
+	d := DenyList{
+		Raw:      raw,
+		ISD:      addr.ISD(a.ISD),
+		Serial:   scrypto.Version(a.Serial),
+		IssuedAt: a.IssuedAt,
+		Entries:  entries,
+	}
+	if err := d.Validate(); err != nil {
+		return DenyList{}, err
+	}
+	return d, nil
+}
+
+// Encode encodes the payload in ASN.1 DER format.
+func (d *DenyList) Encode() ([]byte, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	entries := make([]asn1DenyListEntry, 0, len(d.Entries))
+	for _, entry := range d.Entries {
+		var issuer asn1.RawValue
+		if _, err := asn1.Unmarshal(entry.Issuer, &issuer); err != nil {
+			return nil, err
+		}
+		entries = append(entries, asn1DenyListEntry{
+			Issuer:       issuer,
+			SerialNumber: entry.SerialNumber,
+		})
+	}
+	a := asn1DenyListPayload{
+		ISD:      int64(d.ISD),
+		Serial:   int64(d.Serial),
+		IssuedAt: d.IssuedAt.UTC().Truncate(time.Second),
+		Entries:  entries,
+	}
+	return asn1.Marshal(a)
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cppki_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDecodeEncodedDenyList(t *testing.T) {
+	chain := xtest.LoadChain(t, "testdata/denylist/ISD1-ASff00_0_111.pem")
+	d := cppki.DenyList{
+		ISD:      1,
+		Serial:   3,
+		IssuedAt: time.Now().UTC().Truncate(time.Second),
+		Entries: []cppki.DenyListEntry{
+			cppki.NewDenyListEntry(chain[0]),
+			cppki.NewDenyListEntry(chain[1]),
+		},
+	}
+	raw, err := d.Encode()
+	require.NoError(t, err)
+	dec, err := cppki.DecodeDenyList(raw)
+	require.NoError(t, err)
+	assert.Equal(t, raw, dec.Raw)
+	dec.Raw = nil
+	assert.Equal(t, d, dec)
+}
+
+func TestDenyListValidate(t *testing.T) {
+	chain := xtest.LoadChain(t, "testdata/denylist/ISD1-ASff00_0_111.pem")
+	valid := func() cppki.DenyList {
+		return cppki.DenyList{
+			ISD:      1,
+			Serial:   1,
+			IssuedAt: time.Now(),
+			Entries:  []cppki.DenyListEntry{cppki.NewDenyListEntry(chain[0])},
+		}
+	}
+	testCases := map[string]struct {
+		Modify       func(d *cppki.DenyList)
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Modify:       func(*cppki.DenyList) {},
+			ErrAssertion: assert.NoError,
+		},
+		"no entries": {
+			Modify:       func(d *cppki.DenyList) { d.Entries = nil },
+			ErrAssertion: assert.NoError,
+		},
+		"wildcard ISD": {
+			Modify:       func(d *cppki.DenyList) { d.ISD = 0 },
+			ErrAssertion: assert.Error,
+		},
+		"zero serial": {
+			Modify:       func(d *cppki.DenyList) { d.Serial = 0 },
+			ErrAssertion: assert.Error,
+		},
+		"issued at not set": {
+			Modify:       func(d *cppki.DenyList) { d.IssuedAt = time.Time{} },
+			ErrAssertion: assert.Error,
+		},
+		"serial number not set": {
+			Modify:       func(d *cppki.DenyList) { d.Entries[0].SerialNumber = nil },
+			ErrAssertion: assert.Error,
+		},
+		"invalid issuer": {
+			Modify:       func(d *cppki.DenyList) { d.Entries[0].Issuer = []byte("garbage") },
+			ErrAssertion: assert.Error,
+		},
+		"duplicate entry": {
+			Modify: func(d *cppki.DenyList) {
+				d.Entries = append(d.Entries, cppki.NewDenyListEntry(chain[0]))
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := valid()
+			tc.Modify(&d)
+			tc.ErrAssertion(t, d.Validate())
+		})
+	}
+}
+
+func TestDenyListDenies(t *testing.T) {
+	chain111 := xtest.LoadChain(t, "testdata/denylist/ISD1-ASff00_0_111.pem")
+	chain112 := xtest.LoadChain(t, "testdata/denylist/ISD1-ASff00_0_112.pem")
+	d := cppki.DenyList{
+		Entries: []cppki.DenyListEntry{cppki.NewDenyListEntry(chain111[0])},
+	}
+	assert.True(t, d.Denies(chain111[0]))
+	assert.True(t, d.DeniesChain(chain111))
+	assert.False(t, d.Denies(chain111[1]))
+	assert.False(t, d.DeniesChain(chain112))
+
+	// Same serial number, but different issuer.
+	d.Entries[0].Issuer = chain111[1].RawSubject
+	d.Entries[0].SerialNumber = new(big.Int).Set(chain111[1].SerialNumber)
+	assert.False(t, d.Denies(chain111[1]))
+
+	d.Entries = append(d.Entries, cppki.NewDenyListEntry(chain112[1]))
+	assert.True(t, d.DeniesChain(chain111))
+	assert.True(t, d.DeniesChain(chain112))
+}
@@ -0,0 +1,229 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cppki
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cms/protocol"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// SignedDenyList represents the parsed signed deny list.
+type SignedDenyList struct {
+	Raw         []byte
+	DenyList    DenyList
+	SignerInfos []protocol.SignerInfo
+	// Certificates are the certificates that are included in the signed
+	// message. They are used to find the CA certificate that signed the deny
+	// list.
+	Certificates []*x509.Certificate
+	// CA is the CA certificate that signed the deny list. It is nil if the
+	// deny list is signed by the voters of the ISD.
+	CA *x509.Certificate
+}
+
+// DenyListVerifyOptions contains the options for verifying a signed deny list.
+type DenyListVerifyOptions struct {
+	TRC         *TRC
+	CurrentTime time.Time // if zero, the current time is used
+	// ISDDenyList is the deny list issued by the voters of the ISD. A CA that
+	// is listed on it can no longer issue deny lists.
+	ISDDenyList *DenyList
+}
+
+// SignDenyList signs the deny list payload with the provided key. The
+// certificate must authenticate the key and is included in the signed message.
+// It can either be a voting certificate of the ISD, or a CA certificate. In the
+// latter case, the deny list only applies to the certificates issued by the CA.
+func SignDenyList(d DenyList, key crypto.Signer, cert *x509.Certificate) (SignedDenyList, error) {
+	payload, err := d.Encode()
+	if err != nil {
+		return SignedDenyList{}, err
+	}
+	eci, err := protocol.NewDataEncapsulatedContentInfo(payload)
+	if err != nil {
+		return SignedDenyList{}, err
+	}
+	sd, err := protocol.NewSignedData(eci)
+	if err != nil {
+		return SignedDenyList{}, err
+	}
+	if err := sd.AddSignerInfo([]*x509.Certificate{cert}, key); err != nil {
+		return SignedDenyList{}, serrors.WrapStr("signing deny list", err)
+	}
+	raw, err := sd.ContentInfoDER()
+	if err != nil {
+		return SignedDenyList{}, err
+	}
+	return DecodeSignedDenyList(raw)
+}
+
+// DecodeSignedDenyList parses the signed deny list.
+func DecodeSignedDenyList(raw []byte) (SignedDenyList, error) {
+	ci, err := protocol.ParseContentInfo(raw)
+	if err != nil {
+		return SignedDenyList{}, serrors.WrapStr("error parsing ContentInfo", err)
+	}
+	sd, err := ci.SignedDataContent()
+	if err != nil {
+		return SignedDenyList{}, serrors.WrapStr("error parsing SignedData", err)
+	}
+	if sd.Version != 1 {
+		return SignedDenyList{}, serrors.New("unsupported SignedData version",
+			"version", sd.Version)
+	}
+	if !sd.EncapContentInfo.IsTypeData() {
+		return SignedDenyList{}, serrors.New("unsupported EncapContentInfo type",
+			"type", sd.EncapContentInfo.EContentType)
+	}
+	praw, err := sd.EncapContentInfo.EContentValue()
+	if err != nil {
+		return SignedDenyList{}, serrors.WrapStr("error reading raw payload", err)
+	}
+	d, err := DecodeDenyList(praw)
+	if err != nil {
+		return SignedDenyList{}, serrors.WrapStr("error parsing deny list payload", err)
+	}
+	certs, err := sd.X509Certificates()
+	if err != nil {
+		return SignedDenyList{}, serrors.WrapStr("error parsing certificates", err)
+	}
+	ca, err := signingCA(sd.SignerInfos, certs)
+	if err != nil {
+		return SignedDenyList{}, err
+	}
+	return SignedDenyList{
+		Raw:          raw,
+		DenyList:     d,
+		SignerInfos:  sd.SignerInfos,
+		Certificates: certs,
+		CA:           ca,
+	}, nil
+}
+
+// signingCA returns the included CA certificate that signed the deny list, or
+// nil if no signer is a CA. A deny list issued by a CA must have exactly one
+// signer.
+func signingCA(sis []protocol.SignerInfo, certs []*x509.Certificate) (*x509.Certificate, error) {
+	for _, si := range sis {
+		cert, err := si.FindCertificate(certs)
+		if err != nil {
+			continue
+		}
+		if ct, err := classifyCert(cert); err != nil || ct != CA {
+			continue
+		}
+		if len(sis) != 1 {
+			return nil, serrors.New("deny list signed by CA must have exactly one signer",
+				"signers", len(sis))
+		}
+		return cert, nil
+	}
+	return nil, nil
+}
+
+// Verify verifies the signatures on the signed deny list with the TRC in the
+// options. A deny list issued by the voters of the ISD must have at least one
+// signature, and every signature must be verifiable with a voting certificate
+// in the TRC.
+//
+// A deny list issued by a CA must be signed by the CA only. The CA certificate
+// must be verifiable with the root certificates in the TRC at the current time,
+// and it must not be listed on the ISD deny list in the options. Furthermore, a
+// CA is only allowed to deny certificates it has issued itself.
+func (s *SignedDenyList) Verify(opts DenyListVerifyOptions) error {
+	trc := opts.TRC
+	if trc == nil || trc.IsZero() {
+		return serrors.New("TRC required for deny list verification")
+	}
+	if trc.ID.ISD != s.DenyList.ISD {
+		return serrors.New("ISD mismatch", "expected", trc.ID.ISD, "actual", s.DenyList.ISD)
+	}
+	if len(s.SignerInfos) == 0 {
+		return serrors.New("deny list not signed")
+	}
+	if s.CA != nil {
+		if err := s.verifyCA(opts); err != nil {
+			return err
+		}
+		if err := verifySignerInfo(s.DenyList.Raw, s.CA, s.SignerInfos[0]); err != nil {
+			return serrors.WrapStr("verifying signature", err)
+		}
+		return nil
+	}
+	certs, err := classifyCerts(trc.Certificates)
+	if err != nil {
+		return err
+	}
+	voters := make([]*x509.Certificate, 0, len(certs.Sensitive)+len(certs.Regular))
+	for _, cert := range certs.Sensitive {
+		voters = append(voters, cert)
+	}
+	for _, cert := range certs.Regular {
+		voters = append(voters, cert)
+	}
+	for i, si := range s.SignerInfos {
+		cert, err := si.FindCertificate(voters)
+		if err != nil {
+			return serrors.WrapStr("finding signer certificate", err, "index", i)
+		}
+		if err := verifySignerInfo(s.DenyList.Raw, cert, si); err != nil {
+			return serrors.WrapStr("verifying signature", err, "index", i)
+		}
+	}
+	return nil
+}
+
+// verifyCA verifies the CA certificate that signed the deny list against the
+// TRC, checks that the CA is not denied and that it is only denying
+// certificates that it issued.
+func (s *SignedDenyList) verifyCA(opts DenyListVerifyOptions) error {
+	ca := s.CA
+	if ct, err := ValidateCert(ca); err != nil || ct != CA {
+		return serrors.WithCtx(ErrInvalidCertType, "expected", CA, "actual", ct, "err", err)
+	}
+	rootPool, err := opts.TRC.RootPool()
+	if err != nil {
+		return serrors.WrapStr("failed to extract root certs", err, "trc", opts.TRC.ID)
+	}
+	_, err = ca.Verify(x509.VerifyOptions{
+		Roots:       rootPool,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		CurrentTime: opts.CurrentTime,
+	})
+	if err != nil {
+		return serrors.WrapStr("verifying CA certificate", err)
+	}
+	if opts.ISDDenyList != nil && opts.ISDDenyList.Denies(ca) {
+		return serrors.New("CA certificate is denied")
+	}
+	for i, entry := range s.DenyList.Entries {
+		if !bytes.Equal(entry.Issuer, ca.RawSubject) {
+			return serrors.New("CA denies certificate issued by other CA", "index", i)
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether this deny list is empty.
+func (s *SignedDenyList) IsZero() bool {
+	return len(s.Raw) == 0 &&
+		s.DenyList.IsZero() &&
+		len(s.SignerInfos) == 0
+}
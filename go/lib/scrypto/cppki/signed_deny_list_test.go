@@ -0,0 +1,227 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cppki_test
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestSignedDenyListVerify(t *testing.T) {
+	trc := loadTRC(t, "testdata/denylist/ISD1-B1-S1.trc")
+	chain111 := xtest.LoadChain(t, "testdata/denylist/ISD1-ASff00_0_111.pem")
+	chain112 := xtest.LoadChain(t, "testdata/denylist/ISD1-ASff00_0_112.pem")
+	caCert := loadCert(t, "testdata/denylist/cp-ca.crt")
+	caKey := loadKey(t, "testdata/denylist/cp-ca.key")
+	votingCert := loadCert(t, "testdata/denylist/regular-voting.crt")
+	votingKey := loadKey(t, "testdata/denylist/regular-voting.key")
+
+	// The CA certificate is verified at a fixed time during its validity
+	// period, such that the test does not depend on the test crypto expiring.
+	now := caCert.NotBefore.Add(time.Hour)
+	denyList := func(entries ...cppki.DenyListEntry) cppki.DenyList {
+		return cppki.DenyList{ISD: 1, Serial: 1, IssuedAt: now, Entries: entries}
+	}
+	deniedCA := &cppki.DenyList{
+		ISD:      1,
+		Serial:   1,
+		IssuedAt: now,
+		Entries:  []cppki.DenyListEntry{cppki.NewDenyListEntry(caCert)},
+	}
+
+	testCases := map[string]struct {
+		DenyList     cppki.DenyList
+		Key          crypto.Signer
+		Cert         *x509.Certificate
+		TRC          *cppki.TRC
+		CurrentTime  time.Time
+		ISDDenyList  *cppki.DenyList
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"voting signer": {
+			DenyList: denyList(
+				cppki.NewDenyListEntry(chain111[0]),
+				cppki.NewDenyListEntry(chain112[1]),
+			),
+			Key:          votingKey,
+			Cert:         votingCert,
+			TRC:          &trc.TRC,
+			ErrAssertion: assert.NoError,
+		},
+		"CA signer": {
+			DenyList: denyList(
+				cppki.NewDenyListEntry(chain111[0]),
+				cppki.NewDenyListEntry(chain112[0]),
+			),
+			Key:          caKey,
+			Cert:         caCert,
+			TRC:          &trc.TRC,
+			ErrAssertion: assert.NoError,
+		},
+		"CA signer denies certificate not issued by it": {
+			DenyList:     denyList(cppki.NewDenyListEntry(chain111[1])),
+			Key:          caKey,
+			Cert:         caCert,
+			TRC:          &trc.TRC,
+			ErrAssertion: assert.Error,
+		},
+		"CA signer empty deny list": {
+			DenyList:     denyList(),
+			Key:          caKey,
+			Cert:         caCert,
+			TRC:          &trc.TRC,
+			ErrAssertion: assert.NoError,
+		},
+		"CA signer outside of validity period": {
+			DenyList: cppki.DenyList{
+				ISD:      1,
+				Serial:   1,
+				IssuedAt: caCert.NotAfter.Add(time.Hour),
+				Entries:  []cppki.DenyListEntry{cppki.NewDenyListEntry(chain111[0])},
+			},
+			Key:          caKey,
+			Cert:         caCert,
+			TRC:          &trc.TRC,
+			CurrentTime:  caCert.NotAfter.Add(time.Hour),
+			ErrAssertion: assert.Error,
+		},
+		"CA signer backdated": {
+			DenyList:     denyList(cppki.NewDenyListEntry(chain111[0])),
+			Key:          caKey,
+			Cert:         caCert,
+			TRC:          &trc.TRC,
+			CurrentTime:  caCert.NotAfter.Add(time.Hour),
+			ErrAssertion: assert.Error,
+		},
+		"denied CA signer": {
+			DenyList:     denyList(cppki.NewDenyListEntry(chain111[0])),
+			Key:          caKey,
+			Cert:         caCert,
+			TRC:          &trc.TRC,
+			ISDDenyList:  deniedCA,
+			ErrAssertion: assert.Error,
+		},
+		"voting signer ignores ISD deny list": {
+			DenyList:     denyList(cppki.NewDenyListEntry(chain111[0])),
+			Key:          votingKey,
+			Cert:         votingCert,
+			TRC:          &trc.TRC,
+			ISDDenyList:  deniedCA,
+			ErrAssertion: assert.NoError,
+		},
+		"AS signer": {
+			DenyList:     denyList(cppki.NewDenyListEntry(chain112[0])),
+			Key:          loadKey(t, "testdata/denylist/cp-as-111.key"),
+			Cert:         chain111[0],
+			TRC:          &trc.TRC,
+			ErrAssertion: assert.Error,
+		},
+		"ISD mismatch": {
+			DenyList: cppki.DenyList{
+				ISD:      2,
+				Serial:   1,
+				IssuedAt: now,
+			},
+			Key:          votingKey,
+			Cert:         votingCert,
+			TRC:          &trc.TRC,
+			ErrAssertion: assert.Error,
+		},
+		"nil TRC": {
+			DenyList:     denyList(cppki.NewDenyListEntry(chain111[0])),
+			Key:          votingKey,
+			Cert:         votingCert,
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			signed, err := cppki.SignDenyList(tc.DenyList, tc.Key, tc.Cert)
+			require.NoError(t, err)
+			currentTime := tc.CurrentTime
+			if currentTime.IsZero() {
+				currentTime = now
+			}
+			err = signed.Verify(cppki.DenyListVerifyOptions{
+				TRC:         tc.TRC,
+				CurrentTime: currentTime,
+				ISDDenyList: tc.ISDDenyList,
+			})
+			tc.ErrAssertion(t, err)
+		})
+	}
+}
+
+func TestDecodeSignedDenyList(t *testing.T) {
+	chain := xtest.LoadChain(t, "testdata/denylist/ISD1-ASff00_0_111.pem")
+	caCert := loadCert(t, "testdata/denylist/cp-ca.crt")
+	d := cppki.DenyList{
+		ISD:      1,
+		Serial:   2,
+		IssuedAt: time.Now().UTC().Truncate(time.Second),
+		Entries:  []cppki.DenyListEntry{cppki.NewDenyListEntry(chain[0])},
+	}
+	signed, err := cppki.SignDenyList(d, loadKey(t, "testdata/denylist/cp-ca.key"), caCert)
+	require.NoError(t, err)
+
+	dec, err := cppki.DecodeSignedDenyList(signed.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, signed, dec)
+	assert.Equal(t, []*x509.Certificate{caCert}, dec.Certificates)
+	assert.Equal(t, caCert, dec.CA)
+	dec.DenyList.Raw = nil
+	assert.Equal(t, d, dec.DenyList)
+
+	voted, err := cppki.SignDenyList(d, loadKey(t, "testdata/denylist/regular-voting.key"),
+		loadCert(t, "testdata/denylist/regular-voting.crt"))
+	require.NoError(t, err)
+	dec, err = cppki.DecodeSignedDenyList(voted.Raw)
+	require.NoError(t, err)
+	assert.Nil(t, dec.CA)
+
+	_, err = cppki.DecodeSignedDenyList(signed.DenyList.Raw)
+	assert.Error(t, err)
+}
+
+func TestSignedDenyListVerifyUnsigned(t *testing.T) {
+	trc := loadTRC(t, "testdata/denylist/ISD1-B1-S1.trc")
+	signed := cppki.SignedDenyList{
+		DenyList: cppki.DenyList{ISD: 1, Serial: 1, IssuedAt: time.Now()},
+	}
+	assert.Error(t, signed.Verify(cppki.DenyListVerifyOptions{TRC: &trc.TRC}))
+}
+
+func loadKey(t *testing.T, file string) crypto.Signer {
+	t.Helper()
+	raw, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	return key.(crypto.Signer)
+}
@@ -145,7 +145,7 @@ func (s *SignedTRC) verifyAll(certs []*x509.Certificate) error {
 		if err != nil {
 			return err
 		}
-		if err := s.verifySignerInfo(cert, si); err != nil {
+		if err := verifySignerInfo(s.TRC.Raw, cert, si); err != nil {
 			return err
 		}
 		seen[cert] = struct{}{}
@@ -170,10 +170,10 @@ func (s *SignedTRC) IsZero() bool {
 		len(s.SignerInfos) == 0
 }
 
-// verifySignerInfo verifies the singer information. The provided certificate is
-// trusted and authenticates the public key for the private key that signed the
-// signer info.
-func (s *SignedTRC) verifySignerInfo(cert *x509.Certificate, si protocol.SignerInfo) error {
+// verifySignerInfo verifies the singer information over the payload. The
+// provided certificate is trusted and authenticates the public key for the
+// private key that signed the signer info.
+func verifySignerInfo(payload []byte, cert *x509.Certificate, si protocol.SignerInfo) error {
 	hash, err := si.Hash()
 	if err != nil {
 		return err
@@ -183,7 +183,7 @@ func (s *SignedTRC) verifySignerInfo(cert *x509.Certificate, si protocol.SignerI
 		return err
 	}
 	actualDigest := hash.New()
-	actualDigest.Write(s.TRC.Raw)
+	actualDigest.Write(payload)
 	if !bytes.Equal(attrDigest, actualDigest.Sum(nil)) {
 		return serrors.New("message digest does not match")
 	}
@@ -0,0 +1,131 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package colibri implements the COLIBRI path type. A COLIBRI path forwards packets over a
+// bandwidth reservation. It consists of a single info field describing the reservation,
+// followed by one hop field per AS on the reservation path.
+package colibri
+
+import (
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// MaxHops is the maximum number of hop fields in a COLIBRI path.
+const MaxHops = 64
+
+// Path is a COLIBRI path.
+type Path struct {
+	// InfoField describes the reservation.
+	InfoField InfoField
+	// HopFields contains one HopField per AS on the reservation path, in reservation direction.
+	HopFields []*HopField
+}
+
+// DecodeFromBytes fully decodes the COLIBRI path into the corresponding fields.
+func (p *Path) DecodeFromBytes(data []byte) error {
+	if err := p.InfoField.DecodeFromBytes(data); err != nil {
+		return err
+	}
+	hops := int(p.InfoField.HFCount)
+	if minLen := InfoLen + hops*HopLen; len(data) < minLen {
+		return serrors.New("COLIBRI path raw too short", "expected", minLen,
+			"actual", len(data))
+	}
+	if hops > MaxHops {
+		return serrors.New("too many hop fields", "max", MaxHops, "actual", hops)
+	}
+	if hops > 0 && p.InfoField.CurrHF >= p.InfoField.HFCount {
+		return serrors.New("current hop field out of range", "curr_hf", p.InfoField.CurrHF,
+			"hf_count", hops)
+	}
+	offset := InfoLen
+	p.HopFields = make([]*HopField, hops)
+	for i := 0; i < hops; i++ {
+		hop := &HopField{}
+		if err := hop.DecodeFromBytes(data[offset : offset+HopLen]); err != nil {
+			return err
+		}
+		p.HopFields[i] = hop
+		offset += HopLen
+	}
+	return nil
+}
+
+// SerializeTo writes the path to a slice. The slice must be big enough to hold the entire data,
+// otherwise an error is returned. The HFCount of the info field must match the number of
+// hop fields.
+func (p *Path) SerializeTo(b []byte) error {
+	if len(b) < p.Len() {
+		return serrors.New("buffer too small to serialize path.", "expected", p.Len(),
+			"actual", len(b))
+	}
+	if int(p.InfoField.HFCount) != len(p.HopFields) {
+		return serrors.New("HFCount does not match the hop fields",
+			"hf_count", p.InfoField.HFCount, "hop_fields", len(p.HopFields))
+	}
+	if err := p.InfoField.SerializeTo(b[:InfoLen]); err != nil {
+		return err
+	}
+	offset := InfoLen
+	for _, hop := range p.HopFields {
+		if err := hop.SerializeTo(b[offset : offset+HopLen]); err != nil {
+			return err
+		}
+		offset += HopLen
+	}
+	return nil
+}
+
+// Reverse reverses the path such that it can be used in the reverse direction. The hop fields
+// keep their reservation direction, the R flag indicates that they are traversed in reverse.
+func (p *Path) Reverse() error {
+	if len(p.HopFields) == 0 {
+		return serrors.New("cannot reverse empty COLIBRI path")
+	}
+	for i, j := 0, len(p.HopFields)-1; i < j; i, j = i+1, j-1 {
+		p.HopFields[i], p.HopFields[j] = p.HopFields[j], p.HopFields[i]
+	}
+	p.InfoField.R = !p.InfoField.R
+	p.InfoField.CurrHF = uint8(len(p.HopFields)-1) - p.InfoField.CurrHF
+	return nil
+}
+
+// IncPath increments the current hop field.
+func (p *Path) IncPath() error {
+	if int(p.InfoField.CurrHF)+1 >= len(p.HopFields) {
+		return serrors.New("path already at end", "curr_hf", p.InfoField.CurrHF,
+			"hf_count", len(p.HopFields))
+	}
+	p.InfoField.CurrHF++
+	return nil
+}
+
+// IsLastHop returns whether the current hop field is the last one.
+func (p *Path) IsLastHop() bool {
+	return int(p.InfoField.CurrHF) == len(p.HopFields)-1
+}
+
+// GetCurrentHopField returns the current hop field.
+func (p *Path) GetCurrentHopField() (*HopField, error) {
+	if int(p.InfoField.CurrHF) >= len(p.HopFields) {
+		return nil, serrors.New("current hop field out of range",
+			"curr_hf", p.InfoField.CurrHF, "hf_count", len(p.HopFields))
+	}
+	return p.HopFields[p.InfoField.CurrHF], nil
+}
+
+// Len returns the length of the path in bytes.
+func (p *Path) Len() int {
+	return InfoLen + len(p.HopFields)*HopLen
+}
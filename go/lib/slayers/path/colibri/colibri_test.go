@@ -0,0 +1,102 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+func TestSerializeDecode(t *testing.T) {
+	want := newPath()
+	b := make([]byte, want.Len())
+	assert.NoError(t, want.SerializeTo(b))
+
+	got := &colibri.Path{}
+	assert.NoError(t, got.DecodeFromBytes(b))
+	assert.Equal(t, want, got)
+}
+
+func TestSerializeDecodeErrors(t *testing.T) {
+	p := newPath()
+	assert.Error(t, p.SerializeTo(make([]byte, p.Len()-1)))
+	p.InfoField.HFCount = 2
+	assert.Error(t, p.SerializeTo(make([]byte, p.Len())))
+	p.InfoField.HFCount = 3
+	p.InfoField.Idx = 16
+	assert.Error(t, p.SerializeTo(make([]byte, p.Len())))
+
+	p = newPath()
+	b := make([]byte, p.Len())
+	require.NoError(t, p.SerializeTo(b))
+	assert.Error(t, (&colibri.Path{}).DecodeFromBytes(b[:len(b)-1]))
+	b[1] = 3 // CurrHF
+	assert.Error(t, (&colibri.Path{}).DecodeFromBytes(b))
+}
+
+func TestReverse(t *testing.T) {
+	p := newPath()
+	require.NoError(t, p.Reverse())
+	assert.True(t, p.InfoField.R)
+	assert.Equal(t, uint8(2), p.InfoField.CurrHF)
+	assert.Equal(t, uint16(3), p.HopFields[0].ConsIngress)
+	assert.Equal(t, uint16(0), p.HopFields[2].ConsIngress)
+	require.NoError(t, p.Reverse())
+	assert.Equal(t, newPath(), p)
+
+	assert.Error(t, (&colibri.Path{}).Reverse())
+}
+
+func TestIncPath(t *testing.T) {
+	p := newPath()
+	hf, err := p.GetCurrentHopField()
+	require.NoError(t, err)
+	assert.Equal(t, p.HopFields[0], hf)
+	assert.False(t, p.IsLastHop())
+	require.NoError(t, p.IncPath())
+	require.NoError(t, p.IncPath())
+	assert.True(t, p.IsLastHop())
+	assert.Error(t, p.IncPath())
+	hf, err = p.GetCurrentHopField()
+	require.NoError(t, err)
+	assert.Equal(t, p.HopFields[2], hf)
+}
+
+func newPath() *colibri.Path {
+	return &colibri.Path{
+		InfoField: colibri.InfoField{
+			C:               true,
+			S:               true,
+			HFCount:         3,
+			BWCls:           13,
+			RLC:             4,
+			Idx:             15,
+			PathType:        6,
+			OrigPayLen:      1200,
+			ExpTick:         0x01020304,
+			ResID:           [colibri.ResIDLen]byte{0xff, 0, 0, 0, 0, 0x1, 1, 2, 3, 4},
+			PacketTimestamp: 0x0102030405060708,
+		},
+		HopFields: []*colibri.HopField{
+			{ConsIngress: 0, ConsEgress: 1, Mac: []byte{1, 2, 3, 4}},
+			{ConsIngress: 2, ConsEgress: 3, Mac: []byte{5, 6, 7, 8}},
+			{ConsIngress: 3, ConsEgress: 0, Mac: []byte{9, 10, 11, 12}},
+		},
+	}
+}
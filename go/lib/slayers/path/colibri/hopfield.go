@@ -0,0 +1,73 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// HopLen is the size of a HopField in bytes.
+	HopLen = 8
+	// MacLen is the size of the MAC of each HopField.
+	MacLen = 4
+)
+
+// HopField is the HopField used in the COLIBRI path type.
+//
+// The Hop Field has the following format:
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |          ConsIngress          |          ConsEgress           |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                              MAC                              |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+type HopField struct {
+	// ConsIngress is the ingress interface ID in reservation direction.
+	ConsIngress uint16
+	// ConsEgress is the egress interface ID in reservation direction.
+	ConsEgress uint16
+	// Mac is the 4-byte Message Authentication Code to authenticate the HopField. For e2e
+	// reservations it is computed per packet, see PacketMAC.
+	Mac []byte
+}
+
+// DecodeFromBytes populates the fields from a raw buffer. The buffer must be of length >=
+// colibri.HopLen
+func (h *HopField) DecodeFromBytes(raw []byte) error {
+	if len(raw) < HopLen {
+		return serrors.New("HopField raw too short", "expected", HopLen, "actual", len(raw))
+	}
+	h.ConsIngress = binary.BigEndian.Uint16(raw[0:2])
+	h.ConsEgress = binary.BigEndian.Uint16(raw[2:4])
+	h.Mac = append([]byte(nil), raw[4:4+MacLen]...)
+	return nil
+}
+
+// SerializeTo writes the fields into the provided buffer. The buffer must be of length >=
+// colibri.HopLen
+func (h *HopField) SerializeTo(b []byte) error {
+	if len(b) < HopLen {
+		return serrors.New("buffer for HopField too short", "expected", HopLen, "actual", len(b))
+	}
+	binary.BigEndian.PutUint16(b[0:2], h.ConsIngress)
+	binary.BigEndian.PutUint16(b[2:4], h.ConsEgress)
+	copy(b[4:4+MacLen], h.Mac)
+	return nil
+}
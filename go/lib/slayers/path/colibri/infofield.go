@@ -0,0 +1,154 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// InfoLen is the size of an InfoField in bytes.
+	InfoLen = 36
+	// ResIDLen is the size of the reservation ID in bytes.
+	ResIDLen = 16
+)
+
+// InfoField is the InfoField used in the COLIBRI path type.
+//
+// InfoField has the following format:
+//
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |C R S r r r r r|    CurrHF     |    HFCount    |     BWCls     |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |      RLC      |  Idx  |  Type |           OrigPayLen          |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                            ExpTick                            |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                                                               |
+//   +                                                               +
+//   |                         ReservationID                         |
+//   +                                                               +
+//   |                                                               |
+//   +                                                               +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                                                               |
+//   +                        PacketTimestamp                        +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+type InfoField struct {
+	// C is the control plane flag. It is set for COLIBRI control traffic, e.g. the renewal
+	// requests sent over an existing reservation.
+	C bool
+	// R is the reverse flag. If set, the hop fields are traversed in the reverse direction of
+	// the reservation.
+	R bool
+	// S is the segment reservation flag. It is set if the path uses a segment reservation
+	// directly, and unset for e2e reservations.
+	S bool
+	// CurrHF is the index of the current hop field.
+	CurrHF uint8
+	// HFCount is the number of hop fields in the path.
+	HFCount uint8
+	// BWCls is the bandwidth class of the reservation.
+	BWCls uint8
+	// RLC is the request latency class of the reservation.
+	RLC uint8
+	// Idx is the 4 bit index of the reservation.
+	Idx uint8
+	// PathType is the 4 bit type of the reservation, as in lib/colibri/reservation.
+	PathType uint8
+	// OrigPayLen is the payload length of the packet when sent by the source. It is part of the
+	// per packet MACs, and allows routers to verify them even if the packet was changed, e.g.
+	// by an extension.
+	OrigPayLen uint16
+	// ExpTick is the expiration time of the reservation in units of 4 seconds since the Unix
+	// epoch.
+	ExpTick uint32
+	// ResID is the ID of the reservation: the AS ID (6 bytes) followed by the suffix. Segment
+	// reservation suffixes are padded with zeroes.
+	ResID [ResIDLen]byte
+	// PacketTimestamp is set by the source for every packet. Together with the source address
+	// it makes the per packet MACs unique.
+	PacketTimestamp uint64
+}
+
+// DecodeFromBytes populates the fields from a raw buffer. The buffer must be of length >=
+// colibri.InfoLen
+func (inf *InfoField) DecodeFromBytes(raw []byte) error {
+	if len(raw) < InfoLen {
+		return serrors.New("InfoField raw too short", "expected", InfoLen, "actual", len(raw))
+	}
+	inf.C = raw[0]&0x80 == 0x80
+	inf.R = raw[0]&0x40 == 0x40
+	inf.S = raw[0]&0x20 == 0x20
+	inf.CurrHF = raw[1]
+	inf.HFCount = raw[2]
+	inf.BWCls = raw[3]
+	inf.RLC = raw[4]
+	inf.Idx = raw[5] >> 4
+	inf.PathType = raw[5] & 0x0f
+	inf.OrigPayLen = binary.BigEndian.Uint16(raw[6:8])
+	inf.ExpTick = binary.BigEndian.Uint32(raw[8:12])
+	copy(inf.ResID[:], raw[12:12+ResIDLen])
+	inf.PacketTimestamp = binary.BigEndian.Uint64(raw[28:36])
+	return nil
+}
+
+// SerializeTo writes the fields into the provided buffer. The buffer must be of length >=
+// colibri.InfoLen
+func (inf *InfoField) SerializeTo(b []byte) error {
+	if len(b) < InfoLen {
+		return serrors.New("buffer for InfoField too short", "expected", InfoLen,
+			"actual", len(b))
+	}
+	if inf.Idx > 0x0f || inf.PathType > 0x0f {
+		return serrors.New("index and path type must fit in 4 bits", "idx", inf.Idx,
+			"path_type", inf.PathType)
+	}
+	b[0] = 0
+	if inf.C {
+		b[0] |= 0x80
+	}
+	if inf.R {
+		b[0] |= 0x40
+	}
+	if inf.S {
+		b[0] |= 0x20
+	}
+	b[1] = inf.CurrHF
+	b[2] = inf.HFCount
+	b[3] = inf.BWCls
+	b[4] = inf.RLC
+	b[5] = inf.Idx<<4 | inf.PathType
+	binary.BigEndian.PutUint16(b[6:8], inf.OrigPayLen)
+	binary.BigEndian.PutUint32(b[8:12], inf.ExpTick)
+	copy(b[12:12+ResIDLen], inf.ResID[:])
+	binary.BigEndian.PutUint64(b[28:36], inf.PacketTimestamp)
+	return nil
+}
+
+func (inf *InfoField) String() string {
+	return fmt.Sprintf("{C: %t, R: %t, S: %t, CurrHF: %d, HFCount: %d, BWCls: %d, RLC: %d, "+
+		"Idx: %d, PathType: %d, OrigPayLen: %d, ExpTick: %d, ResID: %x, PacketTimestamp: %d}",
+		inf.C, inf.R, inf.S, inf.CurrHF, inf.HFCount, inf.BWCls, inf.RLC, inf.Idx, inf.PathType,
+		inf.OrigPayLen, inf.ExpTick, inf.ResID, inf.PacketTimestamp)
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// AuthenticatorLen is the size of a hop authenticator in bytes.
+const AuthenticatorLen = 16
+
+// HopAuthenticator computes the hop authenticator of an AS for the reservation in info and the
+// interfaces in hf. h is the AS' MAC keyed with its secret key and must produce at least
+// AuthenticatorLen bytes. The authenticator does not depend on the packet, it is handed out
+// with the reservation token. This method does not modify info or hf.
+func HopAuthenticator(h hash.Hash, info *InfoField, hf *HopField) []byte {
+	h.Reset()
+	// Write must not return an error: https://godoc.org/hash#Hash
+	if _, err := h.Write(AuthenticatorInput(info, hf)); err != nil {
+		panic(err)
+	}
+	return h.Sum(nil)[:AuthenticatorLen]
+}
+
+// PacketMAC computes the per packet MAC of an e2e reservation hop field, from the hop
+// authenticator of the AS and the per packet values in info. This method does not modify info.
+func PacketMAC(authenticator []byte, info *InfoField) ([]byte, error) {
+	h, err := scrypto.InitMac(authenticator)
+	if err != nil {
+		return nil, serrors.WrapStr("invalid hop authenticator", err)
+	}
+	input := make([]byte, 16)
+	binary.BigEndian.PutUint64(input[0:8], info.PacketTimestamp)
+	binary.BigEndian.PutUint16(input[8:10], info.OrigPayLen)
+	// Write must not return an error: https://godoc.org/hash#Hash
+	if _, err := h.Write(input); err != nil {
+		panic(err)
+	}
+	return h.Sum(nil)[:MacLen], nil
+}
+
+// SetMACs sets the MACs of all hop fields, using the hop authenticators of the ASes on the path
+// in reservation direction. Segment reservations carry the truncated authenticators, e2e
+// reservations the per packet MACs. The per packet values in the info field must already be
+// set.
+func (p *Path) SetMACs(authenticators [][]byte) error {
+	if len(authenticators) != len(p.HopFields) {
+		return serrors.New("wrong number of hop authenticators",
+			"expected", len(p.HopFields), "actual", len(authenticators))
+	}
+	for i, hf := range p.HopFields {
+		auth := authenticators[i]
+		if p.InfoField.R {
+			auth = authenticators[len(authenticators)-1-i]
+		}
+		if len(auth) != AuthenticatorLen {
+			return serrors.New("invalid hop authenticator length", "expected",
+				AuthenticatorLen, "actual", len(auth))
+		}
+		if p.InfoField.S {
+			hf.Mac = append([]byte(nil), auth[:MacLen]...)
+			continue
+		}
+		mac, err := PacketMAC(auth, &p.InfoField)
+		if err != nil {
+			return err
+		}
+		hf.Mac = mac
+	}
+	return nil
+}
+
+// VerifyMAC verifies the MAC of the hop field with the MAC of the AS, i.e. the one used to
+// compute its hop authenticator. If the MAC is correct nil is returned, otherwise an error.
+func VerifyMAC(h hash.Hash, info *InfoField, hf *HopField) error {
+	expected := HopAuthenticator(h, info, hf)[:MacLen]
+	if !info.S {
+		var err error
+		if expected, err = PacketMAC(HopAuthenticator(h, info, hf), info); err != nil {
+			return err
+		}
+	}
+	if !bytes.Equal(hf.Mac, expected) {
+		return serrors.New("MAC",
+			"expected", fmt.Sprintf("%x", expected),
+			"actual", fmt.Sprintf("%x", hf.Mac))
+	}
+	return nil
+}
+
+// AuthenticatorInput returns the input data block of the hop authenticator with the following
+// layout:
+//
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                                                               |
+//   +                                                               +
+//   |                         ReservationID                         |
+//   +                                                               +
+//   |                                                               |
+//   +                                                               +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                            ExpTick                            |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |     BWCls     |      RLC      |  Idx  |  Type |C S 0 0 0 0 0 0|
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |          ConsIngress          |          ConsEgress           |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                               0                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+func AuthenticatorInput(info *InfoField, hf *HopField) []byte {
+	input := make([]byte, 32)
+	copy(input[0:ResIDLen], info.ResID[:])
+	binary.BigEndian.PutUint32(input[16:20], info.ExpTick)
+	input[20] = info.BWCls
+	input[21] = info.RLC
+	input[22] = info.Idx<<4 | info.PathType&0x0f
+	if info.C {
+		input[23] |= 0x80
+	}
+	if info.S {
+		input[23] |= 0x40
+	}
+	binary.BigEndian.PutUint16(input[24:26], hf.ConsIngress)
+	binary.BigEndian.PutUint16(input[26:28], hf.ConsEgress)
+	return input
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"hash"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+func TestSetAndVerifyMACs(t *testing.T) {
+	testCases := map[string]struct {
+		segment bool
+		reverse bool
+	}{
+		"e2e":             {},
+		"segment":         {segment: true},
+		"e2e reverse":     {reverse: true},
+		"segment reverse": {segment: true, reverse: true},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			p := newPath()
+			p.InfoField.S = tc.segment
+			keys := []hash.Hash{newMac(t, 1), newMac(t, 2), newMac(t, 3)}
+			auths := make([][]byte, len(p.HopFields))
+			for i, hf := range p.HopFields {
+				auths[i] = colibri.HopAuthenticator(keys[i], &p.InfoField, hf)
+				assert.Len(t, auths[i], colibri.AuthenticatorLen)
+			}
+			if tc.reverse {
+				require.NoError(t, p.Reverse())
+				keys[0], keys[2] = keys[2], keys[0]
+			}
+			require.NoError(t, p.SetMACs(auths))
+			for i, hf := range p.HopFields {
+				assert.NoError(t, colibri.VerifyMAC(keys[i], &p.InfoField, hf), "hop %d", i)
+			}
+			// a different key fails
+			assert.Error(t, colibri.VerifyMAC(newMac(t, 4), &p.InfoField, p.HopFields[0]))
+			// e2e MACs change with every packet
+			p.InfoField.PacketTimestamp++
+			err := colibri.VerifyMAC(keys[0], &p.InfoField, p.HopFields[0])
+			if tc.segment {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSetMACsErrors(t *testing.T) {
+	p := newPath()
+	assert.Error(t, p.SetMACs(make([][]byte, 2)))
+	assert.Error(t, p.SetMACs(make([][]byte, 3)))
+}
+
+func newMac(t *testing.T, key byte) hash.Hash {
+	t.Helper()
+	mac, err := scrypto.InitMac(append([]byte{key}, make([]byte, 15)...))
+	require.NoError(t, err)
+	return mac
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 )
@@ -265,7 +266,12 @@ func (s *SCION) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
 			break
 		}
 		s.Path = &onehop.Path{}
-	case PathTypeEPIC, PathTypeCOLIBRI:
+	case PathTypeCOLIBRI:
+		if _, ok := s.Path.(*colibri.Path); ok {
+			break
+		}
+		s.Path = &colibri.Path{}
+	case PathTypeEPIC:
 		return serrors.New("unsupported path type", "type", s.PathType.String())
 	default:
 		return serrors.New("unknown path type", "type", s.PathType.String())
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
@@ -54,6 +55,34 @@ func TestSCIONSerializeDecode(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestSCIONSerializeDecodeColibri(t *testing.T) {
+	want := prepPacket(t, common.L4UDP)
+	want.PathType = slayers.PathTypeCOLIBRI
+	want.Path = &colibri.Path{
+		InfoField: colibri.InfoField{
+			CurrHF:   1,
+			HFCount:  2,
+			BWCls:    13,
+			Idx:      2,
+			PathType: 5,
+			ExpTick:  0x100,
+			ResID:    [colibri.ResIDLen]byte{0xff, 0, 0, 0, 0x1, 0x11, 1},
+		},
+		HopFields: []*colibri.HopField{
+			{ConsIngress: 0, ConsEgress: 1, Mac: []byte{1, 2, 3, 4}},
+			{ConsIngress: 2, ConsEgress: 0, Mac: []byte{5, 6, 7, 8}},
+		},
+	}
+	buffer := gopacket.NewSerializeBuffer()
+	require.NoError(t, want.SerializeTo(buffer, gopacket.SerializeOptions{FixLengths: true}))
+
+	got := &slayers.SCION{}
+	assert.NoError(t, got.DecodeFromBytes(buffer.Bytes(), gopacket.NilDecodeFeedback),
+		"DecodeFromBytes")
+	want.BaseLayer = got.BaseLayer
+	assert.Equal(t, want, got)
+}
+
 func TestSetAndGetAddr(t *testing.T) {
 	testCases := map[string]struct {
 		srcAddr net.Addr
@@ -118,7 +118,17 @@ var scmpTypeCodeInfo = map[uint8]struct {
 	name  string
 	codes map[uint8]string
 }{
-	SCMPTypeDestinationUnreachable:   {name: "DestinationUnreachable"},
+	SCMPTypeDestinationUnreachable: {
+		"DestinationUnreachable", map[uint8]string{
+			SCMPCodeNoRoute:                   "NoRoute",
+			SCMPCodeAdminDeny:                 "AdminDeny",
+			SCMPCodeBeyondScopeOfSourceAddr:   "BeyondScopeOfSourceAddr",
+			SCMPCodeAddressUnreachable:        "AddressUnreachable",
+			SCMPCodePortUnreachable:           "PortUnreachable",
+			SCMPCodeSourceAddressFailedPolicy: "SourceAddressFailedPolicy",
+			SCMPCodeRejectRouteToDest:         "RejectRouteToDest",
+		},
+	},
 	SCMPTypeExternalInterfaceDown:    {name: "ExternalInterfaceDown"},
 	SCMPTypeInternalConnectivityDown: {name: "InternalConnectivityDown"},
 	SCMPTypePacketTooBig:             {name: "PacketTooBig"},
@@ -35,6 +35,10 @@ func TestTypeSCMPCodeString(t *testing.T) {
 			c:    slayers.CreateSCMPTypeCode(4, 0),
 			want: "ParameterProblem(ErroneousHeaderField)",
 		},
+		"destination unreachable known code": {
+			c:    slayers.CreateSCMPTypeCode(1, 4),
+			want: "DestinationUnreachable(PortUnreachable)",
+		},
 		"known type unknown code": {
 			c:    slayers.CreateSCMPTypeCode(4, 100),
 			want: "ParameterProblem(Code: 100)",
@@ -57,7 +57,19 @@ func TestSCIONSCMP(t *testing.T) {
 				gopacket.Payload(bytes.Repeat([]byte{0xff}, 18)),
 			},
 		},
-		// "packet too big":          {},
+		"packet too big": {
+			rawFile: filepath.Join(goldenDir, "scion-scmp-packet-too-big.bin"),
+			decodedLayers: []gopacket.SerializableLayer{
+				prepPacket(t, common.L4SCMP),
+				&slayers.SCMP{
+					TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypePacketTooBig, 0),
+				},
+				&slayers.SCMPPacketTooBig{
+					MTU: 1280,
+				},
+				gopacket.Payload(bytes.Repeat([]byte{0xff}, 18)),
+			},
+		},
 		// "parameter problem":       {},
 		"internal connectivity down": {
 			rawFile: filepath.Join(goldenDir, "scion-scmp-int-conn-down.bin"),
@@ -129,6 +141,12 @@ func TestSCIONSCMP(t *testing.T) {
 					s := sl.(*slayers.SCMPDestinationUnreachable)
 					v.BaseLayer = s.BaseLayer
 					assert.Equal(t, v, s)
+				case *slayers.SCMPPacketTooBig:
+					sl := packet.Layer(slayers.LayerTypeSCMPPacketTooBig)
+					require.NotNil(t, sl, "SCMPPacketTooBig layer should exist")
+					s := sl.(*slayers.SCMPPacketTooBig)
+					v.BaseLayer = s.BaseLayer
+					assert.Equal(t, v, s)
 				case *slayers.SCMPExternalInterfaceDown:
 					sl := packet.Layer(slayers.LayerTypeSCMPExternalInterfaceDown)
 					require.NotNil(t, sl, "SCMPExternalInterfaceDown layer should exist")
@@ -0,0 +1,90 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+// NewColibriPath creates a raw path that sends packets over the e2e reservation with the given ID
+// and token. authenticators contains the hop authenticators of the ASes on the reservation path,
+// in reservation direction; they are used to compute the per packet MACs when writing. The
+// returned path can be used as the Path of a UDPAddr or SVCAddr passed to a Conn.
+func NewColibriPath(id *reservation.E2EID, tok *reservation.Token,
+	authenticators [][]byte) (*spath.Path, error) {
+
+	var resID [colibri.ResIDLen]byte
+	if _, err := id.Read(resID[:]); err != nil {
+		return nil, err
+	}
+	return newColibriPath(resID, false, tok, authenticators)
+}
+
+// NewColibriSegmentPath creates a raw path that sends packets directly over the segment
+// reservation with the given ID and token, e.g. for COLIBRI control traffic. authenticators is as
+// in NewColibriPath.
+func NewColibriSegmentPath(id *reservation.SegmentID, tok *reservation.Token,
+	authenticators [][]byte) (*spath.Path, error) {
+
+	var resID [colibri.ResIDLen]byte
+	if _, err := id.Read(resID[:]); err != nil {
+		return nil, err
+	}
+	return newColibriPath(resID, true, tok, authenticators)
+}
+
+func newColibriPath(resID [colibri.ResIDLen]byte, segment bool, tok *reservation.Token,
+	authenticators [][]byte) (*spath.Path, error) {
+
+	if tok == nil {
+		return nil, serrors.New("missing token")
+	}
+	if len(tok.HopFields) == 0 || len(tok.HopFields) > colibri.MaxHops {
+		return nil, serrors.New("invalid number of hop fields", "actual", len(tok.HopFields),
+			"max", colibri.MaxHops)
+	}
+	if authenticators != nil && len(authenticators) != len(tok.HopFields) {
+		return nil, serrors.New("wrong number of hop authenticators",
+			"expected", len(tok.HopFields), "actual", len(authenticators))
+	}
+	p := colibri.Path{
+		InfoField: colibri.InfoField{
+			S:        segment,
+			HFCount:  uint8(len(tok.HopFields)),
+			BWCls:    uint8(tok.BWCls),
+			RLC:      uint8(tok.RLC),
+			Idx:      uint8(tok.Idx),
+			PathType: uint8(tok.PathType),
+			ExpTick:  uint32(tok.ExpirationTick),
+			ResID:    resID,
+		},
+		HopFields: make([]*colibri.HopField, 0, len(tok.HopFields)),
+	}
+	for _, hf := range tok.HopFields {
+		p.HopFields = append(p.HopFields, &colibri.HopField{
+			ConsIngress: uint16(hf.ConsIngress),
+			ConsEgress:  uint16(hf.ConsEgress),
+			Mac:         append([]byte(nil), hf.Mac...),
+		})
+	}
+	raw := make([]byte, p.Len())
+	if err := p.SerializeTo(raw); err != nil {
+		return nil, err
+	}
+	return spath.NewColibri(raw, authenticators), nil
+}
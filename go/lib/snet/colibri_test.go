@@ -0,0 +1,96 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestNewColibriPath(t *testing.T) {
+	tok := &reservation.Token{
+		InfoField: reservation.InfoField{
+			ExpirationTick: 0x100,
+			BWCls:          13,
+			RLC:            4,
+			Idx:            3,
+			PathType:       reservation.CorePath,
+		},
+		HopFields: []spath.HopField{
+			{ConsIngress: 0, ConsEgress: 1},
+			{ConsIngress: 2, ConsEgress: 3},
+			{ConsIngress: 4, ConsEgress: 0},
+		},
+	}
+	auths := make([][]byte, 3)
+	for i := range auths {
+		auths[i] = make([]byte, colibri.AuthenticatorLen)
+	}
+
+	t.Run("e2e", func(t *testing.T) {
+		id, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:111"),
+			xtest.MustParseHexString("0123456789abcdef0123"))
+		require.NoError(t, err)
+		p, err := snet.NewColibriPath(id, tok, auths)
+		require.NoError(t, err)
+		assert.True(t, p.IsHeaderV2())
+		assert.True(t, p.IsColibri())
+		assert.Equal(t, auths, p.ColibriAuthenticators())
+
+		var decoded colibri.Path
+		require.NoError(t, decoded.DecodeFromBytes(p.Raw))
+		assert.False(t, decoded.InfoField.S)
+		assert.Equal(t, uint8(3), decoded.InfoField.HFCount)
+		assert.Equal(t, uint8(13), decoded.InfoField.BWCls)
+		assert.Equal(t, uint8(4), decoded.InfoField.RLC)
+		assert.Equal(t, uint8(3), decoded.InfoField.Idx)
+		assert.Equal(t, uint8(reservation.CorePath), decoded.InfoField.PathType)
+		assert.Equal(t, uint32(0x100), decoded.InfoField.ExpTick)
+		assert.Equal(t, id.ToRaw(), decoded.InfoField.ResID[:])
+		assert.Equal(t, uint16(2), decoded.HopFields[1].ConsIngress)
+		assert.Equal(t, uint16(3), decoded.HopFields[1].ConsEgress)
+	})
+	t.Run("segment", func(t *testing.T) {
+		id, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:111"),
+			xtest.MustParseHexString("01234567"))
+		require.NoError(t, err)
+		p, err := snet.NewColibriSegmentPath(id, tok, nil)
+		require.NoError(t, err)
+		assert.True(t, p.IsColibri())
+
+		var decoded colibri.Path
+		require.NoError(t, decoded.DecodeFromBytes(p.Raw))
+		assert.True(t, decoded.InfoField.S)
+		assert.Equal(t, xtest.MustParseHexString("ff000000011101234567000000000000"),
+			decoded.InfoField.ResID[:])
+	})
+	t.Run("errors", func(t *testing.T) {
+		id := &reservation.E2EID{}
+		_, err := snet.NewColibriPath(id, nil, nil)
+		assert.Error(t, err)
+		_, err = snet.NewColibriPath(id, &reservation.Token{}, nil)
+		assert.Error(t, err)
+		_, err = snet.NewColibriPath(id, tok, auths[:2])
+		assert.Error(t, err)
+	})
+}
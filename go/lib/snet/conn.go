@@ -38,6 +38,9 @@ var _ Error = (*OpError)(nil)
 type OpError struct {
 	scmp    *scmp.Hdr
 	revInfo *path_mgmt.RevInfo
+	mtu     uint16
+	// interfaceDown is set for SCMP errors that report interfaces down.
+	interfaceDown *scmp.InfoInterfaceDown
 }
 
 func (e *OpError) SCMP() *scmp.Hdr {
@@ -48,6 +51,19 @@ func (e *OpError) RevInfo() *path_mgmt.RevInfo {
 	return e.revInfo
 }
 
+// MTU returns the MTU reported by an SCMP packet too big error. If the error
+// does not carry an MTU, zero is returned.
+func (e *OpError) MTU() uint16 {
+	return e.mtu
+}
+
+// InterfaceDown returns the interfaces reported down by an SCMP external
+// interface down or internal connectivity down error. If the error does not
+// report any interfaces, nil is returned.
+func (e *OpError) InterfaceDown() *scmp.InfoInterfaceDown {
+	return e.interfaceDown
+}
+
 func (e *OpError) Error() string {
 	return e.scmp.String()
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/scmp"
 	"github.com/scionproto/scion/go/lib/snet/internal/metrics"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/spath"
 )
 
 // PacketDispatcherService constructs SCION sockets where applications have
@@ -103,6 +104,9 @@ func NewSCMPHandler(rh RevocationHandler) SCMPHandler {
 type scmpHandler struct {
 	// revocationHandler manages revocations received via SCMP. If nil, the handler is not called.
 	revocationHandler RevocationHandler
+	// pathMTUs is updated with the MTUs received via SCMP packet too big errors. If nil, the
+	// MTUs are only passed back to the caller.
+	pathMTUs *PathMTUs
 }
 
 func (h *scmpHandler) Handle(pkt *Packet) error {
@@ -118,10 +122,15 @@ func (h *scmpHandler) Handle(pkt *Packet) error {
 		metrics.M.SCMPErrors().Inc()
 	}
 
-	// Only handle revocations for now
 	if hdr.Class == scmp.C_Path && hdr.Type == scmp.T_P_RevokedIF {
 		return h.handleSCMPRev(hdr, pkt)
 	}
+	if hdr.Class == scmp.C_Routing && hdr.Type == scmp.T_R_OversizePkt {
+		return h.handleSCMPPacketTooBig(hdr, pkt)
+	}
+	if hdr.Class == scmp.C_Path && hdr.Type == scmp.T_P_BadIF {
+		return h.handleSCMPBadIF(hdr, pkt)
+	}
 	log.Debug("Ignoring scmp packet", "hdr", hdr, "src", pkt.Source)
 	return nil
 }
@@ -150,3 +159,48 @@ func (h *scmpHandler) handleSCMPRev(hdr *scmp.Hdr, pkt *Packet) error {
 	}
 	return &OpError{scmp: hdr, revInfo: revInfo}
 }
+
+func (h *scmpHandler) handleSCMPPacketTooBig(hdr *scmp.Hdr, pkt *Packet) error {
+	scmpPayload, ok := pkt.Payload.(*scmp.Payload)
+	if !ok {
+		return common.NewBasicError("Unable to type assert payload to SCMP payload", nil,
+			"type", common.TypeOf(pkt.Payload))
+	}
+	info, ok := scmpPayload.Info.(*scmp.InfoPktSize)
+	if !ok {
+		return common.NewBasicError("Unable to type assert SCMP Info to SCMP Pkt Size Info", nil,
+			"type", common.TypeOf(scmpPayload.Info))
+	}
+	if h.pathMTUs != nil && len(scmpPayload.PathHdr) > 0 {
+		h.pathMTUs.Update(quotedPath(pkt, scmpPayload.PathHdr), info.MTU)
+	}
+	return &OpError{scmp: hdr, mtu: info.MTU}
+}
+
+// handleSCMPBadIF returns the interfaces reported down by an SCMP error in the
+// new format to the caller. Legacy bad interface errors are ignored.
+func (h *scmpHandler) handleSCMPBadIF(hdr *scmp.Hdr, pkt *Packet) error {
+	scmpPayload, ok := pkt.Payload.(*scmp.Payload)
+	if !ok {
+		return common.NewBasicError("Unable to type assert payload to SCMP payload", nil,
+			"type", common.TypeOf(pkt.Payload))
+	}
+	info, ok := scmpPayload.Info.(*scmp.InfoInterfaceDown)
+	if !ok {
+		log.Debug("Ignoring scmp packet", "hdr", hdr, "src", pkt.Source)
+		return nil
+	}
+	return &OpError{scmp: hdr, interfaceDown: info}
+}
+
+// quotedPath returns the path quoted in an SCMP error. The quoted path has the
+// same header version as the packet that carries the error. Note that v2
+// packets without a path are parsed with a nil path, whereas legacy packets
+// have an empty path.
+func quotedPath(pkt *Packet, raw common.RawBytes) *spath.Path {
+	raw = append(common.RawBytes(nil), raw...)
+	if pkt.Path == nil || pkt.Path.IsHeaderV2() {
+		return spath.NewV2(raw, false)
+	}
+	return spath.New(raw)
+}
@@ -16,6 +16,7 @@ package snet
 
 import (
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 )
@@ -28,3 +29,9 @@ func NewScionConnBase(localIA addr.IA, listen *net.UDPAddr) *scionConnBase {
 		scionNet: &SCIONNetwork{LocalIA: localIA},
 	}
 }
+
+func NewPathMTUsWithClock(now func() time.Time) *PathMTUs {
+	m := NewPathMTUs()
+	m.now = now
+	return m
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multipath implements a SCION connection that sends traffic to a
+// single remote over multiple paths.
+//
+// The paths are kept up to date by the path manager. If a revocation or an
+// interface down error is received through the snet.SCMPHandler, i.e., if a
+// Read call on the underlying connection returns an *snet.OpError carrying
+// revocation info or interfaces that are down, the paths that cross the
+// affected interfaces are excluded and the connection fails over to the
+// remaining paths. The error is not returned to the caller.
+//
+// Depending on the mode, traffic is either sent on a single path, or spread
+// round-robin or by weight across disjoint paths.
+//
+// As with snet connections, the connection must be drained with Read calls in
+// order to receive revocations. Packets that are not sent by the remote are
+// dropped.
+package multipath
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathmgr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// ErrNoPath indicates that no path to the remote is usable.
+var ErrNoPath = serrors.New("no path available")
+
+// Mode determines how traffic is spread across the paths.
+type Mode int
+
+const (
+	// Failover sends all traffic on a single path. A different path is only
+	// selected if the current one becomes unusable.
+	Failover Mode = iota
+	// RoundRobin spreads the traffic evenly across disjoint paths.
+	RoundRobin
+	// Weighted spreads the traffic across disjoint paths proportionally to
+	// their weights.
+	Weighted
+)
+
+// Config configures a multipath connection.
+type Config struct {
+	// Mode determines how traffic is spread across the paths. The default is
+	// Failover.
+	Mode Mode
+	// Weight returns the weight of a path in Weighted mode. Paths with a
+	// weight smaller than 1 are not used. If nil, all paths have weight 1.
+	Weight func(snet.Path) int
+}
+
+var _ net.Conn = (*Conn)(nil)
+
+// Conn is a connection to a single remote that sends traffic over the
+// paths provided by the path manager.
+type Conn struct {
+	conn   net.PacketConn
+	remote *snet.UDPAddr
+	pool   *pool
+}
+
+// NewConn creates a multipath connection to remote. Packets are sent and
+// received with conn, which must not be connected to a remote. The paths are
+// usually obtained with pathmgr.Resolver.WatchFilter. The connection takes
+// ownership of conn and paths.
+func NewConn(conn net.PacketConn, remote *snet.UDPAddr, paths *pathmgr.SyncPaths,
+	cfg Config) *Conn {
+
+	return &Conn{
+		conn:   conn,
+		remote: remote.Copy(),
+		pool:   newPool(paths, cfg),
+	}
+}
+
+// Read reads a packet from the remote. Revocations and interface down errors
+// are used to update the paths and are not returned. Packets from other
+// sources are dropped.
+func (c *Conn) Read(b []byte) (int, error) {
+	for {
+		n, src, err := c.conn.ReadFrom(b)
+		var opErr *snet.OpError
+		if errors.As(err, &opErr) {
+			if opErr.RevInfo() != nil {
+				c.pool.Revoke(opErr.RevInfo())
+				continue
+			}
+			if opErr.InterfaceDown() != nil {
+				c.pool.InterfaceDown(opErr.InterfaceDown())
+				continue
+			}
+		}
+		if err != nil {
+			return n, err
+		}
+		if !c.fromRemote(src) {
+			continue
+		}
+		return n, nil
+	}
+}
+
+// fromRemote indicates whether src is the address of the remote.
+func (c *Conn) fromRemote(src net.Addr) bool {
+	a, ok := src.(*snet.UDPAddr)
+	if !ok || a.IA != c.remote.IA || a.Host == nil || c.remote.Host == nil {
+		return false
+	}
+	return a.Host.IP.Equal(c.remote.Host.IP) && a.Host.Port == c.remote.Host.Port
+}
+
+// Write sends b to the remote on the path selected according to the mode.
+// If no path is usable, ErrNoPath is returned.
+func (c *Conn) Write(b []byte) (int, error) {
+	path := c.pool.Select()
+	if path == nil {
+		return 0, serrors.WithCtx(ErrNoPath, "remote", c.remote.IA)
+	}
+	remote := c.remote.Copy()
+	remote.Path = path.Path()
+	remote.NextHop = path.UnderlayNextHop()
+	return c.conn.WriteTo(b, remote)
+}
+
+// Paths returns the paths that traffic is currently sent on.
+func (c *Conn) Paths() []snet.Path {
+	return c.pool.Paths()
+}
+
+// Close closes the underlying connection and stops the path updates.
+func (c *Conn) Close() error {
+	c.pool.paths.Destroy()
+	return c.conn.Close()
+}
+
+func (c *Conn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.remote.Copy()
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// Dialer creates multipath connections.
+type Dialer struct {
+	// Network is used to register the connections with the dispatcher.
+	Network snet.Network
+	// Resolver provides the paths to the remote.
+	Resolver pathmgr.Resolver
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// Policy filters the paths. If nil, all paths are used.
+	Policy pathmgr.Policy
+	// Config configures the created connections.
+	Config Config
+}
+
+// Dial creates a multipath connection from listen to remote. The context is
+// used for connection setup, it doesn't affect the returned connection.
+func (d *Dialer) Dial(ctx context.Context, listen *net.UDPAddr,
+	remote *snet.UDPAddr) (*Conn, error) {
+
+	if remote == nil {
+		return nil, serrors.New("nil remote not supported")
+	}
+	paths, err := d.Resolver.WatchFilter(ctx, d.LocalIA, remote.IA, d.Policy)
+	if err != nil {
+		return nil, serrors.WrapStr("watching paths", err, "remote", remote.IA)
+	}
+	conn, err := d.Network.Listen(ctx, "udp", listen, addr.SvcNone)
+	if err != nil {
+		paths.Destroy()
+		return nil, err
+	}
+	return NewConn(conn, remote, paths, d.Config), nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/hpkt"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestConnWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	remote := &snet.UDPAddr{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Host: &net.UDPAddr{IP: net.IP{192, 168, 0, 1}, Port: 4242},
+	}
+	a := newWritePath(ctrl, []byte{1}, &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 30041},
+		"1-ff00:0:110#1", "1-ff00:0:111#2")
+	b := newWritePath(ctrl, []byte{2}, &net.UDPAddr{IP: net.IP{10, 0, 0, 2}, Port: 30041},
+		"1-ff00:0:110#3", "1-ff00:0:111#4")
+
+	pconn := mock_net.NewMockPacketConn(ctrl)
+	conn := NewConn(pconn, remote, newSyncPaths(a, b), Config{Mode: RoundRobin})
+	assert.Equal(t, remote, conn.RemoteAddr())
+
+	var nextHops []string
+	pconn.EXPECT().WriteTo([]byte("hello"), gomock.Any()).DoAndReturn(
+		func(b []byte, raddr net.Addr) (int, error) {
+			a := raddr.(*snet.UDPAddr)
+			assert.Equal(t, remote.IA, a.IA)
+			assert.Equal(t, remote.Host, a.Host)
+			require.NotNil(t, a.Path)
+			nextHops = append(nextHops, a.NextHop.String())
+			return len(b), nil
+		},
+	).Times(3)
+	for i := 0; i < 3; i++ {
+		n, err := conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+	}
+	assert.Equal(t, []string{"10.0.0.1:30041", "10.0.0.2:30041", "10.0.0.1:30041"},
+		nextHops)
+}
+
+func TestConnWriteNoPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	remote := &snet.UDPAddr{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Host: &net.UDPAddr{IP: net.IP{192, 168, 0, 1}, Port: 4242},
+	}
+	conn := NewConn(mock_net.NewMockPacketConn(ctrl), remote, newSyncPaths(), Config{})
+	_, err := conn.Write([]byte("hello"))
+	assert.True(t, errors.Is(err, ErrNoPath))
+}
+
+func TestConnReadInterfaceDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	remote := &snet.UDPAddr{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Host: &net.UDPAddr{IP: net.IP{192, 168, 0, 1}, Port: 4242},
+	}
+	short := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#1", "1-ff00:0:111#2")
+	long := newTestPath(ctrl, time.Time{},
+		"1-ff00:0:110#3", "1-ff00:0:112#4", "1-ff00:0:112#5", "1-ff00:0:111#6")
+
+	pconn := mock_net.NewMockPacketConn(ctrl)
+	conn := NewConn(pconn, remote, newSyncPaths(short, long), Config{})
+	require.Equal(t, []snet.Path{short}, conn.Paths())
+
+	other := &snet.UDPAddr{
+		IA:   remote.IA,
+		Host: &net.UDPAddr{IP: net.IP{192, 168, 0, 2}, Port: 4242},
+	}
+	gomock.InOrder(
+		pconn.EXPECT().ReadFrom(gomock.Any()).Return(0, nil,
+			interfaceDownError(t, xtest.MustParseIA("1-ff00:0:111"), 2)),
+		pconn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+			func(b []byte) (int, net.Addr, error) {
+				return copy(b, "spoofed"), other, nil
+			},
+		),
+		pconn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+			func(b []byte) (int, net.Addr, error) {
+				return copy(b, "hello"), remote.Copy(), nil
+			},
+		),
+	)
+	b := make([]byte, 16)
+	n, err := conn.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b[:n]), "packets from other sources are dropped")
+	assert.Equal(t, []snet.Path{long}, conn.Paths(), "fail over on interface down")
+}
+
+// interfaceDownError returns the error that the default SCMP handler returns
+// for an SCMP external interface down message in the new format.
+func interfaceDownError(t *testing.T, ia addr.IA, ifID uint64) error {
+	// The offending packet that is quoted in the SCMP message.
+	quote := make([]byte, common.MaxMTU)
+	n, err := hpkt.WriteScnPkt2(&spkt.ScnPkt{
+		SrcIA:   xtest.MustParseIA("1-ff00:0:110"),
+		DstIA:   xtest.MustParseIA("1-ff00:0:111"),
+		SrcHost: addr.HostFromIP(net.IP{127, 0, 0, 1}),
+		DstHost: addr.HostFromIP(net.IP{192, 168, 0, 1}),
+		L4:      &l4.UDP{SrcPort: 40000, DstPort: 4242},
+		Pld:     common.RawBytes("hello"),
+	}, quote)
+	require.NoError(t, err)
+	quote = quote[:n]
+
+	var scn slayers.SCION
+	require.NoError(t, scn.DecodeFromBytes(quote, gopacket.NilDecodeFeedback))
+	scn.NextHdr = common.L4SCMP
+	scmpLayer := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+	}
+	require.NoError(t, scmpLayer.SetNetworkLayerForChecksum(&scn))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, &scn, scmpLayer,
+		&slayers.SCMPExternalInterfaceDown{IA: ia, IfID: ifID}, gopacket.Payload(quote)))
+
+	parsed := &spkt.ScnPkt{}
+	require.NoError(t, hpkt.ParseScnPkt2(parsed, buf.Bytes()))
+	pkt := &snet.Packet{PacketInfo: snet.PacketInfo{L4Header: parsed.L4, Payload: parsed.Pld}}
+	err = snet.NewSCMPHandler(nil).Handle(pkt)
+	require.Error(t, err)
+	return err
+}
+
+func newWritePath(ctrl *gomock.Controller, raw []byte, nextHop *net.UDPAddr,
+	intfs ...string) snet.Path {
+
+	path := newTestPath(ctrl, time.Time{}, intfs...).(*mock_snet.MockPath)
+	path.EXPECT().Path().DoAndReturn(func() *spath.Path {
+		return spath.New(append([]byte(nil), raw...))
+	}).AnyTimes()
+	path.EXPECT().UnderlayNextHop().Return(nextHop).AnyTimes()
+	return path
+}
@@ -0,0 +1,269 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/pathmgr"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// intf identifies an interface of an AS.
+type intf struct {
+	ia   addr.IA
+	ifID common.IFIDType
+}
+
+// pool keeps track of the paths to a single destination that are usable for
+// sending traffic. Paths are taken from the path manager and paths that cross
+// revoked interfaces are excluded until the revocation expires.
+type pool struct {
+	paths *pathmgr.SyncPaths
+	mode  Mode
+	// weight returns the weight of a path in Weighted mode.
+	weight func(snet.Path) int
+
+	mtx sync.Mutex
+	// revoked maps the revoked interfaces to the expiration time of their
+	// revocation.
+	revoked map[intf]time.Time
+	// modified is the modification time of the path set the usable paths
+	// were computed from.
+	modified time.Time
+	// dirty indicates that the usable paths must be recomputed.
+	dirty bool
+	// usable contains the paths that traffic is spread across.
+	usable []snet.Path
+	// current is the path that is used in Failover mode.
+	current snet.Path
+	// next is the index of the next path in RoundRobin mode.
+	next int
+	// credits holds the current credit of every usable path in Weighted
+	// mode.
+	credits []int
+}
+
+func newPool(paths *pathmgr.SyncPaths, cfg Config) *pool {
+	weight := cfg.Weight
+	if weight == nil {
+		weight = func(snet.Path) int { return 1 }
+	}
+	return &pool{
+		paths:   paths,
+		mode:    cfg.Mode,
+		weight:  weight,
+		revoked: make(map[intf]time.Time),
+		dirty:   true,
+	}
+}
+
+// Revoke excludes all paths that cross the revoked interface until the
+// revocation expires.
+func (p *pool) Revoke(revInfo *path_mgmt.RevInfo) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.exclude(intf{ia: revInfo.IA(), ifID: revInfo.IfID}, revInfo.Expiration())
+}
+
+// InterfaceDown excludes all paths that cross one of the interfaces that are
+// reported down. As the report carries no lifetime, the paths are excluded for
+// the minimum lifetime of a revocation.
+func (p *pool) InterfaceDown(info *scmp.InfoInterfaceDown) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	exp := time.Now().Add(path_mgmt.MinRevTTL)
+	for _, ifID := range info.Interfaces() {
+		p.exclude(intf{ia: info.IA, ifID: ifID}, exp)
+	}
+}
+
+// exclude excludes the paths that cross the interface until exp. The caller
+// must hold the lock.
+func (p *pool) exclude(key intf, exp time.Time) {
+	if exp.After(p.revoked[key]) {
+		p.revoked[key] = exp
+	}
+	p.dirty = true
+}
+
+// Select returns the path the next packet is sent on. If no path is usable,
+// nil is returned.
+func (p *pool) Select() snet.Path {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.refresh(time.Now())
+	if len(p.usable) == 0 {
+		return nil
+	}
+	switch p.mode {
+	case RoundRobin:
+		path := p.usable[p.next%len(p.usable)]
+		p.next = (p.next + 1) % len(p.usable)
+		return path
+	case Weighted:
+		return p.selectWeighted()
+	default:
+		return p.current
+	}
+}
+
+// Paths returns the paths that traffic is currently spread across.
+func (p *pool) Paths() []snet.Path {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.refresh(time.Now())
+	if p.mode == Failover {
+		if p.current == nil {
+			return nil
+		}
+		return []snet.Path{p.current}
+	}
+	return append([]snet.Path(nil), p.usable...)
+}
+
+// selectWeighted implements smooth weighted round robin. Every path gains
+// credit according to its weight, and the path with the most credit is
+// selected and charged the total weight of all paths.
+func (p *pool) selectWeighted() snet.Path {
+	best, total := 0, 0
+	for i, path := range p.usable {
+		w := p.weight(path)
+		total += w
+		p.credits[i] += w
+		if p.credits[i] > p.credits[best] {
+			best = i
+		}
+	}
+	p.credits[best] -= total
+	return p.usable[best]
+}
+
+// refresh recomputes the usable paths if the path set changed, or if a
+// revocation was added or has expired since the last computation.
+func (p *pool) refresh(now time.Time) {
+	for key, exp := range p.revoked {
+		if !exp.After(now) {
+			delete(p.revoked, key)
+			p.dirty = true
+		}
+	}
+	data := p.paths.Load()
+	if !p.dirty && data.ModifyTime.Equal(p.modified) && !p.expired(now) {
+		return
+	}
+	p.modified = data.ModifyTime
+	p.dirty = false
+
+	var candidates []snet.Path
+	for _, path := range data.APS {
+		if p.isRevoked(path) || isExpired(path, now) {
+			continue
+		}
+		candidates = append(candidates, path)
+	}
+	// Prefer short paths, and break ties by fingerprint to get a stable
+	// order.
+	sort.Slice(candidates, func(i, j int) bool {
+		li, lj := len(candidates[i].Interfaces()), len(candidates[j].Interfaces())
+		if li != lj {
+			return li < lj
+		}
+		return candidates[i].Fingerprint() < candidates[j].Fingerprint()
+	})
+	p.current = keepCurrent(p.current, candidates)
+
+	switch p.mode {
+	case RoundRobin:
+		p.usable = disjoint(candidates)
+		p.next = 0
+	case Weighted:
+		p.usable = nil
+		for _, path := range disjoint(candidates) {
+			if p.weight(path) > 0 {
+				p.usable = append(p.usable, path)
+			}
+		}
+		p.credits = make([]int, len(p.usable))
+	default:
+		p.usable = candidates
+	}
+}
+
+// expired indicates whether one of the paths in use has expired.
+func (p *pool) expired(now time.Time) bool {
+	for _, path := range p.usable {
+		if isExpired(path, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pool) isRevoked(path snet.Path) bool {
+	for _, pi := range path.Interfaces() {
+		if _, ok := p.revoked[intf{ia: pi.IA(), ifID: pi.ID()}]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// keepCurrent returns the up-to-date version of the current path if it is
+// still among the candidates. Otherwise, the first candidate is returned.
+func keepCurrent(current snet.Path, candidates []snet.Path) snet.Path {
+	if len(candidates) == 0 {
+		return nil
+	}
+	if current != nil {
+		for _, path := range candidates {
+			if path.Fingerprint() == current.Fingerprint() {
+				return path
+			}
+		}
+	}
+	return candidates[0]
+}
+
+// disjoint greedily selects the paths that do not share any interface with a
+// previously selected path. The order of the paths is preserved.
+func disjoint(paths []snet.Path) []snet.Path {
+	used := make(map[intf]struct{})
+	var selected []snet.Path
+Outer:
+	for _, path := range paths {
+		for _, pi := range path.Interfaces() {
+			if _, ok := used[intf{ia: pi.IA(), ifID: pi.ID()}]; ok {
+				continue Outer
+			}
+		}
+		for _, pi := range path.Interfaces() {
+			used[intf{ia: pi.IA(), ifID: pi.ID()}] = struct{}{}
+		}
+		selected = append(selected, path)
+	}
+	return selected
+}
+
+func isExpired(path snet.Path, now time.Time) bool {
+	exp := path.Expiry()
+	return !exp.IsZero() && !exp.After(now)
+}
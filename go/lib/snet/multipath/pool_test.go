@@ -0,0 +1,170 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/pathmgr"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath/spathmeta"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPoolFailover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	short := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#1", "1-ff00:0:111#2")
+	long := newTestPath(ctrl, time.Time{},
+		"1-ff00:0:110#3", "1-ff00:0:112#4", "1-ff00:0:112#5", "1-ff00:0:111#6")
+	sp := newSyncPaths(short, long)
+	p := newPool(sp, Config{})
+
+	assert.Equal(t, short, p.Select(), "shortest path is preferred")
+	assert.Equal(t, []snet.Path{short}, p.Paths())
+
+	p.Revoke(newRevInfo("1-ff00:0:111", 2, time.Now().Add(time.Minute)))
+	assert.Equal(t, long, p.Select(), "fail over on revocation")
+
+	// Once the path set changes, the current path is kept if it is still
+	// available, even if a shorter path appears.
+	other := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#7", "1-ff00:0:111#8")
+	sp.Update(spathmeta.NewAppPathSet([]snet.Path{short, long, other}))
+	assert.Equal(t, long, p.Select())
+
+	p.Revoke(newRevInfo("1-ff00:0:112", 4, time.Now().Add(time.Minute)))
+	assert.Equal(t, other, p.Select())
+	p.Revoke(newRevInfo("1-ff00:0:111", 8, time.Now().Add(time.Minute)))
+	assert.Nil(t, p.Select())
+	assert.Empty(t, p.Paths())
+}
+
+func TestPoolRevocationExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#1", "1-ff00:0:111#2")
+	p := newPool(newSyncPaths(path), Config{})
+
+	p.Revoke(newRevInfo("1-ff00:0:111", 2, time.Now().Add(-time.Second)))
+	assert.Equal(t, path, p.Select(), "expired revocation is ignored")
+	p.Revoke(newRevInfo("1-ff00:0:111", 2, time.Now().Add(time.Minute)))
+	assert.Nil(t, p.Select())
+	p.refresh(time.Now().Add(2 * time.Minute))
+	assert.Equal(t, path, p.current, "path is usable after revocation expired")
+}
+
+func TestPoolExpiredPaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expired := newTestPath(ctrl, time.Now().Add(-time.Second),
+		"1-ff00:0:110#1", "1-ff00:0:111#2")
+	valid := newTestPath(ctrl, time.Now().Add(time.Hour),
+		"1-ff00:0:110#3", "1-ff00:0:112#4", "1-ff00:0:112#5", "1-ff00:0:111#6")
+	p := newPool(newSyncPaths(expired, valid), Config{})
+	assert.Equal(t, valid, p.Select())
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#1", "1-ff00:0:111#2")
+	b := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#3", "1-ff00:0:111#4")
+	// c shares the first interface with a, thus it is not disjoint.
+	c := newTestPath(ctrl, time.Time{},
+		"1-ff00:0:110#1", "1-ff00:0:112#5", "1-ff00:0:112#6", "1-ff00:0:111#7")
+	p := newPool(newSyncPaths(a, b, c), Config{Mode: RoundRobin})
+
+	assert.ElementsMatch(t, []snet.Path{a, b}, p.Paths())
+	var selected []snet.Path
+	for i := 0; i < 4; i++ {
+		selected = append(selected, p.Select())
+	}
+	assert.Equal(t, []snet.Path{a, b, a, b}, selected)
+
+	p.Revoke(newRevInfo("1-ff00:0:111", 2, time.Now().Add(time.Minute)))
+	assert.ElementsMatch(t, []snet.Path{b, c}, p.Paths())
+}
+
+func TestPoolWeighted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#1", "1-ff00:0:111#2")
+	b := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#3", "1-ff00:0:111#4")
+	c := newTestPath(ctrl, time.Time{}, "1-ff00:0:110#5", "1-ff00:0:111#6")
+	weights := map[snet.Path]int{a: 3, b: 1, c: 0}
+	p := newPool(newSyncPaths(a, b, c), Config{
+		Mode:   Weighted,
+		Weight: func(path snet.Path) int { return weights[path] },
+	})
+
+	assert.ElementsMatch(t, []snet.Path{a, b}, p.Paths())
+	counts := make(map[snet.Path]int)
+	for i := 0; i < 8; i++ {
+		counts[p.Select()]++
+	}
+	assert.Equal(t, map[snet.Path]int{a: 6, b: 2}, counts)
+}
+
+func newSyncPaths(paths ...snet.Path) *pathmgr.SyncPaths {
+	sp := pathmgr.NewSyncPaths()
+	sp.Update(spathmeta.NewAppPathSet(paths))
+	return sp
+}
+
+func newRevInfo(ia string, ifID common.IFIDType, exp time.Time) *path_mgmt.RevInfo {
+	return &path_mgmt.RevInfo{
+		IfID:         ifID,
+		RawIsdas:     xtest.MustParseIA(ia).IAInt(),
+		RawTimestamp: util.TimeToSecs(exp.Add(-10 * time.Second)),
+		RawTTL:       10,
+	}
+}
+
+// newTestPath creates a mock path that crosses the interfaces, which are
+// specified in the form ISD-AS#IfID.
+func newTestPath(ctrl *gomock.Controller, expiry time.Time, intfs ...string) snet.Path {
+	var pis []snet.PathInterface
+	for _, s := range intfs {
+		parts := strings.Split(s, "#")
+		var ifID common.IFIDType
+		fmt.Sscan(parts[1], &ifID)
+		pis = append(pis, sciond.PathInterface{
+			RawIsdas: xtest.MustParseIA(parts[0]).IAInt(),
+			IfID:     ifID,
+		})
+	}
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Interfaces().Return(pis).AnyTimes()
+	path.EXPECT().Fingerprint().Return(
+		snet.PathFingerprint(strings.Join(intfs, " "))).AnyTimes()
+	path.EXPECT().Expiry().Return(expiry).AnyTimes()
+	return path
+}
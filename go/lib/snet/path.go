@@ -61,6 +61,9 @@ type Path interface {
 	// Expiry returns the expiration time of the path. If the result is a zero
 	// value expiration time is unknown.
 	Expiry() time.Time
+	// Metadata returns the static information announced for the path. If the
+	// result is nil, no metadata is available.
+	Metadata() *PathMetadata
 	// Copy create a copy of the path.
 	Copy() Path
 }
@@ -75,6 +78,91 @@ type PathInterface interface {
 	IA() addr.IA
 }
 
+// PathMetadata contains the static information that the ASes on a path
+// announce in the StaticInfo extension of the path segments.
+type PathMetadata struct {
+	// Latency is the sum of the announced latencies on the path. Zero means
+	// that the latency is unknown.
+	Latency time.Duration
+	// Bandwidth is the announced bandwidth bottleneck of the path in Kbit/s.
+	// Zero means that the bandwidth is unknown.
+	Bandwidth uint32
+	// InternalHops is the number of AS internal hops on the path.
+	InternalHops uint8
+	// LinkTypes contains the announced types of the inter-AS links on the
+	// path.
+	LinkTypes []LinkType
+	// Geo contains the announced router locations of the ASes on the path.
+	Geo map[addr.IA][]GeoCoordinates
+	// Notes contains the announced notes of the ASes on the path.
+	Notes map[addr.IA]string
+}
+
+// Copy creates a deep copy of the metadata.
+func (m *PathMetadata) Copy() *PathMetadata {
+	if m == nil {
+		return nil
+	}
+	c := &PathMetadata{
+		Latency:      m.Latency,
+		Bandwidth:    m.Bandwidth,
+		InternalHops: m.InternalHops,
+		LinkTypes:    append(m.LinkTypes[:0:0], m.LinkTypes...),
+	}
+	if m.Geo != nil {
+		c.Geo = make(map[addr.IA][]GeoCoordinates, len(m.Geo))
+		for ia, locs := range m.Geo {
+			c.Geo[ia] = append(locs[:0:0], locs...)
+		}
+	}
+	if m.Notes != nil {
+		c.Notes = make(map[addr.IA]string, len(m.Notes))
+		for ia, note := range m.Notes {
+			c.Notes[ia] = note
+		}
+	}
+	return c
+}
+
+// LinkType is the type of an inter-AS link.
+type LinkType uint16
+
+const (
+	// LinkTypeUnset indicates that the link type is unknown.
+	LinkTypeUnset LinkType = iota
+	// LinkTypeDirect is a direct physical connection.
+	LinkTypeDirect
+	// LinkTypeMultihop is a connection with local routing/switching.
+	LinkTypeMultihop
+	// LinkTypeOpennet is a connection overlayed over the public Internet.
+	LinkTypeOpennet
+)
+
+func (t LinkType) String() string {
+	switch t {
+	case LinkTypeDirect:
+		return "direct"
+	case LinkTypeMultihop:
+		return "multihop"
+	case LinkTypeOpennet:
+		return "opennet"
+	default:
+		return "unset"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t LinkType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// GeoCoordinates is the announced location of a router.
+type GeoCoordinates struct {
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
 // partialPath is a path object with incomplete metadata. It is used as a
 // temporary solution where a full path cannot be reconstituted from other
 // objects, notably snet.UDPAddr and snet.SVCAddr.
@@ -115,6 +203,10 @@ func (p *partialPath) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *partialPath) Metadata() *PathMetadata {
+	return nil
+}
+
 func (p *partialPath) Copy() Path {
 	if p == nil {
 		return nil
@@ -0,0 +1,129 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+const (
+	// MinPathMTU is the smallest MTU estimate that is learned from SCMP packet
+	// too big errors. SCMP errors are not authenticated, smaller values are
+	// raised to this minimum such that a spoofed error cannot shrink the
+	// packets sent on a path arbitrarily.
+	MinPathMTU = common.MinMTU
+	// PathMTUTimeout is the time after which a learned MTU estimate is
+	// discarded, such that the estimate falls back to the MTU announced for
+	// the path.
+	PathMTUTimeout = 10 * time.Minute
+)
+
+// PathMTUs keeps track of the path MTU estimates that are learned from SCMP
+// packet too big errors. The estimate of a path starts at the MTU announced
+// for the path, and it is only lowered until it expires after PathMTUTimeout.
+type PathMTUs struct {
+	mtx  sync.Mutex
+	mtus map[string]learnedMTU
+	now  func() time.Time
+}
+
+type learnedMTU struct {
+	mtu     uint16
+	expires time.Time
+}
+
+// NewPathMTUs creates an empty path MTU store.
+func NewPathMTUs() *PathMTUs {
+	return &PathMTUs{mtus: make(map[string]learnedMTU), now: time.Now}
+}
+
+// MTU returns the current MTU estimate of the path. This is the MTU of the
+// path, unless a smaller MTU has been learned for it. If the result is zero,
+// the MTU is unknown.
+func (m *PathMTUs) MTU(path Path) uint16 {
+	mtu := path.MTU()
+	raw := path.Path()
+	if raw == nil || raw.IsEmpty() {
+		return mtu
+	}
+	key := pathMTUKey(raw)
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	learned, ok := m.lookup(key)
+	if ok && (mtu == 0 || learned < mtu) {
+		return learned
+	}
+	return mtu
+}
+
+// Update lowers the MTU estimate of the path to mtu. If the estimate is
+// already lower, it is kept. Values below MinPathMTU are raised to MinPathMTU.
+func (m *PathMTUs) Update(path *spath.Path, mtu uint16) {
+	if path == nil || path.IsEmpty() || mtu == 0 {
+		return
+	}
+	if mtu < MinPathMTU {
+		mtu = MinPathMTU
+	}
+	key := pathMTUKey(path)
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if current, ok := m.lookup(key); !ok || mtu < current {
+		m.mtus[key] = learnedMTU{mtu: mtu, expires: m.now().Add(PathMTUTimeout)}
+	}
+}
+
+// lookup returns the learned MTU estimate for the key. Expired estimates are
+// removed. The caller must hold the lock.
+func (m *PathMTUs) lookup(key string) (uint16, bool) {
+	learned, ok := m.mtus[key]
+	if !ok {
+		return 0, false
+	}
+	if !m.now().Before(learned.expires) {
+		delete(m.mtus, key)
+		return 0, false
+	}
+	return learned.mtu, true
+}
+
+// pathMTUKey identifies the path independently of the fields that are updated
+// while the packet is forwarded. For SCION v2 paths, these are the current
+// info and hop field pointers and the segment identifiers. The path quoted in
+// an SCMP error thus maps to the same key as the path the packet was sent on.
+func pathMTUKey(path *spath.Path) string {
+	if !path.IsHeaderV2() || path.IsOHP() || path.IsColibri() {
+		return string(path.Raw)
+	}
+	var decoded scion.Decoded
+	if err := decoded.DecodeFromBytes(path.Raw); err != nil {
+		return string(path.Raw)
+	}
+	decoded.PathMeta.CurrINF = 0
+	decoded.PathMeta.CurrHF = 0
+	for _, info := range decoded.InfoFields {
+		info.SegID = 0
+	}
+	raw := make([]byte, decoded.Len())
+	if err := decoded.SerializeTo(raw); err != nil {
+		return string(path.Raw)
+	}
+	return string(raw)
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPathMTUs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sent := newV2Path(t, 0, 0x1111)
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Path().Return(sent).AnyTimes()
+	path.EXPECT().MTU().Return(uint16(1472)).AnyTimes()
+
+	mtus := snet.NewPathMTUs()
+	assert.Equal(t, uint16(1472), mtus.MTU(path), "initialized from path")
+
+	// The quoted path has been updated by the routers on the way.
+	mtus.Update(newV2Path(t, 1, 0x2222), 1400)
+	assert.Equal(t, uint16(1400), mtus.MTU(path))
+	mtus.Update(newV2Path(t, 1, 0x2222), 1450)
+	assert.Equal(t, uint16(1400), mtus.MTU(path), "estimate is not raised")
+
+	other := newV2Path(t, 0, 0x1111)
+	other.Raw[len(other.Raw)-1] ^= 0xff
+	mtus.Update(other, 1280)
+	assert.Equal(t, uint16(1400), mtus.MTU(path), "other path is not affected")
+}
+
+func TestPathMTUsBounds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Path().Return(newV2Path(t, 0, 0x1111)).AnyTimes()
+	path.EXPECT().MTU().Return(uint16(1472)).AnyTimes()
+
+	now := time.Now()
+	mtus := snet.NewPathMTUsWithClock(func() time.Time { return now })
+
+	mtus.Update(newV2Path(t, 1, 0x2222), 1)
+	assert.Equal(t, uint16(snet.MinPathMTU), mtus.MTU(path), "clamped to minimum")
+
+	now = now.Add(snet.PathMTUTimeout - time.Second)
+	assert.Equal(t, uint16(snet.MinPathMTU), mtus.MTU(path))
+	now = now.Add(time.Second)
+	assert.Equal(t, uint16(1472), mtus.MTU(path), "learned estimate expired")
+
+	mtus.Update(newV2Path(t, 1, 0x2222), 1400)
+	assert.Equal(t, uint16(1400), mtus.MTU(path), "estimate learned after expiry")
+}
+
+func TestSCMPHandlerPacketTooBig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Path().Return(newV2Path(t, 0, 0x1111)).AnyTimes()
+	path.EXPECT().MTU().Return(uint16(1472)).AnyTimes()
+
+	n := snet.NewNetwork(xtest.MustParseIA("1-ff00:0:110"), nil, nil)
+	handler := n.Dispatcher.(*snet.DefaultPacketDispatcherService).SCMPHandler
+
+	ct := scmp.ClassType{Class: scmp.C_Routing, Type: scmp.T_R_OversizePkt}
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Path:     newV2Path(t, 0, 0x3333),
+			L4Header: scmp.NewHdr(ct, 0),
+			Payload: &scmp.Payload{
+				Meta:    &scmp.Meta{L4Proto: common.L4UDP},
+				Info:    &scmp.InfoPktSize{Size: 1472, MTU: 1350},
+				PathHdr: common.RawBytes(newV2Path(t, 1, 0x2222).Raw),
+			},
+		},
+	}
+	err := handler.Handle(pkt)
+	var opErr *snet.OpError
+	require.True(t, errors.As(err, &opErr), "err: %v", err)
+	assert.Equal(t, uint16(1350), opErr.MTU())
+	assert.Equal(t, uint16(1350), n.PathMTUs.MTU(path))
+}
+
+// newV2Path creates a path with a single segment of two hops. The current hop
+// field and the segment identifier are the fields updated during forwarding.
+func newV2Path(t *testing.T, currHF uint8, segID uint16) *spath.Path {
+	decoded := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{CurrHF: currHF, SegLen: [3]uint8{2, 0, 0}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []*path.InfoField{{ConsDir: true, SegID: segID, Timestamp: 42}},
+		HopFields: []*path.HopField{
+			{ConsEgress: 1, ExpTime: 63, Mac: []byte{1, 2, 3, 4, 5, 6}},
+			{ConsIngress: 2, ExpTime: 63, Mac: []byte{6, 5, 4, 3, 2, 1}},
+		},
+	}
+	raw := make([]byte, decoded.Len())
+	require.NoError(t, decoded.SerializeTo(raw))
+	return spath.NewV2(raw, false)
+}
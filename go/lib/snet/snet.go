@@ -36,6 +36,11 @@
 // *OpError. Method SCMP() can be called on the error to extract the SCMP
 // header.
 //
+// SCMP packet too big errors are returned the same way, and method MTU()
+// reports the MTU of the link the packet did not fit. For networks created
+// with NewNetwork, the error also lowers the path MTU estimate in
+// SCIONNetwork.PathMTUs, which callers can consult to size their packets.
+//
 // Important: not draining SCMP errors via Read calls can cause the dispatcher
 // to shutdown the socket (see https://github.com/scionproto/scion/pull/1356).
 // To prevent this on a Conn object with only Write calls, run a separate
@@ -61,20 +66,27 @@ type SCIONNetwork struct {
 	LocalIA    addr.IA
 	Dispatcher PacketDispatcherService
 	Version2   bool
+	// PathMTUs contains the path MTU estimates learned from SCMP packet too
+	// big errors that are received on the connections of the network. It is
+	// only set if the network is created with NewNetwork.
+	PathMTUs *PathMTUs
 }
 
 // NewNetwork creates a new networking context.
 func NewNetwork(ia addr.IA, dispatcher reliable.Dispatcher,
 	revHandler RevocationHandler) *SCIONNetwork {
 
+	pathMTUs := NewPathMTUs()
 	return &SCIONNetwork{
 		LocalIA: ia,
 		Dispatcher: &DefaultPacketDispatcherService{
 			Dispatcher: dispatcher,
 			SCMPHandler: &scmpHandler{
 				revocationHandler: revHandler,
+				pathMTUs:          pathMTUs,
 			},
 		},
+		PathMTUs: pathMTUs,
 	}
 }
 
@@ -19,12 +19,15 @@ package squic
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"net"
 
 	"github.com/lucas-clemente/quic-go"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 )
@@ -32,14 +35,35 @@ import (
 const (
 	defKeyPath = "gen-certs/tls.key"
 	defPemPath = "gen-certs/tls.pem"
+
+	// errCodeUnauthenticated is the application error code used to close
+	// sessions of peers that fail the CP-PKI authentication.
+	errCodeUnauthenticated quic.ErrorCode = 0x101
 )
 
 var (
 	// Don't verify the server's cert, as we are not using the TLS PKI.
 	cliTlsCfg = &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"SCION"}}
 	srvTlsCfg = &tls.Config{NextProtos: []string{"SCION"}}
+
+	// cryptoMgr authenticates the sessions with the CP-PKI, if set.
+	cryptoMgr CryptoManager
 )
 
+// CryptoManager provides the TLS credentials of the local AS and verifies the
+// certificates of peers with the control-plane PKI.
+type CryptoManager interface {
+	// GetCertificate returns the certificate for the server side of the
+	// handshake.
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	// GetClientCertificate returns the certificate for the client side of
+	// the handshake.
+	GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error)
+	// VerifyPeerCertificate verifies the certificate chain presented by the
+	// peer. If ia is not zero, the certificate must be issued for ia.
+	VerifyPeerCertificate(rawCerts [][]byte, ia addr.IA) error
+}
+
 func Init(keyPath, pemPath string) error {
 	if keyPath == "" {
 		keyPath = defKeyPath
@@ -55,6 +79,36 @@ func Init(keyPath, pemPath string) error {
 	return nil
 }
 
+// InitCPPKI configures squic to authenticate sessions with the control-plane
+// PKI instead of the static TLS certificate. Both peers present the
+// certificate chain of their AS. The client verifies that the server
+// certificate is issued for the ISD-AS of the dialed address, the server
+// verifies that the client certificate is issued for the ISD-AS of the remote
+// address of the session.
+func InitCPPKI(mgr CryptoManager) {
+	cryptoMgr = mgr
+}
+
+// CPPKITLSConfig returns a TLS configuration for both sides of a handshake
+// that authenticates the peer with the control-plane PKI of the crypto
+// manager. Both peers present the certificate chain of their AS. Unlike for
+// sessions established with Dial and Listen, the ISD-AS of the peer
+// certificate is not checked against the address of the peer.
+func CPPKITLSConfig(mgr CryptoManager) *tls.Config {
+	return &tls.Config{
+		// InsecureSkipVerify only skips the web PKI verification, the peer
+		// certificate is verified with the CP-PKI instead.
+		InsecureSkipVerify:   true,
+		NextProtos:           []string{"SCION"},
+		GetCertificate:       mgr.GetCertificate,
+		GetClientCertificate: mgr.GetClientCertificate,
+		ClientAuth:           tls.RequireAnyClientCert,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return mgr.VerifyPeerCertificate(rawCerts, addr.IA{})
+		},
+	}
+}
+
 // Dial dials using quic over the scion network.
 func Dial(network *snet.SCIONNetwork, listen *net.UDPAddr, remote *snet.UDPAddr,
 	svc addr.HostSVC, quicConfig *quic.Config) (quic.Session, error) {
@@ -64,20 +118,102 @@ func Dial(network *snet.SCIONNetwork, listen *net.UDPAddr, remote *snet.UDPAddr,
 		return nil, err
 	}
 	// Use dummy hostname, as it's used for SNI, and we're not doing cert verification.
-	return quic.Dial(sconn, remote, "host:0", cliTlsCfg, quicConfig)
+	return quic.Dial(sconn, remote, "host:0", clientTLSConfig(remote.IA), quicConfig)
 }
 
 func Listen(network *snet.SCIONNetwork, listen *net.UDPAddr,
 	svc addr.HostSVC, quicConfig *quic.Config) (quic.Listener, error) {
 
-	if len(srvTlsCfg.Certificates) == 0 {
+	if cryptoMgr == nil && len(srvTlsCfg.Certificates) == 0 {
 		return nil, serrors.New("squic: No server TLS certificate configured")
 	}
 	sconn, err := sListen(network, listen, svc)
 	if err != nil {
 		return nil, err
 	}
-	return quic.Listen(sconn, srvTlsCfg, quicConfig)
+	if cryptoMgr == nil {
+		return quic.Listen(sconn, srvTlsCfg, quicConfig)
+	}
+	listener, err := quic.Listen(sconn, serverTLSConfig(), quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	return cppkiListener{Listener: listener}, nil
+}
+
+// clientTLSConfig returns the TLS configuration for dialing the given ISD-AS.
+func clientTLSConfig(ia addr.IA) *tls.Config {
+	if cryptoMgr == nil {
+		return cliTlsCfg
+	}
+	mgr := cryptoMgr
+	cfg := cliTlsCfg.Clone()
+	// InsecureSkipVerify stays enabled to skip the web PKI verification, the
+	// server certificate is verified with the CP-PKI instead.
+	cfg.GetClientCertificate = mgr.GetClientCertificate
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		return mgr.VerifyPeerCertificate(rawCerts, ia)
+	}
+	return cfg
+}
+
+func serverTLSConfig() *tls.Config {
+	mgr := cryptoMgr
+	cfg := srvTlsCfg.Clone()
+	cfg.Certificates = nil
+	cfg.GetCertificate = mgr.GetCertificate
+	cfg.ClientAuth = tls.RequireAnyClientCert
+	// The remote address is not known during the handshake. The ISD-AS of
+	// the client certificate is checked when the session is accepted.
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		return mgr.VerifyPeerCertificate(rawCerts, addr.IA{})
+	}
+	return cfg
+}
+
+// cppkiListener only accepts sessions with clients that present a certificate
+// for the ISD-AS of their remote address.
+type cppkiListener struct {
+	quic.Listener
+}
+
+func (l cppkiListener) Accept(ctx context.Context) (quic.Session, error) {
+	for {
+		session, err := l.Listener.Accept(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPeerIA(session); err != nil {
+			log.Info("squic: Rejecting unauthenticated session",
+				"remote", session.RemoteAddr(), "err", err)
+			session.CloseWithError(errCodeUnauthenticated, "unauthenticated")
+			continue
+		}
+		return session, nil
+	}
+}
+
+// checkPeerIA checks that the client certificate of the session is issued for
+// the ISD-AS of the remote address.
+func checkPeerIA(session quic.Session) error {
+	remote, ok := session.RemoteAddr().(*snet.UDPAddr)
+	if !ok {
+		return serrors.New("unexpected remote address type",
+			"type", common.TypeOf(session.RemoteAddr()))
+	}
+	certs := session.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return serrors.New("no peer certificate")
+	}
+	ia, err := cppki.ExtractIA(certs[0].Subject)
+	if err != nil {
+		return err
+	}
+	if !ia.Equal(remote.IA) {
+		return serrors.New("peer certificate does not match remote ISD-AS",
+			"expected", remote.IA, "actual", *ia)
+	}
+	return nil
 }
 
 func sListen(network *snet.SCIONNetwork, listen *net.UDPAddr,
@@ -0,0 +1,143 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squic_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/squic"
+)
+
+func TestCPPKITLSConfig(t *testing.T) {
+	client, server := newManager(t, "client"), newManager(t, "server")
+
+	testCases := map[string]struct {
+		ClientErr    error
+		ServerErr    error
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			ErrAssertion: assert.NoError,
+		},
+		"client rejects server": {
+			ClientErr:    serrors.New("rejected"),
+			ErrAssertion: assert.Error,
+		},
+		"server rejects client": {
+			ServerErr:    serrors.New("rejected"),
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client.err, server.err = tc.ClientErr, tc.ServerErr
+			client.verified, server.verified = nil, nil
+
+			cConn, sConn := net.Pipe()
+			defer cConn.Close()
+			defer sConn.Close()
+			cTLS := tls.Client(cConn, squic.CPPKITLSConfig(client))
+			sTLS := tls.Server(sConn, squic.CPPKITLSConfig(server))
+			errs := make(chan error, 1)
+			go func() {
+				err := sTLS.Handshake()
+				// Unblock the client if the server fails the handshake.
+				sConn.Close()
+				errs <- err
+			}()
+			cErr := cTLS.Handshake()
+			cConn.Close()
+			sErr := <-errs
+			tc.ErrAssertion(t, combine(cErr, sErr))
+			if cErr != nil || sErr != nil {
+				return
+			}
+			assert.Equal(t, server.cert.Leaf.Raw, client.verified)
+			assert.Equal(t, client.cert.Leaf.Raw, server.verified)
+		})
+	}
+}
+
+func combine(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// manager is a crypto manager that presents a self-signed certificate and
+// records the peer certificate it was asked to verify.
+type manager struct {
+	cert     *tls.Certificate
+	err      error
+	verified []byte
+}
+
+func newManager(t *testing.T, name string) *manager {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	return &manager{
+		cert: &tls.Certificate{
+			Certificate: [][]byte{raw},
+			PrivateKey:  key,
+			Leaf:        leaf,
+		},
+	}
+}
+
+func (m *manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return m.cert, nil
+}
+
+func (m *manager) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return m.cert, nil
+}
+
+func (m *manager) VerifyPeerCertificate(rawCerts [][]byte, ia addr.IA) error {
+	if !ia.IsZero() {
+		return serrors.New("unexpected ISD-AS", "ia", ia)
+	}
+	if len(rawCerts) > 0 {
+		m.verified = rawCerts[0]
+	}
+	return m.err
+}
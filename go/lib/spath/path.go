@@ -17,7 +17,6 @@ package spath
 
 import (
 	"crypto/rand"
-	"encoding/binary"
 	"fmt"
 	"hash"
 	"math"
@@ -28,6 +27,7 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/util"
@@ -51,6 +51,9 @@ type Path struct {
 	// version is a temporary solution for supporting V2 paths in method calls.
 	version int
 	ohp     bool
+	colibri bool
+	// auths are the hop authenticators of a COLIBRI path, in reservation direction.
+	auths [][]byte
 }
 
 func New(raw common.RawBytes) *Path {
@@ -61,6 +64,13 @@ func NewV2(raw []byte, ohp bool) *Path {
 	return &Path{Raw: raw, version: 2, ohp: ohp}
 }
 
+// NewColibri creates a V2 path from a raw COLIBRI path. auths are the hop authenticators of the
+// reservation, which are used to compute the per packet MACs when sending. They can be nil if
+// the path is only used for receiving, or if the MACs are already set.
+func NewColibri(raw []byte, auths [][]byte) *Path {
+	return &Path{Raw: raw, version: 2, colibri: true, auths: auths}
+}
+
 // NewOneHop creates a new one hop path with. If necessary, the caller has
 // to initialize the offsets.
 func NewOneHop(isd addr.ISD, ifid common.IFIDType, ts time.Time, exp ExpTimeType,
@@ -102,18 +112,7 @@ func NewOneHopV2(isd addr.ISD, ifid common.IFIDType, ts time.Time, exp ExpTimeTy
 		},
 	}
 
-	input := make([]byte, 16)
-	binary.BigEndian.PutUint32(input[:4], util.TimeToSecs(ts))
-	input[4] = uint8(exp)
-	binary.BigEndian.PutUint16(input[7:9], ohp.FirstHop.ConsEgress)
-	binary.BigEndian.PutUint16(input[9:11], ohp.Info.SegID)
-
-	// Write must not return an error: https://godoc.org/hash#Hash
-	if _, err := hfmac.Write(input); err != nil {
-		panic(err)
-	}
-	fullMAC := hfmac.Sum(nil)
-	ohp.FirstHop.Mac = fullMAC[:6]
+	ohp.FirstHop.Mac = path.MAC(hfmac, &ohp.Info, &ohp.FirstHop)
 
 	raw := make([]byte, onehop.PathLen)
 	if err := ohp.SerializeTo(raw); err != nil {
@@ -136,10 +135,26 @@ func (p *Path) Copy() *Path {
 		HopOff:  p.HopOff,
 		version: p.version,
 		ohp:     p.ohp,
+		colibri: p.colibri,
+		auths:   copyAuths(p.auths),
+	}
+}
+
+func copyAuths(auths [][]byte) [][]byte {
+	if auths == nil {
+		return nil
+	}
+	c := make([][]byte, 0, len(auths))
+	for _, a := range auths {
+		c = append(c, append([]byte(nil), a...))
 	}
+	return c
 }
 
 func (p *Path) reverse2() error {
+	if p.colibri {
+		return p.reverseColibri()
+	}
 	var path scion.Decoded
 	if p.ohp {
 		//  Since a OHP can't be reversed we create a proper SCION path instead,
@@ -174,6 +189,20 @@ func (p *Path) reverse2() error {
 	return nil
 }
 
+func (p *Path) reverseColibri() error {
+	var path colibri.Path
+	if err := path.DecodeFromBytes(p.Raw); err != nil {
+		return serrors.WrapStr("decoding v2 COLIBRI path", err)
+	}
+	if err := path.Reverse(); err != nil {
+		return err
+	}
+	if err := path.SerializeTo(p.Raw); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (p *Path) Reverse() error {
 	if p.version == 2 {
 		return p.reverse2()
@@ -248,6 +277,17 @@ func (path *Path) IsOHP() bool {
 	return path.ohp
 }
 
+// IsColibri returns whether the path is a V2 COLIBRI path.
+func (path *Path) IsColibri() bool {
+	return path.colibri
+}
+
+// ColibriAuthenticators returns the hop authenticators of a COLIBRI path, in reservation
+// direction.
+func (path *Path) ColibriAuthenticators() [][]byte {
+	return path.auths
+}
+
 // InitOffsets computes the initial Hop Field offset (in bytes) for a newly
 // created packet.
 func (path *Path) InitOffsets() error {
@@ -377,6 +417,13 @@ func (path *Path) String() string {
 				fmt.Sprintf("I: %d, E: %d", op.FirstHop.ConsIngress, op.FirstHop.ConsEgress),
 				fmt.Sprintf("I: %d, E: %d", op.SecondHop.ConsIngress, op.SecondHop.ConsEgress))
 		}
+	case path.version == 2 && path.colibri:
+		var cp colibri.Path
+		if err := cp.DecodeFromBytes(path.Raw); err != nil {
+			p = fmt.Sprintf("err decoding: %v", err)
+		} else {
+			p = fmt.Sprintf("{Info: %s, NumHops: %d}", &cp.InfoField, len(cp.HopFields))
+		}
 	case path.version == 2:
 		var sp scion.Decoded
 		if err := sp.DecodeFromBytes(path.Raw); err != nil {
@@ -386,5 +433,6 @@ func (path *Path) String() string {
 				sp.PathMeta, sp.NumINF, sp.NumHops)
 		}
 	}
-	return fmt.Sprintf("{version: %d, ohp: %t, p: %s}", path.version, path.ohp, p)
+	return fmt.Sprintf("{version: %d, ohp: %t, colibri: %t, p: %s}", path.version, path.ohp,
+		path.colibri, p)
 }
@@ -19,9 +19,12 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
@@ -181,3 +184,29 @@ func TestNewOneHop(t *testing.T) {
 		})
 	})
 }
+
+func TestColibriPath(t *testing.T) {
+	cp := colibri.Path{
+		InfoField: colibri.InfoField{HFCount: 2},
+		HopFields: []*colibri.HopField{
+			{ConsIngress: 0, ConsEgress: 1, Mac: []byte{1, 2, 3, 4}},
+			{ConsIngress: 2, ConsEgress: 0, Mac: []byte{5, 6, 7, 8}},
+		},
+	}
+	raw := make([]byte, cp.Len())
+	require.NoError(t, cp.SerializeTo(raw))
+	auths := [][]byte{{1}, {2}}
+	p := NewColibri(raw, auths)
+
+	c := p.Copy()
+	assert.Equal(t, p, c)
+	require.NoError(t, c.Reverse())
+	assert.True(t, c.IsColibri())
+	assert.Equal(t, auths, c.ColibriAuthenticators())
+	var reversed colibri.Path
+	require.NoError(t, reversed.DecodeFromBytes(c.Raw))
+	assert.True(t, reversed.InfoField.R)
+	assert.Equal(t, uint16(2), reversed.HopFields[0].ConsIngress)
+	// The original path is not modified.
+	assert.Equal(t, raw, []byte(p.Raw))
+}
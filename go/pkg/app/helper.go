@@ -25,6 +25,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -48,18 +49,55 @@ func QueryASInfo(ctx context.Context, conn sciond.Connector) (ASInfo, error) {
 	}, nil
 }
 
-// ChoosePath selects a path to the remote
+// PathOption configures the path selection of ChoosePath.
+type PathOption func(o *pathOptions)
+
+type pathOptions struct {
+	interactive bool
+	refresh     bool
+	policy      *pathpol.Policy
+}
+
+// WithInteractive configures whether the path is selected interactively.
+func WithInteractive(interactive bool) PathOption {
+	return func(o *pathOptions) {
+		o.interactive = interactive
+	}
+}
+
+// WithRefresh configures whether SCIOND is queried with the refresh flag.
+func WithRefresh(refresh bool) PathOption {
+	return func(o *pathOptions) {
+		o.refresh = refresh
+	}
+}
+
+// WithPolicy configures the path policy that the paths are filtered with
+// before selection. A nil policy allows all paths.
+func WithPolicy(policy *pathpol.Policy) PathOption {
+	return func(o *pathOptions) {
+		o.policy = policy
+	}
+}
+
+// ChoosePath selects a path to the remote. By default, a random path is
+// selected among the paths that match the configured policy.
 func ChoosePath(ctx context.Context, conn sciond.Connector, remote addr.IA,
-	interactive, refresh bool) (snet.Path, error) {
+	opts ...PathOption) (snet.Path, error) {
 
-	paths, err := conn.Paths(ctx, remote, addr.IA{}, sciond.PathReqFlags{Refresh: refresh})
+	var o pathOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	paths, err := conn.Paths(ctx, remote, addr.IA{}, sciond.PathReqFlags{Refresh: o.refresh})
 	if err != nil {
 		return nil, serrors.WrapStr("retreiving paths", err)
 	}
+	paths = FilterPaths(paths, o.policy)
 	if len(paths) == 0 {
 		return nil, serrors.New("no path available")
 	}
-	if !interactive {
+	if !o.interactive {
 		return paths[rand.Intn(len(paths))], nil
 	}
 
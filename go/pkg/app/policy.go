@@ -0,0 +1,160 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// Policy creates a path policy from a policy file, a sequence and ACL entries,
+// all of which are optional. The sequence and the ACL override the ones in the
+// policy file. If none of them is set, nil is returned, which allows all paths.
+func Policy(file, sequence string, acl []string) (*pathpol.Policy, error) {
+	var policy *pathpol.Policy
+	if file != "" {
+		var err error
+		if policy, err = LoadPolicy(file); err != nil {
+			return nil, err
+		}
+	}
+	if sequence == "" && len(acl) == 0 {
+		return policy, nil
+	}
+	if policy == nil {
+		policy = &pathpol.Policy{}
+	}
+	if sequence != "" {
+		seq, err := pathpol.NewSequence(sequence)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing sequence", err)
+		}
+		policy.Sequence = seq
+	}
+	if len(acl) != 0 {
+		entries := make([]*pathpol.ACLEntry, 0, len(acl))
+		for _, str := range acl {
+			var entry pathpol.ACLEntry
+			if err := entry.LoadFromString(str); err != nil {
+				return nil, serrors.WrapStr("parsing ACL entry", err, "entry", str)
+			}
+			entries = append(entries, &entry)
+		}
+		a, err := pathpol.NewACL(entries...)
+		if err != nil {
+			return nil, serrors.WrapStr("creating ACL", err)
+		}
+		policy.ACL = a
+	}
+	return policy, nil
+}
+
+// LoadPolicy loads a path policy from a file. Files with a .yml or .yaml
+// extension are parsed as YAML, all other files as JSON. The policy must not
+// extend other policies.
+func LoadPolicy(file string) (*pathpol.Policy, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("reading policy file", err, "file", file)
+	}
+	switch filepath.Ext(file) {
+	case ".yml", ".yaml":
+		if raw, err = yamlToJSON(raw); err != nil {
+			return nil, serrors.WrapStr("parsing policy file", err, "file", file)
+		}
+	}
+	var ext pathpol.ExtPolicy
+	if err := json.Unmarshal(raw, &ext); err != nil {
+		return nil, serrors.WrapStr("parsing policy file", err, "file", file)
+	}
+	policy, err := pathpol.PolicyFromExtPolicy(&ext, nil)
+	if err != nil {
+		return nil, serrors.WrapStr("loading policy", err, "file", file)
+	}
+	return policy, nil
+}
+
+// FilterPaths returns the paths that match the policy, in their original
+// order. A nil policy allows all paths.
+func FilterPaths(paths []snet.Path, policy *pathpol.Policy) []snet.Path {
+	if policy == nil {
+		return paths
+	}
+	set := make(pathpol.PathSet, len(paths))
+	for _, path := range paths {
+		set[path.Fingerprint()] = path
+	}
+	allowed := policy.Filter(set)
+	filtered := make([]snet.Path, 0, len(allowed))
+	for _, path := range paths {
+		if _, ok := allowed[path.Fingerprint()]; ok {
+			filtered = append(filtered, path)
+		}
+	}
+	return filtered
+}
+
+// yamlToJSON converts a YAML document to JSON, such that the custom JSON
+// unmarshalers of the path policy can be used.
+func yamlToJSON(raw []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	v, err := jsonValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// jsonValue converts the maps decoded by the YAML parser, which have
+// interface keys, to maps with string keys.
+func jsonValue(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			key, ok := k.(string)
+			if !ok {
+				return nil, serrors.New("non-string key", "key", k)
+			}
+			var err error
+			if m[key], err = jsonValue(val); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case []interface{}:
+		l := make([]interface{}, 0, len(t))
+		for _, val := range t {
+			converted, err := jsonValue(val)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, converted)
+		}
+		return l, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/app"
+)
+
+func TestPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	via111 := newTestPath(ctrl, "1-ff00:0:110", 1, "1-ff00:0:111", 2)
+	via112 := newTestPath(ctrl, "1-ff00:0:110", 3, "1-ff00:0:112", 4)
+	paths := []snet.Path{via111, via112}
+
+	tests := map[string]struct {
+		File      string
+		Sequence  string
+		ACL       []string
+		Expected  []snet.Path
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"no policy": {
+			Expected:  paths,
+			Assertion: assert.NoError,
+		},
+		"json file": {
+			File:      "testdata/policy.json",
+			Expected:  []snet.Path{via112},
+			Assertion: assert.NoError,
+		},
+		"yaml file": {
+			File:      "testdata/policy.yml",
+			Expected:  []snet.Path{via112},
+			Assertion: assert.NoError,
+		},
+		"missing file": {
+			File:      "testdata/missing.json",
+			Assertion: assert.Error,
+		},
+		"sequence": {
+			Sequence:  "1-ff00:0:110 1-ff00:0:111",
+			Expected:  []snet.Path{via111},
+			Assertion: assert.NoError,
+		},
+		"invalid sequence": {
+			Sequence:  "1-ff00:0:110 (",
+			Assertion: assert.Error,
+		},
+		"acl": {
+			ACL:       []string{"- 1-ff00:0:112", "+"},
+			Expected:  []snet.Path{via111},
+			Assertion: assert.NoError,
+		},
+		"acl without default": {
+			ACL:       []string{"- 1-ff00:0:112"},
+			Assertion: assert.Error,
+		},
+		"acl overrides file": {
+			File:      "testdata/policy.json",
+			ACL:       []string{"- 1-ff00:0:112", "+"},
+			Expected:  []snet.Path{via111},
+			Assertion: assert.NoError,
+		},
+		"sequence and file": {
+			File:      "testdata/policy.json",
+			Sequence:  "1-ff00:0:110 1-ff00:0:111",
+			Expected:  []snet.Path{},
+			Assertion: assert.NoError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := app.Policy(test.File, test.Sequence, test.ACL)
+			test.Assertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.Expected, app.FilterPaths(paths, policy))
+		})
+	}
+}
+
+func newTestPath(ctrl *gomock.Controller, srcIA string, srcIfID common.IFIDType,
+	dstIA string, dstIfID common.IFIDType) snet.Path {
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Interfaces().Return([]snet.PathInterface{
+		sciond.PathInterface{RawIsdas: xtest.MustParseIA(srcIA).IAInt(), IfID: srcIfID},
+		sciond.PathInterface{RawIsdas: xtest.MustParseIA(dstIA).IAInt(), IfID: dstIfID},
+	}).AnyTimes()
+	path.EXPECT().Fingerprint().Return(
+		snet.PathFingerprint(fmt.Sprintf("%s#%d %s#%d", srcIA, srcIfID, dstIA, dstIfID)),
+	).AnyTimes()
+	return path
+}
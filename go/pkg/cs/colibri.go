@@ -0,0 +1,88 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cs
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/cs/reservation/handler"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission/impl"
+	"github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/cs/reservationstore"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/cleaner"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+// ColibriConfig holds the configuration for the COLIBRI handlers.
+type ColibriConfig struct {
+	// DB is the path to the sqlite database of the reservations.
+	DB string
+	// Delta is the fraction of the free bandwidth that can be granted to a segment reservation.
+	Delta  float64
+	Topo   topology.Topology
+	Msgr   infra.Messenger
+	Router snet.Router
+}
+
+// Colibri keeps track of the resources used to serve COLIBRI requests.
+type Colibri struct {
+	// Handler handles the COLIBRI requests.
+	Handler *handler.Handler
+	db      *sqlite.Backend
+	cleaner *periodic.Runner
+}
+
+// StartColibri opens the reservation database, creates the COLIBRI handler and starts the
+// task removing the expired reservation indices. The handler still has to be registered
+// with the messengers.
+func StartColibri(cfg ColibriConfig) (*Colibri, error) {
+	db, err := sqlite.New(cfg.DB)
+	if err != nil {
+		return nil, serrors.WrapStr("initializing reservation database", err)
+	}
+	admitter := &impl.StatelessAdmission{
+		Caps:  admission.NewTopologyCapacities(cfg.Topo),
+		Delta: cfg.Delta,
+	}
+	store := reservationstore.NewStore(db, admitter, cfg.Topo)
+	return &Colibri{
+		Handler: &handler.Handler{
+			IA:     cfg.Topo.IA(),
+			Store:  store,
+			RPC:    cfg.Msgr,
+			Router: cfg.Router,
+		},
+		db: db,
+		cleaner: periodic.Start(
+			cleaner.New(store.DeleteExpiredIndices, "colibri_reservations"),
+			10*time.Second,
+			10*time.Second,
+		),
+	}, nil
+}
+
+// Close stops the cleaner task and closes the reservation database.
+func (c *Colibri) Close() error {
+	if c == nil {
+		return nil
+	}
+	c.cleaner.Kill()
+	return c.db.Close()
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cs
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/drkey"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/drkey/sqlite"
+	"github.com/scionproto/scion/go/lib/infra/modules/cleaner"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/trust"
+)
+
+// DRKeyConfig holds the configuration for the DRKey handlers.
+type DRKeyConfig struct {
+	// DB is the path to the sqlite database of the level 1 keys.
+	DB string
+	// EpochDuration is the duration of the DRKey epochs.
+	EpochDuration time.Duration
+	// ConfigDir is the directory containing the master key of the AS.
+	ConfigDir string
+	IA        addr.IA
+	RPC       drkey.RPC
+	Router    snet.Router
+	Signer    ctrl.Signer
+	// Provider provides the certificate chains to verify the level 1 key messages.
+	Provider trust.Provider
+}
+
+// DRKey keeps track of the resources used to serve DRKey requests.
+type DRKey struct {
+	// Lvl1Handler handles the level 1 key requests of other ASes.
+	Lvl1Handler *drkey.Lvl1ReqHandler
+	// Lvl2Handler handles the level 2 key requests of the local end hosts.
+	Lvl2Handler *drkey.Lvl2ReqHandler
+	db          *sqlite.Backend
+	cleaner     *periodic.Runner
+}
+
+// StartDRKey loads the master key, opens the DRKey database, creates the DRKey handlers and
+// starts the task removing the expired level 1 keys and secret values. The handlers still have
+// to be registered with the messengers.
+func StartDRKey(cfg DRKeyConfig) (*DRKey, error) {
+	mk, err := keyconf.LoadMaster(filepath.Join(cfg.ConfigDir, "keys"))
+	if err != nil {
+		return nil, serrors.WrapStr("loading master key", err)
+	}
+	db, err := sqlite.New(cfg.DB)
+	if err != nil {
+		return nil, serrors.WrapStr("initializing DRKey database", err)
+	}
+	// The secret values are stored in the database, such that a rotation of the master key
+	// only takes effect for the epochs that have not started yet.
+	svs, err := drkey.NewSecretValueFactory(mk.Key0, cfg.EpochDuration, db)
+	if err != nil {
+		db.Close()
+		return nil, serrors.WrapStr("initializing secret values", err)
+	}
+	verifier := drkey.TrustVerifier{Engine: cfg.Provider}
+	store := &drkey.ServiceStore{
+		IA:           cfg.IA,
+		DB:           db,
+		SecretValues: svs,
+		Fetcher: &drkey.Fetcher{
+			IA:       cfg.IA,
+			RPC:      cfg.RPC,
+			Router:   cfg.Router,
+			Signer:   cfg.Signer,
+			Verifier: verifier,
+		},
+	}
+	return &DRKey{
+		Lvl1Handler: &drkey.Lvl1ReqHandler{
+			Store:    store,
+			Signer:   cfg.Signer,
+			Verifier: verifier,
+		},
+		Lvl2Handler: &drkey.Lvl2ReqHandler{Store: store},
+		db:          db,
+		cleaner: periodic.Start(
+			cleaner.New(store.DeleteExpiredKeys, "drkey_lvl1_keys"),
+			time.Minute,
+			10*time.Second,
+		),
+	}, nil
+}
+
+// Close stops the cleaner task and closes the level 1 key database.
+func (d *DRKey) Close() error {
+	if d == nil {
+		return nil
+	}
+	d.cleaner.Kill()
+	return d.db.Close()
+}
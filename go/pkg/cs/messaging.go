@@ -19,6 +19,7 @@ import (
 	"hash"
 	"net"
 	"path/filepath"
+	"sync"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/infra"
@@ -47,17 +48,51 @@ func NewMessenger(nc infraenv.NetworkConfig) (infra.Messenger, *tcp.Messenger, e
 	return msgr, tcpMsgr, nil
 }
 
-// MACGenFactory creates a MAC factory
-func MACGenFactory(configDir string) (func() hash.Hash, error) {
-	mk, err := keyconf.LoadMaster(filepath.Join(configDir, "keys"))
+// MACGen creates hop field MAC instances for the active master key (Key0).
+// The master keys can be reloaded from disk at runtime, which allows rotating
+// the master key without restarting the control service. The border routers
+// accept hop fields MACed with the previous master key (Key1) during the
+// overlap period.
+type MACGen struct {
+	configDir string
+
+	mtx     sync.RWMutex
+	factory func() hash.Hash
+}
+
+// NewMACGen loads the master keys from the config directory and creates a MAC
+// generator for the active master key.
+func NewMACGen(configDir string) (*MACGen, error) {
+	g := &MACGen{configDir: configDir}
+	if err := g.Reload(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// New creates a new MAC instance for the currently active master key.
+func (g *MACGen) New() hash.Hash {
+	g.mtx.RLock()
+	defer g.mtx.RUnlock()
+	return g.factory()
+}
+
+// Reload reloads the master keys from the config directory. All MAC instances
+// created after a successful reload use the new active master key. On error,
+// the previously loaded key stays active.
+func (g *MACGen) Reload() error {
+	mk, err := keyconf.LoadMaster(filepath.Join(g.configDir, "keys"))
 	if err != nil {
-		return nil, serrors.WrapStr("loading master key", err)
+		return serrors.WrapStr("loading master key", err)
 	}
-	hfMacFactory, err := scrypto.HFMacFactory(mk.Key0)
+	factory, err := scrypto.HFMacFactory(mk.Key0)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return hfMacFactory, nil
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.factory = factory
+	return nil
 }
 
 // NewOneHopConn registers a new connection that should be used with one hop
@@ -49,6 +49,20 @@ func LoadNonCorePolicies(cfg config.Policies) (beacon.Policies, error) {
 	return policies, nil
 }
 
+// LoadHiddenPathRegistration loads the hidden path registration policy. If no
+// policy file is configured, nil is returned.
+func LoadHiddenPathRegistration(cfg config.Policies) (*beacon.HPRegistration, error) {
+	if cfg.HiddenPathRegistration == "" {
+		return nil, nil
+	}
+	reg, err := beacon.LoadHPRegFromYaml(cfg.HiddenPathRegistration)
+	if err != nil {
+		return nil, serrors.WrapStr("loading hidden path registration policy", err,
+			"file", cfg.HiddenPathRegistration)
+	}
+	return reg, nil
+}
+
 func loadPolicy(fn string, t beacon.PolicyType) (beacon.Policy, error) {
 	var policy beacon.Policy
 	if fn != "" {
@@ -32,13 +32,16 @@ import (
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
 	"github.com/scionproto/scion/go/lib/periodic"
 	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
 	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/topology"
+	cstrust "github.com/scionproto/scion/go/pkg/cs/trust"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/proto"
 )
@@ -61,6 +64,9 @@ type TasksConfig struct {
 	MACGen       func() hash.Hash
 	TopoProvider topology.Provider
 	StaticInfo   func() *beaconing.StaticInfoCfg
+	// HiddenPathRegistration is the hidden path registration policy. If it is
+	// nil, all segments are registered publicly.
+	HiddenPathRegistration *beacon.HPRegistration
 
 	OriginationInterval  time.Duration
 	PropagationInterval  time.Duration
@@ -68,6 +74,19 @@ type TasksConfig struct {
 
 	AllowIsdLoop bool
 	HeaderV2     bool
+	// PropagationFilter is the filter of the propagation policy. Its egress
+	// interface filters are applied when propagating beacons.
+	PropagationFilter *beacon.Filter
+
+	// ChainRenewer renews the AS certificate chain. If it is nil, no renewal
+	// task is started.
+	ChainRenewer         *cstrust.ChainRenewer
+	ChainRenewalInterval time.Duration
+
+	// TrustProvider is used to refresh the deny list of the local ISD. If it
+	// is nil, no deny list refresh task is started.
+	TrustProvider    trust.Provider
+	DenyListInterval time.Duration
 }
 
 // Originator starts a periodic beacon origination task. For non-core ASes, no
@@ -78,14 +97,14 @@ func (t *TasksConfig) Originator() *periodic.Runner {
 		return nil
 	}
 	s := &beaconing.Originator{
-		Extender: t.extender("originator", topo.IA(), topo.MTU(), func() spath.ExpTimeType {
+		Extender: t.extender("originator", topo.IA(), topo.MTU(), false, func() spath.ExpTimeType {
 			return t.BeaconStore.MaxExpTime(beacon.PropPolicy)
 		}),
 		BeaconSender: &onehop.BeaconSender{
 			Sender: onehop.Sender{
 				Conn:     t.OneHopConn,
 				IA:       topo.IA(),
-				MAC:      t.MACGen(),
+				MAC:      t.MACGen,
 				Addr:     t.Public,
 				HeaderV2: t.HeaderV2,
 			},
@@ -104,14 +123,14 @@ func (t *TasksConfig) Originator() *periodic.Runner {
 func (t *TasksConfig) Propagator() *periodic.Runner {
 	topo := t.TopoProvider.Get()
 	p := &beaconing.Propagator{
-		Extender: t.extender("propagator", topo.IA(), topo.MTU(), func() spath.ExpTimeType {
+		Extender: t.extender("propagator", topo.IA(), topo.MTU(), false, func() spath.ExpTimeType {
 			return t.BeaconStore.MaxExpTime(beacon.PropPolicy)
 		}),
 		BeaconSender: &onehop.BeaconSender{
 			Sender: onehop.Sender{
 				Conn:     t.OneHopConn,
 				IA:       topo.IA(),
-				MAC:      t.MACGen(),
+				MAC:      t.MACGen,
 				Addr:     t.Public,
 				HeaderV2: t.HeaderV2,
 			},
@@ -123,6 +142,7 @@ func (t *TasksConfig) Propagator() *periodic.Runner {
 		Signer:       t.Signer,
 		Intfs:        t.Intfs,
 		AllowIsdLoop: t.AllowIsdLoop,
+		Filter:       t.PropagationFilter,
 		Core:         topo.Core(),
 		Tick:         beaconing.NewTick(t.PropagationInterval),
 	}
@@ -144,13 +164,15 @@ func (t *TasksConfig) Registrars() []*periodic.Runner {
 func (t *TasksConfig) registrar(topo topology.Topology, segType proto.PathSegType,
 	policyType beacon.PolicyType) *periodic.Runner {
 
+	maxExp := func() spath.ExpTimeType {
+		return t.BeaconStore.MaxExpTime(policyType)
+	}
+	rpc := beaconingcompat.RPC{Messenger: t.Msgr}
 	r := &beaconing.Registrar{
-		Extender: t.extender("registrar", topo.IA(), topo.MTU(), func() spath.ExpTimeType {
-			return t.BeaconStore.MaxExpTime(policyType)
-		}),
+		Extender: t.extender("registrar", topo.IA(), topo.MTU(), false, maxExp),
 		Provider: t.BeaconStore,
 		Store:    &seghandler.DefaultStorage{PathDB: t.PathDB},
-		RPC:      beaconingcompat.RPC{Messenger: t.Msgr},
+		RPC:      rpc,
 		IA:       topo.IA(),
 		Signer:   t.Signer,
 		Intfs:    t.Intfs,
@@ -158,34 +180,74 @@ func (t *TasksConfig) registrar(topo topology.Topology, segType proto.PathSegTyp
 		Pather:   addrutil.NewPather(t.TopoProvider, t.HeaderV2),
 		Tick:     beaconing.NewTick(t.RegistrationInterval),
 	}
+	if t.HiddenPathRegistration != nil && segType != proto.PathSegType_core {
+		r.HiddenPath = t.HiddenPathRegistration
+		r.HiddenExtender = t.extender("registrar", topo.IA(), topo.MTU(), true, maxExp)
+		r.HiddenRPC = rpc
+	}
 	return periodic.Start(r, 500*time.Millisecond, t.RegistrationInterval)
 }
 
-func (t *TasksConfig) extender(task string, ia addr.IA, mtu uint16,
+func (t *TasksConfig) extender(task string, ia addr.IA, mtu uint16, hidden bool,
 	maxExp func() spath.ExpTimeType) beaconing.Extender {
 
 	if !t.HeaderV2 {
 		return &beaconing.LegacyExtender{
-			IA:         ia,
-			Signer:     t.Signer,
-			MAC:        t.MACGen,
-			Intfs:      t.Intfs,
-			MTU:        mtu,
-			MaxExpTime: maxExp,
-			StaticInfo: t.StaticInfo,
-			Task:       task,
+			IA:            ia,
+			Signer:        t.Signer,
+			MAC:           t.MACGen,
+			Intfs:         t.Intfs,
+			MTU:           mtu,
+			MaxExpTime:    maxExp,
+			StaticInfo:    t.StaticInfo,
+			Task:          task,
+			HiddenPathSeg: hidden,
 		}
 	}
 	return &beaconing.DefaultExtender{
-		IA:         ia,
-		Signer:     t.Signer,
-		MAC:        t.MACGen,
-		Intfs:      t.Intfs,
-		MTU:        mtu,
-		MaxExpTime: func() uint8 { return uint8(maxExp()) },
-		StaticInfo: t.StaticInfo,
-		Task:       task,
+		IA:            ia,
+		Signer:        t.Signer,
+		MAC:           t.MACGen,
+		Intfs:         t.Intfs,
+		MTU:           mtu,
+		MaxExpTime:    func() uint8 { return uint8(maxExp()) },
+		StaticInfo:    t.StaticInfo,
+		Task:          task,
+		HiddenPathSeg: hidden,
+	}
+}
+
+// ChainRenewal starts a periodic AS certificate chain renewal task. If no
+// chain renewer is configured, no periodic runner is started.
+func (t *TasksConfig) ChainRenewal() *periodic.Runner {
+	if t.ChainRenewer == nil {
+		return nil
 	}
+	return periodic.Start(t.ChainRenewer, t.ChainRenewalInterval, t.ChainRenewalInterval)
+}
+
+// DenyListRefresh starts a periodic task that fetches the latest deny list of
+// the local ISD. If no trust provider is configured, no periodic runner is
+// started.
+func (t *TasksConfig) DenyListRefresh() *periodic.Runner {
+	if t.TrustProvider == nil {
+		return nil
+	}
+	isd := t.TopoProvider.Get().IA().I
+	return periodic.Start(
+		periodic.Func{
+			Task: func(ctx context.Context) {
+				err := t.TrustProvider.NotifyDenyList(ctx, isd, scrypto.LatestVer)
+				if err != nil {
+					log.FromCtx(ctx).Info("Failed to refresh deny list",
+						"isd", isd, "err", err)
+				}
+			},
+			TaskName: "deny_list_refresh",
+		},
+		t.DenyListInterval,
+		t.DenyListInterval,
+	)
 }
 
 // Tasks keeps track of the running tasks.
@@ -196,6 +258,8 @@ type Tasks struct {
 
 	BeaconCleaner *periodic.Runner
 	PathCleaner   *periodic.Runner
+	ChainRenewal  *periodic.Runner
+	DenyList      *periodic.Runner
 }
 
 func StartTasks(cfg TasksConfig) (*Tasks, error) {
@@ -229,6 +293,8 @@ func StartTasks(cfg TasksConfig) (*Tasks, error) {
 			10*time.Second,
 			10*time.Second,
 		),
+		ChainRenewal: cfg.ChainRenewal(),
+		DenyList:     cfg.DenyListRefresh(),
 	}, nil
 
 }
@@ -243,12 +309,16 @@ func (t *Tasks) Kill() {
 		t.Propagator,
 		t.BeaconCleaner,
 		t.PathCleaner,
+		t.ChainRenewal,
+		t.DenyList,
 	})
 	killRunners(t.Registrars)
 	t.Originator = nil
 	t.Propagator = nil
 	t.BeaconCleaner = nil
 	t.PathCleaner = nil
+	t.ChainRenewal = nil
+	t.DenyList = nil
 	t.Registrars = nil
 }
 
@@ -307,7 +377,7 @@ func (t LegacyTasksConfig) Keepalive() *periodic.Runner {
 			Sender: &onehop.Sender{
 				Conn:     t.OneHopConn,
 				IA:       t.TopoProvider.Get().IA(),
-				MAC:      t.MACGen(),
+				MAC:      t.MACGen,
 				Addr:     t.Public,
 				HeaderV2: t.HeaderV2,
 			},
@@ -39,6 +39,14 @@ func LoadTrustMaterial(configDir string, db trust.DB, logger log.Logger) error {
 	for f, r := range loaded.Ignored {
 		logger.Info("Ignoring non-TRC", "file", f, "reason", r)
 	}
+	loaded, err = trust.LoadDenyLists(context.Background(), certsDir, db)
+	if err != nil {
+		return serrors.WrapStr("loading deny lists from disk", err)
+	}
+	logger.Info("Deny lists loaded", "files", loaded.Loaded)
+	for f, r := range loaded.Ignored {
+		logger.Info("Ignoring non-deny list", "file", f, "reason", r)
+	}
 	localCertsDir := filepath.Join(configDir, "crypto/as")
 	loaded, err = trust.LoadChains(context.Background(), localCertsDir, db)
 	if err != nil {
@@ -109,6 +117,20 @@ func NewSigner(ia addr.IA, db trust.DB, cfgDir string) (cstrust.RenewingSigner,
 	}, nil
 }
 
+// NewChainRenewer creates a chain renewer that renews the AS certificate chain
+// used by the given signer.
+func NewChainRenewer(ia addr.IA, db trust.DB, signer cstrust.RenewingSigner,
+	requester cstrust.ChainRenewalRequester, cfgDir string) *cstrust.ChainRenewer {
+
+	return &cstrust.ChainRenewer{
+		IA:        ia,
+		SignerGen: signer.SignerGen,
+		Requester: requester,
+		DB:        db,
+		Dir:       filepath.Join(cfgDir, "crypto/as"),
+	}
+}
+
 // LoadClientChains loads the client certificate chains.
 func LoadClientChains(db renewal.DB, configDir string) error {
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
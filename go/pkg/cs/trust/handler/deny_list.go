@@ -0,0 +1,92 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/pkg/cs/trust/internal/metrics"
+	"github.com/scionproto/scion/go/pkg/trust"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// DenyListReq handles deny list requests.
+type DenyListReq struct {
+	Provider trust.Provider
+	IA       addr.IA
+}
+
+// Handle handles a deny list request.
+func (h DenyListReq) Handle(req *infra.Request) *infra.HandlerResult {
+	l := metrics.HandlerLabels{ReqType: metrics.DenyListReq, Client: infra.PromSrcUnknown}
+	if req == nil {
+		log.Error("[trust:DenyListReq] Request is nil")
+		metrics.Handler.Request(l.WithResult(metrics.ErrInternal)).Inc()
+		return infra.MetricsErrInternal
+	}
+	l.Client = metrics.PeerToLabel(req.Peer, h.IA)
+
+	span, ctx := opentracing.StartSpanFromContext(req.Context(), "trusthandler.deny_list_request")
+	defer span.Finish()
+	logger := log.FromCtx(ctx)
+
+	dlReq, ok := req.Message.(*cert_mgmt.DenyListReq)
+	if !ok {
+		logger.Error("[trust:DenyListReq] Wrong message type, expected cert_mgmt.DenyListReq",
+			"msg", req.Message, "type", common.TypeOf(req.Message))
+		setHandlerMetric(span, l.WithResult(metrics.ErrInternal), errWrongMsgType)
+		return infra.MetricsErrInternal
+	}
+
+	logger.Debug("[trust:DenyListReq] Received request", "dlReq", dlReq, "peer", req.Peer)
+	rw, ok := infra.ResponseWriterFromContext(ctx)
+	if !ok {
+		logger.Error("[trust:DenyListReq] Unable to service request, no ResponseWriter found")
+		setHandlerMetric(span, l.WithResult(metrics.ErrInternal), errNoResponseWriter)
+		return infra.MetricsErrInternal
+	}
+	sendAck := messenger.SendAckHelper(ctx, rw)
+	dl, err := h.Provider.GetDenyList(ctx,
+		dlReq.ISD,
+		dlReq.Serial,
+		trust.Client(req.Peer),
+	)
+	if err != nil {
+		logger.Error("[trust:DenyListReq] Unable to retrieve deny list", "err", err)
+		sendAck(proto.Ack_ErrCode_reject, AckNotFound)
+		setHandlerMetric(span, l.WithResult(metrics.ErrInternal), err)
+		return infra.MetricsErrTrustStore(err)
+	}
+	if dl.IsZero() {
+		logger.Debug("[trust:DenyListReq] Deny list not found", "dlReq", dlReq)
+		sendAck(proto.Ack_ErrCode_reject, AckNotFound)
+		setHandlerMetric(span, l.WithResult(metrics.ErrNotFound), nil)
+		return infra.MetricsErrInvalid
+	}
+	if err = rw.SendDenyListReply(ctx, &cert_mgmt.DenyList{RawDenyList: dl.Raw}); err != nil {
+		logger.Error("[trust:DenyListReq] Messenger API error", "err", err)
+		setHandlerMetric(span, l.WithResult(metrics.ErrTransmit), err)
+		return infra.MetricsErrMsger(err)
+	}
+	logger.Debug("[trust:DenyListReq] Replied with deny list")
+	setHandlerMetric(span, l.WithResult(metrics.Success), nil)
+	return infra.MetricsResultOk
+}
@@ -37,6 +37,7 @@ const (
 	TRCReq          = "trc_request"
 	ChainReq        = "chain_request"
 	ChainRenewalReq = "chain_renewal_request"
+	DenyListReq     = "deny_list_request"
 	LatestTRC       = "latest_trc_number"
 )
 
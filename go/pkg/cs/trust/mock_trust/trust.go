@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/pkg/cs/trust (interfaces: CACertProvider,PolicyGen,SignerGen)
+// Source: github.com/scionproto/scion/go/pkg/cs/trust (interfaces: CACertProvider,ChainRenewalRequester,PolicyGen,SignerGen)
 
 // Package mock_trust is a generated GoMock package.
 package mock_trust
@@ -8,8 +8,10 @@ import (
 	context "context"
 	x509 "crypto/x509"
 	gomock "github.com/golang/mock/gomock"
+	cert_mgmt "github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
 	cppki "github.com/scionproto/scion/go/lib/scrypto/cppki"
 	trust "github.com/scionproto/scion/go/pkg/trust"
+	net "net"
 	reflect "reflect"
 )
 
@@ -51,6 +53,44 @@ func (mr *MockCACertProviderMockRecorder) CACerts(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CACerts", reflect.TypeOf((*MockCACertProvider)(nil).CACerts), arg0)
 }
 
+// MockChainRenewalRequester is a mock of ChainRenewalRequester interface
+type MockChainRenewalRequester struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainRenewalRequesterMockRecorder
+}
+
+// MockChainRenewalRequesterMockRecorder is the mock recorder for MockChainRenewalRequester
+type MockChainRenewalRequesterMockRecorder struct {
+	mock *MockChainRenewalRequester
+}
+
+// NewMockChainRenewalRequester creates a new mock instance
+func NewMockChainRenewalRequester(ctrl *gomock.Controller) *MockChainRenewalRequester {
+	mock := &MockChainRenewalRequester{ctrl: ctrl}
+	mock.recorder = &MockChainRenewalRequesterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockChainRenewalRequester) EXPECT() *MockChainRenewalRequesterMockRecorder {
+	return m.recorder
+}
+
+// RequestChainRenewal mocks base method
+func (m *MockChainRenewalRequester) RequestChainRenewal(arg0 context.Context, arg1 *cert_mgmt.ChainRenewalRequest, arg2 net.Addr, arg3 uint64) (*cert_mgmt.ChainRenewalReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestChainRenewal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*cert_mgmt.ChainRenewalReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestChainRenewal indicates an expected call of RequestChainRenewal
+func (mr *MockChainRenewalRequesterMockRecorder) RequestChainRenewal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestChainRenewal", reflect.TypeOf((*MockChainRenewalRequester)(nil).RequestChainRenewal), arg0, arg1, arg2, arg3)
+}
+
 // MockPolicyGen is a mock of PolicyGen interface
 type MockPolicyGen struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,240 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/trust"
+	"github.com/scionproto/scion/go/pkg/trust/renewal"
+)
+
+// ChainRenewalRequester sends certificate chain renewal requests to a CA.
+type ChainRenewalRequester interface {
+	RequestChainRenewal(ctx context.Context, msg *cert_mgmt.ChainRenewalRequest, a net.Addr,
+		id uint64) (*cert_mgmt.ChainRenewalReply, error)
+}
+
+// ChainRenewer is a periodic task that renews the AS certificate chain of the
+// control service before it expires.
+//
+// The renewer inspects the chain of the signer that is currently in use. Once
+// less than a third of the AS certificate validity period remains, it
+// generates a fresh key, requests a new chain from the issuing CA, and
+// verifies it against the active TRCs. The key and chain are written to Dir
+// and the chain is inserted into the trust DB. The signer generator backing
+// the RenewingSigner picks up the new key and chain on its next generation,
+// because the renewed chain expires later than the current one.
+type ChainRenewer struct {
+	// IA is the ISD-AS of the local AS.
+	IA addr.IA
+	// SignerGen generates the signer that is currently in use. It is also
+	// used to authenticate the renewal request.
+	SignerGen SignerGen
+	// Requester sends the renewal request to the issuing CA.
+	Requester ChainRenewalRequester
+	// DB is used to look up the active TRCs and to store the renewed chain.
+	DB trust.DB
+	// Dir is the directory the renewed key and chain are written to.
+	Dir string
+}
+
+// Name returns the task name.
+func (r *ChainRenewer) Name() string {
+	return "cs_trust_chain_renewer"
+}
+
+// Run renews the AS certificate chain if it is about to expire.
+func (r *ChainRenewer) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	chain, err := r.renew(ctx, time.Now())
+	if err != nil {
+		logger.Info("Failed to renew AS certificate chain", "err", err)
+		return
+	}
+	if chain == nil {
+		return
+	}
+	logger.Info("Renewed AS certificate chain",
+		"subject_key_id", fmt.Sprintf("%x", chain[0].SubjectKeyId),
+		"not_after", chain[0].NotAfter,
+	)
+}
+
+// renew renews the AS certificate chain if required. It returns the renewed
+// chain, or nil if no renewal was necessary.
+func (r *ChainRenewer) renew(ctx context.Context, now time.Time) ([]*x509.Certificate, error) {
+	signer, err := r.SignerGen.Generate(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("generating signer", err)
+	}
+	if !needsRenewal(signer.ChainValidity, now) {
+		return nil, nil
+	}
+	if len(signer.Chain) != 2 {
+		return nil, serrors.New("signer has no certificate chain")
+	}
+	caIA, err := cppki.ExtractIA(signer.Chain[1].Subject)
+	if err != nil || caIA == nil {
+		return nil, serrors.New("unable to extract CA ISD-AS", "err", err)
+	}
+	key, err := newKey(signer.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, csrTemplate(signer.Chain[0]), key)
+	if err != nil {
+		return nil, serrors.WrapStr("creating CSR", err)
+	}
+	req, err := renewal.NewChainRenewalRequest(ctx, csr, signer)
+	if err != nil {
+		return nil, serrors.WrapStr("creating renewal request", err)
+	}
+	dst := &snet.SVCAddr{IA: *caIA, SVC: addr.SvcCS}
+	rep, err := r.Requester.RequestChainRenewal(ctx, req, dst, messenger.NextId())
+	if err != nil {
+		return nil, serrors.WrapStr("requesting renewal", err, "ca", caIA)
+	}
+	chain, err := rep.Chain()
+	if err != nil {
+		return nil, serrors.WrapStr("parsing renewed chain", err)
+	}
+	if err := r.verify(ctx, chain, key, now); err != nil {
+		return nil, serrors.WrapStr("verifying renewed chain", err)
+	}
+	if err := r.write(chain, key); err != nil {
+		return nil, err
+	}
+	if _, err := r.DB.InsertChain(ctx, chain); err != nil {
+		return nil, serrors.WrapStr("inserting renewed chain", err)
+	}
+	return chain, nil
+}
+
+// verify checks that the renewed chain authenticates the given key for the
+// local AS, and that it is verifiable by one of the active TRCs.
+func (r *ChainRenewer) verify(ctx context.Context, chain []*x509.Certificate,
+	key *ecdsa.PrivateKey, now time.Time) error {
+
+	ia, err := cppki.ExtractIA(chain[0].Subject)
+	if err != nil || ia == nil || !ia.Equal(r.IA) {
+		return serrors.New("chain does not authenticate local AS",
+			"expected", r.IA, "actual", ia, "err", err)
+	}
+	pub, ok := chain[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok || pub.X.Cmp(key.X) != 0 || pub.Y.Cmp(key.Y) != 0 {
+		return serrors.New("chain does not authenticate renewed key")
+	}
+	trc, err := r.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    r.IA.I,
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		return serrors.WrapStr("loading TRC", err)
+	}
+	if trc.IsZero() {
+		return serrors.New("no TRC found", "isd", r.IA.I)
+	}
+	opts := cppki.VerifyOptions{TRC: &trc.TRC, CurrentTime: now}
+	err = cppki.VerifyChain(chain, opts)
+	if err == nil || !trc.TRC.InGracePeriod(now) {
+		return err
+	}
+	grace, dbErr := r.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    r.IA.I,
+		Base:   trc.TRC.ID.Base,
+		Serial: trc.TRC.ID.Serial - 1,
+	})
+	if dbErr != nil || grace.IsZero() {
+		return err
+	}
+	return cppki.VerifyChain(chain, cppki.VerifyOptions{TRC: &grace.TRC, CurrentTime: now})
+}
+
+// write writes the key and the chain to the renewer directory. The key is
+// written first, such that the chain is never loaded without its key.
+func (r *ChainRenewer) write(chain []*x509.Certificate, key *ecdsa.PrivateKey) error {
+	raw, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return serrors.WrapStr("packing private key", err)
+	}
+	suffix := fmt.Sprintf("%x", chain[0].SerialNumber.Bytes())
+	keyFile := filepath.Join(r.Dir, fmt.Sprintf("cp-as.%s.key", suffix))
+	rawKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw})
+	if err := ioutil.WriteFile(keyFile, rawKey, 0600); err != nil {
+		return serrors.WrapStr("writing private key", err, "file", keyFile)
+	}
+	var rawChain []byte
+	for _, cert := range chain {
+		rawChain = append(rawChain, pem.EncodeToMemory(
+			&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	chainFile := filepath.Join(r.Dir, fmt.Sprintf("ISD%d-AS%s.%s.pem",
+		r.IA.I, r.IA.A.FileFmt(), suffix))
+	if err := ioutil.WriteFile(chainFile, rawChain, 0644); err != nil {
+		return serrors.WrapStr("writing certificate chain", err, "file", chainFile)
+	}
+	return nil
+}
+
+// needsRenewal indicates whether less than a third of the validity period
+// remains at the given point in time.
+func needsRenewal(validity cppki.Validity, now time.Time) bool {
+	period := validity.NotAfter.Sub(validity.NotBefore)
+	return validity.NotAfter.Sub(now) < period/3
+}
+
+// newKey generates a fresh key on the same curve as the current key.
+func newKey(current crypto.Signer) (*ecdsa.PrivateKey, error) {
+	pub, ok := current.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, serrors.New("unsupported key type", "type", fmt.Sprintf("%T", current))
+	}
+	key, err := ecdsa.GenerateKey(pub.Curve, rand.Reader)
+	if err != nil {
+		return nil, serrors.WrapStr("generating key", err)
+	}
+	return key, nil
+}
+
+// csrTemplate creates a certificate request template with the same subject as
+// the current AS certificate.
+func csrTemplate(cert *x509.Certificate) *x509.CertificateRequest {
+	s := cert.Subject
+	s.ExtraNames = s.Names
+	return &x509.CertificateRequest{
+		Subject:            s,
+		SignatureAlgorithm: x509.ECDSAWithSHA512,
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	cstrust "github.com/scionproto/scion/go/pkg/cs/trust"
+	mock_cstrust "github.com/scionproto/scion/go/pkg/cs/trust/mock_trust"
+	"github.com/scionproto/scion/go/pkg/trust"
+	"github.com/scionproto/scion/go/pkg/trust/mock_trust"
+)
+
+func TestChainRenewerRenew(t *testing.T) {
+	trc := xtest.LoadTRC(t, "testdata/common/trcs/ISD1-B1-S1.trc")
+	chain := xtest.LoadChain(t, "testdata/common/ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem")
+	key := loadKey(t, "testdata/common/ISD1/ASff00_0_111/crypto/as/cp-as.key")
+	ca := cppki.CAPolicy{
+		Validity:    30 * 24 * time.Hour,
+		Certificate: chain[1],
+		Signer:      loadKey(t, "testdata/common/ISD1/ASff00_0_110/crypto/ca/cp-ca.key"),
+	}
+	signer := trust.Signer{
+		PrivateKey:   key,
+		Hash:         crypto.SHA512,
+		IA:           xtest.MustParseIA("1-ff00:0:111"),
+		TRCID:        trc.TRC.ID,
+		SubjectKeyID: chain[0].SubjectKeyId,
+		Expiration:   time.Now().Add(2 * time.Hour),
+		ChainValidity: cppki.Validity{
+			NotBefore: chain[0].NotBefore,
+			NotAfter:  chain[0].NotAfter,
+		},
+		Chain: chain,
+	}
+	// due is a point in time where less than a third of the AS certificate
+	// validity remains.
+	due := chain[0].NotAfter.Add(-30 * 24 * time.Hour)
+
+	testCases := map[string]struct {
+		Now       time.Time
+		Requester func(t *testing.T, ctrl *gomock.Controller) cstrust.ChainRenewalRequester
+		DB        func(ctrl *gomock.Controller) trust.DB
+		Renewed   assert.BoolAssertionFunc
+		ErrAssert assert.ErrorAssertionFunc
+	}{
+		"not due": {
+			Now: chain[0].NotBefore.Add(time.Hour),
+			Requester: func(t *testing.T,
+				ctrl *gomock.Controller) cstrust.ChainRenewalRequester {
+
+				return mock_cstrust.NewMockChainRenewalRequester(ctrl)
+			},
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				return mock_trust.NewMockDB(ctrl)
+			},
+			Renewed:   assert.False,
+			ErrAssert: assert.NoError,
+		},
+		"renewed": {
+			Now: due,
+			Requester: func(t *testing.T,
+				ctrl *gomock.Controller) cstrust.ChainRenewalRequester {
+
+				r := mock_cstrust.NewMockChainRenewalRequester(ctrl)
+				r.EXPECT().RequestChainRenewal(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *cert_mgmt.ChainRenewalRequest, _ net.Addr,
+						_ uint64) (*cert_mgmt.ChainRenewalReply, error) {
+
+						csr, err := req.CertificateRequest()
+						require.NoError(t, err)
+						ca := ca
+						ca.CurrentTime = due
+						renewed, err := ca.CreateChain(csr)
+						require.NoError(t, err)
+						return &cert_mgmt.ChainRenewalReply{
+							RawChain: append(renewed[0].Raw, renewed[1].Raw...),
+						}, nil
+					},
+				)
+				return r
+			},
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), cppki.TRCID{ISD: 1}).Return(trc, nil)
+				db.EXPECT().InsertChain(gomock.Any(), gomock.Any()).Return(true, nil)
+				return db
+			},
+			Renewed:   assert.True,
+			ErrAssert: assert.NoError,
+		},
+		"request fails": {
+			Now: due,
+			Requester: func(t *testing.T,
+				ctrl *gomock.Controller) cstrust.ChainRenewalRequester {
+
+				r := mock_cstrust.NewMockChainRenewalRequester(ctrl)
+				r.EXPECT().RequestChainRenewal(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).Return(nil, serrors.New("internal"))
+				return r
+			},
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				return mock_trust.NewMockDB(ctrl)
+			},
+			Renewed:   assert.False,
+			ErrAssert: assert.Error,
+		},
+		"renewed chain for other key": {
+			Now: due,
+			Requester: func(t *testing.T,
+				ctrl *gomock.Controller) cstrust.ChainRenewalRequester {
+
+				r := mock_cstrust.NewMockChainRenewalRequester(ctrl)
+				r.EXPECT().RequestChainRenewal(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).Return(&cert_mgmt.ChainRenewalReply{
+					RawChain: append(chain[0].Raw, chain[1].Raw...),
+				}, nil)
+				return r
+			},
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				return mock_trust.NewMockDB(ctrl)
+			},
+			Renewed:   assert.False,
+			ErrAssert: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir, err := ioutil.TempDir("", "chain-renewer")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			gen := mock_cstrust.NewMockSignerGen(ctrl)
+			gen.EXPECT().Generate(gomock.Any()).Return(signer, nil)
+			renewer := cstrust.ChainRenewer{
+				IA:        xtest.MustParseIA("1-ff00:0:111"),
+				SignerGen: gen,
+				Requester: tc.Requester(t, ctrl),
+				DB:        tc.DB(ctrl),
+				Dir:       dir,
+			}
+			renewed, err := renewer.Renew(context.Background(), tc.Now)
+			tc.ErrAssert(t, err)
+			tc.Renewed(t, renewed != nil)
+
+			keys, err := cstrust.LoadingRing{Dir: dir}.PrivateKeys(context.Background())
+			require.NoError(t, err)
+			files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+			require.NoError(t, err)
+			if renewed == nil {
+				assert.Empty(t, keys)
+				assert.Empty(t, files)
+				return
+			}
+			require.Len(t, keys, 1)
+			require.Len(t, files, 1)
+			assert.Equal(t, renewed[0].PublicKey, keys[0].Public())
+			assert.NotEqual(t, key.Public(), keys[0].Public())
+			written, err := cppki.ReadPEMCerts(files[0])
+			require.NoError(t, err)
+			assert.Equal(t, renewed, written)
+			assert.Equal(t, x509.ECDSAWithSHA512, renewed[0].SignatureAlgorithm)
+		})
+	}
+}
@@ -17,6 +17,7 @@ package ping
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"net"
 	"time"
@@ -28,13 +29,21 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
+// ErrPacketTooBig indicates that an SCMP packet too big error was received
+// for an echo request.
+var ErrPacketTooBig = serrors.New("packet too big")
+
 // Stats contains the statistics of a ping run.
 type Stats struct {
 	Sent     int
 	Received int
+	// PathMTU is the MTU estimate of the path at the end of the run. It is
+	// zero if no path is configured.
+	PathMTU uint16
 }
 
 // Update contains intermediary information about a received echo reply
@@ -70,6 +79,13 @@ type Config struct {
 	Timeout time.Duration
 	// PayloadSize is the size of the SCMP echo payload.
 	PayloadSize int
+	// Path is the path the pings are sent on. If set, the MTU estimate of
+	// the path is tracked. It starts at the MTU of the path and is lowered
+	// whenever an SCMP packet too big error is received.
+	Path snet.Path
+	// MaxMTU sets the payload size such that the packets are as big as the
+	// current MTU estimate of the path. PayloadSize is ignored. Requires Path.
+	MaxMTU bool
 
 	// ErrHandler is invoked for every error that does not cause pinging to
 	// abort. Execution time must be small, as it is run synchronous.
@@ -87,15 +103,21 @@ func Run(ctx context.Context, cfg Config) (Stats, error) {
 	if cfg.Interval < time.Millisecond {
 		return Stats{}, serrors.New("interval below millisecond")
 	}
+	if cfg.MaxMTU && cfg.Path == nil {
+		return Stats{}, serrors.New("path required for max MTU")
+	}
 
 	id := rand.Uint64()
 	replies := make(chan reply, 10)
+	pathMTUs := snet.NewPathMTUs()
 
 	svc := snet.DefaultPacketDispatcherService{
 		Dispatcher: cfg.Dispatcher,
 		SCMPHandler: scmpHandler{
-			id:      id,
-			replies: replies,
+			id:       id,
+			replies:  replies,
+			headerV2: cfg.HeaderV2,
+			pathMTUs: pathMTUs,
 		},
 		Version2: cfg.HeaderV2,
 	}
@@ -118,8 +140,16 @@ func Run(ctx context.Context, cfg Config) (Stats, error) {
 		replies:       replies,
 		errHandler:    cfg.ErrHandler,
 		updateHandler: cfg.UpdateHandler,
+		headerV2:      cfg.HeaderV2,
+		path:          cfg.Path,
+		pathMTUs:      pathMTUs,
+		maxMTU:        cfg.MaxMTU,
+	}
+	stats, err := p.Ping(ctx, cfg.Remote)
+	if cfg.Path != nil {
+		stats.PathMTU = pathMTUs.MTU(cfg.Path)
 	}
-	return p.Ping(ctx, cfg.Remote)
+	return stats, err
 }
 
 type pinger struct {
@@ -137,6 +167,12 @@ type pinger struct {
 	errHandler    func(error)
 	updateHandler func(Update)
 
+	// Path MTU discovery
+	headerV2 bool
+	path     snet.Path
+	pathMTUs *snet.PathMTUs
+	maxMTU   bool
+
 	// Mutable state
 	sentSequence     int
 	receivedSequence int
@@ -156,6 +192,12 @@ func (p *pinger) Ping(ctx context.Context, remote *snet.UDPAddr) (Stats, error)
 		p.drain(ctx)
 	}()
 
+	if p.maxMTU {
+		if err := p.adaptPayload(remote); err != nil {
+			return p.stats, err
+		}
+	}
+
 	for i := uint16(0); i < p.attempts; i++ {
 		select {
 		case <-ctx.Done():
@@ -166,6 +208,11 @@ func (p *pinger) Ping(ctx context.Context, remote *snet.UDPAddr) (Stats, error)
 			}
 		case reply := <-p.replies:
 			if reply.Error != nil {
+				if p.maxMTU && errors.Is(reply.Error, ErrPacketTooBig) {
+					if err := p.adaptPayload(remote); err != nil {
+						return p.stats, err
+					}
+				}
 				if p.errHandler != nil {
 					p.errHandler(reply.Error)
 				}
@@ -203,6 +250,25 @@ func (p *pinger) send(remote *snet.UDPAddr) error {
 	return nil
 }
 
+// adaptPayload sets the payload size such that the echo requests are as big
+// as the current MTU estimate of the path.
+func (p *pinger) adaptPayload(remote *snet.UDPAddr) error {
+	size := SizeLegacy
+	if p.headerV2 {
+		size = Size
+	}
+	overhead, err := size(p.local, remote, 0)
+	if err != nil {
+		return err
+	}
+	mtu := int(p.pathMTUs.MTU(p.path))
+	if mtu < overhead {
+		return serrors.New("path MTU too small", "mtu", mtu, "overhead", overhead)
+	}
+	p.pldSize = mtu - overhead
+	return nil
+}
+
 func (p *pinger) receive(reply reply) {
 	rtt := reply.Received.Sub(reply.Header.Time()).Round(time.Microsecond)
 	var state State
@@ -259,8 +325,10 @@ type reply struct {
 }
 
 type scmpHandler struct {
-	id      uint64
-	replies chan<- reply
+	id       uint64
+	replies  chan<- reply
+	headerV2 bool
+	pathMTUs *snet.PathMTUs
 }
 
 func (h scmpHandler) Handle(pkt *snet.Packet) error {
@@ -287,6 +355,9 @@ func (h scmpHandler) handle(pkt *snet.Packet) (*scmp.Hdr, *scmp.InfoEcho, error)
 		return scmpHdr, nil,
 			serrors.New("not an SCMP payload", "type", common.TypeOf(pkt.Payload))
 	}
+	if scmpHdr.Class == scmp.C_Routing && scmpHdr.Type == scmp.T_R_OversizePkt {
+		return nil, nil, h.handlePacketTooBig(scmpPld)
+	}
 	info, ok := scmpPld.Info.(*scmp.InfoEcho)
 	if !ok {
 		return nil, nil, serrors.New("not an echo", "type", common.TypeOf(scmpPld.Info))
@@ -296,3 +367,21 @@ func (h scmpHandler) handle(pkt *snet.Packet) (*scmp.Hdr, *scmp.InfoEcho, error)
 	}
 	return scmpHdr, info, nil
 }
+
+// handlePacketTooBig lowers the MTU estimate of the path quoted in the SCMP
+// packet too big error.
+func (h scmpHandler) handlePacketTooBig(pld *scmp.Payload) error {
+	info, ok := pld.Info.(*scmp.InfoPktSize)
+	if !ok {
+		return serrors.New("not a packet size info", "type", common.TypeOf(pld.Info))
+	}
+	if len(pld.PathHdr) > 0 {
+		raw := append([]byte(nil), pld.PathHdr...)
+		path := spath.New(raw)
+		if h.headerV2 {
+			path = spath.NewV2(raw, false)
+		}
+		h.pathMTUs.Update(path, info.MTU)
+	}
+	return serrors.WithCtx(ErrPacketTooBig, "size", info.Size, "mtu", info.MTU)
+}
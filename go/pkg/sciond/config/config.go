@@ -17,8 +17,10 @@ package config
 
 import (
 	"io"
+	"net"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
@@ -44,7 +46,9 @@ type Config struct {
 	TrustDB  truststorage.TrustDBConf `toml:"trust_db,omitempty"`
 	// PathDB contains the configuration for the PathDB connection.
 	PathDB pathstorage.PathDBConf `toml:"path_db,omitempty"`
-	SD     SDConfig               `toml:"sd,omitempty"`
+	// RevCache contains the configuration for the RevCache connection.
+	RevCache pathstorage.RevCacheConf `toml:"rev_cache,omitempty"`
+	SD       SDConfig                 `toml:"sd,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -56,6 +60,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Tracing,
 		&cfg.TrustDB,
 		&cfg.PathDB,
+		&cfg.RevCache,
 		&cfg.SD,
 	)
 }
@@ -68,6 +73,7 @@ func (cfg *Config) Validate() error {
 		&cfg.Metrics,
 		&cfg.TrustDB,
 		&cfg.PathDB,
+		&cfg.RevCache,
 		&cfg.SD,
 	)
 }
@@ -81,6 +87,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Tracing,
 		&cfg.TrustDB,
 		&cfg.PathDB,
+		&cfg.RevCache,
 		&cfg.SD,
 	)
 }
@@ -94,6 +101,17 @@ type SDConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+	// HiddenPathServer is the TCP address of the hidden path server that is
+	// queried for hidden path segments. It is required if HiddenPathGroups is
+	// set.
+	HiddenPathServer string `toml:"hidden_path_server,omitempty"`
+	// HiddenPathRegistries maps the ISD-AS of hidden path registries to the
+	// TCP address of their hidden path server. Groups with one of these
+	// registries are requested from it directly instead of HiddenPathServer.
+	HiddenPathRegistries map[string]string `toml:"hidden_path_registries,omitempty"`
+	// HiddenPathGroups are the configuration files of the hidden path groups
+	// SCIOND is a member of.
+	HiddenPathGroups []string `toml:"hidden_path_groups,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -109,6 +127,24 @@ func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
+	if len(cfg.HiddenPathGroups) > 0 {
+		if cfg.HiddenPathServer == "" {
+			return serrors.New("HiddenPathServer must be set if HiddenPathGroups is set")
+		}
+		if _, err := net.ResolveTCPAddr("tcp", cfg.HiddenPathServer); err != nil {
+			return serrors.WrapStr("invalid HiddenPathServer", err,
+				"address", cfg.HiddenPathServer)
+		}
+	}
+	for ia, a := range cfg.HiddenPathRegistries {
+		if _, err := addr.IAFromString(ia); err != nil {
+			return serrors.WrapStr("invalid hidden path registry", err, "isd_as", ia)
+		}
+		if _, err := net.ResolveTCPAddr("tcp", a); err != nil {
+			return serrors.WrapStr("invalid hidden path registry address", err,
+				"isd_as", ia, "address", a)
+		}
+	}
 	return nil
 }
 
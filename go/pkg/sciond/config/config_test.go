@@ -44,6 +44,7 @@ func InitTestConfig(cfg *Config) {
 	logtest.InitTestLogging(&cfg.Logging)
 	truststoragetest.InitTestConfig(&cfg.TrustDB)
 	pathstoragetest.InitTestPathDBConf(&cfg.PathDB)
+	pathstoragetest.InitTestRevCacheConf(&cfg.RevCache)
 	InitTestSDConfig(&cfg.SD)
 }
 
@@ -56,10 +57,80 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
 	truststoragetest.CheckTestConfig(t, &cfg.TrustDB, id)
 	pathstoragetest.CheckTestPathDBConf(t, &cfg.PathDB, id)
+	pathstoragetest.CheckTestRevCacheConf(t, &cfg.RevCache, id)
 	CheckTestSDConfig(t, &cfg.SD, id)
 }
 
 func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, sciond.DefaultSCIONDAddress, cfg.Address)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Equal(t, "127.0.0.1:30260", cfg.HiddenPathServer)
+	assert.Equal(t, map[string]string{"1-ff00:0:111": "127.0.0.1:30262"},
+		cfg.HiddenPathRegistries)
+	assert.Equal(t, []string{"/etc/scion/hpg/ff00_0_110-69b5.json"},
+		cfg.HiddenPathGroups)
+}
+
+func TestSDConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		Modify    func(cfg *SDConfig)
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"default": {
+			Modify:    func(cfg *SDConfig) {},
+			Assertion: assert.NoError,
+		},
+		"hidden path groups": {
+			Modify: func(cfg *SDConfig) {
+				cfg.HiddenPathServer = "127.0.0.1:30260"
+				cfg.HiddenPathGroups = []string{"group.json"}
+			},
+			Assertion: assert.NoError,
+		},
+		"hidden path groups without server": {
+			Modify: func(cfg *SDConfig) {
+				cfg.HiddenPathGroups = []string{"group.json"}
+			},
+			Assertion: assert.Error,
+		},
+		"invalid hidden path server": {
+			Modify: func(cfg *SDConfig) {
+				cfg.HiddenPathServer = "garbage"
+				cfg.HiddenPathGroups = []string{"group.json"}
+			},
+			Assertion: assert.Error,
+		},
+		"hidden path registries": {
+			Modify: func(cfg *SDConfig) {
+				cfg.HiddenPathServer = "127.0.0.1:30260"
+				cfg.HiddenPathRegistries = map[string]string{"1-ff00:0:111": "127.0.0.1:30262"}
+				cfg.HiddenPathGroups = []string{"group.json"}
+			},
+			Assertion: assert.NoError,
+		},
+		"invalid hidden path registry": {
+			Modify: func(cfg *SDConfig) {
+				cfg.HiddenPathServer = "127.0.0.1:30260"
+				cfg.HiddenPathRegistries = map[string]string{"garbage": "127.0.0.1:30262"}
+				cfg.HiddenPathGroups = []string{"group.json"}
+			},
+			Assertion: assert.Error,
+		},
+		"invalid hidden path registry address": {
+			Modify: func(cfg *SDConfig) {
+				cfg.HiddenPathServer = "127.0.0.1:30260"
+				cfg.HiddenPathRegistries = map[string]string{"1-ff00:0:111": "garbage"}
+				cfg.HiddenPathGroups = []string{"group.json"}
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg SDConfig
+			cfg.InitDefaults()
+			test.Modify(&cfg)
+			test.Assertion(t, cfg.Validate())
+		})
+	}
 }
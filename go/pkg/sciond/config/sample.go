@@ -22,4 +22,18 @@ address = "127.0.0.1:30255"
 
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
+
+# The TCP address of the hidden path server that is queried for the segments of
+# the hidden path groups. Required if hidden_path_groups is set. (default "")
+hidden_path_server = "127.0.0.1:30260"
+
+# The TCP addresses of the hidden path servers of hidden path registries, keyed
+# by the ISD-AS of the registry. Groups with one of these registries are
+# requested from it directly instead of hidden_path_server. (default {})
+hidden_path_registries = { "1-ff00:0:111" = "127.0.0.1:30262" }
+
+# The configuration files of the hidden path groups the SCION Daemon is a member
+# of. Hidden paths are only looked up if at least one group is configured.
+# (default [])
+hidden_path_groups = ["/etc/scion/hpg/ff00_0_110-69b5.json"]
 `
@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
 	"github.com/scionproto/scion/go/lib/revcache"
@@ -56,15 +57,25 @@ type Fetcher interface {
 
 type fetcher struct {
 	pather segfetcher.Pather
+	hidden *hiddenFetcher
 	config config.SDConfig
 }
 
 func NewFetcher(requestAPI segfetcher.RequestAPI, pathDB pathdb.PathDB, inspector trust.Inspector,
 	verifier infra.Verifier, revCache revcache.RevCache, cfg config.SDConfig,
-	topoProvider topology.Provider, headerV2 bool) Fetcher {
+	topoProvider topology.Provider, headerV2 bool, hidden HiddenConfig) Fetcher {
 
 	localIA := topoProvider.Get().IA()
 	return &fetcher{
+		hidden: &hiddenFetcher{
+			config:       hidden,
+			topoProvider: topoProvider,
+			pathDB:       pathDB,
+			replyHandler: &seghandler.Handler{
+				Verifier: &seghandler.DefaultVerifier{Verifier: verifier},
+				Storage:  &seghandler.DefaultStorage{PathDB: pathDB, RevCache: revCache},
+			},
+		},
 		pather: segfetcher.Pather{
 			RevCache:     revCache,
 			TopoProvider: topoProvider,
@@ -109,7 +120,13 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 		return &sciond.PathReply{ErrorCode: sciond.ErrorBadSrcIA},
 			serrors.New("Bad source AS", "src", req.Src.IA())
 	}
-	cPaths, err := f.pather.GetPaths(ctx, req.Dst.IA(), req.Flags.Refresh)
+	var cPaths []*combinator.Path
+	var err error
+	if req.Flags.Hidden {
+		cPaths, err = f.getHiddenPaths(ctx, req)
+	} else {
+		cPaths, err = f.pather.GetPaths(ctx, req.Dst.IA(), req.Flags.Refresh)
+	}
 	switch {
 	case err == nil:
 		break
@@ -142,6 +159,39 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 	return &sciond.PathReply{ErrorCode: sciond.ErrorOk, Entries: paths}, nil
 }
 
+// getHiddenPaths returns the paths that are built from the hidden segments of
+// the requested hidden path groups. If requested, the public segments are used
+// as well. Note that core segments are public, i.e., if only hidden segments
+// are used, only paths that do not contain a core segment are found.
+func (f *fetcher) getHiddenPaths(ctx context.Context,
+	req *sciond.PathReq) ([]*combinator.Path, error) {
+
+	dst := req.Dst.IA()
+	if dst.I == 0 || dst.Equal(f.pather.TopoProvider.Get().IA()) {
+		return f.pather.GetPaths(ctx, dst, req.Flags.Refresh)
+	}
+	ids, public, err := f.hidden.groups(req.HPCfgs)
+	if err != nil {
+		return nil, serrors.Wrap(segfetcher.ErrNoPaths, err)
+	}
+	segs, err := f.hidden.Fetch(ctx, ids, dst)
+	if err != nil {
+		return nil, err
+	}
+	if public {
+		reqs, err := f.pather.Splitter.Split(ctx, dst)
+		if err != nil {
+			return nil, err
+		}
+		publicSegs, err := f.pather.Fetcher.Fetch(ctx, reqs, req.Flags.Refresh)
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, publicSegs...)
+	}
+	return f.pather.Build(ctx, dst, segs)
+}
+
 // translate returns a translated sciond.PathReplyEntry objects from the
 // combinator path.
 //
@@ -17,6 +17,7 @@ package fetcher
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/pathpol"
@@ -28,9 +29,18 @@ type Policy interface {
 	Filter(pathpol.PathSet) pathpol.PathSet
 }
 
-// Filter filters the given paths with the given policy. Note that this
-// function might change the order of elements.
+// Ranker is a policy that additionally orders the paths it accepts.
+type Ranker interface {
+	Rank(pathpol.PathSet) []pathpol.Path
+}
+
+// Filter filters the given paths with the given policy. If the policy is a
+// Ranker, the paths are returned in the order of preference of the policy.
+// Otherwise, this function might change the order of elements.
 func Filter(paths []*combinator.Path, policy Policy) []*combinator.Path {
+	if ranker, ok := policy.(Ranker); ok {
+		return rankedToPaths(ranker.Rank(pathsToPs(paths)))
+	}
 	return psToPaths(policy.Filter(pathsToPs(paths)))
 }
 
@@ -51,10 +61,19 @@ func psToPaths(ps pathpol.PathSet) []*combinator.Path {
 	return paths
 }
 
+func rankedToPaths(ranked []pathpol.Path) []*combinator.Path {
+	paths := make([]*combinator.Path, 0, len(ranked))
+	for _, wp := range ranked {
+		paths = append(paths, wp.(pathWrap).origPath)
+	}
+	return paths
+}
+
 type pathWrap struct {
 	key      snet.PathFingerprint
 	intfs    []snet.PathInterface
 	origPath *combinator.Path
+	meta     *snet.PathMetadata
 }
 
 func newPathWrap(p *combinator.Path) pathWrap {
@@ -68,8 +87,26 @@ func newPathWrap(p *combinator.Path) pathWrap {
 		key:      snet.PathFingerprint(strings.Join(keyParts, " ")),
 		intfs:    intfs,
 		origPath: p,
+		meta:     condenseSnetMetadata(p.Metadata),
 	}
 }
 
 func (p pathWrap) Interfaces() []snet.PathInterface  { return p.intfs }
 func (p pathWrap) Fingerprint() snet.PathFingerprint { return p.key }
+func (p pathWrap) MTU() uint16                       { return p.origPath.Mtu }
+func (p pathWrap) Expiry() time.Time                 { return p.origPath.ComputeExpTime() }
+func (p pathWrap) Metadata() *snet.PathMetadata      { return p.meta }
+
+// condenseSnetMetadata condenses the raw metadata to the values that are
+// considered by path policies.
+func condenseSnetMetadata(data *combinator.PathMetadata) *snet.PathMetadata {
+	if data == nil {
+		return nil
+	}
+	condensed := CondenseMetadata(data)
+	return &snet.PathMetadata{
+		Latency:      time.Duration(condensed.Latency) * time.Millisecond,
+		Bandwidth:    condensed.Bandwidth,
+		InternalHops: condensed.Hops,
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
@@ -82,3 +83,42 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterRanked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	seg110To130 := g.Beacon([]common.IFIDType{graph.If_110_X_130_A})
+	seg130To112 := g.Beacon([]common.IFIDType{graph.If_130_A_112_X})
+	seg130To112Via111 := g.Beacon([]common.IFIDType{graph.If_130_B_111_A,
+		graph.If_111_A_112_X})
+
+	paths := combinator.Combine(ia112, ia110,
+		[]*seg.PathSegment{seg130To112, seg130To112Via111},
+		[]*seg.PathSegment{seg110To130},
+		nil)
+	require.Len(t, paths, 2)
+
+	tests := map[string]struct {
+		Ordering pathpol.Ordering
+		Less     func(a, b int) bool
+	}{
+		"fewest hops first": {
+			Ordering: pathpol.Ordering{{Metric: pathpol.Hops}},
+			Less:     func(a, b int) bool { return a < b },
+		},
+		"most hops first": {
+			Ordering: pathpol.Ordering{{Metric: pathpol.Hops, Descending: true}},
+			Less:     func(a, b int) bool { return a > b },
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ranked := fetcher.Filter(paths, &pathpol.Policy{Ordering: test.Ordering})
+			require.ElementsMatch(t, paths, ranked)
+			assert.True(t, test.Less(len(ranked[0].Interfaces), len(ranked[1].Interfaces)))
+		})
+	}
+}
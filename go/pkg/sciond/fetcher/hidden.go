@@ -0,0 +1,244 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"net"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// HPSegRequester requests hidden path segments from a hidden path server.
+type HPSegRequester interface {
+	GetHPSegs(ctx context.Context, msg *path_mgmt.HPSegReq, a net.Addr,
+		id uint64) (*path_mgmt.HPSegReply, error)
+}
+
+// HiddenConfig is the configuration for the hidden path segment lookups.
+type HiddenConfig struct {
+	// Groups are the hidden path groups SCIOND is a member of.
+	Groups map[hiddenpath.GroupId]*hiddenpath.Group
+	// Registries maps the ISD-AS of hidden path registries to the address of
+	// their hidden path server. The segments of a group are requested from the
+	// first of its registries that has an address.
+	Registries map[addr.IA]net.Addr
+	// Server is the address of the hidden path server that is queried for the
+	// segments of groups without a registry in Registries. It forwards the
+	// request to the registries of the group.
+	Server net.Addr
+	// Requester is used to request the hidden path segments.
+	Requester HPSegRequester
+}
+
+// hiddenFetcher fetches the hidden path segments of a set of groups from the
+// hidden path server, verifies them and stores them in the path database.
+type hiddenFetcher struct {
+	config       HiddenConfig
+	topoProvider topology.Provider
+	pathDB       pathdb.Read
+	replyHandler segfetcher.ReplyHandler
+}
+
+// groups returns the hidden path groups that are requested in hpCfgs and
+// whether public segments are requested as well. If no group is requested
+// explicitly, all configured groups are returned.
+func (f *hiddenFetcher) groups(hpCfgs []*path_mgmt.HPGroupId) ([]hiddenpath.GroupId,
+	bool, error) {
+
+	if len(f.config.Groups) == 0 {
+		return nil, false, serrors.New("no hidden path groups configured")
+	}
+	var ids []hiddenpath.GroupId
+	public := false
+	for _, msg := range hpCfgs {
+		id := hiddenpath.IdFromMsg(msg)
+		if id == sciond.PublicHPGroupID {
+			public = true
+			continue
+		}
+		if _, ok := f.config.Groups[id]; !ok {
+			return nil, false, serrors.New("unknown hidden path group", "group", id)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		for id := range f.config.Groups {
+			ids = append(ids, id)
+		}
+	}
+	return ids, public, nil
+}
+
+// Fetch returns the hidden up segments of the local AS and the hidden down
+// segments to dst in the given groups. The segments are requested from the
+// hidden path servers of the groups first. If the request fails, the segments
+// that are already in the path database are returned.
+func (f *hiddenFetcher) Fetch(ctx context.Context, ids []hiddenpath.GroupId,
+	dst addr.IA) (segfetcher.Segments, error) {
+
+	logger := log.FromCtx(ctx)
+	local := f.topoProvider.Get().IA()
+	queries := []*query.Params{{
+		EndsAt:   []addr.IA{local},
+		SegTypes: []proto.PathSegType{proto.PathSegType_up},
+		HpCfgIDs: hpCfgIDs(ids),
+	}}
+	if !dst.IsWildcard() {
+		queries = append(queries, &query.Params{
+			EndsAt:   []addr.IA{dst},
+			SegTypes: []proto.PathSegType{proto.PathSegType_down},
+			HpCfgIDs: hpCfgIDs(ids),
+		})
+	}
+	for _, q := range queries {
+		if err := f.request(ctx, q.EndsAt[0], ids); err != nil {
+			logger.Info("Failed to fetch hidden segments, using cached segments",
+				"dst", q.EndsAt[0], "err", err)
+		}
+	}
+	var segs segfetcher.Segments
+	for _, q := range queries {
+		results, err := f.pathDB.Get(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, results.SegMetas()...)
+	}
+	return segs, nil
+}
+
+// hpsRequest is the set of groups that are requested from one hidden path
+// server.
+type hpsRequest struct {
+	server net.Addr
+	ids    []hiddenpath.GroupId
+}
+
+// resolve maps the groups to the hidden path servers they are requested from.
+// Groups that share a server are requested in a single request.
+func (f *hiddenFetcher) resolve(ids []hiddenpath.GroupId) ([]hpsRequest, error) {
+	var reqs []hpsRequest
+	indices := make(map[string]int)
+	for _, id := range ids {
+		server := f.server(f.config.Groups[id])
+		if server == nil {
+			return nil, serrors.New("no hidden path server for group", "group", id)
+		}
+		i, ok := indices[server.String()]
+		if !ok {
+			i = len(reqs)
+			indices[server.String()] = i
+			reqs = append(reqs, hpsRequest{server: server})
+		}
+		reqs[i].ids = append(reqs[i].ids, id)
+	}
+	return reqs, nil
+}
+
+// server returns the address of the hidden path server the segments of the
+// group are requested from.
+func (f *hiddenFetcher) server(g *hiddenpath.Group) net.Addr {
+	if g != nil {
+		for _, registry := range g.Registries {
+			if a, ok := f.config.Registries[registry]; ok {
+				return a
+			}
+		}
+	}
+	return f.config.Server
+}
+
+// request requests the hidden segments ending at dst from the hidden path
+// servers of the groups, and verifies and stores them.
+func (f *hiddenFetcher) request(ctx context.Context, dst addr.IA,
+	ids []hiddenpath.GroupId) error {
+
+	reqs, err := f.resolve(ids)
+	if err != nil {
+		return err
+	}
+	var errs serrors.List
+	for _, r := range reqs {
+		if err := f.requestFrom(ctx, r.server, dst, r.ids); err != nil {
+			errs = append(errs, serrors.WrapStr("requesting hidden segments", err,
+				"server", r.server))
+		}
+	}
+	return errs.ToError()
+}
+
+// requestFrom requests the hidden segments ending at dst in the given groups
+// from a single hidden path server, and verifies and stores them.
+func (f *hiddenFetcher) requestFrom(ctx context.Context, hps net.Addr, dst addr.IA,
+	ids []hiddenpath.GroupId) error {
+
+	req := &path_mgmt.HPSegReq{RawDstIA: dst.IAInt()}
+	for _, id := range ids {
+		req.GroupIds = append(req.GroupIds, id.ToMsg())
+	}
+	reply, err := f.config.Requester.GetHPSegs(ctx, req, hps, messenger.NextId())
+	if err != nil {
+		return err
+	}
+	// Missing crypto material is fetched from the local control service.
+	server, err := f.topoProvider.Get().Anycast(addr.SvcPS)
+	if err != nil {
+		return err
+	}
+	var errs serrors.List
+	for _, rec := range reply.Recs {
+		id := hiddenpath.IdFromMsg(rec.GroupId)
+		if rec.Err != "" {
+			errs = append(errs, serrors.New("hidden path server error", "group", id,
+				"err", rec.Err))
+			continue
+		}
+		segs := seghandler.Segments{Segs: rec.Recs, HPGroupID: id}
+		r := f.replyHandler.Handle(ctx, segs, server, nil)
+		select {
+		case <-r.FullReplyProcessed():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := r.Err(); err != nil {
+			errs = append(errs, serrors.WrapStr("handling hidden segments", err, "group", id))
+		}
+	}
+	return errs.ToError()
+}
+
+func hpCfgIDs(ids []hiddenpath.GroupId) []*query.HPCfgID {
+	cfgIDs := make([]*query.HPCfgID, 0, len(ids))
+	for _, id := range ids {
+		cfgIDs = append(cfgIDs, &query.HPCfgID{
+			IA: addr.IA{A: id.OwnerAS},
+			ID: uint64(id.Suffix),
+		})
+	}
+	return cfgIDs
+}
@@ -0,0 +1,297 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler/mock_seghandler"
+	"github.com/scionproto/scion/go/lib/pathdb/mock_pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/mock_topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+	"github.com/scionproto/scion/go/lib/xtest/matchers"
+	"github.com/scionproto/scion/go/pkg/sciond/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	group1 = hiddenpath.GroupId{OwnerAS: xtest.MustParseAS("ff00:0:111"), Suffix: 1}
+	group2 = hiddenpath.GroupId{OwnerAS: xtest.MustParseAS("ff00:0:111"), Suffix: 2}
+)
+
+func TestHiddenFetcherGroups(t *testing.T) {
+	groups := map[hiddenpath.GroupId]*hiddenpath.Group{
+		group1: {Id: group1},
+		group2: {Id: group2},
+	}
+	tests := map[string]struct {
+		Groups         map[hiddenpath.GroupId]*hiddenpath.Group
+		HPCfgs         []*path_mgmt.HPGroupId
+		ExpectedIDs    []hiddenpath.GroupId
+		ExpectedPublic bool
+		Assertion      assert.ErrorAssertionFunc
+	}{
+		"no groups configured": {
+			Assertion: assert.Error,
+		},
+		"all groups": {
+			Groups:      groups,
+			ExpectedIDs: []hiddenpath.GroupId{group1, group2},
+			Assertion:   assert.NoError,
+		},
+		"all groups and public": {
+			Groups:         groups,
+			HPCfgs:         []*path_mgmt.HPGroupId{sciond.PublicHPGroupID.ToMsg()},
+			ExpectedIDs:    []hiddenpath.GroupId{group1, group2},
+			ExpectedPublic: true,
+			Assertion:      assert.NoError,
+		},
+		"single group": {
+			Groups:      groups,
+			HPCfgs:      []*path_mgmt.HPGroupId{group2.ToMsg()},
+			ExpectedIDs: []hiddenpath.GroupId{group2},
+			Assertion:   assert.NoError,
+		},
+		"unknown group": {
+			Groups:    map[hiddenpath.GroupId]*hiddenpath.Group{group1: {Id: group1}},
+			HPCfgs:    []*path_mgmt.HPGroupId{group2.ToMsg()},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &hiddenFetcher{config: HiddenConfig{Groups: test.Groups}}
+			ids, public, err := f.groups(test.HPCfgs)
+			test.Assertion(t, err)
+			assert.ElementsMatch(t, test.ExpectedIDs, ids)
+			assert.Equal(t, test.ExpectedPublic, public)
+		})
+	}
+}
+
+func TestHiddenFetcherFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	local := xtest.MustParseIA("1-ff00:0:111")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	upSeg := seg.NewMeta(g.Beacon([]common.IFIDType{graph.If_120_X_111_B}),
+		proto.PathSegType_up)
+	downSeg := seg.NewMeta(g.Beacon([]common.IFIDType{graph.If_130_A_112_X}),
+		proto.PathSegType_down)
+	hps := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30260}
+	cs := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30254}
+	upQuery := &query.Params{
+		EndsAt:   []addr.IA{local},
+		SegTypes: []proto.PathSegType{proto.PathSegType_up},
+		HpCfgIDs: hpCfgIDs([]hiddenpath.GroupId{group1}),
+	}
+	downQuery := &query.Params{
+		EndsAt:   []addr.IA{dst},
+		SegTypes: []proto.PathSegType{proto.PathSegType_down},
+		HpCfgIDs: hpCfgIDs([]hiddenpath.GroupId{group1}),
+	}
+	hpSegReq := func(ia addr.IA) *path_mgmt.HPSegReq {
+		return &path_mgmt.HPSegReq{
+			RawDstIA: ia.IAInt(),
+			GroupIds: []*path_mgmt.HPGroupId{group1.ToMsg()},
+		}
+	}
+
+	tests := map[string]struct {
+		Dst     addr.IA
+		Prepare func(requester *mock_fetcher.MockHPSegRequester, db *mock_pathdb.MockPathDB,
+			verifier *mock_seghandler.MockVerifier)
+		Expected  segfetcher.Segments
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"hidden segments fetched": {
+			Dst: dst,
+			Prepare: func(requester *mock_fetcher.MockHPSegRequester, db *mock_pathdb.MockPathDB,
+				verifier *mock_seghandler.MockVerifier) {
+
+				for ia, s := range map[addr.IA]*seg.Meta{local: upSeg, dst: downSeg} {
+					requester.EXPECT().GetHPSegs(gomock.Any(), hpSegReq(ia), hps, gomock.Any()).
+						Return(&path_mgmt.HPSegReply{Recs: []*path_mgmt.HPSegRecs{{
+							GroupId: group1.ToMsg(),
+							Recs:    []*seg.Meta{s},
+						}}}, nil)
+					verifier.EXPECT().Verify(gomock.Any(), seghandler.Segments{
+						Segs:      []*seg.Meta{s},
+						HPGroupID: group1,
+					}, cs)
+				}
+				db.EXPECT().Get(gomock.Any(), matchers.EqParams(upQuery)).
+					Return(query.Results{{Seg: upSeg.Segment, Type: upSeg.Type}}, nil)
+				db.EXPECT().Get(gomock.Any(), matchers.EqParams(downQuery)).
+					Return(query.Results{{Seg: downSeg.Segment, Type: downSeg.Type}}, nil)
+			},
+			Expected:  segfetcher.Segments{upSeg, downSeg},
+			Assertion: assert.NoError,
+		},
+		"hidden path server error": {
+			Dst: dst,
+			Prepare: func(requester *mock_fetcher.MockHPSegRequester, db *mock_pathdb.MockPathDB,
+				verifier *mock_seghandler.MockVerifier) {
+
+				requester.EXPECT().GetHPSegs(gomock.Any(), gomock.Any(), hps, gomock.Any()).
+					Return(nil, serrors.New("test err")).Times(2)
+				db.EXPECT().Get(gomock.Any(), matchers.EqParams(upQuery)).
+					Return(query.Results{{Seg: upSeg.Segment, Type: upSeg.Type}}, nil)
+				db.EXPECT().Get(gomock.Any(), matchers.EqParams(downQuery))
+			},
+			Expected:  segfetcher.Segments{upSeg},
+			Assertion: assert.NoError,
+		},
+		"wildcard destination": {
+			Dst: xtest.MustParseIA("1-0"),
+			Prepare: func(requester *mock_fetcher.MockHPSegRequester, db *mock_pathdb.MockPathDB,
+				verifier *mock_seghandler.MockVerifier) {
+
+				requester.EXPECT().GetHPSegs(gomock.Any(), hpSegReq(local), hps, gomock.Any()).
+					Return(&path_mgmt.HPSegReply{}, nil)
+				db.EXPECT().Get(gomock.Any(), matchers.EqParams(upQuery)).
+					Return(query.Results{{Seg: upSeg.Segment, Type: upSeg.Type}}, nil)
+			},
+			Expected:  segfetcher.Segments{upSeg},
+			Assertion: assert.NoError,
+		},
+		"path db error": {
+			Dst: dst,
+			Prepare: func(requester *mock_fetcher.MockHPSegRequester, db *mock_pathdb.MockPathDB,
+				verifier *mock_seghandler.MockVerifier) {
+
+				requester.EXPECT().GetHPSegs(gomock.Any(), gomock.Any(), hps, gomock.Any()).
+					Return(&path_mgmt.HPSegReply{}, nil).Times(2)
+				db.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, serrors.New("test err"))
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			topo := mock_topology.NewMockTopology(ctrl)
+			topo.EXPECT().IA().Return(local).AnyTimes()
+			topo.EXPECT().Anycast(addr.SvcPS).Return(cs, nil).AnyTimes()
+			requester := mock_fetcher.NewMockHPSegRequester(ctrl)
+			db := mock_pathdb.NewMockPathDB(ctrl)
+			verifier := mock_seghandler.NewMockVerifier(ctrl)
+			test.Prepare(requester, db, verifier)
+
+			f := &hiddenFetcher{
+				config: HiddenConfig{
+					Groups:    map[hiddenpath.GroupId]*hiddenpath.Group{group1: {Id: group1}},
+					Server:    hps,
+					Requester: requester,
+				},
+				topoProvider: topoProvider{topo},
+				pathDB:       db,
+				replyHandler: &seghandler.Handler{
+					Verifier: verifier,
+					Storage:  mock_seghandler.NewMockStorage(ctrl),
+				},
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			segs, err := f.Fetch(ctx, []hiddenpath.GroupId{group1}, test.Dst)
+			test.Assertion(t, err)
+			require.Len(t, segs, len(test.Expected))
+			for i := range test.Expected {
+				assert.Equal(t, test.Expected[i].Type, segs[i].Type)
+				assert.Equal(t, test.Expected[i].Segment.GetLoggingID(),
+					segs[i].Segment.GetLoggingID())
+			}
+		})
+	}
+}
+
+func TestHiddenFetcherFetchRegistries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	local := xtest.MustParseIA("1-ff00:0:111")
+	registry := xtest.MustParseIA("1-ff00:0:112")
+	hps := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30260}
+	remoteHPS := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30262}
+	cs := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30254}
+
+	topo := mock_topology.NewMockTopology(ctrl)
+	topo.EXPECT().IA().Return(local).AnyTimes()
+	topo.EXPECT().Anycast(addr.SvcPS).Return(cs, nil).AnyTimes()
+	requester := mock_fetcher.NewMockHPSegRequester(ctrl)
+	db := mock_pathdb.NewMockPathDB(ctrl)
+
+	// group1 is not served by a known registry and is requested from the
+	// default server, group2 is requested from its registry directly.
+	requester.EXPECT().GetHPSegs(gomock.Any(), &path_mgmt.HPSegReq{
+		RawDstIA: local.IAInt(),
+		GroupIds: []*path_mgmt.HPGroupId{group1.ToMsg()},
+	}, hps, gomock.Any()).Return(&path_mgmt.HPSegReply{}, nil)
+	requester.EXPECT().GetHPSegs(gomock.Any(), &path_mgmt.HPSegReq{
+		RawDstIA: local.IAInt(),
+		GroupIds: []*path_mgmt.HPGroupId{group2.ToMsg()},
+	}, remoteHPS, gomock.Any()).Return(&path_mgmt.HPSegReply{}, nil)
+	db.EXPECT().Get(gomock.Any(), gomock.Any())
+
+	f := &hiddenFetcher{
+		config: HiddenConfig{
+			Groups: map[hiddenpath.GroupId]*hiddenpath.Group{
+				group1: {Id: group1, Registries: []addr.IA{local}},
+				group2: {Id: group2, Registries: []addr.IA{local, registry}},
+			},
+			Registries: map[addr.IA]net.Addr{registry: remoteHPS},
+			Server:     hps,
+			Requester:  requester,
+		},
+		topoProvider: topoProvider{topo},
+		pathDB:       db,
+		replyHandler: &seghandler.Handler{
+			Verifier: mock_seghandler.NewMockVerifier(ctrl),
+			Storage:  mock_seghandler.NewMockStorage(ctrl),
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := f.Fetch(ctx, []hiddenpath.GroupId{group1, group2}, xtest.MustParseIA("1-0"))
+	assert.NoError(t, err)
+}
+
+type topoProvider struct {
+	topology.Topology
+}
+
+func (p topoProvider) Get() topology.Topology {
+	return p.Topology
+}
@@ -32,6 +32,7 @@ const (
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
 	subsystemRevocation = "revocation"
+	subsystemDRKeyLvl2  = "drkey_lvl2"
 )
 
 // Revocation sources
@@ -49,6 +50,7 @@ const (
 	ErrDB            = prom.ErrDB
 	ErrTimeout       = prom.ErrTimeout
 	ErrParse         = prom.ErrParse
+	ErrInvalidReq    = prom.ErrInvalidReq
 	ErrNotClassified = prom.ErrNotClassified
 )
 
@@ -64,6 +66,8 @@ var (
 	IFInfos = newIFInfo()
 	// SVCInfos contains metrics for SVC info requests.
 	SVCInfos = newSVCInfo()
+	// DRKeyLvl2s contains metrics for DRKey level 2 key requests.
+	DRKeyLvl2s = newDRKeyLvl2()
 )
 
 type resultLabel struct {
@@ -228,3 +232,13 @@ func newIFInfo() Request {
 			resultLabel{}, prom.DefaultLatencyBuckets),
 	}
 }
+
+func newDRKeyLvl2() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemDRKeyLvl2, "requests_total",
+			"The amount of DRKey level 2 requests received.", resultLabel{}),
+		latency: prom.NewHistogramVecWithLabels(Namespace, subsystemDRKeyLvl2,
+			"request_duration_seconds", "Time to handle DRKey level 2 requests.",
+			resultLabel{}, prom.DefaultLatencyBuckets),
+	}
+}
@@ -20,9 +20,13 @@ import (
 	"net"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/hostinfo"
 	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
 	"github.com/scionproto/scion/go/lib/infra/modules/segverifier"
@@ -30,6 +34,7 @@ import (
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/pkg/sciond/fetcher"
 	"github.com/scionproto/scion/go/pkg/sciond/internal/metrics"
 	"github.com/scionproto/scion/go/pkg/trust"
@@ -249,6 +254,98 @@ func (h *SVCInfoRequestHandler) Handle(ctx context.Context, conn net.Conn,
 	metricsDone(metrics.OkSuccess)
 }
 
+// DRKeyRPC requests level 2 keys from the control service.
+type DRKeyRPC interface {
+	RequestDRKeyLvl2(ctx context.Context, msg *drkey_mgmt.Lvl2Req, a net.Addr,
+		id uint64) (*drkey_mgmt.Lvl2Rep, error)
+}
+
+// DRKeyLvl2RequestHandler represents the shared global state for the handling of all
+// DRKeyLvl2 queries. The requests are forwarded to the control service of the local AS.
+// Clients only obtain the keys they are an endpoint of, see drkey.Lvl2Meta.AuthorizeHost.
+// Clients that connect over the loopback interface act for all addresses of the host.
+type DRKeyLvl2RequestHandler struct {
+	RPC          DRKeyRPC
+	TopoProvider topology.Provider
+	// LocalAddrs returns the addresses of the host. If nil, net.InterfaceAddrs is used.
+	LocalAddrs func() ([]net.Addr, error)
+}
+
+func (h *DRKeyLvl2RequestHandler) Handle(ctx context.Context, conn net.Conn,
+	src net.Addr, pld *sciond.Pld) {
+
+	defer conn.Close()
+	metricsDone := metrics.DRKeyLvl2s.Start()
+	logger := log.FromCtx(ctx)
+	req := pld.DRKeyLvl2Req
+	logger.Debug("[DRKeyLvl2RequestHandler] Received request", "req", req)
+	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+	defer workCancelF()
+
+	rep := &drkey_mgmt.Lvl2Rep{}
+	result := metrics.OkSuccess
+	if err := h.authorize(req, src); err != nil {
+		logger.Info("Unauthorized level 2 key request", "client", src, "err", err)
+		result = metrics.ErrInvalidReq
+	} else if cs, err := h.TopoProvider.Get().Anycast(addr.SvcCS); err != nil {
+		logger.Info("Unable to find control service", "err", err)
+		result = metrics.ErrInternal
+	} else if r, err := h.RPC.RequestDRKeyLvl2(workCtx, req, cs, messenger.NextId()); err != nil {
+		logger.Info("Unable to get level 2 key from control service", "err", err)
+		result = metrics.ErrNetwork
+	} else {
+		rep = r
+	}
+	reply := &sciond.Pld{
+		Id:           pld.Id,
+		Which:        proto.SCIONDMsg_Which_drkeyLvl2Rep,
+		DRKeyLvl2Rep: rep,
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		logger.Info("Unable to reply to client", "client", src, "err", err)
+		metricsDone(metrics.ErrNetwork)
+		return
+	}
+	logger.Debug("Sent reply", "found", len(rep.DRKey) != 0)
+	metricsDone(result)
+}
+
+// authorize checks that the client is an endpoint of the requested key.
+func (h *DRKeyLvl2RequestHandler) authorize(req *drkey_mgmt.Lvl2Req, src net.Addr) error {
+	client, ok := src.(*net.TCPAddr)
+	if !ok {
+		return serrors.New("unsupported client address", "type", common.TypeOf(src))
+	}
+	meta, err := req.ToMeta()
+	if err != nil {
+		return err
+	}
+	hosts := []net.IP{client.IP}
+	if client.IP.IsLoopback() {
+		localAddrs := h.LocalAddrs
+		if localAddrs == nil {
+			localAddrs = net.InterfaceAddrs
+		}
+		addrs, err := localAddrs()
+		if err != nil {
+			return serrors.WrapStr("listing host addresses", err)
+		}
+		for _, a := range addrs {
+			if ipNet, ok := a.(*net.IPNet); ok {
+				hosts = append(hosts, ipNet.IP)
+			}
+		}
+	}
+	local := h.TopoProvider.Get().IA()
+	for _, host := range hosts {
+		if err = meta.AuthorizeHost(local, addr.HostFromIP(host)); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 // RevNotificationHandler represents the shared global state for the handling of all
 // RevNotification announcements. The SCIOND API spawns a goroutine with method Handle
 // for each RevNotification it receives.
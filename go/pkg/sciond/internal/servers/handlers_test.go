@@ -0,0 +1,107 @@
+// Copyright 2020 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/drkey_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/mock_topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+type topoProvider struct {
+	topo topology.Topology
+}
+
+func (p topoProvider) Get() topology.Topology {
+	return p.topo
+}
+
+func TestDRKeyLvl2RequestHandlerAuthorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	local := xtest.MustParseIA("1-ff00:0:110")
+	topo := mock_topology.NewMockTopology(ctrl)
+	topo.EXPECT().IA().Return(local).AnyTimes()
+
+	hostIP := net.IP{10, 0, 0, 1}
+	asToHost := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: "test",
+		SrcIA:    xtest.MustParseIA("1-ff00:0:111"),
+		DstIA:    local,
+		DstHost:  addr.HostFromIP(hostIP),
+	}
+	asToAS := asToHost
+	asToAS.KeyType = drkey.AS2AS
+	asToAS.DstHost = nil
+
+	testCases := map[string]struct {
+		Meta      drkey.Lvl2Meta
+		Client    net.Addr
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"client is the host": {
+			Meta:      asToHost,
+			Client:    &net.TCPAddr{IP: hostIP, Port: 4000},
+			Assertion: assert.NoError,
+		},
+		"client is another host": {
+			Meta:      asToHost,
+			Client:    &net.TCPAddr{IP: net.IP{10, 0, 0, 2}, Port: 4000},
+			Assertion: assert.Error,
+		},
+		"loopback client on the host": {
+			Meta:      asToHost,
+			Client:    &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4000},
+			Assertion: assert.NoError,
+		},
+		"unknown client address": {
+			Meta:      asToHost,
+			Client:    &net.UnixAddr{Name: "/run/shm/sciond.sock", Net: "unix"},
+			Assertion: assert.Error,
+		},
+		"AS to AS key": {
+			Meta:      asToAS,
+			Client:    &net.TCPAddr{IP: hostIP, Port: 4000},
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			h := &DRKeyLvl2RequestHandler{
+				TopoProvider: topoProvider{topo: topo},
+				LocalAddrs: func() ([]net.Addr, error) {
+					return []net.Addr{
+						&net.IPNet{IP: net.IPv4(127, 0, 0, 1), Mask: net.CIDRMask(8, 32)},
+						&net.IPNet{IP: hostIP, Mask: net.CIDRMask(24, 32)},
+					}, nil
+				},
+			}
+			req := drkey_mgmt.NewLvl2ReqFromMeta(tc.Meta, time.Now())
+			tc.Assertion(t, h.authorize(req, tc.Client))
+		})
+	}
+}
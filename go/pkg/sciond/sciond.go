@@ -19,13 +19,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"path/filepath"
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/pelletier/go-toml"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/hiddenpath"
 	"github.com/scionproto/scion/go/lib/infra/messenger/tcp"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
 	"github.com/scionproto/scion/go/lib/log"
@@ -85,12 +88,51 @@ func TrustEngine(cfgDir string, db trust.DB) (trust.Engine, error) {
 	}, nil
 }
 
+// HiddenConfig loads the hidden path groups SCIOND is a member of. The hidden
+// path segments are requested from the configured hidden path servers using
+// rpc.
+func HiddenConfig(cfg config.SDConfig, rpc fetcher.HPSegRequester) (fetcher.HiddenConfig, error) {
+	if len(cfg.HiddenPathGroups) == 0 {
+		return fetcher.HiddenConfig{}, nil
+	}
+	groups, err := hiddenpath.LoadGroups(cfg.HiddenPathGroups)
+	if err != nil {
+		return fetcher.HiddenConfig{}, serrors.WrapStr("loading hidden path groups", err)
+	}
+	server, err := net.ResolveTCPAddr("tcp", cfg.HiddenPathServer)
+	if err != nil {
+		return fetcher.HiddenConfig{}, serrors.WrapStr("resolving hidden path server", err,
+			"address", cfg.HiddenPathServer)
+	}
+	registries := make(map[addr.IA]net.Addr, len(cfg.HiddenPathRegistries))
+	for raw, a := range cfg.HiddenPathRegistries {
+		ia, err := addr.IAFromString(raw)
+		if err != nil {
+			return fetcher.HiddenConfig{}, serrors.WrapStr("parsing hidden path registry", err,
+				"isd_as", raw)
+		}
+		if registries[ia], err = net.ResolveTCPAddr("tcp", a); err != nil {
+			return fetcher.HiddenConfig{}, serrors.WrapStr("resolving hidden path registry",
+				err, "isd_as", ia, "address", a)
+		}
+	}
+	log.Info("Loaded hidden path groups", "count", len(groups))
+	return fetcher.HiddenConfig{
+		Groups:     groups,
+		Registries: registries,
+		Server:     server,
+		Requester:  rpc,
+	}, nil
+}
+
 // ServerCfg is the configuration for the API server.
 type ServerCfg struct {
 	Fetcher  fetcher.Fetcher
 	PathDB   pathdb.PathDB
 	RevCache revcache.RevCache
 	Engine   trust.Engine
+	// DRKeyRPC forwards the DRKey level 2 requests to the control service.
+	DRKeyRPC servers.DRKeyRPC
 }
 
 // Server constructs a API server. The caller is responsible for starting and
@@ -109,6 +151,10 @@ func Server(listen string, cfg ServerCfg) *servers.Server {
 			RevCache: cfg.RevCache,
 			Verifier: compat.Verifier{Verifier: trust.Verifier{Engine: cfg.Engine}},
 		},
+		proto.SCIONDMsg_Which_drkeyLvl2Req: &servers.DRKeyLvl2RequestHandler{
+			RPC:          cfg.DRKeyRPC,
+			TopoProvider: itopo.Provider(),
+		},
 	}
 	return servers.NewServer("tcp", listen, handlers)
 }
@@ -16,6 +16,8 @@ package showpaths
 
 import (
 	"net"
+
+	"github.com/scionproto/scion/go/lib/pathpol"
 )
 
 // DefaultMaxPaths is the maximum number of paths that are displayed by default.
@@ -35,4 +37,7 @@ type Config struct {
 	Refresh bool
 	// NoProbe configures whether the path status is probed or not.
 	NoProbe bool
+	// Policy filters the displayed paths. If this option is not provided, all
+	// paths are displayed.
+	Policy *pathpol.Policy
 }
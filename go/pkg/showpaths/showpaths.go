@@ -30,6 +30,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/pkg/app"
 )
 
 // Result contains all the discovered paths.
@@ -49,6 +50,36 @@ type Path struct {
 	Status      string    `json:"status,omitempty"`
 	StatusInfo  string    `json:"status_info,omitempty"`
 	Local       net.IP    `json:"local_ip,omitempty"`
+	Metadata    *Metadata `json:"metadata,omitempty"`
+}
+
+// Metadata holds the static information announced for the path. Fields that
+// are unknown are omitted.
+type Metadata struct {
+	// Latency is the sum of the announced latencies on the path.
+	Latency time.Duration `json:"-"`
+	// LatencyMS is the latency in milliseconds, for the json output.
+	LatencyMS int64 `json:"latency_ms,omitempty"`
+	// Bandwidth is the bandwidth bottleneck of the path in Kbit/s.
+	Bandwidth    uint32          `json:"bandwidth_kbps,omitempty"`
+	InternalHops uint8           `json:"internal_hops,omitempty"`
+	LinkTypes    []snet.LinkType `json:"link_types,omitempty"`
+	Geo          []GeoLocation   `json:"geo,omitempty"`
+	Notes        []Note          `json:"notes,omitempty"`
+}
+
+// GeoLocation is the announced location of a router of an AS on the path.
+type GeoLocation struct {
+	IA        addr.IA `json:"isd_as"`
+	Latitude  float32 `json:"latitude"`
+	Longitude float32 `json:"longitude"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// Note is the note announced by an AS on the path.
+type Note struct {
+	IA   addr.IA `json:"isd_as"`
+	Note string  `json:"note"`
 }
 
 // Hop represents an hop on the path.
@@ -57,8 +88,10 @@ type Hop struct {
 	IA   addr.IA         `json:"isd_as"`
 }
 
-// Human writes human readable output to the writer.
-func (r Result) Human(w io.Writer, showExpiration bool) {
+// Human writes human readable output to the writer. The announced latency
+// and bandwidth of a path are displayed if they are known. If showExtended is
+// set, the remaining metadata is displayed on separate lines.
+func (r Result) Human(w io.Writer, showExpiration, showExtended bool) {
 	fmt.Fprintln(w, "Available paths to", r.Destination)
 	for i, path := range r.Paths {
 		fmt.Fprintf(w, "[%2d] %s", i, fmt.Sprintf("%s", path.FullPath))
@@ -66,10 +99,44 @@ func (r Result) Human(w io.Writer, showExpiration bool) {
 			ttl := time.Until(path.Expiry).Truncate(time.Second)
 			fmt.Fprintf(w, " Expires: %s (%s)", path.Expiry, ttl)
 		}
+		if meta := path.Metadata; meta != nil {
+			if meta.Latency != 0 {
+				fmt.Fprintf(w, " Latency: %s", meta.Latency)
+			}
+			if meta.Bandwidth != 0 {
+				fmt.Fprintf(w, " Bandwidth: %dKbit/s", meta.Bandwidth)
+			}
+		}
 		if path.Status != "" {
 			fmt.Fprintf(w, " Status: %s LocalIP: %s", path.Status, path.Local)
 		}
 		fmt.Fprintln(w)
+		if showExtended && path.Metadata != nil {
+			path.Metadata.human(w)
+		}
+	}
+}
+
+func (m *Metadata) human(w io.Writer) {
+	if m.InternalHops != 0 {
+		fmt.Fprintf(w, "     Internal hops: %d\n", m.InternalHops)
+	}
+	if len(m.LinkTypes) != 0 {
+		types := make([]string, 0, len(m.LinkTypes))
+		for _, t := range m.LinkTypes {
+			types = append(types, t.String())
+		}
+		fmt.Fprintf(w, "     Link types: %s\n", strings.Join(types, ", "))
+	}
+	for _, geo := range m.Geo {
+		fmt.Fprintf(w, "     Location: %s %f,%f", geo.IA, geo.Latitude, geo.Longitude)
+		if geo.Address != "" {
+			fmt.Fprintf(w, " (%s)", geo.Address)
+		}
+		fmt.Fprintln(w)
+	}
+	for _, note := range m.Notes {
+		fmt.Fprintf(w, "     Note: %s %s\n", note.IA, note.Note)
 	}
 }
 
@@ -100,6 +167,7 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 	if err != nil {
 		return nil, serrors.WrapStr("failed to retrieve paths from SCIOND", err)
 	}
+	paths = app.FilterPaths(paths, cfg.Policy)
 
 	var statuses map[string]pathprobe.Status
 	var localIP net.IP
@@ -142,6 +210,7 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 			MTU:         path.MTU(),
 			Local:       localIP,
 			Hops:        []Hop{},
+			Metadata:    newMetadata(path),
 		}
 		for _, hop := range path.Interfaces() {
 			rpath.Hops = append(rpath.Hops, Hop{IA: hop.IA(), IfID: hop.ID()})
@@ -155,6 +224,43 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 	return res, nil
 }
 
+// newMetadata creates the displayed metadata of the path. The locations and
+// notes are ordered by the position of the AS on the path. If no metadata is
+// available, nil is returned.
+func newMetadata(path snet.Path) *Metadata {
+	meta := path.Metadata()
+	if meta == nil {
+		return nil
+	}
+	m := &Metadata{
+		Latency:      meta.Latency,
+		LatencyMS:    meta.Latency.Milliseconds(),
+		Bandwidth:    meta.Bandwidth,
+		InternalHops: meta.InternalHops,
+		LinkTypes:    meta.LinkTypes,
+	}
+	var ias []addr.IA
+	for _, intf := range path.Interfaces() {
+		if len(ias) == 0 || !ias[len(ias)-1].Equal(intf.IA()) {
+			ias = append(ias, intf.IA())
+		}
+	}
+	for _, ia := range ias {
+		for _, loc := range meta.Geo[ia] {
+			m.Geo = append(m.Geo, GeoLocation{
+				IA:        ia,
+				Latitude:  loc.Latitude,
+				Longitude: loc.Longitude,
+				Address:   loc.Address,
+			})
+		}
+		if note, ok := meta.Notes[ia]; ok {
+			m.Notes = append(m.Notes, Note{IA: ia, Note: note})
+		}
+	}
+	return m
+}
+
 // TODO(matzf): this is a simple, hopefully temporary, workaround to not having
 // wildcard addresses in snet.
 // Here we just use a seemingly sensible default IP, but in the general case
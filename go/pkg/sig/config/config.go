@@ -99,6 +99,10 @@ type SigConf struct {
 	// dispatcher. If the field is empty bypass is not done and SCION dispatcher is used
 	// instead.
 	DispatcherBypass string `toml:"disaptcher_bypass,omitempty"`
+	// PathPolicy is the path policy file that is used to filter and rank the
+	// paths to remote SIGs. Files with a .yml or .yaml extension are parsed
+	// as YAML, all other files as JSON. If it is empty, all paths are used.
+	PathPolicy string `toml:"path_policy,omitempty"`
 }
 
 // InitDefaults sets the default values to unset values.
@@ -40,4 +40,9 @@ tun = "sig"
 
 # Id of the routing table. (default 11)
 tun_routing_table_id = 11
+
+# Path policy file that is used to filter and rank the paths to remote SIGs.
+# Files with a .yml or .yaml extension are parsed as YAML, all other files as
+# JSON. If it is empty, all paths are used. (default "")
+path_policy = ""
 `
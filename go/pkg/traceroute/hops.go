@@ -0,0 +1,160 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceroute
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/spkt"
+)
+
+// hop is a single interface on the path that is probed.
+type hop struct {
+	ia   addr.IA
+	ifID common.IFIDType
+	// path is the path the probes are sent on. With header v2, the router
+	// alert flag for the interface is set in the corresponding hop field.
+	path *spath.Path
+	// hopOff and in identify the interface with header v1. hopOff is the
+	// offset of the hop field in the packet in lines, in indicates whether
+	// the interface is the ingress interface.
+	hopOff uint8
+	in     bool
+}
+
+// hopsV2 returns the hops of a header v2 path. For every AS on the path, the
+// ingress and the egress interface in direction of travel are probed. At a
+// crossover AS, the ingress interface is in the last hop field of the previous
+// segment, and the egress interface in the first hop field of the next
+// segment. At peering ASes, both interfaces are in the same hop field.
+func hopsV2(p snet.Path) ([]hop, error) {
+	raw := p.Path().Raw
+	var decoded scion.Decoded
+	if err := decoded.DecodeFromBytes(raw); err != nil {
+		return nil, serrors.WrapStr("decoding path", err)
+	}
+	intfs := p.Interfaces()
+	var hops []hop
+	add := func(hf int, consDir, ingress bool) error {
+		if len(hops) >= len(intfs) {
+			return serrors.New("path has more hops than interfaces", "interfaces", len(intfs))
+		}
+		path, err := alertPath(raw, hf, consDir, ingress)
+		if err != nil {
+			return err
+		}
+		hops = append(hops, hop{
+			ia:   intfs[len(hops)].IA(),
+			ifID: intfs[len(hops)].ID(),
+			path: path,
+		})
+		return nil
+	}
+	hf := 0
+	for i := 0; i < decoded.NumINF; i++ {
+		info := decoded.InfoFields[i]
+		segLen := int(decoded.PathMeta.SegLen[i])
+		for k := 0; k < segLen; k++ {
+			if k > 0 || (i > 0 && decoded.InfoFields[i-1].Peer) {
+				if err := add(hf, info.ConsDir, true); err != nil {
+					return nil, err
+				}
+			}
+			if k < segLen-1 || (i < decoded.NumINF-1 && info.Peer) {
+				if err := add(hf, info.ConsDir, false); err != nil {
+					return nil, err
+				}
+			}
+			hf++
+		}
+	}
+	if len(hops) != len(intfs) {
+		return nil, serrors.New("path has less hops than interfaces", "hops", len(hops),
+			"interfaces", len(intfs))
+	}
+	return hops, nil
+}
+
+// alertPath returns a copy of the raw path with the router alert flag set for
+// the ingress or egress interface (in direction of travel) of the hop field.
+func alertPath(raw []byte, hf int, consDir, ingress bool) (*spath.Path, error) {
+	var decoded scion.Decoded
+	if err := decoded.DecodeFromBytes(raw); err != nil {
+		return nil, serrors.WrapStr("decoding path", err)
+	}
+	// The router alert flags refer to the construction direction.
+	if ingress == consDir {
+		decoded.HopFields[hf].IngressRouterAlert = true
+	} else {
+		decoded.HopFields[hf].EgressRouterAlert = true
+	}
+	b := make([]byte, decoded.Len())
+	if err := decoded.SerializeTo(b); err != nil {
+		return nil, serrors.WrapStr("serializing path", err)
+	}
+	return spath.NewV2(b, false), nil
+}
+
+// hopsLegacy returns the hops of a header v1 path. The interfaces are
+// identified by the hop field offset in the traceroute info. The probes are
+// all sent on the unmodified path.
+func hopsLegacy(p snet.Path, local, remote addr.HostAddr) ([]hop, error) {
+	path := p.Path().Copy()
+	intfs := p.Interfaces()
+	hopPktOff := func() uint8 {
+		off := spkt.CmnHdrLen + spkt.AddrHdrLen(remote, local) + path.HopOff
+		return uint8(off / common.LineLen)
+	}
+	hops := make([]hop, 0, len(intfs))
+	in := false
+	for i, intf := range intfs {
+		if i > 0 {
+			if err := nextHopField(path, in); err != nil {
+				return nil, err
+			}
+			in = !in
+		}
+		hops = append(hops, hop{
+			ia:     intf.IA(),
+			ifID:   intf.ID(),
+			path:   p.Path(),
+			hopOff: hopPktOff(),
+			in:     in,
+		})
+	}
+	return hops, nil
+}
+
+// nextHopField advances the path to the hop field of the next interface. After
+// the egress interface, the next hop field contains the ingress interface of
+// the next AS. After the ingress interface, the egress interface is in the
+// same hop field, unless it is a crossover hop field.
+func nextHopField(path *spath.Path, in bool) error {
+	if !in {
+		return path.IncOffsets()
+	}
+	hopF, err := path.GetHopField(path.HopOff)
+	if err != nil {
+		return err
+	}
+	if hopF.Xover {
+		return path.IncOffsets()
+	}
+	return nil
+}
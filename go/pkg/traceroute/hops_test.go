@@ -0,0 +1,153 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceroute
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestHopsV2(t *testing.T) {
+	ia := xtest.MustParseIA
+	intfs := []snet.PathInterface{
+		sciond.PathInterface{RawIsdas: ia("1-ff00:0:110").IAInt(), IfID: 1},
+		sciond.PathInterface{RawIsdas: ia("1-ff00:0:111").IAInt(), IfID: 2},
+		sciond.PathInterface{RawIsdas: ia("1-ff00:0:111").IAInt(), IfID: 3},
+		sciond.PathInterface{RawIsdas: ia("1-ff00:0:112").IAInt(), IfID: 4},
+		sciond.PathInterface{RawIsdas: ia("1-ff00:0:112").IAInt(), IfID: 5},
+		sciond.PathInterface{RawIsdas: ia("1-ff00:0:113").IAInt(), IfID: 6},
+	}
+	// alert describes the router alert flag that is expected to be set.
+	type alert struct {
+		HopField int
+		Ingress  bool
+	}
+	tests := map[string]struct {
+		InfoFields []*path.InfoField
+		SegLen     [3]uint8
+		Expected   []alert
+	}{
+		"crossover": {
+			// The up segment is traversed against construction direction.
+			InfoFields: []*path.InfoField{{ConsDir: false}, {ConsDir: true}},
+			SegLen:     [3]uint8{3, 2, 0},
+			Expected: []alert{
+				{HopField: 0, Ingress: true},
+				{HopField: 1, Ingress: false},
+				{HopField: 1, Ingress: true},
+				{HopField: 2, Ingress: false},
+				{HopField: 3, Ingress: false},
+				{HopField: 4, Ingress: true},
+			},
+		},
+		"peering": {
+			InfoFields: []*path.InfoField{
+				{ConsDir: false, Peer: true},
+				{ConsDir: true, Peer: true},
+			},
+			SegLen: [3]uint8{2, 2, 0},
+			Expected: []alert{
+				{HopField: 0, Ingress: true},
+				{HopField: 1, Ingress: false},
+				{HopField: 1, Ingress: true},
+				{HopField: 2, Ingress: true},
+				{HopField: 2, Ingress: false},
+				{HopField: 3, Ingress: true},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			numHops := int(test.SegLen[0] + test.SegLen[1] + test.SegLen[2])
+			decoded := scion.Decoded{
+				Base: scion.Base{
+					PathMeta: scion.MetaHdr{SegLen: test.SegLen},
+					NumINF:   len(test.InfoFields),
+					NumHops:  numHops,
+				},
+				InfoFields: test.InfoFields,
+			}
+			for i := 0; i < numHops; i++ {
+				decoded.HopFields = append(decoded.HopFields,
+					&path.HopField{Mac: make([]byte, path.MacLen)})
+			}
+			raw := make([]byte, decoded.Len())
+			require.NoError(t, decoded.SerializeTo(raw))
+			p := mock_snet.NewMockPath(ctrl)
+			p.EXPECT().Path().Return(spath.NewV2(raw, false)).AnyTimes()
+			p.EXPECT().Interfaces().Return(intfs).AnyTimes()
+
+			hops, err := hopsV2(p)
+			require.NoError(t, err)
+			require.Len(t, hops, len(test.Expected))
+			for i, h := range hops {
+				assert.Equal(t, intfs[i].IA(), h.ia)
+				assert.Equal(t, intfs[i].ID(), h.ifID)
+				var actual scion.Decoded
+				require.NoError(t, actual.DecodeFromBytes(h.path.Raw))
+				for j, hf := range actual.HopFields {
+					expected := test.Expected[i]
+					assert.Equal(t, j == expected.HopField && expected.Ingress,
+						hf.IngressRouterAlert, "hop %d hop field %d", i, j)
+					assert.Equal(t, j == expected.HopField && !expected.Ingress,
+						hf.EgressRouterAlert, "hop %d hop field %d", i, j)
+				}
+			}
+		})
+	}
+}
+
+func TestHopsV2Mismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	decoded := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{2, 0, 0}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []*path.InfoField{{ConsDir: true}},
+		HopFields: []*path.HopField{
+			{Mac: make([]byte, path.MacLen)},
+			{Mac: make([]byte, path.MacLen)},
+		},
+	}
+	raw := make([]byte, decoded.Len())
+	require.NoError(t, decoded.SerializeTo(raw))
+	p := mock_snet.NewMockPath(ctrl)
+	p.EXPECT().Path().Return(spath.NewV2(raw, false)).AnyTimes()
+	p.EXPECT().Interfaces().Return([]snet.PathInterface{
+		sciond.PathInterface{RawIsdas: xtest.MustParseIA("1-ff00:0:110").IAInt(), IfID: 1},
+		sciond.PathInterface{RawIsdas: xtest.MustParseIA("1-ff00:0:111").IAInt(), IfID: 2},
+		sciond.PathInterface{RawIsdas: xtest.MustParseIA("1-ff00:0:111").IAInt(), IfID: 3},
+	}).AnyTimes()
+	_, err := hopsV2(p)
+	assert.Error(t, err)
+}
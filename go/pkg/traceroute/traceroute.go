@@ -0,0 +1,363 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traceroute implements tracerouting based on SCMP traceroute messages.
+package traceroute
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+)
+
+// Stats contains the statistics of a traceroute run.
+type Stats struct {
+	Sent     int
+	Received int
+}
+
+// Update contains the information for a single hop.
+type Update struct {
+	// Index indicates the hop index in the path.
+	Index int
+	// IA is the ISD-AS of the router that is probed.
+	IA addr.IA
+	// Interface is the interface ID of the probed router.
+	Interface common.IFIDType
+	// Remote is the address of the router that replied. It is nil if none of
+	// the probes were answered.
+	Remote addr.HostAddr
+	// RTTs are the RTTs of the probes for this hop. A probe that timed out
+	// has an RTT that is larger than the configured timeout.
+	RTTs []time.Duration
+}
+
+// Result contains the per-hop results and the statistics of a traceroute run.
+type Result struct {
+	Hops  []Update
+	Stats Stats
+}
+
+// Config configures the traceroute run.
+type Config struct {
+	Dispatcher reliable.Dispatcher
+	Local      *snet.UDPAddr
+	Remote     *snet.UDPAddr
+	// PathEntry is the path that is traced. The interfaces on the path are
+	// probed in order.
+	PathEntry snet.Path
+
+	// ProbesPerHop is the number of probes that are sent to every hop.
+	ProbesPerHop int
+	// Timeout is the time until a probe is considered to have timed out.
+	Timeout time.Duration
+
+	// ErrHandler is invoked for every error that does not cause tracerouting
+	// to abort. Execution time must be small, as it is run synchronous.
+	ErrHandler func(err error)
+	// UpdateHandler is invoked for every probed hop. Execution time must be
+	// small, as it is run synchronous.
+	UpdateHandler func(Update)
+
+	HeaderV2 bool
+}
+
+// Run traceroute with the configuration. This blocks until all hops on the
+// path are probed, or the context is canceled. In the latter case, the hops
+// that were fully probed are returned.
+func Run(ctx context.Context, cfg Config) (Result, error) {
+	if cfg.PathEntry == nil || cfg.PathEntry.Path() == nil {
+		return Result{}, serrors.New("no path to trace")
+	}
+	if cfg.ProbesPerHop < 1 {
+		return Result{}, serrors.New("at least one probe per hop required",
+			"probes", cfg.ProbesPerHop)
+	}
+	local, remote := addr.HostFromIP(cfg.Local.Host.IP), addr.HostFromIP(cfg.Remote.Host.IP)
+	var hops []hop
+	var err error
+	if cfg.HeaderV2 {
+		hops, err = hopsV2(cfg.PathEntry)
+	} else {
+		hops, err = hopsLegacy(cfg.PathEntry, local, remote)
+	}
+	if err != nil {
+		return Result{}, serrors.WrapStr("computing hops", err)
+	}
+
+	id := rand.Uint64()
+	if cfg.HeaderV2 {
+		// The identifier of SCMP traceroute messages in the new format only
+		// has 16 bits.
+		id = uint64(uint16(id))
+	}
+	replies := make(chan reply, 10)
+
+	svc := snet.DefaultPacketDispatcherService{
+		Dispatcher: cfg.Dispatcher,
+		SCMPHandler: scmpHandler{
+			id:      id,
+			replies: replies,
+		},
+		Version2: cfg.HeaderV2,
+	}
+	conn, port, err := svc.Register(ctx, cfg.Local.IA, cfg.Local.Host, addr.SvcNone)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	l := cfg.Local.Copy()
+	l.Host.Port = int(port)
+
+	t := tracerouter{
+		probesPerHop:  cfg.ProbesPerHop,
+		timeout:       cfg.Timeout,
+		id:            id,
+		conn:          conn.(*snet.SCIONPacketConn),
+		local:         l,
+		remote:        cfg.Remote,
+		nextHop:       cfg.PathEntry.UnderlayNextHop(),
+		headerV2:      cfg.HeaderV2,
+		replies:       replies,
+		errHandler:    cfg.ErrHandler,
+		updateHandler: cfg.UpdateHandler,
+	}
+	return t.Traceroute(ctx, hops)
+}
+
+type tracerouter struct {
+	probesPerHop int
+	timeout      time.Duration
+
+	id       uint64
+	conn     *snet.SCIONPacketConn
+	local    *snet.UDPAddr
+	remote   *snet.UDPAddr
+	nextHop  *net.UDPAddr
+	headerV2 bool
+	replies  <-chan reply
+
+	// Handlers
+	errHandler    func(error)
+	updateHandler func(Update)
+
+	// Mutable state
+	stats Stats
+}
+
+func (t *tracerouter) Traceroute(ctx context.Context, hops []hop) (Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		defer log.HandlePanic()
+		t.drain(ctx)
+	}()
+
+	var res Result
+	for i, h := range hops {
+		u, err := t.probeHop(ctx, i, h)
+		if err != nil {
+			res.Stats = t.stats
+			if ctx.Err() != nil {
+				return res, nil
+			}
+			return res, err
+		}
+		res.Hops = append(res.Hops, u)
+		if t.updateHandler != nil {
+			t.updateHandler(u)
+		}
+	}
+	res.Stats = t.stats
+	return res, nil
+}
+
+func (t *tracerouter) probeHop(ctx context.Context, index int, h hop) (Update, error) {
+	u := Update{
+		Index:     index,
+		IA:        h.ia,
+		Interface: h.ifID,
+	}
+	for i := 0; i < t.probesPerHop; i++ {
+		sent := time.Now()
+		if err := t.send(h); err != nil {
+			return u, serrors.WrapStr("sending", err)
+		}
+		r, err := t.wait(ctx, h, sent)
+		if err != nil {
+			return u, err
+		}
+		if r == nil {
+			u.RTTs = append(u.RTTs, t.timeout+time.Nanosecond)
+			continue
+		}
+		u.Remote = r.Source.Host
+		u.RTTs = append(u.RTTs, r.Received.Sub(sent).Round(time.Microsecond))
+	}
+	return u, nil
+}
+
+func (t *tracerouter) send(h hop) error {
+	info := scmp.InfoTraceRoute{Id: t.id, HopOff: h.hopOff, In: h.in}
+	pld := make([]byte, scmp.MetaLen+info.Len())
+	meta := scmp.Meta{InfoLen: uint8(info.Len() / common.LineLen)}
+	if err := meta.Write(pld); err != nil {
+		return err
+	}
+	if _, err := info.Write(pld[scmp.MetaLen:]); err != nil {
+		return err
+	}
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA:   t.remote.IA,
+				Host: addr.HostFromIP(t.remote.Host.IP),
+			},
+			Source: snet.SCIONAddress{
+				IA:   t.local.IA,
+				Host: addr.HostFromIP(t.local.Host.IP),
+			},
+			Path: h.path,
+			L4Header: scmp.NewHdr(
+				scmp.ClassType{
+					Class: scmp.C_General,
+					Type:  scmp.T_G_TraceRouteRequest,
+				},
+				len(pld),
+			),
+			Payload: common.RawBytes(pld),
+		},
+	}
+	if !t.headerV2 {
+		pkt.Extensions = []common.Extension{&layers.ExtnSCMP{HopByHop: true}}
+	}
+	if err := t.conn.WriteTo(pkt, t.nextHop); err != nil {
+		return err
+	}
+	t.stats.Sent++
+	return nil
+}
+
+// wait waits for the reply of the hop. If the probe times out, nil is
+// returned.
+func (t *tracerouter) wait(ctx context.Context, h hop, sent time.Time) (*reply, error) {
+	timeout := time.NewTimer(time.Until(sent.Add(t.timeout)))
+	defer timeout.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, nil
+		case r := <-t.replies:
+			if r.Error != nil {
+				t.handleErr(r.Error)
+				continue
+			}
+			// Late replies of previous hops are ignored.
+			if !r.Info.IA.Equal(h.ia) || r.Info.IfID != h.ifID {
+				t.handleErr(serrors.New("unexpected traceroute reply",
+					"expected_ia", h.ia, "expected_ifid", h.ifID,
+					"actual_ia", r.Info.IA, "actual_ifid", r.Info.IfID))
+				continue
+			}
+			t.stats.Received++
+			return &r, nil
+		}
+	}
+}
+
+func (t *tracerouter) handleErr(err error) {
+	if t.errHandler != nil {
+		t.errHandler(err)
+	}
+}
+
+func (t *tracerouter) drain(ctx context.Context) {
+	var last time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			var pkt snet.Packet
+			var ov net.UDPAddr
+			if err := t.conn.ReadFrom(&pkt, &ov); err != nil && ctx.Err() == nil {
+				// Rate limit the error reports.
+				if now := time.Now(); now.Sub(last) > 500*time.Millisecond {
+					t.handleErr(serrors.WrapStr("reading packet", err))
+					last = now
+				}
+			}
+		}
+	}
+}
+
+type reply struct {
+	Received time.Time
+	Source   snet.SCIONAddress
+	Info     *scmp.InfoTraceRoute
+	Error    error
+}
+
+type scmpHandler struct {
+	id      uint64
+	replies chan<- reply
+}
+
+func (h scmpHandler) Handle(pkt *snet.Packet) error {
+	info, err := h.handle(pkt)
+	h.replies <- reply{
+		Error:    err,
+		Source:   pkt.Source,
+		Info:     info,
+		Received: time.Now(),
+	}
+	return nil
+}
+
+func (h scmpHandler) handle(pkt *snet.Packet) (*scmp.InfoTraceRoute, error) {
+	scmpHdr, ok := pkt.L4Header.(*scmp.Hdr)
+	if !ok {
+		return nil, serrors.New("not an SCMP header", "type", common.TypeOf(pkt.L4Header))
+	}
+	if scmpHdr.Class != scmp.C_General || scmpHdr.Type != scmp.T_G_TraceRouteReply {
+		return nil, serrors.New("not a traceroute reply", "class", scmpHdr.Class,
+			"type", scmpHdr.Type)
+	}
+	scmpPld, ok := pkt.PacketInfo.Payload.(*scmp.Payload)
+	if !ok {
+		return nil, serrors.New("not an SCMP payload", "type", common.TypeOf(pkt.Payload))
+	}
+	info, ok := scmpPld.Info.(*scmp.InfoTraceRoute)
+	if !ok {
+		return nil, serrors.New("not a traceroute", "type", common.TypeOf(scmpPld.Info))
+	}
+	if info.Id != h.id {
+		return nil, serrors.New("wrong SCMP ID", "expected", h.id, "actual", info.Id)
+	}
+	return info, nil
+}
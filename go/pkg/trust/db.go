@@ -22,6 +22,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 )
 
@@ -51,4 +52,18 @@ type DB interface {
 	// InsertTRC inserts the given TRC. Returns true if the TRC was not yet in
 	// the DB.
 	InsertTRC(ctx context.Context, trc cppki.SignedTRC) (bool, error)
+
+	// DenyList looks up the deny list issued by the voters of the ISD with the
+	// given serial number. If the serial number is scrypto.LatestVer, the deny
+	// list with the highest serial number is returned. If no deny list is
+	// found, the returned deny list is empty.
+	DenyList(ctx context.Context, isd addr.ISD, serial scrypto.Version) (cppki.SignedDenyList,
+		error)
+	// CADenyLists looks up the deny lists issued by the CAs of the ISD. For
+	// every CA, only the deny list with the highest serial number is returned.
+	CADenyLists(ctx context.Context, isd addr.ISD) ([]cppki.SignedDenyList, error)
+	// InsertDenyList inserts the given deny list. Deny lists issued by a CA are
+	// stored per CA and do not supersede the deny lists issued by the voters.
+	// Returns true if the deny list was not yet in the DB.
+	InsertDenyList(ctx context.Context, dl cppki.SignedDenyList) (bool, error)
 }
@@ -17,6 +17,7 @@ package trust_test
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -26,10 +27,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/pkg/command"
@@ -132,3 +135,45 @@ func loadKey(t *testing.T, file string) *ecdsa.PrivateKey {
 	}
 	return ret
 }
+
+// signDenyList creates a deny list for ISD 1 that denies the provided
+// certificates. The deny list is signed with the regular voting key of
+// 1-ff00:0:110.
+func signDenyList(t *testing.T, serial scrypto.Version,
+	denied ...*x509.Certificate) cppki.SignedDenyList {
+
+	t.Helper()
+	key := loadKey(t, filepath.Join(goldenDir,
+		"ISD1/ASff00_0_110/crypto/voting/regular-voting.key"))
+	cert := xtest.LoadChain(t, filepath.Join(goldenDir,
+		"ISD1/ASff00_0_110/crypto/voting/ISD1-ASff00_0_110.regular.crt"))[0]
+	return signDenyListWith(t, key, cert, serial, denied...)
+}
+
+// signCADenyList creates a deny list issued by the CA of the golden crypto.
+func signCADenyList(t *testing.T, serial scrypto.Version,
+	denied ...*x509.Certificate) cppki.SignedDenyList {
+
+	t.Helper()
+	key := loadKey(t, filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/ca/cp-ca.key"))
+	cert := xtest.LoadChain(t, filepath.Join(goldenDir,
+		"ISD1/ASff00_0_110/crypto/ca/ISD1-ASff00_0_110.ca.crt"))[0]
+	return signDenyListWith(t, key, cert, serial, denied...)
+}
+
+func signDenyListWith(t *testing.T, key crypto.Signer, cert *x509.Certificate,
+	serial scrypto.Version, denied ...*x509.Certificate) cppki.SignedDenyList {
+
+	t.Helper()
+	dl := cppki.DenyList{
+		ISD:      1,
+		Serial:   serial,
+		IssuedAt: cert.NotBefore.Add(time.Hour).UTC().Truncate(time.Second),
+	}
+	for _, c := range denied {
+		dl.Entries = append(dl.Entries, cppki.NewDenyListEntry(c))
+	}
+	signed, err := cppki.SignDenyList(dl, key, cert)
+	require.NoError(t, err)
+	return signed
+}
@@ -16,8 +16,13 @@ package dbtest
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
+	"math/big"
 	"path/filepath"
 	"testing"
 	"time"
@@ -71,8 +76,9 @@ type TestableDB interface {
 func Run(t *testing.T, db TestableDB, cfg Config) {
 	cfg.InitDefaults()
 	tests := map[string]func(*testing.T, trust.DB, Config){
-		"test TRC":   testTRC,
-		"test chain": testChain,
+		"test TRC":       testTRC,
+		"test chain":     testChain,
+		"test deny list": testDenyList,
 	}
 	// Run test suite on DB directly.
 	for name, test := range tests {
@@ -291,6 +297,152 @@ func testChain(t *testing.T, db trust.DB, cfg Config) {
 	})
 }
 
+func testDenyList(t *testing.T, db trust.DB, cfg Config) {
+	bern1Chain := loadChainFiles(t, "bern", 1, cfg)
+	geneva1Chain := loadChainFiles(t, "geneva", 1, cfg)
+	issuedAt := time.Date(2020, 6, 25, 13, 0, 0, 0, time.UTC)
+	dl1 := newDenyList(t, 1, issuedAt, bern1Chain[0])
+	dl2 := newDenyList(t, 2, issuedAt, bern1Chain[0], geneva1Chain[0])
+
+	ctx, cancelF := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancelF()
+
+	in, err := db.InsertDenyList(ctx, dl1)
+	require.NoError(t, err)
+	require.True(t, in)
+
+	t.Run("InsertDenyList", func(t *testing.T) {
+		t.Run("Insert existing", func(t *testing.T) {
+			in, err := db.InsertDenyList(ctx, dl1)
+			assert.NoError(t, err)
+			assert.False(t, in)
+		})
+		t.Run("Insert existing modified", func(t *testing.T) {
+			modified := newDenyList(t, 1, issuedAt.Add(time.Hour), bern1Chain[0])
+			in, err := db.InsertDenyList(ctx, modified)
+			assert.Error(t, err)
+			assert.False(t, in)
+		})
+	})
+	t.Run("DenyList", func(t *testing.T) {
+		t.Run("Non existing ISD", func(t *testing.T) {
+			dl, err := db.DenyList(ctx, 2, scrypto.LatestVer)
+			assert.NoError(t, err)
+			assert.Equal(t, cppki.SignedDenyList{}, dl)
+		})
+		t.Run("Non existing serial", func(t *testing.T) {
+			dl, err := db.DenyList(ctx, 1, 3)
+			assert.NoError(t, err)
+			assert.Equal(t, cppki.SignedDenyList{}, dl)
+		})
+		t.Run("Existing deny list", func(t *testing.T) {
+			dl, err := db.DenyList(ctx, 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, dl1, dl)
+		})
+		t.Run("Latest deny list single", func(t *testing.T) {
+			dl, err := db.DenyList(ctx, 1, scrypto.LatestVer)
+			assert.NoError(t, err)
+			assert.Equal(t, dl1, dl)
+		})
+		t.Run("Latest deny list multiple in DB", func(t *testing.T) {
+			_, err := db.InsertDenyList(ctx, dl2)
+			require.NoError(t, err)
+
+			dl, err := db.DenyList(ctx, 1, scrypto.LatestVer)
+			assert.NoError(t, err)
+			assert.Equal(t, dl2, dl)
+			dl, err = db.DenyList(ctx, 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, dl1, dl)
+		})
+	})
+	t.Run("CADenyLists", func(t *testing.T) {
+		t.Run("No CA deny lists", func(t *testing.T) {
+			dls, err := db.CADenyLists(ctx, 1)
+			assert.NoError(t, err)
+			assert.Empty(t, dls)
+		})
+		caA1 := newCADenyList(t, "CA A", 1, issuedAt)
+		caA2 := newCADenyList(t, "CA A", 2, issuedAt)
+		caB1 := newCADenyList(t, "CA B", 1, issuedAt)
+		for _, dl := range []cppki.SignedDenyList{caA1, caA2, caB1} {
+			in, err := db.InsertDenyList(ctx, dl)
+			require.NoError(t, err)
+			require.True(t, in)
+		}
+		t.Run("Latest deny list per CA", func(t *testing.T) {
+			dls, err := db.CADenyLists(ctx, 1)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []cppki.SignedDenyList{caA2, caB1}, dls)
+		})
+		t.Run("Non existing ISD", func(t *testing.T) {
+			dls, err := db.CADenyLists(ctx, 2)
+			assert.NoError(t, err)
+			assert.Empty(t, dls)
+		})
+		t.Run("Voter deny lists unaffected", func(t *testing.T) {
+			dl, err := db.DenyList(ctx, 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, dl1, dl)
+			dl, err = db.DenyList(ctx, 1, scrypto.LatestVer)
+			assert.NoError(t, err)
+			assert.Equal(t, dl2, dl)
+		})
+	})
+}
+
+// newDenyList creates a deny list for ISD 1 that is signed with a throwaway
+// key. The signature is not verifiable with any TRC.
+func newDenyList(t *testing.T, serial scrypto.Version, issuedAt time.Time,
+	denied ...*x509.Certificate) cppki.SignedDenyList {
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    issuedAt.Add(-time.Hour),
+		NotAfter:     issuedAt.Add(time.Hour),
+	}
+	return signDenyList(t, tmpl, serial, issuedAt, denied...)
+}
+
+// newCADenyList creates a deny list for ISD 1 that is signed by a throwaway
+// CA with the given common name.
+func newCADenyList(t *testing.T, name string, serial scrypto.Version,
+	issuedAt time.Time, denied ...*x509.Certificate) cppki.SignedDenyList {
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    issuedAt.Add(-time.Hour),
+		NotAfter:     issuedAt.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	return signDenyList(t, tmpl, serial, issuedAt, denied...)
+}
+
+func signDenyList(t *testing.T, tmpl *x509.Certificate, serial scrypto.Version,
+	issuedAt time.Time, denied ...*x509.Certificate) cppki.SignedDenyList {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	dl := cppki.DenyList{
+		ISD:      1,
+		Serial:   serial,
+		IssuedAt: issuedAt,
+	}
+	for _, c := range denied {
+		dl.Entries = append(dl.Entries, cppki.NewDenyListEntry(c))
+	}
+	signed, err := cppki.SignDenyList(dl, key, cert)
+	require.NoError(t, err)
+	return signed
+}
+
 func loadTRCFile(t *testing.T, file string, cfg Config) cppki.SignedTRC {
 	return xtest.LoadTRC(t, cfg.filePath(file))
 }
@@ -0,0 +1,31 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import "time"
+
+// FetchingProviderWithClock returns a copy of the provider that uses the clock
+// to determine the current time.
+func FetchingProviderWithClock(p FetchingProvider, now func() time.Time) FetchingProvider {
+	p.now = now
+	return p
+}
+
+// SignerGenWithClock returns a copy of the signer generator that uses the
+// clock to determine the current time.
+func SignerGenWithClock(s SignerGen, now func() time.Time) SignerGen {
+	s.now = now
+	return s
+}
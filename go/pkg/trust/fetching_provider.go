@@ -15,6 +15,7 @@
 package trust
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"fmt"
@@ -45,6 +46,9 @@ type FetchingProvider struct {
 	Recurser Recurser
 	Fetcher  Fetcher
 	Router   Router
+
+	// now returns the current time. If nil, time.Now is used.
+	now func() time.Time
 }
 
 // GetChains returns certificate chains that match the chain query. If no chain
@@ -60,6 +64,10 @@ type FetchingProvider struct {
 // this feature is not implemented. Thus, certificate chains that are fetched
 // over the network that are not verifiable against the active TRCs are ignored,
 // even if the AllowInactive option is set.
+//
+// Chains that contain a certificate that is listed on the latest locally
+// available deny list issued by the voters of the ISD, or on the latest locally
+// available deny list of any CA of the ISD, are never returned.
 func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 	opts ...Option) ([][]*x509.Certificate, error) {
 
@@ -79,8 +87,9 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 		"date", util.TimeToCompact(query.Date),
 		"subject_key_id", fmt.Sprintf("%x", query.SubjectKeyID))
 
+	now := p.currentTime()
 	if query.Date.IsZero() {
-		query.Date = time.Now()
+		query.Date = now
 		logger.Debug("[trust:Provider] Set date for chain request with zero time")
 	}
 
@@ -91,13 +100,21 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 		setProviderMetric(span, l.WithResult(metrics.ErrDB), err)
 		return nil, serrors.WrapStr("fetching chains from database", err)
 	}
+	dls, err := denyLists(ctx, p.DB, query.IA.I)
+	if err != nil {
+		logger.Info("[trust:Provider] Failed to get deny lists from database",
+			"isd", query.IA.I, "err", err)
+		setProviderMetric(span, l.WithResult(metrics.ErrDB), err)
+		return nil, serrors.WrapStr("fetching deny lists from database", err)
+	}
+	chains = filterDeniedChains(chains, dls)
 
 	if o.allowInactive && len(chains) > 0 {
 		setProviderMetric(span, l.WithResult(metrics.Success), nil)
 		return chains, nil
 	}
 
-	trcs, result, err := activeTRCs(ctx, p.DB, query.IA.I)
+	trcs, result, err := activeTRCs(ctx, p.DB, query.IA.I, now)
 	if err != nil {
 		logger.Info("[trust:Provider] Failed to get TRC for chain verification",
 			"isd", query.IA.I, "err", err)
@@ -105,7 +122,7 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 		return nil, serrors.WrapStr("fetching active TRCs from database", err)
 	}
 
-	chains = filterVerifiableChains(chains, trcs)
+	chains = filterVerifiableChains(chains, trcs, now)
 	if len(chains) > 0 {
 		setProviderMetric(span, l.WithResult(metrics.Success), nil)
 		return chains, nil
@@ -129,7 +146,7 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 	}
 
 	// For simplicity, we ignore non-verifiable chains.
-	chains = filterVerifiableChains(chains, trcs)
+	chains = filterDeniedChains(filterVerifiableChains(chains, trcs, now), dls)
 	if len(chains) > 0 {
 		// FIXME(roosd): Should probably be a transaction.
 		for _, chain := range chains {
@@ -221,7 +238,116 @@ func (p FetchingProvider) NotifyTRC(ctx context.Context, id cppki.TRCID, opts ..
 	return nil
 }
 
-func activeTRCs(ctx context.Context, db DB, isd addr.ISD) ([]cppki.SignedTRC, string, error) {
+// GetDenyList returns the signed deny list issued by the voters of the ISD.
+// Currently, this method only uses the DB and doesn't recurse over the network.
+// Deny lists are resolved over the network with NotifyDenyList.
+func (p FetchingProvider) GetDenyList(ctx context.Context, isd addr.ISD,
+	serial scrypto.Version, opts ...Option) (cppki.SignedDenyList, error) {
+
+	return p.DB.DenyList(ctx, isd, serial)
+}
+
+// GetCADenyLists returns the latest deny list of every CA of the ISD. This
+// method only uses the DB. Deny lists issued by a CA are loaded from disk, or
+// resolved over the network with NotifyDenyList if a remote serves them.
+func (p FetchingProvider) GetCADenyLists(ctx context.Context, isd addr.ISD,
+	opts ...Option) ([]cppki.SignedDenyList, error) {
+
+	return p.DB.CADenyLists(ctx, isd)
+}
+
+// NotifyDenyList notifies the provider of the existence of a deny list. If the
+// deny list is newer than the locally available one, it is fetched, verified
+// against the active TRCs and inserted into the database. If the serial number
+// is scrypto.LatestVer, the latest deny list is always fetched from the remote.
+// This method only fails in case of a DB, network or verification error.
+//
+// A fetched deny list that is issued by a CA only supersedes the deny lists of
+// the same CA. It never replaces the deny list issued by the voters of the ISD.
+// The CA must not be listed on the deny list issued by the voters.
+func (p FetchingProvider) NotifyDenyList(ctx context.Context, isd addr.ISD,
+	serial scrypto.Version, opts ...Option) error {
+
+	l := metrics.ProviderLabels{Type: metrics.NotifyDenyList, Trigger: metrics.FromCtx(ctx)}
+	o := applyOptions(opts)
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "trustengine.notify_deny_list")
+	defer span.Finish()
+	opentracingext.Component.Set(span, "trust")
+	span.SetTag("deny_list.isd", isd)
+	span.SetTag("deny_list.serial", serial)
+
+	logger := log.FromCtx(ctx)
+	logger.Debug("[trust:Provider] Deny list notify", "isd", isd, "serial", serial)
+
+	local, err := p.DB.DenyList(ctx, isd, scrypto.LatestVer)
+	if err != nil {
+		setProviderMetric(span, l.WithResult(metrics.ErrDB), err)
+		return err
+	}
+	if !serial.IsLatest() && !local.IsZero() && serial <= local.DenyList.Serial {
+		setProviderMetric(span, l.WithResult(metrics.Success), nil)
+		return nil
+	}
+	if err := p.Recurser.AllowRecursion(o.client); err != nil {
+		setProviderMetric(span, l.WithResult(metrics.ErrNotAllowed), err)
+		return serrors.WrapStr("recursion not allowed", err)
+	}
+	if o.server == nil {
+		if o.server, err = p.Router.ChooseServer(ctx, isd); err != nil {
+			setProviderMetric(span, l.WithResult(metrics.ErrInternal), err)
+			return serrors.WrapStr("choosing server", err)
+		}
+	}
+	fetched, err := p.Fetcher.DenyList(ctx, isd, serial, o.server)
+	if err != nil {
+		setProviderMetric(span, l.WithResult(metrics.ErrInternal), err)
+		return serrors.WrapStr("resolving deny list", err, "isd", isd, "serial", serial)
+	}
+	prev := local
+	if fetched.CA != nil {
+		if prev, err = caDenyList(ctx, p.DB, isd, fetched.CA); err != nil {
+			setProviderMetric(span, l.WithResult(metrics.ErrDB), err)
+			return err
+		}
+	}
+	if !prev.IsZero() && fetched.DenyList.Serial <= prev.DenyList.Serial {
+		setProviderMetric(span, l.WithResult(metrics.OkIgnored), nil)
+		return nil
+	}
+	now := p.currentTime()
+	trcs, result, err := activeTRCs(ctx, p.DB, isd, now)
+	if err != nil {
+		setProviderMetric(span, l.WithResult(result), err)
+		return serrors.WrapStr("fetching active TRCs from database", err)
+	}
+	if err := verifyDenyList(fetched, trcs, &local.DenyList, now); err != nil {
+		setProviderMetric(span, l.WithResult(metrics.ErrVerify), err)
+		return serrors.WrapStr("verifying deny list", err, "isd", isd,
+			"serial", fetched.DenyList.Serial)
+	}
+	if _, err := p.DB.InsertDenyList(ctx, fetched); err != nil {
+		setProviderMetric(span, l.WithResult(metrics.ErrInternal), err)
+		return serrors.WrapStr("inserting deny list", err, "isd", isd,
+			"serial", fetched.DenyList.Serial)
+	}
+	logger.Info("[trust:Provider] Inserted new deny list", "isd", isd,
+		"serial", fetched.DenyList.Serial, "entries", len(fetched.DenyList.Entries),
+		"ca_issued", fetched.CA != nil)
+	setProviderMetric(span, l.WithResult(metrics.OkInserted), nil)
+	return nil
+}
+
+func (p FetchingProvider) currentTime() time.Time {
+	if p.now == nil {
+		return time.Now()
+	}
+	return p.now()
+}
+
+func activeTRCs(ctx context.Context, db DB, isd addr.ISD,
+	now time.Time) ([]cppki.SignedTRC, string, error) {
+
 	trc, err := db.SignedTRC(ctx, cppki.TRCID{
 		ISD:    isd,
 		Base:   scrypto.LatestVer,
@@ -236,10 +362,10 @@ func activeTRCs(ctx context.Context, db DB, isd addr.ISD) ([]cppki.SignedTRC, st
 	// XXX(roosd): This could resolve newer TRCs over the network. However,
 	// for every GetChains by the verifier, there should be a NotifyTRC, such
 	// that should never run into this condition in the first place.
-	if !trc.TRC.Validity.Contains(time.Now()) {
+	if !trc.TRC.Validity.Contains(now) {
 		return nil, metrics.ErrInactive, errInactive
 	}
-	if !trc.TRC.InGracePeriod(time.Now()) {
+	if !trc.TRC.InGracePeriod(now) {
 		return []cppki.SignedTRC{trc}, metrics.Success, nil
 	}
 	grace, err := db.SignedTRC(ctx, cppki.TRCID{
@@ -257,12 +383,13 @@ func activeTRCs(ctx context.Context, db DB, isd addr.ISD) ([]cppki.SignedTRC, st
 }
 
 func filterVerifiableChains(chains [][]*x509.Certificate,
-	trcs []cppki.SignedTRC) [][]*x509.Certificate {
+	trcs []cppki.SignedTRC, now time.Time) [][]*x509.Certificate {
 
 	verified := make([][]*x509.Certificate, 0, len(chains))
 	for _, chain := range chains {
 		for _, trc := range trcs {
-			if err := cppki.VerifyChain(chain, cppki.VerifyOptions{TRC: &trc.TRC}); err == nil {
+			opts := cppki.VerifyOptions{TRC: &trc.TRC, CurrentTime: now}
+			if err := cppki.VerifyChain(chain, opts); err == nil {
 				verified = append(verified, chain)
 				break
 			}
@@ -271,6 +398,89 @@ func filterVerifiableChains(chains [][]*x509.Certificate,
 	return verified
 }
 
+// denyLists returns the deny lists that apply to the ISD, i.e., the latest deny
+// list issued by the voters, if any, and the latest deny list of every CA.
+func denyLists(ctx context.Context, db DB, isd addr.ISD) ([]cppki.SignedDenyList, error) {
+	dl, err := db.DenyList(ctx, isd, scrypto.LatestVer)
+	if err != nil {
+		return nil, err
+	}
+	dls, err := db.CADenyLists(ctx, isd)
+	if err != nil {
+		return nil, err
+	}
+	if dl.IsZero() {
+		return dls, nil
+	}
+	return append(dls, dl), nil
+}
+
+// caDenyList returns the latest deny list issued by the CA. If no deny list is
+// found, the returned deny list is empty.
+func caDenyList(ctx context.Context, db DB, isd addr.ISD,
+	ca *x509.Certificate) (cppki.SignedDenyList, error) {
+
+	dls, err := db.CADenyLists(ctx, isd)
+	if err != nil {
+		return cppki.SignedDenyList{}, err
+	}
+	for _, dl := range dls {
+		if bytes.Equal(dl.CA.RawSubject, ca.RawSubject) {
+			return dl, nil
+		}
+	}
+	return cppki.SignedDenyList{}, nil
+}
+
+// deniedChain indicates whether any of the deny lists contains a certificate of
+// the chain.
+func deniedChain(chain []*x509.Certificate, dls []cppki.SignedDenyList) bool {
+	for _, dl := range dls {
+		if dl.DenyList.DeniesChain(chain) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDeniedChains removes all chains that contain a certificate that is
+// listed on any of the deny lists.
+func filterDeniedChains(chains [][]*x509.Certificate,
+	dls []cppki.SignedDenyList) [][]*x509.Certificate {
+
+	if len(dls) == 0 {
+		return chains
+	}
+	allowed := make([][]*x509.Certificate, 0, len(chains))
+	for _, chain := range chains {
+		if !deniedChain(chain, dls) {
+			allowed = append(allowed, chain)
+		}
+	}
+	return allowed
+}
+
+// verifyDenyList verifies the deny list against the provided TRCs. It is
+// sufficient if one of the TRCs verifies the deny list. A deny list issued by a
+// CA is rejected if the CA is listed on the ISD deny list.
+func verifyDenyList(dl cppki.SignedDenyList, trcs []cppki.SignedTRC, isdDenyList *cppki.DenyList,
+	now time.Time) error {
+
+	var errs serrors.List
+	for _, trc := range trcs {
+		err := dl.Verify(cppki.DenyListVerifyOptions{
+			TRC:         &trc.TRC,
+			CurrentTime: now,
+			ISDDenyList: isdDenyList,
+		})
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errs.ToError()
+}
+
 func setProviderMetric(span opentracing.Span, l metrics.ProviderLabels, err error) {
 	metrics.Provider.Request(l).Inc()
 	tracing.ResultLabel(span, l.Result)
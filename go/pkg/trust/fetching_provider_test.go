@@ -24,6 +24,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/scrypto"
@@ -43,12 +44,13 @@ func TestFetchingProviderGetChains(t *testing.T) {
 		filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/ISD1-ASff00_0_110.pem"))
 	inactive := xtest.LoadChain(t,
 		filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/ISD1-ASff00_0_110.pem"))
-	inactive[0].NotAfter = time.Now().Add(-time.Second)
+	now := valid[0].NotBefore.Add(time.Hour)
+	inactive[0].NotAfter = now.Add(-time.Second)
 	all := append([][]*x509.Certificate{valid}, inactive)
 
 	query := trust.ChainQuery{
 		IA:           xtest.MustParseIA("1-ff00:0:110"),
-		Date:         time.Now(),
+		Date:         now,
 		SubjectKeyID: valid[0].SubjectKeyId,
 	}
 
@@ -67,6 +69,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
 					ia:   query.IA,
 					skid: query.SubjectKeyID}).Return(all, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				return db
 			},
 			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
@@ -88,6 +94,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
 					ia:   query.IA,
 					skid: query.SubjectKeyID}).Return(all, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				return db
 			},
@@ -110,6 +120,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
 					ia:   query.IA,
 					skid: query.SubjectKeyID}).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				db.EXPECT().InsertChain(gomock.Any(), valid).Return(true, nil)
 				return db
@@ -143,6 +157,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
 					ia:   query.IA,
 					skid: query.SubjectKeyID}).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				db.EXPECT().InsertChain(gomock.Any(), valid).Return(true, nil)
 				return db
@@ -170,6 +188,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
 					ia:   query.IA,
 					skid: query.SubjectKeyID}).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				return db
 			},
@@ -194,6 +216,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
 				db := mock_trust.NewMockDB(ctrl)
 				db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
 					cppki.SignedTRC{}, serrors.New("internal"))
 				return db
@@ -213,6 +239,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
 				db := mock_trust.NewMockDB(ctrl)
 				db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(cppki.SignedTRC{}, nil)
 				return db
 			},
@@ -248,6 +278,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
 				db := mock_trust.NewMockDB(ctrl)
 				db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				return db
 			},
@@ -268,6 +302,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
 				db := mock_trust.NewMockDB(ctrl)
 				db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				return db
 			},
@@ -291,6 +329,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
 				db := mock_trust.NewMockDB(ctrl)
 				db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				return db
 			},
@@ -315,6 +357,10 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
 				db := mock_trust.NewMockDB(ctrl)
 				db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				db.EXPECT().InsertChain(gomock.Any(), valid).Return(
 					false, serrors.New("internal"))
@@ -336,12 +382,152 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
 			ErrAssertion: assert.Error,
 		},
+		"denied chain in database": {
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
+					ia:   query.IA,
+					skid: query.SubjectKeyID}).Return(all, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					signDenyList(t, 1, valid[0]), nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(serrors.New("not allowed"))
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			Options:      []trust.Option{},
+			ErrAssertion: assert.Error,
+		},
+		"denied chain remote": {
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
+					ia:   query.IA,
+					skid: query.SubjectKeyID}).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					signDenyList(t, 1, valid[0]), nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().Chains(gomock.Any(), query, &net.UDPAddr{Port: 90}).Return(
+					[][]*x509.Certificate{valid}, nil,
+				)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.NoError,
+		},
+		"deny list fails": {
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
+					ia:   query.IA,
+					skid: query.SubjectKeyID}).Return(all, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, serrors.New("internal"),
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				return mock_trust.NewMockRecurser(ctrl)
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			Options:      []trust.Option{trust.AllowInactive()},
+			ErrAssertion: assert.Error,
+		},
+		"chain denied by CA in database": {
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
+					ia:   query.IA,
+					skid: query.SubjectKeyID}).Return(all, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(
+					[]cppki.SignedDenyList{signCADenyList(t, 1, valid[0])}, nil,
+				)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(serrors.New("not allowed"))
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			Options:      []trust.Option{},
+			ErrAssertion: assert.Error,
+		},
+		"CA deny lists fail": {
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
+					ia:   query.IA,
+					skid: query.SubjectKeyID}).Return(all, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(
+					nil, serrors.New("internal"),
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				return mock_trust.NewMockRecurser(ctrl)
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			Options:      []trust.Option{trust.AllowInactive()},
+			ErrAssertion: assert.Error,
+		},
 		"reject forged chain": {
 			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
 				db := mock_trust.NewMockDB(ctrl)
 				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
 					ia:   query.IA,
 					skid: query.SubjectKeyID}).Return(nil, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
 				return db
 			},
@@ -373,12 +559,12 @@ func TestFetchingProviderGetChains(t *testing.T) {
 			t.Parallel()
 			mctrl := gomock.NewController(t)
 			defer mctrl.Finish()
-			p := trust.FetchingProvider{
+			p := trust.FetchingProviderWithClock(trust.FetchingProvider{
 				DB:       tc.DB(t, mctrl),
 				Recurser: tc.Recurser(t, mctrl),
 				Fetcher:  tc.Fetcher(t, mctrl),
 				Router:   tc.Router(t, mctrl),
-			}
+			}, func() time.Time { return now })
 			c, err := p.GetChains(context.Background(), query, tc.Options...)
 			tc.ErrAssertion(t, err)
 			if err != nil {
@@ -686,3 +872,401 @@ func TestFetchingProviderNotifyTRC(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchingProviderNotifyDenyList(t *testing.T) {
+	if *update {
+		t.Skip("test crypto is being updated")
+	}
+	trc := xtest.LoadTRC(t, filepath.Join(goldenDir, "trcs/ISD1-B1-S1.trc"))
+	chain := xtest.LoadChain(t,
+		filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/ISD1-ASff00_0_110.pem"))
+	dl1 := signDenyList(t, 1)
+	dl2 := signDenyList(t, 2, chain[0])
+	forged, err := cppki.SignDenyList(dl2.DenyList,
+		loadKey(t, filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/cp-as.key")),
+		chain[0],
+	)
+	require.NoError(t, err)
+	caDL1 := signCADenyList(t, 1, chain[0])
+	caDL2 := signCADenyList(t, 2, chain[0])
+	deniedCA := signDenyList(t, 1, chain[1])
+	now := chain[0].NotBefore.Add(time.Hour)
+
+	testCases := map[string]struct {
+		Serial       scrypto.Version
+		DB           func(t *testing.T, ctrl *gomock.Controller) trust.DB
+		Recurser     func(t *testing.T, ctrl *gomock.Controller) trust.Recurser
+		Router       func(t *testing.T, ctrl *gomock.Controller) trust.Router
+		Fetcher      func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher
+		Options      []trust.Option
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"deny list in database": {
+			Serial: 1,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl2, nil,
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				return mock_trust.NewMockRecurser(ctrl)
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			Options:      []trust.Option{},
+			ErrAssertion: assert.NoError,
+		},
+		"newer deny list remote": {
+			Serial: 2,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				db.EXPECT().InsertDenyList(gomock.Any(), dl2).Return(true, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.Version(2),
+					&net.UDPAddr{Port: 90}).Return(dl2, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.NoError,
+		},
+		"latest without local deny list": {
+			Serial: scrypto.LatestVer,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				db.EXPECT().InsertDenyList(gomock.Any(), dl1).Return(true, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				r := mock_trust.NewMockRouter(ctrl)
+				r.EXPECT().ChooseServer(gomock.Any(), addr.ISD(1)).Return(
+					&net.UDPAddr{Port: 90}, nil,
+				)
+				return r
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer,
+					&net.UDPAddr{Port: 90}).Return(dl1, nil)
+				return f
+			},
+			Options:      []trust.Option{},
+			ErrAssertion: assert.NoError,
+		},
+		"latest remote is older": {
+			Serial: scrypto.LatestVer,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl2, nil,
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer,
+					&net.UDPAddr{Port: 90}).Return(dl1, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.NoError,
+		},
+		"database error": {
+			Serial: 2,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, serrors.New("internal"),
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				return mock_trust.NewMockRecurser(ctrl)
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			Options:      []trust.Option{},
+			ErrAssertion: assert.Error,
+		},
+		"recursion not allowed": {
+			Serial: 2,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(serrors.New("not allowed"))
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			Options:      []trust.Option{},
+			ErrAssertion: assert.Error,
+		},
+		"fetcher fails": {
+			Serial: 2,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.Version(2),
+					&net.UDPAddr{Port: 90}).Return(cppki.SignedDenyList{}, serrors.New("fail"))
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.Error,
+		},
+		"reject forged deny list": {
+			Serial: 2,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.Version(2),
+					&net.UDPAddr{Port: 90}).Return(forged, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.Error,
+		},
+		"CA deny list remote": {
+			Serial: scrypto.LatestVer,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				db.EXPECT().InsertDenyList(gomock.Any(), caDL1).Return(true, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer,
+					&net.UDPAddr{Port: 90}).Return(caDL1, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.NoError,
+		},
+		"CA deny list remote is older": {
+			Serial: scrypto.LatestVer,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(
+					[]cppki.SignedDenyList{caDL2}, nil,
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer,
+					&net.UDPAddr{Port: 90}).Return(caDL1, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.NoError,
+		},
+		"CA deny lists fail": {
+			Serial: scrypto.LatestVer,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(
+					nil, serrors.New("internal"),
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer,
+					&net.UDPAddr{Port: 90}).Return(caDL1, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.Error,
+		},
+		"reject deny list of denied CA": {
+			Serial: scrypto.LatestVer,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					deniedCA, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer,
+					&net.UDPAddr{Port: 90}).Return(caDL1, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.Error,
+		},
+		"insert fails": {
+			Serial: 2,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl1, nil,
+				)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				db.EXPECT().InsertDenyList(gomock.Any(), dl2).Return(
+					false, serrors.New("internal"),
+				)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.Version(2),
+					&net.UDPAddr{Port: 90}).Return(dl2, nil)
+				return f
+			},
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.Error,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			p := trust.FetchingProviderWithClock(trust.FetchingProvider{
+				DB:       tc.DB(t, mctrl),
+				Recurser: tc.Recurser(t, mctrl),
+				Fetcher:  tc.Fetcher(t, mctrl),
+				Router:   tc.Router(t, mctrl),
+			}, func() time.Time { return now })
+			err := p.NotifyDenyList(context.Background(), 1, tc.Serial, tc.Options...)
+			tc.ErrAssertion(t, err)
+		})
+	}
+}
@@ -29,6 +29,10 @@ const (
 
 	Chains      = "get_chains"
 	InsertChain = "insert_chain"
+
+	GetDenyList    = "get_deny_list"
+	GetCADenyLists = "get_ca_deny_lists"
+	InsertDenyList = "insert_deny_list"
 )
 
 // Version indicator
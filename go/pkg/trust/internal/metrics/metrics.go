@@ -28,16 +28,19 @@ const Namespace = "trustengine"
 
 // Trust material
 const (
-	Chain = "chain"
-	TRC   = "trc"
+	Chain    = "chain"
+	TRC      = "trc"
+	DenyList = "deny_list"
 )
 
 // Request types
 const (
-	TRCReq    = "trc_request"
-	ChainReq  = "chain_request"
-	NotifyTRC = "trc_notify"
-	LatestTRC = "latest_trc_number"
+	TRCReq         = "trc_request"
+	ChainReq       = "chain_request"
+	DenyListReq    = "deny_list_request"
+	NotifyTRC      = "trc_notify"
+	NotifyDenyList = "deny_list_notify"
+	LatestTRC      = "latest_trc_number"
 )
 
 // Triggers
@@ -22,7 +22,9 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	dblib "github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/tracing"
 	"github.com/scionproto/scion/go/pkg/trust"
@@ -118,6 +120,48 @@ func (e *executor) InsertTRC(ctx context.Context, trc cppki.SignedTRC) (bool, er
 	return inserted, err
 }
 
+func (e *executor) DenyList(ctx context.Context, isd addr.ISD,
+	serial scrypto.Version) (cppki.SignedDenyList, error) {
+
+	var dl cppki.SignedDenyList
+	var err error
+	e.metrics.Observe(ctx, metrics.GetDenyList, func(ctx context.Context) (string, error) {
+		dl, err = e.db.DenyList(ctx, isd, serial)
+		label := dblib.ErrToMetricLabel(err)
+		if len(dl.Raw) == 0 && err == nil {
+			label = metrics.ErrNotFound
+		}
+		return label, err
+	})
+	return dl, err
+}
+
+func (e *executor) CADenyLists(ctx context.Context,
+	isd addr.ISD) ([]cppki.SignedDenyList, error) {
+
+	var dls []cppki.SignedDenyList
+	var err error
+	e.metrics.Observe(ctx, metrics.GetCADenyLists, func(ctx context.Context) (string, error) {
+		dls, err = e.db.CADenyLists(ctx, isd)
+		label := dblib.ErrToMetricLabel(err)
+		if len(dls) == 0 && err == nil {
+			label = metrics.ErrNotFound
+		}
+		return label, err
+	})
+	return dls, err
+}
+
+func (e *executor) InsertDenyList(ctx context.Context, dl cppki.SignedDenyList) (bool, error) {
+	var inserted bool
+	var err error
+	e.metrics.Observe(ctx, metrics.InsertDenyList, func(ctx context.Context) (string, error) {
+		inserted, err = e.db.InsertDenyList(ctx, dl)
+		return dblib.ErrToMetricLabel(err), err
+	})
+	return inserted, err
+}
+
 func (e *executor) Chains(ctx context.Context, q trust.ChainQuery) ([][]*x509.Certificate, error) {
 	var chains [][]*x509.Certificate
 	var err error
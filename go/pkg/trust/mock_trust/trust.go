@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	addr "github.com/scionproto/scion/go/lib/addr"
 	cert_mgmt "github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	scrypto "github.com/scionproto/scion/go/lib/scrypto"
 	cppki "github.com/scionproto/scion/go/lib/scrypto/cppki"
 	trust "github.com/scionproto/scion/go/pkg/trust"
 	net "net"
@@ -40,6 +41,21 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// CADenyLists mocks base method
+func (m *MockDB) CADenyLists(arg0 context.Context, arg1 addr.ISD) ([]cppki.SignedDenyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CADenyLists", arg0, arg1)
+	ret0, _ := ret[0].([]cppki.SignedDenyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CADenyLists indicates an expected call of CADenyLists
+func (mr *MockDBMockRecorder) CADenyLists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CADenyLists", reflect.TypeOf((*MockDB)(nil).CADenyLists), arg0, arg1)
+}
+
 // Chains mocks base method
 func (m *MockDB) Chains(arg0 context.Context, arg1 trust.ChainQuery) ([][]*x509.Certificate, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +85,21 @@ func (mr *MockDBMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDB)(nil).Close))
 }
 
+// DenyList mocks base method
+func (m *MockDB) DenyList(arg0 context.Context, arg1 addr.ISD, arg2 scrypto.Version) (cppki.SignedDenyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(cppki.SignedDenyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DenyList indicates an expected call of DenyList
+func (mr *MockDBMockRecorder) DenyList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyList", reflect.TypeOf((*MockDB)(nil).DenyList), arg0, arg1, arg2)
+}
+
 // InsertChain mocks base method
 func (m *MockDB) InsertChain(arg0 context.Context, arg1 []*x509.Certificate) (bool, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +115,21 @@ func (mr *MockDBMockRecorder) InsertChain(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertChain", reflect.TypeOf((*MockDB)(nil).InsertChain), arg0, arg1)
 }
 
+// InsertDenyList mocks base method
+func (m *MockDB) InsertDenyList(arg0 context.Context, arg1 cppki.SignedDenyList) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDenyList", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertDenyList indicates an expected call of InsertDenyList
+func (mr *MockDBMockRecorder) InsertDenyList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDenyList", reflect.TypeOf((*MockDB)(nil).InsertDenyList), arg0, arg1)
+}
+
 // InsertTRC mocks base method
 func (m *MockDB) InsertTRC(arg0 context.Context, arg1 cppki.SignedTRC) (bool, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +222,21 @@ func (mr *MockFetcherMockRecorder) Chains(arg0, arg1, arg2 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chains", reflect.TypeOf((*MockFetcher)(nil).Chains), arg0, arg1, arg2)
 }
 
+// DenyList mocks base method
+func (m *MockFetcher) DenyList(arg0 context.Context, arg1 addr.ISD, arg2 scrypto.Version, arg3 net.Addr) (cppki.SignedDenyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyList", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(cppki.SignedDenyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DenyList indicates an expected call of DenyList
+func (mr *MockFetcherMockRecorder) DenyList(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyList", reflect.TypeOf((*MockFetcher)(nil).DenyList), arg0, arg1, arg2, arg3)
+}
+
 // TRC mocks base method
 func (m *MockFetcher) TRC(arg0 context.Context, arg1 cppki.TRCID, arg2 net.Addr) (cppki.SignedTRC, error) {
 	m.ctrl.T.Helper()
@@ -305,6 +366,26 @@ func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
 	return m.recorder
 }
 
+// GetCADenyLists mocks base method
+func (m *MockProvider) GetCADenyLists(arg0 context.Context, arg1 addr.ISD, arg2 ...trust.Option) ([]cppki.SignedDenyList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCADenyLists", varargs...)
+	ret0, _ := ret[0].([]cppki.SignedDenyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCADenyLists indicates an expected call of GetCADenyLists
+func (mr *MockProviderMockRecorder) GetCADenyLists(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCADenyLists", reflect.TypeOf((*MockProvider)(nil).GetCADenyLists), varargs...)
+}
+
 // GetChains mocks base method
 func (m *MockProvider) GetChains(arg0 context.Context, arg1 trust.ChainQuery, arg2 ...trust.Option) ([][]*x509.Certificate, error) {
 	m.ctrl.T.Helper()
@@ -325,6 +406,26 @@ func (mr *MockProviderMockRecorder) GetChains(arg0, arg1 interface{}, arg2 ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChains", reflect.TypeOf((*MockProvider)(nil).GetChains), varargs...)
 }
 
+// GetDenyList mocks base method
+func (m *MockProvider) GetDenyList(arg0 context.Context, arg1 addr.ISD, arg2 scrypto.Version, arg3 ...trust.Option) (cppki.SignedDenyList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDenyList", varargs...)
+	ret0, _ := ret[0].(cppki.SignedDenyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDenyList indicates an expected call of GetDenyList
+func (mr *MockProviderMockRecorder) GetDenyList(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDenyList", reflect.TypeOf((*MockProvider)(nil).GetDenyList), varargs...)
+}
+
 // GetSignedTRC mocks base method
 func (m *MockProvider) GetSignedTRC(arg0 context.Context, arg1 cppki.TRCID, arg2 ...trust.Option) (cppki.SignedTRC, error) {
 	m.ctrl.T.Helper()
@@ -345,6 +446,25 @@ func (mr *MockProviderMockRecorder) GetSignedTRC(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignedTRC", reflect.TypeOf((*MockProvider)(nil).GetSignedTRC), varargs...)
 }
 
+// NotifyDenyList mocks base method
+func (m *MockProvider) NotifyDenyList(arg0 context.Context, arg1 addr.ISD, arg2 scrypto.Version, arg3 ...trust.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NotifyDenyList", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyDenyList indicates an expected call of NotifyDenyList
+func (mr *MockProviderMockRecorder) NotifyDenyList(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyDenyList", reflect.TypeOf((*MockProvider)(nil).NotifyDenyList), varargs...)
+}
+
 // NotifyTRC mocks base method
 func (m *MockProvider) NotifyTRC(arg0 context.Context, arg1 cppki.TRCID, arg2 ...trust.Option) error {
 	m.ctrl.T.Helper()
@@ -477,6 +597,21 @@ func (mr *MockRPCMockRecorder) GetCertChain(arg0, arg1, arg2, arg3 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertChain", reflect.TypeOf((*MockRPC)(nil).GetCertChain), arg0, arg1, arg2, arg3)
 }
 
+// GetDenyList mocks base method
+func (m *MockRPC) GetDenyList(arg0 context.Context, arg1 *cert_mgmt.DenyListReq, arg2 net.Addr, arg3 uint64) (*cert_mgmt.DenyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDenyList", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*cert_mgmt.DenyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDenyList indicates an expected call of GetDenyList
+func (mr *MockRPCMockRecorder) GetDenyList(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDenyList", reflect.TypeOf((*MockRPC)(nil).GetDenyList), arg0, arg1, arg2, arg3)
+}
+
 // GetTRC mocks base method
 func (m *MockRPC) GetTRC(arg0 context.Context, arg1 *cert_mgmt.TRCReq, arg2 net.Addr, arg3 uint64) (*cert_mgmt.TRC, error) {
 	m.ctrl.T.Helper()
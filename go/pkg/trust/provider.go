@@ -19,6 +19,8 @@ import (
 	"crypto/x509"
 	"net"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 )
 
@@ -38,6 +40,18 @@ type Provider interface {
 	// GetSignedTRC returns the TRC with the given ID. If the TRC is not
 	// available, the provider can resolve it over the network.
 	GetSignedTRC(context.Context, cppki.TRCID, ...Option) (cppki.SignedTRC, error)
+	// NotifyDenyList notifies a provider of the existence of a deny list for
+	// the ISD. If the serial number is scrypto.LatestVer, the provider checks
+	// whether a newer deny list than the locally available one exists.
+	NotifyDenyList(context.Context, addr.ISD, scrypto.Version, ...Option) error
+	// GetDenyList returns the deny list issued by the voters of the ISD with
+	// the given serial number. If the serial number is scrypto.LatestVer, the
+	// latest locally available deny list is returned.
+	GetDenyList(context.Context, addr.ISD, scrypto.Version, ...Option) (cppki.SignedDenyList,
+		error)
+	// GetCADenyLists returns the latest locally available deny list of every CA
+	// of the ISD.
+	GetCADenyLists(context.Context, addr.ISD, ...Option) ([]cppki.SignedDenyList, error)
 }
 
 type options struct {
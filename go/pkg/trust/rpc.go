@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/tracing"
@@ -39,6 +40,8 @@ import (
 type RPC interface {
 	GetCertChain(ctx context.Context, msg *cert_mgmt.ChainReq, a net.Addr,
 		id uint64) (*cert_mgmt.Chain, error)
+	GetDenyList(ctx context.Context, msg *cert_mgmt.DenyListReq, a net.Addr,
+		id uint64) (*cert_mgmt.DenyList, error)
 	GetTRC(ctx context.Context, msg *cert_mgmt.TRCReq, a net.Addr,
 		id uint64) (*cert_mgmt.TRC, error)
 }
@@ -49,6 +52,11 @@ type Fetcher interface {
 	Chains(ctx context.Context, req ChainQuery, server net.Addr) ([][]*x509.Certificate, error)
 	// TRC fetches a specific TRC from the remote.
 	TRC(ctx context.Context, id cppki.TRCID, server net.Addr) (cppki.SignedTRC, error)
+	// DenyList fetches the deny list of the ISD from the remote. If the serial
+	// number is scrypto.LatestVer, the latest deny list known to the remote is
+	// fetched.
+	DenyList(ctx context.Context, isd addr.ISD, serial scrypto.Version,
+		server net.Addr) (cppki.SignedDenyList, error)
 }
 
 // DefaultFetcher fetches trust material from a remote.
@@ -146,6 +154,51 @@ func (r DefaultFetcher) TRC(ctx context.Context, id cppki.TRCID,
 	return trc, nil
 }
 
+func (r DefaultFetcher) DenyList(ctx context.Context, isd addr.ISD, serial scrypto.Version,
+	server net.Addr) (cppki.SignedDenyList, error) {
+
+	l := metrics.RPCLabels{Type: metrics.DenyListReq, Trigger: metrics.FromCtx(ctx),
+		Peer: metrics.PeerToLabel(server, r.IA)}
+	span, ctx := opentracing.StartSpanFromContext(ctx, "trustengine.fetch_deny_list")
+	defer span.Finish()
+	opentracingext.Component.Set(span, "trust")
+	span.SetTag("deny_list.isd", isd)
+	span.SetTag("deny_list.serial", serial)
+
+	logger := log.FromCtx(ctx)
+	logger.Debug("[trust:Resolver] Fetch deny list from remote", "isd", isd,
+		"serial", serial, "server", server)
+
+	req := &cert_mgmt.DenyListReq{
+		ISD:    isd,
+		Serial: serial,
+	}
+	reply, err := r.RPC.GetDenyList(ctx, req, server, messenger.NextId())
+	if err != nil {
+		tracing.Error(span, err)
+		metrics.RPC.Fetch(l.WithResult(metrics.ErrTransmit)).Inc()
+		return cppki.SignedDenyList{}, serrors.WrapStr("fetch deny list", err)
+	}
+	dl, err := cppki.DecodeSignedDenyList(reply.RawDenyList)
+	if err != nil {
+		tracing.Error(span, err)
+		metrics.RPC.Fetch(l.WithResult(metrics.ErrParse)).Inc()
+		return cppki.SignedDenyList{}, serrors.WrapStr("parse deny list reply", err)
+	}
+	logger.Debug("[trust:Resolver] Received deny list from remote", "isd", isd,
+		"serial", dl.DenyList.Serial)
+	if dl.DenyList.ISD != isd || (!serial.IsLatest() && dl.DenyList.Serial != serial) {
+		err := serrors.New("received wrong deny list",
+			"expected_isd", isd, "expected_serial", serial,
+			"actual_isd", dl.DenyList.ISD, "actual_serial", dl.DenyList.Serial)
+		tracing.Error(span, err)
+		metrics.RPC.Fetch(l.WithResult(metrics.ErrMismatch)).Inc()
+		return cppki.SignedDenyList{}, err
+	}
+	metrics.RPC.Fetch(l.WithResult(metrics.Success)).Inc()
+	return dl, nil
+}
+
 func checkChainsMatchQuery(query ChainQuery, chains [][]*x509.Certificate) error {
 	for i, chain := range chains {
 		ia, err := cppki.ExtractIA(chain[0].Subject)
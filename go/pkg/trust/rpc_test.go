@@ -25,7 +25,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -219,3 +221,105 @@ func TestFetcherTRC(t *testing.T) {
 		})
 	}
 }
+
+func TestFetcherDenyList(t *testing.T) {
+	if *update {
+		t.Skip("test crypto is being updated")
+	}
+	dl := signDenyList(t, 2)
+
+	testCases := map[string]struct {
+		RPC       func(t *testing.T, mctrl *gomock.Controller) *mock_trust.MockRPC
+		ISD       addr.ISD
+		Serial    scrypto.Version
+		AssertErr assert.ErrorAssertionFunc
+		Expected  cppki.SignedDenyList
+	}{
+		"RPC fail": {
+			RPC: func(t *testing.T, mctrl *gomock.Controller) *mock_trust.MockRPC {
+				r := mock_trust.NewMockRPC(mctrl)
+				r.EXPECT().GetDenyList(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).Return(nil, serrors.New("internal"))
+				return r
+			},
+			ISD:       1,
+			Serial:    2,
+			AssertErr: assert.Error,
+		},
+		"garbage deny list": {
+			RPC: func(t *testing.T, mctrl *gomock.Controller) *mock_trust.MockRPC {
+				r := mock_trust.NewMockRPC(mctrl)
+				r.EXPECT().GetDenyList(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).Return(&cert_mgmt.DenyList{RawDenyList: []byte("garbage")}, nil)
+				return r
+			},
+			ISD:       1,
+			Serial:    2,
+			AssertErr: assert.Error,
+		},
+		"mismatching ISD": {
+			RPC: func(t *testing.T, mctrl *gomock.Controller) *mock_trust.MockRPC {
+				r := mock_trust.NewMockRPC(mctrl)
+				r.EXPECT().GetDenyList(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).Return(&cert_mgmt.DenyList{RawDenyList: dl.Raw}, nil)
+				return r
+			},
+			ISD:       2,
+			Serial:    2,
+			AssertErr: assert.Error,
+		},
+		"mismatching serial": {
+			RPC: func(t *testing.T, mctrl *gomock.Controller) *mock_trust.MockRPC {
+				r := mock_trust.NewMockRPC(mctrl)
+				r.EXPECT().GetDenyList(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).Return(&cert_mgmt.DenyList{RawDenyList: dl.Raw}, nil)
+				return r
+			},
+			ISD:       1,
+			Serial:    1,
+			AssertErr: assert.Error,
+		},
+		"valid deny list": {
+			RPC: func(t *testing.T, mctrl *gomock.Controller) *mock_trust.MockRPC {
+				r := mock_trust.NewMockRPC(mctrl)
+				r.EXPECT().GetDenyList(gomock.Any(),
+					&cert_mgmt.DenyListReq{ISD: 1, Serial: 2}, gomock.Any(),
+					gomock.Any()).Return(&cert_mgmt.DenyList{RawDenyList: dl.Raw}, nil)
+				return r
+			},
+			ISD:       1,
+			Serial:    2,
+			AssertErr: assert.NoError,
+			Expected:  dl,
+		},
+		"valid latest deny list": {
+			RPC: func(t *testing.T, mctrl *gomock.Controller) *mock_trust.MockRPC {
+				r := mock_trust.NewMockRPC(mctrl)
+				r.EXPECT().GetDenyList(gomock.Any(),
+					&cert_mgmt.DenyListReq{ISD: 1, Serial: scrypto.LatestVer}, gomock.Any(),
+					gomock.Any()).Return(&cert_mgmt.DenyList{RawDenyList: dl.Raw}, nil)
+				return r
+			},
+			ISD:       1,
+			Serial:    scrypto.LatestVer,
+			AssertErr: assert.NoError,
+			Expected:  dl,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			r := trust.DefaultFetcher{
+				RPC: tc.RPC(t, mctrl),
+			}
+			dl, err := r.DenyList(context.Background(), tc.ISD, tc.Serial, nil)
+			tc.AssertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.Expected, dl)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/rand"
+	"crypto/x509"
 	"fmt"
 	"time"
 
@@ -40,6 +41,8 @@ type Signer struct {
 	TRCID         cppki.TRCID
 	ChainValidity cppki.Validity
 	InGrace       bool
+	// Chain is the certificate chain that authenticates the private key.
+	Chain []*x509.Certificate
 }
 
 // Sign signs the message.
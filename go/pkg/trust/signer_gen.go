@@ -36,12 +36,16 @@ type SignerGen struct {
 	IA      addr.IA
 	KeyRing KeyRing
 	DB      DB // FIXME(roosd): Eventually this should use a crypto provider
+
+	// now returns the current time. If nil, time.Now is used.
+	now func() time.Time
 }
 
 // Generate fetches private keys from the key ring and searches active
 // certificate chains that authenticate the corresponding public key. The
 // returned signer uses the private key which is backed by the certificate chain
-// with the highest expiration time.
+// with the highest expiration time. Certificate chains that are listed on a
+// deny list of the local ISD are not considered.
 func (s SignerGen) Generate(ctx context.Context) (Signer, error) {
 	l := metrics.SignerLabels{}
 	keys, err := s.KeyRing.PrivateKeys(ctx)
@@ -54,16 +58,25 @@ func (s SignerGen) Generate(ctx context.Context) (Signer, error) {
 		return Signer{}, serrors.New("no private key found")
 	}
 
-	trcs, res, err := activeTRCs(ctx, s.DB, s.IA.I)
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+	trcs, res, err := activeTRCs(ctx, s.DB, s.IA.I, now)
 	if err != nil {
 		metrics.Signer.Generate(l.WithResult(res)).Inc()
 		return Signer{}, serrors.WrapStr("loading TRC", err)
 	}
+	dls, err := denyLists(ctx, s.DB, s.IA.I)
+	if err != nil {
+		metrics.Signer.Generate(l.WithResult(metrics.ErrDB)).Inc()
+		return Signer{}, serrors.WrapStr("loading deny lists", err)
+	}
 
 	// Search the private key that has a certificate that expires the latest.
 	var best *Signer
 	for _, key := range keys {
-		signer, err := s.bestForKey(ctx, key, trcs)
+		signer, err := s.bestForKey(ctx, key, trcs, dls, now)
 		if err != nil {
 			metrics.Signer.Generate(l.WithResult(metrics.ErrDB)).Inc()
 			return Signer{}, err
@@ -85,7 +98,7 @@ func (s SignerGen) Generate(ctx context.Context) (Signer, error) {
 }
 
 func (s *SignerGen) bestForKey(ctx context.Context, key crypto.Signer,
-	trcs []cppki.SignedTRC) (*Signer, error) {
+	trcs []cppki.SignedTRC, dls []cppki.SignedDenyList, now time.Time) (*Signer, error) {
 	// FIXME(roosd): We currently take the sha1 sum of the public key.
 	// The final implementation needs to be smarter than that, but this
 	// requires a proper design that also considers certificate renewal.
@@ -96,13 +109,14 @@ func (s *SignerGen) bestForKey(ctx context.Context, key crypto.Signer,
 	chains, err := s.DB.Chains(ctx, ChainQuery{
 		IA:           s.IA,
 		SubjectKeyID: skid,
-		Date:         time.Now(),
+		Date:         now,
 	})
 	if err != nil {
 		// TODO	metrics.Signer.Generate(l.WithResult(metrics.ErrDB)).Inc()
 		return nil, err
 	}
-	chain := bestChain(&trcs[0].TRC, chains)
+	chains = filterDeniedChains(chains, dls)
+	chain := bestChain(&trcs[0].TRC, chains, now)
 	if chain == nil && len(trcs) == 1 {
 		return nil, nil
 	}
@@ -110,7 +124,7 @@ func (s *SignerGen) bestForKey(ctx context.Context, key crypto.Signer,
 	// Attempt to find a chain that is verifiable only in grace period. If we
 	// have not found a chain yet.
 	if chain == nil && len(trcs) == 2 {
-		chain = bestChain(&trcs[1].TRC, chains)
+		chain = bestChain(&trcs[1].TRC, chains, now)
 		if chain == nil {
 			return nil, nil
 		}
@@ -132,11 +146,14 @@ func (s *SignerGen) bestForKey(ctx context.Context, key crypto.Signer,
 			NotAfter:  chain[0].NotAfter,
 		},
 		InGrace: inGrace,
+		Chain:   chain,
 	}, nil
 }
 
-func bestChain(trc *cppki.TRC, chains [][]*x509.Certificate) []*x509.Certificate {
-	opts := cppki.VerifyOptions{TRC: trc}
+func bestChain(trc *cppki.TRC, chains [][]*x509.Certificate,
+	now time.Time) []*x509.Certificate {
+
+	opts := cppki.VerifyOptions{TRC: trc, CurrentTime: now}
 	var best []*x509.Certificate
 	for _, chain := range chains {
 		if err := cppki.VerifyChain(chain, opts); err != nil {
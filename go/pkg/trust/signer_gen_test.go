@@ -23,6 +23,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
+	"math/big"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -33,6 +34,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -54,8 +57,22 @@ func TestSignerGenGenerate(t *testing.T) {
 	trc := xtest.LoadTRC(t, filepath.Join(goldenDir, "ISD1/trcs/ISD1-B1-S1.trc"))
 	key := loadKey(t, filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/cp-as.key"))
 	chain := getChain(t)
+	longer := getChain(t)
+	longer[0].NotAfter = longer[0].NotAfter.Add(time.Hour)
+	longer[0].SubjectKeyId = []byte("longer")
+	denied := getChain(t)
+	denied[0].NotAfter = denied[0].NotAfter.Add(2 * time.Hour)
+	denied[0].SerialNumber = new(big.Int).Add(denied[0].SerialNumber, big.NewInt(1))
+	denied[0].SubjectKeyId = []byte("denied")
+	dl := cppki.SignedDenyList{
+		DenyList: cppki.DenyList{
+			ISD:     1,
+			Serial:  1,
+			Entries: []cppki.DenyListEntry{cppki.NewDenyListEntry(denied[0])},
+		},
+	}
 
-	now := time.Now()
+	now := chain[0].NotBefore.Add(time.Hour)
 
 	testCases := map[string]struct {
 		keyRing    func(mctrcl *gomock.Controller) trust.KeyRing
@@ -81,6 +98,10 @@ func TestSignerGenGenerate(t *testing.T) {
 				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
 					trc, nil,
 				)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().Chains(ctxMatcher{}, matcher).Return(
 					[][]*x509.Certificate{chain}, nil,
 				)
@@ -102,6 +123,7 @@ func TestSignerGenGenerate(t *testing.T) {
 					NotBefore: chain[0].NotBefore,
 					NotAfter:  chain[0].NotAfter,
 				},
+				Chain: chain,
 			},
 		},
 		"select newest": {
@@ -120,10 +142,6 @@ func TestSignerGenGenerate(t *testing.T) {
 					skid: cert.SubjectKeyId,
 				}
 
-				longer := getChain(t)
-				longer[0].NotAfter = longer[0].NotAfter.Add(time.Hour)
-				longer[0].SubjectKeyId = []byte("longer")
-
 				shorter := getChain(t)
 				shorter[0].NotAfter = shorter[0].NotAfter.Add(-time.Hour)
 				shorter[0].SubjectKeyId = []byte("shorter")
@@ -131,6 +149,10 @@ func TestSignerGenGenerate(t *testing.T) {
 				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
 					trc, nil,
 				)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().Chains(gomock.Any(), matcher).Return(
 					[][]*x509.Certificate{chain, longer, shorter}, nil,
 				)
@@ -152,6 +174,7 @@ func TestSignerGenGenerate(t *testing.T) {
 					NotBefore: chain[0].NotBefore,
 					NotAfter:  chain[0].NotAfter.Add(time.Hour),
 				},
+				Chain: longer,
 			},
 		},
 		"select best from grace": {
@@ -170,10 +193,6 @@ func TestSignerGenGenerate(t *testing.T) {
 					skid: cert.SubjectKeyId,
 				}
 
-				longer := getChain(t)
-				longer[0].NotAfter = longer[0].NotAfter.Add(time.Hour)
-				longer[0].SubjectKeyId = []byte("longer")
-
 				shorter := getChain(t)
 				shorter[0].NotAfter = shorter[0].NotAfter.Add(-time.Hour)
 				shorter[0].SubjectKeyId = []byte("shorter")
@@ -196,6 +215,10 @@ func TestSignerGenGenerate(t *testing.T) {
 				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
 					trc, nil,
 				)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().Chains(gomock.Any(), matcher).Return(
 					[][]*x509.Certificate{chain, longer, shorter}, nil,
 				)
@@ -218,6 +241,101 @@ func TestSignerGenGenerate(t *testing.T) {
 					NotAfter:  chain[0].NotAfter.Add(time.Hour),
 				},
 				InGrace: true,
+				Chain:   longer,
+			},
+		},
+		"skip denied": {
+			keyRing: func(mctrl *gomock.Controller) trust.KeyRing {
+				ring := mock_trust.NewMockKeyRing(mctrl)
+				ring.EXPECT().PrivateKeys(gomock.Any()).Return(
+					[]crypto.Signer{key}, nil,
+				)
+				return ring
+			},
+			db: func(mctrl *gomock.Controller) trust.DB {
+				cert := chain[0]
+				db := mock_trust.NewMockDB(mctrl)
+				matcher := chainQueryMatcher{
+					ia:   xtest.MustParseIA("1-ff00:0:110"),
+					skid: cert.SubjectKeyId,
+				}
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
+					trc, nil,
+				)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					dl, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
+				db.EXPECT().Chains(gomock.Any(), matcher).Return(
+					[][]*x509.Certificate{chain, denied}, nil,
+				)
+				return db
+			},
+			assertFunc: assert.NoError,
+			expected: trust.Signer{
+				PrivateKey: key,
+				Hash:       crypto.SHA512,
+				IA:         xtest.MustParseIA("1-ff00:0:110"),
+				TRCID: cppki.TRCID{
+					ISD:    1,
+					Base:   1,
+					Serial: 1,
+				},
+				SubjectKeyID: chain[0].SubjectKeyId,
+				Expiration:   chain[0].NotAfter,
+				ChainValidity: cppki.Validity{
+					NotBefore: chain[0].NotBefore,
+					NotAfter:  chain[0].NotAfter,
+				},
+				Chain: chain,
+			},
+		},
+		"skip denied by CA": {
+			keyRing: func(mctrl *gomock.Controller) trust.KeyRing {
+				ring := mock_trust.NewMockKeyRing(mctrl)
+				ring.EXPECT().PrivateKeys(gomock.Any()).Return(
+					[]crypto.Signer{key}, nil,
+				)
+				return ring
+			},
+			db: func(mctrl *gomock.Controller) trust.DB {
+				cert := chain[0]
+				db := mock_trust.NewMockDB(mctrl)
+				matcher := chainQueryMatcher{
+					ia:   xtest.MustParseIA("1-ff00:0:110"),
+					skid: cert.SubjectKeyId,
+				}
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
+					trc, nil,
+				)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(
+					[]cppki.SignedDenyList{signCADenyList(t, 1, denied[0])}, nil,
+				)
+				db.EXPECT().Chains(gomock.Any(), matcher).Return(
+					[][]*x509.Certificate{chain, denied}, nil,
+				)
+				return db
+			},
+			assertFunc: assert.NoError,
+			expected: trust.Signer{
+				PrivateKey: key,
+				Hash:       crypto.SHA512,
+				IA:         xtest.MustParseIA("1-ff00:0:110"),
+				TRCID: cppki.TRCID{
+					ISD:    1,
+					Base:   1,
+					Serial: 1,
+				},
+				SubjectKeyID: chain[0].SubjectKeyId,
+				Expiration:   chain[0].NotAfter,
+				ChainValidity: cppki.Validity{
+					NotBefore: chain[0].NotBefore,
+					NotAfter:  chain[0].NotAfter,
+				},
+				Chain: chain,
 			},
 		},
 		"no keys": {
@@ -249,6 +367,10 @@ func TestSignerGenGenerate(t *testing.T) {
 				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
 					trc, nil,
 				)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				return db
 			},
 			assertFunc: assert.Error,
@@ -270,6 +392,10 @@ func TestSignerGenGenerate(t *testing.T) {
 				}
 				db.EXPECT().SignedTRC(ctxMatcher{},
 					TRCIDMatcher{ISD: 1}).Return(trc, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().Chains(gomock.Any(), matcher).Return(nil, nil)
 				return db
 			},
@@ -308,6 +434,25 @@ func TestSignerGenGenerate(t *testing.T) {
 			},
 			assertFunc: assert.Error,
 		},
+		"db.DenyList error": {
+			keyRing: func(mctrl *gomock.Controller) trust.KeyRing {
+				ring := mock_trust.NewMockKeyRing(mctrl)
+				ring.EXPECT().PrivateKeys(gomock.Any()).Return(
+					[]crypto.Signer{key}, nil,
+				)
+				return ring
+			},
+			db: func(mctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{},
+					TRCIDMatcher{ISD: 1}).Return(trc, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, serrors.New("fail"),
+				)
+				return db
+			},
+			assertFunc: assert.Error,
+		},
 		"db.Chain error": {
 			keyRing: func(mctrl *gomock.Controller) trust.KeyRing {
 				ring := mock_trust.NewMockKeyRing(mctrl)
@@ -325,6 +470,10 @@ func TestSignerGenGenerate(t *testing.T) {
 				}
 				db.EXPECT().SignedTRC(ctxMatcher{},
 					TRCIDMatcher{ISD: 1}).Return(trc, nil)
+				db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+					cppki.SignedDenyList{}, nil,
+				)
+				db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 				db.EXPECT().Chains(gomock.Any(), matcher).Return(
 					nil, serrors.New("fail"),
 				)
@@ -343,11 +492,11 @@ func TestSignerGenGenerate(t *testing.T) {
 				mctrl := gomock.NewController(t)
 				defer mctrl.Finish()
 
-				gen := trust.SignerGen{
+				gen := trust.SignerGenWithClock(trust.SignerGen{
 					IA:      xtest.MustParseIA("1-ff00:0:110"),
 					DB:      tc.db(mctrl),
 					KeyRing: tc.keyRing(mctrl),
-				}
+				}, func() time.Time { return now })
 				signer, err := gen.Generate(context.Background())
 				tc.assertFunc(t, err)
 				assert.Equal(t, tc.expected, signer)
@@ -362,24 +511,29 @@ func TestSignerGenGenerate(t *testing.T) {
 		ring.EXPECT().PrivateKeys(gomock.Any()).Return([]crypto.Signer{key}, nil)
 		db := mock_trust.NewMockDB(mctrl)
 		db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+		db.EXPECT().DenyList(gomock.Any(), addr.ISD(1), scrypto.LatestVer).Return(
+			cppki.SignedDenyList{}, nil,
+		)
+		db.EXPECT().CADenyLists(gomock.Any(), addr.ISD(1)).Return(nil, nil)
 		db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(
 			[][]*x509.Certificate{chain}, nil,
 		)
-		_, err := trust.SignerGen{
+		gen := trust.SignerGenWithClock(trust.SignerGen{
 			IA:      xtest.MustParseIA("1-ff00:0:110"),
 			DB:      db,
 			KeyRing: ring,
-		}.Generate(context.Background())
+		}, func() time.Time { return now })
+		_, err := gen.Generate(context.Background())
 		require.NoError(t, err)
 
 		s := "trustengine_generated_signers_total"
 		want := fmt.Sprintf(`
 # HELP %s Number of generated signers backed by the trust engine
 # TYPE %s counter
-trustengine_generated_signers_total{result="err_db"} 2
+trustengine_generated_signers_total{result="err_db"} 3
 trustengine_generated_signers_total{result="err_key"} 1
 trustengine_generated_signers_total{result="err_not_found"} 3
-trustengine_generated_signers_total{result="ok_success"} 4
+trustengine_generated_signers_total{result="ok_success"} 6
 `, s, s)
 		err = testutil.CollectAndCompare(metrics.Signer.Signers, strings.NewReader(want))
 		require.NoError(t, err)
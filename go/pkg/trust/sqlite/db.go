@@ -23,7 +23,9 @@ import (
 
 	_ "github.com/mattn/go-sqlite3"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/trust"
@@ -142,6 +144,111 @@ func (e *executor) InsertTRC(ctx context.Context, trc cppki.SignedTRC) (bool, er
 	return inserted, nil
 }
 
+func (e *executor) DenyList(ctx context.Context, isd addr.ISD,
+	serial scrypto.Version) (cppki.SignedDenyList, error) {
+
+	e.RLock()
+	defer e.RUnlock()
+
+	sqlQuery := `SELECT deny_list FROM deny_lists WHERE isd_id=$1 AND length(issuer)=0
+				ORDER BY serial DESC
+				LIMIT 1`
+	args := []interface{}{isd}
+	if !serial.IsLatest() {
+		sqlQuery = `SELECT deny_list FROM deny_lists
+					WHERE isd_id=$1 AND length(issuer)=0 AND serial=$2`
+		args = append(args, serial)
+	}
+	r := e.db.QueryRowContext(ctx, sqlQuery, args...)
+	var rawDenyList []byte
+	err := r.Scan(&rawDenyList)
+	if err == sql.ErrNoRows {
+		return cppki.SignedDenyList{}, nil
+	}
+	if err != nil {
+		return cppki.SignedDenyList{}, serrors.Wrap(db.ErrReadFailed, err)
+	}
+	dl, err := cppki.DecodeSignedDenyList(rawDenyList)
+	if err != nil {
+		return cppki.SignedDenyList{}, serrors.Wrap(db.ErrDataInvalid, err)
+	}
+	return dl, nil
+}
+
+func (e *executor) CADenyLists(ctx context.Context,
+	isd addr.ISD) ([]cppki.SignedDenyList, error) {
+
+	e.RLock()
+	defer e.RUnlock()
+
+	sqlQuery := `SELECT deny_list FROM deny_lists AS d
+				WHERE isd_id=$1 AND length(issuer)>0 AND serial=(
+					SELECT MAX(serial) FROM deny_lists
+					WHERE isd_id=d.isd_id AND issuer=d.issuer
+				)`
+	rows, err := e.db.QueryContext(ctx, sqlQuery, isd)
+	if err != nil {
+		return nil, serrors.Wrap(db.ErrReadFailed, err)
+	}
+	defer rows.Close()
+	var dls []cppki.SignedDenyList
+	for rows.Next() {
+		var rawDenyList []byte
+		if err := rows.Scan(&rawDenyList); err != nil {
+			return nil, serrors.Wrap(db.ErrReadFailed, err)
+		}
+		dl, err := cppki.DecodeSignedDenyList(rawDenyList)
+		if err != nil {
+			return nil, serrors.Wrap(db.ErrDataInvalid, err)
+		}
+		dls = append(dls, dl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, serrors.Wrap(db.ErrReadFailed, err)
+	}
+	return dls, nil
+}
+
+func (e *executor) InsertDenyList(ctx context.Context, dl cppki.SignedDenyList) (bool, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	sqlQuery := `INSERT INTO deny_lists (isd_id, issuer, serial, fingerprint, deny_list)
+				 SELECT $1, $2, $3, $4, $5 WHERE NOT EXISTS (
+					 SELECT 1 FROM deny_lists
+					 WHERE isd_id=$1 AND issuer=$2 AND serial=$3 AND fingerprint=$4
+				 )`
+	// Deny lists issued by the voters of the ISD are stored with an empty
+	// issuer, deny lists issued by a CA with the subject of the CA.
+	issuer := []byte{}
+	if dl.CA != nil {
+		issuer = dl.CA.RawSubject
+	}
+	var inserted bool
+	err := db.DoInTx(ctx, e.db, func(ctx context.Context, tx *sql.Tx) error {
+		r, err := tx.ExecContext(ctx, sqlQuery,
+			dl.DenyList.ISD,
+			issuer,
+			dl.DenyList.Serial,
+			denyListFingerprint(dl),
+			dl.Raw,
+		)
+		if err != nil {
+			return serrors.Wrap(db.ErrWriteFailed, err)
+		}
+		ar, err := r.RowsAffected()
+		if err != nil {
+			return serrors.Wrap(db.ErrWriteFailed, err)
+		}
+		inserted = ar > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
+
 func (e *executor) Chains(ctx context.Context,
 	query trust.ChainQuery) ([][]*x509.Certificate, error) {
 
@@ -232,3 +339,9 @@ func trcFingerprint(trc cppki.SignedTRC) []byte {
 	h.Write(trc.TRC.Raw)
 	return h.Sum(nil)
 }
+
+func denyListFingerprint(dl cppki.SignedDenyList) []byte {
+	h := sha256.New()
+	h.Write(dl.DenyList.Raw)
+	return h.Sum(nil)
+}
@@ -18,7 +18,7 @@ const (
 	// SchemaVersion is the version of the SQLite schema understood by this backend.
 	// Whenever changes to the schema are made, this version number should be increased
 	// to prevent data corruption between incompatible database schemas.
-	SchemaVersion = 1
+	SchemaVersion = 2
 	// Schema is the SQLite database layout.
 	Schema = `
 	CREATE TABLE chains(
@@ -40,5 +40,13 @@ const (
 		trc DATA NOT NULL,
 		PRIMARY KEY (isd_id, base, serial)
 	);
+	CREATE TABLE deny_lists(
+		isd_id INTEGER NOT NULL,
+		issuer DATA NOT NULL,
+		serial INTEGER NOT NULL,
+		fingerprint DATA NOT NULL,
+		deny_list DATA NOT NULL,
+		PRIMARY KEY (isd_id, issuer, serial)
+	);
 	`
 )
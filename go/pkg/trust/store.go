@@ -139,3 +139,67 @@ func LoadTRCs(ctx context.Context, dir string, db DB) (LoadResult, error) {
 	}
 	return res, nil
 }
+
+// LoadDenyLists loads all *.dl files located in a directory in the database
+// after verifying first that each one is a signed deny list that is verifiable
+// with the latest TRC of its ISD. All *.dl files that are not valid deny lists
+// are ignored. Deny lists issued by a CA are checked against the deny list
+// issued by the voters that is already in the database.
+func LoadDenyLists(ctx context.Context, dir string, db DB) (LoadResult, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return LoadResult{}, serrors.WithCtx(err, "dir", dir)
+	}
+
+	files, err := filepath.Glob(fmt.Sprintf("%s/*.dl", dir))
+	if err != nil {
+		return LoadResult{}, serrors.WithCtx(err, "dir", dir)
+	}
+
+	res := LoadResult{Ignored: map[string]error{}}
+	for _, f := range files {
+		raw, err := ioutil.ReadFile(f)
+		if err != nil {
+			return res, serrors.WithCtx(err, "file", f)
+		}
+		dl, err := cppki.DecodeSignedDenyList(raw)
+		if err != nil {
+			res.Ignored[f] = err
+			continue
+		}
+		tid := cppki.TRCID{
+			ISD:    dl.DenyList.ISD,
+			Serial: scrypto.LatestVer,
+			Base:   scrypto.LatestVer,
+		}
+		trc, err := db.SignedTRC(ctx, tid)
+		if err != nil {
+			return res, serrors.WithCtx(err, "file", f)
+		}
+		if trc.IsZero() {
+			res.Ignored[f] = serrors.New("TRC not found", "isd", dl.DenyList.ISD)
+			continue
+		}
+		opts := cppki.DenyListVerifyOptions{TRC: &trc.TRC}
+		if dl.CA != nil {
+			isdDenyList, err := db.DenyList(ctx, dl.DenyList.ISD, scrypto.LatestVer)
+			if err != nil {
+				return res, serrors.WithCtx(err, "file", f)
+			}
+			opts.ISDDenyList = &isdDenyList.DenyList
+		}
+		if err := dl.Verify(opts); err != nil {
+			res.Ignored[f] = err
+			continue
+		}
+		inserted, err := db.InsertDenyList(ctx, dl)
+		if err != nil {
+			return res, serrors.WithCtx(err, "file", f)
+		}
+		if !inserted {
+			res.Ignored[f] = serrors.Wrap(ErrAlreadyExists, err)
+			continue
+		}
+		res.Loaded = append(res.Loaded, f)
+	}
+	return res, nil
+}
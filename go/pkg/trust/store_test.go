@@ -17,12 +17,15 @@ package trust_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"sort"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -227,3 +230,134 @@ func TestLoadTRCs(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadDenyLists(t *testing.T) {
+	if *update {
+		t.Skip("test crypto is being updated")
+	}
+
+	trc := xtest.LoadTRC(t, filepath.Join(goldenDir, "ISD1/trcs/ISD1-B1-S1.trc"))
+	chain := xtest.LoadChain(t,
+		filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/ISD1-ASff00_0_110.pem"))
+	dl := signDenyList(t, 1, chain[0])
+	forged, err := cppki.SignDenyList(dl.DenyList,
+		loadKey(t, filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/cp-as.key")),
+		chain[0],
+	)
+	require.NoError(t, err)
+
+	dir, cleanF := xtest.MustTempDir("", "trust_deny_lists")
+	defer cleanF()
+	validDir := filepath.Join(dir, "valid")
+	invalidDir := filepath.Join(dir, "invalid")
+	require.NoError(t, os.MkdirAll(validDir, 0755))
+	require.NoError(t, os.MkdirAll(invalidDir, 0755))
+	valid := filepath.Join(validDir, "ISD1-S1.dl")
+	garbage := filepath.Join(invalidDir, "garbage.dl")
+	forgedFile := filepath.Join(invalidDir, "forged.dl")
+	require.NoError(t, ioutil.WriteFile(valid, dl.Raw, 0644))
+	require.NoError(t, ioutil.WriteFile(garbage, []byte("garbage"), 0644))
+	require.NoError(t, ioutil.WriteFile(forgedFile, forged.Raw, 0644))
+
+	testCases := map[string]struct {
+		inputDir   string
+		setupDB    func(mctrl *gomock.Controller) trust.DB
+		assertFunc assert.ErrorAssertionFunc
+		ignored    []string
+		loaded     []string
+	}{
+		"valid": {
+			inputDir: validDir,
+			setupDB: func(mctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(trc, nil)
+				db.EXPECT().InsertDenyList(ctxMatcher{}, gomock.Any()).Return(true, nil)
+				return db
+			},
+			assertFunc: assert.NoError,
+			loaded:     []string{valid},
+		},
+		"already exists": {
+			inputDir: validDir,
+			setupDB: func(mctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(trc, nil)
+				db.EXPECT().InsertDenyList(ctxMatcher{}, gomock.Any()).Return(false, nil)
+				return db
+			},
+			assertFunc: assert.NoError,
+			ignored:    []string{valid},
+		},
+		"TRC not found": {
+			inputDir: validDir,
+			setupDB: func(mctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
+					cppki.SignedTRC{}, nil,
+				)
+				return db
+			},
+			assertFunc: assert.NoError,
+			ignored:    []string{valid},
+		},
+		"invalid deny lists": {
+			inputDir: invalidDir,
+			setupDB: func(mctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(trc, nil)
+				return db
+			},
+			assertFunc: assert.NoError,
+			ignored:    []string{forgedFile, garbage},
+		},
+		"invalid dir": {
+			inputDir: "./path/to/nowhere",
+			setupDB: func(mctrl *gomock.Controller) trust.DB {
+				return mock_trust.NewMockDB(mctrl)
+			},
+			assertFunc: assert.Error,
+		},
+		"db.SignedTRC error": {
+			inputDir: validDir,
+			setupDB: func(mctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(
+					cppki.SignedTRC{}, serrors.New("db failed"),
+				)
+				return db
+			},
+			assertFunc: assert.Error,
+		},
+		"db.InsertDenyList error": {
+			inputDir: validDir,
+			setupDB: func(mctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(ctxMatcher{}, TRCIDMatcher{ISD: 1}).Return(trc, nil)
+				db.EXPECT().InsertDenyList(ctxMatcher{}, gomock.Any()).Return(
+					false, fmt.Errorf("db failed"),
+				)
+				return db
+			},
+			assertFunc: assert.Error,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			res, err := trust.LoadDenyLists(context.Background(), tc.inputDir,
+				tc.setupDB(mctrl))
+			tc.assertFunc(t, err)
+			assert.Equal(t, tc.loaded, res.Loaded)
+
+			var ignored []string
+			for f := range res.Ignored {
+				ignored = append(ignored, f)
+			}
+			sort.Strings(ignored)
+			assert.Equal(t, tc.ignored, ignored)
+		})
+	}
+}
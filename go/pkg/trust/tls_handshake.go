@@ -0,0 +1,211 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultTLSHandshakeTimeout is the default timeout for generating the local
+// credentials and looking up the TRCs during a TLS handshake.
+const DefaultTLSHandshakeTimeout = 5 * time.Second
+
+// SignerGenerator generates signers.
+type SignerGenerator interface {
+	Generate(ctx context.Context) (Signer, error)
+}
+
+// TLSCryptoManager authenticates TLS handshakes with the control-plane PKI.
+// The local AS presents the certificate chain of its signer, the peer
+// certificate chains are verified against the active TRCs of the peer's ISD.
+// Peer certificate chains that are listed on a deny list of the peer's ISD are
+// rejected.
+type TLSCryptoManager struct {
+	// Signer generates the signer whose private key and certificate chain are
+	// used as TLS credentials.
+	Signer SignerGenerator
+	// Verifier provides the TRCs and deny lists that peer certificate chains
+	// are verified against. They are requested from the verifier's engine,
+	// using the bound server, if any, for network resolution.
+	Verifier Verifier
+	// Timeout is the timeout for generating signers and looking up TRCs. If
+	// zero, DefaultTLSHandshakeTimeout is used.
+	Timeout time.Duration
+}
+
+// GetCertificate returns the certificate of the local AS for the server side
+// of the handshake.
+func (m *TLSCryptoManager) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return m.certificate()
+}
+
+// GetClientCertificate returns the certificate of the local AS for the client
+// side of the handshake.
+func (m *TLSCryptoManager) GetClientCertificate(
+	_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+
+	return m.certificate()
+}
+
+// VerifyPeerCertificate verifies the certificate chain presented by the peer.
+// The chain must be verifiable with an active TRC of the ISD of the AS
+// certificate, and it must not be denied. If ia is not zero, the AS
+// certificate must be issued for ia.
+func (m *TLSCryptoManager) VerifyPeerCertificate(rawCerts [][]byte, ia addr.IA) error {
+	chain := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return serrors.WrapStr("parsing peer certificate", err)
+		}
+		chain = append(chain, cert)
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), m.timeout())
+	defer cancelF()
+	return m.verifyChain(ctx, chain, ia, time.Now())
+}
+
+func (m *TLSCryptoManager) certificate() (*tls.Certificate, error) {
+	if m.Signer == nil {
+		return nil, serrors.New("no signer generator configured")
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), m.timeout())
+	defer cancelF()
+	signer, err := m.Signer.Generate(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("generating signer", err)
+	}
+	if len(signer.Chain) == 0 {
+		return nil, serrors.New("signer without certificate chain",
+			"subject_key_id", signer.SubjectKeyID)
+	}
+	cert := &tls.Certificate{
+		PrivateKey: signer.PrivateKey,
+		Leaf:       signer.Chain[0],
+	}
+	for _, c := range signer.Chain {
+		cert.Certificate = append(cert.Certificate, c.Raw)
+	}
+	return cert, nil
+}
+
+func (m *TLSCryptoManager) verifyChain(ctx context.Context, chain []*x509.Certificate,
+	ia addr.IA, now time.Time) error {
+
+	if err := cppki.ValidateChain(chain); err != nil {
+		return serrors.WrapStr("validating peer certificate chain", err)
+	}
+	certIA, err := cppki.ExtractIA(chain[0].Subject)
+	if err != nil {
+		return serrors.WrapStr("extracting ISD-AS from peer certificate", err)
+	}
+	if !ia.IsZero() && !ia.Equal(*certIA) {
+		return serrors.New("peer certificate does not match peer ISD-AS",
+			"expected", ia, "actual", *certIA)
+	}
+	trcs, err := m.activeTRCs(ctx, certIA.I, now)
+	if err != nil {
+		return serrors.WrapStr("loading TRC", err, "isd", certIA.I)
+	}
+	verified := filterVerifiableChains([][]*x509.Certificate{chain}, trcs, now)
+	if len(verified) == 0 {
+		return serrors.New("peer certificate chain not verifiable with active TRCs",
+			"isd_as", *certIA)
+	}
+	dls, err := m.denyLists(ctx, certIA.I)
+	if err != nil {
+		return serrors.WrapStr("loading deny lists", err, "isd", certIA.I)
+	}
+	if deniedChain(chain, dls) {
+		return serrors.New("peer certificate chain is denied", "isd_as", *certIA)
+	}
+	return nil
+}
+
+// denyLists returns the deny lists of the ISD, i.e., the latest deny list
+// issued by the voters, if any, and the latest deny list of every CA.
+func (m *TLSCryptoManager) denyLists(ctx context.Context,
+	isd addr.ISD) ([]cppki.SignedDenyList, error) {
+
+	opts := []Option{Server(m.Verifier.BoundServer)}
+	dl, err := m.Verifier.Engine.GetDenyList(ctx, isd, scrypto.LatestVer, opts...)
+	if err != nil {
+		return nil, err
+	}
+	dls, err := m.Verifier.Engine.GetCADenyLists(ctx, isd, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if dl.IsZero() {
+		return dls, nil
+	}
+	return append(dls, dl), nil
+}
+
+// activeTRCs returns the active TRCs of the ISD. The first TRC is the latest
+// one, the second one is its predecessor, if the latest TRC is still in the
+// grace period.
+func (m *TLSCryptoManager) activeTRCs(ctx context.Context, isd addr.ISD,
+	now time.Time) ([]cppki.SignedTRC, error) {
+
+	if m.Verifier.Engine == nil {
+		return nil, serrors.New("nil engine that provides TRCs")
+	}
+	opts := []Option{Server(m.Verifier.BoundServer)}
+	trc, err := m.Verifier.Engine.GetSignedTRC(ctx, cppki.TRCID{
+		ISD:    isd,
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if trc.IsZero() {
+		return nil, errNotFound
+	}
+	if !trc.TRC.Validity.Contains(now) {
+		return nil, errInactive
+	}
+	if !trc.TRC.InGracePeriod(now) {
+		return []cppki.SignedTRC{trc}, nil
+	}
+	grace, err := m.Verifier.Engine.GetSignedTRC(ctx, cppki.TRCID{
+		ISD:    isd,
+		Base:   trc.TRC.ID.Base,
+		Serial: trc.TRC.ID.Serial - 1,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if grace.IsZero() {
+		return nil, errNotFound
+	}
+	return []cppki.SignedTRC{trc, grace}, nil
+}
+
+func (m *TLSCryptoManager) timeout() time.Duration {
+	if m.Timeout == 0 {
+		return DefaultTLSHandshakeTimeout
+	}
+	return m.Timeout
+}
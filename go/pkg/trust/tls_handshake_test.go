@@ -0,0 +1,284 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/squic"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var _ squic.CryptoManager = (*TLSCryptoManager)(nil)
+
+func TestTLSCryptoManagerGetCertificate(t *testing.T) {
+	chain := xtest.LoadChain(t, filepath.Join("testdata", "common",
+		"ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"))
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		signer       SignerGenerator
+		assertErr    assert.ErrorAssertionFunc
+		expectedCert [][]byte
+	}{
+		"valid": {
+			signer:       staticSignerGen{signer: Signer{PrivateKey: key, Chain: chain}},
+			assertErr:    assert.NoError,
+			expectedCert: [][]byte{chain[0].Raw, chain[1].Raw},
+		},
+		"generator error": {
+			signer:    staticSignerGen{err: serrors.New("internal")},
+			assertErr: assert.Error,
+		},
+		"no chain": {
+			signer:    staticSignerGen{signer: Signer{PrivateKey: key}},
+			assertErr: assert.Error,
+		},
+		"no generator": {
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mgr := &TLSCryptoManager{Signer: tc.signer}
+			for _, get := range []func() (*tls.Certificate, error){
+				func() (*tls.Certificate, error) { return mgr.GetCertificate(nil) },
+				func() (*tls.Certificate, error) { return mgr.GetClientCertificate(nil) },
+			} {
+				cert, err := get()
+				tc.assertErr(t, err)
+				if err != nil {
+					continue
+				}
+				assert.Equal(t, tc.expectedCert, cert.Certificate)
+				assert.Equal(t, key, cert.PrivateKey)
+				assert.Equal(t, chain[0], cert.Leaf)
+			}
+		})
+	}
+}
+
+func TestTLSCryptoManagerVerifyChain(t *testing.T) {
+	chain := xtest.LoadChain(t, filepath.Join("testdata", "common",
+		"ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"))
+	loadTRC := func(t *testing.T) cppki.SignedTRC {
+		return xtest.LoadTRC(t, filepath.Join("testdata", "common", "ISD1/trcs/ISD1-B1-S1.trc"))
+	}
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	now := chain[0].NotBefore.Add(time.Hour)
+	deny := func(certs ...*x509.Certificate) cppki.SignedDenyList {
+		dl := cppki.SignedDenyList{DenyList: cppki.DenyList{ISD: 1, Serial: 1, IssuedAt: now}}
+		for _, cert := range certs {
+			dl.DenyList.Entries = append(dl.DenyList.Entries, cppki.NewDenyListEntry(cert))
+		}
+		return dl
+	}
+
+	testCases := map[string]struct {
+		trcs        map[cppki.TRCID]cppki.SignedTRC
+		denyList    cppki.SignedDenyList
+		caDenyLists []cppki.SignedDenyList
+		err         error
+		ia          addr.IA
+		now         time.Time
+		assertErr   assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			trcs:      map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			ia:        ia111,
+			now:       now,
+			assertErr: assert.NoError,
+		},
+		"valid any IA": {
+			trcs:      map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			now:       now,
+			assertErr: assert.NoError,
+		},
+		"valid with deny lists": {
+			trcs:        map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			denyList:    deny(),
+			caDenyLists: []cppki.SignedDenyList{deny()},
+			ia:          ia111,
+			now:         now,
+			assertErr:   assert.NoError,
+		},
+		"denied AS certificate": {
+			trcs:      map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			denyList:  deny(chain[0]),
+			ia:        ia111,
+			now:       now,
+			assertErr: assert.Error,
+		},
+		"denied CA certificate": {
+			trcs:      map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			denyList:  deny(chain[1]),
+			ia:        ia111,
+			now:       now,
+			assertErr: assert.Error,
+		},
+		"denied by CA": {
+			trcs:        map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			caDenyLists: []cppki.SignedDenyList{deny(), deny(chain[0])},
+			ia:          ia111,
+			now:         now,
+			assertErr:   assert.Error,
+		},
+		"IA mismatch": {
+			trcs:      map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			ia:        xtest.MustParseIA("1-ff00:0:110"),
+			now:       now,
+			assertErr: assert.Error,
+		},
+		"TRC not found": {
+			ia:        ia111,
+			now:       now,
+			assertErr: assert.Error,
+		},
+		"engine error": {
+			err:       serrors.New("internal"),
+			ia:        ia111,
+			now:       now,
+			assertErr: assert.Error,
+		},
+		"inactive TRC": {
+			trcs:      map[cppki.TRCID]cppki.SignedTRC{latest(1): loadTRC(t)},
+			ia:        ia111,
+			now:       loadTRC(t).TRC.Validity.NotAfter.Add(time.Second),
+			assertErr: assert.Error,
+		},
+		"unverifiable chain": {
+			trcs: map[cppki.TRCID]cppki.SignedTRC{latest(1): func() cppki.SignedTRC {
+				trc := loadTRC(t)
+				roots, err := trc.TRC.RootCerts()
+				require.NoError(t, err)
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				require.NoError(t, err)
+				for _, root := range roots {
+					root.PublicKey = key.Public()
+				}
+				return trc
+			}()},
+			ia:        ia111,
+			now:       now,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mgr := &TLSCryptoManager{
+				Verifier: Verifier{Engine: trcProvider{
+					trcs:        tc.trcs,
+					denyList:    tc.denyList,
+					caDenyLists: tc.caDenyLists,
+					err:         tc.err,
+				}},
+			}
+			ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+			defer cancelF()
+			err := mgr.verifyChain(ctx, chain, tc.ia, tc.now)
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestTLSCryptoManagerVerifyPeerCertificate(t *testing.T) {
+	chain := xtest.LoadChain(t, filepath.Join("testdata", "common",
+		"ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"))
+	mgr := &TLSCryptoManager{Verifier: Verifier{Engine: trcProvider{}}}
+
+	err := mgr.VerifyPeerCertificate([][]byte{[]byte("garbage")}, addr.IA{})
+	assert.Error(t, err)
+	err = mgr.VerifyPeerCertificate([][]byte{chain[0].Raw}, addr.IA{})
+	assert.Error(t, err)
+	err = mgr.VerifyPeerCertificate([][]byte{chain[0].Raw, chain[1].Raw},
+		xtest.MustParseIA("1-ff00:0:110"))
+	assert.Error(t, err)
+}
+
+func latest(isd addr.ISD) cppki.TRCID {
+	return cppki.TRCID{ISD: isd, Base: scrypto.LatestVer, Serial: scrypto.LatestVer}
+}
+
+type staticSignerGen struct {
+	signer Signer
+	err    error
+}
+
+func (g staticSignerGen) Generate(context.Context) (Signer, error) {
+	return g.signer, g.err
+}
+
+// trcProvider serves the TRCs from a map. Missing TRCs are returned as zero
+// values. The deny lists are served regardless of the requested ISD.
+type trcProvider struct {
+	trcs        map[cppki.TRCID]cppki.SignedTRC
+	denyList    cppki.SignedDenyList
+	caDenyLists []cppki.SignedDenyList
+	err         error
+}
+
+func (p trcProvider) NotifyTRC(context.Context, cppki.TRCID, ...Option) error {
+	return p.err
+}
+
+func (p trcProvider) GetChains(context.Context, ChainQuery,
+	...Option) ([][]*x509.Certificate, error) {
+
+	return nil, p.err
+}
+
+func (p trcProvider) GetSignedTRC(_ context.Context, id cppki.TRCID,
+	_ ...Option) (cppki.SignedTRC, error) {
+
+	return p.trcs[id], p.err
+}
+
+func (p trcProvider) NotifyDenyList(context.Context, addr.ISD, scrypto.Version,
+	...Option) error {
+
+	return p.err
+}
+
+func (p trcProvider) GetDenyList(context.Context, addr.ISD, scrypto.Version,
+	...Option) (cppki.SignedDenyList, error) {
+
+	return p.denyList, p.err
+}
+
+func (p trcProvider) GetCADenyLists(context.Context, addr.ISD,
+	...Option) ([]cppki.SignedDenyList, error) {
+
+	return p.caDenyLists, p.err
+}
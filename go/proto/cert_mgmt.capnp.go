@@ -502,6 +502,138 @@ func (p TRC_Promise) Struct() (TRC, error) {
 	return TRC{s}, err
 }
 
+type DenyListReq struct{ capnp.Struct }
+
+// DenyListReq_TypeID is the unique identifier for the type DenyListReq.
+const DenyListReq_TypeID = 0xd39db53690ece7d8
+
+func NewDenyListReq(s *capnp.Segment) (DenyListReq, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return DenyListReq{st}, err
+}
+
+func NewRootDenyListReq(s *capnp.Segment) (DenyListReq, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return DenyListReq{st}, err
+}
+
+func ReadRootDenyListReq(msg *capnp.Message) (DenyListReq, error) {
+	root, err := msg.RootPtr()
+	return DenyListReq{root.Struct()}, err
+}
+
+func (s DenyListReq) String() string {
+	str, _ := text.Marshal(0xd39db53690ece7d8, s.Struct)
+	return str
+}
+
+func (s DenyListReq) Isd() uint16 {
+	return s.Struct.Uint16(0)
+}
+
+func (s DenyListReq) SetIsd(v uint16) {
+	s.Struct.SetUint16(0, v)
+}
+
+func (s DenyListReq) Serial() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s DenyListReq) SetSerial(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+// DenyListReq_List is a list of DenyListReq.
+type DenyListReq_List struct{ capnp.List }
+
+// NewDenyListReq creates a new list of DenyListReq.
+func NewDenyListReq_List(s *capnp.Segment, sz int32) (DenyListReq_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return DenyListReq_List{l}, err
+}
+
+func (s DenyListReq_List) At(i int) DenyListReq { return DenyListReq{s.List.Struct(i)} }
+
+func (s DenyListReq_List) Set(i int, v DenyListReq) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DenyListReq_List) String() string {
+	str, _ := text.MarshalList(0xd39db53690ece7d8, s.List)
+	return str
+}
+
+// DenyListReq_Promise is a wrapper for a DenyListReq promised by a client call.
+type DenyListReq_Promise struct{ *capnp.Pipeline }
+
+func (p DenyListReq_Promise) Struct() (DenyListReq, error) {
+	s, err := p.Pipeline.Struct()
+	return DenyListReq{s}, err
+}
+
+type DenyList struct{ capnp.Struct }
+
+// DenyList_TypeID is the unique identifier for the type DenyList.
+const DenyList_TypeID = 0xc6ad8f5d019b2359
+
+func NewDenyList(s *capnp.Segment) (DenyList, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return DenyList{st}, err
+}
+
+func NewRootDenyList(s *capnp.Segment) (DenyList, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return DenyList{st}, err
+}
+
+func ReadRootDenyList(msg *capnp.Message) (DenyList, error) {
+	root, err := msg.RootPtr()
+	return DenyList{root.Struct()}, err
+}
+
+func (s DenyList) String() string {
+	str, _ := text.Marshal(0xc6ad8f5d019b2359, s.Struct)
+	return str
+}
+
+func (s DenyList) DenyList() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s DenyList) HasDenyList() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DenyList) SetDenyList(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+// DenyList_List is a list of DenyList.
+type DenyList_List struct{ capnp.List }
+
+// NewDenyList creates a new list of DenyList.
+func NewDenyList_List(s *capnp.Segment, sz int32) (DenyList_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return DenyList_List{l}, err
+}
+
+func (s DenyList_List) At(i int) DenyList { return DenyList{s.List.Struct(i)} }
+
+func (s DenyList_List) Set(i int, v DenyList) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DenyList_List) String() string {
+	str, _ := text.MarshalList(0xc6ad8f5d019b2359, s.List)
+	return str
+}
+
+// DenyList_Promise is a wrapper for a DenyList promised by a client call.
+type DenyList_Promise struct{ *capnp.Pipeline }
+
+func (p DenyList_Promise) Struct() (DenyList, error) {
+	s, err := p.Pipeline.Struct()
+	return DenyList{s}, err
+}
+
 type CertMgmt struct{ capnp.Struct }
 type CertMgmt_Which uint16
 
@@ -513,10 +645,12 @@ const (
 	CertMgmt_Which_trc                     CertMgmt_Which = 4
 	CertMgmt_Which_certChainRenewalRequest CertMgmt_Which = 5
 	CertMgmt_Which_certChainRenewalReply   CertMgmt_Which = 6
+	CertMgmt_Which_denyListReq             CertMgmt_Which = 7
+	CertMgmt_Which_denyList                CertMgmt_Which = 8
 )
 
 func (w CertMgmt_Which) String() string {
-	const s = "unsetcertChainReqcertChaintrcReqtrccertChainRenewalRequestcertChainRenewalReply"
+	const s = "unsetcertChainReqcertChaintrcReqtrccertChainRenewalRequestcertChainRenewalReplydenyListReqdenyList"
 	switch w {
 	case CertMgmt_Which_unset:
 		return s[0:5]
@@ -532,6 +666,10 @@ func (w CertMgmt_Which) String() string {
 		return s[35:58]
 	case CertMgmt_Which_certChainRenewalReply:
 		return s[58:79]
+	case CertMgmt_Which_denyListReq:
+		return s[79:90]
+	case CertMgmt_Which_denyList:
+		return s[90:98]
 
 	}
 	return "CertMgmt_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -766,6 +904,72 @@ func (s CertMgmt) NewCertChainRenewalReply() (CertChainRenewalReply, error) {
 	return ss, err
 }
 
+func (s CertMgmt) DenyListReq() (DenyListReq, error) {
+	if s.Struct.Uint16(0) != 7 {
+		panic("Which() != denyListReq")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DenyListReq{Struct: p.Struct()}, err
+}
+
+func (s CertMgmt) HasDenyListReq() bool {
+	if s.Struct.Uint16(0) != 7 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s CertMgmt) SetDenyListReq(v DenyListReq) error {
+	s.Struct.SetUint16(0, 7)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDenyListReq sets the denyListReq field to a newly
+// allocated DenyListReq struct, preferring placement in s's segment.
+func (s CertMgmt) NewDenyListReq() (DenyListReq, error) {
+	s.Struct.SetUint16(0, 7)
+	ss, err := NewDenyListReq(s.Struct.Segment())
+	if err != nil {
+		return DenyListReq{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s CertMgmt) DenyList() (DenyList, error) {
+	if s.Struct.Uint16(0) != 8 {
+		panic("Which() != denyList")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DenyList{Struct: p.Struct()}, err
+}
+
+func (s CertMgmt) HasDenyList() bool {
+	if s.Struct.Uint16(0) != 8 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s CertMgmt) SetDenyList(v DenyList) error {
+	s.Struct.SetUint16(0, 8)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDenyList sets the denyList field to a newly
+// allocated DenyList struct, preferring placement in s's segment.
+func (s CertMgmt) NewDenyList() (DenyList, error) {
+	s.Struct.SetUint16(0, 8)
+	ss, err := NewDenyList(s.Struct.Segment())
+	if err != nil {
+		return DenyList{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // CertMgmt_List is a list of CertMgmt.
 type CertMgmt_List struct{ capnp.List }
 
@@ -816,53 +1020,70 @@ func (p CertMgmt_Promise) CertChainRenewalReply() CertChainRenewalReply_Promise
 	return CertChainRenewalReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-const schema_ec3b2b10a5e23975 = "x\xda\x84\x92]H4U\x1c\xc6\xff\xcf9\xb3;*" +
-	"\x8e;\xc3,D`,\x8aE\x1a\x86\x1f\xdd\xb8]\xac" +
-	"\xb5\x0aInx\xa6\xbd\x08\x82b\xdc\x1dt\xc5\xdd\xd6" +
-	"\x9dY\xc4.\x84 \xba\xad\x0bo\x0a\xba\xa8\xf0R\x8a" +
-	"H\xa8\xf0\"c\xa3\x8c\x84,\xa3\x0f\x10\xac\xab\x12\x09" +
-	"\x84\"\xfa\x9c8\xb3\xee\xce\xbe\xa3\xef\xeb\xdda\xe6\xf7" +
-	"\x7f\xfe\xcf9\xcf3\xf6\x16\xa6\xd9x\xec9F$\xfa" +
-	"cq\xff\xdb?\xb6\xad\x8b\x91__#C\x87_\x9f" +
-	"\xfai[\x7f\xe0\xe1s\x8aA%2\xef\xc1\x9b\xe6\xbd" +
-	"\xc1i\x00\x19\x82?\xf5\xdd\xf9K\xefU_y\x83\x84" +
-	"\x8e\x0ex\x16\xaad\x1e\xc1\xe7fN\xd2\x93sx\x19" +
-	"\x04\xff\xae\xee\xbb7S\x9f\xee\xec\\'\xfd\x0b\xfb\xca" +
-	"\xfc\x9d\xc9\xd3\x05\x93\xd2_\xe77\xd7O\x8f\x8a\x0d)" +
-	"\xcd\xa2\xf0\x00?3G\xb9<\x0d\xf3\xb7\x09\xfe\xde\xeb" +
-	"=\xfb\xcfg\x1a\xc7\x12\xe6!\xacH\xe2\x13\xfe\xa1y" +
-	"\x18\xb0\x07\x01\xbb\xfb\xea3g'?\xff\xf8C\xc4\x84" +
-	"\\=i+\x130\xd7\x82\xb1\xb2\xb2N\xf0\xefK=" +
-	"=\xfe\xa4\xb6uv\x1d|\xa0<\x0a\xf3\xfb\x00\xfe&" +
-	"\x80\x0bN\xcd{\xb6\xbcT\x86\xf7`\xc1\xaeV\xaai" +
-	"5oe\x17\x00\xa1p\x85H\x01\x91\xa1\x0d\x12\x89." +
-	"\x0e\x91dP\xbdZ\x01\x1a1h\x1d\xb3\xac5\x9bu" +
-	"j^n\xa9\xec\x11I\x85~\xae\xf4\xfa~ \xb1;" +
-	"A$\xde\xe1\x10{\x0c\x1a\xfe\xf3\x93\x90_?X!" +
-	"\x12\xefs\x88\x06\x83\xc6\xfe\xf5\x93`D\xc6\xbeE$" +
-	">\xe2\x10_0h\xfc\x1f?\x09Nd\x1c\xa4\x89D" +
-	"\x83C|\xc9\xa0)\x7f\xfbI(D\xc6\xa1\xb4\xf6\x19" +
-	"\x878f\xd0b\x7f\xf9I\xc4\x88\x8c\xa3\x8f\x89\xc41" +
-	"\x878e\xd0\xe2\x7f\xfaI\xc4\x89\x8c\x93w\x89\xc4)" +
-	"\x878gH\xd5+\xae\xe3Q<\xb8Bv\xd9.Q" +
-	"\xa2b9k\xd0\xc3\x08\x09\xd0/\xef\x18\x00\xa8@\x0f" +
-	"\xcb\xd0\xfc\x9b\xf1j\x85\xe6X;\xcc\xe6\x8f\xe0\x99\xf4" +
-	"\xb0\x96\x111V\xb1\x9c\x8a\xb3n\xafZ\xceZ\xddq" +
-	"=\x82\x1e\xa6\x16a\xd1bS\x96S]\xdd\x80\x1e\x96" +
-	"\xa1\x83\xbc\x12Cs4\x12d\xfa2\xc8!\x86La" +
-	"\xd9.U\\\xf4\x11\x168\x82H\xfb\xee\xa4\x15\xbc\x8f" +
-	"\x94\xebm\xcb\xcd\xcaP\xa79\xc4<\x03\xd0\x8ctN" +
-	"F\xfa\x18\x87\xc83\x18\x0c\xcdD\xc5\x08\x91\x98\xe7\x10" +
-	"O1\xa4Jn\xd1v\xd1M\x0c\xdd\x04\xdf\xad/\xae" +
-	"8\x05\xefqJ8\x1bs3\xadf%\x8a\xb6\xe7 " +
-	"F\x0c\xb1\xeb*\x9a\xc9[\xd9\xabn\x06C7F\xdb" +
-	"\x8e\xdc<\xc3!\x16\xa4\x1d\xd6\xb4\x93K\x87\x1e\xd5\x92" +
-	"[\x84J\x0c*!\xb1h\xbbN\xcbY\xc6uj%" +
-	"{\xb5m\xb4e\x82G\x1f\xe6\xd6x\xa4\xa7\xae\xb6\xa7" +
-	"a\xf9BC\x1cb\xac\xc3\xd3\xa8\xf4t?\x87x\x88" +
-	"!\x15\xa4\xd0\xbe\xb6[Z\x92-\xeby\xe1\x89\xdf\x8a" +
-	"[\xb9\x17\xa3\x01+\xb7\xdb\xdd\xaaQd\xfb\xe0\x0d\xdb" +
-	"\xd5\x82[\xbba\xf7\xff\x01\x00\x00\xff\xff\xba\x0c]b"
+func (p CertMgmt_Promise) DenyListReq() DenyListReq_Promise {
+	return DenyListReq_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p CertMgmt_Promise) DenyList() DenyList_Promise {
+	return DenyList_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+const schema_ec3b2b10a5e23975 = "x\xda\x8cSAh\x1cU\x18\xfe\xbf\xf7fvv\xcb" +
+	"n\xb3\xcb\xac\x18O\xabq\x916Zib\x15\x1a\x91" +
+	"\xad&\x05S\x13\xc9\x9b\xcdA\x11\x95\xcd\xee\x90nI" +
+	"\x96tg\x96\x10\xc1\x82 ^\xed\xa1\x97*9T\xc8" +
+	"\xc1C\x10\xc4^\xc4K\xa5()x\xa8\xb5X\x05A" +
+	"{\xb1\x84(\x16\x0am4\xfa\xe4\x9f\xcd\xcel&\x1b" +
+	"\xed\xed\xf1\xbe\xef}\xff\xfb\xbf\xff\xfb\x0f\xdb\xe2\x98\x18" +
+	"2\x0b\x92H\x15\xcd\x84\xfe\xfe\xee\x8as{\xf0\xf7\x0f" +
+	"(\x97\x85n\x1d\xbd\xb9\x92}\xfc\xd9\x0d2a\x11\xd9" +
+	"-|d\xbf\x1d\x9c\x96P\"\xe8\xa376\xde\xfbl" +
+	"\xe1\xec\x05RYt\x91\x8f\xc3J\x11\xd9\xe7q\xc5^" +
+	"a\xf6S\x17\xa0A\xd0\x0f\xa6\x1e:S\xf8zu\xb5" +
+	"\x97\xf4%\xf9\xad\xfd\x8d\xe4\xd3\x9ad\xe9k\xd3g\x16" +
+	"\x7f\xbeZ\xbb\xcc\xd2\"N\xde\x94\xeb\xb6i\xf0\x09\xc6" +
+	"'\x04\xfd\xea\xa3\x1f\xe2\xf5\xf7W\xbf\xea%\xbcl\\" +
+	"\xb1?\x0e\xb8+\x06\x0b\xdf\xf8u\xe3\xec3\x17\x97\xaf" +
+	"\xc5\x84\x03\xc6\x9aq\xd3\xbe\x1e\x9c\xae\x1a\x8b\x04\xfd\xc5" +
+	"\xf2\xbeKo\x95.\x7f\xc7\\\x19\xe3\x1e2?\xb7\x9f" +
+	"6\xf94d\xf2\x1f.\x9e\x7fc\xfd\xa7[\xbf\xfc\x18" +
+	"\xfb\x83\xe0\xfe\xd7\xcca\xd8?\x04\xe4\xeb&\x0b?V" +
+	"xm\xa8\x9c9\xb7\xde\x8b<\x94x\x01\xf6\xf3\x09&" +
+	"?\x97`r\xd5m\xfao\xce\xcf\xce\xc3\x7f\xb2ZY" +
+	"h,\x8cX\xd3\xce\xe8\x14\xa0\x0ci\x10\x19 \xcae" +
+	"\x06\x88TRB\xe5\x05,\xbfYE\x86\x042]o" +
+	"E\xe7\xed\xa8\xdb\xf4'g\xe7}\"V(J#\xad" +
+	"u \xf1\xdb0\x91\xba%\xa1\xee\x08d\xf0\x8f\xce\x83" +
+	"oo\x9f\"R\x7fH\xa8-\x81\x8c\xf8[\xe7!\x88" +
+	"r\x9b\x0e\x91\xba'Q6 \x90\x91[:\x0f\xc9\xc3" +
+	"\xc0\x08\x91\xda\x92('\xf9\xde\xf8K\xe7a\x10\xd9&" +
+	"\x06\x88\x1cH\x94\xd3|o\xfe\xa9\xf30\x89\xec\x14\xbe" +
+	"$*\xa7\x19\xe8g \xb1\xa9\xf3H\x10\xd9\x0f\xe0S" +
+	"\xa2r?\x03E\x06\xac{:\x1f\x0c\xf3\x11\xcc\x10\x95" +
+	"\x1ff\xe0\x09\x06\x92wu\x1eI\"\xfb N\x10\x95" +
+	"\x0f0p\x04\x02\x85V\xc3s}J\x04\xfd\x8f\x9e\xac" +
+	"\xd4\xa9\xaf\xe1\xb8\xa7\x91\x8drE@\x96\x10\x11\xd0@" +
+	"6Jh\x1b-\xf9\xcdj\xfbY\x98\x846\x10x\x9c" +
+	"\x8dv%&&\x1a\x8e\xdbp\x17+s\x8e{\xba\xe5" +
+	"z>!\x1b\x8d<\xc6E\x87[p\xdc\x85\xb9%d" +
+	"\xa3$m3knci\xa2\xee\xf9d\xb5\xff\x12&" +
+	"8\x8e\x13\xd7\x09w\xa1\xab\xce\xae\x04\xb4\x0b\xc724" +
+	"\xb2\x9d\xa1\xa2@\xa9z\xb2Rox\xd8O\x98\x92\x08" +
+	"\xd2\xb4\xff\xbf\xb4\x02wY.\x1d\xca\x1d\xe7<\x1d\x93" +
+	"P\x13\x02@;M\xe3\x9c\xa6\x17%\xd4\xb4@N\xa0" +
+	"\x1d&5H\xa4&$\xd4+\x02\x85\xbaW\xabxH" +
+	"\x91@\x8a\xa0\xbd\xd6\xcc)\xb7\xea\xbfD}\xee\xd2\xf8" +
+	"X'\xd4}\xb5\x8a\xef\xc2$\x01\xb3\xd7\x9f\xc6B;" +
+	"b\xfd\x9d Ri\x09\xd5/vz\xb6\xe7\xaet\x94" +
+	"\xac\xed\xe6\x92\xa1\xd6A\xde\xb7\xa2\x84:,\x90\xebt" +
+	"w\x88\x0d< \xa1\x8e\x08Xu\xaf\x06\x8b\x04,B" +
+	"\xc9s\x9b\xf5\xca\\\xd8\xd5\xae}.M;\xa3\xbb\xfd" +
+	"\x1b\x88\xfc\x0bK\x8c\xb3Wc\x12j\x8a\x0d\x14m\x03" +
+	"'G\"W\xbb\xeb\xf6\xcdT<\xb7Su\xafO\xc8" +
+	"\xf8(w\xc61\xd6\xf6p\xaf\xb6\x07\xa3\xb6\x0bAn" +
+	"\xc2Ay\xf5Y\xde\xaa}\xef\xbc|\xa7vn\xf2\xdd" +
+	"x$\x8d\xbdjw\xd6\xe6~L\xef\xaanU\xbd\xe6" +
+	"\xff\xd4\xfew\x00i\x9d\xb2\x8c"
 
 func init() {
 	schemas.Register(schema_ec3b2b10a5e23975,
@@ -870,6 +1091,8 @@ func init() {
 		0xa19070b486ecd839,
 		0xadadc71f7e190917,
 		0xc464d1e0777e54d3,
+		0xc6ad8f5d019b2359,
+		0xd39db53690ece7d8,
 		0xd4c43f7ac10a9dbc,
 		0xdae1e8ddea5e99b5,
 		0xea940d53315b1f26)
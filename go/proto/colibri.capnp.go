@@ -2341,12 +2341,12 @@ func (w ColibriRequestPayload_Which) String() string {
 const ColibriRequestPayload_TypeID = 0xc571cc47a792000f
 
 func NewColibriRequestPayload(s *capnp.Segment) (ColibriRequestPayload, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return ColibriRequestPayload{st}, err
 }
 
 func NewRootColibriRequestPayload(s *capnp.Segment) (ColibriRequestPayload, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return ColibriRequestPayload{st}, err
 }
 
@@ -2442,12 +2442,59 @@ func (s ColibriRequestPayload) NewResponse() (Response, error) {
 	return ss, err
 }
 
+func (s ColibriRequestPayload) SegmentID() (SegmentReservationID, error) {
+	p, err := s.Struct.Ptr(1)
+	return SegmentReservationID{Struct: p.Struct()}, err
+}
+
+func (s ColibriRequestPayload) HasSegmentID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s ColibriRequestPayload) SetSegmentID(v SegmentReservationID) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewSegmentID sets the segmentID field to a newly
+// allocated SegmentReservationID struct, preferring placement in s's segment.
+func (s ColibriRequestPayload) NewSegmentID() (SegmentReservationID, error) {
+	ss, err := NewSegmentReservationID(s.Struct.Segment())
+	if err != nil {
+		return SegmentReservationID{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s ColibriRequestPayload) Index() uint8 {
+	return s.Struct.Uint8(6)
+}
+
+func (s ColibriRequestPayload) SetIndex(v uint8) {
+	s.Struct.SetUint8(6, v)
+}
+
+func (s ColibriRequestPayload) Path() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s ColibriRequestPayload) HasPath() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s ColibriRequestPayload) SetPath(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
 // ColibriRequestPayload_List is a list of ColibriRequestPayload.
 type ColibriRequestPayload_List struct{ capnp.List }
 
 // NewColibriRequestPayload creates a new list of ColibriRequestPayload.
 func NewColibriRequestPayload_List(s *capnp.Segment, sz int32) (ColibriRequestPayload_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
 	return ColibriRequestPayload_List{l}, err
 }
 
@@ -2480,126 +2527,137 @@ func (p ColibriRequestPayload_Promise) Response() Response_Promise {
 	return Response_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-const schema_a3bf9fed859570f0 = "x\xda\xd4Wm\x8cTW\xf9\x7f\x9es\xe6maw" +
-	"g\x86;M\xf9\xf3O\\KJ\xb3\x10\x0at\x97\xa8" +
-	" \xed\xd4\xdd\x1de+\xd593\xbb)E6\xe5\xb2" +
-	"s\xb6\\\x9d\xb9w\xe6\xde\xbb]j\x8aT\xc4D\x82" +
-	"\x8amZ\xad\x1f\xacPi,\xbaI\x8b\xa9Ii\xa8" +
-	"o)\x81\xbe\x18c\xacV\x1b\x8d\x1am\x9aX1\x85" +
-	"\xa4\xd4-\x96k\x9e33\xf7\xce\xab\xcb\x07\xbf\xf8m" +
-	"\xf7<\xbfy\xces\x9f\xdf\xefy9\x1b\xfa\xc3\xb7\xb2" +
-	"\x9b\xc2G\"\x00b[8\xe2\x1d\\\xd8\xb6\xf3\xfb?" +
-	"\xfb\xcda\x10\xfd\x88\xde[\xe5\x87\xbfx\xfe\xdb?\xfe" +
-	"\x0e\x84\xa2\x00\xc37\xf25\xa8\xdd\xcc\xa3\x00\xda&\x9e" +
-	"\x06\xf4~x\xe5\x8d\xdf^<\xf7\x8f\xafB\xb2\xbf\x01" +
-	"\x1bf\x04\x9e\xe2+P+)\xb0\xc1\xe7\x00\xbdWg" +
-	"\x9e\xfb\x84\xbd\xeb\xef_o\x01#!\xce\xf0\xbfi\xbf" +
-	"T\xd8\x9f+\xc7w\xad\xdc\xfd\x97[^\xba\xf4\x0d\x10" +
-	"\xff\x8f\xe8\xfd\xc2\x9d\x1a\xfe\xc2\xce\xfc\x9bp\x0d\x8f\"" +
-	"\xc0\xf0\x05\xbe\x02\x01\xb5\x05\x05\xfd\xdc\xe0\x9b\xc7\x7f\xe4" +
-	"\x96\x1ek\x098L\xde\x86\xff/\xb4\x0c\xb5\xd5*\xf8" +
-	"U\xa1;\x10\xd0\xdbsf\xe9\xa7\xce\x7f\xfc\xc3\x8f7" +
-	"G\xa1\x10\x0f\x84\xd7\xa0v,\x1c\x05\xee=;\xf8\xd7" +
-	"\xc37?\x99?\xd1\xe9\xc3\xf6\x85W\xa2\xf6\x950\x05" +
-	"{(L\x1f\xf6\xf8\xfaug'\xc4\xc6\xef\xb5D\x90" +
-	"\xc1(\x07\x18~=\xbc\x0c\xb5\xb7\x15\xfcB\xf8I@" +
-	"\xef\x86?L\x1dY\xe6\xcd\x9f\xee\x90a\xedP\xe45" +
-	"\xed\xe1\x08\xfd\xf5@\x84\\\xcf/\x0c\xbe\xb3\xf3\x937" +
-	"<\xdf\x89\x8d\x0b\x91\x1d\xa8\x85\xa3\x04\xc6\xe8\x1c\xe0{" +
-	"\xf1\x07\xbf\xfb\xb1\x97+g\xda\xa3@6<\x15]\x89" +
-	"ZI\x81\x8d\xe8\x1b\x80\xde\xb1\x85\x89\xb7\x8e\\\xfb\xb5" +
-	"\xb3\x90\xecg\x01\x1ap8\x13[\x89\xdad\x8c\x90\"" +
-	"\xf6A@o\xcb\x8b\x9b\xde\x97.\xbd\xfe\xab\xd6\x04\x13" +
-	"q\xc3\x93\xb1%\xa8I\x85\xd6cs\x80\x97G.\x0c" +
-	"}\xe0\xf2\xe1_w\xf8\xb6\xe7b\x17\xb5\x17\x14\xf2\x0c" +
-	"!\xbd}\xdb\xcf\xffn\xfd\xfa\xb3\x7fjO\xdbR\x00" +
-	"\xed\xba\x9eg\xb5\xd5=\x8a\xb8\x9e?2@o\xcc>" +
-	"\xf7\xfb\xf9\xbbJ\x7f\xee\x0c\x9f\xec\xfd\x816\xd5K\xf0" +
-	";{-\x82\xfb\x9ai\x81s\"E\xeb\x89\xbf\xa6]" +
-	"\x13\xa7X\x92q\xca\xc6\xa3\x95[V]\xcf_\xba\xd8" +
-	"Yo'\xe3Jo\xa7\xe2D\xdf\xc1\xe5\xd7>z\xe0" +
-	"\xd5\xeb.u\x90\x86f$\xde\xd5f\x13\xf4W%A" +
-	"\x9f8m\x15\x8d\xdd\xb6\xb1\x8eO\xebe\xb3\xbc9/" +
-	"\xef.I\xd3\x9d\x90\xba]\xb0\xe6\xcc\x9ct\xe2c\xba" +
-	"\xabg\x11E\x88\x87\x00B\x08\x90\xec\xcb\x01\x88^\x8e" +
-	"b9CO\xda\xb6e\x8fZ\x05@\x89\x11`\x18\xe9" +
-	"\xea4'\x1di\xdf\xa3\xbb\x86e\xf2\xf11r\x19\xf3" +
-	"]\xae^\x03 \xae\xe7(60L\"\xa6\x90\x0eo" +
-	"\xdc\x0c \x069\x8a\x8d\x0c\xe3\xbac\x14\xb0\x0f\x18\xf6" +
-	"\x01\xa6\x9d\xd9\x99\x19co\xfd_\xff>V\xbd/3" +
-	"\x94\x19-J\xdd\x9c-w\x0a\xden\x08\xde\xae\x85\x04" +
-	"\x03\x86e\x8e\x8fa\"\xc8\x1d &\xda?%3\x94" +
-	"\xc9Kw\xb6L~\xd79\xb3\xd3\xd3\x92;\x0eyO" +
-	"`\x0a\x19\xb9\x1f\x02\x101\x8e\"\xc5p\xc0\xb5>#" +
-	"\xcd\xb6(\x9b\xb3\xa2\xdc\xe5deLwQ\x85\xba\xdc" +
-	"\x0f\xf5\x9b\xe4\xeb!\x8e\xe2hCR\xbeE\x87\x8fp" +
-	"\x14\xc7\x19&\x19\xab\xdez\xec6\x00q\x94\xa3\x98g" +
-	"\x88<\x85\x1c yb\x07\x80x\x82\xa3x\x9aa2" +
-	"\x84)\x0c\x01$O\x12\xf0)\x8e\xe24\xc3d\x98\xa5" +
-	"0\x0c\x90<u\x00@<\xc3Q\xbc\xc2p\xa0d\x98" +
-	"#w\xd4\x99\x1c(\xe9{\x83\xff<\xa7\\4\xdc\xd1" +
-	"\xa2\x03\x00\xc1\x99\xab\xdbn\xd6\xb6\x80\x97\x1dL\x04\xad" +
-	"\xa3\x96?i\x16\xb2\xb6UV?i\xb7\xea\xc5\xa25" +
-	"\xad\xbb\x06Z\xe6\x84\xad\x1bE\xc0~\xc0,GL\xd4" +
-	"\xab\x14\x90\x8e\x16\x95i\xa5\xcetWdQ:U\xe6" +
-	"\xa25I4\x88oh\x11\xf1\x0d8\xf4KL\x04\x9d" +
-	"\xbc\x1a\x7fz\xb7\xeeH%\x1b\x7f\xcct\x96M3\xd7" +
-	"\x8e\xcfu/\x0f\xf5z\x9e\x0a\"CA\xdc\xcaQl" +
-	"c\xd8\x87W\xbcj\x14\xe3#\x00b\x8c\xa3\xc82\xec" +
-	"c\xefyU\xbao'\xecV\x8eb\x82\xe1\xc0\xac\xe9" +
-	"H\x17\"\xfbgt\xa38k\xcb\xf6(\xbb\xc8\xb0V" +
-	",Y\xdd\xdd\x93Q$\xf1\xb2s5y!\x05\xad\xe5" +
-	"(>\xc4p\x80\xd8+\"\x02C\x1a\\\xae\xad\x9b\xce" +
-	"\x8c\xb4\x89\xeb\xfaY\xfd\xb6PS\"\xc6\xcd\x82\xdc;" +
-	"j\x993\x86]R\x0daL\xe7W\xc7\xcaP\x03+" +
-	"\x06y\xf1\xa5\xea\xb8\xba+1\x1e\x8c\x0d@\x8c\xb7S" +
-	"1Z\xfd7'+\xb3\xd2q\xb3\xfa\xbd\xc5\xa8\xa5\x17" +
-	"\xe8\xea\x84\x7f\xb5N\x0dn\x17GQ$.\xbc\x1a\x17" +
-	"\x06\xdd]\xe0(\xca\xc4\xc5\x95\x1a\x17%bh\x0fG" +
-	"\xe12\xec\xe3\xc4\x10\x15_\x85\xb2T\xe6(\xeec\xe8" +
-	"\xb9FI:\xae^\x02,c\x0c\x18\xc6 `\xcd\xae" +
-	"\xc6\x81\x89`z\xd4$dK\xa7l\x99\x8e\xacV\x8e" +
-	"?\x8a:\x0b\xac\xa1\xb7\xaa\xdc\xe6\xdd\xa8\xeeJ\x95P" +
-	"\x15er\x84~\x98\xec\xd9\x0c\xb0\xbf,\xcd\x82a\xde" +
-	"\x9d\xd6\xa7]\xe3\x1e\xd9E\xaa\xb5\x06JU\x05\xd0B" +
-	"\xcc\x8a\x80\x98N\xbcp\xa3\xd0^\x11\xcdd\xb5\x8a\xf0" +
-	"#\xb5>`\x99\x03#R/\xb4\xeapd\x11%\xec" +
-	"W}\xa4k\xdb\xaa_\x86\xf5\\\xa5\xab\x99\xa5[6" +
-	"\xfa\x15\xa8M\xe1\x10@~;r\xcc\x17\xb0\xa1\x085" +
-	"\x1d?\x0d\x90\xdfE\x86\"6\xd4\xa1f\xe0g\x01\xf2" +
-	"{\xc8\xe0\x92\x81\xff\xabJ\xbfV\xc1\x07\x01\xf2.\x19" +
-	"\xee'C\xe8\xb2\xa7\xfa\xaf\xb6\x0f\x1f\x03\xc8\xdfO\x86" +
-	"/\x93!\xfc\xae\xa7z\xb0v\x08\x0f\x00\xe4\xbfD\x86" +
-	"\x87\xc8\x10Y\xf0R\x18\xa1-\x0b_\x04\xc8?B\x86" +
-	"\xe3d\x88\xfe\xd3K\xa9\x85\xf4\x98\xba\xfc(\x19\xe6\xc9" +
-	"\x10{\xc7Ka\x0c@;\x81\xb7\x01\xe4\x9f \xc3\xd3" +
-	"d\xe8\xb9\xe4\xa5\xb0\x07@;\x89;\x00\xf2O\x91\xe1" +
-	"4\x19\x96\xbc\xed\xa5p\x09\x80vJ\x19\x9e!\xc3\xf3" +
-	"\xc80\xb94\x91BZY~\xaa<\xfd\x84\xce_\xa6" +
-	"\xf3^\x9e\xc2^\x00\xed\x05\xcc\x01\xe4\xcf\xd1\xf9+\x18" +
-	"\xb4 \xcf\xa9\xb59\x88\xd7\xfa\xa5\xbfw\xd6T[\x03" +
-	"\xe4 -M9\xa7\x17\xbbC&\xb0\xde\xb1\xb1\xbc\x18" +
-	"*'M\x19]\xcc[uRP)\xf9\xef\x87f\xcc" +
-	"8kmJ\xaa\xf0\xfc\xfd\xb6\x19=\x0a\xe9j\x85`" +
-	"\"X?\xeb3oH\xaaN_\xad\\\x7fU\x0b\xac" +
-	"\x14\xf0\x1cp\x15q\x07\xb3\xaa=\xe0\xca\xb7\xff$\xa9" +
-	"O\xcc\xe9iYve\xa1\xb1\xc7R\xe7\x97\x85\xad\x16" +
-	"\xf5\x97\xae\x9a\x1f\xa8\xf6;D\xb16\x90\xfc*%\xf9" +
-	"\xf7\x13\x91k\x9b$\xbfZI~\x90\x0c\x1b\x9b$\x7f" +
-	"\x93R\xdd\x062li\x92\xfc&%\xf9-d\xd8\xda" +
-	"$\xf9\x8c\x92\xfcV2L4I^(\xc9g\xc9\xb0" +
-	"\xb3I\xf2w*\xc9\x07\xf5\xe6K\xbe\xb5\xde|\xc9W" +
-	"\x94P\xcbd\xb8\xafI\xf2\xf7*e\xef%\xc3\xc1&" +
-	"\xc9\x7f^\x19\xfcB\xec.\xe1\x96a\xdaI\xc2] " +
-	"-\x12\xf6\x1fl\x8bH\xb8;.\x90\xb0\xffD\xfc_" +
-	"\x94p\xfb\xb6^[\xa9k+@\xc3\x1c\xb6\xaff\x0e" +
-	"c\xc3c\x9c\xc61\xa31\x8c\x0d/\xa6\xe4\xe4\x08\xb0" +
-	"\xc5w}\x7f&\xab\xbd\xdeq\xea\x1b\xd5\x7f|\x03(" +
-	"\x0c\xb7%\xadr\x09V\x1d\xfe\x99\\\xb0\xb4\xa9yE" +
-	"\x9b\xf7\xedt\xb8\x8d\xa3\xd8^\xdb\xdbi\xf3\x9e\xa4%" +
-	"3[\xfd\xc0\x8e/)\xc3\x9c\xb1>j\xc8\"`\xf0" +
-	"\xf8Q\xc3\xcd\xa9o\xca\x84\xec\xef\x98\xd5\xdaN\x90V" +
-	"K\xc1\x7f\xf3\xbd\xf5\xef\x00\x00\x00\xff\xff\xb3\xde\xb9R"
+func (p ColibriRequestPayload_Promise) SegmentID() SegmentReservationID_Promise {
+	return SegmentReservationID_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+const schema_a3bf9fed859570f0 = "x\xda\xd4X{\x8cTg\x15?\xbf\xfb\xcdc;\xb0" +
+	"\xbb3{\x87\x14\xf1\xb1\x96\x94\x06\x08\xe5\xb1\x8b\x0f\x90" +
+	"v\xeb>\x14*\xd5\xbd;\xdb\x14\x11R.;\xdf\x96" +
+	"\xab3wf\xef\xbd\xdb\x05SJE\x9aHP\x91\xa6" +
+	"\xd5\xfa\x87\x15*\x8dEI\xda\x1a\x9a\xb4\x0d\xd5\xd6@" +
+	"@Z\xa3\x8d\xd4jSc\x1b%U+\xa6\x90\x94\xba" +
+	"E\xb8\xe6|w\xe6\xcecg\\\xfe\xd0?\xfco\xef" +
+	"\xf7;s\xbe\xf3\x9d\xf3;\xaf]zw\xf4&mY" +
+	"to\x8c\xc8X\x1b\x8d\xf9\xbb&\xd7n\xf8\xf1\xcf\x7f" +
+	"\xbb\x87\x8c6\xc0\x7f\xbb\xf8\xc0\xbdg\xbf\xff\xb3\x1fP" +
+	"$N\xd4}\xbdX\x08\xfd\x06\x11'\xd2W\x88\x1e\x82" +
+	"\xff\xe4\xe57\x7fw\xfe\xe4?\xbeI\xa9\xb6*\xd9\xa8" +
+	"\xc6\xc2\x1b\xc5\x1c\xe8y%l\x89\x09\x82\xff\xca\xe8\xb3" +
+	"\x9fu6\xfd\xfd\xdbu\xc2`\x89\xe3\xe2o\xfaKJ" +
+	"\xf6\x97J\xf1\xeds7\xff\xe9\xc6\x17.|\x87\x8c\xf7" +
+	"\x03\xfe\xaf\xbc\x8d\xdd_\xdd\x90y\x8bf\x898\x88\xba" +
+	"\xcf\x899 \xe8\x93J\xf4\xee\xf9o\x1d\xfc\xa9\x97\x7f" +
+	"\xb8\xce\xe0(k\xeb~_\xa4\x03\xfa\x02e\xfc\xbc\xc8" +
+	"m \xf8[\x8e\xcf\xf8\xc2\xd9\xcf|\xe2\x91Z+\x94" +
+	"\xc4\xbe\xe8B\xe8\x07\xa2q\x12\xfe3\xf3\xff\xbc\xe7\x86" +
+	"\xc72\x87\x1a=l{t.\xf4oD\xd9\xd8\xddQ" +
+	"~\xd8#K\x16\x9f\x186\x96\xff\xa8\xce\x82\x01\xc4\x05" +
+	"Q\xf7\x99h\x07\xf4w\x94\xf8\xb9\xe8c\x04\xff\xba?" +
+	"l\xdc\xdb\xe1\x1f>\xda\xc0\xc3\xfa\xee\xd8\xab\xfa\x031" +
+	"\xfek_\x8cU\x1f\x9e\x9c\xff\xee\x86\xcf]w\xacQ" +
+	"4\xce\xc5\xd6C\x8f\xc6Y\x18\xf1\x09\xc2\xa5\xf6\xfb~" +
+	"\xf8\xe9\x17\xc7\x8e\xd7Y!\xe2\x02Z\xf7\xc6\xf8\\\xe8" +
+	"y\x16\xee\x96-{A\xf0\x0fL\x0e\xbf\xbd\xf7\xeao" +
+	"\x9d\xa0T\x9bV\x11'tG\x13s\xa1\xcfJ\xb0\xde" +
+	"T\xe2c\x04\x7f\xd5\xa9\x15\x1f\xea\xc9\x9f\xf9M\xbd\x87" +
+	"9r\xdd\xb3\x12\x09\xe8\xf3\x94\xf45\x89\x09\xc2\xc5\xde" +
+	"s]\x1f\xbd\xb8\xe7\xe5\x06\x8f\xdb\x9e8\xaf\xefV\x92" +
+	"\xf7\xb2\xa4\xbf}\xdd\xd9\xdf/Yr\xe2\xf5\xa9~\x9b" +
+	"A\xa4\xbf\x91xF\xff+Kw\x9fI\xfcQ#\xf8" +
+	"\xfd\xce\xc9\xd7\x0e\xdf\x9e\x7f\xa3\xb1\xf8\xac\xb6\x9f\xe8\x1f" +
+	"lS1o+hTE\x9a\xa9\xfe \xd2\x7f\x91|" +
+	"U\x7f9\xc9\xb6\xbc\x94|\x93\xe0?4v\xe3\xbck" +
+	"\xc5\x0b\xe7\x1b\x13n,\xa5\x08\xb7-\xc5\xf1\xdb5\xfb" +
+	"\xea\x87v\xber\xcd\x85\x06\xdc\xd0\x17t\xbc\xa7\x7f\xa4" +
+	"\x83\xffZ\xd6\xc1O\x1c)\xe4\xac\xcd\x8e\xb5X\x8c\x98" +
+	"E\xbb\xb82#\xef\xc8K\xdb\x1b\x96\xa6\x93-L\xd8" +
+	"C\xd2m\xef7=s\x100\"\"B\x14\x01Q\xaa" +
+	"u\x88\xc8\x98)`\xcc\xd6\xe0K\xc7)8}\x85," +
+	"A\"F\x1abM\x95\x0eIW:w\x9a\x9eU\xb0" +
+	"\xc5\x9a~V\xd9\x12\xaa\\\xb0\x90\xc8\xb8V\xc0X\xaa" +
+	"!\x05\xa4\xc1\x87\xd7\xaf$2\xe6\x0b\x18\xcb5\xb4\x9b" +
+	"\xae\x95E+ih%\xf4\xb8\xe3\xa3\xa3\xd6\xd6\xf2g" +
+	"x\x9f\x16\xdc7\xd05\xd0\x97\x93\xa6=^ld\xbc" +
+	"Se\xbcS2\x89:\xad\x82\xbd\xa6\x1f\xc9\x8a\xef\x08" +
+	"HN}\xca@\xd7@Fz\xe3E\xd6\xbb\xd8\x1d\x1f" +
+	"\x19\x91\xc2uY{\x12ih\xac\xbe\x8b\xc8h\x110" +
+	"\xd2\x1a:\xbd\xc2\x97\xa4=\xc5\xcaZ\xaf(uCr" +
+	"\xac\xdf\xf4\xa0L\x9d\x1d\x9a\xfa]\xd6u\xbf\x80\xb1\xbf" +
+	"\xca)\xdf\xe3\xc3\x07\x05\x8c\x83\x1aR\x9a\x16\xdcz\xe0" +
+	"f\"c\xbf\x80qX\x03D\x1a\x82(uh=\x91" +
+	"\xf1\xa8\x80qDC*\x824\"D\xa9'X\xf0q" +
+	"\x01\xe3\xa8\x86TTK#J\x94zz'\x91\xf1\x94" +
+	"\x80qZCg\xde\xb2{o+G\xb23on\xad" +
+	"|\xf9n1gy}9\x97\x88*g\x9e\xe9x\x83" +
+	"N\x81D\xd1E\xb2R;J\xfe\x93vv\xd0)\x14" +
+	"\xd5O\xa6\xa2f.W\x181=\x0b\x05{\xd81\xad" +
+	"\x1c\xa1\x8d0(\x80d9K\x09hk\xea\xbb\x0aM" +
+	"\xc7\xca\x91n*\x99\x93n\x10\xb9x\x89\x12U\xe4\xeb" +
+	"\x9a\x86|\x9d.\xff\x12\xc9J)\x0f\xec\xef\xd9l\xba" +
+	"R\xd1&\xec3\x8diS\x1bk7\x8c\xf5L\x11\x99" +
+	"\xe9\xfb\xca\x88\x016\xe2&\x01c\xad\x86V\\\xf6\x03" +
+	"+\xd6\xf4\x12\x19\xfd\x02\xc6\xa0\x86V\xed\x92\x1f\x84\xfb" +
+	"\x16\x96]-`\x0ck\xe8\x1c\xb7]\xe9Ql\xc7\xa8" +
+	"i\xe5\xc6\x1d9\xd5\xca&4,%\xcb\xa0\xe9m\x19" +
+	"PA\x12E\xf7J\xfc\xc2\x0cZ$`|\\C'" +
+	"G/\x07\x90\x06\x10|\xcf1mwT:D\x14\x9e" +
+	"\x95o\x8b\xd48b\x8d\x9d\x95[\xfb\x0a\xf6\xa8\xe5\xe4" +
+	"UA\xe87\xc5\x95E\xa5\xab**\x16k\x09\xa9\xea" +
+	"z\xa6'\xd1^i\x1b\x04\xb4O\x0dE_\xf09$" +
+	"\xc7\xc6\xa5\xeb\x0d\x9a\xdbr\xf1\x82\x99\xe5\xab\x93\xe1\xd5" +
+	"&\x17\xb8M\x02F\x8ec\xe1\x97ba\xf1\xddY\x01" +
+	"\xa3\xc8\xb1\xb8\\\x8aE\x9e#\xb4E\xc0\xf04\xb4\x8a" +
+	"K~\x90|c\xec\xa5\xa2\x80q\x97\x06\xdf\xb3\xf2\xd2" +
+	"\xf5\xcc<\xa1\x88\x16\xd2\xd0B\x95\xa89\x81\x1dHV" +
+	"\xbaG\x89B\x8et\x8b\x05\xdb\x95A\xe6\x84\xad(@" +
+	"\x8d\x0f\x84\xc6>\xc9\xc6\x1e\x110\x9e\xab6\xf6\xd9\xae" +
+	"RN\x1f\xab6\xf6y6\xf6\xa8\x80q\xb2\xda\xd8\xe3" +
+	"l\xec1\x01\xe3\xd7U\x95\xe2,\xab=\x19\x14\x85T" +
+	"4\x16T\x8aI\xd6zZ\xc0x]C*\xa6\xa5\x11" +
+	"#\xd2\x01.\xde\xaf\x09\x18\x7f\xf9\xdf<VX\xd9\x06" +
+	")\xe6\x96\xa8D\xe8\xafc\x82\xfa\xaae}{\xd1\xf4" +
+	"\xb6\xd4S\xa1\xaa!)Bf\xbc\xb8\xe9I\xc5B\xe5" +
+	"\xadT/\x11\x90\xbaj%\xd1\x8e\xa2\xb4\xb3\x96}G" +
+	"\x8f9\xe2Yw\xca&\xf9]\xea:\\\x8a\x88\xea\xd8" +
+	"<\xa7\xc2\xe6Fdn\xf8\xc6\xdaw\xd5g\xee'K" +
+	"\xc5\xb3`w\xf6J3[\x9f\xbc\xbd\xd3\xa4\xcf\x0eU" +
+	"|\x9b\xd6\xfa\xf2e(\xfb\xaa'\x88\x10\xdf\xb2<," +
+	"[\xfaFt\x11e\xd6A \x93EU\xe5\xd2M|" +
+	"\x91(\xb3\x89\x81\x1c\xaa\x8a\x97n\xe1\xcbD\x99-\x0c" +
+	"x\x0c\x88\x7f\x054\xd4\xc7p\x1fQ\xc6c\xe0\x1e\x06" +
+	"\"\x17}EE};\x1e&\xca\xdc\xc3\xc0\xd7\x19\x88" +
+	"\xbe\xe7+:\xea\xbb\xb1\x93(\xf35\x06\xeeg 6" +
+	"\xe9\x07\x94\xdc\x87SD\x99\x07\x198\xc8@\xfc\x9f~" +
+	"Z\x8d\xf1\x07\xd4\xe5\xfb\x198\xcc@\xcb\xbb~\x1a-" +
+	"D\xfa!\xdcL\x94y\x94\x81#\x0c\\u\xc1O\xe3" +
+	"*\"\xfd\x09\xac'\xca<\xce\xc0Q\x06\x12\xef\xf8i" +
+	"$\x88\xf4\xa7\x15\xf0\x14\x03\xc7\xa0!5#\x99\x06\xcf" +
+	"y\xcf+M\xcf\xf1\xf9\x8b|>S\xa41\x93\x07:" +
+	"\x0c\x11eN\xf2\xf9iT\xeav\x99\xc7\x19j/5" +
+	"\x99pZ\xaf%\xfa\x10\xf5H[N\x98\xb9\xe6\"\xc3" +
+	"(\xb79\x14\xa7\x93\x1a\x92\xb6\x8cO\xa7-h\xaf\x9c" +
+	"\x92\xe1\xd6U\x97}Z}%W\x09\x1cn\x05\xb5\xd2" +
+	"}\xd4\x13d\x08\x92\x95\x99\xbd$\"\xbb\xa4j\x8fA" +
+	"\x05\x08\xe7\xdb\x0a\xca\x06O\x900s\x8da\x95{$" +
+	"\x94\xeep\x91+\xc1\xe6\xc8\x88,z2[\xdd\x98\xb8" +
+	"]\xca\xec\xea\x02\xa1\xd8\x9c\xf3\x9dA\x93\x00\x8cE\x15" +
+	"\xca\xcfS\x94\xff0\x07rQ\x0d\xe5\x17(\xca\xcfg" +
+	"`y\x0d\xe5\x97)\xd6-e`U\x0d\xe5W(\xca" +
+	"\xafb`u\x0d\xe5\x07\x14\xe5W30\\CyC" +
+	"Q~\x90\x81\x0d5\x94\xff<N\xd5\xe4[H\xf9\xfa" +
+	"|\x0b)?\xa6\x88Zd\xe0\xae\x1a\xcaoS\xcc\xde" +
+	"\xca\xc0\xae\x1a\xca\x7f\x05\xeb\xab\x13\xb19\x85\xeb&\x90" +
+	"F\x14n\"RG\xe1p\xcd\x9d\x86\xc2\xcd\xe5*\x14" +
+	"\x0e\x17\xeb\xffG\x0aO]qJ{Hin\xaa\x1a" +
+	"^\x9c+\x19^P\xf5/\x0c\x9ea4\x1e\x07P\xb5" +
+	"f\xa6n\xed%m\xfa\x05)\xec\xedj\x19r\xdd\xf2" +
+	"\x18\xfa\x1f\x17'%#\x1c\xc9\xf3oR\x0b\x86\x90\x81" +
+	"\xa1\xca\xa4\x9bBi\x08\xb9\x85\x0f\xd7\x0a\x18\xebJ\xcb" +
+	"\x0e\x0f!\xb7\xf2d>\x18<\xb0\xe1\xfai\xd9\xa3\x85" +
+	"OY2G\xa8l\x8c\xaa\xb9\xb9\xe5\xf5\x82%\xdb\x1a" +
+	"z\xb54\x13\xf4\xa8\xa1\xe0\xbf\xb9\xa4\xfe{\x00\x93}" +
+	"\x08\xcf"
 
 func init() {
 	schemas.Register(schema_a3bf9fed859570f0,
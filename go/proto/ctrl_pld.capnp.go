@@ -117,10 +117,11 @@ const (
 	CtrlPld_Which_sig       CtrlPld_Which = 7
 	CtrlPld_Which_extn      CtrlPld_Which = 8
 	CtrlPld_Which_ack       CtrlPld_Which = 9
+	CtrlPld_Which_colibri   CtrlPld_Which = 10
 )
 
 func (w CtrlPld_Which) String() string {
-	const s = "unsetpcbifidcertMgmtpathMgmtsibradrkeyMgmtsigextnack"
+	const s = "unsetpcbifidcertMgmtpathMgmtsibradrkeyMgmtsigextnackcolibri"
 	switch w {
 	case CtrlPld_Which_unset:
 		return s[0:5]
@@ -142,6 +143,8 @@ func (w CtrlPld_Which) String() string {
 		return s[45:49]
 	case CtrlPld_Which_ack:
 		return s[49:52]
+	case CtrlPld_Which_colibri:
+		return s[52:59]
 
 	}
 	return "CtrlPld_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -475,6 +478,39 @@ func (s CtrlPld) NewAck() (Ack, error) {
 	return ss, err
 }
 
+func (s CtrlPld) Colibri() (ColibriRequestPayload, error) {
+	if s.Struct.Uint16(0) != 10 {
+		panic("Which() != colibri")
+	}
+	p, err := s.Struct.Ptr(0)
+	return ColibriRequestPayload{Struct: p.Struct()}, err
+}
+
+func (s CtrlPld) HasColibri() bool {
+	if s.Struct.Uint16(0) != 10 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s CtrlPld) SetColibri(v ColibriRequestPayload) error {
+	s.Struct.SetUint16(0, 10)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewColibri sets the colibri field to a newly
+// allocated ColibriRequestPayload struct, preferring placement in s's segment.
+func (s CtrlPld) NewColibri() (ColibriRequestPayload, error) {
+	s.Struct.SetUint16(0, 10)
+	ss, err := NewColibriRequestPayload(s.Struct.Segment())
+	if err != nil {
+		return ColibriRequestPayload{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 func (s CtrlPld) ReqId() uint64 {
 	return s.Struct.Uint64(8)
 }
@@ -559,45 +595,51 @@ func (p CtrlPld_Promise) Ack() Ack_Promise {
 	return Ack_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-const schema_df42b02816bdc1bf = "x\xdaD\xd2MHTk\x1c\x06\xf0\xe7y\xcf|\xeb" +
-	"0\xe7p\x0eww\x11..T\xee\xbd\xa8\x08\x97+" +
-	"\xf7r\xfd\xb8\x92#N8\x1d\x037\xa53s\xc6q" +
-	"r\x9c\x8eg\x8e\xa8\x10T .\xc3EA\x05\x11\xad" +
-	"Z\x15\x09\x81\x14X\x89\x04\x12\x04\xd1BZ\x04\xd1\xa2" +
-	"M\xb40\xe9\xc3\xb4z\xe3\x1dfrw\xf8\xff\xce\xfb" +
-	">\xff\x17\x1e}\xbfG\x18\xc15 \x1d\x09\x86\xe4\xe8" +
-	"\xf5\xc1\xf9\xad\xddS\x17\x90NP\xc8\x87\x1b\xeb\xbf\xb4" +
-	"\xdc\xe9{\x85\x01\x11\x8e\x01F\xef}#\x19\x06:\x06" +
-	"n\x0aP^\xd9\xf1W\x17\x1e\x0fm\xc2H\xf0\xf0\xd7" +
-	"\xa0\x08\x03fP{k\x1a\x9a\xfa\x8ak\xf3\xa0\xcc\xf9" +
-	"^i\xdc-9\xfc3\x97q\xcbnw\xff\x7f\xbeW" +
-	"\x1a)9#d\xba]\x0b4J\x19 `\xf6\xb2\x13" +
-	"\xb0\xff\xa1F{\x90\x82q~\x97\x16\x15\x0c\xf07\xc0" +
-	"\xeeQ0\xac@|\x93\x16\x05`&\xd9\x06\xd8\xff+" +
-	"\x18Q\xa0}\x95\x165\xc0Lq\x08\xb0\x87\x15\x8c)" +
-	"\x08\x1cH\x8b\x01\xc0<^\x85Q\x05\x13\x0a\x82\xfb\xd2" +
-	"b\x100OT\xc3\xc7\x148\x0aB_\xa4\xc5\x10`" +
-	"fx\x0c\xb0'\x14\x94\x14\x84\xf7\xa4E\xf5\xb6bu" +
-	"+G\x81\xab \xf2YZ\x8c\x00\xe6Lu\xab)\x05" +
-	">\x05\x8d\x18-F\x01s\xb6\x1aQR\xf3\x055o" +
-	"\xa0\xc5\x18`\xce\xb1\x0f\xb0]5?\xa3.\x8a~\x92" +
-	"\x16\x1b\x00s\xb1\x9a\xe0+8G\xc1\xa6\xb9r%\xef" +
-	"#\x14vsY\xea\xf2Mz\xf1\xaf\xdc\x91G\xdb\x00" +
-	"\xa9\x83\x89\xe2d\xd1\xa1.\xef\xb5\x06\xf7\xcc\x83K\xd7" +
-	"jc\x99\xcb{~\xaa0\xe3\x03\xa0.\xff~\xf1n" +
-	"\xf9\xae\xbbr\xa3\xaen\xc6\x9f:\xd4'\xcf\xae6\xf6" +
-	"\xed\xff^\xd7\xa6J1\xebe\xa8\xcb\x95\xcd\xa5\xf1\x8f" +
-	"[\x1d\xbb\xf5S\x8e7\x9d_L\x15f@_\x9dz" +
-	"o\x1el\xaf\xad\xaf\xd64\\)\x16\xa8\xcb\xc8\xbf\x9d" +
-	"\x95\xd6\xe6\x93\xaf\xeb\xeb\xe5\x17\xfc2u9\xb9!\x96" +
-	"\x1f\xfc:\xf1\xb4\x1e\xe1\xe5g\x93\x0e\xa3\x10\x8c\x82g" +
-	"}/\x93\xcb'\x1d\xc6!\x18\x07\xc3\x99\xdc4u\xf9" +
-	"\xf2v\xd7\xf3\xee\xcb\xb7v~>\xa9\xd6'Q\xeb\x93" +
-	"],\x94\xf3N\xbf\xef%\xea\xad\x8ah\x01@\x95\xca" +
-	"hm\x03\xd2\xcd\x1a\xd3\xed\x82\x06Y-\x94\xf1\x87\x1a" +
-	"\xb6hLw\x09&\xb2\xa5\xd3\xd9z`\xa2R,\xa8" +
-	"-c\xe7\x8f~p.\xa6\x96j\x89?\x02\x00\x00\xff" +
-	"\xff\xf6\xaa\xbf\x18"
+func (p CtrlPld_Promise) Colibri() ColibriRequestPayload_Promise {
+	return ColibriRequestPayload_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+const schema_df42b02816bdc1bf = "x\xdaD\xd2_H\x14k\x18\x06\xf0\xe7\xfdf\xff\xea" +
+	"\xca\xce0\xc3\xb9;\x08\x07/T\xce9\xa8\x08\x87#" +
+	"\x1c\xceQ\x11\xcf\x0a\x1bn\xd3E\x17\x95\xee\xce\x8c\xeb" +
+	"\xe4\xba\xae\xb3\x13*\x18\x1a\x98\x18Xl\xa1\xa0QT" +
+	"W]\x15\x09\x81\x14X\x89\x08\x12\x05\xd1\x85t\x11H" +
+	"\x17\xddD\x17&Y\xa6\xd9\x17\xdf\xb0\x83w\xcb\xf3\xdb" +
+	"w\xde\xe7\x83\xb7!J\xff1%\xb8\x0c\xa4\"\xc1\x10" +
+	"?q\xeb\xff\x91\x8d\x9d\xb3W\x90\x8a\x13\xe3OWW" +
+	"~\xa9}\xd0\xb6\x85\x0e\x16\xae\x04\x94\xd6\xc7J\"\x0c" +
+	"4vl1\x10_\xdcv\x97F\xd7\xbb\xd6\xa0\xc4\xe9" +
+	"\xe8\xafA\x16\x06\xd4V\xe9\x83\x9a\x94\xc4\xaf\x844\x02" +
+	"\xe2\x86\xeb\xe4z\x0a9\x93\xfe4\xd2\x85|\xa1\xa5\xfd" +
+	"_\xd7\xc9u\xe7\xccn\xa2T\xb3\x14\x88q\x1e @" +
+	"=MM\x80~\x92$\xd2MbTE?\xb8F\x02" +
+	"\xd2\xf4\x1b\xa0\x9f\x12\xd0/\x80\x1dr\x8d\x18\xa0ZT" +
+	"\x0f\xe8\xbd\x02r\x02\xa4\xef\\#\x09Pm\xea\x02\xf4" +
+	"~\x01\xae\x80\xc0\x01\xd7(\x00\xa8\xc3\x1e\x14\x04\x8c\x0b" +
+	"\x08\xees\x8d\x82\x80:\xe6-w\x05L\x0a\x08}\xe3" +
+	"\x1a\x85\x00\xf5<\x1d\x07\xf4q\x013\x02\xc2{\\#" +
+	"\xf1\xb6\x8b^\xabI\x01\xb3\x02\"_\xb9F\x11@\xbd" +
+	"\xe4\xb5\x9a\x12P\"FJ%i\x14\x05\xd4\xcb\xde\x8a" +
+	"\x19\x91\xcf\x89<F\x1aU\x00\xeaUj\x03\xf4Y\x91" +
+	"/\x88\x0fE\xbfp\x8d*\x01u\xde\xdbP\x12pC" +
+	"@\xc5.\xd7(\x06\xa8\x8b\xde\xc4\x9c\x80\xdb\xc4\xa8\xfa" +
+	"\\\xbeh\xb9\x08\x85\x0bF\x86d\xfe>5\xf6\x97\xd1" +
+	"\xf9l\x13 \x92Aq\xbb\xcf6I\xe6\x8f\xea\x82{" +
+	"\xea\xc1\xfc\xcdr\xcc\x0d\xcbq\x93\xd9A\x17\x00\xc9\xfc" +
+	"\xef7\x1f\xa7\x1f\x16Jw|-\xa4\xdd\xfe#}\xfe" +
+	"\xeaz\xacm\xffw_\xab\x8bv\xc6I\x93\xccKk" +
+	"S=\xbb\x1b\x8d;\xfe\x94\xe9\x0cXc\xc9\xec \xc8" +
+	"\x15S\x9f\xd4\x83\xcd\xe5\x95\xa5\xb2\x86\x8bv\x96d\x1e" +
+	"\xf9\xa7\xa9XWs\xe6\x9d_\xcf\x1au\xf3$\xf3\xbe" +
+	"U6\xfd\xe4\xd7\xde\x97\xfe\x0a\xc7\x1aN\x98\x14\x05\xa3" +
+	"(h\xc2u\xd2\x86\x950\xa9\x0a\x8c\xaa@\xe1\xb41" +
+	"@2\x7f{\xbf\xf9u\xcb\xc2\xbd\xed\xf2\xcc\x841\x94" +
+	"\xb33\x8eM\xf2a\xfc\xda\xdd\xce\x17\xc3\xeb\xfeS\xcb" +
+	"\x07\xc8\xca\x07\xa8\xdb\xd9\xbce\xb6\xbbN\xdc?\xc3\x88" +
+	"\x14\x00\x02\x04(u\xf5@\xaaF\xa2T\x03#\x85\xc8" +
+	"\xbb@\xe5\x0f\x11\xd6J\x94jf\x14\xcf\xe4\x862~" +
+	"\x91x\xd1\xce\x8a\xf6\x15\x17\x8e}6\xe7\x92S\xe5&" +
+	"?\x07\x00L\xc9\xd0>"
 
 func init() {
 	schemas.Register(schema_df42b02816bdc1bf,
@@ -9,269 +9,624 @@ import (
 	schemas "zombiezen.com/go/capnproto2/schemas"
 )
 
-type DRKeyReq struct{ capnp.Struct }
-type DRKeyReq_flags DRKeyReq
+type DRKeyLvl1Req struct{ capnp.Struct }
 
-// DRKeyReq_TypeID is the unique identifier for the type DRKeyReq.
-const DRKeyReq_TypeID = 0x9f50d21c9d4ce7ef
+// DRKeyLvl1Req_TypeID is the unique identifier for the type DRKeyLvl1Req.
+const DRKeyLvl1Req_TypeID = 0xfa255f8dc1ac13e3
 
-func NewDRKeyReq(s *capnp.Segment) (DRKeyReq, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
-	return DRKeyReq{st}, err
+func NewDRKeyLvl1Req(s *capnp.Segment) (DRKeyLvl1Req, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
+	return DRKeyLvl1Req{st}, err
 }
 
-func NewRootDRKeyReq(s *capnp.Segment) (DRKeyReq, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
-	return DRKeyReq{st}, err
+func NewRootDRKeyLvl1Req(s *capnp.Segment) (DRKeyLvl1Req, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
+	return DRKeyLvl1Req{st}, err
 }
 
-func ReadRootDRKeyReq(msg *capnp.Message) (DRKeyReq, error) {
+func ReadRootDRKeyLvl1Req(msg *capnp.Message) (DRKeyLvl1Req, error) {
 	root, err := msg.RootPtr()
-	return DRKeyReq{root.Struct()}, err
+	return DRKeyLvl1Req{root.Struct()}, err
 }
 
-func (s DRKeyReq) String() string {
-	str, _ := text.Marshal(0x9f50d21c9d4ce7ef, s.Struct)
+func (s DRKeyLvl1Req) String() string {
+	str, _ := text.Marshal(0xfa255f8dc1ac13e3, s.Struct)
 	return str
 }
 
-func (s DRKeyReq) Isdas() uint64 {
+func (s DRKeyLvl1Req) DstIA() uint64 {
 	return s.Struct.Uint64(0)
 }
 
-func (s DRKeyReq) SetIsdas(v uint64) {
+func (s DRKeyLvl1Req) SetDstIA(v uint64) {
 	s.Struct.SetUint64(0, v)
 }
 
-func (s DRKeyReq) Timestamp() uint32 {
+func (s DRKeyLvl1Req) ValTime() uint32 {
 	return s.Struct.Uint32(8)
 }
 
-func (s DRKeyReq) SetTimestamp(v uint32) {
+func (s DRKeyLvl1Req) SetValTime(v uint32) {
 	s.Struct.SetUint32(8, v)
 }
 
-func (s DRKeyReq) Signature() ([]byte, error) {
+func (s DRKeyLvl1Req) Timestamp() uint32 {
+	return s.Struct.Uint32(12)
+}
+
+func (s DRKeyLvl1Req) SetTimestamp(v uint32) {
+	s.Struct.SetUint32(12, v)
+}
+
+func (s DRKeyLvl1Req) EphemeralKey() ([]byte, error) {
 	p, err := s.Struct.Ptr(0)
 	return []byte(p.Data()), err
 }
 
-func (s DRKeyReq) HasSignature() bool {
+func (s DRKeyLvl1Req) HasEphemeralKey() bool {
 	p, err := s.Struct.Ptr(0)
 	return p.IsValid() || err != nil
 }
 
-func (s DRKeyReq) SetSignature(v []byte) error {
+func (s DRKeyLvl1Req) SetEphemeralKey(v []byte) error {
 	return s.Struct.SetData(0, v)
 }
 
-func (s DRKeyReq) CertVer() uint32 {
-	return s.Struct.Uint32(12)
-}
-
-func (s DRKeyReq) SetCertVer(v uint32) {
-	s.Struct.SetUint32(12, v)
-}
-
-func (s DRKeyReq) TrcVer() uint32 {
-	return s.Struct.Uint32(16)
+func (s DRKeyLvl1Req) Sign() (Sign, error) {
+	p, err := s.Struct.Ptr(1)
+	return Sign{Struct: p.Struct()}, err
 }
 
-func (s DRKeyReq) SetTrcVer(v uint32) {
-	s.Struct.SetUint32(16, v)
+func (s DRKeyLvl1Req) HasSign() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
 }
 
-func (s DRKeyReq) Flags() DRKeyReq_flags { return DRKeyReq_flags(s) }
-
-func (s DRKeyReq_flags) Prefetch() bool {
-	return s.Struct.Bit(160)
+func (s DRKeyLvl1Req) SetSign(v Sign) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
 }
 
-func (s DRKeyReq_flags) SetPrefetch(v bool) {
-	s.Struct.SetBit(160, v)
+// NewSign sets the sign field to a newly
+// allocated Sign struct, preferring placement in s's segment.
+func (s DRKeyLvl1Req) NewSign() (Sign, error) {
+	ss, err := NewSign(s.Struct.Segment())
+	if err != nil {
+		return Sign{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
 }
 
-// DRKeyReq_List is a list of DRKeyReq.
-type DRKeyReq_List struct{ capnp.List }
+// DRKeyLvl1Req_List is a list of DRKeyLvl1Req.
+type DRKeyLvl1Req_List struct{ capnp.List }
 
-// NewDRKeyReq creates a new list of DRKeyReq.
-func NewDRKeyReq_List(s *capnp.Segment, sz int32) (DRKeyReq_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1}, sz)
-	return DRKeyReq_List{l}, err
+// NewDRKeyLvl1Req creates a new list of DRKeyLvl1Req.
+func NewDRKeyLvl1Req_List(s *capnp.Segment, sz int32) (DRKeyLvl1Req_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2}, sz)
+	return DRKeyLvl1Req_List{l}, err
 }
 
-func (s DRKeyReq_List) At(i int) DRKeyReq { return DRKeyReq{s.List.Struct(i)} }
+func (s DRKeyLvl1Req_List) At(i int) DRKeyLvl1Req { return DRKeyLvl1Req{s.List.Struct(i)} }
 
-func (s DRKeyReq_List) Set(i int, v DRKeyReq) error { return s.List.SetStruct(i, v.Struct) }
+func (s DRKeyLvl1Req_List) Set(i int, v DRKeyLvl1Req) error { return s.List.SetStruct(i, v.Struct) }
 
-func (s DRKeyReq_List) String() string {
-	str, _ := text.MarshalList(0x9f50d21c9d4ce7ef, s.List)
+func (s DRKeyLvl1Req_List) String() string {
+	str, _ := text.MarshalList(0xfa255f8dc1ac13e3, s.List)
 	return str
 }
 
-// DRKeyReq_Promise is a wrapper for a DRKeyReq promised by a client call.
-type DRKeyReq_Promise struct{ *capnp.Pipeline }
+// DRKeyLvl1Req_Promise is a wrapper for a DRKeyLvl1Req promised by a client call.
+type DRKeyLvl1Req_Promise struct{ *capnp.Pipeline }
 
-func (p DRKeyReq_Promise) Struct() (DRKeyReq, error) {
+func (p DRKeyLvl1Req_Promise) Struct() (DRKeyLvl1Req, error) {
 	s, err := p.Pipeline.Struct()
-	return DRKeyReq{s}, err
+	return DRKeyLvl1Req{s}, err
 }
 
-func (p DRKeyReq_Promise) Flags() DRKeyReq_flags_Promise { return DRKeyReq_flags_Promise{p.Pipeline} }
-
-// DRKeyReq_flags_Promise is a wrapper for a DRKeyReq_flags promised by a client call.
-type DRKeyReq_flags_Promise struct{ *capnp.Pipeline }
-
-func (p DRKeyReq_flags_Promise) Struct() (DRKeyReq_flags, error) {
-	s, err := p.Pipeline.Struct()
-	return DRKeyReq_flags{s}, err
+func (p DRKeyLvl1Req_Promise) Sign() Sign_Promise {
+	return Sign_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
 }
 
-type DRKeyRep struct{ capnp.Struct }
+type DRKeyLvl1Rep struct{ capnp.Struct }
 
-// DRKeyRep_TypeID is the unique identifier for the type DRKeyRep.
-const DRKeyRep_TypeID = 0xc3fe25dd82681d64
+// DRKeyLvl1Rep_TypeID is the unique identifier for the type DRKeyLvl1Rep.
+const DRKeyLvl1Rep_TypeID = 0xd70d7b2bf8abab14
 
-func NewDRKeyRep(s *capnp.Segment) (DRKeyRep, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2})
-	return DRKeyRep{st}, err
+func NewDRKeyLvl1Rep(s *capnp.Segment) (DRKeyLvl1Rep, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
+	return DRKeyLvl1Rep{st}, err
 }
 
-func NewRootDRKeyRep(s *capnp.Segment) (DRKeyRep, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2})
-	return DRKeyRep{st}, err
+func NewRootDRKeyLvl1Rep(s *capnp.Segment) (DRKeyLvl1Rep, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
+	return DRKeyLvl1Rep{st}, err
 }
 
-func ReadRootDRKeyRep(msg *capnp.Message) (DRKeyRep, error) {
+func ReadRootDRKeyLvl1Rep(msg *capnp.Message) (DRKeyLvl1Rep, error) {
 	root, err := msg.RootPtr()
-	return DRKeyRep{root.Struct()}, err
+	return DRKeyLvl1Rep{root.Struct()}, err
 }
 
-func (s DRKeyRep) String() string {
-	str, _ := text.Marshal(0xc3fe25dd82681d64, s.Struct)
+func (s DRKeyLvl1Rep) String() string {
+	str, _ := text.Marshal(0xd70d7b2bf8abab14, s.Struct)
 	return str
 }
 
-func (s DRKeyRep) Isdas() uint64 {
+func (s DRKeyLvl1Rep) DstIA() uint64 {
 	return s.Struct.Uint64(0)
 }
 
-func (s DRKeyRep) SetIsdas(v uint64) {
+func (s DRKeyLvl1Rep) SetDstIA(v uint64) {
 	s.Struct.SetUint64(0, v)
 }
 
-func (s DRKeyRep) Timestamp() uint32 {
+func (s DRKeyLvl1Rep) EpochBegin() uint32 {
 	return s.Struct.Uint32(8)
 }
 
-func (s DRKeyRep) SetTimestamp(v uint32) {
+func (s DRKeyLvl1Rep) SetEpochBegin(v uint32) {
 	s.Struct.SetUint32(8, v)
 }
 
-func (s DRKeyRep) ExpTime() uint32 {
+func (s DRKeyLvl1Rep) EpochEnd() uint32 {
 	return s.Struct.Uint32(12)
 }
 
-func (s DRKeyRep) SetExpTime(v uint32) {
+func (s DRKeyLvl1Rep) SetEpochEnd(v uint32) {
 	s.Struct.SetUint32(12, v)
 }
 
-func (s DRKeyRep) Cipher() ([]byte, error) {
+func (s DRKeyLvl1Rep) Cipher() ([]byte, error) {
 	p, err := s.Struct.Ptr(0)
 	return []byte(p.Data()), err
 }
 
-func (s DRKeyRep) HasCipher() bool {
+func (s DRKeyLvl1Rep) HasCipher() bool {
 	p, err := s.Struct.Ptr(0)
 	return p.IsValid() || err != nil
 }
 
-func (s DRKeyRep) SetCipher(v []byte) error {
+func (s DRKeyLvl1Rep) SetCipher(v []byte) error {
 	return s.Struct.SetData(0, v)
 }
 
-func (s DRKeyRep) Signature() ([]byte, error) {
+func (s DRKeyLvl1Rep) Nonce() ([]byte, error) {
 	p, err := s.Struct.Ptr(1)
 	return []byte(p.Data()), err
 }
 
-func (s DRKeyRep) HasSignature() bool {
+func (s DRKeyLvl1Rep) HasNonce() bool {
 	p, err := s.Struct.Ptr(1)
 	return p.IsValid() || err != nil
 }
 
-func (s DRKeyRep) SetSignature(v []byte) error {
+func (s DRKeyLvl1Rep) SetNonce(v []byte) error {
 	return s.Struct.SetData(1, v)
 }
 
-func (s DRKeyRep) CertVerSrc() uint32 {
+func (s DRKeyLvl1Rep) EphemeralKey() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s DRKeyLvl1Rep) HasEphemeralKey() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl1Rep) SetEphemeralKey(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
+func (s DRKeyLvl1Rep) Timestamp() uint32 {
 	return s.Struct.Uint32(16)
 }
 
-func (s DRKeyRep) SetCertVerSrc(v uint32) {
+func (s DRKeyLvl1Rep) SetTimestamp(v uint32) {
 	s.Struct.SetUint32(16, v)
 }
 
-func (s DRKeyRep) CertVerDst() uint32 {
-	return s.Struct.Uint32(20)
+func (s DRKeyLvl1Rep) Sign() (Sign, error) {
+	p, err := s.Struct.Ptr(3)
+	return Sign{Struct: p.Struct()}, err
 }
 
-func (s DRKeyRep) SetCertVerDst(v uint32) {
-	s.Struct.SetUint32(20, v)
+func (s DRKeyLvl1Rep) HasSign() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
 }
 
-func (s DRKeyRep) TrcVer() uint32 {
-	return s.Struct.Uint32(24)
+func (s DRKeyLvl1Rep) SetSign(v Sign) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
 }
 
-func (s DRKeyRep) SetTrcVer(v uint32) {
-	s.Struct.SetUint32(24, v)
+// NewSign sets the sign field to a newly
+// allocated Sign struct, preferring placement in s's segment.
+func (s DRKeyLvl1Rep) NewSign() (Sign, error) {
+	ss, err := NewSign(s.Struct.Segment())
+	if err != nil {
+		return Sign{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
 }
 
-// DRKeyRep_List is a list of DRKeyRep.
-type DRKeyRep_List struct{ capnp.List }
+// DRKeyLvl1Rep_List is a list of DRKeyLvl1Rep.
+type DRKeyLvl1Rep_List struct{ capnp.List }
 
-// NewDRKeyRep creates a new list of DRKeyRep.
-func NewDRKeyRep_List(s *capnp.Segment, sz int32) (DRKeyRep_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2}, sz)
-	return DRKeyRep_List{l}, err
+// NewDRKeyLvl1Rep creates a new list of DRKeyLvl1Rep.
+func NewDRKeyLvl1Rep_List(s *capnp.Segment, sz int32) (DRKeyLvl1Rep_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4}, sz)
+	return DRKeyLvl1Rep_List{l}, err
 }
 
-func (s DRKeyRep_List) At(i int) DRKeyRep { return DRKeyRep{s.List.Struct(i)} }
+func (s DRKeyLvl1Rep_List) At(i int) DRKeyLvl1Rep { return DRKeyLvl1Rep{s.List.Struct(i)} }
 
-func (s DRKeyRep_List) Set(i int, v DRKeyRep) error { return s.List.SetStruct(i, v.Struct) }
+func (s DRKeyLvl1Rep_List) Set(i int, v DRKeyLvl1Rep) error { return s.List.SetStruct(i, v.Struct) }
 
-func (s DRKeyRep_List) String() string {
-	str, _ := text.MarshalList(0xc3fe25dd82681d64, s.List)
+func (s DRKeyLvl1Rep_List) String() string {
+	str, _ := text.MarshalList(0xd70d7b2bf8abab14, s.List)
 	return str
 }
 
-// DRKeyRep_Promise is a wrapper for a DRKeyRep promised by a client call.
-type DRKeyRep_Promise struct{ *capnp.Pipeline }
+// DRKeyLvl1Rep_Promise is a wrapper for a DRKeyLvl1Rep promised by a client call.
+type DRKeyLvl1Rep_Promise struct{ *capnp.Pipeline }
 
-func (p DRKeyRep_Promise) Struct() (DRKeyRep, error) {
+func (p DRKeyLvl1Rep_Promise) Struct() (DRKeyLvl1Rep, error) {
 	s, err := p.Pipeline.Struct()
-	return DRKeyRep{s}, err
+	return DRKeyLvl1Rep{s}, err
+}
+
+func (p DRKeyLvl1Rep_Promise) Sign() Sign_Promise {
+	return Sign_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+type DRKeyHost struct{ capnp.Struct }
+
+// DRKeyHost_TypeID is the unique identifier for the type DRKeyHost.
+const DRKeyHost_TypeID = 0x929b462d5a0499b7
+
+func NewDRKeyHost(s *capnp.Segment) (DRKeyHost, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return DRKeyHost{st}, err
+}
+
+func NewRootDRKeyHost(s *capnp.Segment) (DRKeyHost, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return DRKeyHost{st}, err
+}
+
+func ReadRootDRKeyHost(msg *capnp.Message) (DRKeyHost, error) {
+	root, err := msg.RootPtr()
+	return DRKeyHost{root.Struct()}, err
+}
+
+func (s DRKeyHost) String() string {
+	str, _ := text.Marshal(0x929b462d5a0499b7, s.Struct)
+	return str
+}
+
+func (s DRKeyHost) Type() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s DRKeyHost) SetType(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s DRKeyHost) Host() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s DRKeyHost) HasHost() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyHost) SetHost(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+// DRKeyHost_List is a list of DRKeyHost.
+type DRKeyHost_List struct{ capnp.List }
+
+// NewDRKeyHost creates a new list of DRKeyHost.
+func NewDRKeyHost_List(s *capnp.Segment, sz int32) (DRKeyHost_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return DRKeyHost_List{l}, err
+}
+
+func (s DRKeyHost_List) At(i int) DRKeyHost { return DRKeyHost{s.List.Struct(i)} }
+
+func (s DRKeyHost_List) Set(i int, v DRKeyHost) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DRKeyHost_List) String() string {
+	str, _ := text.MarshalList(0x929b462d5a0499b7, s.List)
+	return str
+}
+
+// DRKeyHost_Promise is a wrapper for a DRKeyHost promised by a client call.
+type DRKeyHost_Promise struct{ *capnp.Pipeline }
+
+func (p DRKeyHost_Promise) Struct() (DRKeyHost, error) {
+	s, err := p.Pipeline.Struct()
+	return DRKeyHost{s}, err
+}
+
+type DRKeyLvl2Req struct{ capnp.Struct }
+
+// DRKeyLvl2Req_TypeID is the unique identifier for the type DRKeyLvl2Req.
+const DRKeyLvl2Req_TypeID = 0xe5a448baf4040d94
+
+func NewDRKeyLvl2Req(s *capnp.Segment) (DRKeyLvl2Req, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return DRKeyLvl2Req{st}, err
+}
+
+func NewRootDRKeyLvl2Req(s *capnp.Segment) (DRKeyLvl2Req, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return DRKeyLvl2Req{st}, err
+}
+
+func ReadRootDRKeyLvl2Req(msg *capnp.Message) (DRKeyLvl2Req, error) {
+	root, err := msg.RootPtr()
+	return DRKeyLvl2Req{root.Struct()}, err
+}
+
+func (s DRKeyLvl2Req) String() string {
+	str, _ := text.Marshal(0xe5a448baf4040d94, s.Struct)
+	return str
+}
+
+func (s DRKeyLvl2Req) Protocol() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s DRKeyLvl2Req) HasProtocol() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Req) ProtocolBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s DRKeyLvl2Req) SetProtocol(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s DRKeyLvl2Req) ReqType() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s DRKeyLvl2Req) SetReqType(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s DRKeyLvl2Req) ValTime() uint32 {
+	return s.Struct.Uint32(4)
+}
+
+func (s DRKeyLvl2Req) SetValTime(v uint32) {
+	s.Struct.SetUint32(4, v)
+}
+
+func (s DRKeyLvl2Req) SrcIA() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s DRKeyLvl2Req) SetSrcIA(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s DRKeyLvl2Req) DstIA() uint64 {
+	return s.Struct.Uint64(16)
+}
+
+func (s DRKeyLvl2Req) SetDstIA(v uint64) {
+	s.Struct.SetUint64(16, v)
+}
+
+func (s DRKeyLvl2Req) SrcHost() (DRKeyHost, error) {
+	p, err := s.Struct.Ptr(1)
+	return DRKeyHost{Struct: p.Struct()}, err
+}
+
+func (s DRKeyLvl2Req) HasSrcHost() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Req) SetSrcHost(v DRKeyHost) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewSrcHost sets the srcHost field to a newly
+// allocated DRKeyHost struct, preferring placement in s's segment.
+func (s DRKeyLvl2Req) NewSrcHost() (DRKeyHost, error) {
+	ss, err := NewDRKeyHost(s.Struct.Segment())
+	if err != nil {
+		return DRKeyHost{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DRKeyLvl2Req) DstHost() (DRKeyHost, error) {
+	p, err := s.Struct.Ptr(2)
+	return DRKeyHost{Struct: p.Struct()}, err
+}
+
+func (s DRKeyLvl2Req) HasDstHost() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Req) SetDstHost(v DRKeyHost) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewDstHost sets the dstHost field to a newly
+// allocated DRKeyHost struct, preferring placement in s's segment.
+func (s DRKeyLvl2Req) NewDstHost() (DRKeyHost, error) {
+	ss, err := NewDRKeyHost(s.Struct.Segment())
+	if err != nil {
+		return DRKeyHost{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// DRKeyLvl2Req_List is a list of DRKeyLvl2Req.
+type DRKeyLvl2Req_List struct{ capnp.List }
+
+// NewDRKeyLvl2Req creates a new list of DRKeyLvl2Req.
+func NewDRKeyLvl2Req_List(s *capnp.Segment, sz int32) (DRKeyLvl2Req_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3}, sz)
+	return DRKeyLvl2Req_List{l}, err
+}
+
+func (s DRKeyLvl2Req_List) At(i int) DRKeyLvl2Req { return DRKeyLvl2Req{s.List.Struct(i)} }
+
+func (s DRKeyLvl2Req_List) Set(i int, v DRKeyLvl2Req) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DRKeyLvl2Req_List) String() string {
+	str, _ := text.MarshalList(0xe5a448baf4040d94, s.List)
+	return str
+}
+
+// DRKeyLvl2Req_Promise is a wrapper for a DRKeyLvl2Req promised by a client call.
+type DRKeyLvl2Req_Promise struct{ *capnp.Pipeline }
+
+func (p DRKeyLvl2Req_Promise) Struct() (DRKeyLvl2Req, error) {
+	s, err := p.Pipeline.Struct()
+	return DRKeyLvl2Req{s}, err
+}
+
+func (p DRKeyLvl2Req_Promise) SrcHost() DRKeyHost_Promise {
+	return DRKeyHost_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p DRKeyLvl2Req_Promise) DstHost() DRKeyHost_Promise {
+	return DRKeyHost_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+type DRKeyLvl2Rep struct{ capnp.Struct }
+
+// DRKeyLvl2Rep_TypeID is the unique identifier for the type DRKeyLvl2Rep.
+const DRKeyLvl2Rep_TypeID = 0xdfa735fef0302b84
+
+func NewDRKeyLvl2Rep(s *capnp.Segment) (DRKeyLvl2Rep, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return DRKeyLvl2Rep{st}, err
+}
+
+func NewRootDRKeyLvl2Rep(s *capnp.Segment) (DRKeyLvl2Rep, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return DRKeyLvl2Rep{st}, err
+}
+
+func ReadRootDRKeyLvl2Rep(msg *capnp.Message) (DRKeyLvl2Rep, error) {
+	root, err := msg.RootPtr()
+	return DRKeyLvl2Rep{root.Struct()}, err
+}
+
+func (s DRKeyLvl2Rep) String() string {
+	str, _ := text.Marshal(0xdfa735fef0302b84, s.Struct)
+	return str
+}
+
+func (s DRKeyLvl2Rep) Timestamp() uint32 {
+	return s.Struct.Uint32(0)
+}
+
+func (s DRKeyLvl2Rep) SetTimestamp(v uint32) {
+	s.Struct.SetUint32(0, v)
+}
+
+func (s DRKeyLvl2Rep) Drkey() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s DRKeyLvl2Rep) HasDrkey() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyLvl2Rep) SetDrkey(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s DRKeyLvl2Rep) EpochBegin() uint32 {
+	return s.Struct.Uint32(4)
+}
+
+func (s DRKeyLvl2Rep) SetEpochBegin(v uint32) {
+	s.Struct.SetUint32(4, v)
+}
+
+func (s DRKeyLvl2Rep) EpochEnd() uint32 {
+	return s.Struct.Uint32(8)
+}
+
+func (s DRKeyLvl2Rep) SetEpochEnd(v uint32) {
+	s.Struct.SetUint32(8, v)
+}
+
+// DRKeyLvl2Rep_List is a list of DRKeyLvl2Rep.
+type DRKeyLvl2Rep_List struct{ capnp.List }
+
+// NewDRKeyLvl2Rep creates a new list of DRKeyLvl2Rep.
+func NewDRKeyLvl2Rep_List(s *capnp.Segment, sz int32) (DRKeyLvl2Rep_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return DRKeyLvl2Rep_List{l}, err
+}
+
+func (s DRKeyLvl2Rep_List) At(i int) DRKeyLvl2Rep { return DRKeyLvl2Rep{s.List.Struct(i)} }
+
+func (s DRKeyLvl2Rep_List) Set(i int, v DRKeyLvl2Rep) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DRKeyLvl2Rep_List) String() string {
+	str, _ := text.MarshalList(0xdfa735fef0302b84, s.List)
+	return str
+}
+
+// DRKeyLvl2Rep_Promise is a wrapper for a DRKeyLvl2Rep promised by a client call.
+type DRKeyLvl2Rep_Promise struct{ *capnp.Pipeline }
+
+func (p DRKeyLvl2Rep_Promise) Struct() (DRKeyLvl2Rep, error) {
+	s, err := p.Pipeline.Struct()
+	return DRKeyLvl2Rep{s}, err
 }
 
 type DRKeyMgmt struct{ capnp.Struct }
 type DRKeyMgmt_Which uint16
 
 const (
-	DRKeyMgmt_Which_unset    DRKeyMgmt_Which = 0
-	DRKeyMgmt_Which_drkeyReq DRKeyMgmt_Which = 1
-	DRKeyMgmt_Which_drkeyRep DRKeyMgmt_Which = 2
+	DRKeyMgmt_Which_unset        DRKeyMgmt_Which = 0
+	DRKeyMgmt_Which_drkeyLvl1Req DRKeyMgmt_Which = 1
+	DRKeyMgmt_Which_drkeyLvl1Rep DRKeyMgmt_Which = 2
+	DRKeyMgmt_Which_drkeyLvl2Req DRKeyMgmt_Which = 3
+	DRKeyMgmt_Which_drkeyLvl2Rep DRKeyMgmt_Which = 4
 )
 
 func (w DRKeyMgmt_Which) String() string {
-	const s = "unsetdrkeyReqdrkeyRep"
+	const s = "unsetdrkeyLvl1ReqdrkeyLvl1RepdrkeyLvl2ReqdrkeyLvl2Rep"
 	switch w {
 	case DRKeyMgmt_Which_unset:
 		return s[0:5]
-	case DRKeyMgmt_Which_drkeyReq:
-		return s[5:13]
-	case DRKeyMgmt_Which_drkeyRep:
-		return s[13:21]
+	case DRKeyMgmt_Which_drkeyLvl1Req:
+		return s[5:17]
+	case DRKeyMgmt_Which_drkeyLvl1Rep:
+		return s[17:29]
+	case DRKeyMgmt_Which_drkeyLvl2Req:
+		return s[29:41]
+	case DRKeyMgmt_Which_drkeyLvl2Rep:
+		return s[41:53]
 
 	}
 	return "DRKeyMgmt_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -308,15 +663,15 @@ func (s DRKeyMgmt) SetUnset() {
 
 }
 
-func (s DRKeyMgmt) DrkeyReq() (DRKeyReq, error) {
+func (s DRKeyMgmt) DrkeyLvl1Req() (DRKeyLvl1Req, error) {
 	if s.Struct.Uint16(0) != 1 {
-		panic("Which() != drkeyReq")
+		panic("Which() != drkeyLvl1Req")
 	}
 	p, err := s.Struct.Ptr(0)
-	return DRKeyReq{Struct: p.Struct()}, err
+	return DRKeyLvl1Req{Struct: p.Struct()}, err
 }
 
-func (s DRKeyMgmt) HasDrkeyReq() bool {
+func (s DRKeyMgmt) HasDrkeyLvl1Req() bool {
 	if s.Struct.Uint16(0) != 1 {
 		return false
 	}
@@ -324,32 +679,32 @@ func (s DRKeyMgmt) HasDrkeyReq() bool {
 	return p.IsValid() || err != nil
 }
 
-func (s DRKeyMgmt) SetDrkeyReq(v DRKeyReq) error {
+func (s DRKeyMgmt) SetDrkeyLvl1Req(v DRKeyLvl1Req) error {
 	s.Struct.SetUint16(0, 1)
 	return s.Struct.SetPtr(0, v.Struct.ToPtr())
 }
 
-// NewDrkeyReq sets the drkeyReq field to a newly
-// allocated DRKeyReq struct, preferring placement in s's segment.
-func (s DRKeyMgmt) NewDrkeyReq() (DRKeyReq, error) {
+// NewDrkeyLvl1Req sets the drkeyLvl1Req field to a newly
+// allocated DRKeyLvl1Req struct, preferring placement in s's segment.
+func (s DRKeyMgmt) NewDrkeyLvl1Req() (DRKeyLvl1Req, error) {
 	s.Struct.SetUint16(0, 1)
-	ss, err := NewDRKeyReq(s.Struct.Segment())
+	ss, err := NewDRKeyLvl1Req(s.Struct.Segment())
 	if err != nil {
-		return DRKeyReq{}, err
+		return DRKeyLvl1Req{}, err
 	}
 	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
 	return ss, err
 }
 
-func (s DRKeyMgmt) DrkeyRep() (DRKeyRep, error) {
+func (s DRKeyMgmt) DrkeyLvl1Rep() (DRKeyLvl1Rep, error) {
 	if s.Struct.Uint16(0) != 2 {
-		panic("Which() != drkeyRep")
+		panic("Which() != drkeyLvl1Rep")
 	}
 	p, err := s.Struct.Ptr(0)
-	return DRKeyRep{Struct: p.Struct()}, err
+	return DRKeyLvl1Rep{Struct: p.Struct()}, err
 }
 
-func (s DRKeyMgmt) HasDrkeyRep() bool {
+func (s DRKeyMgmt) HasDrkeyLvl1Rep() bool {
 	if s.Struct.Uint16(0) != 2 {
 		return false
 	}
@@ -357,18 +712,84 @@ func (s DRKeyMgmt) HasDrkeyRep() bool {
 	return p.IsValid() || err != nil
 }
 
-func (s DRKeyMgmt) SetDrkeyRep(v DRKeyRep) error {
+func (s DRKeyMgmt) SetDrkeyLvl1Rep(v DRKeyLvl1Rep) error {
 	s.Struct.SetUint16(0, 2)
 	return s.Struct.SetPtr(0, v.Struct.ToPtr())
 }
 
-// NewDrkeyRep sets the drkeyRep field to a newly
-// allocated DRKeyRep struct, preferring placement in s's segment.
-func (s DRKeyMgmt) NewDrkeyRep() (DRKeyRep, error) {
+// NewDrkeyLvl1Rep sets the drkeyLvl1Rep field to a newly
+// allocated DRKeyLvl1Rep struct, preferring placement in s's segment.
+func (s DRKeyMgmt) NewDrkeyLvl1Rep() (DRKeyLvl1Rep, error) {
 	s.Struct.SetUint16(0, 2)
-	ss, err := NewDRKeyRep(s.Struct.Segment())
+	ss, err := NewDRKeyLvl1Rep(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl1Rep{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DRKeyMgmt) DrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	if s.Struct.Uint16(0) != 3 {
+		panic("Which() != drkeyLvl2Req")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Req{Struct: p.Struct()}, err
+}
+
+func (s DRKeyMgmt) HasDrkeyLvl2Req() bool {
+	if s.Struct.Uint16(0) != 3 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyMgmt) SetDrkeyLvl2Req(v DRKeyLvl2Req) error {
+	s.Struct.SetUint16(0, 3)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Req sets the drkeyLvl2Req field to a newly
+// allocated DRKeyLvl2Req struct, preferring placement in s's segment.
+func (s DRKeyMgmt) NewDrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	s.Struct.SetUint16(0, 3)
+	ss, err := NewDRKeyLvl2Req(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl2Req{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DRKeyMgmt) DrkeyLvl2Rep() (DRKeyLvl2Rep, error) {
+	if s.Struct.Uint16(0) != 4 {
+		panic("Which() != drkeyLvl2Rep")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Rep{Struct: p.Struct()}, err
+}
+
+func (s DRKeyMgmt) HasDrkeyLvl2Rep() bool {
+	if s.Struct.Uint16(0) != 4 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DRKeyMgmt) SetDrkeyLvl2Rep(v DRKeyLvl2Rep) error {
+	s.Struct.SetUint16(0, 4)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Rep sets the drkeyLvl2Rep field to a newly
+// allocated DRKeyLvl2Rep struct, preferring placement in s's segment.
+func (s DRKeyMgmt) NewDrkeyLvl2Rep() (DRKeyLvl2Rep, error) {
+	s.Struct.SetUint16(0, 4)
+	ss, err := NewDRKeyLvl2Rep(s.Struct.Segment())
 	if err != nil {
-		return DRKeyRep{}, err
+		return DRKeyLvl2Rep{}, err
 	}
 	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
 	return ss, err
@@ -400,59 +821,93 @@ func (p DRKeyMgmt_Promise) Struct() (DRKeyMgmt, error) {
 	return DRKeyMgmt{s}, err
 }
 
-func (p DRKeyMgmt_Promise) DrkeyReq() DRKeyReq_Promise {
-	return DRKeyReq_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
-}
-
-func (p DRKeyMgmt_Promise) DrkeyRep() DRKeyRep_Promise {
-	return DRKeyRep_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
-}
-
-const schema_f85d2602085656c1 = "x\xda\x84\x92OHT]\x18\xc6\xdf\xe7}\xef\x9dQ" +
-	"p`\x0e3\x1f\xc8\xc7\xf7a\x9b\x846\x92\xbas\xa3" +
-	"\x84B\x7f\x0c<\xa3(D\x12\xc3x\x1c\x87\x1a\xb9\xde" +
-	"{\x85\xdc$\xb5k\xd1\xaeM\x90d\x90\xe0\xa2(\xa1" +
-	"\xa8E-\xa2E\x10m\x1a*,\x12\x12\x94Z$\x15" +
-	"\x14\x08\xa17\x8eW\xc7!\x94v3\xcfy\xce{\x9f" +
-	"\xe7\xfc\xde\xc3C\xe8\xe2V\xb7\x08\"\x9dv\x13\xd1\xd7" +
-	"O\xbd3\xffU\xfan\x90V\x90\xe8\xe9\xe0`\x1d7" +
-	"\x0f\xaf\x93\x8b$\x91\xbaVQsI\xa2\xd6\x9bC " +
-	"D/\xbeg~\xbd}\xf8d\xc1Z\xb1k\xedAR" +
-	"\x882\xef\xf0>\xb3joe\x96q\x97\x10\x8d\xfc?" +
-	"vi\xe9\xe0\xe63\xebvj\x06\xb3\xb5\\\xe4J\xe6" +
-	"\x8a\xfd\xd5~\x99\x9f\xdb\xd9\x8f\x9b\x0f\x05\x17\xd6\xe6+" +
-	"\xa4\xff\x85\xec\x86\xfa\x07I\x10\xb5\x0f;\x0cB&\xef" +
-	"t\xda\xc1\xfeY3u\xa6\\\xe4r\xd8R\xc8{\xe3" +
-	"^Gw\xee\x84\x99\xca\x19L\xf4\x01\xbaQ\x1c\"\x07" +
-	"6}\x1b\x91\xbe*\xd0\xb3\x0c\x05\xce\xc2\x8a39\"" +
-	"}]\xa0\xe7\x19Vc\"5g\xb5[\x02}\x8f\xa1" +
-	"D\xb2\x10\"u\xe7\x08\x91\x9e\x17\xe8\xfb\x0c\xe58Y" +
-	"8Dj\xa1\x83H\xdf\x16\xe8G\x0c\xb8\xa8\xc9\xad\x1e" +
-	"\xb4\x117\x95\x82\x91|\x80zb\xd4\x13\xa2\xb0T6" +
-	"A\x98/\x13<\xd4\x11\xa3\x8e\x10\x05\xa5\xe2x>\x9c" +
-	"\xf4\x09\x06)b\xa4\x08\xd3\x05\xe3\x87\x83\xc6\xdf\xf1t" +
-	"\x86~\xa1\xe6o\xd3\xe8\xb9|1\xd8\xaf\xf6\xc9\xa2\x94" +
-	"C\xdb\xbbA\x9c\x86(\xda*\xdec\x8bw\x09t/" +
-	"#\x85\xcd(n~\xec8\x91>*\xd0\x03\x8c\x14o" +
-	"Dqwm\xd5>\x81>\xcdh\x9a\x1c\x0fLH\x89" +
-	"\xf8[93ADH\xef\xd2  \xbd\x03 g\xbc" +
-	"\xf8\xb4\xca\xb9\xf6tO<\x9e\x8dy\xa0\x8a\xe7\x95M" +
-	"\xf9R\xa0\x17k\xf0\xbc\xb1(^\x0b\xf4G\x86b\x89" +
-	"3.Y\x14\x8b\x02\xbd\xc2\xc06\x9eeK\xe2\x83@" +
-	"\x7f\xb6x\x10\xe3Y\xb5\xb7W\x04\xfa\x1bC\xb9N\x16" +
-	".\x91Z;E\xa4\xbf\x08\xf4:C%\xdc,\x12D" +
-	"\xea\xa7\x15\x7f\x08r`\xa8d\"\xbb\xb5\xee\x1bv\xe6" +
-	"\xba\xa0\xdf\x01\xe3\xef,\xa7\xcdyo\xa0T6Un" +
-	"\x85\x927f\xfc\x1d\xac{\xa1\x8e\xb6Q\xf7\x93\xf8\x85" +
-	"\xeaNl\x8b\xdd$A\xb8\xcf\x12T\x9fU\xfe|\xd6" +
-	"\x89\x96\xad\xfd \xed\x88\x93\x9e\x8d+\xa7,\xd4\x06\x81" +
-	"ndD\x9eoFMX\x18\xb3\xb8@\x0c\x10~\x07" +
-	"\x00\x00\xff\xff\x9fS\xf7\xb5"
+func (p DRKeyMgmt_Promise) DrkeyLvl1Req() DRKeyLvl1Req_Promise {
+	return DRKeyLvl1Req_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p DRKeyMgmt_Promise) DrkeyLvl1Rep() DRKeyLvl1Rep_Promise {
+	return DRKeyLvl1Rep_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p DRKeyMgmt_Promise) DrkeyLvl2Req() DRKeyLvl2Req_Promise {
+	return DRKeyLvl2Req_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p DRKeyMgmt_Promise) DrkeyLvl2Rep() DRKeyLvl2Rep_Promise {
+	return DRKeyLvl2Rep_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+const schema_f85d2602085656c1 = "x\xda\x94U]\x88\x94\xe5\x17?\xbf\xe7yg\xce\xfe" +
+	"\xff8\xec<\xbc\x03)\x08\xd3M\x91k\x85;[7" +
+	"\x12dC\x86\xf9\x11{v\xc5\x0b!d\x99y\xd9\x8f" +
+	"\xe6{Fs(Y\x84\x15\xecB\xb2\xf0F\xd2;\xa5" +
+	"\xc4\x02\xbd\x08-\x08Z0j\xa3\x8b\x84\xb6\\Qj" +
+	")I\xef\xdce\x835?\xdex\xe6\x9d\x9d\x8f\xd5i" +
+	"\x13\xdes\xf3<\xe7<\xe7\x9c\xdf\xf9\xfd\xce\xbbaB" +
+	"mR\xbd\xa1'\x14\x91\xac\x0e\x85\xfd\x0b\xc7\x9d\xdd\xcf" +
+	"\xbd\xf6\xd1\x87$\x06\xf0'w\xed\xeaRO\xbf\xb9H" +
+	"!0\x91\x99\xbbj\xees\xed{\x9b\xc8\xad\x82\xfd\xef" +
+	"\xe7\xdc\xbb?_\xf8\xea\xfc2\xf7\xcd\xe0\x10\x91\xeb\xe1" +
+	"\xaa[\x04[\xeb+\"\x0e\"\xf7\xbab?v\xf6\xec" +
+	"\xe2\xfaw\"\xbf\xd8 \xdd\x92\xc3a\"wJ\xcd\xbb" +
+	"\xd3\x8a\xad\xf5M\xabom\xcc-\x87\xfd\x89\xf5\x1bn" +
+	"?x\xf1\xe3_m\x8cZV\x97;\xed\xcc\xbb\xb3\x0e" +
+	"\xd7\xedO\x1b\x12b\xffX\xc4Y\xf8r\xcb\xa9\x1b\xcb" +
+	"\xd3\xe8ZHh\xde\x9d\x0d\xb1\xb5\xbe\xd9\xd0\xfb6\xcd" +
+	"yf\xffw\xf7\xd3\xc9#{\x9e\xfa{y\x1aec" +
+	"N\xf2\xbc{\x86\xd9Z\xdf\x19\xae\xb5\xf3W\x17\xfb\xe9" +
+	"\xd2[^uOvXe+\xcf\xa7\x86\x0a\xb9\xc2\xc6" +
+	"W\x07\xb6y\xd5-y]\xae\xf4\x03\xfdP\xd2\xa5\x1d" +
+	"\"\x07Df]\x8fY\xc7\xf2\x8c\x86\xbc\xa0\x00\xc4`" +
+	"\x0f{{L/\xcb\x06\x0dyI\xa1\xbbR-x\xfd" +
+	"P\x08\x935t\x8f\xe4\xed;\x0a\x11\xb2\x86M\xe8\x94" +
+	"q\xc7\xb0\xce.e\x8cig\x95\xef\xd7R\x1eH\x98" +
+	"\x03,\xefj\xc8a\x85\x08\x1e\xf8A\xd2Cc\xe6=" +
+	"\x96\xc3\x1arB!\xa2\xee\xfb1(\"s|\xcc\x9c" +
+	"d9\xa1!\xe7\x14\"\xfa\x9e\x1f\x83&2\x9f\x8d\x99" +
+	"\xf3,\xe74\xe4\x92B\xc4\xb9\xeb\xc7\xe0\x10\x99\xc91" +
+	"\xf3\x0d\xcb%\x0d\xb9\xa2\x10\xdf\x9b+{\xb6T\x0a\x07" +
+	"%n\xdf\x97\xa1\xee\xde\x01\xafh\xcb\x8f6\xc1%\xda" +
+	"\x04\x03\xeeW@\x94\xd0\xee[\x08|\x1b\x1c\xe9\xe8\x9b" +
+	"h\xbc\xdb\x18\xf4\xbf\xf9\xd6\xdfm\xf0\xa8\xdd\xb73\xa8" +
+	"\xdb\xf7\xc53AY5\\\x9flL\xf2r\xc2\\f" +
+	"\xf9QC\xae)\x18\xa8\x00\xd5\x99\xdd\xe6:\xcb5\x0d" +
+	"\xb9\xa9`\x94\x0e@\xbd\xb1\xd5\xdcb\xb9\xa9!\x0b\x0a" +
+	"\xd0\x01\xa4s\x1b\xcd\x1c\xcbm\x0d\xb9\xa7`\x1c\x04\x88" +
+	"\xdeI\x98;,\x8b\x1a\x83\x0e\x14LH\xc5`\xa5\x04" +
+	"\x8c\xb9!\xf0\xa0\x03\x8d\xc1\xa8\xbd\x09;1\x84\x89\xdc" +
+	"\x08\x06\\\x03\x1e\x8c\xda\x9b\xb5\xf6\x86u\xac&\x8b5" +
+	"\xe8q\xd7\x80\x07W\xdb\x9bg\xa1\x10O\x97+\xaf\xbf" +
+	"b\x81\xf8\x1fY\x83\xef\x15\xf2\xa9\x91\xa47Lz4" +
+	"g\xcf\xbb\xc8Z\xfd|s.MD-\xc7/\xa7F" +
+	"\x0b#^\xa9\x85\x8c\xf1\\>\x97\xf2Z\x0e|\xaf0" +
+	"\xe2e\xbd\xd2\x10ug\xb6y\xd5\xd6\x9b\xcah\xd6+" +
+	"W\x86\xb2\x84B\xcb\x9b\xdd\xe5\xd1\xe1\\0\x9b\xff\x1f" +
+	"|c!}l\xc7\xc4c\xcd&\xd1\x9cM\xb41\x9b" +
+	"\xa1\x01\xe3\xb1\xa45\xa4\xd0TY6a\xb2,\x19\x0d" +
+	"\xd9oG\x83`4{w\x9b*\xcb~\x0d\x99P0" +
+	"Z\x05\xb39\xb8\xd5\x1cb\x99\xd0\x90\xa3\xaaS\xe5\xf1" +
+	"ZU\xed\xbd?\x0e\x9a+\xf6U\xac\xf7\xb5\xb6\xd1\xd7" +
+	"\xe7[\xcd\x17,\x17\x03\x15.\xf55\x994\x93,_" +
+	"k\xc8\x0f-}M%\xcd\x14\xcbw\x1a\xf2\x93\xed\x0b" +
+	"A_\xed\xa4uT\xc0\xb9\x99\x84\x99a\xb9\xa2!\x7f" +
+	"X\xca\xa1F93\x9b4\xb3,\xbf\x05\xa45aU" +
+	"\xa3\x9b\x99K\xd6Y[\xe3\xa7_(\xe5+\xf9T>" +
+	"Som\x15Y\xc3x\xc9+\xeel_c\xe3\xfb\x86" +
+	"2;G\xb3^+\x80\xe5R\xaa\x8d\x8e\x0f\xf1s\xbc" +
+	"\\Jm\xa9\xaf\xbfh\xf3\xdf\xd4\xce\x8f\xf1t\xb9\xb2" +
+	"\xa2\xd3\x8a\x02/6\x17'\xd1\xc3{\xb3!\xf0C\xc9" +
+	"\x16f4\x04~d\xc0|\xc0r4X\xa6K\x02o" +
+	"\xae\xd2OZ\x04~\xba\xc7\x9cf9\xa5!\x17\x1f\xa1" +
+	"\xc8G\xc0\xd4\x81~\x9d\x95\xf6_$\xf5\xcf\x00v-" +
+	"\xd2 "
 
 func init() {
 	schemas.Register(schema_f85d2602085656c1,
-		0x9f50d21c9d4ce7ef,
+		0x929b462d5a0499b7,
 		0xb1bdb7d6fb13f1ca,
-		0xc3fe25dd82681d64,
-		0xd2a8ed7e732926bc)
+		0xd70d7b2bf8abab14,
+		0xdfa735fef0302b84,
+		0xe5a448baf4040d94,
+		0xfa255f8dc1ac13e3)
 }
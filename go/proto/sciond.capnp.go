@@ -27,10 +27,12 @@ const (
 	SCIONDMsg_Which_revReply           SCIONDMsg_Which = 10
 	SCIONDMsg_Which_segTypeHopReq      SCIONDMsg_Which = 11
 	SCIONDMsg_Which_segTypeHopReply    SCIONDMsg_Which = 12
+	SCIONDMsg_Which_drkeyLvl2Req       SCIONDMsg_Which = 13
+	SCIONDMsg_Which_drkeyLvl2Rep       SCIONDMsg_Which = 14
 )
 
 func (w SCIONDMsg_Which) String() string {
-	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReply"
+	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReplydrkeyLvl2ReqdrkeyLvl2Rep"
 	switch w {
 	case SCIONDMsg_Which_unset:
 		return s[0:5]
@@ -58,6 +60,10 @@ func (w SCIONDMsg_Which) String() string {
 		return s[122:135]
 	case SCIONDMsg_Which_segTypeHopReply:
 		return s[135:150]
+	case SCIONDMsg_Which_drkeyLvl2Req:
+		return s[150:162]
+	case SCIONDMsg_Which_drkeyLvl2Rep:
+		return s[162:174]
 
 	}
 	return "SCIONDMsg_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -498,6 +504,72 @@ func (s SCIONDMsg) NewSegTypeHopReply() (SegTypeHopReply, error) {
 	return ss, err
 }
 
+func (s SCIONDMsg) DrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	if s.Struct.Uint16(8) != 13 {
+		panic("Which() != drkeyLvl2Req")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Req{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasDrkeyLvl2Req() bool {
+	if s.Struct.Uint16(8) != 13 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetDrkeyLvl2Req(v DRKeyLvl2Req) error {
+	s.Struct.SetUint16(8, 13)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Req sets the drkeyLvl2Req field to a newly
+// allocated DRKeyLvl2Req struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewDrkeyLvl2Req() (DRKeyLvl2Req, error) {
+	s.Struct.SetUint16(8, 13)
+	ss, err := NewDRKeyLvl2Req(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl2Req{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SCIONDMsg) DrkeyLvl2Rep() (DRKeyLvl2Rep, error) {
+	if s.Struct.Uint16(8) != 14 {
+		panic("Which() != drkeyLvl2Rep")
+	}
+	p, err := s.Struct.Ptr(0)
+	return DRKeyLvl2Rep{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasDrkeyLvl2Rep() bool {
+	if s.Struct.Uint16(8) != 14 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetDrkeyLvl2Rep(v DRKeyLvl2Rep) error {
+	s.Struct.SetUint16(8, 14)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDrkeyLvl2Rep sets the drkeyLvl2Rep field to a newly
+// allocated DRKeyLvl2Rep struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewDrkeyLvl2Rep() (DRKeyLvl2Rep, error) {
+	s.Struct.SetUint16(8, 14)
+	ss, err := NewDRKeyLvl2Rep(s.Struct.Segment())
+	if err != nil {
+		return DRKeyLvl2Rep{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 func (s SCIONDMsg) TraceId() ([]byte, error) {
 	p, err := s.Struct.Ptr(1)
 	return []byte(p.Data()), err
@@ -586,6 +658,14 @@ func (p SCIONDMsg_Promise) SegTypeHopReply() SegTypeHopReply_Promise {
 	return SegTypeHopReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SCIONDMsg_Promise) DrkeyLvl2Req() DRKeyLvl2Req_Promise {
+	return DRKeyLvl2Req_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SCIONDMsg_Promise) DrkeyLvl2Rep() DRKeyLvl2Rep_Promise {
+	return DRKeyLvl2Rep_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type PathReq struct{ capnp.Struct }
 type PathReq_flags PathReq
 
@@ -2714,164 +2794,170 @@ func (p SegTypeHopReplyEntry_Promise) Struct() (SegTypeHopReplyEntry, error) {
 	return SegTypeHopReplyEntry{s}, err
 }
 
-const schema_8f4bd412642c9517 = "x\xda\x94X\x7fp\x14\xe5\xf9\x7f\x9ew/w\xc9\xe5" +
-	"\x92\xbb\xcd{(_\xbe\xd3\xa6u\xb0\x08#\x19\x13l" +
-	"\xb5\x8c\x1a\x08?\xcf\x12\xc8{Gm\xeb\xd8\xa9kn" +
-	"\x93\x9c\xbd\xdc\x1e\xbb\x9b@li\xaa#\xfdA\xeb(" +
-	"UF\xa92%*\xd6\xb48UK-\xe0\x8f\xb1#" +
-	"\xb4\x12\xb5\x9a\xa9\x8c5\xa3\x15\xf1WP;#H\x8b" +
-	"Xt;\xcf\xee\xde\xee\xb2\x1c`\xf9k\xd9\xe7\xb9\xcf" +
-	"\xfb>\xcf\xf3y>\xcf\xb3\xb9\xa07:\x8f\xb5\xd6\xac" +
-	"\xa8\x03\x10\xc5\x9a\xa8\xf5\xe1\x83\x0f\xdc\xf7\xde\x91\xeb~" +
-	"\x0cr\x03Zgo<?\xdf\xf4\xe2\xd7n\x86\x1a\x8c" +
-	"\x01\xf0\x9b\"\x13|S\x84\x9e6F\xda\x01\xad#\x13" +
-	"\xc7\xbe\xf3\xc4\xd8k\xebA4`\xd0Y\"\x97=\x91" +
-	"1>\x1e9\x1b`\xce\xab\x91o \xa05M\xbek" +
-	"\xf1\x9b\xfa\xf57\x87\xbcm\xbcE\xd1\x87yg\x94\x9e" +
-	"2QB^\xfc\xd4\xe2\xe1\xedw\xbe\xbb\x81|\x99\xef" +
-	"\xbb\x88\xc5\x1a0\xc2\x0b\xd1]|\x15y\xcf\xe9\x8f\xae" +
-	"\x90\x00\xad\xcd\x93\xe9\x03\xe7M\xfd\xc1m\xd5.]\x17" +
-	"\x1f\xe3S\xe2\xf4$\xc7\x09zdm\xfd\xfd_\x9e7" +
-	"\xb41\x04m_c~|\x82w\xda\xbe\x99\xf8j@" +
-	"\xeb`\xc7k\xeb~\xbd.zg5\xdc\xad\xf1w\xf9" +
-	"C\xb6\xef\x036\xee\xc4\xcb\xeb'_\xaf\xf9\xeb\x9d " +
-	"\xa6\xa0d\xbdw\xcf\xeeWZ\xa7\xfcy7L\xc1\x18" +
-	"\x02\xf0\xf1\xf8\x04 \xdfg\xa36\xb5ni\xbd\xaav" +
-	"\xc5h\x15\xd49\xad\xf5\x0c\xf9\xa5\xf5\x04\xfb\xd5z\x82" +
-	"\xdd~hT\\9\xf5\xa3m\xe1\x1c\xdb\xde\xfd\xf5M" +
-	"\xc8\xd7\xda\xdeC\xf5\xbf\x03\xb4\xbep\xee\xad\xabkf" +
-	"L{8\xec\xcd\xc8ef\xe2a\xde\x9a\xa0\xa7\xd9\x09" +
-	"\xbaG\xcb\xe7v<:y\xc7\xd8# \xbe\x84hM" +
-	"\xff\xde\xf3E>\xef\xf6\x9d\x15\xe8\xc4\x95\xc8\xaf\xb7\xbd" +
-	"\xd7\xda\xde\x9e]\xfc\x1f\x06Jo\x17{\xce\xcb\x896" +
-	"\xe4\x07\x133\x00\xe6|\x92\xb0\xab=y\xf8\xac\xc1\xb7" +
-	"\xfe9\xef\xa9j\xa9\xdb\xd0\xf8.\xdf\xdcHO\x9b\x1a" +
-	")F/Y\xa2\x01\xa5\xb0\xf3\xde\xc6\xdf\xf0qr\x9e" +
-	"\xf3\\c3!\xbf?x{ye\x8b\xb5'\x84l" +
-	"\x87\xb8/y\x80\xbf\x9e\xa4\xa7W\x93t\xe9\x1b\xfe\xb8" +
-	"\xe3\xd3\xbf\xffm\xc1\xd3UC\xbc4\x95E.Rg" +
-	"\x03\xf0o\xa5\xc8;\xa9>?\xbf\xe3\xc6\xcf\x8fUc" +
-	"\xe8\xde\xd4\x04\xdf\x97\xa2\xa7\xf1\x14\xdd\xf9\x99e\xe3#" +
-	"o\xdc|\xd13 fa\xe0\x1c\x07\xf9P\xea\x17\xc8" +
-	"\xebd\xf2\xae\x91\xa9.[\xdf\x9e~\xd7\xfdw\xab\xcf" +
-	"VC\xde*\xef\xe2\x0f\xd8\xbe\xa32!\xbf\xf2\xfa\xa3" +
-	"\xf7\xfd\xf4\xd6\x19\xefT\xad\xf8^y\x1a\xf2\x97m\xef" +
-	"}6rq\x7f\xf6\x8ai\xe3G\xdf\xa9\x96\xe7uM" +
-	"c|C\x93\xdd\xb9M\x84|\xf1\x8c\x97~\xd4;e" +
-	"\xcf\x07\xd5\x90\xf9\xe3M\x87\xf9^\xdbyO\x13%\xa3" +
-	"\xfd\xed\xcbf>r0y\xa8\xaa\xf3\xb9|\x17\x9f\xcd" +
-	"mRqr\xde\xf9\xc4\x9a\xd1\x9f\xbdt\xdf\xd1j\xb7" +
-	"\xd8\xc4\x0f\xf3\xad\xb6\xef\x08\xa7[$\xa6\xfd\xe3\xb7\xbd" +
-	"\xe7\xbeu\x0c\xc4Y\x18`\xec\x14\xe64\x0a?@\x8d" +
-	"b\xa3\xfe\xfe\x91\xeb\x96l\xbf\xe7\xa1\x8f\xab\xb5jk" +
-	"\xfa0\xbf4m\xf7I\x9a\xf2`t\x17\xb4R\xbe\xa5" +
-	"\x9b)\xe5Rynfq\xa6\xd4\xa3e\xd5U\x03\xaa" +
-	"d\x98]\x88\"\"E\x00\"\x08 7\xb4\x01\x88Z" +
-	"\x09\xc5t\x86\xcd\x85\x9e\xccB\x03\x1b\x01\xbb$\xc4:" +
-	"`\xf4\x18\xc2Z\xbc:\xdf\xa5\x98}\x9d\xaa\xa9\x00\x88" +
-	"H\x90G2^ky\xb6d^1\x151\xd5;g" +
-	"S\x07\x80\xb8MB\xb1\x85!b\x1a\xe9\xdd\xe6s\x00" +
-	"\xc4\x1d\x12\x8a{\x19\xca\x0c\xd3\xc8\x00\xe4\x91+\x01\xc4" +
-	"\x16\x09\xc5c\x0ce\x09\xd3(\x01\xc8;\xe9\xd7\xdb%" +
-	"\x14O2\x94#\xa94F\x00\xe4\xc7/\x07\x10\x8fI" +
-	"(\x9ef(\xd7\xb04\xd6\x00\xc8{\xe8\xe5n\x09\xc5" +
-	"\x0b\x0c\x87{\x9c\xcbb\x030l\x00\x8c\xf5\x9b\x03\x18" +
-	"\x03\x861@\xabP2U\xbdG\xe9\x06I\xf5BN" +
-	"\xf9z\x08H/\x87\xd55\xe5\x95\x85~\x15k\x81a" +
-	"-\xa0\xd5\xa7*yU\xbf\xa2\x0d\xc8\x01\x18R\x0b\xf6" +
-	"\xab\xa6B\xd1\xd2\xbb\x94\x9f\x0e@L\x05\xd2\x87v\xfa" +
-	"\xb2\xea`sV-\x17\x87BU\x98\xebV!\xcd\xb0" +
-	"]W\x8d\x81\xa2\xe9]\xf4D\x80\xdc\x82L\xfb\x8a\xe5" +
-	"\x0b;\x8d^B\xb8\xa4\x82\xc07\xe24\x80\xdc-(" +
-	"a\xee.d\xd8\x80\x96e'\x99o\xc26\x80\xdcm" +
-	"d\xd8B\x06\xf6\xa9e'\x9ao\xc6\x0e\x80\xdc\x1dd" +
-	"\xb8\x97\x0c\xd2'\x96\x9dl>\x82Y\x80\xdc\x162l" +
-	"#C\xe4\xb8e'\x9c\x8f\xda\x86\xfb\xc9\xb0\x9d\x0c5" +
-	"\xff\xb1\xec\xa4\xf3\x87\xf0\x1a\x80\xdc\x83dx\x8c\x0c\xd1" +
-	"\x8f\xad4F\x01\xf8N\xbc\x01 \xb7\x83\x0c\xbb\xc9\x10" +
-	";f\xa5\xed6\xf8\x13\xea\x00\xb9'\xc9\xf0,\x19j" +
-	"?\xb2\xd2XK\xcabC=M\x86\x17\xc9Pw\xd4" +
-	"Jc\x1d\xb5\x02\xfe\x12 \xf7\"\x19\xf6\x93!\xfeo" +
-	"+\x8dq\xd26\\\x0f\x90\xdbO\x86\xf7\xc9P\xff/" +
-	"+\x8d\xf5\x00\xfc ^\x0e\x90\x9b$\xc3\x112$\x8e" +
-	"XiL\x00\xf0C\xf6\xe1\x1f\x90\xe18\x19\x1a>\xb4" +
-	"\xd2\xd8\x00\xc0\x8f\xd9\xd7\xfd\x88\x0c\x11\xc6Pn\xc44" +
-	"6\x02pd\x94\xaa\xe3\xf4\xbe\x961\x94\x0ay\xbb;" +
-	"\xea\x00\x9b\x07J\x86jBt\xb8\xac\x98}Yu\x15" +
-	"\xa6|\xfdv\x09\xe0X\xcaE\xc0!L\xf9B\xe2Z" +
-	"\x15\xc3\xe9M@\xfa\xad\xa7\x8cak\xac\\\xa4_{" +
-	"\xa3\xdd\xb5\xeb\xea\xe0r\xcd,\xf4`\xa1[1\x0bZ" +
-	"\x89\x08\xe8\x8di\xd7\xa7\xd0\xe3b4\xaf\x1aP\x0d\x13" +
-	"S\xfeV\x13\xf6pO\xf1T\xb4BaU\x1f,t" +
-	"\xab\x19\x0c\xa8\x08\xa6\xfc\xc9]\xd5\xad\\\x1c\xb2\xfb\xc1" +
-	"\x13C\xff\xca\xae\x91\xac\xde\x1a\xe4a\xf4\xae\x1c*\xab" +
-	"K\xa1Y+;\xe9\xf4\xc6P\xc8\x03\xc9\x81p0\xe5" +
-	"\x8fW\xc7g\xd8\xd4\x95n5\x93\xaf\xf4}H\xc2\xe6" +
-	"\xe72\xfe\x0dC}\xd8\xe1\xab\xe1\xb0Z2\xf5BP" +
-	"\x1c<\x05v\xc4!\x04KJ\x93qDE\xeaV\x09" +
-	"\xb7\xd6\xc3\x9dI*;]Bq\x01C\xb9\"\x7f\xb3" +
-	"g\x01\x88\xf3$\x14\x17\x92\xf4\x1ay\xc5\xa8\xb0*I" +
-	"B\\\xf9O\xe8\x98\xac[\xf2B\xb7\x92\xa4\x92\x87\x02" +
-	" \xf9KH(\xa62\xb4\x8c\xac:H\xa1:\xa9\xce" +
-	"\xbe\xf1\xf1E\xeb\x96\xb4\xfd*,L\xfe\xed\xb3\xea\xaa" +
-	"\x96\x9e\xa2\"\xf5\x1at\xf5\xd4-\x8e\xf6\xce\xec\x08\xde" +
-	"}\x83\xa3\xbd\xb3\xe7\xfaw\x1f\xd6\xd5\x1e]5\xfa*" +
-	"\x82\xd8\xdeW\xc8\xe7\xd5\x92\xa7\x8f\x95\x83$G\xc0\\" +
-	"\x96T\xb8d\x98\xe1\x1a\\\xeb\x86p\x1e\xf38\xb5\x12" +
-	"\x92Ce\xbf\x14I\xcb\xec}\xe1\xffg\xce\xce\x1e\x08" +
-	"\x97\xa2r\x86\xc3\x11\x97\"\x8bJ\xa6\x8e\xb6\xe2&\xbc" +
-	"S\x16\xd1\x98Y(\xa1\xb8\xda\x9fG\xdf\xce\x02\x88\xab" +
-	"$\x14}\x81y\xa4R\xf8WK(\x8a\xec3\x0e\x0d" +
-	"\xcb,\xf4\xab\x86\xa9\xf4\x03\x96+\x83\xe3\xa4Ar\xa2" +
-	"\xaa/\xd5\x8cf\x93R\x12\xa2\xcd,?\xf5\xf4\xcf\xdf" +
-	"\x16\xe4\xd9m\xc0\x92eM\xf7\xe6D\xb3\x92\xcf\xeb\x86" +
-	"\x87\x1b\x09O\xeb\x96\xca\x03\x8d\xaa\x96\xe5\x9ad\xaa\xa7" +
-	";\xcc\xa5h\x9b_\xe6dI3UL\x00\xc3\x04\x84" +
-	"\xf8\x1aJ\x7f\x95S\x9b\xedcEmp;\x94\xeb\xce" +
-	"\xf1\xb7n\xb9fVl\x89\xaa%\x97k\xa6\x1a\\\x1b" +
-	"\xae\x0d\xac\x08\xc8\x9c[\x8dd\xdd\x15a[\xa0N\xa3" +
-	"\xeb\x01\xc46\x09\xc5\x0e\x86(9\xd4\xfdC\xd6]\x1b" +
-	"^\xa0\xb5\x01\x1d\xea>w\x0d\x80xVB1\x19X" +
-	"\x1b\xde\xa2@\xf7K(\x8e3\xb4L\xcdT\x8a\xcb\x14" +
-	"\x13\x92j\xa9{\xc8\x1b\xc4\xf6\xeb\xa5Z\x19\xd0\xc0(" +
-	"0\x8c\xd2\xec/\x94\x0a\xfdJ\xb1\x03\x95R~u!" +
-	"o\xf6\x01x%.\x16J\xdf%\x1a\x92\xbf\xcb\x95\x98" +
-	"\xbbS)\xc62\x8d\x14\x1bbZ)@$/5\x0e" +
-	"\x91\x9a)\xe3\x01\xb3\x97\xac\xaa\xfa\x13 }\xb2\xca\x86" +
-	"qZe\xf3\xbe\xabB\xc8X\xd1\x86$\x89\x03!\xa6" +
-	"=\xc4\xb5\xb4\xbd\xad\x91P\xdc\x18\xd0\xb4\xeb\xe9\xe5\xf7" +
-	"%\x14?\xa1\xd2\xd4:\xa5YG\xa2t\xa3\x84\xe2\x16" +
-	"*\x0d\x06\xbe\x0e\xe5\x9b\xda\x80a\xc4\xa9\xc1\x00iJ" +
-	"YB\xf1s\x86\xb1\xbcaV\xd8\x153\xf4n\x8fi" +
-	"\xfd\xca\x1a\xa2\x94A\x9aVa~OQ\xe95\xda\xfb" +
-	"\xca\x0bzz\x031M]\xf4\xe6e\xfc/_\xdcu" +
-	"j\xb5v\xc5!f\xeaC\xa7n\x05_\xae)\x8a\xf3" +
-	"%\x14\x173L\xd2P\xc7\x94\xff\xe1\xe7jj\x9ff" +
-	"\x98\xbe\xe2z\xdb|Hq\xcf\xd0\x9bKT\xd4\xec\xcd" +
-	"\xda\xfb\xaa\x92\xb1cxIWn\xa1\xddB\xfe-o" +
-	"p\x9bs\xa1\xdf\xb0\xf3\x89\xc7\x97H(\x962\xb4t" +
-	"m\xc0T\xf5e\x1a:\xcb\x81\x01~r<h\x97i" +
-	"\xd5\xdb\xf9$bI\x0e\x09\x02\xb4\x9a\xe5/\xaeIs" +
-	"\xa8\xacb\xd2\xfa\xe1\xc5w\xc7\xd5\xd1\xa3#\x84\x9d\x0c" +
-	"\xa0\xd5\x9c)l\xad\xc5\x09\x12\x95\x90V_\xeeju" +
-	"W\xa0\x1c\x9d\xd4\xdb\xcb$\x14\xdfdH\xc2@D\xfb" +
-	":\x91\xbcKBq\x15C\xab\xa8\x98\x05s \xafR" +
-	"-\xe2\xc00N-\xa9\x95z\xe9%\xa0Zy7L" +
-	"\xba\xa9\x1aFE\xd9B\xdc\x9f\x9f\xcb\xb4;\xa3\xea\x14" +
-	"\xdfMivz1<a\xde9\xc3H\xd2\xc3\xc3\xe8" +
-	"\x9aj\x01R+-\x95P\xac\xf4\x03\x14Y7\xc0b" +
-	"p6\xc6\x9c\xbc\x07gb\x120f\x9aE\xff\xbb\xa5" +
-	"BL\x0ctH\x90\x9f\x8d\xa7\xfcj\xfc\x9f\xd7$\xef" +
-	"\xc3\xfaL\xb0\xcd4\x98\x87>\xc3\x14\x0av\xde\x09\xab" +
-	"\xd1g\xeb7O\xc5\xda\xfb\xbco\xaf\xc0\x89Y\x7f\xc6" +
-	"UNl\xedpO\xa46Ru]\xd3\x17h6i" +
-	"\\\xc999h\xef\xef0\xa7\xd0f\x8f\x04U?\xff" +
-	"N\x9bO\xefO U\xa1\x97\xba)hQ\xf2\xb1\xbc" +
-	"n8\x819Q\x9c$c,\xb4u&\x0b\xe5\xc1\x0b" +
-	"+[2\xfd\xe7+g^\x99\xfd\xa2\x05\xf8K\xcd0" +
-	"OB\xb1\x8c\x0e\x8a8\xa7g\xce\x09\x90\x9au9\xa7" +
-	"w\xce\xf5I}b\xdb\x04?\xce\xdb\x0b\xc6\x02MW" +
-	"+\x1b\xe4\x7f\x03\x00\x00\xff\xff\xd8\x86k\xda"
+const schema_8f4bd412642c9517 = "x\xda\x94X}\x90\x14\xd5\x11\xef~\xb3\xb7{\x1f\xbb" +
+	"\xcc\xce\xbd9=I\x99S\x0b\xc3G\xe4\x8a;4\x1a" +
+	"J<8\xbe\xee\xcc\x81\xf7v\xd1\xa4,\xac8\xdc\xce" +
+	"\xdd-\xee\xed\xee\xcd\xcc\x1d\x9c\x89\xb9hA\x12I," +
+	"5J\xa9Q+\x9e\x1a\x95D+\x89!F\xf1#\xb1" +
+	"\x94DQ#T\xa4T\xcaDAQPS%H\x82" +
+	"\x18\xe3\xa4zfvf\x18\x160\xfb\xd7\xdb\xd7\xbd\xfd" +
+	"^w\xff\xfa\xd7\xfdv\xd6u\xf1y\xac\xad\xe6\xa2:" +
+	"\x00Q\xa8\x89\xdb\x1f\xff\xe6\xa1\xfb>8x\xe5\x0fA" +
+	"I\xa1}\xf2\x86\xb3r\x8d\xaf|\xe3z\xa8\xc1\x04\x00" +
+	"\xbf.\xb6\x93\xdf\x16\xa3\xd5\x86X\x07\xa0}p\xe7\xe1" +
+	"o?\xb5\xf5\xcd\xf5 R\x18V\x96HeKl+" +
+	"\xdf\x1e;\x19`\xf6\xdfc\xdfD@{\xb2r\xc7\xe2" +
+	"w\x8c\xab\xaf\x8fh;\xf6\x16\xc5\x1f\xe6K\xe3\xb4\xea" +
+	"\x8e\x93\xe5\xc5\xcf,\x1e\xdft\xfb\xfb7\x92.\x0bt" +
+	"\x17\xb1\x84\x8c1\x9e\x8fo\xe6\xc3\xa4={(\xfeG" +
+	"\x09\xd0\xbes\xaf\xba{Z\xf3\xf7n\xaev\xe9\x89\xfa" +
+	"\xad\xfc\xa1zZm\xac'\xd3\x13W5<p\xce\xbc" +
+	"\xb1\x0d\x11\xd3\xce5^\xaf\xdf\xc9\xf78\xba\xbb\xeaW" +
+	"\x03\xda\xfb:\xdf\\w\xff\xba\xf8\xed\xd5\xec\xcemx" +
+	"\x9fw78\x97o \xbb;__\xbfwW\xcd_" +
+	"o\x07\xd1\x84\x92\xfd\xc1=\xcf\xbe\xd1\xd6\xf4\xe7g\xa1" +
+	"\x09\x13\x08\xc0\xf3\x0d;\x01\xf9P\x03Yml\xbb\xab" +
+	"mE\xedE\x1b\xabX\x9d\xfd|\x03C\xbe\xc31\xbb" +
+	"\xdd1\xbbi\xffFqi\xf3'\x0fFc\xech\xd7" +
+	"$\x1b\x917%I[I\xfe\x1a\xd0>\xed\xcc\x9bV" +
+	"\xd7L\x9d\xfcpT\x9b\x91\xca\xd3\xc9\x87\xf9\xf3\x8e\xf2" +
+	"\x96$\xdd\xa3\xf5\xd4G\x1f\xdf{\xeb\xd6G@|\x05" +
+	"\xd1\x9e\xf2\x9d\x97\x0b|\xde-\x8fUL\xa7.E~" +
+	"J\x8a\xb4\x9bR\xab!$\x17\xa7`(\xf5N\xb2g" +
+	"\x0f\xa7\xda\x91_\x9d\x9a\x0a0{C\xca\xc9\xf6\xde\x03" +
+	"'\x8d\xee\xf9\xe7\xbcg\xaa\x85n\xba\xfc>?G\xa6" +
+	"U\x9bL>\xfa\xc1\x12)\x94\xa2\xca\x9a\xfcK\x9e'" +
+	"\xe5\xd9\xba\xdcB\x96?\x1c\xbd\xa5\xbc\xbc\xd5\xde\x12\xb1" +
+	"\xec\xb88\x94\xde\xcd\xc7\xd2\xb4\x1aI\xd3\xa5\xaf\xf9\xc3" +
+	"\xa3\x9f\xbf\xf6\xb7\x05\xcfUuqG:\x83|_\xfa" +
+	"d\x00\xbe\xdf\xd1\x96\xf5\x97\xe7w\xae\xfd\xf2\xd6j\x08" +
+	"\xd5\x94\x9d|H\xa1U^\xa1;\xbf\xd0\xb3}\xe2\xed" +
+	"\xeb\xcf}\x01\xc4\x0c\x0c\x9d\xe3Z\xbeV\xf9)\xf2\x09" +
+	"G\xfbN\x85\xf2\xf2\x8bw\xa7\xdc\xf1\xc0\xdd\xfa\x8b\xd5" +
+	",\xcfm\xdc\xcc\x175\xd2j~#Y~c\xd7\xe3" +
+	"\xf7]{\xd3\xd4\xf7\xaaf\\k\x9c\x8c|\xd8\xd1\x1e" +
+	"j$\xcb\x85\xb72\x97L\xde~\xe8\xbdjq>\x95" +
+	"o\xe5\xd39\xad\xce\xe4d\xf9\xbc\xa9\xaf\xfe`\xa0i" +
+	"\xcbG\xd5,\xf3\x8b\xf9\x01\xae9\xca\x97q\x0aF\xc7" +
+	"\xbb\x17L\x7fd\x9f\xbc\xbf\xaa\xf2\x93|3\xdf\xe2(" +
+	"?\xed(?\xf6\xd4\x9a\x8d?~\xf5\xbeC\xd5n\xd1" +
+	"\xa6\x1e\xe0sUZ}]\xa5[$'\xff\xe3W\x03" +
+	"g\xee9\x0c\xe2$\x0c!\xb6\x89\xb9\x85\xa2\xee\xa6B" +
+	"Q\xc9\xea\xef\x1e\xb9r\xc9\xa6{~\xfbi\xb5R}" +
+	"^=\xc0w8V\xb7\xab\x14\x07\xb3/_*\xe6Z" +
+	"\xfb\x98V.\x96\xe7t/\xee.\xf6\x972\xfa\xf0\x88" +
+	".\x99V/\xa2\x88I1\x80\x18\x02(\xa9v\x00Q" +
+	"+\xa1\x98\xc2\xb0%\xdf\xdf\xbd\xd0\xc4I\x80\xbd\x12b" +
+	"\x1d0\x9ct\x94\xad\xc5\xabs\xbd\x9a5\xb8T\xb74" +
+	"\x00\x11\x0b\xe3H\xc1U\xb6/\x93s\x9a\xa5\x89f\xff" +
+	"\x9c\xdb:\x01\xc4\xcd\x12\x8a\xbb\x18\"\xaaH{w\x9e" +
+	"\x01 n\x95P\xdc\xcbPa\xa8\"\x03P&.\x05" +
+	"\x10wI(\x9e`\xa8H\xa8\xa2\x04\xa0<F\xbf\xde" +
+	"$\xa1\xf8\x13C%\x96V1\x06\xa0<y!\x80x" +
+	"BB\xf1\x1cC\xa5\x86\xa9X\x03\xa0l\xa1\xcdg%" +
+	"\x14\xdb\x18\x8e\xf7\xbb\x97\xc5\x140L\x01&\x86\xac\x11" +
+	"L\x00\xc3\x04\xa0\x9d/Z\xba\xd1\xaf\xf5\x81\xa4\xfb." +
+	"\xa7\x03>\x04\xa4\xcdq}Myy~H\xc7Z`" +
+	"X\x0bh\x0f\xeaZN7.i\x07R\x00\x86\x08h" +
+	"\x0f\xe9\x96F\xde\xd2^:\x08\x07 \xa6C\xe1C'" +
+	"|\x19}\xb4%\xa3\x97\x0bc\x91,\xcc\xf1\xb2\xa02" +
+	"\xec0ts\xa4`\xf9\x17=\xd2@vAw\xc7E" +
+	"\xcb\x16.5\x07\xc8\xc2\xc2\x8a\x05\xfe\x12N\x06\xc8>" +
+	"\x87\x12f_A\x86)\xb4m'\xc8|;\xb6\x03d" +
+	"_$\xc1k$`\x9f\xdbN\xa0\xf9\x0e\xec\x04\xc8n" +
+	"#\xc1\x1b$\x90\xfek;\xc1\xe6\xafc\x06 \xfb\x1a" +
+	"\x09\xde!A\xec3\xdb\x098\xdf\xe5\x08\xde\"\xc1\x87" +
+	"$\xa8\xf9\x8f\xed\x04\x9d\xef\xc3\x95\x00\xd9\xbd$8H" +
+	"\x82\xf8\xa7\xb6\x8aqb\x12\xbc\x06 \xfb\x11\x09>#" +
+	"A\xe2\xb0\xad:ep\x18\x0d\x80\xec'$\x881\x86" +
+	"\xa9\xdaOl\x15k\x018\xb2\x95\x00\x19&a6I" +
+	"\xfbu\x87l\x15\xeb\x00x\x1d\xfb\x19@6I\x82f" +
+	"\x12\xd4\xff\xdbV\xb1\x9e\xf8\x98\xad\x07\xc86\x93`\x0a" +
+	"\x09\x1a\xfee\xab\xd8\x00\xc0Og\x17\x02dO#\xc1" +
+	"Y$H\x1e\xb4UL\x12\xe12:{\x1a\x09\xce&" +
+	"A\xeac[\xc5\x14\xd5&\xa3\xdb\xce\"\xc1\xf9$\x98" +
+	"t\xc0VQ\xa6Re\xab\xf8\\\x96\xc8\x9eO\xa2^" +
+	"\x12\xc9\xfbm\x15\xd3\x00|)[\xc5\x05Kd{I" +
+	"\x94c\x0c\x954\xaa8\x898\x92Q|W\xd0\xfe " +
+	"c(\xe5sNI\xd5\x01\xb6\x8c\x14M\xdd\x82\xf8x" +
+	"Y\xb3\x063\xfa0\xa6\x03\xd2\xf7P\xe3J\xca\x05\xc0" +
+	"1L\x07\xec\xe3I5\xd3-h@\xfa\xadO\xa7Q" +
+	"i\xa2\\\xa0_\xfb\xf3\x80'7\xf4\xd1e%+\xdf" +
+	"\x8f\xf9>\xcd\xca\x97\x8a\x84Z\xbf\xb7{:\xf9~\xcf" +
+	"F\xcb\xf0\x88nZ\x98\x0eF\xa1\xa8\x86w\x8aO\xbd" +
+	"\x15\xdc\xeb\xc6h\xbeO\xef\xc6\x10\xf5`:h\xf7U" +
+	"\xd5\xca\x851\xa7\x88|\x06\x0d\xae\xec\x09I\xea\xcfN" +
+	"\xbe\x8d\x81\xe5ce\xbd\x0bZJe7\x9c~\xef\x8a" +
+	"h`\xa9\xec\xda\xc1t\xd0\x93=\x9d\x9cq\x85>\xd6" +
+	"3Z\x00\xb9=\xa3\x0f\xf7\"\xc3\xb4}s*vp" +
+	"s\xd7\xbd{\x00`\x1e*\x98\xe8eUt\xcb\xae\xee" +
+	"\xda\xaf\xce\xfa\xe8\xf3s\xee\x7f3\xa2;n\x19Z\x9f" +
+	"\xde\x9d\xab\x90P\x84O\xe7g\xbb\x03\xcf#\xa4\xd0\x19" +
+	"P\xf3\xb8^\xb4\x8c|\x98\xa9\xfcv\xe02U\xc4," +
+	"\xd1^\xb7\xcbpR\x9fNvk}\xbb\xd3\x89\xf2\xa7" +
+	"H(f1T*\\<s\x06\x80\x98&\xa18\x9b" +
+	"\xfa\x80\x99\xd3\xcc\x0aZe\xea\x0a\x95/\x91c2\x1e" +
+	"\x94\xf2}\x9aLP\x8a8@\\\x9c\x94P43\xb4" +
+	"\xcd\x8c>J\xae\xba)\xcc\xbc\xfd\xe9\xb9\xeb\x96\xb4\xff" +
+	"<\xca\x92\xc1\xed3\xfapk\x7fA\x93\x06L\xbaz" +
+	"\xfa\x06\xb7\x11L\xef\x0c\xdf\xfdF\xb7\x11\xcc\x9c\x13\xdc" +
+	"}\xdc\xd0\xfb\x0d\xdd\x1c\xac\xb0s\xc7`>\x97\xd3\x8b" +
+	"\x95\xaf\xfeA\x92\xcb\xa6\x1e\xfa*\x185\xadh\x0eV" +
+	"y.Lc>V\x97\x83<V\x0eR!\xdb\xd6\xc0" +
+	"\xb6/M\x9f\x99\xd9\x1dME\xe5\x0c\x17{\x1e\xf4\x16" +
+	"\x15-\x03\x1d\xfaO\xfa\xa7,\xa2\x9e\xb7PBqy" +
+	"\xd0\x1c/\xcb\x00\x88\x15\x12\x8a\xc1Ps\xd4\xc9\xfd\xcb" +
+	"%\x14\x05\xf6\x05;\x98m\xe5\x87t\xd3\xd2\x86\x00\xcb" +
+	"\x95.vTW;\xb2\xc5t\x95\xcc\x16\x8bB\x12\x81" +
+	"\xcd\x8c \xf4\xf4\x09F\x17ef;0\xb9\\2\xfc" +
+	"\xa6\xd5\xa2\xe5r\x86\xe9\xdb\x8dEG\x87\xd6\xca\x82\xfa" +
+	"f\xeb\xb2\x92d\xe9\xc7;\xcc\x83h{\x90f\xb9X" +
+	"\xb2tL\x02\xc3$D\xf0\x1a\x09\x7f\x95S[\x9cc" +
+	"EmxTU\xea\xce\x08\x9e\x00J\xcd\x8c\xc4\x12\xbd" +
+	"$/+Yzx\x86Y\x15\x9aW\x90\xb9\xb7\x9a\xc8" +
+	"x\xf3\xca\x83\xa1<m\\\x0f \x1e\x94P<\xca\x10" +
+	"%\x17\xba\xbf\xcfx3\xcc6\x9aa\xd0\x85\xeeK+" +
+	"\x01\xc4\x8b\x12\x8a\xbd\xa1\x19f\x0f9\xfa\x96\x84\xe23" +
+	"\x86\xb6U\xb2\xb4B\x8ff\x81\xac\x17\xfb\xc6\xfc\xa9\xc0" +
+	"\xd9\xee*\x95\x01M\x8c\x03\xc38\x0d\"\xf9b~H" +
+	"+t\xa2V\xcc\xad\xce\xe7\xacA\x00?\xc5\x85|\xf1" +
+	"\x0a\x82!\xa0\x8f\x95\x847\xe0ifO\x89:\x01$" +
+	"J\xc5\x10\x90\xfc\xd0\xb8@j\xa1\x88\x87\xc4~\xb0\xaa" +
+	"\xf2O\x08\xf4r\x95q\xe7\xb8\xcc\xe6?\xf2\"\x96\xb1" +
+	"\xc2\x0d\xf2\xa0C\xd0(T\xdf\xe2U4J\xae\x91P" +
+	"\xac\x0dq\xda\xd5\xb4\xf9]\x09\xc5\x8f(5\xb5nj" +
+	"\xd6\x11)\xad\x95P\xdc@\xa9\xc1\xd0SU\xb9\xae\x1d" +
+	"\x18\xc6\xdc\x1c\x8c\x10\xa7\x94%\x14?a\x98\xc8\x99V" +
+	"\x05]\x09\xd3\xe8\xab\xac\xed!m\x0dA\xca\x04\x00\x1f" +
+	"\xf9\xfd\x05m\xc0\xec\x18,/\xe8\x1f\x08\xf9\xd4\xbc\xe8" +
+	"\x9d\x0b\xf8_N\xdf|l\xb6\xf6\xc8!a\x19c\xc7" +
+	".\x85\x80\xae\xc9\x8b\xb3$\x14\xe71\x94iX\xc0t" +
+	"\xf0\x0a\xf58u\xb0dZ\x01\xe3\xfaO\x8b\x08\xe3\x9e" +
+	"\xa06\x97\xe8Xr\xc6|\xff\x89\xa7`\xe7\xf8\x92\xde" +
+	"\xecB\xa7\x84\x82[^\xe3\x15\xe7\xc2\xa0`\xe7\x13\x8e" +
+	"\xcf\x97Pt1\xb4\x8d\xd2\x88\xa5\x1b=%t\x87\x0e" +
+	"\x13\x82\xe0\xf8\xa6=\xa4U/\xe7\xa3\x80%\xe9\xc3\x11" +
+	"X\xcd\x08\xa6h\xd9\x1a+\xeb(\xdb\xdf?\xef\xeez" +
+	"}\xe3\xa1\x09\x00D9d\xad\xe6Dn\x97Z]'" +
+	"Q\x8bp\xf5\x85\x1eW\xf7\x86\xd2\xb1\x94j\xbbGB" +
+	"\xf1-\x86\xc8\\\xa0]L \xef\x95P\xac`h\x17" +
+	"4+o\x8d\xe4t\x00\xc0z`XO%Y*\x0e" +
+	"\xd0&\xa0^\xd9\x1b'\xde\xd4M\xb3\xc2l\x11\xec\xcf" +
+	"\xcfvw\xb8\xad\xea\x18\x8f8\x95\x1d\x9f\x0c\x8f\xe8w" +
+	"n3\x92\x8ch3ZY\xcdA*\xa5.\x09\xc5\xf2" +
+	"\xc0A\x91\xf1\x1c,\x84{c\xc2\x8d{\xb8'\xca\x80" +
+	"\x09\xcb*\x04\x8f\xa8\x0a01T!a|N:\xe6" +
+	"\x13\xf6\xff\x1e\x93\xfcW\xfe\x89\xcc\xb6Pc\x1e\xfb\x02" +
+	"](\\yG\x8cF_\xac\xde|\x16\xeb\x18\xf4\x1f" +
+	"\x82\xa1\x133A\x8f\xab\x9c\xd8\xd6\xe9\x9dHe\xa4\x1b" +
+	"F\xc9XPr@\xe3Q\xce\xd1N\xfb\x7f\x0a\x1d\x83" +
+	"\x9b}\x10T}\x8b\x1e7\x9e\xfe\xff1UMwy" +
+	"!h\xd5r\x89\x9ca\xba\x8e\xa9\x18\x8d\xa5\x03+\x16" +
+	"\x99:\xe5|y\xf4\xec\xca\x94L_\xbev\xe2\x919" +
+	"HZ\x08\xbfT\x0c\xf3$\x14=tP\xcc=\xbd\xfb" +
+	"\x8c\x10\xa8Y\xaf{\xfa\xd29\x01\xa8\x8f,\x9b\xf0?" +
+	"\x05\x1dysA\xc9\xd0+\x13\xe4\xff\x06\x00\xf3\xc1\x81" +
+	"x"
 
 func init() {
 	schemas.Register(schema_8f4bd412642c9517,
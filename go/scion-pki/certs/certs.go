@@ -54,6 +54,8 @@ func Cmd(pather command.Pather) *cobra.Command {
 		newValidateCmd(joined),
 		newVerifyCmd(joined),
 		newRenewCmd(joined),
+		newCreateCmd(joined),
+		newInspectCmd(joined),
 	)
 	return cmd
 }
@@ -0,0 +1,404 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/scion-pki/conf"
+	"github.com/scionproto/scion/go/scion-pki/key"
+)
+
+var createProfiles = []cppki.CertType{cppki.Root, cppki.CA, cppki.AS}
+
+// createFlags holds the flags of the create command.
+type createFlags struct {
+	profile     string
+	csr         bool
+	ca          string
+	caKey       string
+	existingKey bool
+	curve       string
+	notBefore   uint32
+	validity    string
+	bundle      bool
+}
+
+func newCreateCmd(pather command.Pather) *cobra.Command {
+	var flags createFlags
+
+	cmd := &cobra.Command{
+		Use:   "create [flags] <subject-template> <cert-file> <key-file>",
+		Short: "Create a certificate or certificate signing request",
+		Example: fmt.Sprintf(`  %[1]s create --profile cp-root --validity 5y \
+	subject.json cp-root.crt cp-root.key
+  %[1]s create --profile cp-ca --validity 1y --ca cp-root.crt --ca-key cp-root.key \
+	subject.json cp-ca.crt cp-ca.key
+  %[1]s create --profile cp-as --validity 3d --ca cp-ca.crt --ca-key cp-ca.key \
+	--bundle subject.json chain.pem cp-as.key
+  %[1]s create --csr subject.json cp-as.csr cp-as.key`, pather.CommandPath()),
+		Long: `'create' creates a certificate or a certificate signing request (CSR).
+
+The subject of the certificate is defined by the subject template. It has the
+same format as the template of the 'renew' command. The isd_as field is
+required.
+
+The profile determines the type of certificate that is created:
+
+  cp-root: A self-signed control plane root certificate.
+  cp-ca:   A control plane CA certificate, signed by the root certificate
+           that is specified with the --ca and --ca-key flags.
+  cp-as:   A control plane AS certificate, signed by the CA certificate
+           that is specified with the --ca and --ca-key flags.
+
+The validity period starts at the not-before time, specified in seconds since
+the Unix epoch, or at the current time if it is not set. Its duration is set
+with the validity flag, e.g., 3d or 1y. The validity period must be covered
+by the validity period of the signing certificate.
+
+With the csr flag set, a certificate signing request is created instead of a
+certificate. The profile, CA and validity flags are ignored in that case.
+
+Unless the existing-key flag is set, a new private key is generated on the
+specified curve and written to the key file. An existing key file is never
+overwritten. With the existing-key flag set, the key file is used as the
+private key of the subject.
+
+With the bundle flag set, the AS certificate is bundled together with the CA
+certificate, and the resulting certificate chain is written to the certificate
+file. The bundle flag is only allowed for the cp-as profile.
+`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.validate(); err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			return runCreate(flags, args[0], args[1], args[2], time.Now())
+		},
+	}
+
+	profiles := make([]string, 0, len(createProfiles))
+	for _, p := range createProfiles {
+		profiles = append(profiles, p.String())
+	}
+	cmd.Flags().StringVar(&flags.profile, "profile", "",
+		fmt.Sprintf("Profile of the certificate (%s)", strings.Join(profiles, "|")))
+	cmd.Flags().BoolVar(&flags.csr, "csr", false,
+		"Create a certificate signing request instead of a certificate")
+	cmd.Flags().StringVar(&flags.ca, "ca", "",
+		"Certificate of the issuer (required for cp-ca and cp-as)")
+	cmd.Flags().StringVar(&flags.caKey, "ca-key", "",
+		"Private key of the issuer (required for cp-ca and cp-as)")
+	cmd.Flags().BoolVar(&flags.existingKey, "existing-key", false,
+		"Use the existing private key in the key file")
+	cmd.Flags().StringVar(&flags.curve, "curve", "P-256",
+		fmt.Sprintf("Elliptic curve of the generated key (%s)",
+			strings.Join(key.Curves(), "|")))
+	cmd.Flags().Uint32Var(&flags.notBefore, "not-before", 0,
+		"Start of the validity period in seconds since the Unix epoch (default now)")
+	cmd.Flags().StringVar(&flags.validity, "validity", "",
+		"Duration of the validity period, e.g., 3d or 1y")
+	cmd.Flags().BoolVar(&flags.bundle, "bundle", false,
+		"Bundle the AS certificate with the CA certificate into a chain")
+	return cmd
+}
+
+func (f createFlags) validate() error {
+	if f.csr {
+		if f.bundle {
+			return serrors.New("bundle flag not allowed with csr flag")
+		}
+		return nil
+	}
+	ct, err := f.certType()
+	if err != nil {
+		return err
+	}
+	if ct == cppki.Root && (f.ca != "" || f.caKey != "") {
+		return serrors.New("ca and ca-key flags not allowed for self-signed certificate",
+			"profile", f.profile)
+	}
+	if ct != cppki.Root && (f.ca == "" || f.caKey == "") {
+		return serrors.New("ca and ca-key flags required", "profile", f.profile)
+	}
+	if f.bundle && ct != cppki.AS {
+		return serrors.New("bundle flag only allowed for AS certificate",
+			"profile", f.profile)
+	}
+	return nil
+}
+
+func (f createFlags) certType() (cppki.CertType, error) {
+	for _, ct := range createProfiles {
+		if ct.String() == f.profile {
+			return ct, nil
+		}
+	}
+	return cppki.Invalid, serrors.New("invalid profile flag", "profile", f.profile)
+}
+
+func (f createFlags) validityPeriod(now time.Time) (cppki.Validity, error) {
+	var dur time.Duration
+	if f.validity != "" {
+		var err error
+		if dur, err = util.ParseDuration(f.validity); err != nil {
+			return cppki.Validity{}, serrors.WrapStr("parsing validity", err)
+		}
+	}
+	v := conf.Validity{
+		NotBefore: f.notBefore,
+		Validity:  util.DurWrap{Duration: dur},
+	}
+	if err := v.Validate(); err != nil {
+		return cppki.Validity{}, err
+	}
+	return v.Eval(now), nil
+}
+
+func runCreate(flags createFlags, tmplFile, certFile, keyFile string, now time.Time) error {
+	vars, err := readVars(tmplFile)
+	if err != nil {
+		return serrors.WrapStr("reading template", err)
+	}
+	subject, err := subjectFromVars(vars)
+	if err != nil {
+		return err
+	}
+	priv, encodedKey, err := loadOrGenerateKey(flags, keyFile)
+	if err != nil {
+		return err
+	}
+
+	if flags.csr {
+		csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			Subject:            subject,
+			SignatureAlgorithm: x509.ECDSAWithSHA512,
+		}, priv)
+		if err != nil {
+			return serrors.WrapStr("creating certificate signing request", err)
+		}
+		encoded := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})
+		if err := writeKey(encodedKey, keyFile); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(certFile, encoded, 0644); err != nil {
+			return serrors.WrapStr("writing certificate signing request", err,
+				"file", certFile)
+		}
+		fmt.Printf("Successfully created certificate signing request at %s\n", certFile)
+		return nil
+	}
+
+	ct, err := flags.certType()
+	if err != nil {
+		return err
+	}
+	validity, err := flags.validityPeriod(now)
+	if err != nil {
+		return err
+	}
+	var issuer *x509.Certificate
+	issuerKey := priv
+	if ct != cppki.Root {
+		if issuer, issuerKey, err = loadIssuer(ct, flags.ca, flags.caKey); err != nil {
+			return err
+		}
+	}
+	cert, err := createCertificate(ct, subject, priv.Public(), validity, issuer, issuerKey)
+	if err != nil {
+		return err
+	}
+	chain := []*x509.Certificate{cert}
+	if flags.bundle {
+		chain = append(chain, issuer)
+		if err := cppki.ValidateChain(chain); err != nil {
+			return serrors.WrapStr("validating certificate chain", err)
+		}
+	}
+	if err := writeKey(encodedKey, keyFile); err != nil {
+		return err
+	}
+	if err := writeChain(chain, certFile); err != nil {
+		return serrors.WrapStr("writing certificate", err, "file", certFile)
+	}
+	fmt.Printf("Successfully created %s certificate at %s\n", ct, certFile)
+	return nil
+}
+
+// loadOrGenerateKey returns the private key of the subject. If a new key is
+// generated, it is returned in encoded form as well, such that it can be
+// written to the key file.
+func loadOrGenerateKey(flags createFlags, keyFile string) (crypto.Signer, []byte, error) {
+	if flags.existingKey {
+		priv, err := key.LoadPrivateKey(keyFile)
+		return priv, nil, err
+	}
+	if _, err := os.Stat(keyFile); err == nil {
+		return nil, nil, serrors.New("key file already exists, use --existing-key to reuse it",
+			"file", keyFile)
+	}
+	priv, err := key.GeneratePrivateKey(flags.curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	encoded, err := key.EncodePEMPrivateKey(priv)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("encoding private key", err)
+	}
+	return priv, encoded, nil
+}
+
+func writeKey(encoded []byte, file string) error {
+	if encoded == nil {
+		return nil
+	}
+	if err := ioutil.WriteFile(file, encoded, 0600); err != nil {
+		return serrors.WrapStr("writing private key", err, "file", file)
+	}
+	return nil
+}
+
+func loadIssuer(ct cppki.CertType, certFile,
+	keyFile string) (*x509.Certificate, crypto.Signer, error) {
+
+	certs, err := cppki.ReadPEMCerts(certFile)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("reading issuer certificate", err)
+	}
+	issuerType, err := cppki.ValidateCert(certs[0])
+	if err != nil {
+		return nil, nil, serrors.WrapStr("validating issuer certificate", err)
+	}
+	expected := map[cppki.CertType]cppki.CertType{cppki.CA: cppki.Root, cppki.AS: cppki.CA}
+	if issuerType != expected[ct] {
+		return nil, nil, serrors.New("wrong issuer certificate type",
+			"expected", expected[ct], "actual", issuerType)
+	}
+	issuerKey, err := key.LoadPrivateKey(keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certs[0], issuerKey, nil
+}
+
+// createCertificate creates a certificate of the given type. For root
+// certificates, the issuer is ignored, and the issuer key is expected to be
+// the private key of the subject.
+func createCertificate(ct cppki.CertType, subject pkix.Name, pub crypto.PublicKey,
+	validity cppki.Validity, issuer *x509.Certificate, issuerKey crypto.Signer,
+) (*x509.Certificate, error) {
+
+	if issuer != nil {
+		issuerValidity := cppki.Validity{NotBefore: issuer.NotBefore, NotAfter: issuer.NotAfter}
+		if !issuerValidity.Covers(validity) {
+			return nil, serrors.New("validity not covered by issuer certificate",
+				"issuer", issuerValidity, "validity", validity)
+		}
+	}
+	// Choose random serial number.
+	serial := make([]byte, 20)
+	if _, err := rand.Read(serial); err != nil {
+		return nil, serrors.WrapStr("creating random serial number", err)
+	}
+	skid, err := cppki.SubjectKeyID(pub)
+	if err != nil {
+		return nil, serrors.WrapStr("computing subject key ID", err)
+	}
+	tmpl := &x509.Certificate{
+		SignatureAlgorithm: x509.ECDSAWithSHA512,
+		Version:            3,
+		SerialNumber:       big.NewInt(0).SetBytes(serial),
+		Subject:            subject,
+		NotBefore:          validity.NotBefore,
+		NotAfter:           validity.NotAfter,
+		SubjectKeyId:       skid,
+	}
+	switch ct {
+	case cppki.Root:
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping}
+		tmpl.UnknownExtKeyUsage = []asn1.ObjectIdentifier{cppki.OIDExtKeyUsageRoot}
+		tmpl.BasicConstraintsValid = true
+		tmpl.IsCA = true
+		tmpl.MaxPathLen = 1
+		issuer = tmpl
+	case cppki.CA:
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		tmpl.BasicConstraintsValid = true
+		tmpl.IsCA = true
+		tmpl.MaxPathLen = 0
+		tmpl.MaxPathLenZero = true
+		tmpl.AuthorityKeyId = issuer.SubjectKeyId
+	case cppki.AS:
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+			x509.ExtKeyUsageTimeStamping,
+		}
+		tmpl.AuthorityKeyId = issuer.SubjectKeyId
+	default:
+		return nil, serrors.New("unsupported certificate type", "type", ct)
+	}
+	return signCertificate(ct, tmpl, issuer, pub, issuerKey)
+}
+
+func signCertificate(ct cppki.CertType, tmpl, issuer *x509.Certificate, pub crypto.PublicKey,
+	issuerKey crypto.Signer) (*x509.Certificate, error) {
+
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, pub, issuerKey)
+	if err != nil {
+		return nil, serrors.WrapStr("creating certificate", err)
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing created certificate", err)
+	}
+	// Ensure the issuer key matches the issuer certificate.
+	parent := issuer
+	if ct == cppki.Root {
+		parent = cert
+	}
+	if err := cert.CheckSignatureFrom(parent); err != nil {
+		return nil, serrors.WrapStr("issuer key does not match issuer certificate", err)
+	}
+	actual, err := cppki.ValidateCert(cert)
+	if err != nil {
+		return nil, serrors.WrapStr("created invalid certificate", err)
+	}
+	if actual != ct {
+		return nil, serrors.New("created certificate of wrong type",
+			"expected", ct, "actual", actual)
+	}
+	return cert, nil
+}
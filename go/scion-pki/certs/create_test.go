@@ -0,0 +1,158 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/scion-pki/key"
+)
+
+func TestCreate(t *testing.T) {
+	dir, cleanF := xtest.MustTempDir("", "scion-pki-certs-create")
+	defer cleanF()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	tmpl := "testdata/renew/ISD1-ASff00_0_111.csr.json"
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	// Create the full hierarchy: root -> CA -> AS chain.
+	err := runCreate(createFlags{profile: "cp-root", curve: "P-384", validity: "5y"},
+		tmpl, file("cp-root.crt"), file("cp-root.key"), now)
+	require.NoError(t, err)
+	err = runCreate(createFlags{profile: "cp-ca", curve: "P-256", validity: "1y",
+		ca: file("cp-root.crt"), caKey: file("cp-root.key")},
+		tmpl, file("cp-ca.crt"), file("cp-ca.key"), now)
+	require.NoError(t, err)
+	err = runCreate(createFlags{profile: "cp-as", curve: "P-256", validity: "3d",
+		ca: file("cp-ca.crt"), caKey: file("cp-ca.key"), bundle: true},
+		tmpl, file("chain.pem"), file("cp-as.key"), now)
+	require.NoError(t, err)
+
+	root := loadCerts(t, file("cp-root.crt"))
+	require.Len(t, root, 1)
+	ct, err := cppki.ValidateCert(root[0])
+	require.NoError(t, err)
+	assert.Equal(t, cppki.Root, ct)
+	assert.Equal(t, now, root[0].NotBefore)
+	assert.Equal(t, now.Add(5*365*24*time.Hour), root[0].NotAfter)
+
+	chain := loadCerts(t, file("chain.pem"))
+	require.Len(t, chain, 2)
+	assert.NoError(t, cppki.ValidateChain(chain))
+	assert.NoError(t, chain[1].CheckSignatureFrom(root[0]))
+	asKey, err := key.LoadPrivateKey(file("cp-as.key"))
+	require.NoError(t, err)
+	assert.Equal(t, asKey.Public(), chain[0].PublicKey)
+
+	t.Run("csr", func(t *testing.T) {
+		err := runCreate(createFlags{csr: true, existingKey: true},
+			tmpl, file("cp-as.csr"), file("cp-as.key"), now)
+		require.NoError(t, err)
+		raw, err := ioutil.ReadFile(file("cp-as.csr"))
+		require.NoError(t, err)
+		block, _ := pem.Decode(raw)
+		require.NotNil(t, block)
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		require.NoError(t, err)
+		assert.NoError(t, csr.CheckSignature())
+		assert.Equal(t, asKey.Public(), csr.PublicKey)
+		assert.Equal(t, chain[0].Subject.String(), csr.Subject.String())
+	})
+	t.Run("existing key file", func(t *testing.T) {
+		err := runCreate(createFlags{profile: "cp-root", curve: "P-256", validity: "1y"},
+			tmpl, file("other-root.crt"), file("cp-root.key"), now)
+		assert.Error(t, err)
+	})
+	t.Run("validity not covered", func(t *testing.T) {
+		err := runCreate(createFlags{profile: "cp-as", curve: "P-256", validity: "2y",
+			ca: file("cp-ca.crt"), caKey: file("cp-ca.key")},
+			tmpl, file("long.crt"), file("long.key"), now)
+		assert.Error(t, err)
+	})
+	t.Run("wrong issuer type", func(t *testing.T) {
+		err := runCreate(createFlags{profile: "cp-as", curve: "P-256", validity: "3d",
+			ca: file("cp-root.crt"), caKey: file("cp-root.key")},
+			tmpl, file("wrong.crt"), file("wrong.key"), now)
+		assert.Error(t, err)
+	})
+	t.Run("mismatching issuer key", func(t *testing.T) {
+		err := runCreate(createFlags{profile: "cp-as", curve: "P-256", validity: "3d",
+			ca: file("cp-ca.crt"), caKey: file("cp-as.key")},
+			tmpl, file("mismatch.crt"), file("mismatch.key"), now)
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateFlagsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Flags        createFlags
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"root": {
+			Flags:        createFlags{profile: "cp-root"},
+			ErrAssertion: assert.NoError,
+		},
+		"root with ca": {
+			Flags:        createFlags{profile: "cp-root", ca: "ca.crt", caKey: "ca.key"},
+			ErrAssertion: assert.Error,
+		},
+		"ca without ca key": {
+			Flags:        createFlags{profile: "cp-ca", ca: "ca.crt"},
+			ErrAssertion: assert.Error,
+		},
+		"as bundle": {
+			Flags: createFlags{profile: "cp-as", ca: "ca.crt", caKey: "ca.key",
+				bundle: true},
+			ErrAssertion: assert.NoError,
+		},
+		"ca bundle": {
+			Flags: createFlags{profile: "cp-ca", ca: "ca.crt", caKey: "ca.key",
+				bundle: true},
+			ErrAssertion: assert.Error,
+		},
+		"invalid profile": {
+			Flags:        createFlags{profile: "regular-voting"},
+			ErrAssertion: assert.Error,
+		},
+		"csr": {
+			Flags:        createFlags{csr: true},
+			ErrAssertion: assert.NoError,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.ErrAssertion(t, tc.Flags.validate())
+		})
+	}
+}
+
+func loadCerts(t *testing.T, file string) []*x509.Certificate {
+	certs, err := cppki.ReadPEMCerts(file)
+	require.NoError(t, err)
+	return certs
+}
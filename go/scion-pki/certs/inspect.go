@@ -0,0 +1,349 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/command"
+)
+
+func newInspectCmd(pather command.Pather) *cobra.Command {
+	var flags struct {
+		format   string
+		trcFile  string
+		unixTime int64
+	}
+
+	cmd := &cobra.Command{
+		Use:   "inspect [flags] <cert-file>",
+		Short: "Represent certificates in a human readable form",
+		Long: `'inspect' outputs the certificates in a PEM file in a human readable form.
+
+For every certificate, the control plane certificate type, the ISD-AS of the
+subject and issuer, the validity period, the key usage, and the subject and
+authority key identifiers are displayed. Certificates that are not valid
+control plane certificates are displayed with the validation error.
+
+If a TRC is provided, the certificates are checked against it. The check
+displays the root certificate in the TRC that the certificates chain to, the
+time when the chain expires, and whether the chain is verifiable with the TRC
+at the current time.
+
+The output can either be in yaml, or json.
+`,
+		Example: fmt.Sprintf(`  %[1]s inspect ISD1-ASff00_0_110.pem
+  %[1]s inspect --trc ISD1-B1-S1.trc --format json ISD1-ASff00_0_110.pem`,
+			pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			encoder, err := newEncoder(os.Stdout, flags.format)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			certs, err := cppki.ReadPEMCerts(args[0])
+			if err != nil {
+				return serrors.WrapStr("reading certificates", err, "file", args[0])
+			}
+			var trc *cppki.TRC
+			if flags.trcFile != "" {
+				signed, err := loadTRC(flags.trcFile)
+				if err != nil {
+					return err
+				}
+				trc = &signed.TRC
+			}
+			now := time.Now()
+			if flags.unixTime != 0 {
+				now = time.Unix(flags.unixTime, 0)
+			}
+			return encoder.Encode(inspect(certs, trc, now))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.format, "format", "yaml", "Output format (yaml|json)")
+	cmd.Flags().StringVar(&flags.trcFile, "trc", "", "TRC to check the certificates against")
+	cmd.Flags().Int64Var(&flags.unixTime, "currenttime", 0,
+		"Optional unix timestamp that sets the current time")
+	return cmd
+}
+
+func newEncoder(w io.Writer, format string) (interface{ Encode(v interface{}) error }, error) {
+	switch format {
+	case "yaml", "yml":
+		return yaml.NewEncoder(w), nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		return enc, nil
+	default:
+		return nil, serrors.New("format not supported", "format", format)
+	}
+}
+
+type inspection struct {
+	Certificates []certInfo `yaml:"certificates" json:"certificates"`
+	TRCCheck     *trcCheck  `yaml:"trc_check,omitempty" json:"trc_check,omitempty"`
+}
+
+type certInfo struct {
+	Type           string   `yaml:"type,omitempty" json:"type,omitempty"`
+	CommonName     string   `yaml:"common_name,omitempty" json:"common_name,omitempty"`
+	IA             addr.IA  `yaml:"isd_as,omitempty" json:"isd_as,omitempty"`
+	SerialNumber   string   `yaml:"serial_number,omitempty" json:"serial_number,omitempty"`
+	Issuer         issuer   `yaml:"issuer" json:"issuer"`
+	Validity       validity `yaml:"validity" json:"validity"`
+	PublicKey      string   `yaml:"public_key,omitempty" json:"public_key,omitempty"`
+	KeyUsage       []string `yaml:"key_usage,omitempty" json:"key_usage,omitempty"`
+	ExtKeyUsage    []string `yaml:"ext_key_usage,omitempty" json:"ext_key_usage,omitempty"`
+	SubjectKeyID   string   `yaml:"subject_key_id,omitempty" json:"subject_key_id,omitempty"`
+	AuthorityKeyID string   `yaml:"authority_key_id,omitempty" json:"authority_key_id,omitempty"`
+	Error          string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+type issuer struct {
+	CommonName string  `yaml:"common_name,omitempty" json:"common_name,omitempty"`
+	IA         addr.IA `yaml:"isd_as,omitempty" json:"isd_as,omitempty"`
+}
+
+type validity struct {
+	NotBefore time.Time `yaml:"not_before" json:"not_before"`
+	NotAfter  time.Time `yaml:"not_after" json:"not_after"`
+}
+
+type trcCheck struct {
+	TRC        string    `yaml:"trc" json:"trc"`
+	Root       *certRef  `yaml:"root,omitempty" json:"root,omitempty"`
+	CA         *certRef  `yaml:"ca,omitempty" json:"ca,omitempty"`
+	Expiration time.Time `yaml:"expiration,omitempty" json:"expiration,omitempty"`
+	Verified   bool      `yaml:"verified" json:"verified"`
+	Error      string    `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+type certRef struct {
+	CommonName   string  `yaml:"common_name,omitempty" json:"common_name,omitempty"`
+	IA           addr.IA `yaml:"isd_as,omitempty" json:"isd_as,omitempty"`
+	SerialNumber string  `yaml:"serial_number,omitempty" json:"serial_number,omitempty"`
+}
+
+// inspect describes the certificates. If the TRC is not nil, the certificates
+// are checked against it at the provided time.
+func inspect(certs []*x509.Certificate, trc *cppki.TRC, now time.Time) inspection {
+	var result inspection
+	types := make([]cppki.CertType, 0, len(certs))
+	for _, cert := range certs {
+		info := newCertInfo(cert)
+		ct, err := cppki.ValidateCert(cert)
+		if err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Type = ct.String()
+		}
+		types = append(types, ct)
+		result.Certificates = append(result.Certificates, info)
+	}
+	if trc != nil {
+		check := checkTRC(certs, types, trc, now)
+		result.TRCCheck = &check
+	}
+	return result
+}
+
+func newCertInfo(cert *x509.Certificate) certInfo {
+	return certInfo{
+		CommonName:   cert.Subject.CommonName,
+		IA:           extractIA(cert.Subject),
+		SerialNumber: fmt.Sprintf("% X", cert.SerialNumber.Bytes()),
+		Issuer: issuer{
+			CommonName: cert.Issuer.CommonName,
+			IA:         extractIA(cert.Issuer),
+		},
+		Validity: validity{
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		},
+		PublicKey:      publicKeyDesc(cert),
+		KeyUsage:       keyUsages(cert.KeyUsage),
+		ExtKeyUsage:    extKeyUsages(cert),
+		SubjectKeyID:   fmt.Sprintf("% X", cert.SubjectKeyId),
+		AuthorityKeyID: fmt.Sprintf("% X", cert.AuthorityKeyId),
+	}
+}
+
+// checkTRC checks the certificates against the TRC. The certificates must
+// either be a single root or CA certificate, or a chain consisting of an AS
+// and a CA certificate.
+func checkTRC(certs []*x509.Certificate, types []cppki.CertType, trc *cppki.TRC,
+	now time.Time) trcCheck {
+
+	check := trcCheck{TRC: trc.ID.String()}
+	roots, err := trc.RootCerts()
+	if err != nil {
+		check.Error = serrors.WrapStr("extracting root certificates", err).Error()
+		return check
+	}
+	top := certs[len(certs)-1]
+	expiration := top.NotAfter
+	for _, cert := range certs {
+		if cert.NotAfter.Before(expiration) {
+			expiration = cert.NotAfter
+		}
+	}
+	var root *x509.Certificate
+	for _, r := range roots {
+		if r.Equal(top) || top.CheckSignatureFrom(r) == nil {
+			root = r
+			break
+		}
+	}
+	if root == nil {
+		check.Error = "no issuing root certificate in TRC"
+		return check
+	}
+	if root.NotAfter.Before(expiration) {
+		expiration = root.NotAfter
+	}
+	check.Root = newCertRef(root)
+	check.Expiration = expiration
+	if types[len(types)-1] == cppki.CA {
+		check.CA = newCertRef(top)
+	}
+
+	switch {
+	case len(certs) == 2 && types[0] == cppki.AS && types[1] == cppki.CA:
+		err = cppki.VerifyChain(certs, cppki.VerifyOptions{TRC: trc, CurrentTime: now})
+	case len(certs) == 1 && (types[0] == cppki.CA || types[0] == cppki.Root):
+		err = verifyCert(top, trc, now)
+	default:
+		err = serrors.New("unsupported certificate sequence",
+			"expected", "single cp-root or cp-ca certificate, or cp-as/cp-ca chain")
+	}
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.Verified = true
+	return check
+}
+
+func verifyCert(cert *x509.Certificate, trc *cppki.TRC, now time.Time) error {
+	pool, err := trc.RootPool()
+	if err != nil {
+		return err
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:       pool,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		CurrentTime: now,
+	})
+	return err
+}
+
+func newCertRef(cert *x509.Certificate) *certRef {
+	return &certRef{
+		CommonName:   cert.Subject.CommonName,
+		IA:           extractIA(cert.Subject),
+		SerialNumber: fmt.Sprintf("% X", cert.SerialNumber.Bytes()),
+	}
+}
+
+func publicKeyDesc(cert *x509.Certificate) string {
+	if pub, ok := cert.PublicKey.(*ecdsa.PublicKey); ok {
+		return fmt.Sprintf("%s %s", cert.PublicKeyAlgorithm, pub.Curve.Params().Name)
+	}
+	return cert.PublicKeyAlgorithm.String()
+}
+
+func keyUsages(usage x509.KeyUsage) []string {
+	names := []struct {
+		Usage x509.KeyUsage
+		Name  string
+	}{
+		{Usage: x509.KeyUsageDigitalSignature, Name: "digital_signature"},
+		{Usage: x509.KeyUsageContentCommitment, Name: "content_commitment"},
+		{Usage: x509.KeyUsageKeyEncipherment, Name: "key_encipherment"},
+		{Usage: x509.KeyUsageDataEncipherment, Name: "data_encipherment"},
+		{Usage: x509.KeyUsageKeyAgreement, Name: "key_agreement"},
+		{Usage: x509.KeyUsageCertSign, Name: "cert_sign"},
+		{Usage: x509.KeyUsageCRLSign, Name: "crl_sign"},
+		{Usage: x509.KeyUsageEncipherOnly, Name: "encipher_only"},
+		{Usage: x509.KeyUsageDecipherOnly, Name: "decipher_only"},
+	}
+	var usages []string
+	for _, n := range names {
+		if usage&n.Usage != 0 {
+			usages = append(usages, n.Name)
+		}
+	}
+	return usages
+}
+
+func extKeyUsages(cert *x509.Certificate) []string {
+	names := map[x509.ExtKeyUsage]string{
+		x509.ExtKeyUsageAny:          "any",
+		x509.ExtKeyUsageServerAuth:   "server_auth",
+		x509.ExtKeyUsageClientAuth:   "client_auth",
+		x509.ExtKeyUsageTimeStamping: "time_stamping",
+	}
+	var usages []string
+	for _, u := range cert.ExtKeyUsage {
+		if name, ok := names[u]; ok {
+			usages = append(usages, name)
+		} else {
+			usages = append(usages, fmt.Sprintf("unknown(%d)", u))
+		}
+	}
+	unknown := []struct {
+		OID  asn1.ObjectIdentifier
+		Name string
+	}{
+		{OID: cppki.OIDExtKeyUsageSensitive, Name: "sensitive_voting"},
+		{OID: cppki.OIDExtKeyUsageRegular, Name: "regular_voting"},
+		{OID: cppki.OIDExtKeyUsageRoot, Name: "root"},
+	}
+	for _, oid := range cert.UnknownExtKeyUsage {
+		name := oid.String()
+		for _, u := range unknown {
+			if u.OID.Equal(oid) {
+				name = u.Name
+			}
+		}
+		usages = append(usages, name)
+	}
+	return usages
+}
+
+func extractIA(name pkix.Name) addr.IA {
+	if ia, _ := cppki.ExtractIA(name); ia != nil {
+		return *ia
+	}
+	return addr.IA{}
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestInspect(t *testing.T) {
+	chain, err := cppki.ReadPEMCerts("testdata/renew/ISD1-ASff00_0_111.pem")
+	require.NoError(t, err)
+	trc, err := loadTRC("testdata/renew/ISD1-B1-S1.trc")
+	require.NoError(t, err)
+	root := trc.TRC.Certificates[0]
+	valid := chain[0].NotBefore.Add(time.Hour)
+	rootName := "1-ff00:0:110 High Security Root Certificate"
+
+	t.Run("chain without TRC", func(t *testing.T) {
+		result := inspect(chain, nil, valid)
+		require.Len(t, result.Certificates, 2)
+		as, ca := result.Certificates[0], result.Certificates[1]
+		assert.Equal(t, cppki.AS.String(), as.Type)
+		assert.Equal(t, xtest.MustParseIA("1-ff00:0:111"), as.IA)
+		assert.Equal(t, ca.IA, as.Issuer.IA)
+		assert.Equal(t, "ECDSA P-256", as.PublicKey)
+		assert.Equal(t, []string{"digital_signature"}, as.KeyUsage)
+		assert.Contains(t, as.ExtKeyUsage, "time_stamping")
+		assert.Equal(t, ca.SubjectKeyID, as.AuthorityKeyID)
+		assert.Empty(t, as.Error)
+		assert.Equal(t, cppki.CA.String(), ca.Type)
+		assert.Equal(t, []string{"cert_sign", "crl_sign"}, ca.KeyUsage)
+		assert.Nil(t, result.TRCCheck)
+	})
+	t.Run("chain with TRC", func(t *testing.T) {
+		check := inspect(chain, &trc.TRC, valid).TRCCheck
+		require.NotNil(t, check)
+		assert.True(t, check.Verified)
+		assert.Empty(t, check.Error)
+		assert.Equal(t, "ISD1-B1-S1", check.TRC)
+		require.NotNil(t, check.Root)
+		assert.Equal(t, rootName, check.Root.CommonName)
+		require.NotNil(t, check.CA)
+		assert.Equal(t, chain[1].Subject.CommonName, check.CA.CommonName)
+		assert.Equal(t, chain[0].NotAfter, check.Expiration)
+	})
+	t.Run("expired chain with TRC", func(t *testing.T) {
+		check := inspect(chain, &trc.TRC, chain[0].NotAfter.Add(time.Hour)).TRCCheck
+		require.NotNil(t, check)
+		assert.False(t, check.Verified)
+		assert.NotEmpty(t, check.Error)
+		require.NotNil(t, check.Root)
+		assert.Equal(t, rootName, check.Root.CommonName)
+	})
+	t.Run("CA certificate with TRC", func(t *testing.T) {
+		check := inspect(chain[1:], &trc.TRC, valid).TRCCheck
+		require.NotNil(t, check)
+		assert.True(t, check.Verified, check.Error)
+		require.NotNil(t, check.Root)
+		assert.Equal(t, rootName, check.Root.CommonName)
+		assert.Equal(t, chain[1].NotAfter, check.Expiration)
+	})
+	t.Run("root certificate with TRC", func(t *testing.T) {
+		result := inspect([]*x509.Certificate{root}, &trc.TRC, valid)
+		assert.Equal(t, []string{"time_stamping", "root"},
+			result.Certificates[0].ExtKeyUsage)
+		check := result.TRCCheck
+		require.NotNil(t, check)
+		assert.True(t, check.Verified, check.Error)
+		require.NotNil(t, check.Root)
+		assert.Equal(t, rootName, check.Root.CommonName)
+		assert.Nil(t, check.CA)
+	})
+	t.Run("AS certificate with TRC", func(t *testing.T) {
+		check := inspect(chain[:1], &trc.TRC, valid).TRCCheck
+		require.NotNil(t, check)
+		assert.False(t, check.Verified)
+		assert.NotEmpty(t, check.Error)
+	})
+	t.Run("voting certificate", func(t *testing.T) {
+		result := inspect(trc.TRC.Certificates[1:2], nil, valid)
+		assert.Equal(t, cppki.Regular.String(), result.Certificates[0].Type)
+		assert.Contains(t, result.Certificates[0].ExtKeyUsage, "regular_voting")
+	})
+}
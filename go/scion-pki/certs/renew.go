@@ -345,8 +345,20 @@ func csrTemplate(chain []*x509.Certificate, tmpl string) (*x509.CertificateReque
 	if err != nil {
 		return nil, serrors.WrapStr("reading template", err)
 	}
+	s, err := subjectFromVars(vars)
+	if err != nil {
+		return nil, err
+	}
+	return &x509.CertificateRequest{
+		Subject:            s,
+		SignatureAlgorithm: x509.ECDSAWithSHA512,
+	}, nil
+}
+
+// subjectFromVars creates the distinguished name from the template variables.
+func subjectFromVars(vars subjectVars) (pkix.Name, error) {
 	if vars.ISDAS.IsZero() {
-		return nil, serrors.New("isd_as required in template")
+		return pkix.Name{}, serrors.New("isd_as required in template")
 	}
 	s := pkix.Name{
 		CommonName:   vars.CommonName,
@@ -371,10 +383,7 @@ func csrTemplate(chain []*x509.Certificate, tmpl string) (*x509.CertificateReque
 			*field = []string{value}
 		}
 	}
-	return &x509.CertificateRequest{
-		Subject:            s,
-		SignatureAlgorithm: x509.ECDSAWithSHA512,
-	}, nil
+	return s, nil
 }
 
 func buildMsgr(ctx context.Context, ds reliable.Dispatcher, sds sciond.Service,
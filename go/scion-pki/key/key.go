@@ -0,0 +1,116 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package key provides commands to manage the private and public keys used in
+// the SCION control plane PKI.
+package key
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/command"
+)
+
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// Curves returns the names of the supported elliptic curves.
+func Curves() []string {
+	names := make([]string, 0, len(curves))
+	for name := range curves {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Cmd(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key",
+		Short: "Manage private and public keys for the SCION control plane PKI.",
+	}
+	joined := command.Join(pather, cmd)
+	cmd.AddCommand(
+		newPrivateCmd(joined),
+		newPublicCmd(joined),
+	)
+	return cmd
+}
+
+// GeneratePrivateKey generates a new ECDSA private key on the named curve.
+func GeneratePrivateKey(curve string) (crypto.Signer, error) {
+	c, ok := curves[strings.ToUpper(curve)]
+	if !ok {
+		return nil, serrors.New("unsupported curve", "curve", curve,
+			"supported", strings.Join(Curves(), "|"))
+	}
+	return ecdsa.GenerateKey(c, rand.Reader)
+}
+
+// EncodePEMPrivateKey encodes the private key in PKCS #8 PEM format.
+func EncodePEMPrivateKey(key crypto.PrivateKey) ([]byte, error) {
+	raw, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw}), nil
+}
+
+// EncodePEMPublicKey encodes the public key in PKIX PEM format.
+func EncodePEMPublicKey(key crypto.PublicKey) ([]byte, error) {
+	raw, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: raw}), nil
+}
+
+// LoadPrivateKey loads a PKCS #8 PEM encoded private key from the file. Only
+// ECDSA keys are supported.
+func LoadPrivateKey(file string) (crypto.Signer, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("reading private key", err, "file", file)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, serrors.New("no PEM block found", "file", file)
+	}
+	if block.Type != "PRIVATE KEY" {
+		return nil, serrors.New("wrong PEM block type", "file", file, "type", block.Type)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing private key", err, "file", file)
+	}
+	v, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, serrors.New("only ecdsa keys are supported", "file", file)
+	}
+	return v, nil
+}
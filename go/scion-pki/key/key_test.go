@@ -0,0 +1,89 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/scion-pki/key"
+)
+
+func TestGeneratePrivateKey(t *testing.T) {
+	testCases := map[string]struct {
+		Curve        string
+		BitSize      int
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"P-256":       {Curve: "P-256", BitSize: 256, ErrAssertion: assert.NoError},
+		"P-384":       {Curve: "P-384", BitSize: 384, ErrAssertion: assert.NoError},
+		"P-521":       {Curve: "P-521", BitSize: 521, ErrAssertion: assert.NoError},
+		"lower case":  {Curve: "p-256", BitSize: 256, ErrAssertion: assert.NoError},
+		"unsupported": {Curve: "P-224", ErrAssertion: assert.Error},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			priv, err := key.GeneratePrivateKey(tc.Curve)
+			tc.ErrAssertion(t, err)
+			if err != nil {
+				return
+			}
+			require.IsType(t, &ecdsa.PrivateKey{}, priv)
+			assert.Equal(t, tc.BitSize, priv.(*ecdsa.PrivateKey).Curve.Params().BitSize)
+		})
+	}
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	dir, cleanF := xtest.MustTempDir("", "scion-pki-key")
+	defer cleanF()
+
+	priv, err := key.GeneratePrivateKey("P-256")
+	require.NoError(t, err)
+	raw, err := key.EncodePEMPrivateKey(priv)
+	require.NoError(t, err)
+	file := filepath.Join(dir, "cp-as.key")
+	require.NoError(t, ioutil.WriteFile(file, raw, 0600))
+
+	loaded, err := key.LoadPrivateKey(file)
+	require.NoError(t, err)
+	assert.Equal(t, priv, loaded)
+
+	pub, err := key.EncodePEMPublicKey(loaded.Public())
+	require.NoError(t, err)
+	block, _ := pem.Decode(pub)
+	require.NotNil(t, block)
+	assert.Equal(t, "PUBLIC KEY", block.Type)
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, priv.Public(), parsed)
+
+	pubFile := filepath.Join(dir, "cp-as.pub")
+	require.NoError(t, ioutil.WriteFile(pubFile, pub, 0644))
+	_, err = key.LoadPrivateKey(pubFile)
+	assert.Error(t, err)
+	_, err = key.LoadPrivateKey(filepath.Join(dir, "missing.key"))
+	assert.Error(t, err)
+}
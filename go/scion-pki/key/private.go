@@ -0,0 +1,73 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/command"
+)
+
+func newPrivateCmd(pather command.Pather) *cobra.Command {
+	var flags struct {
+		curve string
+		force bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "private [flags] <private-key-file>",
+		Short: "Generate a private key",
+		Example: fmt.Sprintf(`  %[1]s private cp-as.key
+  %[1]s private --curve P-384 cp-root.key`, pather.CommandPath()),
+		Long: `'private' generates a new ECDSA private key on the specified curve.
+
+The key is written to the file in PKCS #8 PEM format. The file is only readable
+by the owner. An existing file is only overwritten if the force flag is set.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if _, err := os.Stat(args[0]); err == nil && !flags.force {
+				return serrors.New("file already exists, use --force to overwrite",
+					"file", args[0])
+			}
+			key, err := GeneratePrivateKey(flags.curve)
+			if err != nil {
+				return err
+			}
+			raw, err := EncodePEMPrivateKey(key)
+			if err != nil {
+				return serrors.WrapStr("encoding private key", err)
+			}
+			if err := ioutil.WriteFile(args[0], raw, 0600); err != nil {
+				return serrors.WrapStr("writing private key", err, "file", args[0])
+			}
+			fmt.Printf("Successfully created private key at %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.curve, "curve", "P-256",
+		fmt.Sprintf("Elliptic curve to use (%s)", strings.Join(Curves(), "|")))
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Overwrite an existing file")
+	return cmd
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/command"
+)
+
+func newPublicCmd(pather command.Pather) *cobra.Command {
+	var flags struct {
+		out string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "public [flags] <private-key-file>",
+		Short: "Derive the public key from a private key",
+		Example: fmt.Sprintf(`  %[1]s public cp-as.key
+  %[1]s public --out cp-as.pub cp-as.key`, pather.CommandPath()),
+		Long: `'public' derives the public key from the private key.
+
+The public key is written in PKIX PEM format. In case the out flag is not
+specified, the public key is written to stdout.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			key, err := LoadPrivateKey(args[0])
+			if err != nil {
+				return err
+			}
+			raw, err := EncodePEMPublicKey(key.Public())
+			if err != nil {
+				return serrors.WrapStr("encoding public key", err)
+			}
+			if flags.out == "" {
+				_, err := os.Stdout.Write(raw)
+				return err
+			}
+			if err := ioutil.WriteFile(flags.out, raw, 0644); err != nil {
+				return serrors.WrapStr("writing public key", err, "file", flags.out)
+			}
+			fmt.Printf("Successfully wrote public key at %s\n", flags.out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.out, "out", "o", "", "Output file")
+	return cmd
+}
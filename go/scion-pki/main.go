@@ -22,6 +22,7 @@ import (
 
 	"github.com/scionproto/scion/go/pkg/command"
 	"github.com/scionproto/scion/go/scion-pki/certs"
+	"github.com/scionproto/scion/go/scion-pki/key"
 	"github.com/scionproto/scion/go/scion-pki/testcrypto"
 	"github.com/scionproto/scion/go/scion-pki/trcs"
 )
@@ -45,6 +46,7 @@ func main() {
 		command.NewCompletion(cmd),
 		newVersion(),
 		certs.Cmd(cmd),
+		key.Cmd(cmd),
 		trcs.Cmd(cmd),
 		testcrypto.Cmd(cmd),
 	)
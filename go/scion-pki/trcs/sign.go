@@ -0,0 +1,222 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/scrypto/cms/protocol"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/command"
+	"github.com/scionproto/scion/go/scion-pki/key"
+)
+
+func newSign(pather command.Pather) *cobra.Command {
+	var flags struct {
+		out  string
+		pred string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sign <payload> <sign-cert> <sign-key>",
+		Short: "Sign a TRC payload",
+		Long: `'sign' signs the TRC payload with the provided certificate and private key.
+
+The result is a partially signed TRC, i.e., a CMS signed message containing the
+payload and a single signature. Partially signed TRCs of all signers can be
+combined into a single TRC with the 'combine' command.
+
+The certificate must be of type sensitive-voting, regular-voting, or cp-root,
+and it must be required to sign the TRC:
+
+  - For a base TRC, all voting certificates in the payload sign the TRC.
+  - For a TRC update, the predecessor TRC must be provided with the
+    '--predecessor' flag. The voting certificates of the predecessor that cast
+    a vote, the voting certificates that are new in the payload, and the root
+    certificates of the predecessor that are changed in a regular update sign
+    the TRC.
+
+The signing certificate is not included in the signed message, as it is part
+of the payload or the predecessor TRC.
+`,
+		Example: fmt.Sprintf(`  %[1]s sign ISD1-B1-S1.pld.der `+
+			`regular-voting.crt regular-voting.key -o ISD1-B1-S1.regular.trc
+  %[1]s sign ISD1-B1-S2.pld.der cp-root.crt cp-root.key -p ISD1-B1-S1.trc `+
+			`-o ISD1-B1-S2.root.trc`, pather.CommandPath()),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return RunSign(args[0], args[1], args[2], flags.pred, flags.out)
+		},
+	}
+
+	addOutputFlag(&flags.out, cmd)
+	cmd.Flags().StringVarP(&flags.pred, "predecessor", "p", "",
+		"Predecessor TRC (required for TRC update)")
+	return cmd
+}
+
+// RunSign signs the TRC payload in the pld file with the certificate and
+// private key, and writes the partially signed TRC to the out file. The
+// predecessor TRC file is required for TRC updates.
+func RunSign(pld, certFile, keyFile, predFile, out string) error {
+	rawPld, err := ioutil.ReadFile(pld)
+	if err != nil {
+		return serrors.WrapStr("error loading payload", err)
+	}
+	trc, err := cppki.DecodeTRC(rawPld)
+	if err != nil {
+		return serrors.WrapStr("error parsing payload", err)
+	}
+	certs, err := cppki.ReadPEMCerts(certFile)
+	if err != nil {
+		return serrors.WrapStr("error loading certificate", err)
+	}
+	if len(certs) != 1 {
+		return serrors.New("file with multiple certificates not supported", "file", certFile)
+	}
+	signer, err := key.LoadPrivateKey(keyFile)
+	if err != nil {
+		return err
+	}
+	var pred *cppki.TRC
+	if predFile != "" {
+		dec, err := DecodeFromFile(predFile)
+		if err != nil {
+			return serrors.WrapStr("error loading predecessor TRC", err, "file", predFile)
+		}
+		pred = &dec.TRC
+	}
+	roles, err := signatureRoles(&trc, pred, certs[0])
+	if err != nil {
+		return err
+	}
+	signed, err := SignPayload(rawPld, signer, certs[0])
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(out, signed, 0644); err != nil {
+		return serrors.WrapStr("error writing signed TRC", err)
+	}
+	fmt.Printf("Successfully signed TRC payload (%s) at %s\n", strings.Join(roles, ", "), out)
+	return nil
+}
+
+// SignPayload signs the TRC payload and returns the encoded CMS signed message.
+// The signing certificate is not included in the message.
+func SignPayload(pld []byte, signer crypto.Signer, cert *x509.Certificate) ([]byte, error) {
+	pub, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, serrors.WrapStr("error encoding public key", err)
+	}
+	certPub, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return nil, serrors.WrapStr("error encoding certificate public key", err)
+	}
+	if !bytes.Equal(pub, certPub) {
+		return nil, serrors.New("private key does not match certificate")
+	}
+	eci, err := protocol.NewDataEncapsulatedContentInfo(pld)
+	if err != nil {
+		return nil, serrors.WrapStr("error encoding payload", err)
+	}
+	sd, err := protocol.NewSignedData(eci)
+	if err != nil {
+		return nil, serrors.WrapStr("error creating signed data", err)
+	}
+	if err := sd.AddSignerInfo([]*x509.Certificate{cert}, signer); err != nil {
+		return nil, serrors.WrapStr("error signing payload", err)
+	}
+	sd.Certificates = nil
+	packed, err := sd.ContentInfoDER()
+	if err != nil {
+		return nil, serrors.WrapStr("error packing signed TRC", err)
+	}
+	return packed, nil
+}
+
+// signatureRoles determines the roles in which the certificate is required to
+// sign the TRC. It is an error if the certificate is not required to sign.
+func signatureRoles(trc, pred *cppki.TRC, cert *x509.Certificate) ([]string, error) {
+	ct, err := cppki.ValidateCert(cert)
+	if err != nil {
+		return nil, serrors.WrapStr("error validating certificate", err)
+	}
+	switch ct {
+	case cppki.Sensitive, cppki.Regular, cppki.Root:
+	default:
+		return nil, serrors.New("certificate type cannot sign TRC", "type", ct)
+	}
+
+	if trc.ID.IsBase() {
+		if pred != nil {
+			return nil, serrors.New("predecessor specified for base TRC")
+		}
+		if err := trc.Validate(); err != nil {
+			return nil, serrors.WrapStr("error validating payload", err)
+		}
+		if ct == cppki.Root {
+			return nil, serrors.New("root certificate cannot sign base TRC")
+		}
+		if !containsCert(trc.Certificates, cert) {
+			return nil, serrors.New("certificate not part of payload", "type", ct,
+				"common_name", cert.Subject.CommonName)
+		}
+		return []string{"proof of possession"}, nil
+	}
+
+	if pred == nil {
+		return nil, serrors.New("predecessor required for TRC update")
+	}
+	update, err := trc.ValidateUpdate(pred)
+	if err != nil {
+		return nil, serrors.WrapStr("error validating update", err)
+	}
+	var roles []string
+	for _, v := range []struct {
+		Role  string
+		Certs []*x509.Certificate
+	}{
+		{Role: "vote", Certs: update.Votes},
+		{Role: "proof of possession", Certs: update.NewVoters},
+		{Role: "acknowledgement", Certs: update.RootAcknowledgments},
+	} {
+		if containsCert(v.Certs, cert) {
+			roles = append(roles, v.Role)
+		}
+	}
+	if len(roles) == 0 {
+		return nil, serrors.New("certificate not required to sign TRC update", "type", ct,
+			"common_name", cert.Subject.CommonName, "update_type", update.Type)
+	}
+	return roles, nil
+}
+
+func containsCert(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/scion-pki/trcs"
+)
+
+func TestSign(t *testing.T) {
+	dir, clean := xtest.MustTempDir("", "scion-pki-trcs-sign")
+	defer clean()
+
+	file := func(name string) string { return filepath.Join("./testdata/sign", name) }
+	pred, err := trcs.DecodeFromFile(file("ISD1-B1-S1.trc"))
+	require.NoError(t, err)
+
+	type signer struct {
+		Cert string
+		Key  string
+	}
+	testCases := map[string]struct {
+		Payload      string
+		Predecessor  string
+		Signers      []signer
+		ErrAssertion assert.ErrorAssertionFunc
+		Verify       bool
+	}{
+		"base": {
+			Payload: "ISD1-B1-S1.pld.der",
+			Signers: []signer{
+				{Cert: "sensitive-voting.crt", Key: "sensitive-voting.key"},
+				{Cert: "regular-voting.S1.crt", Key: "regular-voting.key"},
+			},
+			ErrAssertion: assert.NoError,
+			Verify:       true,
+		},
+		"base root": {
+			Payload:      "ISD1-B1-S1.pld.der",
+			Signers:      []signer{{Cert: "cp-root.S1.crt", Key: "cp-root.key"}},
+			ErrAssertion: assert.Error,
+		},
+		"base with predecessor": {
+			Payload:      "ISD1-B1-S1.pld.der",
+			Predecessor:  "ISD1-B1-S1.trc",
+			Signers:      []signer{{Cert: "sensitive-voting.crt", Key: "sensitive-voting.key"}},
+			ErrAssertion: assert.Error,
+		},
+		"base not in payload": {
+			Payload:      "ISD1-B1-S1.pld.der",
+			Signers:      []signer{{Cert: "regular-voting.S2.crt", Key: "regular-voting.key"}},
+			ErrAssertion: assert.Error,
+		},
+		"base key mismatch": {
+			Payload:      "ISD1-B1-S1.pld.der",
+			Signers:      []signer{{Cert: "sensitive-voting.crt", Key: "regular-voting.key"}},
+			ErrAssertion: assert.Error,
+		},
+		"regular update": {
+			Payload:     "ISD1-B1-S2.pld.der",
+			Predecessor: "ISD1-B1-S1.trc",
+			// The updated regular voting certificate has the same key and serial
+			// number as its predecessor. Thus, the vote also serves as proof of
+			// possession.
+			Signers: []signer{
+				{Cert: "regular-voting.S1.crt", Key: "regular-voting.key"},
+				{Cert: "cp-root.S1.crt", Key: "cp-root.key"},
+			},
+			ErrAssertion: assert.NoError,
+			Verify:       true,
+		},
+		"regular update proof of possession": {
+			Payload:      "ISD1-B1-S2.pld.der",
+			Predecessor:  "ISD1-B1-S1.trc",
+			Signers:      []signer{{Cert: "regular-voting.S2.crt", Key: "regular-voting.key"}},
+			ErrAssertion: assert.NoError,
+		},
+		"update without predecessor": {
+			Payload:      "ISD1-B1-S2.pld.der",
+			Signers:      []signer{{Cert: "regular-voting.S1.crt", Key: "regular-voting.key"}},
+			ErrAssertion: assert.Error,
+		},
+		"update not required": {
+			Payload:      "ISD1-B1-S2.pld.der",
+			Predecessor:  "ISD1-B1-S1.trc",
+			Signers:      []signer{{Cert: "cp-root.S2.crt", Key: "cp-root.key"}},
+			ErrAssertion: assert.Error,
+		},
+		"update unchanged sensitive": {
+			Payload:      "ISD1-B1-S2.pld.der",
+			Predecessor:  "ISD1-B1-S1.trc",
+			Signers:      []signer{{Cert: "sensitive-voting.crt", Key: "sensitive-voting.key"}},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			var predFile string
+			if tc.Predecessor != "" {
+				predFile = file(tc.Predecessor)
+			}
+			var parts []string
+			for i, s := range tc.Signers {
+				out := filepath.Join(dir, fmt.Sprintf("%s.%d.trc", name, i))
+				err := trcs.RunSign(file(tc.Payload), file(s.Cert), file(s.Key),
+					predFile, out)
+				tc.ErrAssertion(t, err)
+				if err != nil {
+					return
+				}
+				signed, err := trcs.DecodeFromFile(out)
+				require.NoError(t, err)
+				assert.Len(t, signed.SignerInfos, 1)
+				parts = append(parts, out)
+			}
+			if !tc.Verify {
+				return
+			}
+			combined := filepath.Join(dir, name+".trc")
+			err := trcs.RunCombine(parts, file(tc.Payload), combined)
+			require.NoError(t, err)
+			signed, err := trcs.DecodeFromFile(combined)
+			require.NoError(t, err)
+			if signed.TRC.ID.IsBase() {
+				assert.NoError(t, signed.Verify(nil))
+			} else {
+				assert.NoError(t, signed.Verify(&pred.TRC))
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ func Cmd(pather command.Pather) *cobra.Command {
 		newHuman(joined),
 		newExtract(joined),
 		newPayload(joined),
+		newSign(joined),
 		newVerify(joined),
 	)
 	return cmd
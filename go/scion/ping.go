@@ -47,6 +47,7 @@ func newPing(pather CommandPather) *cobra.Command {
 		timeout     time.Duration
 		maxMTU      bool
 
+		policy   policyFlags
 		features []string
 	}
 
@@ -77,8 +78,15 @@ func newPing(pather CommandPather) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			policy, err := flags.policy.policy()
+			if err != nil {
+				return err
+			}
 			path, err := app.ChoosePath(context.Background(), sd, remote.IA,
-				flags.interactive, flags.refresh)
+				app.WithInteractive(flags.interactive),
+				app.WithRefresh(flags.refresh),
+				app.WithPolicy(policy),
+			)
 			if err != nil {
 				return err
 			}
@@ -128,9 +136,11 @@ func newPing(pather CommandPather) *cobra.Command {
 				Timeout:     flags.timeout,
 				Local:       local,
 				Remote:      remote,
-				PayloadSize: int(flags.size),
+				PayloadSize: pldSize,
+				Path:        path,
+				MaxMTU:      flags.maxMTU,
 				ErrHandler: func(err error) {
-					fmt.Fprintf(os.Stderr, "ERROR: %s", err)
+					fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
 				},
 				UpdateHandler: func(update ping.Update) {
 					var additional string
@@ -149,6 +159,9 @@ func newPing(pather CommandPather) *cobra.Command {
 				HeaderV2: features.HeaderV2,
 			})
 			pingSummary(stats, remote, time.Since(start))
+			if flags.maxMTU {
+				fmt.Printf("path MTU estimate %dB\n", stats.PathMTU)
+			}
 			if err != nil {
 				return err
 			}
@@ -173,7 +186,10 @@ the SCION path.`,
 	cmd.Flags().BoolVar(&flags.maxMTU, "max_mtu", false,
 		`choose the payload size such that the sent SCION packet including the SCION Header,
 SCMP echo header and payload are equal to the MTU of the path. This flag overrides the
-'payload_size' flag.`)
+'payload_size' flag. If an SCMP packet too big error is received, the MTU estimate of the
+path is lowered and the payload size is adapted accordingly. The final estimate is
+displayed in the summary.`)
+	flags.policy.register(cmd)
 	cmd.Flags().StringSliceVar(&flags.features, "features", nil,
 		"enable development features "+features{}.supported())
 
@@ -0,0 +1,46 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/pkg/app"
+)
+
+// policyFlags are the flags that configure the path policy the candidate
+// paths are filtered with.
+type policyFlags struct {
+	file     string
+	sequence string
+	acl      []string
+}
+
+func (f *policyFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.file, "policy", "",
+		"path policy file (json or yaml) the paths are filtered with")
+	cmd.Flags().StringVar(&f.sequence, "sequence", "",
+		`space separated list of hop predicates the paths must match, e.g.
+'1-ff00:0:110#0 1-ff00:0:111#2,1 0*'. Overrides the sequence of the policy file.`)
+	cmd.Flags().StringSliceVar(&f.acl, "acl", nil,
+		`comma separated list of ACL entries the paths must match, e.g.
+'- 1-ff00:0:111,+'. The last entry must match all hops. Overrides the ACL of the
+policy file.`)
+}
+
+func (f *policyFlags) policy() (*pathpol.Policy, error) {
+	return app.Policy(f.file, f.sequence, f.acl)
+}
@@ -34,7 +34,9 @@ func newShowpaths(pather CommandPather) *cobra.Command {
 		timeout    time.Duration
 		cfg        showpaths.Config
 		expiration bool
+		extended   bool
 		json       bool
+		policy     policyFlags
 	}
 
 	var cmd = &cobra.Command{
@@ -43,14 +45,24 @@ func newShowpaths(pather CommandPather) *cobra.Command {
 		Aliases: []string{"sp"},
 		Args:    cobra.ExactArgs(1),
 		Example: fmt.Sprintf(`  %[1]s showpaths 1-ff00:0:110 --expiration
+  %[1]s showpaths 1-ff00:0:110 --extended
   %[1]s showpaths 1-ff00:0:110 --local 127.0.0.55 --json
-  %[1]s showpaths 1-ff00:0:110 --no-probe`, pather.CommandPath()),
+  %[1]s showpaths 1-ff00:0:110 --no-probe
+  %[1]s showpaths 1-ff00:0:110 --sequence '0* 1-ff00:0:111 0*'
+  %[1]s showpaths 1-ff00:0:110 --policy policy.yml`, pather.CommandPath()),
 		Long: `'showpaths' lists available paths between the local and the specified SCION ASe a.
 
 By default, the paths are probed. Paths served from the SCION Deamon's might not
 forward traffic successfully (e.g. if a network link went down, or there is a black
 hole on the path). To disable path probing, set the appropriate flag.
 
+The displayed paths can be restricted with a path policy. The policy is either
+loaded from a file, or specified with the --sequence and --acl flags.
+
+If the ASes on a path announce static information about the path, the latency
+and bandwidth of the path are displayed. The remaining information, i.e., the link
+types, router locations and notes, is displayed with the --extended flag.
+
 'showpaths' can be instructed to output the paths as json using the the --json flag.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -65,6 +77,10 @@ hole on the path). To disable path probing, set the appropriate flag.
 			// See https://github.com/spf13/cobra/issues/340
 			cmd.SilenceUsage = true
 
+			if flags.cfg.Policy, err = flags.policy.policy(); err != nil {
+				return err
+			}
+
 			// FIXME(roosd): This practically turns of logging done in libraries. We
 			// should not have to do this.
 			log.Setup(log.Config{Console: log.ConsoleConfig{Level: "crit"}})
@@ -78,7 +94,7 @@ hole on the path). To disable path probing, set the appropriate flag.
 			if flags.json {
 				return res.JSON(os.Stdout)
 			}
-			res.Human(os.Stdout, flags.expiration)
+			res.Human(os.Stdout, flags.expiration, flags.extended)
 			return nil
 		},
 	}
@@ -90,6 +106,8 @@ hole on the path). To disable path probing, set the appropriate flag.
 		"Maximum number of paths that are displayed")
 	cmd.Flags().BoolVarP(&flags.expiration, "expiration", "e", false,
 		"Show path expiration information")
+	cmd.Flags().BoolVar(&flags.extended, "extended", false,
+		"Show all static information announced for the paths")
 	cmd.Flags().BoolVarP(&flags.cfg.Refresh, "refresh", "r", false,
 		"Set refresh flag for SCION Deamon path request")
 	cmd.Flags().BoolVar(&flags.cfg.NoProbe, "no-probe", false,
@@ -98,6 +116,7 @@ hole on the path). To disable path probing, set the appropriate flag.
 		"Write the output as machine readable json")
 	cmd.Flags().IPVarP(&flags.cfg.Local, "local", "l", nil,
 		"Optional local IP address to use for probing health checks")
+	flags.policy.register(cmd)
 
 	return cmd
 }
@@ -0,0 +1,238 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/addrutil"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/app"
+	"github.com/scionproto/scion/go/pkg/traceroute"
+)
+
+func newTraceroute(pather CommandPather) *cobra.Command {
+	var flags struct {
+		probes      int
+		interactive bool
+		local       net.IP
+		refresh     bool
+		sciond      string
+		dispatcher  string
+		timeout     time.Duration
+		json        bool
+
+		policy   policyFlags
+		features []string
+	}
+
+	var cmd = &cobra.Command{
+		Use:     "traceroute [flags] <remote>",
+		Aliases: []string{"tr"},
+		Short:   "Trace the SCION route to a remote SCION host using SCMP traceroute packets",
+		Example: fmt.Sprintf(`  %[1]s traceroute 1-ff00:0:110,10.0.0.1
+  %[1]s traceroute 1-ff00:0:110,10.0.0.1 --probes 5 --interactive
+  %[1]s traceroute 1-ff00:0:110,10.0.0.1 --json`, pather.CommandPath()),
+		Long: `'traceroute' probes every interface on the path to the remote host.
+
+For every interface, the configured number of probes is sent, and the round trip
+time of each probe is displayed. A probe that is not answered within the timeout
+is displayed as '*'.
+
+'traceroute' can be instructed to output the result as json using the --json flag.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			remote, err := snet.ParseUDPAddr(args[0])
+			if err != nil {
+				return serrors.WrapStr("parsing remote", err)
+			}
+			features, err := parseFeatures(flags.features)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			// Informational output is discarded, such that the json output
+			// can be parsed.
+			var out io.Writer = os.Stdout
+			if flags.json {
+				out = ioutil.Discard
+			}
+
+			ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+			defer cancelF()
+			sd, err := sciond.NewService(flags.sciond).Connect(ctx)
+			if err != nil {
+				return serrors.WrapStr("connecting to SCION Daemon", err)
+			}
+
+			info, err := app.QueryASInfo(context.Background(), sd)
+			if err != nil {
+				return err
+			}
+			policy, err := flags.policy.policy()
+			if err != nil {
+				return err
+			}
+			path, err := app.ChoosePath(context.Background(), sd, remote.IA,
+				app.WithInteractive(flags.interactive),
+				app.WithRefresh(flags.refresh),
+				app.WithPolicy(policy),
+			)
+			if err != nil {
+				return err
+			}
+			remote.Path = path.Path()
+			remote.NextHop = path.UnderlayNextHop()
+
+			localIP := flags.local
+			if localIP == nil {
+				target := remote.Host.IP
+				if remote.NextHop != nil {
+					target = remote.NextHop.IP
+				}
+				if localIP, err = addrutil.ResolveLocal(target); err != nil {
+					return serrors.WrapStr("resolving local address", err)
+				}
+				fmt.Fprintf(out, "Resolved local address:\n  %s\n", localIP)
+			}
+			fmt.Fprintf(out, "Using path:\n  %s\n\n", path)
+			local := &snet.UDPAddr{
+				IA:   info.IA,
+				Host: &net.UDPAddr{IP: localIP},
+			}
+
+			ctx = app.WithSignal(context.Background(), os.Interrupt, syscall.SIGTERM)
+			res, err := traceroute.Run(ctx, traceroute.Config{
+				Dispatcher:   reliable.NewDispatcher(flags.dispatcher),
+				Local:        local,
+				Remote:       remote,
+				PathEntry:    path,
+				ProbesPerHop: flags.probes,
+				Timeout:      flags.timeout,
+				ErrHandler: func(err error) {
+					fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+				},
+				UpdateHandler: func(u traceroute.Update) {
+					fmt.Fprintf(out, "%d %s\n", u.Index, fmtTraceUpdate(u, flags.timeout))
+				},
+				HeaderV2: features.HeaderV2,
+			})
+			if err != nil {
+				return err
+			}
+			if flags.json {
+				return traceJSON(os.Stdout, path, res, flags.timeout)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&flags.interactive, "interactive", "i", false, "interactive mode")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", time.Second, "timeout per packet")
+	cmd.Flags().IPVar(&flags.local, "local", nil, "IP address to listen on")
+	cmd.Flags().StringVar(&flags.sciond, "sciond", sciond.DefaultSCIONDAddress, "SCIOND address")
+	cmd.Flags().StringVar(&flags.dispatcher, "dispatcher", reliable.DefaultDispPath,
+		"dispatcher socket")
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	cmd.Flags().IntVarP(&flags.probes, "probes", "p", 3, "number of probes per hop")
+	cmd.Flags().BoolVarP(&flags.json, "json", "j", false,
+		"Write the output as machine readable json")
+	flags.policy.register(cmd)
+	cmd.Flags().StringSliceVar(&flags.features, "features", nil,
+		"enable development features "+features{}.supported())
+
+	return cmd
+}
+
+func fmtTraceUpdate(u traceroute.Update, timeout time.Duration) string {
+	var rtts []string
+	for _, rtt := range u.RTTs {
+		if rtt > timeout {
+			rtts = append(rtts, "*")
+			continue
+		}
+		rtts = append(rtts, rtt.String())
+	}
+	remote := "*"
+	if u.Remote != nil {
+		remote = u.Remote.String()
+	}
+	return fmt.Sprintf("%s,%s IfID=%d %s", u.IA, remote, u.Interface, strings.Join(rtts, " "))
+}
+
+// traceHop is the json representation of a traced hop.
+type traceHop struct {
+	IA        addr.IA         `json:"isd_as"`
+	Interface common.IFIDType `json:"interface"`
+	Remote    string          `json:"ip,omitempty"`
+	// RoundTripTimes contains the round trip time of each probe. Probes that
+	// timed out are null.
+	RoundTripTimes []*time.Duration `json:"round_trip_times"`
+}
+
+func traceJSON(w io.Writer, path snet.Path, res traceroute.Result,
+	timeout time.Duration) error {
+
+	out := struct {
+		Path     string     `json:"path"`
+		Hops     []traceHop `json:"hops"`
+		Sent     int        `json:"sent"`
+		Received int        `json:"received"`
+	}{
+		Path:     fmt.Sprintf("%s", path),
+		Hops:     make([]traceHop, 0, len(res.Hops)),
+		Sent:     res.Stats.Sent,
+		Received: res.Stats.Received,
+	}
+	for _, u := range res.Hops {
+		hop := traceHop{
+			IA:        u.IA,
+			Interface: u.Interface,
+		}
+		if u.Remote != nil {
+			hop.Remote = u.Remote.String()
+		}
+		for i := range u.RTTs {
+			var rtt *time.Duration
+			if u.RTTs[i] <= timeout {
+				rtt = &u.RTTs[i]
+			}
+			hop.RoundTripTimes = append(hop.RoundTripTimes, rtt)
+		}
+		out.Hops = append(out.Hops, hop)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(out)
+}
@@ -102,7 +102,7 @@ func run(file string) error {
 	}
 	defer closer.Close()
 
-	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.PathDB)
+	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.PathDB, cfg.RevCache)
 	if err != nil {
 		return serrors.WrapStr("initializing path storage", err)
 	}
@@ -126,6 +126,11 @@ func run(file string) error {
 		return serrors.WrapStr("creating trust engine", err)
 	}
 
+	hidden, err := sciond.HiddenConfig(cfg.SD, tcp.NewClientMessenger())
+	if err != nil {
+		return err
+	}
+
 	srv := sciond.Server(cfg.SD.Address, sciond.ServerCfg{
 		Fetcher: fetcher.NewFetcher(
 			tcp.NewClientMessenger(),
@@ -136,10 +141,12 @@ func run(file string) error {
 			cfg.SD,
 			itopo.Provider(),
 			cfg.Features.HeaderV2,
+			hidden,
 		),
 		Engine:   engine,
 		PathDB:   pathDB,
 		RevCache: revCache,
+		DRKeyRPC: tcp.NewClientMessenger(),
 	})
 	go func() {
 		defer log.HandlePanic()
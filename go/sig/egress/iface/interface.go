@@ -26,7 +26,6 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
-	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
 )
 
@@ -95,8 +94,8 @@ func (r *RemoteInfo) String() string {
 // PathPool is implemented by objects that maintain sets of paths. PathPools
 // must be safe for concurrent use by multiple goroutines.
 type PathPool interface {
-	// Paths returns the paths contained in the pool.
-	Paths() spathmeta.AppPathSet
+	// Paths returns the paths contained in the pool, most preferred path first.
+	Paths() []snet.Path
 	// Destroy cleans up any resources associated with the PathPool.
 	Destroy() error
 }
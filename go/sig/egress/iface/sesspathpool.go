@@ -19,7 +19,6 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/lib/snet"
-	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 )
 
 const pathFailExpiration = 5 * time.Minute
@@ -31,22 +30,20 @@ func NewSessPathPool() *SessPathPool {
 }
 
 // Get returns the most suitable path. Excludes a specific path, if possible.
+// Among the paths with the least failures, the one with the best rank is
+// returned.
 func (spp SessPathPool) Get(exclude snet.PathFingerprint) *SessPath {
 	var bestSessPath *SessPathStats
-	var minFail uint16 = math.MaxUint16
 	var bestNonExpiringSessPath *SessPathStats
-	var minNonExpiringFail uint16 = math.MaxUint16
 	for k, v := range spp {
 		if k == exclude {
 			continue
 		}
-		if v.failCount < minFail {
+		if v.betterThan(bestSessPath) {
 			bestSessPath = v
-			minFail = v.failCount
 		}
-		if v.failCount < minNonExpiringFail && !v.SessPath.IsCloseToExpiry() {
+		if v.betterThan(bestNonExpiringSessPath) && !v.SessPath.IsCloseToExpiry() {
 			bestNonExpiringSessPath = v
-			minNonExpiringFail = v.failCount
 		}
 	}
 	// Return a non-expiring path with least failures.
@@ -78,22 +75,33 @@ func (spp SessPathPool) PathCount() int {
 	return len(spp)
 }
 
-func (spp SessPathPool) Update(aps spathmeta.AppPathSet) {
+// Update replaces the paths in the pool. The paths are ranked in the order in
+// which they are passed, most preferred path first.
+func (spp SessPathPool) Update(paths []snet.Path) {
+	ranks := make(map[snet.PathFingerprint]int, len(paths))
+	for i, path := range paths {
+		if _, ok := ranks[path.Fingerprint()]; !ok {
+			ranks[path.Fingerprint()] = i
+		}
+	}
 	// Remove any old entries that aren't present in the update.
 	for key := range spp {
-		if _, ok := aps[key]; !ok {
+		if _, ok := ranks[key]; !ok {
 			delete(spp, key)
 		}
 	}
-	for key, path := range aps {
+	for key, rank := range ranks {
+		path := paths[rank]
 		e, ok := spp[key]
 		if !ok {
 			// This is a new path, add an entry.
-			spp[key] = newSessPathStats(key, path)
+			e = newSessPathStats(key, path)
+			spp[key] = e
 		} else {
 			// This path already exists, update it.
 			e.SessPath.path = path
 		}
+		e.rank = rank
 	}
 }
 
@@ -127,6 +135,21 @@ type SessPathStats struct {
 	SessPath  *SessPath
 	lastFail  time.Time
 	failCount uint16
+	// rank is the position of the path in the preference order of the pool,
+	// lower is better.
+	rank int
+}
+
+// betterThan returns whether the path has fewer failures than other, or the
+// same number of failures and a better rank. Every path is better than nil.
+func (sp *SessPathStats) betterThan(other *SessPathStats) bool {
+	if other == nil {
+		return true
+	}
+	if sp.failCount != other.failCount {
+		return sp.failCount < other.failCount
+	}
+	return sp.rank < other.rank
 }
 
 func newSessPathStats(key snet.PathFingerprint, path snet.Path) *SessPathStats {
@@ -29,10 +29,10 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathmgr"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/pktdisp"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
-	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/worker"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
@@ -184,6 +184,18 @@ func (pp *PathPool) Destroy() error {
 	return nil
 }
 
-func (pp *PathPool) Paths() spathmeta.AppPathSet {
-	return pp.pool.Load().APS
+// Paths returns the paths to the remote AS that match the path policy of the
+// SIG, ranked by the policy.
+func (pp *PathPool) Paths() []snet.Path {
+	aps := pp.pool.Load().APS
+	ps := make(pathpol.PathSet, len(aps))
+	for key, path := range aps {
+		ps[key] = path
+	}
+	ranked := sigcmn.PathPolicy.Rank(ps)
+	paths := make([]snet.Path, 0, len(ranked))
+	for _, path := range ranked {
+		paths = append(paths, path.(snet.Path))
+	}
+	return paths
 }
@@ -27,10 +27,12 @@ import (
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathmgr"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/sciond/fake"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/app"
 	sigconfig "github.com/scionproto/scion/go/pkg/sig/config"
 	"github.com/scionproto/scion/go/sig/internal/snetmigrate"
 )
@@ -48,6 +50,7 @@ var (
 var (
 	IA         addr.IA
 	PathMgr    pathmgr.Resolver
+	PathPolicy *pathpol.Policy
 	Dispatcher reliable.Dispatcher
 	Network    *snet.SCIONNetwork
 	CtrlAddr   net.IP
@@ -63,6 +66,13 @@ func Init(cfg sigconfig.SigConf, sdCfg env.SCIONDClient, features env.Features)
 	CtrlPort = int(cfg.CtrlPort)
 	DataAddr = cfg.IP
 	DataPort = int(cfg.EncapPort)
+	if cfg.PathPolicy != "" {
+		policy, err := app.LoadPolicy(cfg.PathPolicy)
+		if err != nil {
+			return common.NewBasicError("Error loading path policy", err)
+		}
+		PathPolicy = policy
+	}
 	network, resolver, err := initNetwork(cfg, sdCfg, features)
 	if err != nil {
 		return common.NewBasicError("Error creating local SCION Network context", err)
@@ -139,6 +139,11 @@ class GoGenerator(object):
                 'backend': 'sqlite',
                 'connection': os.path.join(self.db_dir, '%s.renewal.db' % name),
             }
+        if self.args.colibri:
+            raw_entry['colibri'] = {
+                'enabled': True,
+                'db': os.path.join(self.db_dir, '%s.colibri.db' % name),
+            }
         return raw_entry
 
     def generate_co(self):